@@ -3,19 +3,25 @@ package main
 import (
 	"buku-pintar/internal/delivery/http"
 	"buku-pintar/internal/delivery/http/middleware"
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/helper"
 	"buku-pintar/internal/repository/mysql"
 	"buku-pintar/internal/repository/redis"
 	"buku-pintar/internal/service"
 	"buku-pintar/internal/usecase"
 	"buku-pintar/pkg/config"
+	"buku-pintar/pkg/scheduler"
 	"buku-pintar/pkg/supabase"
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
 	client "net/http"
 	"os"
+	"time"
 
 	_ "github.com/go-sql-driver/mysql"
+	"github.com/google/uuid"
 )
 
 func main() {
@@ -60,19 +66,37 @@ func main() {
 		log.Fatal(err)
 	}
 
+	// Pagination cursors are signed so clients cannot forge keyset positions
+	cursorSecret := cfg.App.CursorSecret
+	if cursorSecret == "" {
+		log.Println("app.cursor_secret is not set, pagination cursors will not survive a restart")
+		cursorSecret = uuid.New().String()
+	}
+	cursorCodec := helper.NewCursorCodec(cursorSecret)
+
+	// Initialize media dependencies
+	mediaStorage, err := cfg.LoadMediaStorage()
+	if err != nil {
+		log.Fatal(err)
+	}
+	mediaRepo := mysql.NewMediaRepository(db)
+	mediaService := service.NewMediaService(mediaRepo, mediaStorage, time.Duration(cfg.Download.TTLSeconds)*time.Second)
+	mediaUsecase := usecase.NewMediaUsecase(mediaService)
+	mediaHandler := http.NewMediaHandler(mediaUsecase)
+
 	// Initialize banner dependencies
 	bannerRepo := mysql.NewBannerRepository(db)
 	bannerRedisRepo := redis.NewBannerRedisRepository(cRedis)
-	bannerService := service.NewBannerService(bannerRepo, bannerRedisRepo)
+	bannerService := service.NewBannerService(bannerRepo, bannerRedisRepo, mediaService)
 	bannerUsecase := usecase.NewBannerUsecase(bannerService)
 	bannerHandler := http.NewBannerHandler(bannerUsecase)
 
 	// Initialize category dependencies
 	categoryRepo := mysql.NewCategoryRepository(db)
 	categoryRedisRepo := redis.NewCategoryRedisRepository(cRedis)
-	categoryService := service.NewCategoryService(categoryRepo, categoryRedisRepo)
+	categoryService := service.NewCategoryService(categoryRepo, categoryRedisRepo, mediaService)
 	categoryUsecase := usecase.NewCategoryUsecase(categoryService)
-	categoryHandler := http.NewCategoryHandler(categoryUsecase)
+	categoryHandler := http.NewCategoryHandler(categoryUsecase, cursorCodec)
 
 	// Initialize user dependencies
 	userRepo := mysql.NewUserRepository(db)
@@ -83,8 +107,6 @@ func main() {
 	// Initialize payment dependencies
 	paymentRepo := mysql.NewPaymentRepository(db)
 	paymentService := service.NewPaymentService(paymentRepo, cfg.Payment.Xendit.Key)
-	paymentUsecase := usecase.NewPaymentUsecase(paymentService)
-	paymentHandler := http.NewPaymentHandler(paymentUsecase)
 
 	// Initialize Supabase auth middleware
 	supabaseAuth, err := supabase.NewAuthenticator(cfg.Supabase)
@@ -104,6 +126,9 @@ func main() {
 	permissionRedisRepo := redis.NewPermissionRedisRepository(cRedis)
 	permissionService := service.NewPermissionService(permissionRepo, permissionRedisRepo)
 
+	// Entitlements decide who may read, download and edit ebooks
+	entitlementService := service.NewEntitlementService(paymentRepo, roleService, permissionService)
+
 	// Initialize role middleware
 	roleMiddleware := middleware.NewRoleMiddleware(roleService, permissionService)
 
@@ -118,6 +143,14 @@ func main() {
 		permissionMiddlewareConfig,
 	)
 
+	// Initialize resumable upload dependencies
+	// Partial data stays on the local disk until the last chunk, then it is stored like any other media
+	uploadRepo := mysql.NewUploadRepository(db)
+	uploadService := service.NewUploadService(uploadRepo, cfg.Upload.Dir)
+	uploadUsecase := usecase.NewUploadUsecase(uploadService, permissionService, mediaUsecase,
+		time.Duration(cfg.Upload.ExpirySeconds)*time.Second)
+	uploadHandler := http.NewUploadHandler(uploadUsecase)
+
 	// Initialize ebook discount dependencies
 	ebookDiscountRepo := mysql.NewEbookDiscountRepository(db)
 	ebookDiscountRedisRepo := redis.NewEbookDiscountRedisRepository(cRedis)
@@ -126,33 +159,298 @@ func main() {
 	// Initialize ebook dependencies
 	ebookRepo := mysql.NewEbookRepository(db)
 	ebookRedisRepo := redis.NewEbookRedisRepository(cRedis)
-	ebookService := service.NewEbookService(ebookRepo, ebookRedisRepo)
-	ebookUsecase := usecase.NewEbookUsecase(ebookService, ebookDiscountService)
-	ebookHandler := http.NewEbookHandler(ebookUsecase)
+	ebookService := service.NewEbookService(ebookRepo, ebookRedisRepo, mediaService)
+
+	// Initialize trending dependencies
+	// Views, reads, wishlist adds and purchases are scored in Redis as they happen
+	trendingRepo := mysql.NewTrendingRepository(db)
+	trendingRedisRepo := redis.NewTrendingRedisRepository(cRedis)
+	trendingService := service.NewTrendingService(trendingRepo, trendingRedisRepo, mediaService, cfg.Trending.SnapshotRetentionDays)
+	trendingUsecase := usecase.NewTrendingUsecase(ebookService, trendingService)
+	trendingHandler := http.NewTrendingHandler(trendingUsecase)
+
+	paymentUsecase := usecase.NewPaymentUsecase(paymentService, ebookService, ebookDiscountService, trendingService)
+	paymentHandler := http.NewPaymentHandler(paymentUsecase, cursorCodec, cfg.Payment.Xendit.CallbackToken)
+
+	// Initialize notification dependencies
+	// Price drops of wishlisted ebooks are written as events for the users who wishlisted them
+	notificationRepo := mysql.NewNotificationRepository(db)
+	notificationService := service.NewNotificationService(notificationRepo, ebookDiscountRepo, ebookRepo)
+	notificationUsecase := usecase.NewNotificationUsecase(notificationService)
+	notificationHandler := http.NewNotificationHandler(notificationUsecase)
+
+	// Initialize series dependencies; the ebook detail shows the series an ebook belongs to
+	seriesRepo := mysql.NewSeriesRepository(db)
+	seriesService := service.NewSeriesService(seriesRepo, mediaService)
+
+	ebookUsecase := usecase.NewEbookUsecase(ebookService, ebookDiscountService, notificationService, seriesService, trendingService)
+	ebookHandler := http.NewEbookHandler(ebookUsecase, cursorCodec)
+
+	// Initialize scheduled publishing dependencies
+	scheduleRepo := mysql.NewContentScheduleRepository(db)
+	scheduleService := service.NewContentScheduleService(scheduleRepo, ebookRedisRepo)
+	scheduleUsecase := usecase.NewContentScheduleUsecase(scheduleService, permissionService)
+	scheduleHandler := http.NewContentScheduleHandler(scheduleUsecase)
+
+	// Initialize translation dependencies
+	locales := entity.LocaleSet{Default: cfg.I18n.DefaultLocale, Supported: cfg.I18n.SupportedLocales}
+	translationRepo := mysql.NewTranslationRepository(db)
+	translationService := service.NewTranslationService(translationRepo, ebookRedisRepo, categoryRedisRepo)
+	translationUsecase := usecase.NewTranslationUsecase(translationService, permissionService, locales)
+	translationHandler := http.NewTranslationHandler(translationUsecase)
+	localeMiddleware := middleware.NewLocaleMiddleware(locales)
+	clientIPMiddleware, err := middleware.NewClientIPMiddleware(cfg.App.TrustedProxies)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Initialize table of contents dependencies
+	tocRepo := mysql.NewTableOfContentRepository(db)
+	tocService := service.NewTableOfContentService(tocRepo)
+	tocUsecase := usecase.NewTableOfContentUsecase(ebookService, tocService, entitlementService)
+	tocHandler := http.NewTableOfContentHandler(tocUsecase)
+
+	// Initialize EPUB ingestion dependencies
+	// Uploaded EPUBs become drafts; only a missing author is saved. Importing pages fills the reader.
+	authorRepo := mysql.NewAuthorRepository(db)
+	authorService := service.NewAuthorService(authorRepo)
+	pageRepo := mysql.NewEbookPageRepository(db)
+	pageService := service.NewEbookPageService(pageRepo)
+	epubIngestUsecase := usecase.NewEpubIngestUsecase(authorService, ebookService, pageService)
+	epubIngestHandler := http.NewEpubIngestHandler(epubIngestUsecase)
+
+	// Initialize ebook contributor dependencies
+	contributorRepo := mysql.NewEbookContributorRepository(db)
+	contributorService := service.NewEbookContributorService(contributorRepo, ebookRedisRepo)
+	contributorUsecase := usecase.NewEbookContributorUsecase(ebookService, authorService, contributorService)
+	contributorHandler := http.NewEbookContributorHandler(contributorUsecase)
+
+	// Initialize bulk catalog import dependencies
+	catalogImportRepo := mysql.NewCatalogImportRepository(db)
+	catalogImportService := service.NewCatalogImportService(catalogImportRepo, ebookRedisRepo, categoryRedisRepo)
+	catalogImportUsecase := usecase.NewCatalogImportUsecase(catalogImportService)
+	catalogImportHandler := http.NewCatalogImportHandler(catalogImportUsecase)
 
 	// Initialize summary dependencies
 	summaryRepo := mysql.NewSummaryRepositoryImpl(db)
 	summaryRedisRepo := redis.NewSummaryRedisRepositoryImpl(cRedis)
-	summaryService := service.NewSummaryServiceImpl(summaryRepo, summaryRedisRepo)
-	summaryHandler := http.NewSummaryHandler(summaryService)
+	summaryService := service.NewSummaryServiceImpl(summaryRepo, summaryRedisRepo, mediaService)
+	summaryUsecase := usecase.NewSummaryUsecaseImpl(summaryService)
+	summaryHandler := http.NewSummaryHandler(summaryUsecase, cursorCodec)
+
+	// Initialize ebook download dependencies
+	// Download links are signed so they can be fetched without an Authorization header
+	downloadSecret := cfg.Download.Secret
+	if downloadSecret == "" {
+		log.Println("download.secret is not set, download links will not survive a restart")
+		downloadSecret = uuid.New().String()
+	}
+	urlSigner := helper.NewURLSigner(downloadSecret, time.Duration(cfg.Download.TTLSeconds)*time.Second)
+	downloadRepo := mysql.NewEbookDownloadRepository(db)
+	downloadService := service.NewEbookDownloadService(downloadRepo)
+	downloadUsecase := usecase.NewEbookDownloadUsecase(ebookService, entitlementService, downloadService, mediaService)
+	downloadHandler := http.NewEbookDownloadHandler(downloadUsecase, urlSigner, cfg.Download.StorageDir)
+
+	seriesUsecase := usecase.NewSeriesUsecase(seriesService, ebookService, entitlementService)
+	seriesHandler := http.NewSeriesHandler(seriesUsecase)
+
+	// Initialize summary audio dependencies
+	summaryAudioRepo := mysql.NewSummaryAudioRepository(db)
+	summaryAudioService := service.NewSummaryAudioService(summaryAudioRepo)
+	summaryAudioUsecase := usecase.NewSummaryAudioUsecase(summaryAudioService, ebookService, entitlementService, mediaService)
+	summaryAudioHandler := http.NewSummaryAudioHandler(summaryAudioUsecase, cfg.Download.StorageDir)
+
+	// Initialize OPDS catalog dependencies
+	opdsUsecase := usecase.NewOPDSUsecase(ebookService, categoryService, entitlementService)
+	opdsHandler := http.NewOPDSHandler(opdsUsecase, urlSigner, cfg.OPDS.Title, cfg.OPDS.BuyURL)
+
+	// Initialize sitemap dependencies
+	sitemapRepo := mysql.NewSitemapRepository(db)
+	sitemapRedisRepo := redis.NewSitemapRedisRepository(cRedis)
+	sitemapService := service.NewSitemapService(sitemapRepo, sitemapRedisRepo, cfg.Sitemap.SiteURL, cfg.Sitemap.URLsPerSitemap)
+	sitemapUsecase := usecase.NewSitemapUsecase(sitemapService)
+	sitemapHandler := http.NewSitemapHandler(sitemapUsecase)
+
+	// Initialize RSS and Atom feed dependencies
+	articleRepo := mysql.NewArticleRepository(db)
+	inspirationRepo := mysql.NewInspirationRepository(db)
+	feedService := service.NewFeedService(articleRepo, inspirationRepo)
+	feedUsecase := usecase.NewFeedUsecase(feedService, ebookService, categoryService, authorService, cfg.Feed.Items)
+	feedHandler := http.NewFeedHandler(feedUsecase, cfg.Feed.Title, cfg.Feed.SiteURL)
+
+	// Initialize ebook reader dependencies
+	readerUsecase := usecase.NewEbookReaderUsecase(ebookService, pageService, entitlementService, ebookDiscountService, trendingService)
+	readerHandler := http.NewEbookReaderHandler(readerUsecase)
+
+	// Initialize reading progress dependencies
+	progressRepo := mysql.NewReadingProgressRepository(db)
+	progressRedisRepo := redis.NewReadingProgressRedisRepository(cRedis)
+	progressService := service.NewReadingProgressService(progressRepo, progressRedisRepo, mediaService)
+	progressUsecase := usecase.NewReadingProgressUsecase(ebookService, progressService, entitlementService)
+	progressHandler := http.NewReadingProgressHandler(progressUsecase)
+
+	// Initialize annotation dependencies
+	annotationRepo := mysql.NewAnnotationRepository(db)
+	annotationService := service.NewAnnotationService(annotationRepo)
+	annotationUsecase := usecase.NewAnnotationUsecase(ebookService, annotationService)
+	annotationHandler := http.NewAnnotationHandler(annotationUsecase)
+
+	// Initialize review dependencies
+	reviewRepo := mysql.NewReviewRepository(db)
+	reviewService := service.NewReviewService(reviewRepo, ebookRedisRepo)
+	reviewUsecase := usecase.NewReviewUsecase(ebookService, reviewService, entitlementService, progressService)
+	reviewHandler := http.NewReviewHandler(reviewUsecase)
+
+	// Initialize wishlist dependencies
+	wishlistRepo := mysql.NewWishlistRepository(db)
+	wishlistService := service.NewWishlistService(wishlistRepo, mediaService)
+	wishlistUsecase := usecase.NewWishlistUsecase(ebookService, wishlistService, trendingService)
+	wishlistHandler := http.NewWishlistHandler(wishlistUsecase)
+
+	// Initialize recommendation dependencies
+	recommendationRepo := mysql.NewRecommendationRepository(db)
+	recommendationRedisRepo := redis.NewRecommendationRedisRepository(cRedis)
+	recommendationService := service.NewRecommendationService(recommendationRepo, recommendationRedisRepo, mediaService,
+		cfg.Recommendation.MaxResults, time.Duration(cfg.Recommendation.ActiveUserDays)*24*time.Hour)
+	recommendationUsecase := usecase.NewRecommendationUsecase(ebookService, recommendationService)
+	recommendationHandler := http.NewRecommendationHandler(recommendationUsecase)
+
+	// Initialize analytics dependencies
+	analyticsRepo := mysql.NewAnalyticsRepository(db)
+	analyticsRedisRepo := redis.NewAnalyticsRedisRepository(cRedis, int64(cfg.Analytics.MaxBufferedEvents))
+	analyticsService := service.NewAnalyticsService(analyticsRepo, analyticsRedisRepo, cfg.Analytics.RetentionMonths)
+	analyticsUsecase := usecase.NewAnalyticsUsecase(analyticsService)
+	analyticsHandler := http.NewAnalyticsHandler(analyticsUsecase, localeMiddleware)
+
+	// Hot reading progress is kept in Redis and flushed to MySQL in the background
+	go scheduler.Every(context.Background(), "reading-progress-flush",
+		time.Duration(cfg.Progress.FlushIntervalSeconds)*time.Second,
+		func(ctx context.Context) error {
+			_, err := progressService.FlushProgress(ctx)
+			return err
+		})
+
+	// Discounts start without a write, so a job looks for newly active ones to announce
+	go scheduler.Every(context.Background(), "price-drop-notify",
+		time.Duration(cfg.Wishlist.PriceDropCheckIntervalSeconds)*time.Second,
+		func(ctx context.Context) error {
+			_, err := notificationService.NotifyStartedDiscounts(ctx)
+			return err
+		})
+
+	// Scheduled publications and unpublications are applied once due
+	go scheduler.Every(context.Background(), "scheduled-publishing",
+		time.Duration(cfg.Publishing.CheckIntervalSeconds)*time.Second,
+		func(ctx context.Context) error {
+			_, err := scheduleUsecase.ApplyDueSchedules(ctx)
+			return err
+		})
+
+	// Uploads nobody resumed before they expired are deleted with their partial data
+	go scheduler.Every(context.Background(), "upload-cleanup",
+		time.Duration(cfg.Upload.CleanupIntervalSeconds)*time.Second,
+		func(ctx context.Context) error {
+			_, err := uploadUsecase.DeleteExpiredUploads(ctx)
+			return err
+		})
+
+	// Sitemaps are regenerated in the background whenever the public content changed
+	go scheduler.Every(context.Background(), "sitemap-refresh",
+		time.Duration(cfg.Sitemap.CheckIntervalSeconds)*time.Second,
+		func(ctx context.Context) error {
+			_, err := sitemapUsecase.RefreshSitemaps(ctx)
+			return err
+		})
+
+	// Recommendations are scored in the background and served from Redis.
+	// The first run happens at startup so they are available before the first interval passes.
+	go func() {
+		refresh := func(ctx context.Context) error {
+			_, err := recommendationService.RefreshRecommendations(ctx)
+			return err
+		}
+		if err := refresh(context.Background()); err != nil {
+			log.Printf("Initial recommendation refresh failed: %v", err)
+		}
+		scheduler.Every(context.Background(), "recommendation-refresh",
+			time.Duration(cfg.Recommendation.RefreshIntervalSeconds)*time.Second, refresh)
+	}()
+
+	// Daily trending scores are copied to MySQL and restored from it if Redis lost them.
+	// The first run happens at startup so a flushed cache is rebuilt right away.
+	go func() {
+		snapshot := func(ctx context.Context) error {
+			_, err := trendingService.SnapshotScores(ctx)
+			return err
+		}
+		if err := snapshot(context.Background()); err != nil {
+			log.Printf("Initial trending snapshot failed: %v", err)
+		}
+		scheduler.Every(context.Background(), "trending-snapshot",
+			time.Duration(cfg.Trending.SnapshotIntervalSeconds)*time.Second, snapshot)
+	}()
+
+	// Usage events are buffered in Redis and written to MySQL in batches
+	go scheduler.Every(context.Background(), "analytics-flush",
+		time.Duration(cfg.Analytics.FlushIntervalSeconds)*time.Second,
+		func(ctx context.Context) error {
+			_, err := analyticsService.FlushEvents(ctx)
+			return err
+		})
+
+	// Monthly event partitions are created ahead of time and dropped past the retention.
+	// The first run happens at startup so events of the current month have a partition.
+	go func() {
+		maintain := analyticsService.MaintainPartitions
+		if err := maintain(context.Background()); err != nil {
+			log.Printf("Initial analytics partition maintenance failed: %v", err)
+		}
+		scheduler.Every(context.Background(), "analytics-partitions",
+			time.Duration(cfg.Analytics.PartitionCheckIntervalSeconds)*time.Second, maintain)
+	}()
+
 	// Initialize router
 	router := http.NewRouter(http.RouterConfig{
-		BannerHandler:        bannerHandler,
-		CategoryHandler:      categoryHandler,
-		EbookHandler:         ebookHandler,
-		SummaryHandler:       summaryHandler,
-		AuthHandler:          authHandler,
-		UserHandler:          userHandler,
-		PaymentHandler:       paymentHandler,
-		AuthMiddleware:       authMiddleware,
-		RoleMiddleware:       roleMiddleware,
-		PermissionMiddleware: permissionMiddleware,
+		BannerHandler:         bannerHandler,
+		CategoryHandler:       categoryHandler,
+		EbookHandler:          ebookHandler,
+		SummaryHandler:        summaryHandler,
+		AuthHandler:           authHandler,
+		UserHandler:           userHandler,
+		PaymentHandler:        paymentHandler,
+		DownloadHandler:       downloadHandler,
+		ReaderHandler:         readerHandler,
+		ProgressHandler:       progressHandler,
+		AnnotationHandler:     annotationHandler,
+		ReviewHandler:         reviewHandler,
+		WishlistHandler:       wishlistHandler,
+		NotificationHandler:   notificationHandler,
+		RecommendationHandler: recommendationHandler,
+		TableOfContentHandler: tocHandler,
+		EpubIngestHandler:     epubIngestHandler,
+		MediaHandler:          mediaHandler,
+		UploadHandler:         uploadHandler,
+		ScheduleHandler:       scheduleHandler,
+		TranslationHandler:    translationHandler,
+		CatalogImportHandler:  catalogImportHandler,
+		OPDSHandler:           opdsHandler,
+		SitemapHandler:        sitemapHandler,
+		FeedHandler:           feedHandler,
+		SeriesHandler:         seriesHandler,
+		ContributorHandler:    contributorHandler,
+		TrendingHandler:       trendingHandler,
+		AnalyticsHandler:      analyticsHandler,
+		SummaryAudioHandler:   summaryAudioHandler,
+		AuthMiddleware:        authMiddleware,
+		RoleMiddleware:        roleMiddleware,
+		PermissionMiddleware:  permissionMiddleware,
 	})
 
 	// Initialize router
 	mux := router.SetupRoutes()
 
-	// Start server
+	// Start server; every request is negotiated for the language content is read in
+	// and attributed to the client behind any trusted proxy
 	fmt.Printf("Server is running on port %s\n", cfg.App.Port)
-	log.Fatal(client.ListenAndServe(":"+cfg.App.Port, mux))
+	log.Fatal(client.ListenAndServe(":"+cfg.App.Port, clientIPMiddleware.Resolve(localeMiddleware.Negotiate(mux))))
 }
@@ -0,0 +1,112 @@
+// Command import creates or updates ebooks from a publisher's CSV or JSON catalog, the same way
+// as the admin import endpoint. Run it with -dry-run first to see what would change.
+package main
+
+import (
+	"buku-pintar/internal/repository/mysql"
+	"buku-pintar/internal/repository/redis"
+	"buku-pintar/internal/service"
+	"buku-pintar/internal/usecase"
+	"buku-pintar/pkg/catalog"
+	"buku-pintar/pkg/config"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	_ "github.com/go-sql-driver/mysql"
+)
+
+func main() {
+	configPath := flag.String("config", "./config.json", "path to application config file")
+	format := flag.String("format", "", "catalog format, csv or json (default: from the file extension)")
+	dryRun := flag.Bool("dry-run", false, "validate the catalog and report what would change without saving")
+	reportPath := flag.String("report", "", "write the per-row JSON report to this file")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] catalog.csv|catalog.json\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	path := flag.Arg(0)
+	catalogFormat := catalog.Format(*format)
+	if catalogFormat == "" {
+		catalogFormat = catalog.FormatFromFilename(path)
+	}
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		log.Fatalf("failed to load config: %v", err)
+	}
+
+	dbConfig := cfg.GetDatabaseConfig()
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?%s",
+		dbConfig.User,
+		dbConfig.Password,
+		dbConfig.Host,
+		dbConfig.Port,
+		dbConfig.Name,
+		dbConfig.Params,
+	)
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		log.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+	if err := db.Ping(); err != nil {
+		log.Fatalf("failed to connect to database: %v", err)
+	}
+
+	cRedis, err := cfg.LoadRedis()
+	if err != nil {
+		log.Fatalf("failed to connect to redis: %v", err)
+	}
+
+	importService := service.NewCatalogImportService(mysql.NewCatalogImportRepository(db),
+		redis.NewEbookRedisRepository(cRedis), redis.NewCategoryRedisRepository(cRedis))
+	importUsecase := usecase.NewCatalogImportUsecase(importService)
+
+	file, err := os.Open(path)
+	if err != nil {
+		log.Fatalf("failed to open catalog: %v", err)
+	}
+	defer file.Close()
+
+	report, err := importUsecase.ImportCatalog(context.Background(), file, catalogFormat, *dryRun)
+	if err != nil {
+		log.Fatalf("import failed: %v", err)
+	}
+
+	for _, row := range report.Rows {
+		for _, problem := range row.Errors {
+			log.Printf("line %d (%s): %s", row.Line, row.Slug, problem)
+		}
+	}
+	if *reportPath != "" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			log.Fatalf("failed to encode report: %v", err)
+		}
+		if err := os.WriteFile(*reportPath, data, 0o644); err != nil {
+			log.Fatalf("failed to write report: %v", err)
+		}
+	}
+
+	prefix := "catalog"
+	if report.DryRun {
+		prefix = "catalog (dry run, nothing saved)"
+	}
+	log.Printf("%s: %d entries, %d created, %d updated, %d failed, %d new authors, %d new categories",
+		prefix, report.Total, report.Created, report.Updated, report.Failed,
+		len(report.AuthorsCreated), len(report.CategoriesCreated))
+	if report.Failed > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,62 @@
+// Command media-variants generates the resized variants of covers and banners uploaded before
+// variants were generated on upload. Run it with -force after changing the variant sizes.
+package main
+
+import (
+	"buku-pintar/internal/repository/mysql"
+	"buku-pintar/internal/service"
+	"buku-pintar/internal/usecase"
+	"buku-pintar/pkg/config"
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	_ "github.com/go-sql-driver/mysql"
+)
+
+func main() {
+	configPath := flag.String("config", "./config.json", "path to application config file")
+	force := flag.Bool("force", false, "regenerate variants of images that already have them")
+	flag.Parse()
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		log.Fatalf("failed to load config: %v", err)
+	}
+
+	dbConfig := cfg.GetDatabaseConfig()
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?%s",
+		dbConfig.User,
+		dbConfig.Password,
+		dbConfig.Host,
+		dbConfig.Port,
+		dbConfig.Name,
+		dbConfig.Params,
+	)
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		log.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+	if err := db.Ping(); err != nil {
+		log.Fatalf("failed to connect to database: %v", err)
+	}
+
+	mediaStorage, err := cfg.LoadMediaStorage()
+	if err != nil {
+		log.Fatalf("failed to open media storage: %v", err)
+	}
+	mediaService := service.NewMediaService(mysql.NewMediaRepository(db), mediaStorage, time.Duration(cfg.Download.TTLSeconds)*time.Second)
+	mediaUsecase := usecase.NewMediaUsecase(mediaService)
+
+	result, err := mediaUsecase.BackfillImageVariants(context.Background(), *force)
+	if result != nil {
+		log.Printf("image variants: %d generated, %d skipped, %d failed", result.Generated, result.Skipped, result.Failed)
+	}
+	if err != nil {
+		log.Fatalf("backfill stopped: %v", err)
+	}
+}
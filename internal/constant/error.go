@@ -13,6 +13,126 @@ const (
 	ERR_CATEGORY_ID_REQUIRED     string = "category ID is required"
 	ERR_AUTHOR_ID_REQUIRED       string = "author ID is required"
 	ERR_ID_REQUIRED              string = "id is required"
+	ERR_CURSOR_MISMATCH          string = "cursor does not belong to this list, sort order or filter"
+	ERR_CURSOR_VALUE_INVALID     string = "cursor position does not fit this list"
+	ERR_EBOOK_ACCESS_DENIED      string = "purchase or premium access is required for this ebook"
+	ERR_EBOOK_FILE_UNAVAILABLE   string = "ebook file is not available"
+	ERR_PAGE_NUMBER_INVALID      string = "page must be a positive number"
+	ERR_EBOOK_PAGE_NOT_FOUND     string = "page not found"
+	ERR_PURCHASE_REQUIRED        string = "purchase this ebook to keep reading beyond the preview"
+	ERR_EBOOK_FREE               string = "free ebooks need no purchase"
+	ERR_CALLBACK_TOKEN_INVALID   string = "callback token is missing or invalid"
+	ERR_LOGIN_REQUIRED_TO_READ   string = "sign in to keep reading this free ebook"
+	ERR_PROGRESS_POSITION        string = "page or cfi is required"
+	ERR_PROGRESS_PAGE_INVALID    string = "page must not be negative"
+	ERR_PROGRESS_PERCENTAGE      string = "percentage must be between 0 and 100"
+	ERR_PROGRESS_CFI_TOO_LONG    string = "cfi must be at most 512 characters"
+	ERR_ANNOTATION_NOT_FOUND     string = "annotation not found"
+	ERR_ANNOTATION_TYPE          string = "type must be bookmark, highlight or note"
+	ERR_ANNOTATION_LOCATION      string = "page or cfi_start is required"
+	ERR_ANNOTATION_PAGE          string = "page must be a positive number"
+	ERR_ANNOTATION_CFI_TOO_LONG  string = "cfi_start and cfi_end must be at most 512 characters"
+	ERR_ANNOTATION_TEXT          string = "selected_text is required for highlights"
+	ERR_ANNOTATION_NOTE          string = "note is required for notes"
+	ERR_ANNOTATION_COLOR         string = "color must be a named color or a hex value like #ffcc00"
+	ERR_UPDATED_SINCE_INVALID    string = "updated_since must be an RFC 3339 timestamp"
+	ERR_REVIEW_NOT_FOUND         string = "review not found"
+	ERR_REVIEW_RATING            string = "rating must be between 1 and 5"
+	ERR_REVIEW_BODY_TOO_LONG     string = "review must be at most 5000 characters"
+	ERR_REVIEW_NOT_ELIGIBLE      string = "only readers with access to this ebook, or who read past its preview, can review it"
+	ERR_REVIEW_ALREADY_EXISTS    string = "you have already reviewed this ebook"
+	ERR_REVIEW_REPORT_OWN        string = "you cannot report your own review"
+	ERR_REVIEW_REASON_TOO_LONG   string = "reason must be at most 500 characters"
+	ERR_TOC_TITLE_REQUIRED       string = "every table of contents entry needs a title"
+	ERR_TOC_TITLE_TOO_LONG       string = "table of contents titles must be at most 255 characters"
+	ERR_TOC_PAGE_INVALID         string = "page_number must be a positive number"
+	ERR_TOC_PAGE_OUT_OF_RANGE    string = "page_number must not exceed the ebook's page count"
+	ERR_TOC_TOO_DEEP             string = "table of contents entries can be nested at most 6 levels deep"
+	ERR_TOC_TOO_MANY_ENTRIES     string = "a table of contents can have at most 2000 entries"
+	ERR_EPUB_INVALID             string = "the uploaded file is not a valid EPUB"
+	ERR_EPUB_FILE_REQUIRED       string = "an EPUB file is required in the file field"
+	ERR_EPUB_TOO_LARGE           string = "the uploaded file is too large"
+	ERR_EPUB_TOO_MANY_PAGES      string = "the EPUB has too many content documents to import"
+	ERR_EPUB_CONTENT_TOO_LARGE   string = "the EPUB content is too large to import"
+	ERR_MEDIA_KIND_INVALID       string = "kind must be one of cover, ebook_file, audio, banner or icon"
+	ERR_MEDIA_FILE_REQUIRED      string = "a file is required in the file field"
+	ERR_MEDIA_EMPTY              string = "the uploaded file is empty"
+	ERR_MEDIA_TOO_LARGE          string = "the uploaded file is too large for this kind of media"
+	ERR_MEDIA_TYPE_NOT_ALLOWED   string = "the uploaded file type is not allowed for this kind of media"
+	ERR_MEDIA_NOT_FOUND          string = "media not found"
+	ERR_UPLOAD_NOT_FOUND         string = "upload not found"
+	ERR_UPLOAD_EXPIRED           string = "upload has expired"
+	ERR_UPLOAD_LENGTH_INVALID    string = "Upload-Length must be a positive number of bytes"
+	ERR_UPLOAD_OFFSET_MISMATCH   string = "Upload-Offset does not match the bytes received so far"
+	ERR_UPLOAD_CHECKSUM_MISMATCH string = "the uploaded data does not match its checksum"
+	ERR_UPLOAD_CHECKSUM_INVALID  string = "checksum must be a hex SHA-256 digest"
+	ERR_UPLOAD_ALGORITHM_INVALID string = "checksum algorithm must be one of sha1, sha256 or md5"
+	ERR_UPLOAD_LOCKED            string = "another request is writing to this upload"
+	ERR_UPLOAD_FORBIDDEN         string = "you are not allowed to upload this kind of media"
+	ERR_SCHEDULE_NOT_FOUND       string = "schedule not found"
+	ERR_SCHEDULE_CONTENT_TYPE    string = "content_type must be one of ebook, article or inspiration"
+	ERR_SCHEDULE_CONTENT_ID      string = "content_id is required"
+	ERR_SCHEDULE_ACTION          string = "action must be publish or unpublish"
+	ERR_SCHEDULE_RUN_AT          string = "run_at must be in the future"
+	ERR_SCHEDULE_CONTENT_MISSING string = "the content to schedule does not exist"
+	ERR_SCHEDULE_APPLIED         string = "the schedule has already been applied"
+	ERR_SCHEDULE_FORBIDDEN       string = "you are not allowed to schedule this content"
+	ERR_TRANSLATION_NOT_FOUND    string = "translation not found"
+	ERR_TRANSLATION_CONTENT_TYPE string = "content_type must be one of ebook, category, article or banner"
+	ERR_TRANSLATION_LOCALE       string = "locale must be a supported language other than the default"
+	ERR_TRANSLATION_FIELDS       string = "at least one translated field is required"
+	ERR_TRANSLATION_FIELD        string = "unknown translated field"
+	ERR_TRANSLATION_TOO_LONG     string = "translated field is too long"
+	ERR_CATALOG_FORMAT           string = "catalog must be a .csv or .json file"
+	ERR_CATALOG_FILE_REQUIRED    string = "catalog file is required"
+	ERR_CATALOG_UNREADABLE       string = "catalog could not be read"
+	ERR_CATALOG_EMPTY            string = "catalog has no entries"
+	ERR_CATALOG_TOO_LARGE        string = "catalog has too many entries"
+	ERR_TRANSLATION_CONTENT_GONE string = "the content to translate does not exist"
+	ERR_TRANSLATION_FORBIDDEN    string = "you are not allowed to translate this content"
+	ERR_OPDS_QUERY_REQUIRED      string = "q is required"
+	ERR_OPDS_CATEGORY_NOT_FOUND  string = "category not found"
+	ERR_OPDS_AUTHOR_NOT_FOUND    string = "author has no published ebooks"
+	ERR_SITEMAP_NOT_FOUND        string = "sitemap not found"
+	ERR_FEED_NOT_FOUND           string = "feed not found"
+	ERR_FEED_CATEGORY_NOT_FOUND  string = "category not found"
+	ERR_FEED_AUTHOR_NOT_FOUND    string = "author not found"
+	ERR_SERIES_NOT_FOUND         string = "series not found"
+	ERR_SERIES_NAME_REQUIRED     string = "name is required"
+	ERR_SERIES_NAME_TOO_LONG     string = "name must be at most 255 characters"
+	ERR_SERIES_SLUG_INVALID      string = "slug may only contain lowercase letters, digits and single hyphens"
+	ERR_SERIES_SLUG_TAKEN        string = "a series with this slug already exists"
+	ERR_SERIES_VOLUME_INVALID    string = "volume must be between 1 and 65535"
+	ERR_SERIES_VOLUME_DUPLICATE  string = "every volume number may be used once"
+	ERR_SERIES_EBOOK_DUPLICATE   string = "an ebook may be listed once"
+	ERR_SERIES_EBOOK_TAKEN       string = "ebook already belongs to another series"
+	ERR_SERIES_TOO_MANY_VOLUMES  string = "a series can have at most 500 volumes"
+	ERR_EBOOK_NOT_IN_SERIES      string = "ebook does not belong to a series"
+	ERR_CONTRIBUTOR_AUTHOR_ID    string = "every contributor needs an author_id"
+	ERR_CONTRIBUTOR_NOT_FOUND    string = "contributor author not found"
+	ERR_CONTRIBUTOR_ROLE_INVALID string = "role must be author, translator, editor or narrator"
+	ERR_CONTRIBUTOR_DUPLICATE    string = "an author may be listed once per role"
+	ERR_CONTRIBUTOR_NO_AUTHOR    string = "at least one contributor must be an author"
+	ERR_CONTRIBUTOR_TOO_MANY     string = "an ebook can have at most 50 contributors"
+	ERR_TRENDING_WINDOW_INVALID  string = "window must be 24h, 7d or 30d"
+	ERR_ANALYTICS_NO_EVENTS      string = "events must contain at least one event"
+	ERR_ANALYTICS_TOO_MANY       string = "a batch can have at most 100 events"
+	ERR_ANALYTICS_TOO_LARGE      string = "the request body must be at most 256 KB"
+	ERR_ANALYTICS_BUSY           string = "too many events are waiting to be stored, try again shortly"
+	ERR_ANALYTICS_TYPE_INVALID   string = "type must be view, read_page, audio_play, search or banner_click"
+	ERR_ANALYTICS_FIELD_REQUIRED string = "%s is required for %s events"
+	ERR_ANALYTICS_FIELD_UNKNOWN  string = "%s is not part of %s events"
+	ERR_ANALYTICS_FIELD_TOO_LONG string = "%s must be at most %d characters"
+	ERR_ANALYTICS_PAGE_INVALID   string = "page_number must be at least 1"
+	ERR_ANALYTICS_POSITION       string = "position_seconds must not be negative"
+	ERR_ANALYTICS_TIME_INVALID   string = "occurred_at must be within the last 7 days and not in the future"
+	ERR_ANALYTICS_PROPERTIES     string = "properties must be a JSON object of at most 2048 bytes"
+	ERR_ANALYTICS_RANGE_INVALID  string = "from must be before to and at most 366 days earlier"
+	ERR_ANALYTICS_DIMENSION      string = "dimension must be ebook, search or banner"
+	ERR_SUMMARY_AUDIO_MISSING    string = "this summary has no audio"
+	ERR_SUMMARY_PREMIUM_REQUIRED string = "premium access is required for this summary"
+	ERR_LISTENING_POSITION       string = "position_seconds must not be negative"
+	ERR_LISTENING_DURATION       string = "duration_seconds must be positive and at least position_seconds"
 
 	ERR_CODE_SERVER_ERROR  string = "internal_server_error"
 	ERR_CODE_BAD_REQUEST   string = "bad_request"
@@ -1,11 +1,15 @@
 package constant
 
 const (
-	CONTENT_TYPE                string = "Content-Type"
-	APPLICATION_JSON            string = "application/json"
-	ACCESS_CONTROL_ALLOW_ORIGIN string = "Access-Control-Allow-Origin"
-	ACCESS_CONTROL_ALLOW_METHOD string = "Access-Control-Allow-Methods"
-	ACCESS_CONTROL_ALLOW_HEADER string = "Access-Control-Allow-Headers"
+	CONTENT_TYPE                 string = "Content-Type"
+	APPLICATION_JSON             string = "application/json"
+	ACCESS_CONTROL_ALLOW_ORIGIN  string = "Access-Control-Allow-Origin"
+	ACCESS_CONTROL_ALLOW_METHOD  string = "Access-Control-Allow-Methods"
+	ACCESS_CONTROL_ALLOW_HEADER  string = "Access-Control-Allow-Headers"
+	ACCESS_CONTROL_EXPOSE_HEADER string = "Access-Control-Expose-Headers"
+	LINK                         string = "Link"
+
+	DEFAULT_CURRENCY string = "IDR"
 
 	STATUS_SUCCESS string = "success"
 	STATUS_ERROR   string = "error"
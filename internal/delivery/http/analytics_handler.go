@@ -0,0 +1,196 @@
+package http
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/delivery/http/middleware"
+	"buku-pintar/internal/delivery/http/response"
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/helper"
+	"buku-pintar/internal/usecase"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+)
+
+const (
+	// maxAnalyticsBodySize bounds a batch of events; 100 events with full properties fit well within it
+	maxAnalyticsBodySize = 256 << 10
+
+	// analyticsRetryAfter is how long, in seconds, clients wait when the event buffer is full;
+	// the flush job drains it every few seconds
+	analyticsRetryAfter = "10"
+)
+
+type AnalyticsHandler struct {
+	analyticsUsecase usecase.AnalyticsUsecase
+	localeMiddleware *middleware.LocaleMiddleware
+}
+
+func NewAnalyticsHandler(analyticsUsecase usecase.AnalyticsUsecase, localeMiddleware *middleware.LocaleMiddleware) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		analyticsUsecase: analyticsUsecase,
+		localeMiddleware: localeMiddleware,
+	}
+}
+
+// AnalyticsEventRequest is one client event; occurred_at is an RFC 3339 time and defaults to when it is received.
+// Which of ebook_id, banner_id, page_number, position_seconds and query are needed depends on the type.
+type AnalyticsEventRequest struct {
+	Type            entity.AnalyticsEventType `json:"type"`
+	OccurredAt      time.Time                 `json:"occurred_at"`
+	SessionID       *string                   `json:"session_id"`
+	EbookID         *string                   `json:"ebook_id"`
+	BannerID        *string                   `json:"banner_id"`
+	PageNumber      *int                      `json:"page_number"`
+	PositionSeconds *int                      `json:"position_seconds"`
+	Query           *string                   `json:"query"`
+	Properties      json.RawMessage           `json:"properties"`
+}
+
+type TrackEventsRequest struct {
+	Events []*AnalyticsEventRequest `json:"events"`
+}
+
+// TrackEvents handles POST /events - Record a batch of up to 100 usage events. Signed-in clients have
+// the events attributed to them; the events are stored asynchronously.
+func (h *AnalyticsHandler) TrackEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	var req TrackEventsRequest
+	r.Body = http.MaxBytesReader(w, r.Body, maxAnalyticsBodySize)
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			response.WriteError(w, http.StatusRequestEntityTooLarge, "events_too_large", constant.ERR_ANALYTICS_TOO_LARGE)
+			return
+		}
+		response.WriteError(w, http.StatusBadRequest, "invalid_request_body", err.Error())
+		return
+	}
+
+	client := &entity.AnalyticsClient{
+		Locale:    h.localeMiddleware.Locale(r),
+		IPAddress: helper.ClientIP(r),
+		UserAgent: r.UserAgent(),
+	}
+	if user, err := middleware.GetUserFromContext(r.Context()); err == nil && user != nil {
+		client.UserID = user.ID
+	}
+
+	events := make([]*entity.AnalyticsEvent, 0, len(req.Events))
+	for _, event := range req.Events {
+		if event == nil {
+			event = &AnalyticsEventRequest{}
+		}
+		events = append(events, &entity.AnalyticsEvent{
+			Type:            event.Type,
+			OccurredAt:      event.OccurredAt,
+			SessionID:       event.SessionID,
+			EbookID:         event.EbookID,
+			BannerID:        event.BannerID,
+			PageNumber:      event.PageNumber,
+			PositionSeconds: event.PositionSeconds,
+			Query:           event.Query,
+			Properties:      event.Properties,
+		})
+	}
+
+	accepted, err := h.analyticsUsecase.TrackEvents(r.Context(), client, events)
+	if err != nil {
+		writeAnalyticsError(w, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusAccepted, &response.AnalyticsAcceptedResponse{Accepted: accepted}, "Events accepted")
+}
+
+// GetSummary handles GET /analytics/events/summary?from=&to=&type=&ebook_id= - Events, users and sessions per event type
+func (h *AnalyticsHandler) GetSummary(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	filter, err := helper.HandleAnalyticsFilter(r)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "validation_error", err.Error())
+		return
+	}
+
+	totals, err := h.analyticsUsecase.GetTotals(r.Context(), filter)
+	if err != nil {
+		writeAnalyticsError(w, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, response.ParseAnalyticsSummaryResponse(filter, totals), "Event summary retrieved successfully")
+}
+
+// GetDailyCounts handles GET /analytics/events/daily?from=&to=&type=&ebook_id= - Events per UTC day and event type
+func (h *AnalyticsHandler) GetDailyCounts(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	filter, err := helper.HandleAnalyticsFilter(r)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "validation_error", err.Error())
+		return
+	}
+
+	counts, err := h.analyticsUsecase.GetDailyCounts(r.Context(), filter)
+	if err != nil {
+		writeAnalyticsError(w, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, response.ParseAnalyticsDailyResponse(filter, counts), "Daily event counts retrieved successfully")
+}
+
+// GetTopItems handles GET /analytics/events/top?dimension=ebook|search|banner&limit=&from=&to=&type= - The ebooks,
+// search queries or banners with the most events
+func (h *AnalyticsHandler) GetTopItems(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	filter, err := helper.HandleAnalyticsFilter(r)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "validation_error", err.Error())
+		return
+	}
+
+	dimension := r.URL.Query().Get("dimension")
+	if dimension == "" {
+		dimension = string(entity.AnalyticsDimensionEbook)
+	}
+	limit, _ := helper.HandlePagination(r)
+
+	items, err := h.analyticsUsecase.GetTopItems(r.Context(), filter, dimension, limit)
+	if err != nil {
+		writeAnalyticsError(w, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, response.ParseAnalyticsTopResponse(filter, dimension, items), "Top items retrieved successfully")
+}
+
+func writeAnalyticsError(w http.ResponseWriter, err error) {
+	var validationErr *usecase.ValidationError
+	if errors.As(err, &validationErr) {
+		response.WriteError(w, http.StatusBadRequest, "validation_error", err.Error())
+		return
+	}
+	if errors.Is(err, usecase.ErrAnalyticsBusy) {
+		w.Header().Set("Retry-After", analyticsRetryAfter)
+		response.WriteError(w, http.StatusServiceUnavailable, "events_buffer_full", err.Error())
+		return
+	}
+	response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+}
@@ -0,0 +1,281 @@
+package http
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/delivery/http/middleware"
+	"buku-pintar/internal/delivery/http/response"
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/helper"
+	"buku-pintar/internal/usecase"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type AnnotationHandler struct {
+	annotationUsecase usecase.AnnotationUsecase
+}
+
+func NewAnnotationHandler(annotationUsecase usecase.AnnotationUsecase) *AnnotationHandler {
+	return &AnnotationHandler{
+		annotationUsecase: annotationUsecase,
+	}
+}
+
+// AnnotationRequest is the body for creating or updating an annotation
+// ebook_id and type are only read on create
+type AnnotationRequest struct {
+	EbookID      string                `json:"ebook_id"`
+	Type         entity.AnnotationType `json:"type"`
+	Page         *int                  `json:"page"`
+	CFIStart     *string               `json:"cfi_start"`
+	CFIEnd       *string               `json:"cfi_end"`
+	SelectedText *string               `json:"selected_text"`
+	Color        *string               `json:"color"`
+	Note         *string               `json:"note"`
+}
+
+func (req *AnnotationRequest) toEntity(userID string) *entity.Annotation {
+	return &entity.Annotation{
+		UserID:       userID,
+		EbookID:      req.EbookID,
+		Type:         req.Type,
+		Page:         req.Page,
+		CFIStart:     req.CFIStart,
+		CFIEnd:       req.CFIEnd,
+		SelectedText: req.SelectedText,
+		Color:        req.Color,
+		Note:         req.Note,
+	}
+}
+
+// ListAnnotations handles GET /annotations - List the user's annotations
+// ebook_id narrows to one book; updated_since returns changes (including deletions) for incremental sync
+func (h *AnnotationHandler) ListAnnotations(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil || user == nil {
+		response.WriteError(w, http.StatusUnauthorized, "unauthorized", "Authentication required")
+		return
+	}
+
+	filter := &entity.AnnotationFilter{
+		UserID:  user.ID,
+		EbookID: r.URL.Query().Get("ebook_id"),
+	}
+	if since := r.URL.Query().Get("updated_since"); since != "" {
+		updatedSince, err := time.Parse(time.RFC3339Nano, since)
+		if err != nil {
+			response.WriteError(w, http.StatusBadRequest, "invalid_updated_since", constant.ERR_UPDATED_SINCE_INVALID)
+			return
+		}
+		filter.UpdatedSince = &updatedSince
+	}
+
+	limit, offset := helper.HandlePagination(r)
+
+	annotations, err := h.annotationUsecase.ListAnnotations(r.Context(), filter, limit, offset)
+	if err != nil {
+		response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+		return
+	}
+
+	total, err := h.annotationUsecase.CountAnnotations(r.Context(), filter)
+	if err != nil {
+		response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+		return
+	}
+
+	if annotations == nil {
+		annotations = []*entity.Annotation{}
+	}
+	response.WritePaginatedMeta(w, r, annotations, response.NewOffsetMeta(total, limit, offset))
+}
+
+// CreateAnnotation handles POST /annotations/create - Create a bookmark, highlight or note
+func (h *AnnotationHandler) CreateAnnotation(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil || user == nil {
+		response.WriteError(w, http.StatusUnauthorized, "unauthorized", "Authentication required")
+		return
+	}
+
+	var req AnnotationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "invalid_request_body", err.Error())
+		return
+	}
+
+	annotation := req.toEntity(user.ID)
+	if err := h.annotationUsecase.CreateAnnotation(r.Context(), annotation); err != nil {
+		writeAnnotationError(w, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusCreated, annotation, "Annotation created successfully")
+}
+
+// GetAnnotation handles GET /annotations/{id} - Get one of the user's annotations
+func (h *AnnotationHandler) GetAnnotation(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil || user == nil {
+		response.WriteError(w, http.StatusUnauthorized, "unauthorized", "Authentication required")
+		return
+	}
+
+	annotation, err := h.annotationUsecase.GetAnnotation(r.Context(), user.ID, lastPathSegment(r))
+	if err != nil {
+		writeAnnotationError(w, err)
+		return
+	}
+
+	if annotation == nil {
+		response.WriteError(w, http.StatusNotFound, "annotation_not_found", constant.ERR_ANNOTATION_NOT_FOUND)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, annotation, "")
+}
+
+// UpdateAnnotation handles PUT /annotations/edit/{id} - Update one of the user's annotations
+func (h *AnnotationHandler) UpdateAnnotation(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil || user == nil {
+		response.WriteError(w, http.StatusUnauthorized, "unauthorized", "Authentication required")
+		return
+	}
+
+	var req AnnotationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "invalid_request_body", err.Error())
+		return
+	}
+
+	annotation := req.toEntity(user.ID)
+	annotation.ID = lastPathSegment(r)
+
+	updated, err := h.annotationUsecase.UpdateAnnotation(r.Context(), annotation)
+	if err != nil {
+		writeAnnotationError(w, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, updated, "Annotation updated successfully")
+}
+
+// DeleteAnnotation handles DELETE /annotations/delete/{id} - Delete one of the user's annotations
+func (h *AnnotationHandler) DeleteAnnotation(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil || user == nil {
+		response.WriteError(w, http.StatusUnauthorized, "unauthorized", "Authentication required")
+		return
+	}
+
+	if err := h.annotationUsecase.DeleteAnnotation(r.Context(), user.ID, lastPathSegment(r)); err != nil {
+		writeAnnotationError(w, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, nil, "Annotation deleted successfully")
+}
+
+// ExportAnnotations handles GET /annotations/export/{ebookID}?format=markdown|json
+// Download the user's annotations for one ebook
+func (h *AnnotationHandler) ExportAnnotations(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil || user == nil {
+		response.WriteError(w, http.StatusUnauthorized, "unauthorized", "Authentication required")
+		return
+	}
+
+	format := strings.ToLower(r.URL.Query().Get("format"))
+	if format == "" || format == "md" {
+		format = "markdown"
+	}
+	if format != "markdown" && format != "json" {
+		response.WriteError(w, http.StatusBadRequest, "invalid_format", "format must be markdown or json")
+		return
+	}
+
+	ebook, annotations, err := h.annotationUsecase.ExportAnnotations(r.Context(), user.ID, lastPathSegment(r))
+	if err != nil {
+		writeAnnotationError(w, err)
+		return
+	}
+
+	if ebook == nil {
+		response.WriteError(w, http.StatusNotFound, "ebook_not_found", constant.EBOOK_NOT_FOUND)
+		return
+	}
+
+	exportedAt := time.Now().UTC()
+	fileName := ebook.Slug
+	if fileName == "" {
+		fileName = ebook.ID
+	}
+
+	if format == "json" {
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-annotations.json"`, fileName))
+		w.Header().Set(constant.CONTENT_TYPE, constant.APPLICATION_JSON)
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(response.ParseAnnotationExportResponse(ebook, annotations, exportedAt)); err != nil {
+			http.Error(w, constant.ERR_ENCODING_RESP, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-annotations.md"`, fileName))
+	w.Header().Set(constant.CONTENT_TYPE, "text/markdown; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(response.RenderAnnotationsMarkdown(ebook, annotations, exportedAt)))
+}
+
+func writeAnnotationError(w http.ResponseWriter, err error) {
+	var validationErr *usecase.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		response.WriteError(w, http.StatusBadRequest, "validation_error", err.Error())
+	case errors.Is(err, usecase.ErrAnnotationNotFound):
+		response.WriteError(w, http.StatusNotFound, "annotation_not_found", err.Error())
+	default:
+		response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+	}
+}
+
+// lastPathSegment returns the trailing {id} of routes like /annotations/edit/{id}
+func lastPathSegment(r *http.Request) string {
+	pathParts := strings.Split(strings.TrimSuffix(r.URL.Path, "/"), "/")
+	return pathParts[len(pathParts)-1]
+}
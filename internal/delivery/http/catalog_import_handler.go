@@ -0,0 +1,81 @@
+package http
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/delivery/http/response"
+	"buku-pintar/internal/usecase"
+	"buku-pintar/pkg/catalog"
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+const (
+	// maxCatalogUploadSize bounds the whole multipart request
+	maxCatalogUploadSize = 20 << 20
+	// catalogUploadMemory is how much of the upload is kept in memory before spilling to a temporary file
+	catalogUploadMemory = 8 << 20
+)
+
+type CatalogImportHandler struct {
+	catalogImportUsecase usecase.CatalogImportUsecase
+}
+
+func NewCatalogImportHandler(catalogImportUsecase usecase.CatalogImportUsecase) *CatalogImportHandler {
+	return &CatalogImportHandler{
+		catalogImportUsecase: catalogImportUsecase,
+	}
+}
+
+// ImportCatalog handles POST /ebooks/import?dry_run= - Create or update ebooks from an uploaded CSV or JSON catalog
+func (h *CatalogImportHandler) ImportCatalog(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	dryRun := false
+	if value := r.URL.Query().Get("dry_run"); value != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(value); err != nil {
+			response.WriteError(w, http.StatusBadRequest, "validation_error", "dry_run must be a boolean")
+			return
+		}
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxCatalogUploadSize)
+	if err := r.ParseMultipartForm(catalogUploadMemory); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			response.WriteError(w, http.StatusRequestEntityTooLarge, "catalog_too_large", constant.ERR_CATALOG_TOO_LARGE)
+			return
+		}
+		response.WriteError(w, http.StatusBadRequest, "invalid_request_body", err.Error())
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "catalog_file_required", constant.ERR_CATALOG_FILE_REQUIRED)
+		return
+	}
+	defer file.Close()
+
+	report, err := h.catalogImportUsecase.ImportCatalog(r.Context(), file, catalog.FormatFromFilename(header.Filename), dryRun)
+	if err != nil {
+		var validationErr *usecase.ValidationError
+		if errors.As(err, &validationErr) {
+			response.WriteError(w, http.StatusBadRequest, "validation_error", err.Error())
+			return
+		}
+		response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+		return
+	}
+
+	message := "Catalog imported"
+	if report.DryRun {
+		message = "Catalog checked, nothing was saved"
+	}
+	response.WriteSuccess(w, http.StatusOK, report, message)
+}
@@ -4,8 +4,10 @@ import (
 	"buku-pintar/internal/constant"
 	"buku-pintar/internal/delivery/http/response"
 	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/helper"
 	"buku-pintar/internal/usecase"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
@@ -14,11 +16,12 @@ import (
 )
 
 type CategoryHandler struct {
-	usecase usecase.CategoryUsecase
+	usecase     usecase.CategoryUsecase
+	cursorCodec *helper.CursorCodec
 }
 
-func NewCategoryHandler(usecase usecase.CategoryUsecase) *CategoryHandler {
-	return &CategoryHandler{usecase: usecase}
+func NewCategoryHandler(usecase usecase.CategoryUsecase, cursorCodec *helper.CursorCodec) *CategoryHandler {
+	return &CategoryHandler{usecase: usecase, cursorCodec: cursorCodec}
 }
 
 func (h *CategoryHandler) ListCategory(w http.ResponseWriter, r *http.Request) {
@@ -38,19 +41,43 @@ func (h *CategoryHandler) ListCategory(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	categories, err := h.usecase.ListCategory(ctx, limit, offset)
+	total, err := h.usecase.CountCategory(ctx)
 	if err != nil {
 		response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
 		return
 	}
 
-	total, err := h.usecase.CountCategory(ctx)
+	// Keyset pagination on (order_number, id) when a cursor is requested
+	if helper.IsCursorPagination(r) {
+		cursor, err := h.cursorCodec.Decode(r.URL.Query().Get("cursor"))
+		if err != nil {
+			response.WriteError(w, http.StatusBadRequest, "invalid_cursor", err.Error())
+			return
+		}
+
+		categories, page, err := h.usecase.ListCategoryByCursor(ctx, cursor, limit)
+		if err != nil {
+			var validationErr *usecase.ValidationError
+			if errors.As(err, &validationErr) {
+				response.WriteError(w, http.StatusBadRequest, "invalid_cursor", err.Error())
+				return
+			}
+			response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+			return
+		}
+
+		meta := response.NewCursorMeta(total, limit, h.cursorCodec.Encode(page.Next), h.cursorCodec.Encode(page.Prev))
+		response.WritePaginatedMeta(w, r, categories, meta)
+		return
+	}
+
+	categories, err := h.usecase.ListCategory(ctx, limit, offset)
 	if err != nil {
 		response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
 		return
 	}
 
-	response.WritePaginated(w, categories, total, limit, offset)
+	response.WritePaginatedMeta(w, r, categories, response.NewOffsetMeta(total, limit, offset))
 }
 
 func (h *CategoryHandler) GetCategoryByID(w http.ResponseWriter, r *http.Request) {
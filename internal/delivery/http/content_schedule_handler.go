@@ -0,0 +1,127 @@
+package http
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/delivery/http/middleware"
+	"buku-pintar/internal/delivery/http/response"
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/usecase"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+)
+
+type ContentScheduleHandler struct {
+	scheduleUsecase usecase.ContentScheduleUsecase
+}
+
+func NewContentScheduleHandler(scheduleUsecase usecase.ContentScheduleUsecase) *ContentScheduleHandler {
+	return &ContentScheduleHandler{
+		scheduleUsecase: scheduleUsecase,
+	}
+}
+
+// ContentScheduleRequest is the body for scheduling content; run_at is an RFC 3339 time
+type ContentScheduleRequest struct {
+	ContentType entity.SchedulableContent `json:"content_type"`
+	ContentID   string                    `json:"content_id"`
+	Action      entity.ScheduleAction     `json:"action"`
+	RunAt       time.Time                 `json:"run_at"`
+}
+
+// ScheduleContent handles POST /schedules/create - Publish or unpublish an ebook, article or inspiration at a given time
+func (h *ContentScheduleHandler) ScheduleContent(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil || user == nil {
+		response.WriteError(w, http.StatusUnauthorized, "unauthorized", "Authentication required")
+		return
+	}
+
+	var req ContentScheduleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "invalid_request_body", err.Error())
+		return
+	}
+
+	schedule := &entity.ContentSchedule{
+		ContentType: req.ContentType,
+		ContentID:   req.ContentID,
+		Action:      req.Action,
+		RunAt:       req.RunAt,
+	}
+	if err := h.scheduleUsecase.ScheduleContent(r.Context(), user.ID, schedule); err != nil {
+		writeContentScheduleError(w, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusCreated, response.ParseContentScheduleResponse(schedule), "Content scheduled successfully")
+}
+
+// ListSchedules handles GET /schedules?content_type=&content_id= - List the schedules of a piece of content
+func (h *ContentScheduleHandler) ListSchedules(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil || user == nil {
+		response.WriteError(w, http.StatusUnauthorized, "unauthorized", "Authentication required")
+		return
+	}
+
+	query := r.URL.Query()
+	schedules, err := h.scheduleUsecase.ListSchedules(r.Context(), user.ID,
+		entity.SchedulableContent(query.Get("content_type")), query.Get("content_id"))
+	if err != nil {
+		writeContentScheduleError(w, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, response.ParseContentScheduleListResponse(schedules), "")
+}
+
+// CancelSchedule handles DELETE /schedules/cancel/{id} - Cancel a schedule that has not run yet
+func (h *ContentScheduleHandler) CancelSchedule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil || user == nil {
+		response.WriteError(w, http.StatusUnauthorized, "unauthorized", "Authentication required")
+		return
+	}
+
+	if err := h.scheduleUsecase.CancelSchedule(r.Context(), user.ID, r.PathValue("id")); err != nil {
+		writeContentScheduleError(w, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, nil, "Schedule cancelled successfully")
+}
+
+func writeContentScheduleError(w http.ResponseWriter, err error) {
+	var validationErr *usecase.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		response.WriteError(w, http.StatusBadRequest, "validation_error", err.Error())
+	case errors.Is(err, usecase.ErrScheduleNotFound):
+		response.WriteError(w, http.StatusNotFound, "schedule_not_found", err.Error())
+	case errors.Is(err, usecase.ErrScheduledContentNotFound):
+		response.WriteError(w, http.StatusNotFound, "content_not_found", err.Error())
+	case errors.Is(err, usecase.ErrScheduleApplied):
+		response.WriteError(w, http.StatusConflict, "schedule_applied", err.Error())
+	case errors.Is(err, usecase.ErrScheduleForbidden):
+		response.WriteError(w, http.StatusForbidden, "forbidden", err.Error())
+	default:
+		response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+	}
+}
@@ -0,0 +1,84 @@
+package http
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/delivery/http/response"
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/usecase"
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+type EbookContributorHandler struct {
+	contributorUsecase usecase.EbookContributorUsecase
+}
+
+func NewEbookContributorHandler(contributorUsecase usecase.EbookContributorUsecase) *EbookContributorHandler {
+	return &EbookContributorHandler{
+		contributorUsecase: contributorUsecase,
+	}
+}
+
+// ReplaceEbookContributorsRequest lists the contributors of an ebook in display order
+type ReplaceEbookContributorsRequest struct {
+	Contributors []struct {
+		AuthorID string                 `json:"author_id"`
+		Role     entity.ContributorRole `json:"role"`
+	} `json:"contributors"`
+}
+
+// GetContributors handles GET /ebooks/{id}/contributors - Everyone who contributed to the ebook, in display order
+func (h *EbookContributorHandler) GetContributors(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	contributors, err := h.contributorUsecase.GetContributors(r.Context(), ebookSubresourceID(r))
+	if err != nil {
+		writeEbookContributorError(w, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, response.ParseEbookContributorResponses(contributors), "Contributors retrieved successfully")
+}
+
+// ReplaceContributors handles PUT /ebooks/{id}/contributors - Replace all contributors at once
+func (h *EbookContributorHandler) ReplaceContributors(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	var req ReplaceEbookContributorsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "invalid_request_body", err.Error())
+		return
+	}
+
+	contributors := make([]*entity.EbookContributor, 0, len(req.Contributors))
+	for _, contributor := range req.Contributors {
+		contributors = append(contributors, &entity.EbookContributor{AuthorID: contributor.AuthorID, Role: contributor.Role})
+	}
+
+	contributors, err := h.contributorUsecase.ReplaceContributors(r.Context(), ebookSubresourceID(r), contributors)
+	if err != nil {
+		writeEbookContributorError(w, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, response.ParseEbookContributorResponses(contributors), "Contributors replaced successfully")
+}
+
+func writeEbookContributorError(w http.ResponseWriter, err error) {
+	var validationErr *usecase.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		response.WriteError(w, http.StatusBadRequest, "validation_error", err.Error())
+	case errors.Is(err, usecase.ErrEbookNotFound):
+		response.WriteError(w, http.StatusNotFound, "ebook_not_found", err.Error())
+	default:
+		response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+	}
+}
@@ -0,0 +1,206 @@
+package http
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/delivery/http/middleware"
+	"buku-pintar/internal/delivery/http/response"
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/helper"
+	"buku-pintar/internal/usecase"
+	"errors"
+	"log"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+)
+
+type EbookDownloadHandler struct {
+	downloadUsecase usecase.EbookDownloadUsecase
+	signer          *helper.URLSigner
+	storageDir      string
+}
+
+func NewEbookDownloadHandler(downloadUsecase usecase.EbookDownloadUsecase, signer *helper.URLSigner, storageDir string) *EbookDownloadHandler {
+	return &EbookDownloadHandler{
+		downloadUsecase: downloadUsecase,
+		signer:          signer,
+		storageDir:      storageDir,
+	}
+}
+
+// RequestDownload handles POST /ebooks/{id}/download - Issue a signed, expiring file URL
+// to a user who owns the ebook or has premium access
+func (h *EbookDownloadHandler) RequestDownload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil || user == nil {
+		response.WriteError(w, http.StatusUnauthorized, "unauthorized", "Authentication required")
+		return
+	}
+
+	ebookID := ebookSubresourceID(r)
+	if ebookID == "" {
+		response.WriteError(w, http.StatusBadRequest, "ebook_id_required", constant.EBOOK_ID_REQUIRED)
+		return
+	}
+
+	ebook, err := h.downloadUsecase.AuthorizeDownload(r.Context(), user, ebookID)
+	if err != nil {
+		if errors.Is(err, usecase.ErrEbookAccessDenied) {
+			response.WriteError(w, http.StatusForbidden, "purchase_required", err.Error())
+			return
+		}
+		if errors.Is(err, usecase.ErrEbookFileUnavailable) {
+			response.WriteError(w, http.StatusNotFound, "file_not_found", err.Error())
+			return
+		}
+		response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+		return
+	}
+
+	if ebook == nil {
+		response.WriteError(w, http.StatusNotFound, "ebook_not_found", constant.EBOOK_NOT_FOUND)
+		return
+	}
+
+	query, expiresAt := h.signer.Sign(ebookFileResource(ebook.ID), user.ID, time.Now())
+	response.WriteSuccess(w, http.StatusOK, &response.EbookDownloadResponse{
+		URL:       apiV1Prefix + "/ebooks/" + ebook.ID + "/file?" + query.Encode(),
+		ExpiresAt: expiresAt,
+		Format:    string(ebook.Format),
+		Filesize:  ebook.Filesize,
+	}, "")
+}
+
+// StreamFile handles GET /ebooks/{id}/file - Stream an ebook file to the holder of a valid signed URL
+// Range requests are supported so readers can resume and seek
+func (h *EbookDownloadHandler) StreamFile(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	ebookID := ebookSubresourceID(r)
+	if ebookID == "" {
+		response.WriteError(w, http.StatusBadRequest, "ebook_id_required", constant.EBOOK_ID_REQUIRED)
+		return
+	}
+
+	userID, err := h.signer.Verify(ebookFileResource(ebookID), r.URL.Query(), time.Now())
+	if err != nil {
+		if errors.Is(err, helper.ErrSignatureExpired) {
+			response.WriteError(w, http.StatusForbidden, "download_link_expired", err.Error())
+			return
+		}
+		response.WriteError(w, http.StatusForbidden, "invalid_signature", err.Error())
+		return
+	}
+
+	ebook, err := h.downloadUsecase.GetDownloadFile(r.Context(), userID, ebookID)
+	if err != nil {
+		response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+		return
+	}
+
+	if ebook == nil || ebook.URL == "" {
+		response.WriteError(w, http.StatusNotFound, "ebook_not_found", constant.EBOOK_NOT_FOUND)
+		return
+	}
+
+	// Only the request that starts the transfer counts as a download, not every resumed range
+	if r.Method == http.MethodGet && isInitialRange(r.Header.Get("Range")) {
+		if err := h.downloadUsecase.LogDownload(r.Context(), userID, ebook.ID, helper.ClientIP(r), r.UserAgent()); err != nil {
+			log.Printf("Error logging download of ebook %s by user %s: %v", ebook.ID, userID, err)
+		}
+	}
+
+	err = serveStoredFile(w, r, ebook.URL, h.storageDir, ebookContentType(ebook.Format), ebookFileName(ebook), cacheNoStore)
+	if errors.Is(err, errStoredFileNotFound) {
+		response.WriteError(w, http.StatusNotFound, "file_not_found", constant.ERR_EBOOK_FILE_UNAVAILABLE)
+		return
+	}
+	if err != nil {
+		log.Printf("Error streaming ebook %s: %v", ebook.ID, err)
+	}
+}
+
+// ListDownloads handles GET /users/downloads - List the authenticated user's download history
+func (h *EbookDownloadHandler) ListDownloads(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil || user == nil {
+		response.WriteError(w, http.StatusUnauthorized, "unauthorized", "Authentication required")
+		return
+	}
+
+	limit, offset := helper.HandlePagination(r)
+
+	downloads, err := h.downloadUsecase.ListDownloads(r.Context(), user.ID, limit, offset)
+	if err != nil {
+		response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+		return
+	}
+
+	total, err := h.downloadUsecase.CountDownloads(r.Context(), user.ID)
+	if err != nil {
+		response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+		return
+	}
+
+	res := make([]*response.EbookDownloadHistoryResponse, 0, len(downloads))
+	for _, download := range downloads {
+		res = append(res, response.ParseEbookDownloadHistoryResponse(download))
+	}
+
+	response.WritePaginatedMeta(w, r, res, response.NewOffsetMeta(total, limit, offset))
+}
+
+// ebookSubresourceID extracts the ebook ID from /ebooks/{id}/{resource} paths
+func ebookSubresourceID(r *http.Request) string {
+	pathParts := strings.Split(strings.TrimSuffix(r.URL.Path, "/"), "/")
+	if len(pathParts) < 2 {
+		return ""
+	}
+	return pathParts[len(pathParts)-2]
+}
+
+func ebookFileResource(ebookID string) string {
+	return "ebook:" + ebookID
+}
+
+func isInitialRange(rangeHeader string) bool {
+	return rangeHeader == "" || strings.HasPrefix(rangeHeader, "bytes=0-")
+}
+
+func ebookContentType(format entity.EbookFormat) string {
+	switch format {
+	case entity.FormatPDF:
+		return "application/pdf"
+	case entity.FormatEPUB:
+		return "application/epub+zip"
+	case entity.FormatMOBI:
+		return "application/x-mobipocket-ebook"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+func ebookFileName(ebook *entity.Ebook) string {
+	name := ebook.Slug
+	if name == "" {
+		name = ebook.ID
+	}
+	if ext := path.Ext(ebook.URL); ext != "" && len(ext) <= 5 {
+		return name + ext
+	}
+	return name + "." + string(ebook.Format)
+}
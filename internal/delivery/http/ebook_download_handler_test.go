@@ -0,0 +1,210 @@
+package http
+
+import (
+	"buku-pintar/internal/delivery/http/middleware"
+	"buku-pintar/internal/delivery/http/response"
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/helper"
+	"buku-pintar/internal/usecase"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// MockEbookDownloadUsecase is a mock implementation for testing
+type MockEbookDownloadUsecase struct {
+	ebook     *entity.Ebook
+	err       error
+	downloads []*entity.EbookDownload
+	logged    int
+}
+
+func (m *MockEbookDownloadUsecase) AuthorizeDownload(ctx context.Context, user *entity.User, ebookID string) (*entity.Ebook, error) {
+	return m.ebook, m.err
+}
+
+func (m *MockEbookDownloadUsecase) GetDownloadFile(ctx context.Context, userID, ebookID string) (*entity.Ebook, error) {
+	return m.ebook, nil
+}
+
+func (m *MockEbookDownloadUsecase) LogDownload(ctx context.Context, userID, ebookID, ipAddress, userAgent string) error {
+	m.logged++
+	return nil
+}
+
+func (m *MockEbookDownloadUsecase) ListDownloads(ctx context.Context, userID string, limit, offset int) ([]*entity.EbookDownload, error) {
+	return m.downloads, m.err
+}
+
+func (m *MockEbookDownloadUsecase) CountDownloads(ctx context.Context, userID string) (int64, error) {
+	return int64(len(m.downloads)), m.err
+}
+
+func withTestUser(req *http.Request) *http.Request {
+	user := &entity.User{ID: "user-1", Email: "reader@example.com"}
+	return req.WithContext(context.WithValue(req.Context(), middleware.UserContextKey, user))
+}
+
+func TestEbookDownloadHandler_RequestDownload(t *testing.T) {
+	signer := helper.NewURLSigner("test-secret", 5*time.Minute)
+
+	t.Run("should require authentication", func(t *testing.T) {
+		handler := NewEbookDownloadHandler(&MockEbookDownloadUsecase{}, signer, t.TempDir())
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/ebooks/ebook-1/download", nil)
+		rr := httptest.NewRecorder()
+
+		handler.RequestDownload(rr, req)
+
+		if rr.Code != http.StatusUnauthorized {
+			t.Errorf("expected status %d, got %d", http.StatusUnauthorized, rr.Code)
+		}
+	})
+
+	t.Run("should forbid users without entitlement", func(t *testing.T) {
+		handler := NewEbookDownloadHandler(&MockEbookDownloadUsecase{err: usecase.ErrEbookAccessDenied}, signer, t.TempDir())
+		req := withTestUser(httptest.NewRequest(http.MethodPost, "/api/v1/ebooks/ebook-1/download", nil))
+		rr := httptest.NewRecorder()
+
+		handler.RequestDownload(rr, req)
+
+		if rr.Code != http.StatusForbidden {
+			t.Errorf("expected status %d, got %d", http.StatusForbidden, rr.Code)
+		}
+	})
+
+	t.Run("should return 404 when the ebook has no file", func(t *testing.T) {
+		handler := NewEbookDownloadHandler(&MockEbookDownloadUsecase{err: usecase.ErrEbookFileUnavailable}, signer, t.TempDir())
+		req := withTestUser(httptest.NewRequest(http.MethodPost, "/api/v1/ebooks/ebook-1/download", nil))
+		rr := httptest.NewRecorder()
+
+		handler.RequestDownload(rr, req)
+
+		if rr.Code != http.StatusNotFound {
+			t.Errorf("expected status %d, got %d", http.StatusNotFound, rr.Code)
+		}
+	})
+
+	t.Run("should return 404 when ebook not found", func(t *testing.T) {
+		handler := NewEbookDownloadHandler(&MockEbookDownloadUsecase{}, signer, t.TempDir())
+		req := withTestUser(httptest.NewRequest(http.MethodPost, "/api/v1/ebooks/ebook-1/download", nil))
+		rr := httptest.NewRecorder()
+
+		handler.RequestDownload(rr, req)
+
+		if rr.Code != http.StatusNotFound {
+			t.Errorf("expected status %d, got %d", http.StatusNotFound, rr.Code)
+		}
+	})
+
+	t.Run("should issue a signed URL that streams the file", func(t *testing.T) {
+		storageDir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(storageDir, "book.pdf"), []byte("%PDF-1.4 test content"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		mockUsecase := &MockEbookDownloadUsecase{
+			ebook: &entity.Ebook{ID: "ebook-1", Slug: "test-ebook", Format: entity.FormatPDF, URL: "book.pdf"},
+		}
+		handler := NewEbookDownloadHandler(mockUsecase, signer, storageDir)
+		req := withTestUser(httptest.NewRequest(http.MethodPost, "/api/v1/ebooks/ebook-1/download", nil))
+		rr := httptest.NewRecorder()
+
+		handler.RequestDownload(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, rr.Code)
+		}
+
+		var resp struct {
+			Data response.EbookDownloadResponse `json:"data"`
+		}
+		if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(resp.Data.URL, "book.pdf") {
+			t.Errorf("expected signed URL to hide the file location, got %s", resp.Data.URL)
+		}
+
+		// Ranged request against the signed URL
+		fileReq := httptest.NewRequest(http.MethodGet, resp.Data.URL, nil)
+		fileReq.Header.Set("Range", "bytes=0-3")
+		fileRR := httptest.NewRecorder()
+		handler.StreamFile(fileRR, fileReq)
+
+		if fileRR.Code != http.StatusPartialContent {
+			t.Fatalf("expected status %d, got %d", http.StatusPartialContent, fileRR.Code)
+		}
+		if body, _ := io.ReadAll(fileRR.Body); string(body) != "%PDF" {
+			t.Errorf("expected ranged body %q, got %q", "%PDF", body)
+		}
+		if mockUsecase.logged != 1 {
+			t.Errorf("expected download to be logged once, got %d", mockUsecase.logged)
+		}
+
+		// Resuming the transfer does not count as another download
+		fileReq.Header.Set("Range", "bytes=4-")
+		handler.StreamFile(httptest.NewRecorder(), fileReq)
+		if mockUsecase.logged != 1 {
+			t.Errorf("expected resumed range not to be logged, got %d downloads", mockUsecase.logged)
+		}
+	})
+}
+
+func TestEbookDownloadHandler_StreamFile_InvalidSignature(t *testing.T) {
+	signer := helper.NewURLSigner("test-secret", 5*time.Minute)
+	mockUsecase := &MockEbookDownloadUsecase{
+		ebook: &entity.Ebook{ID: "ebook-1", Format: entity.FormatPDF, URL: "book.pdf"},
+	}
+	handler := NewEbookDownloadHandler(mockUsecase, signer, t.TempDir())
+
+	valid, _ := signer.Sign(ebookFileResource("ebook-1"), "user-1", time.Now())
+	expired, _ := signer.Sign(ebookFileResource("ebook-1"), "user-1", time.Now().Add(-time.Hour))
+	otherEbook, _ := signer.Sign(ebookFileResource("ebook-2"), "user-1", time.Now())
+	tampered := url.Values{}
+	for key, values := range valid {
+		tampered[key] = values
+	}
+	tampered.Set("uid", "user-2")
+
+	tests := []struct {
+		name     string
+		query    url.Values
+		wantCode string
+	}{
+		{name: "missing signature", query: url.Values{}, wantCode: "invalid_signature"},
+		{name: "tampered user", query: tampered, wantCode: "invalid_signature"},
+		{name: "signed for another ebook", query: otherEbook, wantCode: "invalid_signature"},
+		{name: "expired link", query: expired, wantCode: "download_link_expired"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/ebooks/ebook-1/file?"+tt.query.Encode(), nil)
+			rr := httptest.NewRecorder()
+
+			handler.StreamFile(rr, req)
+
+			if rr.Code != http.StatusForbidden {
+				t.Errorf("expected status %d, got %d", http.StatusForbidden, rr.Code)
+			}
+			var resp response.Response
+			if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+				t.Fatal(err)
+			}
+			if resp.Error == nil || resp.Error.Code != tt.wantCode {
+				t.Errorf("expected error code %s, got %+v", tt.wantCode, resp.Error)
+			}
+			if mockUsecase.logged != 0 {
+				t.Errorf("expected no download to be logged")
+			}
+		})
+	}
+}
@@ -4,43 +4,97 @@ import (
 	"buku-pintar/internal/domain/entity"
 	"buku-pintar/internal/usecase"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strings"
 
 	"buku-pintar/internal/constant"
+	"buku-pintar/internal/delivery/http/middleware"
 	"buku-pintar/internal/delivery/http/response"
 	"buku-pintar/internal/helper"
 )
 
 type EbookHandler struct {
 	ebookUsecase usecase.EbookUsecase
+	cursorCodec  *helper.CursorCodec
 }
 
-func NewEbookHandler(ebookUsecase usecase.EbookUsecase) *EbookHandler {
+func NewEbookHandler(ebookUsecase usecase.EbookUsecase, cursorCodec *helper.CursorCodec) *EbookHandler {
 	return &EbookHandler{
 		ebookUsecase: ebookUsecase,
+		cursorCodec:  cursorCodec,
 	}
 }
 
+// ListEbooks handles GET /ebooks - List published ebooks with faceted filters, sorting and facet counts
+// Passing a cursor parameter switches from limit/offset to keyset pagination
 func (h *EbookHandler) ListEbooks(w http.ResponseWriter, r *http.Request) {
 	limit, offset := helper.HandlePagination(r)
 
-	// Get ebooks from usecase
-	ebooks, err := h.ebookUsecase.ListEbooks(r.Context(), limit, offset)
+	filter, err := helper.HandleEbookFilter(r)
 	if err != nil {
-		response.WriteError(w, http.StatusInternalServerError, "internal_server_error", err.Error())
+		response.WriteError(w, http.StatusBadRequest, "invalid_filter", err.Error())
 		return
 	}
 
+	// Get ebooks from usecase
+	var ebooks []*response.EbookListResponse
+	var page *entity.CursorPageInfo
+	cursorMode := helper.IsCursorPagination(r)
+	if cursorMode {
+		cursor, err := h.cursorCodec.Decode(r.URL.Query().Get("cursor"))
+		if err != nil {
+			response.WriteError(w, http.StatusBadRequest, "invalid_cursor", err.Error())
+			return
+		}
+		ebooks, page, err = h.ebookUsecase.ListEbooksByCursor(r.Context(), filter, cursor, limit)
+		if usecase.IsCursorError(err) {
+			response.WriteError(w, http.StatusBadRequest, "invalid_cursor", err.Error())
+			return
+		}
+		if err != nil {
+			writeEbookListError(w, err)
+			return
+		}
+	} else {
+		ebooks, err = h.ebookUsecase.ListEbooks(r.Context(), filter, limit, offset)
+		if err != nil {
+			writeEbookListError(w, err)
+			return
+		}
+	}
+
 	// Get total count for pagination
-	total, err := h.ebookUsecase.CountEbooks(r.Context())
+	total, err := h.ebookUsecase.CountEbooks(r.Context(), filter)
 	if err != nil {
-		response.WriteError(w, http.StatusInternalServerError, "internal_server_error", err.Error())
+		writeEbookListError(w, err)
+		return
+	}
+
+	// Get facet counts for the same filter set
+	facets, err := h.ebookUsecase.GetEbookFacets(r.Context(), filter)
+	if err != nil {
+		writeEbookListError(w, err)
 		return
 	}
 
 	// Return response
-	response.WritePaginated(w, ebooks, total, limit, offset)
+	meta := response.NewOffsetMeta(total, limit, offset)
+	if cursorMode {
+		meta = response.NewCursorMeta(total, limit, h.cursorCodec.Encode(page.Next), h.cursorCodec.Encode(page.Prev))
+	}
+	meta.Facets = facets
+	response.WritePaginatedMeta(w, r, ebooks, meta)
+}
+
+// writeEbookListError maps usecase validation errors to 400 and everything else to 500
+func writeEbookListError(w http.ResponseWriter, err error) {
+	var validationErr *usecase.ValidationError
+	if errors.As(err, &validationErr) {
+		response.WriteError(w, http.StatusBadRequest, "invalid_filter", err.Error())
+		return
+	}
+	response.WriteError(w, http.StatusInternalServerError, "internal_server_error", err.Error())
 }
 
 // GetEbookByID handles the HTTP GET request to retrieve an ebook by its ID
@@ -70,8 +124,13 @@ func (h *EbookHandler) GetEbookByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// The file location is only handed out through signed download URLs
+	public := *ebook
+	public.URL = ""
+	public.FileMediaKey = nil
+
 	// Return response
-	response.WriteSuccess(w, http.StatusOK, ebook, "")
+	response.WriteSuccess(w, http.StatusOK, public, "")
 }
 
 func (h *EbookHandler) GetEbookBySlug(w http.ResponseWriter, r *http.Request) {
@@ -89,7 +148,7 @@ func (h *EbookHandler) GetEbookBySlug(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get ebook from usecase
-	ebook, err := h.ebookUsecase.GetEbookBySlug(r.Context(), slug)
+	ebook, err := h.ebookUsecase.GetEbookBySlug(r.Context(), slug, viewerKey(r))
 	if err != nil {
 		response.WriteError(w, http.StatusInternalServerError, "internal_server_error", err.Error())
 		return
@@ -104,6 +163,14 @@ func (h *EbookHandler) GetEbookBySlug(w http.ResponseWriter, r *http.Request) {
 	response.WriteSuccess(w, http.StatusOK, ebook, "")
 }
 
+// viewerKey identifies who is viewing an ebook: the signed-in user, otherwise the client address
+func viewerKey(r *http.Request) string {
+	if user, _ := middleware.GetUserFromContext(r.Context()); user != nil {
+		return user.ID
+	}
+	return "ip:" + helper.ClientIP(r)
+}
+
 func (h *EbookHandler) CreateEbook(w http.ResponseWriter, r *http.Request) {
 	// Parse request body
 	var ebook entity.Ebook
@@ -4,12 +4,14 @@ import (
 	"buku-pintar/internal/constant"
 	"buku-pintar/internal/delivery/http/response"
 	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/helper"
 	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -21,6 +23,7 @@ type MockEbookUsecase struct {
 	ebooks []*entity.Ebook
 	ebook  *entity.Ebook
 	ebookDetail *entity.EbookDetail
+	page   *entity.CursorPageInfo
 	err    error
 }
 
@@ -32,7 +35,7 @@ func (m *MockEbookUsecase) GetEbookByID(ctx context.Context, id string) (*entity
 	return m.ebook, m.err
 }
 
-func (m *MockEbookUsecase) GetEbookBySlug(ctx context.Context, slug string) (*response.EbookResponse, error) {
+func (m *MockEbookUsecase) GetEbookBySlug(ctx context.Context, slug, viewerKey string) (*response.EbookResponse, error) {
 	if m.err != nil {
 		return nil, m.err
 	}
@@ -50,7 +53,7 @@ func (m *MockEbookUsecase) DeleteEbook(ctx context.Context, id string) error {
 	return m.err
 }
 
-func (m *MockEbookUsecase) ListEbooks(ctx context.Context, limit, offset int) ([]*response.EbookListResponse, error) {
+func (m *MockEbookUsecase) ListEbooks(ctx context.Context, filter *entity.EbookFilter, limit, offset int) ([]*response.EbookListResponse, error) {
 	// Convert entity.Ebook to response.EbookListResponse
 	ebookResponses := make([]*response.EbookListResponse, 0, len(m.ebooks))
 	for _, ebook := range m.ebooks {
@@ -67,6 +70,14 @@ func (m *MockEbookUsecase) ListEbooks(ctx context.Context, limit, offset int) ([
 	return ebookResponses, m.err
 }
 
+func (m *MockEbookUsecase) ListEbooksByCursor(ctx context.Context, filter *entity.EbookFilter, cursor *entity.PageCursor, limit int) ([]*response.EbookListResponse, *entity.CursorPageInfo, error) {
+	ebooks, err := m.ListEbooks(ctx, filter, limit, 0)
+	if m.page == nil {
+		return ebooks, &entity.CursorPageInfo{}, err
+	}
+	return ebooks, m.page, err
+}
+
 func (m *MockEbookUsecase) ListEbooksByCategory(ctx context.Context, categoryID string, limit, offset int) ([]*entity.Ebook, error) {
 	return m.ebooks, m.err
 }
@@ -75,10 +86,17 @@ func (m *MockEbookUsecase) ListEbooksByAuthor(ctx context.Context, authorID stri
 	return m.ebooks, m.err
 }
 
-func (m *MockEbookUsecase) CountEbooks(ctx context.Context) (int64, error) {
+func (m *MockEbookUsecase) CountEbooks(ctx context.Context, filter *entity.EbookFilter) (int64, error) {
 	return int64(len(m.ebooks)), m.err
 }
 
+func (m *MockEbookUsecase) GetEbookFacets(ctx context.Context, filter *entity.EbookFilter) (*response.EbookFacetsResponse, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &response.EbookFacetsResponse{}, nil
+}
+
 func (m *MockEbookUsecase) CountEbooksByCategory(ctx context.Context, categoryID string) (int64, error) {
 	return int64(len(m.ebooks)), m.err
 }
@@ -148,7 +166,7 @@ func TestEbookHandler_ListEbooks(t *testing.T) {
 				ebooks: tt.mockEbooks,
 				err:    tt.mockError,
 			}
-			handler := NewEbookHandler(mockUsecase)
+			handler := NewEbookHandler(mockUsecase, helper.NewCursorCodec("test-secret"))
 
 			// Create request
 			req, err := http.NewRequest("GET", "/ebooks", nil)
@@ -206,6 +224,110 @@ func TestEbookHandler_ListEbooks(t *testing.T) {
 	}
 }
 
+func TestEbookHandler_ListEbooks_InvalidFilter(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{name: "non numeric min price", query: "?min_price=abc"},
+		{name: "invalid boolean", query: "?free=maybe"},
+		{name: "invalid date", query: "?published_from=yesterday"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewEbookHandler(&MockEbookUsecase{}, helper.NewCursorCodec("test-secret"))
+
+			req, err := http.NewRequest("GET", "/ebooks"+tt.query, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rr := httptest.NewRecorder()
+
+			handler.ListEbooks(rr, req)
+
+			if rr.Code != http.StatusBadRequest {
+				t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+			}
+		})
+	}
+}
+
+func TestEbookHandler_ListEbooks_Pagination(t *testing.T) {
+	codec := helper.NewCursorCodec("test-secret")
+	mockEbooks := []*entity.Ebook{
+		{ID: uuid.New().String(), Title: "Ebook 1", Slug: "ebook-1"},
+		{ID: uuid.New().String(), Title: "Ebook 2", Slug: "ebook-2"},
+		{ID: uuid.New().String(), Title: "Ebook 3", Slug: "ebook-3"},
+	}
+
+	t.Run("offset mode fills page numbers and links", func(t *testing.T) {
+		handler := NewEbookHandler(&MockEbookUsecase{ebooks: mockEbooks}, codec)
+		req := httptest.NewRequest("GET", "/api/v1/ebooks?limit=1&offset=1", nil)
+		rr := httptest.NewRecorder()
+
+		handler.ListEbooks(rr, req)
+
+		var resp response.Response
+		if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		}
+		if resp.Meta.CurrentPage != 2 || resp.Meta.TotalPages != 3 {
+			t.Errorf("expected page 2 of 3, got %d of %d", resp.Meta.CurrentPage, resp.Meta.TotalPages)
+		}
+		link := rr.Header().Get("Link")
+		for _, rel := range []string{`rel="first"`, `rel="prev"`, `rel="next"`, `rel="last"`} {
+			if !strings.Contains(link, rel) {
+				t.Errorf("expected %s in Link header, got %q", rel, link)
+			}
+		}
+	})
+
+	t.Run("cursor mode returns signed cursors", func(t *testing.T) {
+		next := &entity.PageCursor{Sort: entity.EbookCursorSort(entity.EbookSortNewest), Value: "2024-01-01T00:00:00Z", ID: "ebook-3"}
+		handler := NewEbookHandler(&MockEbookUsecase{ebooks: mockEbooks, page: &entity.CursorPageInfo{Next: next}}, codec)
+		req := httptest.NewRequest("GET", "/api/v1/ebooks?cursor=&limit=3", nil)
+		rr := httptest.NewRecorder()
+
+		handler.ListEbooks(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, rr.Code)
+		}
+		var resp response.Response
+		if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		}
+		if resp.Meta.PrevCursor != "" {
+			t.Errorf("expected no prev cursor, got %q", resp.Meta.PrevCursor)
+		}
+		decoded, err := codec.Decode(resp.Meta.NextCursor)
+		if err != nil || decoded == nil || decoded.ID != "ebook-3" {
+			t.Errorf("expected next cursor to decode to ebook-3, got %+v (%v)", decoded, err)
+		}
+		if link := rr.Header().Get("Link"); !strings.Contains(link, `rel="next"`) || strings.Contains(link, `rel="last"`) {
+			t.Errorf("unexpected Link header %q", link)
+		}
+	})
+
+	t.Run("tampered cursor is rejected", func(t *testing.T) {
+		token := codec.Encode(&entity.PageCursor{Sort: entity.EbookCursorSort(entity.EbookSortNewest), Value: "2024-01-01T00:00:00Z", ID: "ebook-3"})
+		forged := helper.NewCursorCodec("other-secret").Encode(&entity.PageCursor{Sort: entity.EbookCursorSort(entity.EbookSortNewest), Value: "2024-01-01T00:00:00Z", ID: "ebook-9"})
+		handler := NewEbookHandler(&MockEbookUsecase{ebooks: mockEbooks}, codec)
+
+		for _, cursor := range []string{token[:len(token)-2], forged, "not-a-cursor"} {
+			req := httptest.NewRequest("GET", "/api/v1/ebooks?cursor="+cursor, nil)
+			rr := httptest.NewRecorder()
+
+			handler.ListEbooks(rr, req)
+
+			if rr.Code != http.StatusBadRequest {
+				t.Errorf("expected status %d for cursor %q, got %d", http.StatusBadRequest, cursor, rr.Code)
+			}
+		}
+	})
+}
+
 func TestEbookHandler_GetEbookByID(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -258,7 +380,7 @@ func TestEbookHandler_GetEbookByID(t *testing.T) {
 				ebook: tt.mockEbook,
 				err:   tt.mockError,
 			}
-			handler := NewEbookHandler(mockUsecase)
+			handler := NewEbookHandler(mockUsecase, helper.NewCursorCodec("test-secret"))
 
 			// Create request
 			req, err := http.NewRequest("GET", "/ebooks/"+tt.ebookID, nil)
@@ -371,7 +493,7 @@ func TestEbookHandler_CreateEbook(t *testing.T) {
 			mockUsecase := &MockEbookUsecase{
 				err: tt.mockError,
 			}
-			handler := NewEbookHandler(mockUsecase)
+			handler := NewEbookHandler(mockUsecase, helper.NewCursorCodec("test-secret"))
 
 			// Create request body
 			body, err := json.Marshal(tt.ebook)
@@ -490,7 +612,7 @@ func TestEbookHandler_GetEbookBySlug(t *testing.T) {
 				ebookDetail: tt.mockEbook,
 				err:        tt.mockError,
 			}
-			handler := NewEbookHandler(mockUsecase)
+			handler := NewEbookHandler(mockUsecase, helper.NewCursorCodec("test-secret"))
 
 			// Create request
 			req, err := http.NewRequest("GET", "/ebooks/slug/"+tt.slug, nil)
@@ -607,7 +729,7 @@ func TestEbookHandler_UpdateEbook(t *testing.T) {
 			mockUsecase := &MockEbookUsecase{
 				err: tt.mockError,
 			}
-			handler := NewEbookHandler(mockUsecase)
+			handler := NewEbookHandler(mockUsecase, helper.NewCursorCodec("test-secret"))
 
 			// Create request body
 			body, err := json.Marshal(tt.ebook)
@@ -705,7 +827,7 @@ func TestEbookHandler_DeleteEbook(t *testing.T) {
 			mockUsecase := &MockEbookUsecase{
 				err: tt.mockError,
 			}
-			handler := NewEbookHandler(mockUsecase)
+			handler := NewEbookHandler(mockUsecase, helper.NewCursorCodec("test-secret"))
 
 			// Create request
 			req, err := http.NewRequest("DELETE", "/ebooks/"+tt.ebookID, nil)
@@ -818,7 +940,7 @@ func TestEbookHandler_ListEbooksByCategory(t *testing.T) {
 				ebooks: tt.mockEbooks,
 				err:    tt.mockError,
 			}
-			handler := NewEbookHandler(mockUsecase)
+			handler := NewEbookHandler(mockUsecase, helper.NewCursorCodec("test-secret"))
 
 			// Create request
 			req, err := http.NewRequest("GET", "/ebooks/category/"+tt.categoryID, nil)
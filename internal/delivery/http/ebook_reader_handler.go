@@ -0,0 +1,75 @@
+package http
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/delivery/http/middleware"
+	"buku-pintar/internal/delivery/http/response"
+	"buku-pintar/internal/usecase"
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+type EbookReaderHandler struct {
+	readerUsecase usecase.EbookReaderUsecase
+}
+
+func NewEbookReaderHandler(readerUsecase usecase.EbookReaderUsecase) *EbookReaderHandler {
+	return &EbookReaderHandler{
+		readerUsecase: readerUsecase,
+	}
+}
+
+// ReadPage handles GET /ebooks/{id}/read?page=N - Serve one page of an ebook
+// Anyone can read up to preview_page; owners and premium users can read the whole book
+func (h *EbookReaderHandler) ReadPage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	ebookID := ebookSubresourceID(r)
+	if ebookID == "" {
+		response.WriteError(w, http.StatusBadRequest, "ebook_id_required", constant.EBOOK_ID_REQUIRED)
+		return
+	}
+
+	pageNumber := 1
+	if pageStr := r.URL.Query().Get("page"); pageStr != "" {
+		parsed, err := strconv.Atoi(pageStr)
+		if err != nil {
+			response.WriteError(w, http.StatusBadRequest, "invalid_page", constant.ERR_PAGE_NUMBER_INVALID)
+			return
+		}
+		pageNumber = parsed
+	}
+
+	// Anonymous readers get the preview, so a missing user is not an error here
+	user, _ := middleware.GetUserFromContext(r.Context())
+
+	page, err := h.readerUsecase.ReadPage(r.Context(), user, ebookID, pageNumber)
+	if err != nil {
+		var purchaseErr *usecase.PurchaseRequiredError
+		var validationErr *usecase.ValidationError
+		switch {
+		case errors.As(err, &purchaseErr):
+			response.WriteErrorWithDetails(w, http.StatusPaymentRequired, "purchase_required", err.Error(), purchaseErr.Pricing)
+		case errors.Is(err, usecase.ErrLoginRequiredToRead):
+			response.WriteError(w, http.StatusUnauthorized, "login_required", err.Error())
+		case errors.Is(err, usecase.ErrEbookPageNotFound):
+			response.WriteError(w, http.StatusNotFound, "page_not_found", err.Error())
+		case errors.As(err, &validationErr):
+			response.WriteError(w, http.StatusBadRequest, "invalid_page", err.Error())
+		default:
+			response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+		}
+		return
+	}
+
+	if page == nil {
+		response.WriteError(w, http.StatusNotFound, "ebook_not_found", constant.EBOOK_NOT_FOUND)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, page, "")
+}
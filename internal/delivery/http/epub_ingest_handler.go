@@ -0,0 +1,111 @@
+package http
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/delivery/http/response"
+	"buku-pintar/internal/usecase"
+	"errors"
+	"mime/multipart"
+	"net/http"
+)
+
+const (
+	// maxEpubUploadSize bounds the whole multipart request
+	maxEpubUploadSize = 200 << 20
+	// epubUploadMemory is how much of the upload is kept in memory before spilling to a temporary file
+	epubUploadMemory = 32 << 20
+)
+
+type EpubIngestHandler struct {
+	epubIngestUsecase usecase.EpubIngestUsecase
+}
+
+func NewEpubIngestHandler(epubIngestUsecase usecase.EpubIngestUsecase) *EpubIngestHandler {
+	return &EpubIngestHandler{
+		epubIngestUsecase: epubIngestUsecase,
+	}
+}
+
+// IngestEpub handles POST /ebooks/epub - Read an uploaded EPUB into an ebook draft with its table of contents
+func (h *EpubIngestHandler) IngestEpub(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	file, header, ok := readEpubUpload(w, r)
+	if !ok {
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+	defer file.Close()
+
+	draft, err := h.epubIngestUsecase.IngestEpub(r.Context(), file, header.Size)
+	if err != nil {
+		writeEpubIngestError(w, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, response.ParseEbookDraftResponse(draft), "EPUB read successfully")
+}
+
+// ImportPages handles PUT /ebooks/{id}/pages - Replace the reader pages of an ebook with the spine items of an uploaded EPUB
+func (h *EpubIngestHandler) ImportPages(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	file, header, ok := readEpubUpload(w, r)
+	if !ok {
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+	defer file.Close()
+
+	pages, err := h.epubIngestUsecase.ImportPages(r.Context(), r.PathValue("id"), file, header.Size)
+	if err != nil {
+		writeEpubIngestError(w, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, response.ParseEbookPagesImportResponse(r.PathValue("id"), pages), "pages imported successfully")
+}
+
+// readEpubUpload parses the multipart upload and returns the EPUB in its file field.
+// On failure the error response has been written and ok is false.
+func readEpubUpload(w http.ResponseWriter, r *http.Request) (multipart.File, *multipart.FileHeader, bool) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxEpubUploadSize)
+	if err := r.ParseMultipartForm(epubUploadMemory); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			response.WriteError(w, http.StatusRequestEntityTooLarge, "epub_too_large", constant.ERR_EPUB_TOO_LARGE)
+			return nil, nil, false
+		}
+		response.WriteError(w, http.StatusBadRequest, "invalid_request_body", err.Error())
+		return nil, nil, false
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		r.MultipartForm.RemoveAll()
+		response.WriteError(w, http.StatusBadRequest, "epub_file_required", constant.ERR_EPUB_FILE_REQUIRED)
+		return nil, nil, false
+	}
+	return file, header, true
+}
+
+func writeEpubIngestError(w http.ResponseWriter, err error) {
+	var invalidErr *usecase.InvalidEpubError
+	var validationErr *usecase.ValidationError
+	switch {
+	case errors.As(err, &invalidErr):
+		response.WriteErrorWithDetails(w, http.StatusUnprocessableEntity, "invalid_epub", err.Error(), invalidErr.Problems)
+	case errors.As(err, &validationErr):
+		response.WriteError(w, http.StatusBadRequest, "validation_error", err.Error())
+	case errors.Is(err, usecase.ErrEbookNotFound):
+		response.WriteError(w, http.StatusNotFound, "ebook_not_found", err.Error())
+	default:
+		response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+	}
+}
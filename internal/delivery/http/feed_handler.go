@@ -0,0 +1,157 @@
+package http
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/delivery/http/response"
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/usecase"
+	"buku-pintar/pkg/feed"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+const feedIDPrefix = "urn:buku-pintar"
+
+// feedTitles names the feed of each kind of content
+var feedTitles = map[entity.FeedContent]string{
+	entity.FeedArticles:     "Articles",
+	entity.FeedInspirations: "Inspirations",
+	entity.FeedEbooks:       "New Ebooks",
+}
+
+type FeedHandler struct {
+	feedUsecase usecase.FeedUsecase
+	title       string
+	siteURL     string
+}
+
+// NewFeedHandler creates the RSS and Atom feed handler. Items link to their storefront page,
+// {siteURL}/{content}/{slug}.
+func NewFeedHandler(feedUsecase usecase.FeedUsecase, title, siteURL string) *FeedHandler {
+	return &FeedHandler{
+		feedUsecase: feedUsecase,
+		title:       title,
+		siteURL:     strings.TrimRight(siteURL, "/"),
+	}
+}
+
+// GetFeed handles GET /feeds/{content}/{format} - The newest articles, inspirations or ebooks as RSS or Atom
+func (h *FeedHandler) GetFeed(w http.ResponseWriter, r *http.Request) {
+	h.serveFeed(w, r, &entity.FeedFilter{})
+}
+
+// GetCategoryFeed handles GET /feeds/{content}/categories/{id}/{format} - The newest content of a category
+func (h *FeedHandler) GetCategoryFeed(w http.ResponseWriter, r *http.Request) {
+	h.serveFeed(w, r, &entity.FeedFilter{CategoryID: r.PathValue("id")})
+}
+
+// GetAuthorFeed handles GET /feeds/{content}/authors/{id}/{format} - The newest content of an author
+func (h *FeedHandler) GetAuthorFeed(w http.ResponseWriter, r *http.Request) {
+	h.serveFeed(w, r, &entity.FeedFilter{AuthorID: r.PathValue("id")})
+}
+
+// serveFeed writes the feed in the requested format. The ETag is a digest of the document and
+// Last-Modified the latest change of its items, so that readers polling with If-None-Match or
+// If-Modified-Since get 304 Not Modified until something is published or edited.
+func (h *FeedHandler) serveFeed(w http.ResponseWriter, r *http.Request, filter *entity.FeedFilter) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	var encode func(*feed.Feed) ([]byte, error)
+	var mediaType string
+	switch r.PathValue("format") {
+	case "rss":
+		encode, mediaType = feed.RSS, feed.MediaTypeRSS
+	case "atom":
+		encode, mediaType = feed.Atom, feed.MediaTypeAtom
+	default:
+		response.WriteError(w, http.StatusNotFound, "feed_not_found", constant.ERR_FEED_NOT_FOUND)
+		return
+	}
+
+	content, err := h.feedUsecase.GetFeed(r.Context(), entity.FeedContent(r.PathValue("content")), filter)
+	if err != nil {
+		writeFeedError(w, err)
+		return
+	}
+
+	document, err := encode(h.buildFeed(r, content))
+	if err != nil {
+		response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+		return
+	}
+
+	digest := sha256.Sum256(document)
+	w.Header().Set("Content-Type", mediaType+"; charset=utf-8")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.Header().Set("ETag", `"`+hex.EncodeToString(digest[:16])+`"`)
+	http.ServeContent(w, r, "", content.Updated(), bytes.NewReader(document))
+}
+
+func (h *FeedHandler) buildFeed(r *http.Request, content *entity.Feed) *feed.Feed {
+	title := h.title + " - " + feedTitles[content.Content]
+	link := h.siteURL + "/" + string(content.Content)
+	id := feedIDPrefix + ":feed:" + string(content.Content)
+	switch {
+	case content.Category != nil:
+		title += " in " + content.Category.Name
+		link = h.siteURL + "/categories/" + content.Category.Slug
+		id += ":category:" + content.Category.ID
+	case content.Author != nil:
+		title += " by " + content.Author.Name
+		id += ":author:" + content.Author.ID
+	}
+
+	doc := &feed.Feed{
+		ID:          id,
+		Title:       title,
+		Description: title,
+		Link:        link,
+		SelfURL:     requestURL(r),
+		Updated:     content.Updated(),
+		Items:       make([]feed.Item, 0, len(content.Items)),
+	}
+	for _, item := range content.Items {
+		doc.Items = append(doc.Items, feed.Item{
+			ID:        feedIDPrefix + ":" + string(content.Content) + ":" + item.ID,
+			Title:     item.Title,
+			Link:      h.siteURL + "/" + string(content.Content) + "/" + item.Slug,
+			Summary:   item.Excerpt,
+			Author:    item.AuthorName,
+			Category:  item.CategoryName,
+			Published: item.PublishedAt,
+			Updated:   item.UpdatedAt,
+			Enclosure: feed.NewImageEnclosure(item.CoverImage),
+		})
+	}
+
+	return doc
+}
+
+// requestURL rebuilds the absolute URL a request was made to, honouring a TLS-terminating proxy
+func requestURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host + r.URL.RequestURI()
+}
+
+func writeFeedError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrFeedNotFound):
+		response.WriteError(w, http.StatusNotFound, "feed_not_found", err.Error())
+	case errors.Is(err, usecase.ErrFeedCategoryNotFound):
+		response.WriteError(w, http.StatusNotFound, "category_not_found", err.Error())
+	case errors.Is(err, usecase.ErrFeedAuthorNotFound):
+		response.WriteError(w, http.StatusNotFound, "author_not_found", err.Error())
+	default:
+		response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+	}
+}
@@ -0,0 +1,100 @@
+package http
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/usecase"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// MockFeedUsecase serves a fixed feed of any content; unknown content is not found
+type MockFeedUsecase struct {
+	items  []*entity.FeedItem
+	filter *entity.FeedFilter
+}
+
+func (m *MockFeedUsecase) GetFeed(ctx context.Context, content entity.FeedContent, filter *entity.FeedFilter) (*entity.Feed, error) {
+	if !content.IsValid() {
+		return nil, usecase.ErrFeedNotFound
+	}
+	m.filter = filter
+	return &entity.Feed{Content: content, Items: m.items}, nil
+}
+
+func TestFeedHandler_GetFeed(t *testing.T) {
+	updated := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	mock := &MockFeedUsecase{items: []*entity.FeedItem{
+		{ID: "article-1", Title: "Membaca", Slug: "membaca", Excerpt: "Kebiasaan membaca", AuthorName: "Andrea Hirata",
+			CoverImage: "https://cdn.app.com/membaca.jpg", PublishedAt: updated, UpdatedAt: updated},
+	}}
+	handler := NewFeedHandler(mock, "Buku Pintar", "https://app.com/")
+
+	serve := func(path string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		for key, values := range header {
+			req.Header[key] = values
+		}
+		rr := httptest.NewRecorder()
+		mux := http.NewServeMux()
+		mux.HandleFunc("/api/v1/feeds/{content}/{format}", handler.GetFeed)
+		mux.HandleFunc("/api/v1/feeds/{content}/authors/{id}/{format}", handler.GetAuthorFeed)
+		mux.ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("should write the feed in the requested format", func(t *testing.T) {
+		rr := serve("/api/v1/feeds/articles/rss", nil)
+		if rr.Code != http.StatusOK || rr.Header().Get("Content-Type") != "application/rss+xml; charset=utf-8" {
+			t.Fatalf("unexpected response %d %q", rr.Code, rr.Header().Get("Content-Type"))
+		}
+		for _, want := range []string{
+			"<link>https://app.com/articles/membaca</link>",
+			"<description>Kebiasaan membaca</description>",
+			"<dc:creator>Andrea Hirata</dc:creator>",
+			`<enclosure url="https://cdn.app.com/membaca.jpg" length="0" type="image/jpeg"></enclosure>`,
+		} {
+			if !strings.Contains(rr.Body.String(), want) {
+				t.Errorf("expected %q in\n%s", want, rr.Body.String())
+			}
+		}
+
+		rr = serve("/api/v1/feeds/articles/atom", nil)
+		if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), "<title>Buku Pintar - Articles</title>") {
+			t.Errorf("expected an Atom feed, got %d\n%s", rr.Code, rr.Body.String())
+		}
+	})
+
+	t.Run("should answer conditional requests for an unchanged feed with 304", func(t *testing.T) {
+		rr := serve("/api/v1/feeds/articles/rss", nil)
+		etag := rr.Header().Get("ETag")
+		if etag == "" || rr.Header().Get("Last-Modified") != "Thu, 01 Oct 2026 08:00:00 GMT" {
+			t.Fatalf("expected validators, got %q %q", etag, rr.Header().Get("Last-Modified"))
+		}
+
+		if rr := serve("/api/v1/feeds/articles/rss", http.Header{"If-None-Match": {etag}}); rr.Code != http.StatusNotModified {
+			t.Errorf("expected 304 for a matching ETag, got %d", rr.Code)
+		}
+		if rr := serve("/api/v1/feeds/articles/rss", http.Header{"If-Modified-Since": {"Thu, 01 Oct 2026 08:00:00 GMT"}}); rr.Code != http.StatusNotModified {
+			t.Errorf("expected 304 when not modified since, got %d", rr.Code)
+		}
+		if rr := serve("/api/v1/feeds/articles/rss", http.Header{"If-None-Match": {`"stale"`}}); rr.Code != http.StatusOK {
+			t.Errorf("expected the feed for a stale ETag, got %d", rr.Code)
+		}
+	})
+
+	t.Run("should pass the author on and reject unknown feeds", func(t *testing.T) {
+		if rr := serve("/api/v1/feeds/inspirations/authors/author-1/atom", nil); rr.Code != http.StatusOK || mock.filter.AuthorID != "author-1" {
+			t.Errorf("expected the author's feed, got %d %+v", rr.Code, mock.filter)
+		}
+		if rr := serve("/api/v1/feeds/articles/json", nil); rr.Code != http.StatusNotFound {
+			t.Errorf("expected 404 for an unknown format, got %d", rr.Code)
+		}
+		if rr := serve("/api/v1/feeds/podcasts/rss", nil); rr.Code != http.StatusNotFound {
+			t.Errorf("expected 404 for unknown content, got %d", rr.Code)
+		}
+	})
+}
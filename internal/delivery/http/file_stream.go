@@ -0,0 +1,114 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+var errStoredFileNotFound = errors.New("stored file not found")
+
+// storedFileClient fetches proxied files. It has no overall timeout because a large file may
+// stream for a long time; a stalled upstream is cut off at connect or while waiting for the
+// response headers, and the request context ends the copy once the client goes away.
+var storedFileClient = &http.Client{
+	Transport: &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   10 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ResponseHeaderTimeout: 15 * time.Second,
+		IdleConnTimeout:       90 * time.Second,
+		MaxIdleConnsPerHost:   10,
+	},
+}
+
+// Cache policies of streamed files: private files must not be kept, audio may be cached by the
+// browser for seeking, and public audio by shared caches too
+const (
+	cacheNoStore      = "private, no-store"
+	cachePrivateAudio = "private, max-age=3600"
+	cachePublicAudio  = "public, max-age=86400"
+)
+
+// serveStoredFile streams a stored file with HTTP Range support.
+// Absolute http(s) locations are proxied with the client's Range headers so the real
+// location is never revealed; anything else is a path inside baseDir.
+func serveStoredFile(w http.ResponseWriter, r *http.Request, location, baseDir, contentType, downloadName, cacheControl string) error {
+	if downloadName != "" {
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": downloadName}))
+	}
+
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		return proxyStoredFile(w, r, location, contentType, cacheControl)
+	}
+
+	// Cleaning against the root keeps "../" from escaping the storage directory
+	path := filepath.Join(baseDir, filepath.Clean("/"+location))
+	file, err := os.Open(path)
+	if err != nil {
+		return errStoredFileNotFound
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil || info.IsDir() {
+		return errStoredFileNotFound
+	}
+
+	if contentType != "" {
+		w.Header().Set("Content-Type", contentType)
+	}
+	w.Header().Set("Cache-Control", cacheControl)
+	http.ServeContent(w, r, info.Name(), info.ModTime(), file)
+	return nil
+}
+
+func proxyStoredFile(w http.ResponseWriter, r *http.Request, location, contentType, cacheControl string) error {
+	req, err := http.NewRequestWithContext(r.Context(), http.MethodGet, location, nil)
+	if err != nil {
+		return err
+	}
+	for _, header := range []string{"Range", "If-Range", "If-None-Match", "If-Modified-Since"} {
+		if value := r.Header.Get(header); value != "" {
+			req.Header.Set(header, value)
+		}
+	}
+
+	resp, err := storedFileClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return errStoredFileNotFound
+	}
+	if resp.StatusCode >= http.StatusBadRequest && resp.StatusCode != http.StatusRequestedRangeNotSatisfiable {
+		return fmt.Errorf("upstream returned %s", resp.Status)
+	}
+
+	for _, header := range []string{"Content-Type", "Content-Length", "Content-Range", "Accept-Ranges", "ETag", "Last-Modified"} {
+		if value := resp.Header.Get(header); value != "" {
+			w.Header().Set(header, value)
+		}
+	}
+	if contentType != "" {
+		w.Header().Set("Content-Type", contentType)
+	}
+	w.Header().Set("Cache-Control", cacheControl)
+	w.WriteHeader(resp.StatusCode)
+
+	// The response has started, so a broken copy can only be logged by the caller
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
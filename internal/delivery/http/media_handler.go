@@ -0,0 +1,183 @@
+package http
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/delivery/http/middleware"
+	"buku-pintar/internal/delivery/http/response"
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/usecase"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+const (
+	// mediaUploadOverhead allows for the multipart framing around the file itself
+	mediaUploadOverhead = 1 << 20
+	// mediaUploadMemory is how much of the upload is kept in memory before spilling to a temporary file
+	mediaUploadMemory = 32 << 20
+)
+
+type MediaHandler struct {
+	mediaUsecase usecase.MediaUsecase
+}
+
+func NewMediaHandler(mediaUsecase usecase.MediaUsecase) *MediaHandler {
+	return &MediaHandler{
+		mediaUsecase: mediaUsecase,
+	}
+}
+
+// UploadMedia handles POST /media/upload/{kind} - Upload a cover, ebook file, audio, banner or icon
+func (h *MediaHandler) UploadMedia(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil || user == nil {
+		response.WriteError(w, http.StatusUnauthorized, "unauthorized", "Authentication required")
+		return
+	}
+
+	kind := entity.MediaKind(lastPathSegment(r))
+	if !kind.IsValid() {
+		response.WriteError(w, http.StatusBadRequest, "validation_error", constant.ERR_MEDIA_KIND_INVALID)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, kind.MaxSize()+mediaUploadOverhead)
+	if err := r.ParseMultipartForm(mediaUploadMemory); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			response.WriteError(w, http.StatusRequestEntityTooLarge, "media_too_large", constant.ERR_MEDIA_TOO_LARGE)
+			return
+		}
+		response.WriteError(w, http.StatusBadRequest, "invalid_request_body", err.Error())
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "media_file_required", constant.ERR_MEDIA_FILE_REQUIRED)
+		return
+	}
+	defer file.Close()
+
+	media, created, err := h.mediaUsecase.UploadMedia(r.Context(), user.ID, kind, header.Filename, file, header.Size)
+	if err != nil {
+		writeMediaError(w, err)
+		return
+	}
+
+	res, err := h.parseMediaResponse(r, media)
+	if err != nil {
+		response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+		return
+	}
+
+	status, message := http.StatusCreated, "Media uploaded successfully"
+	if !created {
+		status, message = http.StatusOK, "Media already uploaded"
+	}
+	response.WriteSuccess(w, status, res, message)
+}
+
+// GetMedia handles GET /media/{id} - Get uploaded media by ID
+func (h *MediaHandler) GetMedia(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	media, err := h.mediaUsecase.GetMedia(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeMediaError(w, err)
+		return
+	}
+	if media == nil {
+		response.WriteError(w, http.StatusNotFound, "media_not_found", constant.ERR_MEDIA_NOT_FOUND)
+		return
+	}
+
+	res, err := h.parseMediaResponse(r, media)
+	if err != nil {
+		response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+		return
+	}
+	response.WriteSuccess(w, http.StatusOK, res, "")
+}
+
+// parseMediaResponse adds the URL and, for covers and banners, the resized variants
+func (h *MediaHandler) parseMediaResponse(r *http.Request, media *entity.Media) (*response.MediaResponse, error) {
+	variants, err := h.mediaUsecase.ListImageVariants(r.Context(), media)
+	if err != nil {
+		return nil, err
+	}
+
+	res := response.ParseMediaResponse(media, h.mediaUsecase.MediaURL(media))
+	res.Variants = response.ParseImageVariantsResponse(variants)
+	return res, nil
+}
+
+// ServeMedia handles GET /media/{key...} - Serve public media stored by the local driver.
+// Keys are content-addressed, so responses never change and may be cached forever.
+func (h *MediaHandler) ServeMedia(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	media, body, err := h.mediaUsecase.OpenPublicMedia(r.Context(), r.PathValue("key"))
+	if err != nil {
+		response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+		return
+	}
+	if media == nil {
+		response.WriteError(w, http.StatusNotFound, "media_not_found", constant.ERR_MEDIA_NOT_FOUND)
+		return
+	}
+	defer body.Close()
+
+	w.Header().Set("Content-Type", media.ContentType)
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	w.Header().Set("ETag", `"`+media.Checksum+`"`)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	// Local files can seek, which gives conditional and Range requests for free
+	if seeker, ok := body.(io.ReadSeeker); ok {
+		http.ServeContent(w, r, "", media.CreatedAt, seeker)
+		return
+	}
+
+	if match := r.Header.Get("If-None-Match"); match == `"`+media.Checksum+`"` {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Length", strconv.FormatInt(media.Size, 10))
+	w.WriteHeader(http.StatusOK)
+	if r.Method != http.MethodHead {
+		io.Copy(w, body)
+	}
+}
+
+func writeMediaError(w http.ResponseWriter, err error) {
+	var validationErr *usecase.ValidationError
+	var typeErr *usecase.UnsupportedMediaTypeError
+	switch {
+	case errors.As(err, &validationErr):
+		response.WriteError(w, http.StatusBadRequest, "validation_error", validationErr.Message)
+	case errors.Is(err, usecase.ErrMediaTooLarge):
+		response.WriteError(w, http.StatusRequestEntityTooLarge, "media_too_large", constant.ERR_MEDIA_TOO_LARGE)
+	case errors.As(err, &typeErr):
+		response.WriteErrorWithDetails(w, http.StatusUnsupportedMediaType, "media_type_not_allowed", constant.ERR_MEDIA_TYPE_NOT_ALLOWED, map[string]any{
+			"content_type": typeErr.ContentType,
+			"allowed":      typeErr.Allowed,
+		})
+	default:
+		response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+	}
+}
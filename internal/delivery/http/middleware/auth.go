@@ -56,6 +56,18 @@ func (m *AuthMiddleware) Authenticate(next http.Handler) http.Handler {
 	})
 }
 
+// OptionalAuthenticate attaches the user when a bearer token is sent and lets anonymous requests through.
+// A token that is sent but invalid is still rejected, so clients notice an expired session.
+func (m *AuthMiddleware) OptionalAuthenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		m.Authenticate(next).ServeHTTP(w, r)
+	})
+}
+
 func (m *AuthMiddleware) authenticateWithSupabase(ctx context.Context, accessToken string) (*entity.User, error) {
 	claims, err := m.supabaseAuth.VerifyToken(ctx, accessToken)
 	if err != nil {
@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// ClientIPMiddleware resolves the address of the client behind trusted reverse proxies
+type ClientIPMiddleware struct {
+	trusted []*net.IPNet
+}
+
+// NewClientIPMiddleware creates a new instance of ClientIPMiddleware trusting the given
+// proxy addresses or CIDR ranges; with none, forwarding headers are never believed
+func NewClientIPMiddleware(trustedProxies []string) (*ClientIPMiddleware, error) {
+	m := &ClientIPMiddleware{}
+	for _, proxy := range trustedProxies {
+		proxy = strings.TrimSpace(proxy)
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q", proxy)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			m.trusted = append(m.trusted, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", proxy, err)
+		}
+		m.trusted = append(m.trusted, network)
+	}
+	return m, nil
+}
+
+// Resolve replaces RemoteAddr with the client address when the request comes from a trusted proxy.
+// X-Forwarded-For is read from the right, skipping trusted hops, so entries the client
+// prepended itself are ignored; X-Real-IP is used when there is no X-Forwarded-For.
+func (m *ClientIPMiddleware) Resolve(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if client := m.clientIP(r); client != "" {
+			r = r.Clone(r.Context())
+			r.RemoteAddr = net.JoinHostPort(client, "0")
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// clientIP returns the forwarded client address, or an empty string to keep RemoteAddr
+func (m *ClientIPMiddleware) clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	if !m.isTrusted(host) {
+		return ""
+	}
+
+	if forwarded := r.Header.Values("X-Forwarded-For"); len(forwarded) > 0 {
+		hops := strings.Split(strings.Join(forwarded, ","), ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			hop := strings.TrimSpace(hops[i])
+			if net.ParseIP(hop) == nil {
+				return ""
+			}
+			if !m.isTrusted(hop) {
+				return hop
+			}
+		}
+		return ""
+	}
+
+	if realIP := strings.TrimSpace(r.Header.Get("X-Real-IP")); net.ParseIP(realIP) != nil {
+		return realIP
+	}
+	return ""
+}
+
+func (m *ClientIPMiddleware) isTrusted(host string) bool {
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, network := range m.trusted {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
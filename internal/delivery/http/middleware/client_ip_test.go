@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientIPMiddleware_Resolve(t *testing.T) {
+	m, err := NewClientIPMiddleware([]string{"10.0.0.0/8", "192.168.1.10"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		remoteAddr     string
+		forwardedFor   string
+		realIP         string
+		wantRemoteAddr string
+	}{
+		{name: "direct client", remoteAddr: "203.0.113.7:5000", wantRemoteAddr: "203.0.113.7:5000"},
+		{name: "direct client spoofing headers", remoteAddr: "203.0.113.7:5000", forwardedFor: "198.51.100.1", realIP: "198.51.100.2", wantRemoteAddr: "203.0.113.7:5000"},
+		{name: "trusted proxy", remoteAddr: "10.0.0.5:5000", forwardedFor: "203.0.113.7", wantRemoteAddr: "203.0.113.7:0"},
+		{name: "client prepending its own hops", remoteAddr: "10.0.0.5:5000", forwardedFor: "198.51.100.1, 203.0.113.7", wantRemoteAddr: "203.0.113.7:0"},
+		{name: "chain of trusted proxies", remoteAddr: "192.168.1.10:5000", forwardedFor: "203.0.113.7, 10.1.2.3", wantRemoteAddr: "203.0.113.7:0"},
+		{name: "trusted proxy with real ip", remoteAddr: "10.0.0.5:5000", realIP: "203.0.113.7", wantRemoteAddr: "203.0.113.7:0"},
+		{name: "trusted proxy with garbage", remoteAddr: "10.0.0.5:5000", forwardedFor: "not-an-ip", wantRemoteAddr: "10.0.0.5:5000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			handler := m.Resolve(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.RemoteAddr
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.forwardedFor != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			}
+			if tt.realIP != "" {
+				req.Header.Set("X-Real-IP", tt.realIP)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			if got != tt.wantRemoteAddr {
+				t.Errorf("expected remote address %q, got %q", tt.wantRemoteAddr, got)
+			}
+		})
+	}
+
+	if _, err := NewClientIPMiddleware([]string{"not-a-proxy"}); err == nil {
+		t.Error("expected an invalid trusted proxy to be rejected")
+	}
+}
@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// LocaleMiddleware picks the language catalog content is read in
+type LocaleMiddleware struct {
+	locales entity.LocaleSet
+}
+
+// NewLocaleMiddleware creates a new instance of LocaleMiddleware
+func NewLocaleMiddleware(locales entity.LocaleSet) *LocaleMiddleware {
+	return &LocaleMiddleware{locales: locales}
+}
+
+// Negotiate translates the content read by GET and HEAD requests into the language asked for by
+// the lang query parameter, or else the Accept-Language header, falling back to the default language.
+// Other requests work on the stored text so updates never save translated text over it.
+func (m *LocaleMiddleware) Negotiate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		locale := m.Locale(r)
+		w.Header().Set("Content-Language", locale)
+		w.Header().Add("Vary", "Accept-Language")
+
+		if locale == m.locales.Default {
+			locale = ""
+		}
+		next.ServeHTTP(w, r.WithContext(entity.ContextWithTranslationLocale(r.Context(), locale)))
+	})
+}
+
+// Locale returns the supported locale the request asks for
+func (m *LocaleMiddleware) Locale(r *http.Request) string {
+	if locale, ok := m.locales.Match(r.URL.Query().Get("lang")); ok {
+		return locale
+	}
+	for _, tag := range acceptedLanguages(r.Header.Get("Accept-Language")) {
+		if locale, ok := m.locales.Match(tag); ok {
+			return locale
+		}
+	}
+	return m.locales.Default
+}
+
+// acceptedLanguages returns the language tags of an Accept-Language header, most preferred first.
+// Tags with a quality of 0 and the wildcard are left out.
+func acceptedLanguages(header string) []string {
+	type weightedTag struct {
+		tag     string
+		quality float64
+	}
+
+	var tags []weightedTag
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range strings.Split(params, ";") {
+			name, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok || strings.TrimSpace(name) != "q" {
+				continue
+			}
+			if q, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+				quality = q
+			}
+		}
+		if quality <= 0 {
+			continue
+		}
+		tags = append(tags, weightedTag{tag: tag, quality: quality})
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].quality > tags[j].quality })
+	accepted := make([]string, len(tags))
+	for i, tag := range tags {
+		accepted[i] = tag.tag
+	}
+	return accepted
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLocaleMiddleware_Negotiate(t *testing.T) {
+	m := NewLocaleMiddleware(entity.LocaleSet{Default: "id", Supported: []string{"id", "en"}})
+
+	tests := []struct {
+		name           string
+		method         string
+		target         string
+		acceptLanguage string
+		wantLocale     string // Translation locale in the request context
+		wantHeader     string // Content-Language
+	}{
+		{name: "no preference", method: http.MethodGet, target: "/", wantLocale: "", wantHeader: "id"},
+		{name: "accept language region", method: http.MethodGet, target: "/", acceptLanguage: "en-US,en;q=0.9", wantLocale: "en", wantHeader: "en"},
+		{name: "quality order", method: http.MethodGet, target: "/", acceptLanguage: "fr;q=1, id;q=0.5, en;q=0.8", wantLocale: "en", wantHeader: "en"},
+		{name: "refused language", method: http.MethodGet, target: "/", acceptLanguage: "en;q=0, *", wantLocale: "", wantHeader: "id"},
+		{name: "query wins", method: http.MethodGet, target: "/?lang=id", acceptLanguage: "en", wantLocale: "", wantHeader: "id"},
+		{name: "unsupported query", method: http.MethodGet, target: "/?lang=fr", acceptLanguage: "en", wantLocale: "en", wantHeader: "en"},
+		{name: "writes are not translated", method: http.MethodPut, target: "/?lang=en", acceptLanguage: "en", wantLocale: "", wantHeader: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotLocale string
+			handler := m.Negotiate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotLocale = entity.TranslationLocale(r.Context())
+			}))
+
+			req := httptest.NewRequest(tt.method, tt.target, nil)
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if gotLocale != tt.wantLocale {
+				t.Errorf("expected translation locale %q, got %q", tt.wantLocale, gotLocale)
+			}
+			if got := rr.Header().Get("Content-Language"); got != tt.wantHeader {
+				t.Errorf("expected Content-Language %q, got %q", tt.wantHeader, got)
+			}
+		})
+	}
+}
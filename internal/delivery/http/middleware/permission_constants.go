@@ -22,6 +22,7 @@ var (
 		entity.PermissionPermissionManage,
 		entity.PermissionPaymentManage,
 		entity.PermissionCommentManage,
+		entity.PermissionReviewManage,
 		entity.PermissionSEOManage,
 		entity.PermissionSummaryManage,
 	}
@@ -39,6 +40,7 @@ var (
 		entity.PermissionSummaryUpdate,
 		entity.PermissionCategoryRead,
 		entity.PermissionSEOUpdate,
+		entity.PermissionReviewManage,
 	}
 
 	// ReaderPermissions - Basic read permissions
@@ -159,6 +161,14 @@ var PermissionDescription = map[string]string{
 	entity.PermissionCommentList:   "List comments",
 	entity.PermissionCommentManage: "Full comment management",
 
+	// Review - from entity package
+	entity.PermissionReviewCreate: "Write ebook reviews",
+	entity.PermissionReviewRead:   "View ebook reviews",
+	entity.PermissionReviewUpdate: "Edit ebook reviews",
+	entity.PermissionReviewDelete: "Delete ebook reviews",
+	entity.PermissionReviewList:   "List ebook reviews",
+	entity.PermissionReviewManage: "Moderate ebook reviews",
+
 	// SEO - from entity package
 	entity.PermissionSEOCreate: "Create SEO metadata",
 	entity.PermissionSEORead:   "View SEO metadata",
@@ -281,6 +291,14 @@ func GetPermissionsByCategory() map[string][]string {
 			entity.PermissionCommentList,
 			entity.PermissionCommentManage,
 		},
+		"review": {
+			entity.PermissionReviewCreate,
+			entity.PermissionReviewRead,
+			entity.PermissionReviewUpdate,
+			entity.PermissionReviewDelete,
+			entity.PermissionReviewList,
+			entity.PermissionReviewManage,
+		},
 		"seo": {
 			entity.PermissionSEOCreate,
 			entity.PermissionSEORead,
@@ -0,0 +1,54 @@
+package http
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/delivery/http/middleware"
+	"buku-pintar/internal/delivery/http/response"
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/helper"
+	"buku-pintar/internal/usecase"
+	"net/http"
+)
+
+type NotificationHandler struct {
+	notificationUsecase usecase.NotificationUsecase
+}
+
+func NewNotificationHandler(notificationUsecase usecase.NotificationUsecase) *NotificationHandler {
+	return &NotificationHandler{
+		notificationUsecase: notificationUsecase,
+	}
+}
+
+// ListNotifications handles GET /users/notifications - The user's notification events, newest first
+func (h *NotificationHandler) ListNotifications(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil || user == nil {
+		response.WriteError(w, http.StatusUnauthorized, "unauthorized", "Authentication required")
+		return
+	}
+
+	limit, offset := helper.HandlePagination(r)
+
+	events, err := h.notificationUsecase.ListNotifications(r.Context(), user.ID, limit, offset)
+	if err != nil {
+		response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+		return
+	}
+
+	total, err := h.notificationUsecase.CountNotifications(r.Context(), user.ID)
+	if err != nil {
+		response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+		return
+	}
+
+	if events == nil {
+		events = []*entity.NotificationEvent{}
+	}
+	response.WritePaginatedMeta(w, r, events, response.NewOffsetMeta(total, limit, offset))
+}
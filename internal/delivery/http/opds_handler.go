@@ -0,0 +1,421 @@
+package http
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/delivery/http/middleware"
+	"buku-pintar/internal/delivery/http/response"
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/helper"
+	"buku-pintar/internal/usecase"
+	"buku-pintar/pkg/opds"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	opdsPrefix   = apiV1Prefix + "/opds"
+	opdsPageSize = 25
+	opdsIDPrefix = "urn:buku-pintar:opds"
+)
+
+type OPDSHandler struct {
+	opdsUsecase usecase.OPDSUsecase
+	signer      *helper.URLSigner
+	title       string
+	buyURL      string
+}
+
+// NewOPDSHandler creates the OPDS catalog handler. buyURL is the store page of an ebook,
+// with {slug} and {id} standing for the ebook's.
+func NewOPDSHandler(opdsUsecase usecase.OPDSUsecase, signer *helper.URLSigner, title, buyURL string) *OPDSHandler {
+	return &OPDSHandler{
+		opdsUsecase: opdsUsecase,
+		signer:      signer,
+		title:       title,
+		buyURL:      buyURL,
+	}
+}
+
+// Root handles GET /opds - The navigation feed reader apps start from
+func (h *OPDSHandler) Root(w http.ResponseWriter, r *http.Request) {
+	if !allowOPDSMethod(w, r) {
+		return
+	}
+
+	now := time.Now().UTC()
+	feed := h.newFeed(opdsIDPrefix, h.title, opdsPrefix, opds.MediaTypeNavigation, now)
+	feed.Entries = []opds.Entry{
+		navigationEntry(opdsIDPrefix+":new", "New Releases", "The latest ebooks in the store",
+			opds.RelNew, opdsPrefix+"/new", opds.MediaTypeAcquisition, now),
+		navigationEntry(opdsIDPrefix+":discounted", "Discounted", "Ebooks with a running discount",
+			opds.RelSubsection, opdsPrefix+"/discounted", opds.MediaTypeAcquisition, now),
+		navigationEntry(opdsIDPrefix+":categories", "Categories", "Browse ebooks by category",
+			opds.RelSubsection, opdsPrefix+"/categories", opds.MediaTypeNavigation, now),
+	}
+
+	writeOPDS(w, opds.MediaTypeNavigation, feed)
+}
+
+// ListCategories handles GET /opds/categories - Navigation feed of the active categories
+func (h *OPDSHandler) ListCategories(w http.ResponseWriter, r *http.Request) {
+	if !allowOPDSMethod(w, r) {
+		return
+	}
+
+	page := opdsPage(r)
+	categories, total, err := h.opdsUsecase.ListCategories(r.Context(), opdsPageSize, (page-1)*opdsPageSize)
+	if err != nil {
+		writeOPDSError(w, err)
+		return
+	}
+
+	updated := time.Time{}
+	entries := make([]opds.Entry, 0, len(categories))
+	for _, category := range categories {
+		entry := navigationEntry("urn:uuid:"+category.ID, category.Name, "", opds.RelSubsection,
+			opdsPrefix+"/categories/"+category.ID, opds.MediaTypeAcquisition, category.UpdatedAt.UTC())
+		if category.Description != nil && *category.Description != "" {
+			entry.Content = &opds.Text{Type: "text", Value: *category.Description}
+		}
+		entries = append(entries, entry)
+		updated = latest(updated, category.UpdatedAt)
+	}
+
+	path := opdsPrefix + "/categories"
+	feed := h.newFeed(opdsIDPrefix+":categories", "Categories", path, opds.MediaTypeNavigation, updated)
+	feed.Links = append(feed.Links, opds.Link{Rel: opds.RelUp, Href: opdsPrefix, Type: opds.MediaTypeNavigation})
+	feed.Links = append(feed.Links, pageLinks(path, nil, page, total, opds.MediaTypeNavigation)...)
+	feed.Entries = entries
+
+	writeOPDS(w, opds.MediaTypeNavigation, feed)
+}
+
+// ListCategoryEbooks handles GET /opds/categories/{id} - Acquisition feed of a category and its subcategories
+func (h *OPDSHandler) ListCategoryEbooks(w http.ResponseWriter, r *http.Request) {
+	if !allowOPDSMethod(w, r) {
+		return
+	}
+
+	id := r.PathValue("id")
+	category, err := h.opdsUsecase.GetCategory(r.Context(), id)
+	if err != nil {
+		writeOPDSError(w, err)
+		return
+	}
+
+	h.writeAcquisitionFeed(w, r, acquisitionFeed{
+		id:     opdsIDPrefix + ":category:" + category.ID,
+		title:  category.Name,
+		path:   opdsPrefix + "/categories/" + category.ID,
+		up:     opdsPrefix + "/categories",
+		filter: &entity.EbookFilter{CategoryID: category.ID},
+	})
+}
+
+// ListNew handles GET /opds/new - Acquisition feed of the latest releases
+func (h *OPDSHandler) ListNew(w http.ResponseWriter, r *http.Request) {
+	if !allowOPDSMethod(w, r) {
+		return
+	}
+
+	h.writeAcquisitionFeed(w, r, acquisitionFeed{
+		id:     opdsIDPrefix + ":new",
+		title:  "New Releases",
+		path:   opdsPrefix + "/new",
+		filter: &entity.EbookFilter{Sort: entity.EbookSortNewest},
+	})
+}
+
+// ListDiscounted handles GET /opds/discounted - Acquisition feed of ebooks with a running discount
+func (h *OPDSHandler) ListDiscounted(w http.ResponseWriter, r *http.Request) {
+	if !allowOPDSMethod(w, r) {
+		return
+	}
+
+	h.writeAcquisitionFeed(w, r, acquisitionFeed{
+		id:     opdsIDPrefix + ":discounted",
+		title:  "Discounted",
+		path:   opdsPrefix + "/discounted",
+		filter: &entity.EbookFilter{Discounted: true},
+	})
+}
+
+// ListAuthorEbooks handles GET /opds/authors/{id} - Acquisition feed of an author's ebooks
+func (h *OPDSHandler) ListAuthorEbooks(w http.ResponseWriter, r *http.Request) {
+	if !allowOPDSMethod(w, r) {
+		return
+	}
+
+	id := r.PathValue("id")
+	h.writeAcquisitionFeed(w, r, acquisitionFeed{
+		id:     opdsIDPrefix + ":author:" + id,
+		title:  "Ebooks by the author",
+		path:   opdsPrefix + "/authors/" + id,
+		filter: &entity.EbookFilter{AuthorID: id},
+		titled: func(entries []*usecase.OPDSEntry) string {
+			return "Ebooks by " + entries[0].Ebook.AuthorName
+		},
+		notFound: constant.ERR_OPDS_AUTHOR_NOT_FOUND,
+	})
+}
+
+// Search handles GET /opds/search?q= - Acquisition feed of ebooks whose title or author matches
+func (h *OPDSHandler) Search(w http.ResponseWriter, r *http.Request) {
+	if !allowOPDSMethod(w, r) {
+		return
+	}
+
+	q := strings.TrimSpace(r.URL.Query().Get("q"))
+	if q == "" {
+		response.WriteError(w, http.StatusBadRequest, "validation_error", constant.ERR_OPDS_QUERY_REQUIRED)
+		return
+	}
+
+	h.writeAcquisitionFeed(w, r, acquisitionFeed{
+		id:     opdsIDPrefix + ":search",
+		title:  "Search results for \"" + q + "\"",
+		path:   opdsPrefix + "/search",
+		query:  url.Values{"q": {q}},
+		filter: &entity.EbookFilter{Query: q},
+	})
+}
+
+// OpenSearchDescription handles GET /opds/opensearch.xml - Tells reader apps how to search the catalog
+func (h *OPDSHandler) OpenSearchDescription(w http.ResponseWriter, r *http.Request) {
+	if !allowOPDSMethod(w, r) {
+		return
+	}
+
+	writeOPDS(w, opds.MediaTypeOpenSearch, opds.NewOpenSearchDescription(
+		h.title, "Search "+h.title+" by title or author", opdsPrefix+"/search?q={searchTerms}"))
+}
+
+// acquisitionFeed describes a paginated feed of ebooks
+type acquisitionFeed struct {
+	id     string
+	title  string
+	path   string
+	up     string
+	query  url.Values
+	filter *entity.EbookFilter
+	// titled names the feed after its entries, when there are any
+	titled func(entries []*usecase.OPDSEntry) string
+	// notFound is reported when set and nothing matches the filter
+	notFound string
+}
+
+func (h *OPDSHandler) writeAcquisitionFeed(w http.ResponseWriter, r *http.Request, spec acquisitionFeed) {
+	user, _ := middleware.GetUserFromContext(r.Context())
+	page := opdsPage(r)
+
+	entries, total, err := h.opdsUsecase.ListEntries(r.Context(), user, spec.filter, opdsPageSize, (page-1)*opdsPageSize)
+	if err != nil {
+		writeOPDSError(w, err)
+		return
+	}
+	if total == 0 && spec.notFound != "" {
+		response.WriteError(w, http.StatusNotFound, constant.ERR_CODE_NOT_FOUND, spec.notFound)
+		return
+	}
+
+	title := spec.title
+	if spec.titled != nil && len(entries) > 0 {
+		title = spec.titled(entries)
+	}
+
+	now := time.Now()
+	updated := time.Time{}
+	feedEntries := make([]opds.Entry, 0, len(entries))
+	for _, entry := range entries {
+		feedEntries = append(feedEntries, h.acquisitionEntry(entry, user, now))
+		updated = latest(updated, entry.Ebook.UpdatedAt)
+	}
+
+	up := spec.up
+	if up == "" {
+		up = opdsPrefix
+	}
+	feed := h.newFeed(spec.id, title, spec.path, opds.MediaTypeAcquisition, updated)
+	feed.Links = append(feed.Links, opds.Link{Rel: opds.RelUp, Href: up, Type: opds.MediaTypeNavigation})
+	feed.Links = append(feed.Links, pageLinks(spec.path, spec.query, page, total, opds.MediaTypeAcquisition)...)
+	feed.TotalResults = total
+	feed.ItemsPerPage = opdsPageSize
+	feed.StartIndex = (page-1)*opdsPageSize + 1
+	feed.Entries = feedEntries
+
+	// Acquisition links of a signed-in user are personal and expire with the download signature
+	if user != nil {
+		w.Header().Set("Cache-Control", "private, no-store")
+	}
+	writeOPDS(w, opds.MediaTypeAcquisition, feed)
+}
+
+// acquisitionEntry describes an ebook. Entitled users get a signed link to the file, as issued by
+// POST /ebooks/{id}/download, and everyone else a link to buy it.
+func (h *OPDSHandler) acquisitionEntry(entry *usecase.OPDSEntry, user *entity.User, now time.Time) opds.Entry {
+	ebook := entry.Ebook
+	authorFeed := opdsPrefix + "/authors/" + ebook.AuthorID
+
+	feedEntry := opds.Entry{
+		ID:       "urn:uuid:" + ebook.ID,
+		Title:    ebook.Title,
+		Updated:  ebook.UpdatedAt.UTC(),
+		Authors:  []opds.Author{{Name: ebook.AuthorName, URI: authorFeed}},
+		Language: ebook.Language,
+	}
+	if ebook.PublishedAt != nil {
+		published := ebook.PublishedAt.UTC()
+		feedEntry.Published = &published
+	}
+	if ebook.CategoryID != "" {
+		feedEntry.Categories = []opds.Category{{Term: ebook.CategoryID, Label: ebook.CategoryName}}
+	}
+	if ebook.Synopsis != "" {
+		feedEntry.Summary = &opds.Text{Type: "text", Value: ebook.Synopsis}
+	}
+
+	if ebook.CoverImage != "" {
+		feedEntry.Links = append(feedEntry.Links,
+			opds.Link{Rel: opds.RelImage, Href: ebook.CoverImage},
+			opds.Link{Rel: opds.RelThumbnail, Href: ebook.CoverImage})
+	}
+	feedEntry.Links = append(feedEntry.Links, opds.Link{
+		Rel: opds.RelRelated, Href: authorFeed, Type: opds.MediaTypeAcquisition, Title: "More by " + ebook.AuthorName,
+	})
+
+	switch {
+	case entry.Entitled && ebook.HasFile:
+		query, _ := h.signer.Sign(ebookFileResource(ebook.ID), user.ID, now)
+		feedEntry.Links = append(feedEntry.Links, opds.Link{
+			Rel:  opds.RelAcquisition,
+			Href: apiV1Prefix + "/ebooks/" + ebook.ID + "/file?" + query.Encode(),
+			Type: ebookContentType(ebook.Format),
+		})
+	case !entry.Entitled:
+		buy := opds.Link{Rel: opds.RelBuy, Href: h.buyHref(ebook), Type: "text/html"}
+		if price := effectivePrice(ebook); price > 0 {
+			buy.Price = &opds.Price{CurrencyCode: constant.DEFAULT_CURRENCY, Value: price}
+		}
+		feedEntry.Links = append(feedEntry.Links, buy)
+	}
+
+	return feedEntry
+}
+
+func (h *OPDSHandler) buyHref(ebook *entity.EbookFeedEntry) string {
+	return strings.NewReplacer("{slug}", url.PathEscape(ebook.Slug), "{id}", url.PathEscape(ebook.ID)).Replace(h.buyURL)
+}
+
+// newFeed starts a feed with the links every catalog page carries
+func (h *OPDSHandler) newFeed(id, title, path, mediaType string, updated time.Time) *opds.Feed {
+	if updated.IsZero() {
+		updated = time.Now()
+	}
+	return &opds.Feed{
+		ID:      id,
+		Title:   title,
+		Updated: updated.UTC().Truncate(time.Second),
+		Author:  &opds.Author{Name: h.title},
+		Links: []opds.Link{
+			{Rel: opds.RelStart, Href: opdsPrefix, Type: opds.MediaTypeNavigation, Title: h.title},
+			{Rel: opds.RelSearch, Href: opdsPrefix + "/opensearch.xml", Type: opds.MediaTypeOpenSearch},
+		},
+	}
+}
+
+func navigationEntry(id, title, content, rel, href, mediaType string, updated time.Time) opds.Entry {
+	entry := opds.Entry{
+		ID:      id,
+		Title:   title,
+		Updated: updated.Truncate(time.Second),
+		Links:   []opds.Link{{Rel: rel, Href: href, Type: mediaType}},
+	}
+	if content != "" {
+		entry.Content = &opds.Text{Type: "text", Value: content}
+	}
+	return entry
+}
+
+// pageLinks returns the self link of the page and links to its neighbours
+func pageLinks(path string, query url.Values, page int, total int64, mediaType string) []opds.Link {
+	href := func(page int) string {
+		values := url.Values{}
+		for key, value := range query {
+			values[key] = value
+		}
+		if page > 1 {
+			values.Set("page", strconv.Itoa(page))
+		}
+		if len(values) == 0 {
+			return path
+		}
+		return path + "?" + values.Encode()
+	}
+
+	links := []opds.Link{{Rel: opds.RelSelf, Href: href(page), Type: mediaType}}
+	if page > 1 {
+		links = append(links,
+			opds.Link{Rel: opds.RelFirst, Href: href(1), Type: mediaType},
+			opds.Link{Rel: opds.RelPrevious, Href: href(page - 1), Type: mediaType})
+	}
+	if int64(page*opdsPageSize) < total {
+		links = append(links, opds.Link{Rel: opds.RelNext, Href: href(page + 1), Type: mediaType})
+	}
+	return links
+}
+
+// opdsPage returns the 1-based page requested, reader apps follow the next links rather than using offsets
+func opdsPage(r *http.Request) int {
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || page < 1 {
+		return 1
+	}
+	return page
+}
+
+func effectivePrice(ebook *entity.EbookFeedEntry) int {
+	if ebook.Discount != nil {
+		return *ebook.Discount
+	}
+	return ebook.Price
+}
+
+func latest(current, candidate time.Time) time.Time {
+	if candidate.After(current) {
+		return candidate
+	}
+	return current
+}
+
+func allowOPDSMethod(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return false
+	}
+	return true
+}
+
+func writeOPDS(w http.ResponseWriter, mediaType string, doc any) {
+	w.Header().Set("Content-Type", mediaType+";charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	if err := opds.Write(w, doc); err != nil {
+		log.Printf("Failed to write OPDS document: %v", err)
+	}
+}
+
+func writeOPDSError(w http.ResponseWriter, err error) {
+	var validationErr *usecase.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		response.WriteError(w, http.StatusBadRequest, "validation_error", err.Error())
+	case errors.Is(err, usecase.ErrOPDSCategoryNotFound):
+		response.WriteError(w, http.StatusNotFound, "category_not_found", err.Error())
+	default:
+		response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+	}
+}
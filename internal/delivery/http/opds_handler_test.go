@@ -0,0 +1,152 @@
+package http
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/helper"
+	"buku-pintar/internal/usecase"
+	"buku-pintar/pkg/opds"
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// MockOPDSUsecase returns the entries as given; users without an ID in entitled are not entitled to any
+type MockOPDSUsecase struct {
+	ebooks   []*entity.EbookFeedEntry
+	total    int64
+	entitled map[string]bool
+	filter   *entity.EbookFilter
+}
+
+func (m *MockOPDSUsecase) ListCategories(ctx context.Context, limit, offset int) ([]*entity.Category, int64, error) {
+	return nil, 0, nil
+}
+
+func (m *MockOPDSUsecase) GetCategory(ctx context.Context, id string) (*entity.Category, error) {
+	return nil, usecase.ErrOPDSCategoryNotFound
+}
+
+func (m *MockOPDSUsecase) ListEntries(ctx context.Context, user *entity.User, filter *entity.EbookFilter, limit, offset int) ([]*usecase.OPDSEntry, int64, error) {
+	m.filter = filter
+	entries := make([]*usecase.OPDSEntry, 0, len(m.ebooks))
+	for _, ebook := range m.ebooks {
+		entries = append(entries, &usecase.OPDSEntry{Ebook: ebook, Entitled: user != nil && m.entitled[ebook.ID]})
+	}
+	return entries, m.total, nil
+}
+
+func decodeOPDSFeed(t *testing.T, rr *httptest.ResponseRecorder) *opds.Feed {
+	t.Helper()
+	feed := &opds.Feed{}
+	if err := xml.Unmarshal(rr.Body.Bytes(), feed); err != nil {
+		t.Fatalf("failed to decode feed: %v\n%s", err, rr.Body.String())
+	}
+	return feed
+}
+
+func findLink(links []opds.Link, rel string) *opds.Link {
+	for i := range links {
+		if links[i].Rel == rel {
+			return &links[i]
+		}
+	}
+	return nil
+}
+
+func TestOPDSHandler_ListNew(t *testing.T) {
+	signer := helper.NewURLSigner("test-secret", 5*time.Minute)
+	discount := 30000
+	mock := &MockOPDSUsecase{
+		ebooks: []*entity.EbookFeedEntry{
+			{ID: "owned", Title: "Owned", Slug: "owned", Price: 50000, Format: entity.FormatEPUB, HasFile: true, AuthorID: "author-1", AuthorName: "Author", UpdatedAt: time.Now()},
+			{ID: "for-sale", Title: "For Sale", Slug: "for-sale", Price: 50000, Discount: &discount, AuthorID: "author-1", AuthorName: "Author", UpdatedAt: time.Now()},
+		},
+		total:    60,
+		entitled: map[string]bool{"owned": true},
+	}
+	handler := NewOPDSHandler(mock, signer, "Buku Pintar", "https://store.example.com/ebooks/{slug}")
+
+	t.Run("should link owned ebooks to a signed download and the rest to the store", func(t *testing.T) {
+		req := withTestUser(httptest.NewRequest(http.MethodGet, "/api/v1/opds/new?page=2", nil))
+		rr := httptest.NewRecorder()
+
+		handler.ListNew(rr, req)
+
+		if rr.Code != http.StatusOK || !strings.HasPrefix(rr.Header().Get("Content-Type"), opds.MediaTypeAcquisition) {
+			t.Fatalf("unexpected response %d %q", rr.Code, rr.Header().Get("Content-Type"))
+		}
+		feed := decodeOPDSFeed(t, rr)
+		if len(feed.Entries) != 2 {
+			t.Fatalf("expected 2 entries, got %d", len(feed.Entries))
+		}
+
+		acquisition := findLink(feed.Entries[0].Links, opds.RelAcquisition)
+		if acquisition == nil || acquisition.Type != "application/epub+zip" {
+			t.Fatalf("expected an EPUB acquisition link, got %+v", feed.Entries[0].Links)
+		}
+		href, _ := url.Parse(acquisition.Href)
+		if userID, err := signer.Verify(ebookFileResource("owned"), href.Query(), time.Now()); href.Path != "/api/v1/ebooks/owned/file" || err != nil || userID != "user-1" {
+			t.Errorf("expected a signed file URL for the user, got %s", acquisition.Href)
+		}
+		if findLink(feed.Entries[0].Links, opds.RelBuy) != nil {
+			t.Error("expected no buy link for an owned ebook")
+		}
+
+		buy := findLink(feed.Entries[1].Links, opds.RelBuy)
+		if buy == nil || buy.Href != "https://store.example.com/ebooks/for-sale" {
+			t.Errorf("expected a buy link to the store, got %+v", feed.Entries[1].Links)
+		}
+		if !strings.Contains(rr.Body.String(), `<opds:price currencycode="IDR">30000</opds:price>`) {
+			t.Errorf("expected the discounted price on the buy link:\n%s", rr.Body.String())
+		}
+
+		if next := findLink(feed.Links, opds.RelNext); next == nil || next.Href != "/api/v1/opds/new?page=3" {
+			t.Errorf("expected a link to page 3, got %+v", feed.Links)
+		}
+		if previous := findLink(feed.Links, opds.RelPrevious); previous == nil || previous.Href != "/api/v1/opds/new" {
+			t.Errorf("expected a link to page 1, got %+v", feed.Links)
+		}
+		if rr.Header().Get("Cache-Control") != "private, no-store" {
+			t.Error("expected a personal feed not to be stored")
+		}
+	})
+
+	t.Run("should offer anonymous clients buy links only", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/opds/new", nil)
+		rr := httptest.NewRecorder()
+
+		handler.ListNew(rr, req)
+
+		feed := decodeOPDSFeed(t, rr)
+		for _, entry := range feed.Entries {
+			if findLink(entry.Links, opds.RelAcquisition) != nil || findLink(entry.Links, opds.RelBuy) == nil {
+				t.Errorf("expected only a buy link, got %+v", entry.Links)
+			}
+		}
+	})
+}
+
+func TestOPDSHandler_Search(t *testing.T) {
+	mock := &MockOPDSUsecase{}
+	handler := NewOPDSHandler(mock, helper.NewURLSigner("test-secret", time.Minute), "Buku Pintar", "/store/{slug}")
+
+	rr := httptest.NewRecorder()
+	handler.Search(rr, httptest.NewRequest(http.MethodGet, "/api/v1/opds/search", nil))
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d without a query, got %d", http.StatusBadRequest, rr.Code)
+	}
+
+	rr = httptest.NewRecorder()
+	handler.Search(rr, httptest.NewRequest(http.MethodGet, "/api/v1/opds/search?q=laskar+pelangi", nil))
+	if rr.Code != http.StatusOK || mock.filter == nil || mock.filter.Query != "laskar pelangi" {
+		t.Errorf("expected the query to be searched, got %d %+v", rr.Code, mock.filter)
+	}
+	if self := findLink(decodeOPDSFeed(t, rr).Links, opds.RelSelf); self == nil || self.Href != "/api/v1/opds/search?q=laskar+pelangi" {
+		t.Errorf("expected the self link to keep the query, got %+v", self)
+	}
+}
@@ -2,43 +2,119 @@ package http
 
 import (
 	"buku-pintar/internal/constant"
+	"buku-pintar/internal/delivery/http/middleware"
+	"buku-pintar/internal/delivery/http/response"
+	"buku-pintar/internal/helper"
 	"buku-pintar/internal/usecase"
+	"crypto/hmac"
 	"encoding/json"
+	"errors"
 	"net/http"
 )
 
 type PaymentHandler struct {
 	paymentUsecase usecase.PaymentUsecase
+	cursorCodec    *helper.CursorCodec
+	callbackToken  string
 }
 
-func NewPaymentHandler(paymentUsecase usecase.PaymentUsecase) *PaymentHandler {
+func NewPaymentHandler(paymentUsecase usecase.PaymentUsecase, cursorCodec *helper.CursorCodec, callbackToken string) *PaymentHandler {
 	return &PaymentHandler{
 		paymentUsecase: paymentUsecase,
+		cursorCodec:    cursorCodec,
+		callbackToken:  callbackToken,
 	}
 }
 
+// ListPayments handles GET /payments - List the authenticated user's payments, newest first
+// Passing a cursor parameter switches from limit/offset to keyset pagination
+func (h *PaymentHandler) ListPayments(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil || user == nil {
+		response.WriteError(w, http.StatusUnauthorized, "unauthorized", "Authentication required")
+		return
+	}
+
+	limit, offset := helper.HandlePagination(r)
+
+	total, err := h.paymentUsecase.CountPayments(r.Context(), user.ID)
+	if err != nil {
+		response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+		return
+	}
+
+	if helper.IsCursorPagination(r) {
+		cursor, err := h.cursorCodec.Decode(r.URL.Query().Get("cursor"))
+		if err != nil {
+			response.WriteError(w, http.StatusBadRequest, "invalid_cursor", err.Error())
+			return
+		}
+
+		payments, page, err := h.paymentUsecase.ListPaymentsByCursor(r.Context(), user.ID, cursor, limit)
+		if err != nil {
+			var validationErr *usecase.ValidationError
+			if errors.As(err, &validationErr) {
+				response.WriteError(w, http.StatusBadRequest, "invalid_cursor", err.Error())
+				return
+			}
+			response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+			return
+		}
+
+		meta := response.NewCursorMeta(total, limit, h.cursorCodec.Encode(page.Next), h.cursorCodec.Encode(page.Prev))
+		response.WritePaginatedMeta(w, r, payments, meta)
+		return
+	}
+
+	payments, err := h.paymentUsecase.ListPayments(r.Context(), user.ID, limit, offset)
+	if err != nil {
+		response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+		return
+	}
+
+	response.WritePaginatedMeta(w, r, payments, response.NewOffsetMeta(total, limit, offset))
+}
+
+// InitiatePaymentRequest names the ebook to buy; the buyer and the price are decided by the server
 type InitiatePaymentRequest struct {
-	UserID      string `json:"user_id"`
-	Amount      int64  `json:"amount"`
-	Currency    string `json:"currency"`
-	Description string `json:"description"`
+	EbookID string `json:"ebook_id"`
 }
 
+// InitiatePayment handles POST /payments/initiate - Start buying an ebook for the authenticated user
 func (h *PaymentHandler) InitiatePayment(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, constant.ERR_METHOD_NOT_ALLOWED, http.StatusMethodNotAllowed)
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil || user == nil {
+		response.WriteError(w, http.StatusUnauthorized, "unauthorized", "Authentication required")
 		return
 	}
 
 	var req InitiatePaymentRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		response.WriteError(w, http.StatusBadRequest, "invalid_request_body", err.Error())
 		return
 	}
 
-	payment, err := h.paymentUsecase.InitiatePayment(r.Context(), req.UserID, req.Amount, req.Currency, req.Description)
+	payment, err := h.paymentUsecase.InitiatePayment(r.Context(), user.ID, req.EbookID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		var validationErr *usecase.ValidationError
+		switch {
+		case errors.As(err, &validationErr):
+			response.WriteError(w, http.StatusBadRequest, "validation_error", err.Error())
+		case errors.Is(err, usecase.ErrEbookNotFound):
+			response.WriteError(w, http.StatusNotFound, "ebook_not_found", err.Error())
+		default:
+			response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+		}
 		return
 	}
 
@@ -49,12 +125,19 @@ func (h *PaymentHandler) InitiatePayment(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// HandleXenditCallback handles POST /payments/callback - Apply a payment status sent by Xendit
+// The endpoint is public, so only requests carrying the configured callback token are trusted
 func (h *PaymentHandler) HandleXenditCallback(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, constant.ERR_METHOD_NOT_ALLOWED, http.StatusMethodNotAllowed)
 		return
 	}
 
+	if !h.validCallbackToken(r.Header.Get("X-CALLBACK-TOKEN")) {
+		response.WriteError(w, http.StatusUnauthorized, "invalid_callback_token", constant.ERR_CALLBACK_TOKEN_INVALID)
+		return
+	}
+
 	var callbackData map[string]interface{}
 	if err := json.NewDecoder(r.Body).Decode(&callbackData); err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
@@ -67,4 +150,9 @@ func (h *PaymentHandler) HandleXenditCallback(w http.ResponseWriter, r *http.Req
 	}
 
 	w.WriteHeader(http.StatusOK)
-} 
\ No newline at end of file
+}
+
+// validCallbackToken compares in constant time; without a configured token every callback is refused
+func (h *PaymentHandler) validCallbackToken(token string) bool {
+	return h.callbackToken != "" && hmac.Equal([]byte(token), []byte(h.callbackToken))
+}
@@ -0,0 +1,76 @@
+package http
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/helper"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// MockPaymentUsecase records the callbacks it is asked to apply
+type MockPaymentUsecase struct {
+	callbacks []map[string]interface{}
+}
+
+func (m *MockPaymentUsecase) InitiatePayment(ctx context.Context, userID, ebookID string) (*entity.Payment, error) {
+	return nil, nil
+}
+
+func (m *MockPaymentUsecase) GetPayment(ctx context.Context, paymentID string) (*entity.Payment, error) {
+	return nil, nil
+}
+
+func (m *MockPaymentUsecase) HandleXenditCallback(ctx context.Context, callbackData map[string]interface{}) error {
+	m.callbacks = append(m.callbacks, callbackData)
+	return nil
+}
+
+func (m *MockPaymentUsecase) ListPayments(ctx context.Context, userID string, limit, offset int) ([]*entity.Payment, error) {
+	return nil, nil
+}
+
+func (m *MockPaymentUsecase) ListPaymentsByCursor(ctx context.Context, userID string, cursor *entity.PageCursor, limit int) ([]*entity.Payment, *entity.CursorPageInfo, error) {
+	return nil, &entity.CursorPageInfo{}, nil
+}
+
+func (m *MockPaymentUsecase) CountPayments(ctx context.Context, userID string) (int64, error) {
+	return 0, nil
+}
+
+func TestPaymentHandler_HandleXenditCallback(t *testing.T) {
+	body := `{"external_id": "inv_123", "status": "PAID"}`
+
+	tests := []struct {
+		name          string
+		configured    string
+		token         string
+		wantStatus    int
+		wantCallbacks int
+	}{
+		{name: "should apply a callback with the configured token", configured: "xendit-token", token: "xendit-token", wantStatus: http.StatusOK, wantCallbacks: 1},
+		{name: "should refuse a callback without a token", configured: "xendit-token", wantStatus: http.StatusUnauthorized},
+		{name: "should refuse a callback with another token", configured: "xendit-token", token: "forged", wantStatus: http.StatusUnauthorized},
+		{name: "should refuse every callback when no token is configured", wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paymentUsecase := &MockPaymentUsecase{}
+			handler := NewPaymentHandler(paymentUsecase, helper.NewCursorCodec("test-secret"), tt.configured)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/payments/callback", strings.NewReader(body))
+			if tt.token != "" {
+				req.Header.Set("X-CALLBACK-TOKEN", tt.token)
+			}
+			rr := httptest.NewRecorder()
+
+			handler.HandleXenditCallback(rr, req)
+
+			if rr.Code != tt.wantStatus || len(paymentUsecase.callbacks) != tt.wantCallbacks {
+				t.Errorf("expected status %d and %d callbacks, got %d and %d", tt.wantStatus, tt.wantCallbacks, rr.Code, len(paymentUsecase.callbacks))
+			}
+		})
+	}
+}
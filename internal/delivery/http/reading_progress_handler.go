@@ -0,0 +1,150 @@
+package http
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/delivery/http/middleware"
+	"buku-pintar/internal/delivery/http/response"
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/helper"
+	"buku-pintar/internal/usecase"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+)
+
+type ReadingProgressHandler struct {
+	progressUsecase usecase.ReadingProgressUsecase
+}
+
+func NewReadingProgressHandler(progressUsecase usecase.ReadingProgressUsecase) *ReadingProgressHandler {
+	return &ReadingProgressHandler{
+		progressUsecase: progressUsecase,
+	}
+}
+
+// UpdateProgressRequest is a reading position reported by a device
+// UpdatedAt is the device time of the position and decides which device wins
+type UpdateProgressRequest struct {
+	Page       int        `json:"page"`
+	CFI        *string    `json:"cfi"`
+	Percentage float64    `json:"percentage"`
+	UpdatedAt  *time.Time `json:"updated_at"`
+}
+
+// UpdateProgress handles PUT /ebooks/{id}/progress - Sync the reading position from a device
+func (h *ReadingProgressHandler) UpdateProgress(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil || user == nil {
+		response.WriteError(w, http.StatusUnauthorized, "unauthorized", "Authentication required")
+		return
+	}
+
+	var req UpdateProgressRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "invalid_request_body", err.Error())
+		return
+	}
+
+	progress := &entity.ReadingProgress{
+		EbookID:    ebookSubresourceID(r),
+		Page:       req.Page,
+		CFI:        req.CFI,
+		Percentage: req.Percentage,
+	}
+	if req.UpdatedAt != nil {
+		progress.ClientUpdatedAt = *req.UpdatedAt
+	}
+
+	stored, applied, err := h.progressUsecase.UpdateProgress(r.Context(), user, progress)
+	if err != nil {
+		var validationErr *usecase.ValidationError
+		if errors.As(err, &validationErr) {
+			response.WriteError(w, http.StatusBadRequest, "validation_error", err.Error())
+			return
+		}
+		response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+		return
+	}
+
+	if stored == nil {
+		response.WriteError(w, http.StatusNotFound, "ebook_not_found", constant.EBOOK_NOT_FOUND)
+		return
+	}
+
+	res := response.ParseReadingProgressResponse(stored)
+	res.Applied = &applied
+	response.WriteSuccess(w, http.StatusOK, res, "")
+}
+
+// GetProgress handles GET /ebooks/{id}/progress - Get the synced reading position
+func (h *ReadingProgressHandler) GetProgress(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil || user == nil {
+		response.WriteError(w, http.StatusUnauthorized, "unauthorized", "Authentication required")
+		return
+	}
+
+	progress, err := h.progressUsecase.GetProgress(r.Context(), user.ID, ebookSubresourceID(r))
+	if err != nil {
+		var validationErr *usecase.ValidationError
+		if errors.As(err, &validationErr) {
+			response.WriteError(w, http.StatusBadRequest, "validation_error", err.Error())
+			return
+		}
+		response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+		return
+	}
+
+	if progress == nil {
+		response.WriteError(w, http.StatusNotFound, "progress_not_found", "no reading progress for this ebook")
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, response.ParseReadingProgressResponse(progress), "")
+}
+
+// ListContinueReading handles GET /users/continue-reading - Unfinished ebooks, most recently read first
+func (h *ReadingProgressHandler) ListContinueReading(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil || user == nil {
+		response.WriteError(w, http.StatusUnauthorized, "unauthorized", "Authentication required")
+		return
+	}
+
+	limit, offset := helper.HandlePagination(r)
+
+	items, err := h.progressUsecase.ListContinueReading(r.Context(), user.ID, limit, offset)
+	if err != nil {
+		response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+		return
+	}
+
+	total, err := h.progressUsecase.CountContinueReading(r.Context(), user.ID)
+	if err != nil {
+		response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+		return
+	}
+
+	res := make([]*response.ContinueReadingResponse, 0, len(items))
+	for _, item := range items {
+		res = append(res, response.ParseContinueReadingResponse(item))
+	}
+
+	response.WritePaginatedMeta(w, r, res, response.NewOffsetMeta(total, limit, offset))
+}
@@ -0,0 +1,80 @@
+package http
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/delivery/http/middleware"
+	"buku-pintar/internal/delivery/http/response"
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/helper"
+	"buku-pintar/internal/usecase"
+	"errors"
+	"net/http"
+)
+
+type RecommendationHandler struct {
+	recommendationUsecase usecase.RecommendationUsecase
+}
+
+func NewRecommendationHandler(recommendationUsecase usecase.RecommendationUsecase) *RecommendationHandler {
+	return &RecommendationHandler{
+		recommendationUsecase: recommendationUsecase,
+	}
+}
+
+// ListRelatedEbooks handles GET /ebooks/{id}/related - Ebooks similar to this one
+func (h *RecommendationHandler) ListRelatedEbooks(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	limit, _ := helper.HandlePagination(r)
+
+	ebooks, err := h.recommendationUsecase.ListRelatedEbooks(r.Context(), ebookSubresourceID(r), limit)
+	if err != nil {
+		var validationErr *usecase.ValidationError
+		switch {
+		case errors.As(err, &validationErr):
+			response.WriteError(w, http.StatusBadRequest, "validation_error", err.Error())
+		case errors.Is(err, usecase.ErrEbookNotFound):
+			response.WriteError(w, http.StatusNotFound, "ebook_not_found", err.Error())
+		default:
+			response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+		}
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, parseRecommendedEbooks(ebooks), "Related ebooks retrieved successfully")
+}
+
+// ListUserRecommendations handles GET /users/recommendations - Ebooks picked for the current user
+func (h *RecommendationHandler) ListUserRecommendations(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil || user == nil {
+		response.WriteError(w, http.StatusUnauthorized, "unauthorized", "Authentication required")
+		return
+	}
+
+	limit, _ := helper.HandlePagination(r)
+
+	ebooks, err := h.recommendationUsecase.ListUserRecommendations(r.Context(), user.ID, limit)
+	if err != nil {
+		response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, parseRecommendedEbooks(ebooks), "Recommendations retrieved successfully")
+}
+
+func parseRecommendedEbooks(ebooks []*entity.EbookList) []*response.EbookListResponse {
+	res := make([]*response.EbookListResponse, 0, len(ebooks))
+	for _, ebook := range ebooks {
+		res = append(res, response.ParseEbookListResponse(ebook))
+	}
+	return res
+}
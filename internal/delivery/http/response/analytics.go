@@ -0,0 +1,107 @@
+package response
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"time"
+)
+
+// AnalyticsRangeResponse is the range [from, to) an aggregate covers
+type AnalyticsRangeResponse struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+type AnalyticsTotalResponse struct {
+	Type     entity.AnalyticsEventType `json:"type"`
+	Events   int64                     `json:"events"`
+	Users    int64                     `json:"users"`
+	Sessions int64                     `json:"sessions"`
+}
+
+type AnalyticsSummaryResponse struct {
+	AnalyticsRangeResponse
+	Totals []*AnalyticsTotalResponse `json:"totals"`
+}
+
+type AnalyticsDailyCountResponse struct {
+	Day    string                    `json:"day"`
+	Type   entity.AnalyticsEventType `json:"type"`
+	Events int64                     `json:"events"`
+}
+
+type AnalyticsDailyResponse struct {
+	AnalyticsRangeResponse
+	Days []*AnalyticsDailyCountResponse `json:"days"`
+}
+
+type AnalyticsTopItemResponse struct {
+	Key    string `json:"key"`
+	Label  string `json:"label"`
+	Events int64  `json:"events"`
+	Users  int64  `json:"users"`
+}
+
+type AnalyticsTopResponse struct {
+	AnalyticsRangeResponse
+	Dimension string                      `json:"dimension"`
+	Items     []*AnalyticsTopItemResponse `json:"items"`
+}
+
+type AnalyticsAcceptedResponse struct {
+	Accepted int `json:"accepted"`
+}
+
+func ParseAnalyticsRangeResponse(filter *entity.AnalyticsFilter) AnalyticsRangeResponse {
+	return AnalyticsRangeResponse{
+		From: filter.From,
+		To:   filter.To,
+	}
+}
+
+func ParseAnalyticsSummaryResponse(filter *entity.AnalyticsFilter, totals []*entity.AnalyticsTotal) *AnalyticsSummaryResponse {
+	res := &AnalyticsSummaryResponse{
+		AnalyticsRangeResponse: ParseAnalyticsRangeResponse(filter),
+		Totals:                 make([]*AnalyticsTotalResponse, 0, len(totals)),
+	}
+	for _, total := range totals {
+		res.Totals = append(res.Totals, &AnalyticsTotalResponse{
+			Type:     total.Type,
+			Events:   total.Events,
+			Users:    total.Users,
+			Sessions: total.Sessions,
+		})
+	}
+	return res
+}
+
+func ParseAnalyticsDailyResponse(filter *entity.AnalyticsFilter, counts []*entity.AnalyticsDailyCount) *AnalyticsDailyResponse {
+	res := &AnalyticsDailyResponse{
+		AnalyticsRangeResponse: ParseAnalyticsRangeResponse(filter),
+		Days:                   make([]*AnalyticsDailyCountResponse, 0, len(counts)),
+	}
+	for _, count := range counts {
+		res.Days = append(res.Days, &AnalyticsDailyCountResponse{
+			Day:    count.Day,
+			Type:   count.Type,
+			Events: count.Events,
+		})
+	}
+	return res
+}
+
+func ParseAnalyticsTopResponse(filter *entity.AnalyticsFilter, dimension string, items []*entity.AnalyticsTopItem) *AnalyticsTopResponse {
+	res := &AnalyticsTopResponse{
+		AnalyticsRangeResponse: ParseAnalyticsRangeResponse(filter),
+		Dimension:              dimension,
+		Items:                  make([]*AnalyticsTopItemResponse, 0, len(items)),
+	}
+	for _, item := range items {
+		res.Items = append(res.Items, &AnalyticsTopItemResponse{
+			Key:    item.Key,
+			Label:  item.Label,
+			Events: item.Events,
+			Users:  item.Users,
+		})
+	}
+	return res
+}
@@ -0,0 +1,93 @@
+package response
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type AnnotationExportEbookResponse struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Slug  string `json:"slug"`
+}
+
+// AnnotationExportResponse is the JSON export of a user's annotations for one ebook
+type AnnotationExportResponse struct {
+	Ebook       AnnotationExportEbookResponse `json:"ebook"`
+	ExportedAt  time.Time                     `json:"exported_at"`
+	Annotations []*entity.Annotation          `json:"annotations"`
+}
+
+func ParseAnnotationExportResponse(ebook *entity.Ebook, annotations []*entity.Annotation, exportedAt time.Time) *AnnotationExportResponse {
+	if annotations == nil {
+		annotations = []*entity.Annotation{}
+	}
+	return &AnnotationExportResponse{
+		Ebook: AnnotationExportEbookResponse{
+			ID:    ebook.ID,
+			Title: ebook.Title,
+			Slug:  ebook.Slug,
+		},
+		ExportedAt:  exportedAt,
+		Annotations: annotations,
+	}
+}
+
+// RenderAnnotationsMarkdown renders a user's annotations for an ebook as a Markdown document,
+// with highlights as block quotes followed by their notes
+func RenderAnnotationsMarkdown(ebook *entity.Ebook, annotations []*entity.Annotation, exportedAt time.Time) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n\n", markdownLine(ebook.Title))
+	fmt.Fprintf(&b, "_%d annotations, exported %s_\n", len(annotations), exportedAt.Format("2 January 2006"))
+
+	for _, annotation := range annotations {
+		b.WriteString("\n---\n\n")
+
+		heading := []string{"**" + annotationLabel(annotation.Type) + "**", annotationLocation(annotation)}
+		if annotation.Color != nil {
+			heading = append(heading, *annotation.Color)
+		}
+		b.WriteString(strings.Join(heading, " · ") + "\n")
+
+		if annotation.SelectedText != nil {
+			b.WriteString("\n")
+			for _, line := range strings.Split(*annotation.SelectedText, "\n") {
+				b.WriteString(strings.TrimRight("> "+line, " ") + "\n")
+			}
+		}
+		if annotation.Note != nil {
+			b.WriteString("\n" + *annotation.Note + "\n")
+		}
+	}
+
+	return b.String()
+}
+
+func annotationLabel(annotationType entity.AnnotationType) string {
+	switch annotationType {
+	case entity.AnnotationBookmark:
+		return "Bookmark"
+	case entity.AnnotationHighlight:
+		return "Highlight"
+	default:
+		return "Note"
+	}
+}
+
+func annotationLocation(annotation *entity.Annotation) string {
+	if annotation.Page != nil {
+		return fmt.Sprintf("Page %d", *annotation.Page)
+	}
+	if annotation.CFIStart != nil {
+		return "`" + *annotation.CFIStart + "`"
+	}
+	return ""
+}
+
+// markdownLine keeps a value on one line so it cannot break out of a heading
+func markdownLine(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
@@ -8,4 +8,6 @@ type BannerResponse struct {
     Link string `json:"link"`
     CTALabel string `json:"cta_label"`
     BackgroundColor string `json:"background_color"`
+
+    ImageVariants *ImageVariantsResponse `json:"image_variants"`
 }
@@ -3,7 +3,10 @@ package response
 import (
 	"buku-pintar/internal/constant"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 )
 
 // Response represents the standard API response structure
@@ -17,17 +20,22 @@ type Response struct {
 
 // Meta contains metadata for paginated responses
 type Meta struct {
-	Total       int64 `json:"total"`
-	Limit       int   `json:"limit"`
-	Offset      int   `json:"offset"`
-	CurrentPage int   `json:"current_page,omitempty"`
-	TotalPages  int   `json:"total_pages,omitempty"`
+	Total       int64  `json:"total"`
+	Limit       int    `json:"limit"`
+	Offset      int    `json:"offset"`
+	CurrentPage int    `json:"current_page,omitempty"`
+	TotalPages  int    `json:"total_pages,omitempty"`
+	NextCursor  string `json:"next_cursor,omitempty"`
+	PrevCursor  string `json:"prev_cursor,omitempty"`
+	Facets      any    `json:"facets,omitempty"`
 }
 
 // Error contains error details
+// Details carries structured data the client can act on, such as pricing for a purchase prompt
 type Error struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
+	Details any    `json:"details,omitempty"`
 }
 
 // NewSuccessResponse creates a new success response
@@ -55,21 +63,47 @@ func NewPaginatedResponse(data any, total int64, limit, offset int) *Response {
 	return &Response{
 		Status: constant.STATUS_SUCCESS,
 		Data:   data,
-		Meta: &Meta{
-			Total:  total,
-			Limit:  limit,
-			Offset: offset,
-		},
+		Meta:   NewOffsetMeta(total, limit, offset),
+	}
+}
+
+// NewOffsetMeta creates the meta for limit/offset pagination, including the page position
+func NewOffsetMeta(total int64, limit, offset int) *Meta {
+	meta := &Meta{
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	}
+	if limit > 0 {
+		meta.CurrentPage = offset/limit + 1
+		meta.TotalPages = int((total + int64(limit) - 1) / int64(limit))
+	}
+	return meta
+}
+
+// NewCursorMeta creates the meta for cursor pagination; empty cursors mean there is no such page
+func NewCursorMeta(total int64, limit int, nextCursor, prevCursor string) *Meta {
+	return &Meta{
+		Total:      total,
+		Limit:      limit,
+		NextCursor: nextCursor,
+		PrevCursor: prevCursor,
 	}
 }
 
 // WriteError writes an error response to the ResponseWriter
 func WriteError(w http.ResponseWriter, statusCode int, errorCode, errorMessage string) {
+	WriteErrorWithDetails(w, statusCode, errorCode, errorMessage, nil)
+}
+
+// WriteErrorWithDetails writes an error response carrying structured details
+func WriteErrorWithDetails(w http.ResponseWriter, statusCode int, errorCode, errorMessage string, details any) {
 	w.Header().Set(constant.CONTENT_TYPE, constant.APPLICATION_JSON)
 	w.Header().Set(constant.ACCESS_CONTROL_ALLOW_ORIGIN, "*")
 	w.Header().Set(constant.ACCESS_CONTROL_ALLOW_HEADER, constant.CONTENT_TYPE)
 	w.WriteHeader(statusCode)
 	resp := NewErrorResponse(errorCode, errorMessage)
+	resp.Error.Details = details
 	if err := json.NewEncoder(w).Encode(resp); err != nil {
 		http.Error(w, constant.ERR_ENCODING_RESP, http.StatusInternalServerError)
 		return
@@ -91,12 +125,76 @@ func WriteSuccess(w http.ResponseWriter, statusCode int, data any, message strin
 
 // WritePaginated writes a paginated response to the ResponseWriter
 func WritePaginated(w http.ResponseWriter, data any, total int64, limit, offset int) {
+	writePaginatedResponse(w, NewPaginatedResponse(data, total, limit, offset))
+}
+
+// WritePaginatedMeta writes a paginated response with a prepared meta and
+// advertises the neighbouring pages of the request in an RFC 8288 Link header
+func WritePaginatedMeta(w http.ResponseWriter, r *http.Request, data any, meta *Meta) {
+	if links := PaginationLinks(r, meta); links != "" {
+		w.Header().Set(constant.LINK, links)
+		w.Header().Set(constant.ACCESS_CONTROL_EXPOSE_HEADER, constant.LINK)
+	}
+	writePaginatedResponse(w, &Response{
+		Status: constant.STATUS_SUCCESS,
+		Data:   data,
+		Meta:   meta,
+	})
+}
+
+// PaginationLinks builds the Link header value for a paginated request.
+// Cursor pages link to first, prev and next; offset pages also link to last.
+func PaginationLinks(r *http.Request, meta *Meta) string {
+	query := r.URL.Query()
+	cursorMode := meta.NextCursor != "" || meta.PrevCursor != "" || query.Has("cursor") || query.Get("pagination") == "cursor"
+
+	var links []string
+	addLink := func(rel, key, value string) {
+		params := r.URL.Query()
+		params.Set("limit", strconv.Itoa(meta.Limit))
+		if cursorMode {
+			params.Del("offset")
+		} else {
+			params.Del("cursor")
+		}
+		params.Set(key, value)
+		links = append(links, fmt.Sprintf(`<%s?%s>; rel="%s"`, r.URL.Path, params.Encode(), rel))
+	}
+
+	if cursorMode {
+		addLink("first", "cursor", "")
+		if meta.PrevCursor != "" {
+			addLink("prev", "cursor", meta.PrevCursor)
+		}
+		if meta.NextCursor != "" {
+			addLink("next", "cursor", meta.NextCursor)
+		}
+		return strings.Join(links, ", ")
+	}
+
+	if meta.Limit <= 0 {
+		return ""
+	}
+
+	addLink("first", "offset", "0")
+	if meta.Offset > 0 {
+		addLink("prev", "offset", strconv.Itoa(max(meta.Offset-meta.Limit, 0)))
+	}
+	if int64(meta.Offset+meta.Limit) < meta.Total {
+		addLink("next", "offset", strconv.Itoa(meta.Offset+meta.Limit))
+	}
+	if meta.TotalPages > 0 {
+		addLink("last", "offset", strconv.Itoa((meta.TotalPages-1)*meta.Limit))
+	}
+	return strings.Join(links, ", ")
+}
+
+func writePaginatedResponse(w http.ResponseWriter, resp *Response) {
 	w.Header().Set(constant.CONTENT_TYPE, constant.APPLICATION_JSON)
 	w.Header().Set(constant.ACCESS_CONTROL_ALLOW_ORIGIN, "*")
 	w.Header().Set(constant.ACCESS_CONTROL_ALLOW_METHOD, "GET")
 	w.Header().Set(constant.ACCESS_CONTROL_ALLOW_HEADER, constant.CONTENT_TYPE)
 	w.WriteHeader(http.StatusOK)
-	resp := NewPaginatedResponse(data, total, limit, offset)
 	if err := json.NewEncoder(w).Encode(resp); err != nil {
 		http.Error(w, constant.ERR_ENCODING_RESP, http.StatusInternalServerError)
 		return
@@ -0,0 +1,39 @@
+package response
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"time"
+)
+
+// ContentScheduleResponse is a scheduled publication or unpublication; AppliedAt is set once it has run
+type ContentScheduleResponse struct {
+	ID          string                    `json:"id"`
+	ContentType entity.SchedulableContent `json:"content_type"`
+	ContentID   string                    `json:"content_id"`
+	Action      entity.ScheduleAction     `json:"action"`
+	RunAt       time.Time                 `json:"run_at"`
+	CreatedBy   *string                   `json:"created_by"`
+	AppliedAt   *time.Time                `json:"applied_at"`
+	CreatedAt   time.Time                 `json:"created_at"`
+}
+
+func ParseContentScheduleResponse(schedule *entity.ContentSchedule) *ContentScheduleResponse {
+	return &ContentScheduleResponse{
+		ID:          schedule.ID,
+		ContentType: schedule.ContentType,
+		ContentID:   schedule.ContentID,
+		Action:      schedule.Action,
+		RunAt:       schedule.RunAt,
+		CreatedBy:   schedule.CreatedBy,
+		AppliedAt:   schedule.AppliedAt,
+		CreatedAt:   schedule.CreatedAt,
+	}
+}
+
+func ParseContentScheduleListResponse(schedules []*entity.ContentSchedule) []*ContentScheduleResponse {
+	responses := make([]*ContentScheduleResponse, 0, len(schedules))
+	for _, schedule := range schedules {
+		responses = append(responses, ParseContentScheduleResponse(schedule))
+	}
+	return responses
+}
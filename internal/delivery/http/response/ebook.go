@@ -19,12 +19,16 @@ type EbookResponse struct {
 	Format      string `json:"format"`
 	PageCount   int16  `json:"page_count"`
 	PreviewPage int16  `json:"preview_page"`
-	URL         string `json:"url"`
 	PublishedAt string `json:"published_at"`
 
+	RatingAverage float64 `json:"rating_average"`
+	RatingCount   int     `json:"rating_count"`
+
 	Author          AuthorResponse               `json:"author"`
+	Contributors    []*EbookContributorResponse  `json:"contributors"`
 	Category        *CategoryResponse            `json:"category"`
 	Discount        *EbookDiscountResponse       `json:"discount"`
+	Series          *EbookSeriesResponse         `json:"series"`
 	TableOfContents []*TableOfContentResponse    `json:"table_of_contents"`
 	Summary         *EbookSummaryResponse        `json:"summary"`
 	PremiumSummary  *EbookPremiumSummaryResponse `json:"premium_summary"`
@@ -38,6 +42,53 @@ type EbookListResponse struct {
 	Status     string `json:"status"`
 	Price      int    `json:"price"`
 	Discount   int    `json:"discount"`
+
+	RatingAverage float64 `json:"rating_average"`
+	RatingCount   int     `json:"rating_count"`
+
+	CoverVariants *ImageVariantsResponse `json:"cover_variants"`
+}
+
+type FacetCountResponse struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+	Count int64  `json:"count"`
+}
+
+type EbookFacetsResponse struct {
+	Categories []FacetCountResponse `json:"categories"`
+	Authors    []FacetCountResponse `json:"authors"`
+	Languages  []FacetCountResponse `json:"languages"`
+	Formats    []FacetCountResponse `json:"formats"`
+	Free       int64                `json:"free"`
+	Discounted int64                `json:"discounted"`
+}
+
+func ParseEbookFacetsResponse(facets *entity.EbookFacets) *EbookFacetsResponse {
+	if facets == nil {
+		return nil
+	}
+
+	return &EbookFacetsResponse{
+		Categories: parseFacetCounts(facets.Categories),
+		Authors:    parseFacetCounts(facets.Authors),
+		Languages:  parseFacetCounts(facets.Languages),
+		Formats:    parseFacetCounts(facets.Formats),
+		Free:       facets.Free,
+		Discounted: facets.Discounted,
+	}
+}
+
+func parseFacetCounts(counts []entity.FacetCount) []FacetCountResponse {
+	res := make([]FacetCountResponse, 0, len(counts))
+	for _, count := range counts {
+		res = append(res, FacetCountResponse{
+			Value: count.Value,
+			Label: count.Label,
+			Count: count.Count,
+		})
+	}
+	return res
 }
 
 func ParseEbookListResponse(ebook *entity.EbookList) *EbookListResponse {
@@ -48,6 +99,11 @@ func ParseEbookListResponse(ebook *entity.EbookList) *EbookListResponse {
 		CoverImage: ebook.CoverImage,
 		Price:      ebook.Price,
 		Discount:   0,
+
+		RatingAverage: ebook.RatingAverage,
+		RatingCount:   ebook.RatingCount,
+
+		CoverVariants: ParseImageVariantsResponse(ebook.CoverVariants),
 	}
 }
 
@@ -76,16 +132,20 @@ func ParseEbookResponse(ebook *entity.EbookDetail) *EbookResponse {
 		Format:      string(ebook.Format), // Convert EbookFormat to string
 		PageCount:   ebook.PageCount,
 		PreviewPage: ebook.PreviewPage,
-		URL:         ebook.URL,
 		PublishedAt: publishedAtStr,
+
+		RatingAverage: ebook.RatingAverage,
+		RatingCount:   ebook.RatingCount,
 		// Related entities will be empty for now since they're not included in the basic entity
 		Author: AuthorResponse{
 			ID:     ebook.AuthorID,
 			Name:   ebook.AuthorName,
 			Avatar: ebook.AuthorAvatar,
 		},
+		Contributors:    ParseEbookContributorResponses(ebook.Contributors),
 		Category:        nil,
 		Discount:        nil,
+		Series:          nil,
 		TableOfContents: []*TableOfContentResponse{},
 		Summary:         nil,
 		PremiumSummary:  nil,
@@ -96,7 +156,6 @@ func ParseEbookResponse(ebook *entity.EbookDetail) *EbookResponse {
 			EbookID:    ebook.ID,
 			EbookTitle: ebook.Title,
 			Slug:       ebook.Slug,
-			URL:        ebook.URL,
 		}
 		if ebook.SummaryContent != nil {
 			res.Summary.Description = *ebook.SummaryContent
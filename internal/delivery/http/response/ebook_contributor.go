@@ -0,0 +1,23 @@
+package response
+
+import "buku-pintar/internal/domain/entity"
+
+type EbookContributorResponse struct {
+	ID     string  `json:"id"`
+	Name   string  `json:"name"`
+	Avatar *string `json:"avatar"`
+	Role   string  `json:"role"`
+}
+
+func ParseEbookContributorResponses(contributors []*entity.EbookContributor) []*EbookContributorResponse {
+	res := make([]*EbookContributorResponse, 0, len(contributors))
+	for _, contributor := range contributors {
+		res = append(res, &EbookContributorResponse{
+			ID:     contributor.AuthorID,
+			Name:   contributor.AuthorName,
+			Avatar: contributor.AuthorAvatar,
+			Role:   string(contributor.Role),
+		})
+	}
+	return res
+}
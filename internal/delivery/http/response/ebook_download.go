@@ -0,0 +1,28 @@
+package response
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"time"
+)
+
+// EbookDownloadResponse is a short-lived signed link to an ebook file
+type EbookDownloadResponse struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Format    string    `json:"format"`
+	Filesize  int64     `json:"filesize"`
+}
+
+type EbookDownloadHistoryResponse struct {
+	ID           string    `json:"id"`
+	EbookID      string    `json:"ebook_id"`
+	DownloadedAt time.Time `json:"downloaded_at"`
+}
+
+func ParseEbookDownloadHistoryResponse(download *entity.EbookDownload) *EbookDownloadHistoryResponse {
+	return &EbookDownloadHistoryResponse{
+		ID:           download.ID,
+		EbookID:      download.EbookID,
+		DownloadedAt: download.CreatedAt,
+	}
+}
@@ -0,0 +1,70 @@
+package response
+
+import "buku-pintar/internal/domain/entity"
+
+// EbookDraftResponse is an ebook pre-filled from an uploaded file, ready for an editor to complete
+type EbookDraftResponse struct {
+	Ebook           *EbookDraftFieldsResponse           `json:"ebook"`
+	Author          *AuthorResponse                     `json:"author"`
+	AuthorCreated   bool                                `json:"author_created"`
+	TableOfContents []*TableOfContentDraftEntryResponse `json:"table_of_contents"`
+	Warnings        []string                            `json:"warnings"`
+}
+
+type EbookDraftFieldsResponse struct {
+	Title     string             `json:"title"`
+	Synopsis  string             `json:"synopsis"`
+	AuthorID  string             `json:"author_id"`
+	Language  string             `json:"language"`
+	Filesize  int64              `json:"filesize"`
+	Format    entity.EbookFormat `json:"format"`
+	PageCount int16              `json:"page_count"`
+}
+
+// TableOfContentDraftEntryResponse has the shape of a table of contents replace request entry,
+// so the draft can be sent back as is once the ebook exists
+type TableOfContentDraftEntryResponse struct {
+	Title      string                              `json:"title"`
+	PageNumber int16                               `json:"page_number"`
+	Children   []*TableOfContentDraftEntryResponse `json:"children,omitempty"`
+}
+
+func ParseEbookDraftResponse(draft *entity.EbookDraft) *EbookDraftResponse {
+	res := &EbookDraftResponse{
+		Ebook: &EbookDraftFieldsResponse{
+			Title:     draft.Ebook.Title,
+			Synopsis:  draft.Ebook.Synopsis,
+			AuthorID:  draft.Ebook.AuthorID,
+			Language:  draft.Ebook.Language,
+			Filesize:  draft.Ebook.Filesize,
+			Format:    draft.Ebook.Format,
+			PageCount: draft.Ebook.PageCount,
+		},
+		AuthorCreated:   draft.AuthorCreated,
+		TableOfContents: parseTableOfContentDraft(draft.TableOfContents),
+		Warnings:        draft.Warnings,
+	}
+	if draft.Author != nil {
+		res.Author = &AuthorResponse{
+			ID:     draft.Author.ID,
+			Name:   draft.Author.Name,
+			Avatar: draft.Author.Avatar,
+		}
+	}
+	if res.Warnings == nil {
+		res.Warnings = []string{}
+	}
+	return res
+}
+
+func parseTableOfContentDraft(entries []*entity.TableOfContent) []*TableOfContentDraftEntryResponse {
+	res := make([]*TableOfContentDraftEntryResponse, 0, len(entries))
+	for _, entry := range entries {
+		res = append(res, &TableOfContentDraftEntryResponse{
+			Title:      entry.Title,
+			PageNumber: entry.PageNumber,
+			Children:   parseTableOfContentDraft(entry.Children),
+		})
+	}
+	return res
+}
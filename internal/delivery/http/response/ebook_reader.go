@@ -0,0 +1,100 @@
+package response
+
+import "buku-pintar/internal/domain/entity"
+
+// EbookPageResponse is a single page served by the reader
+// Preview is true when the reader is limited to the first preview_page pages
+type EbookPageResponse struct {
+	EbookID     string `json:"ebook_id"`
+	PageNumber  int    `json:"page_number"`
+	Title       string `json:"title,omitempty"`
+	ContentType string `json:"content_type"`
+	Content     string `json:"content"`
+	TotalPages  int64  `json:"total_pages"`
+	PreviewPage int16  `json:"preview_page"`
+	Preview     bool   `json:"preview"`
+	PrevPage    *int   `json:"prev_page"`
+	NextPage    *int   `json:"next_page"`
+}
+
+// EbookPricingResponse tells a reader who hit the end of the preview what the full book costs
+type EbookPricingResponse struct {
+	EbookID       string `json:"ebook_id"`
+	Title         string `json:"title"`
+	Price         int    `json:"price"`
+	DiscountPrice *int   `json:"discount_price"`
+	FinalPrice    int    `json:"final_price"`
+	Currency      string `json:"currency"`
+	PreviewPage   int16  `json:"preview_page"`
+	PageCount     int16  `json:"page_count"`
+}
+
+// EbookPagesImportResponse lists the pages an upload produced, without their content
+type EbookPagesImportResponse struct {
+	EbookID   string                    `json:"ebook_id"`
+	PageCount int                       `json:"page_count"`
+	Pages     []*EbookPageImportSummary `json:"pages"`
+}
+
+type EbookPageImportSummary struct {
+	PageNumber  int    `json:"page_number"`
+	Title       string `json:"title,omitempty"`
+	ContentType string `json:"content_type"`
+}
+
+func ParseEbookPagesImportResponse(ebookID string, pages []*entity.EbookPage) *EbookPagesImportResponse {
+	res := &EbookPagesImportResponse{
+		EbookID:   ebookID,
+		PageCount: len(pages),
+		Pages:     make([]*EbookPageImportSummary, 0, len(pages)),
+	}
+	for _, page := range pages {
+		summary := &EbookPageImportSummary{PageNumber: page.PageNumber, ContentType: page.ContentType}
+		if page.Title != nil {
+			summary.Title = *page.Title
+		}
+		res.Pages = append(res.Pages, summary)
+	}
+	return res
+}
+
+func ParseEbookPageResponse(page *entity.EbookPage, totalPages int64, previewPage int16, preview bool) *EbookPageResponse {
+	res := &EbookPageResponse{
+		EbookID:     page.EbookID,
+		PageNumber:  page.PageNumber,
+		ContentType: page.ContentType,
+		Content:     page.Content,
+		TotalPages:  totalPages,
+		PreviewPage: previewPage,
+		Preview:     preview,
+	}
+	if page.Title != nil {
+		res.Title = *page.Title
+	}
+	if page.PageNumber > 1 {
+		prev := page.PageNumber - 1
+		res.PrevPage = &prev
+	}
+	if int64(page.PageNumber) < totalPages {
+		next := page.PageNumber + 1
+		res.NextPage = &next
+	}
+	return res
+}
+
+func ParseEbookPricingResponse(ebook *entity.Ebook, discount *entity.EbookDiscount, currency string) *EbookPricingResponse {
+	res := &EbookPricingResponse{
+		EbookID:     ebook.ID,
+		Title:       ebook.Title,
+		Price:       ebook.Price,
+		FinalPrice:  ebook.Price,
+		Currency:    currency,
+		PreviewPage: ebook.PreviewPage,
+		PageCount:   ebook.PageCount,
+	}
+	if discount != nil {
+		res.DiscountPrice = &discount.DiscountPrice
+		res.FinalPrice = discount.DiscountPrice
+	}
+	return res
+}
@@ -0,0 +1,25 @@
+package response
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"time"
+)
+
+// ListeningProgressResponse is a user's position in a summary's audio
+// Applied is only set on updates; false means a later position from another device was kept
+type ListeningProgressResponse struct {
+	SummaryID       string    `json:"summary_id"`
+	PositionSeconds int       `json:"position_seconds"`
+	DurationSeconds *int      `json:"duration_seconds,omitempty"`
+	LastListenedAt  time.Time `json:"last_listened_at"`
+	Applied         *bool     `json:"applied,omitempty"`
+}
+
+func ParseListeningProgressResponse(progress *entity.ListeningProgress) *ListeningProgressResponse {
+	return &ListeningProgressResponse{
+		SummaryID:       progress.SummaryID,
+		PositionSeconds: progress.PositionSeconds,
+		DurationSeconds: progress.DurationSeconds,
+		LastListenedAt:  progress.ClientUpdatedAt,
+	}
+}
@@ -0,0 +1,84 @@
+package response
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MediaResponse describes uploaded media; URL is only set for public kinds
+type MediaResponse struct {
+	ID          string           `json:"id"`
+	Kind        entity.MediaKind `json:"kind"`
+	Filename    string           `json:"filename"`
+	ContentType string           `json:"content_type"`
+	Size        int64            `json:"size"`
+	Checksum    string           `json:"checksum"`
+	URL         string           `json:"url,omitempty"`
+	CreatedAt   time.Time        `json:"created_at"`
+
+	Variants *ImageVariantsResponse `json:"variants,omitempty"`
+}
+
+// ImageVariantsResponse lists the resized copies of an image for <img srcset> or <picture> sources
+type ImageVariantsResponse struct {
+	// Srcset holds a ready-made srcset value per format, e.g. "https://cdn/covers/…/thumbnail.jpg 200w, …"
+	Srcset   map[string]string      `json:"srcset"`
+	Variants []ImageVariantResponse `json:"variants"`
+}
+
+type ImageVariantResponse struct {
+	Name   string `json:"name"`
+	Format string `json:"format"`
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+// ParseImageVariantsResponse orders variants by format and width; images without variants give nil
+func ParseImageVariantsResponse(variants entity.MediaVariants) *ImageVariantsResponse {
+	if len(variants) == 0 {
+		return nil
+	}
+
+	sorted := slices.Clone(variants)
+	slices.SortFunc(sorted, func(a, b entity.MediaVariant) int {
+		return cmp.Or(cmp.Compare(a.Format, b.Format), cmp.Compare(a.Width, b.Width))
+	})
+
+	res := &ImageVariantsResponse{
+		Srcset:   map[string]string{},
+		Variants: make([]ImageVariantResponse, 0, len(sorted)),
+	}
+	candidates := map[string][]string{}
+	for _, variant := range sorted {
+		res.Variants = append(res.Variants, ImageVariantResponse{
+			Name:   variant.Name,
+			Format: variant.Format,
+			URL:    variant.URL,
+			Width:  variant.Width,
+			Height: variant.Height,
+		})
+		candidates[variant.Format] = append(candidates[variant.Format], variant.URL+" "+strconv.Itoa(variant.Width)+"w")
+	}
+	for format, list := range candidates {
+		res.Srcset[format] = strings.Join(list, ", ")
+	}
+	return res
+}
+
+func ParseMediaResponse(media *entity.Media, url string) *MediaResponse {
+	return &MediaResponse{
+		ID:          media.ID,
+		Kind:        media.Kind,
+		Filename:    media.Filename,
+		ContentType: media.ContentType,
+		Size:        media.Size,
+		Checksum:    media.Checksum,
+		URL:         url,
+		CreatedAt:   media.CreatedAt,
+	}
+}
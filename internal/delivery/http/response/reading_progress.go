@@ -0,0 +1,63 @@
+package response
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"time"
+)
+
+// ReadingProgressResponse is a user's position in an ebook
+// Applied is only set on updates; false means a later position from another device was kept
+type ReadingProgressResponse struct {
+	EbookID    string    `json:"ebook_id"`
+	Page       int       `json:"page"`
+	CFI        string    `json:"cfi,omitempty"`
+	Percentage float64   `json:"percentage"`
+	LastReadAt time.Time `json:"last_read_at"`
+	Applied    *bool     `json:"applied,omitempty"`
+}
+
+type ContinueReadingEbookResponse struct {
+	ID         string `json:"id"`
+	Title      string `json:"title"`
+	Slug       string `json:"slug"`
+	CoverImage string `json:"cover_image"`
+}
+
+type ContinueReadingResponse struct {
+	Ebook      ContinueReadingEbookResponse `json:"ebook"`
+	Page       int                          `json:"page"`
+	CFI        string                       `json:"cfi,omitempty"`
+	Percentage float64                      `json:"percentage"`
+	LastReadAt time.Time                    `json:"last_read_at"`
+}
+
+func ParseReadingProgressResponse(progress *entity.ReadingProgress) *ReadingProgressResponse {
+	res := &ReadingProgressResponse{
+		EbookID:    progress.EbookID,
+		Page:       progress.Page,
+		Percentage: progress.Percentage,
+		LastReadAt: progress.ClientUpdatedAt,
+	}
+	if progress.CFI != nil {
+		res.CFI = *progress.CFI
+	}
+	return res
+}
+
+func ParseContinueReadingResponse(item *entity.ReadingProgressItem) *ContinueReadingResponse {
+	res := &ContinueReadingResponse{
+		Ebook: ContinueReadingEbookResponse{
+			ID:         item.EbookID,
+			Title:      item.Title,
+			Slug:       item.Slug,
+			CoverImage: item.CoverImage,
+		},
+		Page:       item.Page,
+		Percentage: item.Percentage,
+		LastReadAt: item.ClientUpdatedAt,
+	}
+	if item.CFI != nil {
+		res.CFI = *item.CFI
+	}
+	return res
+}
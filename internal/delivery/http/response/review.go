@@ -0,0 +1,47 @@
+package response
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"time"
+)
+
+type ReviewerResponse struct {
+	ID     string  `json:"id"`
+	Name   string  `json:"name"`
+	Avatar *string `json:"avatar"`
+}
+
+// ReviewResponse is the public view of a review; moderation details are left out
+type ReviewResponse struct {
+	ID        string           `json:"id"`
+	EbookID   string           `json:"ebook_id"`
+	Rating    int              `json:"rating"`
+	Body      *string          `json:"body"`
+	Reviewer  ReviewerResponse `json:"reviewer"`
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
+}
+
+func ParseReviewResponse(review *entity.Review) *ReviewResponse {
+	return &ReviewResponse{
+		ID:      review.ID,
+		EbookID: review.EbookID,
+		Rating:  review.Rating,
+		Body:    review.Body,
+		Reviewer: ReviewerResponse{
+			ID:     review.UserID,
+			Name:   review.ReviewerName,
+			Avatar: review.ReviewerAvatar,
+		},
+		CreatedAt: review.CreatedAt,
+		UpdatedAt: review.UpdatedAt,
+	}
+}
+
+func ParseReviewListResponse(reviews []*entity.Review) []*ReviewResponse {
+	res := make([]*ReviewResponse, 0, len(reviews))
+	for _, review := range reviews {
+		res = append(res, ParseReviewResponse(review))
+	}
+	return res
+}
@@ -0,0 +1,81 @@
+package response
+
+import "buku-pintar/internal/domain/entity"
+
+type SeriesResponse struct {
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	Slug        string  `json:"slug"`
+	Description *string `json:"description"`
+	CoverImage  string  `json:"cover_image"`
+	VolumeCount int     `json:"volume_count"`
+}
+
+type SeriesVolumeResponse struct {
+	Volume     int    `json:"volume"`
+	EbookID    string `json:"ebook_id"`
+	Title      string `json:"title"`
+	Slug       string `json:"slug"`
+	CoverImage string `json:"cover_image"`
+	Price      int    `json:"price"`
+}
+
+type SeriesDetailResponse struct {
+	SeriesResponse
+	Volumes []*SeriesVolumeResponse `json:"volumes"`
+}
+
+// EbookSeriesResponse places an ebook in its series: part Volume of VolumeCount
+type EbookSeriesResponse struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Slug        string `json:"slug"`
+	Volume      int    `json:"volume"`
+	VolumeCount int    `json:"volume_count"`
+}
+
+type SeriesNextResponse struct {
+	Series    *EbookSeriesResponse  `json:"series"`
+	Next      *SeriesVolumeResponse `json:"next"`
+	InLibrary bool                  `json:"in_library"`
+}
+
+func ParseSeriesResponse(series *entity.Series) *SeriesResponse {
+	return &SeriesResponse{
+		ID:          series.ID,
+		Name:        series.Name,
+		Slug:        series.Slug,
+		Description: series.Description,
+		CoverImage:  series.CoverImage,
+		VolumeCount: series.VolumeCount,
+	}
+}
+
+func ParseSeriesVolumeResponses(volumes []*entity.SeriesVolume) []*SeriesVolumeResponse {
+	res := make([]*SeriesVolumeResponse, 0, len(volumes))
+	for _, volume := range volumes {
+		res = append(res, ParseSeriesVolumeResponse(volume))
+	}
+	return res
+}
+
+func ParseSeriesVolumeResponse(volume *entity.SeriesVolume) *SeriesVolumeResponse {
+	return &SeriesVolumeResponse{
+		Volume:     volume.Volume,
+		EbookID:    volume.EbookID,
+		Title:      volume.Title,
+		Slug:       volume.Slug,
+		CoverImage: volume.CoverImage,
+		Price:      volume.Price,
+	}
+}
+
+func ParseEbookSeriesResponse(membership *entity.SeriesMembership) *EbookSeriesResponse {
+	return &EbookSeriesResponse{
+		ID:          membership.SeriesID,
+		Name:        membership.SeriesName,
+		Slug:        membership.SeriesSlug,
+		Volume:      membership.Volume,
+		VolumeCount: membership.VolumeCount,
+	}
+}
@@ -1,8 +1,28 @@
 package response
 
+import "buku-pintar/internal/domain/entity"
+
+// TableOfContentResponse is a table of contents entry with its nested chapters
 type TableOfContentResponse struct {
-	ID         string `json:"id"`
-	EbookID    string `json:"ebook_id"`
-	Title      string `json:"title"`
-	PageNumber int16  `json:"page_number"`
+	ID         string                    `json:"id"`
+	EbookID    string                    `json:"ebook_id"`
+	Title      string                    `json:"title"`
+	PageNumber int16                     `json:"page_number"`
+	Depth      int                       `json:"depth"`
+	Children   []*TableOfContentResponse `json:"children"`
+}
+
+func ParseTableOfContentTree(entries []*entity.TableOfContent) []*TableOfContentResponse {
+	res := make([]*TableOfContentResponse, 0, len(entries))
+	for _, entry := range entries {
+		res = append(res, &TableOfContentResponse{
+			ID:         entry.ID,
+			EbookID:    entry.EbookID,
+			Title:      entry.Title,
+			PageNumber: entry.PageNumber,
+			Depth:      entry.Depth,
+			Children:   ParseTableOfContentTree(entry.Children),
+		})
+	}
+	return res
 }
@@ -0,0 +1,33 @@
+package response
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"time"
+)
+
+// TranslationResponse is the text of a piece of content in one locale
+type TranslationResponse struct {
+	ContentType entity.TranslatableContent `json:"content_type"`
+	ContentID   string                     `json:"content_id"`
+	Locale      string                     `json:"locale"`
+	Fields      map[string]string          `json:"fields"`
+	UpdatedAt   time.Time                  `json:"updated_at"`
+}
+
+func ParseTranslationResponse(translation *entity.Translation) *TranslationResponse {
+	return &TranslationResponse{
+		ContentType: translation.ContentType,
+		ContentID:   translation.ContentID,
+		Locale:      translation.Locale,
+		Fields:      translation.Fields,
+		UpdatedAt:   translation.UpdatedAt,
+	}
+}
+
+func ParseTranslationListResponse(translations []*entity.Translation) []*TranslationResponse {
+	responses := make([]*TranslationResponse, 0, len(translations))
+	for _, translation := range translations {
+		responses = append(responses, ParseTranslationResponse(translation))
+	}
+	return responses
+}
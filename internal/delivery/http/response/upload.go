@@ -0,0 +1,29 @@
+package response
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"time"
+)
+
+// UploadResponse describes a resumable upload; MediaID is set once it is complete
+type UploadResponse struct {
+	ID        string           `json:"id"`
+	Kind      entity.MediaKind `json:"kind"`
+	Filename  string           `json:"filename"`
+	Length    int64            `json:"length"`
+	Offset    int64            `json:"offset"`
+	MediaID   *string          `json:"media_id"`
+	ExpiresAt time.Time        `json:"expires_at"`
+}
+
+func ParseUploadResponse(upload *entity.Upload) *UploadResponse {
+	return &UploadResponse{
+		ID:        upload.ID,
+		Kind:      upload.Kind,
+		Filename:  upload.Filename,
+		Length:    upload.Length,
+		Offset:    upload.Offset,
+		MediaID:   upload.MediaID,
+		ExpiresAt: upload.ExpiresAt,
+	}
+}
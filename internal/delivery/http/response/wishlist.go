@@ -0,0 +1,33 @@
+package response
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"time"
+)
+
+// WishlistItemResponse is a wishlisted ebook with the price the user would pay today
+type WishlistItemResponse struct {
+	Ebook         ContinueReadingEbookResponse `json:"ebook"`
+	Price         int                          `json:"price"`
+	DiscountPrice *int                         `json:"discount_price,omitempty"`
+	AddedAt       time.Time                    `json:"added_at"`
+}
+
+type WishlistCountResponse struct {
+	EbookID string `json:"ebook_id"`
+	Count   int64  `json:"count"`
+}
+
+func ParseWishlistItemResponse(item *entity.WishlistItem) *WishlistItemResponse {
+	return &WishlistItemResponse{
+		Ebook: ContinueReadingEbookResponse{
+			ID:         item.EbookID,
+			Title:      item.Title,
+			Slug:       item.Slug,
+			CoverImage: item.CoverImage,
+		},
+		Price:         item.Price,
+		DiscountPrice: item.DiscountPrice,
+		AddedAt:       item.CreatedAt,
+	}
+}
@@ -0,0 +1,244 @@
+package http
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/delivery/http/middleware"
+	"buku-pintar/internal/delivery/http/response"
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/helper"
+	"buku-pintar/internal/usecase"
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+type ReviewHandler struct {
+	reviewUsecase usecase.ReviewUsecase
+}
+
+func NewReviewHandler(reviewUsecase usecase.ReviewUsecase) *ReviewHandler {
+	return &ReviewHandler{
+		reviewUsecase: reviewUsecase,
+	}
+}
+
+// ReviewRequest is the body for creating or editing a review
+// ebook_id is only read on create
+type ReviewRequest struct {
+	EbookID string  `json:"ebook_id"`
+	Rating  int     `json:"rating"`
+	Body    *string `json:"body"`
+}
+
+type ReportReviewRequest struct {
+	Reason *string `json:"reason"`
+}
+
+type ModerateReviewRequest struct {
+	Hidden bool `json:"hidden"`
+}
+
+// ListEbookReviews handles GET /ebooks/{id}/reviews - List the visible reviews of an ebook
+func (h *ReviewHandler) ListEbookReviews(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	ebookID := ebookSubresourceID(r)
+	limit, offset := helper.HandlePagination(r)
+
+	reviews, err := h.reviewUsecase.ListEbookReviews(r.Context(), ebookID, limit, offset)
+	if err != nil {
+		writeReviewError(w, err)
+		return
+	}
+
+	total, err := h.reviewUsecase.CountEbookReviews(r.Context(), ebookID)
+	if err != nil {
+		response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+		return
+	}
+
+	response.WritePaginatedMeta(w, r, response.ParseReviewListResponse(reviews), response.NewOffsetMeta(total, limit, offset))
+}
+
+// CreateReview handles POST /reviews/create - Rate and review an accessible or read ebook
+func (h *ReviewHandler) CreateReview(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil || user == nil {
+		response.WriteError(w, http.StatusUnauthorized, "unauthorized", "Authentication required")
+		return
+	}
+
+	var req ReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "invalid_request_body", err.Error())
+		return
+	}
+
+	review := &entity.Review{
+		EbookID:        req.EbookID,
+		Rating:         req.Rating,
+		Body:           req.Body,
+		ReviewerName:   user.Name,
+		ReviewerAvatar: user.Avatar,
+	}
+	if err := h.reviewUsecase.CreateReview(r.Context(), user, review); err != nil {
+		writeReviewError(w, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusCreated, response.ParseReviewResponse(review), "Review created successfully")
+}
+
+// UpdateReview handles PUT /reviews/edit/{id} - Edit the user's own review
+func (h *ReviewHandler) UpdateReview(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil || user == nil {
+		response.WriteError(w, http.StatusUnauthorized, "unauthorized", "Authentication required")
+		return
+	}
+
+	var req ReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "invalid_request_body", err.Error())
+		return
+	}
+
+	review := &entity.Review{
+		ID:     lastPathSegment(r),
+		UserID: user.ID,
+		Rating: req.Rating,
+		Body:   req.Body,
+	}
+	updated, err := h.reviewUsecase.UpdateReview(r.Context(), review)
+	if err != nil {
+		writeReviewError(w, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, response.ParseReviewResponse(updated), "Review updated successfully")
+}
+
+// ReportReview handles POST /reviews/report/{id} - Flag a review for moderation
+func (h *ReviewHandler) ReportReview(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil || user == nil {
+		response.WriteError(w, http.StatusUnauthorized, "unauthorized", "Authentication required")
+		return
+	}
+
+	// The reason is optional, so an empty body is accepted
+	var req ReportReviewRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			response.WriteError(w, http.StatusBadRequest, "invalid_request_body", err.Error())
+			return
+		}
+	}
+
+	report := &entity.ReviewReport{
+		ReviewID: lastPathSegment(r),
+		UserID:   user.ID,
+		Reason:   req.Reason,
+	}
+	if err := h.reviewUsecase.ReportReview(r.Context(), report); err != nil {
+		writeReviewError(w, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, nil, "Review reported successfully")
+}
+
+// ListReportedReviews handles GET /reviews/reported - Moderation queue of reported reviews
+func (h *ReviewHandler) ListReportedReviews(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	limit, offset := helper.HandlePagination(r)
+
+	reviews, err := h.reviewUsecase.ListReportedReviews(r.Context(), limit, offset)
+	if err != nil {
+		writeReviewError(w, err)
+		return
+	}
+
+	total, err := h.reviewUsecase.CountReportedReviews(r.Context())
+	if err != nil {
+		response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+		return
+	}
+
+	if reviews == nil {
+		reviews = []*entity.Review{}
+	}
+	response.WritePaginatedMeta(w, r, reviews, response.NewOffsetMeta(total, limit, offset))
+}
+
+// ModerateReview handles PUT /reviews/moderate/{id} - Hide or show a review
+func (h *ReviewHandler) ModerateReview(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil || user == nil {
+		response.WriteError(w, http.StatusUnauthorized, "unauthorized", "Authentication required")
+		return
+	}
+
+	var req ModerateReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "invalid_request_body", err.Error())
+		return
+	}
+
+	review, err := h.reviewUsecase.ModerateReview(r.Context(), user.ID, lastPathSegment(r), req.Hidden)
+	if err != nil {
+		writeReviewError(w, err)
+		return
+	}
+
+	message := "Review is visible"
+	if req.Hidden {
+		message = "Review hidden"
+	}
+	response.WriteSuccess(w, http.StatusOK, review, message)
+}
+
+func writeReviewError(w http.ResponseWriter, err error) {
+	var validationErr *usecase.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		response.WriteError(w, http.StatusBadRequest, "validation_error", err.Error())
+	case errors.Is(err, usecase.ErrEbookNotFound):
+		response.WriteError(w, http.StatusNotFound, "ebook_not_found", err.Error())
+	case errors.Is(err, usecase.ErrReviewNotFound):
+		response.WriteError(w, http.StatusNotFound, "review_not_found", err.Error())
+	case errors.Is(err, usecase.ErrReviewNotEligible):
+		response.WriteError(w, http.StatusForbidden, "review_not_eligible", err.Error())
+	case errors.Is(err, usecase.ErrReviewAlreadyExists):
+		response.WriteError(w, http.StatusConflict, "review_already_exists", err.Error())
+	default:
+		response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+	}
+}
@@ -1,55 +1,126 @@
 package http
 
 import (
+	"buku-pintar/internal/constant"
 	"buku-pintar/internal/delivery/http/middleware"
 	"buku-pintar/internal/delivery/http/response"
 	"buku-pintar/internal/domain/entity"
 	"net/http"
+	"strings"
 )
 
 const apiV1Prefix = "/api/v1"
 
 // Router handles all route definitions
 type Router struct {
-	bannerHandler        *BannerHandler
-	categoryHandler      *CategoryHandler
-	ebookHandler         *EbookHandler
-	summaryHandler       *SummaryHandler
-	authHandler          *AuthHandler
-	userHandler          *UserHandler
-	paymentHandler       *PaymentHandler
-	authMiddleware       *middleware.AuthMiddleware
-	roleMiddleware       *middleware.RoleMiddleware
-	permissionMiddleware *middleware.PermissionMiddleware
+	bannerHandler         *BannerHandler
+	categoryHandler       *CategoryHandler
+	ebookHandler          *EbookHandler
+	summaryHandler        *SummaryHandler
+	authHandler           *AuthHandler
+	userHandler           *UserHandler
+	paymentHandler        *PaymentHandler
+	downloadHandler       *EbookDownloadHandler
+	readerHandler         *EbookReaderHandler
+	progressHandler       *ReadingProgressHandler
+	annotationHandler     *AnnotationHandler
+	reviewHandler         *ReviewHandler
+	wishlistHandler       *WishlistHandler
+	notificationHandler   *NotificationHandler
+	recommendationHandler *RecommendationHandler
+	tocHandler            *TableOfContentHandler
+	epubIngestHandler     *EpubIngestHandler
+	mediaHandler          *MediaHandler
+	uploadHandler         *UploadHandler
+	scheduleHandler       *ContentScheduleHandler
+	translationHandler    *TranslationHandler
+	catalogImportHandler  *CatalogImportHandler
+	opdsHandler           *OPDSHandler
+	sitemapHandler        *SitemapHandler
+	feedHandler           *FeedHandler
+	seriesHandler         *SeriesHandler
+	contributorHandler    *EbookContributorHandler
+	trendingHandler       *TrendingHandler
+	analyticsHandler      *AnalyticsHandler
+	summaryAudioHandler   *SummaryAudioHandler
+	authMiddleware        *middleware.AuthMiddleware
+	roleMiddleware        *middleware.RoleMiddleware
+	permissionMiddleware  *middleware.PermissionMiddleware
 }
 
 // RouterConfig groups route handlers and middleware for router construction.
 type RouterConfig struct {
-	BannerHandler        *BannerHandler
-	CategoryHandler      *CategoryHandler
-	EbookHandler         *EbookHandler
-	SummaryHandler       *SummaryHandler
-	AuthHandler          *AuthHandler
-	UserHandler          *UserHandler
-	PaymentHandler       *PaymentHandler
-	AuthMiddleware       *middleware.AuthMiddleware
-	RoleMiddleware       *middleware.RoleMiddleware
-	PermissionMiddleware *middleware.PermissionMiddleware
+	BannerHandler         *BannerHandler
+	CategoryHandler       *CategoryHandler
+	EbookHandler          *EbookHandler
+	SummaryHandler        *SummaryHandler
+	AuthHandler           *AuthHandler
+	UserHandler           *UserHandler
+	PaymentHandler        *PaymentHandler
+	DownloadHandler       *EbookDownloadHandler
+	ReaderHandler         *EbookReaderHandler
+	ProgressHandler       *ReadingProgressHandler
+	AnnotationHandler     *AnnotationHandler
+	ReviewHandler         *ReviewHandler
+	WishlistHandler       *WishlistHandler
+	NotificationHandler   *NotificationHandler
+	RecommendationHandler *RecommendationHandler
+	TableOfContentHandler *TableOfContentHandler
+	EpubIngestHandler     *EpubIngestHandler
+	MediaHandler          *MediaHandler
+	UploadHandler         *UploadHandler
+	ScheduleHandler       *ContentScheduleHandler
+	TranslationHandler    *TranslationHandler
+	CatalogImportHandler  *CatalogImportHandler
+	OPDSHandler           *OPDSHandler
+	SitemapHandler        *SitemapHandler
+	FeedHandler           *FeedHandler
+	SeriesHandler         *SeriesHandler
+	ContributorHandler    *EbookContributorHandler
+	TrendingHandler       *TrendingHandler
+	AnalyticsHandler      *AnalyticsHandler
+	SummaryAudioHandler   *SummaryAudioHandler
+	AuthMiddleware        *middleware.AuthMiddleware
+	RoleMiddleware        *middleware.RoleMiddleware
+	PermissionMiddleware  *middleware.PermissionMiddleware
 }
 
 // NewRouter creates a new router instance
 func NewRouter(config RouterConfig) *Router {
 	return &Router{
-		bannerHandler:        config.BannerHandler,
-		categoryHandler:      config.CategoryHandler,
-		ebookHandler:         config.EbookHandler,
-		summaryHandler:       config.SummaryHandler,
-		authHandler:          config.AuthHandler,
-		userHandler:          config.UserHandler,
-		paymentHandler:       config.PaymentHandler,
-		authMiddleware:       config.AuthMiddleware,
-		roleMiddleware:       config.RoleMiddleware,
-		permissionMiddleware: config.PermissionMiddleware,
+		bannerHandler:         config.BannerHandler,
+		categoryHandler:       config.CategoryHandler,
+		ebookHandler:          config.EbookHandler,
+		summaryHandler:        config.SummaryHandler,
+		authHandler:           config.AuthHandler,
+		userHandler:           config.UserHandler,
+		paymentHandler:        config.PaymentHandler,
+		downloadHandler:       config.DownloadHandler,
+		readerHandler:         config.ReaderHandler,
+		progressHandler:       config.ProgressHandler,
+		annotationHandler:     config.AnnotationHandler,
+		reviewHandler:         config.ReviewHandler,
+		wishlistHandler:       config.WishlistHandler,
+		notificationHandler:   config.NotificationHandler,
+		recommendationHandler: config.RecommendationHandler,
+		tocHandler:            config.TableOfContentHandler,
+		epubIngestHandler:     config.EpubIngestHandler,
+		mediaHandler:          config.MediaHandler,
+		uploadHandler:         config.UploadHandler,
+		scheduleHandler:       config.ScheduleHandler,
+		translationHandler:    config.TranslationHandler,
+		catalogImportHandler:  config.CatalogImportHandler,
+		opdsHandler:           config.OPDSHandler,
+		sitemapHandler:        config.SitemapHandler,
+		feedHandler:           config.FeedHandler,
+		seriesHandler:         config.SeriesHandler,
+		contributorHandler:    config.ContributorHandler,
+		trendingHandler:       config.TrendingHandler,
+		analyticsHandler:      config.AnalyticsHandler,
+		summaryAudioHandler:   config.SummaryAudioHandler,
+		authMiddleware:        config.AuthMiddleware,
+		roleMiddleware:        config.RoleMiddleware,
+		permissionMiddleware:  config.PermissionMiddleware,
 	}
 }
 
@@ -58,6 +129,40 @@ func NotFoundHandler(w http.ResponseWriter, r *http.Request) {
 	response.WriteError(w, http.StatusNotFound, "page_not_found", "The requested page was not found")
 }
 
+// subresourceRoutes dispatches /ebooks/{id}/{resource} requests by method and resource name.
+// A single wildcard pattern is used because ServeMux rejects /ebooks/{id}/download
+// alongside /ebooks/slug/{slug} as conflicting patterns.
+type subresourceRoutes map[string]http.Handler
+
+func (s subresourceRoutes) handle(method, resource string, handler http.Handler) {
+	s[method+" "+resource] = handler
+}
+
+func (s subresourceRoutes) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	resource := req.PathValue("resource")
+	method := req.Method
+	if method == http.MethodHead {
+		method = http.MethodGet
+	}
+	if handler, ok := s[method+" "+resource]; ok {
+		handler.ServeHTTP(w, req)
+		return
+	}
+
+	var allowed []string
+	for _, m := range []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+		if _, ok := s[m+" "+resource]; ok {
+			allowed = append(allowed, m)
+		}
+	}
+	if len(allowed) == 0 {
+		NotFoundHandler(w, req)
+		return
+	}
+	w.Header().Set("Allow", strings.Join(allowed, ", "))
+	response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+}
+
 // SetupRoutes configures all routes and returns the configured mux.
 func (r *Router) SetupRoutes() *http.ServeMux {
 	mux := &http.ServeMux{}
@@ -83,13 +188,66 @@ func (r *Router) SetupRoutes() *http.ServeMux {
 	// Ebook routes (public read)
 	mux.HandleFunc(apiV1("/ebooks"), r.ebookHandler.ListEbooks)
 	mux.HandleFunc(apiV1("/ebooks/{id}"), r.ebookHandler.GetEbookByID)
-	mux.HandleFunc(apiV1("/ebooks/slug/{slug}"), r.ebookHandler.GetEbookBySlug)
+	mux.Handle(apiV1("/ebooks/slug/{slug}"), r.authMiddleware.OptionalAuthenticate(http.HandlerFunc(r.ebookHandler.GetEbookBySlug)))
+	mux.HandleFunc(apiV1("/ebooks/trending"), r.trendingHandler.ListTrendingEbooks)
+
+	// Ebook sub-resources, each with its own access rules
+	ebookResources := subresourceRoutes{}
+	mux.Handle(apiV1("/ebooks/{id}/{resource}"), ebookResources)
+
+	// Ebook file stream (access is granted by the signed URL, not a session)
+	ebookResources.handle(http.MethodGet, "file", http.HandlerFunc(r.downloadHandler.StreamFile))
+
+	// Ebook reader (preview pages are public, the rest needs ownership or premium)
+	ebookResources.handle(http.MethodGet, "read", r.authMiddleware.OptionalAuthenticate(http.HandlerFunc(r.readerHandler.ReadPage)))
+
+	// Reader pages, imported from the spine of an uploaded EPUB
+	ebookResources.handle(http.MethodPut, "pages",
+		r.authMiddleware.Authenticate(
+			r.permissionMiddleware.CheckPermission(entity.PermissionEbookUpdate)(
+				http.HandlerFunc(r.epubIngestHandler.ImportPages))))
+
+	// Ebook reviews (public read, hidden reviews are left out)
+	ebookResources.handle(http.MethodGet, "reviews", http.HandlerFunc(r.reviewHandler.ListEbookReviews))
+
+	// Table of contents (public read of published ebooks, returned as a tree)
+	ebookResources.handle(http.MethodGet, "toc", r.authMiddleware.OptionalAuthenticate(http.HandlerFunc(r.tocHandler.GetTableOfContents)))
+
+	// Contributors: authors, translators, editors and narrators (public read)
+	ebookResources.handle(http.MethodGet, "contributors", http.HandlerFunc(r.contributorHandler.GetContributors))
+
+	// Related ebooks (public, precomputed)
+	ebookResources.handle(http.MethodGet, "related", http.HandlerFunc(r.recommendationHandler.ListRelatedEbooks))
+
+	// Next volume of the ebook's series, with whether it is in the caller's library when signed in
+	ebookResources.handle(http.MethodGet, "next-in-series", r.authMiddleware.OptionalAuthenticate(http.HandlerFunc(r.seriesHandler.GetNextInSeries)))
+
+	// OPDS catalog for e-reader apps (public, signed-in users get download links for what they own)
+	mux.Handle(apiV1("/opds"), r.authMiddleware.OptionalAuthenticate(http.HandlerFunc(r.opdsHandler.Root)))
+	mux.Handle(apiV1("/opds/categories"), r.authMiddleware.OptionalAuthenticate(http.HandlerFunc(r.opdsHandler.ListCategories)))
+	mux.Handle(apiV1("/opds/categories/{id}"), r.authMiddleware.OptionalAuthenticate(http.HandlerFunc(r.opdsHandler.ListCategoryEbooks)))
+	mux.Handle(apiV1("/opds/new"), r.authMiddleware.OptionalAuthenticate(http.HandlerFunc(r.opdsHandler.ListNew)))
+	mux.Handle(apiV1("/opds/discounted"), r.authMiddleware.OptionalAuthenticate(http.HandlerFunc(r.opdsHandler.ListDiscounted)))
+	mux.Handle(apiV1("/opds/authors/{id}"), r.authMiddleware.OptionalAuthenticate(http.HandlerFunc(r.opdsHandler.ListAuthorEbooks)))
+	mux.Handle(apiV1("/opds/search"), r.authMiddleware.OptionalAuthenticate(http.HandlerFunc(r.opdsHandler.Search)))
+	mux.HandleFunc(apiV1("/opds/opensearch.xml"), r.opdsHandler.OpenSearchDescription)
+
+	// Series routes (public read, series with published volumes only)
+	mux.HandleFunc(apiV1("/series"), r.seriesHandler.ListSeries)
+	mux.HandleFunc(apiV1("/series/{slug}"), r.seriesHandler.GetSeriesBySlug)
 
 	// Summary routes (public read)
 	mux.HandleFunc(apiV1("/summaries"), r.summaryHandler.ListSummaries)
 	mux.HandleFunc(apiV1("/summaries/{id}"), r.summaryHandler.GetSummaryByID)
 	mux.HandleFunc(apiV1("/summaries/ebook/{ebookID}"), r.summaryHandler.GetSummariesByEbookID)
 
+	// Summary sub-resources, each with its own access rules
+	summaryResources := subresourceRoutes{}
+	mux.Handle(apiV1("/summaries/{id}/{resource}"), summaryResources)
+
+	// Summary audio stream (regular summaries are public, premium ones need premium access)
+	summaryResources.handle(http.MethodGet, "audio", r.authMiddleware.OptionalAuthenticate(http.HandlerFunc(r.summaryAudioHandler.StreamAudio)))
+
 	// Auth routes (public)
 	mux.HandleFunc(apiV1("/auth/register"), r.authHandler.Register)
 	mux.HandleFunc(apiV1("/auth/verify-email"), r.authHandler.VerifyEmail)
@@ -97,6 +255,21 @@ func (r *Router) SetupRoutes() *http.ServeMux {
 	// Payment callback (public - webhook)
 	mux.HandleFunc(apiV1("/payments/callback"), r.paymentHandler.HandleXenditCallback)
 
+	// RSS and Atom feeds of the published articles, inspirations and ebooks
+	mux.HandleFunc(apiV1("/feeds/{content}/{format}"), r.feedHandler.GetFeed)
+	mux.HandleFunc(apiV1("/feeds/{content}/categories/{id}/{format}"), r.feedHandler.GetCategoryFeed)
+	mux.HandleFunc(apiV1("/feeds/{content}/authors/{id}/{format}"), r.feedHandler.GetAuthorFeed)
+
+	// Usage events from the apps (public, events of signed-in users are attributed to them)
+	mux.Handle(apiV1("/events"), r.authMiddleware.OptionalAuthenticate(http.HandlerFunc(r.analyticsHandler.TrackEvents)))
+
+	// Sitemaps of the public content, for search engines crawling the storefront
+	mux.HandleFunc("/sitemap.xml", r.sitemapHandler.GetSitemapIndex)
+	mux.HandleFunc("/sitemaps/{name}", r.sitemapHandler.GetSitemap)
+
+	// Public media stored by the local driver (covers, audio, banners and icons; never ebook files)
+	mux.HandleFunc("/media/{key...}", r.mediaHandler.ServeMedia)
+
 	// ============================================================================
 	// AUTHENTICATED USER ROUTES - Requires authentication only
 	// ============================================================================
@@ -105,8 +278,42 @@ func (r *Router) SetupRoutes() *http.ServeMux {
 	mux.Handle(apiV1("/users"), r.authMiddleware.Authenticate(http.HandlerFunc(r.userHandler.GetUser)))
 	mux.Handle(apiV1("/users/update"), r.authMiddleware.Authenticate(http.HandlerFunc(r.userHandler.UpdateUser)))
 	mux.Handle(apiV1("/users/delete"), r.authMiddleware.Authenticate(http.HandlerFunc(r.userHandler.DeleteUser)))
+	mux.Handle(apiV1("/users/downloads"), r.authMiddleware.Authenticate(http.HandlerFunc(r.downloadHandler.ListDownloads)))
+	mux.Handle(apiV1("/users/continue-reading"), r.authMiddleware.Authenticate(http.HandlerFunc(r.progressHandler.ListContinueReading)))
+	mux.Handle(apiV1("/users/wishlist"), r.authMiddleware.Authenticate(http.HandlerFunc(r.wishlistHandler.ListWishlist)))
+	mux.Handle(apiV1("/users/notifications"), r.authMiddleware.Authenticate(http.HandlerFunc(r.notificationHandler.ListNotifications)))
+	mux.Handle(apiV1("/users/recommendations"), r.authMiddleware.Authenticate(http.HandlerFunc(r.recommendationHandler.ListUserRecommendations)))
+
+	// Annotation routes (scoped to the owner)
+	mux.Handle(apiV1("/annotations"), r.authMiddleware.Authenticate(http.HandlerFunc(r.annotationHandler.ListAnnotations)))
+	mux.Handle(apiV1("/annotations/create"), r.authMiddleware.Authenticate(http.HandlerFunc(r.annotationHandler.CreateAnnotation)))
+	mux.Handle(apiV1("/annotations/{id}"), r.authMiddleware.Authenticate(http.HandlerFunc(r.annotationHandler.GetAnnotation)))
+	mux.Handle(apiV1("/annotations/edit/{id}"), r.authMiddleware.Authenticate(http.HandlerFunc(r.annotationHandler.UpdateAnnotation)))
+	mux.Handle(apiV1("/annotations/delete/{id}"), r.authMiddleware.Authenticate(http.HandlerFunc(r.annotationHandler.DeleteAnnotation)))
+	mux.Handle(apiV1("/annotations/export/{ebookID}"), r.authMiddleware.Authenticate(http.HandlerFunc(r.annotationHandler.ExportAnnotations)))
+
+	// Review routes (owners and readers of the ebook may review it once)
+	mux.Handle(apiV1("/reviews/create"), r.authMiddleware.Authenticate(http.HandlerFunc(r.reviewHandler.CreateReview)))
+	mux.Handle(apiV1("/reviews/edit/{id}"), r.authMiddleware.Authenticate(http.HandlerFunc(r.reviewHandler.UpdateReview)))
+	mux.Handle(apiV1("/reviews/report/{id}"), r.authMiddleware.Authenticate(http.HandlerFunc(r.reviewHandler.ReportReview)))
+
+	// Ebook downloads (owners and premium users)
+	ebookResources.handle(http.MethodPost, "download", r.authMiddleware.Authenticate(http.HandlerFunc(r.downloadHandler.RequestDownload)))
+
+	// Reading progress sync (per user)
+	ebookResources.handle(http.MethodGet, "progress", r.authMiddleware.Authenticate(http.HandlerFunc(r.progressHandler.GetProgress)))
+	ebookResources.handle(http.MethodPut, "progress", r.authMiddleware.Authenticate(http.HandlerFunc(r.progressHandler.UpdateProgress)))
+
+	// Listening progress sync of summary audio (per user)
+	summaryResources.handle(http.MethodGet, "listening-progress", r.authMiddleware.Authenticate(http.HandlerFunc(r.summaryAudioHandler.GetListeningProgress)))
+	summaryResources.handle(http.MethodPut, "listening-progress", r.authMiddleware.Authenticate(http.HandlerFunc(r.summaryAudioHandler.UpdateListeningProgress)))
+
+	// Wishlist (per user, price drops of wishlisted ebooks are sent as notifications)
+	ebookResources.handle(http.MethodPost, "wishlist", r.authMiddleware.Authenticate(http.HandlerFunc(r.wishlistHandler.AddToWishlist)))
+	ebookResources.handle(http.MethodDelete, "wishlist", r.authMiddleware.Authenticate(http.HandlerFunc(r.wishlistHandler.RemoveFromWishlist)))
 
 	// Payment routes (authenticated users)
+	mux.Handle(apiV1("/payments"), r.authMiddleware.Authenticate(http.HandlerFunc(r.paymentHandler.ListPayments)))
 	mux.Handle(apiV1("/payments/initiate"), r.authMiddleware.Authenticate(http.HandlerFunc(r.paymentHandler.InitiatePayment)))
 
 	// ============================================================================
@@ -165,6 +372,140 @@ func (r *Router) SetupRoutes() *http.ServeMux {
 			r.permissionMiddleware.CheckPermission(entity.PermissionSummaryDelete)(
 				http.HandlerFunc(r.summaryHandler.DeleteSummary))))
 
+	// Review moderation (requires review:manage permission)
+	mux.Handle(apiV1("/reviews/reported"),
+		r.authMiddleware.Authenticate(
+			r.permissionMiddleware.CheckPermission(entity.PermissionReviewManage)(
+				http.HandlerFunc(r.reviewHandler.ListReportedReviews))))
+
+	mux.Handle(apiV1("/reviews/moderate/{id}"),
+		r.authMiddleware.Authenticate(
+			r.permissionMiddleware.CheckPermission(entity.PermissionReviewManage)(
+				http.HandlerFunc(r.reviewHandler.ModerateReview))))
+
+	// EPUB ingestion, returns a pre-filled draft (requires ebook:create permission)
+	mux.Handle(apiV1("/ebooks/epub"),
+		r.authMiddleware.Authenticate(
+			r.permissionMiddleware.CheckPermission(entity.PermissionEbookCreate)(
+				http.HandlerFunc(r.epubIngestHandler.IngestEpub))))
+
+	// Bulk catalog import, which may create authors and categories as well as create and update ebooks
+	mux.Handle(apiV1("/ebooks/import"),
+		r.authMiddleware.Authenticate(
+			r.permissionMiddleware.CheckAllPermissions(
+				entity.PermissionEbookCreate, entity.PermissionEbookUpdate,
+				entity.PermissionAuthorCreate, entity.PermissionCategoryCreate)(
+				http.HandlerFunc(r.catalogImportHandler.ImportCatalog))))
+
+	// Media uploads, each kind guarded by the permission to create the content it belongs to
+	for _, kind := range []entity.MediaKind{entity.MediaCover, entity.MediaEbookFile, entity.MediaAudio, entity.MediaBanner, entity.MediaIcon} {
+		mux.Handle(apiV1("/media/upload/"+string(kind)),
+			r.authMiddleware.Authenticate(
+				r.permissionMiddleware.CheckPermission(kind.UploadPermission())(
+					http.HandlerFunc(r.mediaHandler.UploadMedia))))
+	}
+
+	// Media details for anyone who can upload some kind of media
+	mux.Handle(apiV1("/media/{id}"),
+		r.authMiddleware.Authenticate(
+			r.permissionMiddleware.CheckAnyPermission(
+				entity.PermissionEbookCreate, entity.PermissionSummaryCreate,
+				entity.PermissionBannerCreate, entity.PermissionCategoryCreate)(
+				http.HandlerFunc(r.mediaHandler.GetMedia))))
+
+	// Resumable uploads (tus 1.0) for anyone who can upload some kind of media; the kind's own
+	// permission is checked when an upload is created. OPTIONS is open so clients can discover the protocol.
+	mux.HandleFunc("OPTIONS "+apiV1("/uploads"), r.uploadHandler.Options)
+	mux.HandleFunc("OPTIONS "+apiV1("/uploads/{id}"), r.uploadHandler.Options)
+	mux.Handle(apiV1("/uploads"),
+		r.authMiddleware.Authenticate(
+			r.permissionMiddleware.CheckAnyPermission(
+				entity.PermissionEbookCreate, entity.PermissionSummaryCreate,
+				entity.PermissionBannerCreate, entity.PermissionCategoryCreate)(
+				http.HandlerFunc(r.uploadHandler.CreateUpload))))
+	mux.Handle(apiV1("/uploads/{id}"),
+		r.authMiddleware.Authenticate(
+			r.permissionMiddleware.CheckAnyPermission(
+				entity.PermissionEbookCreate, entity.PermissionSummaryCreate,
+				entity.PermissionBannerCreate, entity.PermissionCategoryCreate)(
+				http.HandlerFunc(r.uploadHandler.ServeUpload))))
+
+	// Scheduled publishing for anyone who can update some kind of content; the permission for the
+	// scheduled content type is checked by the use case
+	schedulePermissions := r.permissionMiddleware.CheckAnyPermission(
+		entity.PermissionEbookUpdate, entity.PermissionArticleUpdate, entity.PermissionInspirationUpdate)
+	mux.Handle(apiV1("/schedules"),
+		r.authMiddleware.Authenticate(schedulePermissions(http.HandlerFunc(r.scheduleHandler.ListSchedules))))
+	mux.Handle(apiV1("/schedules/create"),
+		r.authMiddleware.Authenticate(schedulePermissions(http.HandlerFunc(r.scheduleHandler.ScheduleContent))))
+	mux.Handle(apiV1("/schedules/cancel/{id}"),
+		r.authMiddleware.Authenticate(schedulePermissions(http.HandlerFunc(r.scheduleHandler.CancelSchedule))))
+
+	// Translations for anyone who can update some kind of translatable content; the permission for
+	// the translated content type is checked by the use case
+	translationPermissions := r.permissionMiddleware.CheckAnyPermission(
+		entity.PermissionEbookUpdate, entity.PermissionCategoryUpdate,
+		entity.PermissionArticleUpdate, entity.PermissionBannerUpdate)
+	mux.Handle(apiV1("/translations/{content_type}/{id}"),
+		r.authMiddleware.Authenticate(translationPermissions(http.HandlerFunc(r.translationHandler.ListTranslations))))
+	mux.Handle(apiV1("/translations/{content_type}/{id}/{locale}"),
+		r.authMiddleware.Authenticate(translationPermissions(http.HandlerFunc(r.translationHandler.ServeTranslation))))
+
+	// Series management (requires ebook permissions); volumes are replaced as a whole
+	mux.Handle(apiV1("/series/create"),
+		r.authMiddleware.Authenticate(
+			r.permissionMiddleware.CheckPermission(entity.PermissionEbookCreate)(
+				http.HandlerFunc(r.seriesHandler.CreateSeries))))
+
+	mux.Handle(apiV1("/series/edit/{id}"),
+		r.authMiddleware.Authenticate(
+			r.permissionMiddleware.CheckPermission(entity.PermissionEbookUpdate)(
+				http.HandlerFunc(r.seriesHandler.UpdateSeries))))
+
+	mux.Handle(apiV1("/series/delete/{id}"),
+		r.authMiddleware.Authenticate(
+			r.permissionMiddleware.CheckPermission(entity.PermissionEbookDelete)(
+				http.HandlerFunc(r.seriesHandler.DeleteSeries))))
+
+	mux.Handle(apiV1("/series/volumes/{id}"),
+		r.authMiddleware.Authenticate(
+			r.permissionMiddleware.CheckPermission(entity.PermissionEbookUpdate)(
+				http.HandlerFunc(r.seriesHandler.ReplaceVolumes))))
+
+	// Table of contents editing, replaced as a whole (requires ebook:update permission)
+	ebookResources.handle(http.MethodPut, "toc",
+		r.authMiddleware.Authenticate(
+			r.permissionMiddleware.CheckPermission(entity.PermissionEbookUpdate)(
+				http.HandlerFunc(r.tocHandler.ReplaceTableOfContents))))
+
+	// Contributor editing, replaced as a whole (requires ebook:update permission)
+	ebookResources.handle(http.MethodPut, "contributors",
+		r.authMiddleware.Authenticate(
+			r.permissionMiddleware.CheckPermission(entity.PermissionEbookUpdate)(
+				http.HandlerFunc(r.contributorHandler.ReplaceContributors))))
+
+	// Wishlist demand per ebook (requires ebook:update permission)
+	ebookResources.handle(http.MethodGet, "wishlist-count",
+		r.authMiddleware.Authenticate(
+			r.permissionMiddleware.CheckPermission(entity.PermissionEbookUpdate)(
+				http.HandlerFunc(r.wishlistHandler.GetWishlistCount))))
+
+	// Usage analytics aggregates (requires ebook:update permission)
+	mux.Handle(apiV1("/analytics/events/summary"),
+		r.authMiddleware.Authenticate(
+			r.permissionMiddleware.CheckPermission(entity.PermissionEbookUpdate)(
+				http.HandlerFunc(r.analyticsHandler.GetSummary))))
+
+	mux.Handle(apiV1("/analytics/events/daily"),
+		r.authMiddleware.Authenticate(
+			r.permissionMiddleware.CheckPermission(entity.PermissionEbookUpdate)(
+				http.HandlerFunc(r.analyticsHandler.GetDailyCounts))))
+
+	mux.Handle(apiV1("/analytics/events/top"),
+		r.authMiddleware.Authenticate(
+			r.permissionMiddleware.CheckPermission(entity.PermissionEbookUpdate)(
+				http.HandlerFunc(r.analyticsHandler.GetTopItems))))
+
 	// Catch-all handler for unmatched routes (404)
 	mux.HandleFunc("/", NotFoundHandler)
 
@@ -120,3 +120,44 @@ func TestNotFoundHandlerWithDifferentMethods(t *testing.T) {
 		})
 	}
 }
+
+func TestSubresourceRoutes(t *testing.T) {
+	routes := subresourceRoutes{}
+	routes.handle(http.MethodPost, "download", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/ebooks/slug/{slug}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.Handle("/api/v1/ebooks/{id}/{resource}", routes)
+
+	testCases := []struct {
+		name   string
+		method string
+		path   string
+		status int
+	}{
+		{name: "registered resource", method: http.MethodPost, path: "/api/v1/ebooks/ebook-1/download", status: http.StatusAccepted},
+		{name: "slug route still wins", method: http.MethodGet, path: "/api/v1/ebooks/slug/test-ebook", status: http.StatusOK},
+		{name: "wrong method", method: http.MethodGet, path: "/api/v1/ebooks/ebook-1/download", status: http.StatusMethodNotAllowed},
+		{name: "unknown resource", method: http.MethodGet, path: "/api/v1/ebooks/ebook-1/unknown", status: http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.path, nil)
+			rr := httptest.NewRecorder()
+
+			mux.ServeHTTP(rr, req)
+
+			if rr.Code != tc.status {
+				t.Errorf("expected status %d, got %d", tc.status, rr.Code)
+			}
+			if tc.status == http.StatusMethodNotAllowed && rr.Header().Get("Allow") != http.MethodPost {
+				t.Errorf("expected Allow header %q, got %q", http.MethodPost, rr.Header().Get("Allow"))
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package http
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/delivery/http/middleware"
+	"buku-pintar/internal/delivery/http/response"
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/helper"
+	"buku-pintar/internal/usecase"
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+type SeriesHandler struct {
+	seriesUsecase usecase.SeriesUsecase
+}
+
+func NewSeriesHandler(seriesUsecase usecase.SeriesUsecase) *SeriesHandler {
+	return &SeriesHandler{
+		seriesUsecase: seriesUsecase,
+	}
+}
+
+// SeriesRequest is the body for creating or updating a series; the slug defaults to one derived from the name
+type SeriesRequest struct {
+	Name         string  `json:"name"`
+	Slug         string  `json:"slug"`
+	Description  *string `json:"description"`
+	CoverImage   string  `json:"cover_image"`
+	CoverMediaID *string `json:"cover_media_id"`
+}
+
+// SeriesVolumesRequest is the body for replacing the ebooks of a series
+type SeriesVolumesRequest struct {
+	Volumes []struct {
+		EbookID string `json:"ebook_id"`
+		Volume  int    `json:"volume"`
+	} `json:"volumes"`
+}
+
+// ListSeries handles GET /series - List the series that have published volumes, by name
+func (h *SeriesHandler) ListSeries(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	limit, offset := helper.HandlePagination(r)
+
+	list, total, err := h.seriesUsecase.ListSeries(r.Context(), limit, offset)
+	if err != nil {
+		writeSeriesError(w, err)
+		return
+	}
+
+	res := make([]*response.SeriesResponse, 0, len(list))
+	for _, series := range list {
+		res = append(res, response.ParseSeriesResponse(series))
+	}
+
+	response.WritePaginatedMeta(w, r, res, response.NewOffsetMeta(total, limit, offset))
+}
+
+// GetSeriesBySlug handles GET /series/{slug} - A series with its published volumes in reading order
+func (h *SeriesHandler) GetSeriesBySlug(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	series, volumes, err := h.seriesUsecase.GetSeriesBySlug(r.Context(), r.PathValue("slug"))
+	if err != nil {
+		writeSeriesError(w, err)
+		return
+	}
+
+	res := &response.SeriesDetailResponse{
+		SeriesResponse: *response.ParseSeriesResponse(series),
+		Volumes:        response.ParseSeriesVolumeResponses(volumes),
+	}
+	response.WriteSuccess(w, http.StatusOK, res, "Series retrieved successfully")
+}
+
+// GetNextInSeries handles GET /ebooks/{id}/next-in-series - The volume to read after this ebook and
+// whether it is already in the user's library
+func (h *SeriesHandler) GetNextInSeries(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	// Anonymous clients have an empty library, so a missing user is not an error here
+	user, _ := middleware.GetUserFromContext(r.Context())
+
+	next, err := h.seriesUsecase.GetNextInSeries(r.Context(), user, ebookSubresourceID(r))
+	if err != nil {
+		writeSeriesError(w, err)
+		return
+	}
+
+	res := &response.SeriesNextResponse{
+		Series:    response.ParseEbookSeriesResponse(next.Series),
+		InLibrary: next.InLibrary,
+	}
+	if next.Volume != nil {
+		res.Next = response.ParseSeriesVolumeResponse(next.Volume)
+	}
+	response.WriteSuccess(w, http.StatusOK, res, "")
+}
+
+// CreateSeries handles POST /series/create - Create a series without volumes
+func (h *SeriesHandler) CreateSeries(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	var req SeriesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "invalid_request_body", err.Error())
+		return
+	}
+
+	series := parseSeriesRequest(&req)
+	if err := h.seriesUsecase.CreateSeries(r.Context(), series); err != nil {
+		writeSeriesError(w, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusCreated, response.ParseSeriesResponse(series), "Series created successfully")
+}
+
+// UpdateSeries handles PUT /series/edit/{id} - Update the name, slug, description and cover of a series
+func (h *SeriesHandler) UpdateSeries(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	var req SeriesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "invalid_request_body", err.Error())
+		return
+	}
+
+	series := parseSeriesRequest(&req)
+	series.ID = r.PathValue("id")
+	if err := h.seriesUsecase.UpdateSeries(r.Context(), series); err != nil {
+		writeSeriesError(w, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, response.ParseSeriesResponse(series), "Series updated successfully")
+}
+
+// DeleteSeries handles DELETE /series/delete/{id} - Delete a series; its ebooks are kept
+func (h *SeriesHandler) DeleteSeries(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	if err := h.seriesUsecase.DeleteSeries(r.Context(), r.PathValue("id")); err != nil {
+		writeSeriesError(w, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, nil, "Series deleted successfully")
+}
+
+// ReplaceVolumes handles PUT /series/volumes/{id} - Replace the ebooks of a series and their volume numbers
+func (h *SeriesHandler) ReplaceVolumes(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	var req SeriesVolumesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "invalid_request_body", err.Error())
+		return
+	}
+
+	volumes := make([]*entity.SeriesVolume, 0, len(req.Volumes))
+	for _, volume := range req.Volumes {
+		volumes = append(volumes, &entity.SeriesVolume{EbookID: volume.EbookID, Volume: volume.Volume})
+	}
+
+	volumes, err := h.seriesUsecase.ReplaceVolumes(r.Context(), r.PathValue("id"), volumes)
+	if err != nil {
+		writeSeriesError(w, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, response.ParseSeriesVolumeResponses(volumes), "Series volumes updated successfully")
+}
+
+func parseSeriesRequest(req *SeriesRequest) *entity.Series {
+	return &entity.Series{
+		Name:         req.Name,
+		Slug:         req.Slug,
+		Description:  req.Description,
+		CoverImage:   req.CoverImage,
+		CoverMediaID: req.CoverMediaID,
+	}
+}
+
+func writeSeriesError(w http.ResponseWriter, err error) {
+	var validationErr *usecase.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		response.WriteError(w, http.StatusBadRequest, "validation_error", err.Error())
+	case errors.Is(err, usecase.ErrSeriesNotFound):
+		response.WriteError(w, http.StatusNotFound, "series_not_found", err.Error())
+	case errors.Is(err, usecase.ErrEbookNotInSeries):
+		response.WriteError(w, http.StatusNotFound, "ebook_not_in_series", err.Error())
+	case errors.Is(err, usecase.ErrEbookNotFound):
+		response.WriteError(w, http.StatusNotFound, "ebook_not_found", err.Error())
+	case errors.Is(err, usecase.ErrSeriesSlugTaken):
+		response.WriteError(w, http.StatusConflict, "series_slug_taken", err.Error())
+	default:
+		response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+	}
+}
@@ -0,0 +1,52 @@
+package http
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/delivery/http/response"
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/usecase"
+	"errors"
+	"net/http"
+)
+
+type SitemapHandler struct {
+	sitemapUsecase usecase.SitemapUsecase
+}
+
+func NewSitemapHandler(sitemapUsecase usecase.SitemapUsecase) *SitemapHandler {
+	return &SitemapHandler{
+		sitemapUsecase: sitemapUsecase,
+	}
+}
+
+// GetSitemapIndex handles GET /sitemap.xml - The sitemap index listing the child sitemaps
+func (h *SitemapHandler) GetSitemapIndex(w http.ResponseWriter, r *http.Request) {
+	h.serveSitemap(w, r, entity.SitemapIndexName)
+}
+
+// GetSitemap handles GET /sitemaps/{name} - A child sitemap, e.g. ebooks-1.xml
+func (h *SitemapHandler) GetSitemap(w http.ResponseWriter, r *http.Request) {
+	h.serveSitemap(w, r, r.PathValue("name"))
+}
+
+func (h *SitemapHandler) serveSitemap(w http.ResponseWriter, r *http.Request, name string) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	document, err := h.sitemapUsecase.GetSitemap(r.Context(), name)
+	if err != nil {
+		if errors.Is(err, usecase.ErrSitemapNotFound) {
+			response.WriteError(w, http.StatusNotFound, "sitemap_not_found", err.Error())
+			return
+		}
+		response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	w.WriteHeader(http.StatusOK)
+	w.Write(document)
+}
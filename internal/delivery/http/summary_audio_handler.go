@@ -0,0 +1,157 @@
+package http
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/delivery/http/middleware"
+	"buku-pintar/internal/delivery/http/response"
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/usecase"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+)
+
+type SummaryAudioHandler struct {
+	summaryAudioUsecase usecase.SummaryAudioUsecase
+	storageDir          string
+}
+
+func NewSummaryAudioHandler(summaryAudioUsecase usecase.SummaryAudioUsecase, storageDir string) *SummaryAudioHandler {
+	return &SummaryAudioHandler{
+		summaryAudioUsecase: summaryAudioUsecase,
+		storageDir:          storageDir,
+	}
+}
+
+// StreamAudio handles GET /summaries/{id}/audio - Stream a summary's audio with Range support.
+// Regular summaries are public; premium summaries need the bearer token of a premium user.
+func (h *SummaryAudioHandler) StreamAudio(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	// The token is optional, so an anonymous request simply has no user
+	user, _ := middleware.GetUserFromContext(r.Context())
+
+	audio, err := h.summaryAudioUsecase.AuthorizeAudio(r.Context(), user, r.PathValue("id"))
+	if err != nil {
+		writeSummaryAudioError(w, user, err)
+		return
+	}
+	if audio == nil {
+		response.WriteError(w, http.StatusNotFound, "summary_not_found", constant.SUMMARY_NOT_FOUND)
+		return
+	}
+
+	cacheControl := cachePublicAudio
+	if audio.Premium {
+		cacheControl = cachePrivateAudio
+	}
+
+	err = serveStoredFile(w, r, audio.Location, h.storageDir, *audio.AudioContentType, "", cacheControl)
+	if errors.Is(err, errStoredFileNotFound) {
+		response.WriteError(w, http.StatusNotFound, "audio_not_found", constant.ERR_SUMMARY_AUDIO_MISSING)
+		return
+	}
+	if err != nil {
+		log.Printf("Error streaming audio of summary %s: %v", audio.SummaryID, err)
+	}
+}
+
+// UpdateListeningProgressRequest is a playback position reported by a device
+// UpdatedAt is the device time of the position and decides which device wins
+type UpdateListeningProgressRequest struct {
+	PositionSeconds int        `json:"position_seconds"`
+	DurationSeconds *int       `json:"duration_seconds"`
+	UpdatedAt       *time.Time `json:"updated_at"`
+}
+
+// UpdateListeningProgress handles PUT /summaries/{id}/listening-progress - Sync the playback position from a device
+func (h *SummaryAudioHandler) UpdateListeningProgress(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil || user == nil {
+		response.WriteError(w, http.StatusUnauthorized, "unauthorized", "Authentication required")
+		return
+	}
+
+	var req UpdateListeningProgressRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "invalid_request_body", err.Error())
+		return
+	}
+
+	progress := &entity.ListeningProgress{
+		SummaryID:       r.PathValue("id"),
+		PositionSeconds: req.PositionSeconds,
+		DurationSeconds: req.DurationSeconds,
+	}
+	if req.UpdatedAt != nil {
+		progress.ClientUpdatedAt = *req.UpdatedAt
+	}
+
+	stored, applied, err := h.summaryAudioUsecase.UpdateProgress(r.Context(), user, progress)
+	if err != nil {
+		writeSummaryAudioError(w, user, err)
+		return
+	}
+
+	if stored == nil {
+		response.WriteError(w, http.StatusNotFound, "summary_not_found", constant.SUMMARY_NOT_FOUND)
+		return
+	}
+
+	res := response.ParseListeningProgressResponse(stored)
+	res.Applied = &applied
+	response.WriteSuccess(w, http.StatusOK, res, "")
+}
+
+// GetListeningProgress handles GET /summaries/{id}/listening-progress - Get the synced playback position
+func (h *SummaryAudioHandler) GetListeningProgress(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil || user == nil {
+		response.WriteError(w, http.StatusUnauthorized, "unauthorized", "Authentication required")
+		return
+	}
+
+	progress, err := h.summaryAudioUsecase.GetProgress(r.Context(), user, r.PathValue("id"))
+	if err != nil {
+		writeSummaryAudioError(w, user, err)
+		return
+	}
+
+	if progress == nil {
+		response.WriteError(w, http.StatusNotFound, "progress_not_found", "no listening progress for this summary")
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, response.ParseListeningProgressResponse(progress), "")
+}
+
+func writeSummaryAudioError(w http.ResponseWriter, user *entity.User, err error) {
+	var validationErr *usecase.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		response.WriteError(w, http.StatusBadRequest, "validation_error", err.Error())
+	case errors.Is(err, usecase.ErrSummaryPremiumRequired) && user == nil:
+		response.WriteError(w, http.StatusUnauthorized, "unauthorized", "Authentication required")
+	case errors.Is(err, usecase.ErrSummaryPremiumRequired):
+		response.WriteError(w, http.StatusForbidden, "premium_required", err.Error())
+	case errors.Is(err, usecase.ErrSummaryAudioMissing):
+		response.WriteError(w, http.StatusNotFound, "audio_not_found", err.Error())
+	default:
+		response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"buku-pintar/internal/domain/entity"
 	"buku-pintar/internal/usecase"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strings"
 
@@ -14,34 +15,59 @@ import (
 
 type SummaryHandler struct {
 	summaryUsecase usecase.SummaryUsecase
+	cursorCodec    *helper.CursorCodec
 }
 
-func NewSummaryHandler(summaryUsecase usecase.SummaryUsecase) *SummaryHandler {
+func NewSummaryHandler(summaryUsecase usecase.SummaryUsecase, cursorCodec *helper.CursorCodec) *SummaryHandler {
 	return &SummaryHandler{
 		summaryUsecase: summaryUsecase,
+		cursorCodec:    cursorCodec,
 	}
 }
 
-// ListSummaries handles GET /summaries - List all summaries with pagination
+// ListSummaries handles GET /summaries - List all summaries with offset or cursor pagination
 func (h *SummaryHandler) ListSummaries(w http.ResponseWriter, r *http.Request) {
 	limit, offset := helper.HandlePagination(r)
 
-	// Get summaries from usecase
-	summaries, err := h.summaryUsecase.ListSummaries(r.Context(), limit, offset)
+	// Get total count for pagination
+	total, err := h.summaryUsecase.CountSummaries(r.Context())
 	if err != nil {
 		response.WriteError(w, http.StatusInternalServerError, "internal_server_error", err.Error())
 		return
 	}
 
-	// Get total count for pagination
-	total, err := h.summaryUsecase.CountSummaries(r.Context())
+	if helper.IsCursorPagination(r) {
+		cursor, err := h.cursorCodec.Decode(r.URL.Query().Get("cursor"))
+		if err != nil {
+			response.WriteError(w, http.StatusBadRequest, "invalid_cursor", err.Error())
+			return
+		}
+
+		summaries, page, err := h.summaryUsecase.ListSummariesByCursor(r.Context(), cursor, limit)
+		if err != nil {
+			var validationErr *usecase.ValidationError
+			if errors.As(err, &validationErr) {
+				response.WriteError(w, http.StatusBadRequest, "invalid_cursor", err.Error())
+				return
+			}
+			response.WriteError(w, http.StatusInternalServerError, "internal_server_error", err.Error())
+			return
+		}
+
+		meta := response.NewCursorMeta(total, limit, h.cursorCodec.Encode(page.Next), h.cursorCodec.Encode(page.Prev))
+		response.WritePaginatedMeta(w, r, summaries, meta)
+		return
+	}
+
+	// Get summaries from usecase
+	summaries, err := h.summaryUsecase.ListSummaries(r.Context(), limit, offset)
 	if err != nil {
 		response.WriteError(w, http.StatusInternalServerError, "internal_server_error", err.Error())
 		return
 	}
 
 	// Return response
-	response.WritePaginated(w, summaries, total, limit, offset)
+	response.WritePaginatedMeta(w, r, summaries, response.NewOffsetMeta(total, limit, offset))
 }
 
 // GetSummaryByID handles GET /summaries/{id} - Get summary by ID
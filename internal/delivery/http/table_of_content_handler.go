@@ -0,0 +1,100 @@
+package http
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/delivery/http/middleware"
+	"buku-pintar/internal/delivery/http/response"
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/usecase"
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+type TableOfContentHandler struct {
+	tableOfContentUsecase usecase.TableOfContentUsecase
+}
+
+func NewTableOfContentHandler(tableOfContentUsecase usecase.TableOfContentUsecase) *TableOfContentHandler {
+	return &TableOfContentHandler{
+		tableOfContentUsecase: tableOfContentUsecase,
+	}
+}
+
+// TableOfContentEntryRequest is one chapter of a table of contents, with its sub-chapters in order
+type TableOfContentEntryRequest struct {
+	Title      string                        `json:"title"`
+	PageNumber int16                         `json:"page_number"`
+	Children   []*TableOfContentEntryRequest `json:"children"`
+}
+
+type ReplaceTableOfContentRequest struct {
+	Entries []*TableOfContentEntryRequest `json:"entries"`
+}
+
+// GetTableOfContents handles GET /ebooks/{id}/toc - The ebook's chapters as a tree
+func (h *TableOfContentHandler) GetTableOfContents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	user, _ := middleware.GetUserFromContext(r.Context())
+
+	entries, err := h.tableOfContentUsecase.GetTableOfContents(r.Context(), user, ebookSubresourceID(r))
+	if err != nil {
+		writeTableOfContentError(w, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, response.ParseTableOfContentTree(entries), "Table of contents retrieved successfully")
+}
+
+// ReplaceTableOfContents handles PUT /ebooks/{id}/toc - Replace the whole table of contents at once
+func (h *TableOfContentHandler) ReplaceTableOfContents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	var req ReplaceTableOfContentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "invalid_request_body", err.Error())
+		return
+	}
+
+	entries, err := h.tableOfContentUsecase.ReplaceTableOfContents(r.Context(), ebookSubresourceID(r), parseTableOfContentEntries(req.Entries))
+	if err != nil {
+		writeTableOfContentError(w, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, response.ParseTableOfContentTree(entries), "Table of contents replaced successfully")
+}
+
+func parseTableOfContentEntries(requests []*TableOfContentEntryRequest) []*entity.TableOfContent {
+	entries := make([]*entity.TableOfContent, 0, len(requests))
+	for _, req := range requests {
+		if req == nil {
+			continue
+		}
+		entries = append(entries, &entity.TableOfContent{
+			Title:      req.Title,
+			PageNumber: req.PageNumber,
+			Children:   parseTableOfContentEntries(req.Children),
+		})
+	}
+	return entries
+}
+
+func writeTableOfContentError(w http.ResponseWriter, err error) {
+	var validationErr *usecase.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		response.WriteError(w, http.StatusBadRequest, "validation_error", err.Error())
+	case errors.Is(err, usecase.ErrEbookNotFound):
+		response.WriteError(w, http.StatusNotFound, "ebook_not_found", err.Error())
+	default:
+		response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+	}
+}
@@ -0,0 +1,123 @@
+package http
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/delivery/http/middleware"
+	"buku-pintar/internal/delivery/http/response"
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/usecase"
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+type TranslationHandler struct {
+	translationUsecase usecase.TranslationUsecase
+}
+
+func NewTranslationHandler(translationUsecase usecase.TranslationUsecase) *TranslationHandler {
+	return &TranslationHandler{
+		translationUsecase: translationUsecase,
+	}
+}
+
+// TranslationRequest is the body for saving a translation, the translated text by field name
+type TranslationRequest struct {
+	Fields map[string]string `json:"fields"`
+}
+
+// ListTranslations handles GET /translations/{content_type}/{id} - List the translations of a piece of content
+func (h *TranslationHandler) ListTranslations(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil || user == nil {
+		response.WriteError(w, http.StatusUnauthorized, "unauthorized", "Authentication required")
+		return
+	}
+
+	translations, err := h.translationUsecase.ListTranslations(r.Context(), user.ID,
+		entity.TranslatableContent(r.PathValue("content_type")), r.PathValue("id"))
+	if err != nil {
+		writeTranslationError(w, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, response.ParseTranslationListResponse(translations), "")
+}
+
+// ServeTranslation handles /translations/{content_type}/{id}/{locale}: PUT saves the translation, DELETE removes it
+func (h *TranslationHandler) ServeTranslation(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPut:
+		h.saveTranslation(w, r)
+	case http.MethodDelete:
+		h.deleteTranslation(w, r)
+	default:
+		w.Header().Set("Allow", "PUT, DELETE")
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+	}
+}
+
+func (h *TranslationHandler) saveTranslation(w http.ResponseWriter, r *http.Request) {
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil || user == nil {
+		response.WriteError(w, http.StatusUnauthorized, "unauthorized", "Authentication required")
+		return
+	}
+
+	var req TranslationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.WriteError(w, http.StatusBadRequest, "invalid_request_body", err.Error())
+		return
+	}
+
+	translation := &entity.Translation{
+		ContentType: entity.TranslatableContent(r.PathValue("content_type")),
+		ContentID:   r.PathValue("id"),
+		Locale:      r.PathValue("locale"),
+		Fields:      req.Fields,
+	}
+	if err := h.translationUsecase.SaveTranslation(r.Context(), user.ID, translation); err != nil {
+		writeTranslationError(w, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, response.ParseTranslationResponse(translation), "Translation saved successfully")
+}
+
+func (h *TranslationHandler) deleteTranslation(w http.ResponseWriter, r *http.Request) {
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil || user == nil {
+		response.WriteError(w, http.StatusUnauthorized, "unauthorized", "Authentication required")
+		return
+	}
+
+	err = h.translationUsecase.DeleteTranslation(r.Context(), user.ID,
+		entity.TranslatableContent(r.PathValue("content_type")), r.PathValue("id"), r.PathValue("locale"))
+	if err != nil {
+		writeTranslationError(w, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, nil, "Translation deleted successfully")
+}
+
+func writeTranslationError(w http.ResponseWriter, err error) {
+	var validationErr *usecase.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		response.WriteError(w, http.StatusBadRequest, "validation_error", err.Error())
+	case errors.Is(err, usecase.ErrTranslationNotFound):
+		response.WriteError(w, http.StatusNotFound, "translation_not_found", err.Error())
+	case errors.Is(err, usecase.ErrTranslatedContentNotFound):
+		response.WriteError(w, http.StatusNotFound, "content_not_found", err.Error())
+	case errors.Is(err, usecase.ErrTranslationForbidden):
+		response.WriteError(w, http.StatusForbidden, "forbidden", err.Error())
+	default:
+		response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+	}
+}
@@ -0,0 +1,45 @@
+package http
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/delivery/http/response"
+	"buku-pintar/internal/helper"
+	"buku-pintar/internal/usecase"
+	"errors"
+	"net/http"
+)
+
+type TrendingHandler struct {
+	trendingUsecase usecase.TrendingUsecase
+}
+
+func NewTrendingHandler(trendingUsecase usecase.TrendingUsecase) *TrendingHandler {
+	return &TrendingHandler{
+		trendingUsecase: trendingUsecase,
+	}
+}
+
+// ListTrendingEbooks handles GET /ebooks/trending?window=24h|7d|30d&category_id= - Ebooks with the most
+// recent views, reads, wishlist adds and purchases, recent ones weighing more
+func (h *TrendingHandler) ListTrendingEbooks(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	limit, offset := helper.HandlePagination(r)
+	query := r.URL.Query()
+
+	ebooks, total, err := h.trendingUsecase.ListTrendingEbooks(r.Context(), query.Get("window"), query.Get("category_id"), limit, offset)
+	if err != nil {
+		var validationErr *usecase.ValidationError
+		if errors.As(err, &validationErr) {
+			response.WriteError(w, http.StatusBadRequest, "validation_error", err.Error())
+			return
+		}
+		response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+		return
+	}
+
+	response.WritePaginatedMeta(w, r, parseRecommendedEbooks(ebooks), response.NewOffsetMeta(total, limit, offset))
+}
@@ -0,0 +1,274 @@
+package http
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/delivery/http/middleware"
+	"buku-pintar/internal/delivery/http/response"
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/usecase"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	tusVersion    = "1.0.0"
+	tusExtensions = "creation,expiration,checksum,termination"
+	tusChecksums  = "md5,sha1,sha256"
+	// tusOffsetContentType is the only body type PATCH accepts
+	tusOffsetContentType = "application/offset+octet-stream"
+	// statusChecksumMismatch is defined by the tus checksum extension
+	statusChecksumMismatch = 460
+)
+
+// tusMaxSize is the largest upload of any kind, advertised in Tus-Max-Size
+var tusMaxSize = strconv.FormatInt(entity.MediaEbookFile.MaxSize(), 10)
+
+// UploadHandler speaks the tus 1.0 resumable upload protocol
+type UploadHandler struct {
+	uploadUsecase usecase.UploadUsecase
+}
+
+func NewUploadHandler(uploadUsecase usecase.UploadUsecase) *UploadHandler {
+	return &UploadHandler{
+		uploadUsecase: uploadUsecase,
+	}
+}
+
+// Options handles OPTIONS /uploads and /uploads/{id} - Advertise the supported tus version and extensions
+func (h *UploadHandler) Options(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Tus-Resumable", tusVersion)
+	w.Header().Set("Tus-Version", tusVersion)
+	w.Header().Set("Tus-Extension", tusExtensions)
+	w.Header().Set("Tus-Max-Size", tusMaxSize)
+	w.Header().Set("Tus-Checksum-Algorithm", tusChecksums)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CreateUpload handles POST /uploads - Start an upload of Upload-Length bytes.
+// Upload-Metadata carries the media kind, the filename and optionally the SHA-256 of the whole file.
+func (h *UploadHandler) CreateUpload(w http.ResponseWriter, r *http.Request) {
+	if !checkTusRequest(w, r) {
+		return
+	}
+	if tusMethod(r) != http.MethodPost {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil || user == nil {
+		response.WriteError(w, http.StatusUnauthorized, "unauthorized", "Authentication required")
+		return
+	}
+
+	length, err := strconv.ParseInt(r.Header.Get("Upload-Length"), 10, 64)
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "validation_error", constant.ERR_UPLOAD_LENGTH_INVALID)
+		return
+	}
+	metadata, err := parseUploadMetadata(r.Header.Get("Upload-Metadata"))
+	if err != nil {
+		response.WriteError(w, http.StatusBadRequest, "validation_error", err.Error())
+		return
+	}
+	filename := metadata["filename"]
+	if filename == "" {
+		filename = metadata["name"]
+	}
+
+	upload, err := h.uploadUsecase.CreateUpload(r.Context(), user.ID, entity.MediaKind(metadata["kind"]), filename, length, metadata["checksum"])
+	if err != nil {
+		writeUploadError(w, err)
+		return
+	}
+
+	w.Header().Set("Location", apiV1Prefix+"/uploads/"+upload.ID)
+	w.Header().Set("Upload-Expires", upload.ExpiresAt.UTC().Format(http.TimeFormat))
+	w.Header().Set("Upload-Offset", "0")
+	w.WriteHeader(http.StatusCreated)
+}
+
+// ServeUpload handles /uploads/{id}: HEAD reports progress, PATCH appends a chunk, DELETE abandons the upload
+// and GET describes it as JSON, including the media ID once complete
+func (h *UploadHandler) ServeUpload(w http.ResponseWriter, r *http.Request) {
+	if !checkTusRequest(w, r) {
+		return
+	}
+
+	switch tusMethod(r) {
+	case http.MethodHead:
+		h.headUpload(w, r)
+	case http.MethodGet:
+		h.getUpload(w, r)
+	case http.MethodPatch:
+		h.patchUpload(w, r)
+	case http.MethodDelete:
+		h.deleteUpload(w, r)
+	default:
+		w.Header().Set("Allow", "GET, HEAD, PATCH, DELETE, OPTIONS")
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+	}
+}
+
+func (h *UploadHandler) headUpload(w http.ResponseWriter, r *http.Request) {
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil || user == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	upload, err := h.uploadUsecase.GetUpload(r.Context(), user.ID, r.PathValue("id"))
+	if err != nil {
+		writeUploadError(w, err)
+		return
+	}
+
+	// Progress changes with every chunk, so it must never come from a cache
+	w.Header().Set("Cache-Control", "no-store")
+	writeUploadProgress(w, upload)
+	w.Header().Set("Upload-Length", strconv.FormatInt(upload.Length, 10))
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *UploadHandler) getUpload(w http.ResponseWriter, r *http.Request) {
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil || user == nil {
+		response.WriteError(w, http.StatusUnauthorized, "unauthorized", "Authentication required")
+		return
+	}
+
+	upload, err := h.uploadUsecase.GetUpload(r.Context(), user.ID, r.PathValue("id"))
+	if err != nil {
+		writeUploadError(w, err)
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	response.WriteSuccess(w, http.StatusOK, response.ParseUploadResponse(upload), "")
+}
+
+func (h *UploadHandler) patchUpload(w http.ResponseWriter, r *http.Request) {
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil || user == nil {
+		response.WriteError(w, http.StatusUnauthorized, "unauthorized", "Authentication required")
+		return
+	}
+
+	if r.Header.Get("Content-Type") != tusOffsetContentType {
+		response.WriteError(w, http.StatusUnsupportedMediaType, "invalid_content_type", "Content-Type must be "+tusOffsetContentType)
+		return
+	}
+	offset, err := strconv.ParseInt(r.Header.Get("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		response.WriteError(w, http.StatusBadRequest, "validation_error", constant.ERR_UPLOAD_OFFSET_MISMATCH)
+		return
+	}
+	var checksum *usecase.ChunkChecksum
+	if header := r.Header.Get("Upload-Checksum"); header != "" {
+		checksum, err = parseUploadChecksum(header)
+		if err != nil {
+			response.WriteError(w, http.StatusBadRequest, "validation_error", err.Error())
+			return
+		}
+	}
+
+	upload, err := h.uploadUsecase.AppendChunk(r.Context(), user.ID, r.PathValue("id"), offset, r.Body, checksum)
+	if err != nil {
+		writeUploadError(w, err)
+		return
+	}
+
+	writeUploadProgress(w, upload)
+	if upload.MediaID != nil {
+		w.Header().Set("X-Media-ID", *upload.MediaID)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *UploadHandler) deleteUpload(w http.ResponseWriter, r *http.Request) {
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil || user == nil {
+		response.WriteError(w, http.StatusUnauthorized, "unauthorized", "Authentication required")
+		return
+	}
+
+	if err := h.uploadUsecase.DeleteUpload(r.Context(), user.ID, r.PathValue("id")); err != nil {
+		writeUploadError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// checkTusRequest sets Tus-Resumable on the response and rejects clients speaking another protocol version
+func checkTusRequest(w http.ResponseWriter, r *http.Request) bool {
+	w.Header().Set("Tus-Resumable", tusVersion)
+	if r.Header.Get("Tus-Resumable") != tusVersion {
+		w.Header().Set("Tus-Version", tusVersion)
+		response.WriteError(w, http.StatusPreconditionFailed, "unsupported_tus_version", "Tus-Resumable must be "+tusVersion)
+		return false
+	}
+	return true
+}
+
+// tusMethod honours X-HTTP-Method-Override, which tus clients send from environments limited to GET and POST
+func tusMethod(r *http.Request) string {
+	if override := r.Header.Get("X-HTTP-Method-Override"); override != "" && r.Method == http.MethodPost {
+		return strings.ToUpper(override)
+	}
+	return r.Method
+}
+
+func writeUploadProgress(w http.ResponseWriter, upload *entity.Upload) {
+	w.Header().Set("Upload-Offset", strconv.FormatInt(upload.Offset, 10))
+	w.Header().Set("Upload-Expires", upload.ExpiresAt.UTC().Format(http.TimeFormat))
+}
+
+// parseUploadMetadata decodes Upload-Metadata: comma-separated keys, each followed by a space and a base64 value
+func parseUploadMetadata(header string) (map[string]string, error) {
+	metadata := map[string]string{}
+	for _, pair := range strings.Split(header, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, encoded, _ := strings.Cut(pair, " ")
+		value, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, errors.New("Upload-Metadata value of " + key + " is not valid base64")
+		}
+		metadata[key] = string(value)
+	}
+	return metadata, nil
+}
+
+// parseUploadChecksum decodes Upload-Checksum: the algorithm, a space and the base64 digest
+func parseUploadChecksum(header string) (*usecase.ChunkChecksum, error) {
+	algorithm, encoded, ok := strings.Cut(header, " ")
+	sum, err := base64.StdEncoding.DecodeString(encoded)
+	if !ok || err != nil {
+		return nil, errors.New("Upload-Checksum must be an algorithm and a base64 digest")
+	}
+	return &usecase.ChunkChecksum{Algorithm: algorithm, Sum: sum}, nil
+}
+
+func writeUploadError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrUploadNotFound):
+		response.WriteError(w, http.StatusNotFound, "upload_not_found", constant.ERR_UPLOAD_NOT_FOUND)
+	case errors.Is(err, usecase.ErrUploadExpired):
+		response.WriteError(w, http.StatusGone, "upload_expired", constant.ERR_UPLOAD_EXPIRED)
+	case errors.Is(err, usecase.ErrUploadOffsetMismatch):
+		response.WriteError(w, http.StatusConflict, "upload_offset_mismatch", constant.ERR_UPLOAD_OFFSET_MISMATCH)
+	case errors.Is(err, usecase.ErrUploadChecksumMismatch):
+		response.WriteError(w, statusChecksumMismatch, "checksum_mismatch", constant.ERR_UPLOAD_CHECKSUM_MISMATCH)
+	case errors.Is(err, usecase.ErrUploadLocked):
+		response.WriteError(w, http.StatusLocked, "upload_locked", constant.ERR_UPLOAD_LOCKED)
+	case errors.Is(err, usecase.ErrUploadForbidden):
+		response.WriteError(w, http.StatusForbidden, "forbidden", constant.ERR_UPLOAD_FORBIDDEN)
+	default:
+		writeMediaError(w, err)
+	}
+}
@@ -0,0 +1,132 @@
+package http
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/delivery/http/middleware"
+	"buku-pintar/internal/delivery/http/response"
+	"buku-pintar/internal/helper"
+	"buku-pintar/internal/usecase"
+	"errors"
+	"net/http"
+)
+
+type WishlistHandler struct {
+	wishlistUsecase usecase.WishlistUsecase
+}
+
+func NewWishlistHandler(wishlistUsecase usecase.WishlistUsecase) *WishlistHandler {
+	return &WishlistHandler{
+		wishlistUsecase: wishlistUsecase,
+	}
+}
+
+// AddToWishlist handles POST /ebooks/{id}/wishlist - Save an ebook to the user's wishlist
+func (h *WishlistHandler) AddToWishlist(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil || user == nil {
+		response.WriteError(w, http.StatusUnauthorized, "unauthorized", "Authentication required")
+		return
+	}
+
+	added, err := h.wishlistUsecase.AddToWishlist(r.Context(), user.ID, ebookSubresourceID(r))
+	if err != nil {
+		writeWishlistError(w, err)
+		return
+	}
+
+	if !added {
+		response.WriteSuccess(w, http.StatusOK, nil, "Ebook is already in the wishlist")
+		return
+	}
+	response.WriteSuccess(w, http.StatusCreated, nil, "Ebook added to the wishlist")
+}
+
+// RemoveFromWishlist handles DELETE /ebooks/{id}/wishlist - Remove an ebook from the user's wishlist
+func (h *WishlistHandler) RemoveFromWishlist(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil || user == nil {
+		response.WriteError(w, http.StatusUnauthorized, "unauthorized", "Authentication required")
+		return
+	}
+
+	if err := h.wishlistUsecase.RemoveFromWishlist(r.Context(), user.ID, ebookSubresourceID(r)); err != nil {
+		writeWishlistError(w, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, nil, "Ebook removed from the wishlist")
+}
+
+// ListWishlist handles GET /users/wishlist - The user's wishlist, most recently added first
+func (h *WishlistHandler) ListWishlist(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	user, err := middleware.GetUserFromContext(r.Context())
+	if err != nil || user == nil {
+		response.WriteError(w, http.StatusUnauthorized, "unauthorized", "Authentication required")
+		return
+	}
+
+	limit, offset := helper.HandlePagination(r)
+
+	items, err := h.wishlistUsecase.ListWishlist(r.Context(), user.ID, limit, offset)
+	if err != nil {
+		response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+		return
+	}
+
+	total, err := h.wishlistUsecase.CountWishlist(r.Context(), user.ID)
+	if err != nil {
+		response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+		return
+	}
+
+	res := make([]*response.WishlistItemResponse, 0, len(items))
+	for _, item := range items {
+		res = append(res, response.ParseWishlistItemResponse(item))
+	}
+
+	response.WritePaginatedMeta(w, r, res, response.NewOffsetMeta(total, limit, offset))
+}
+
+// GetWishlistCount handles GET /ebooks/{id}/wishlist-count - How many users wishlisted an ebook
+func (h *WishlistHandler) GetWishlistCount(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		response.WriteError(w, http.StatusMethodNotAllowed, "method_not_allowed", constant.ERR_METHOD_NOT_ALLOWED)
+		return
+	}
+
+	ebookID := ebookSubresourceID(r)
+	count, err := h.wishlistUsecase.CountEbookWishlists(r.Context(), ebookID)
+	if err != nil {
+		writeWishlistError(w, err)
+		return
+	}
+
+	response.WriteSuccess(w, http.StatusOK, &response.WishlistCountResponse{EbookID: ebookID, Count: count}, "Wishlist count retrieved successfully")
+}
+
+func writeWishlistError(w http.ResponseWriter, err error) {
+	var validationErr *usecase.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		response.WriteError(w, http.StatusBadRequest, "validation_error", err.Error())
+	case errors.Is(err, usecase.ErrEbookNotFound):
+		response.WriteError(w, http.StatusNotFound, "ebook_not_found", err.Error())
+	default:
+		response.WriteError(w, http.StatusInternalServerError, constant.ERR_CODE_SERVER_ERROR, err.Error())
+	}
+}
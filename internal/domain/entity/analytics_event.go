@@ -0,0 +1,113 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AnalyticsEventType is the kind of usage event a client reports
+type AnalyticsEventType string
+
+const (
+	AnalyticsEventView        AnalyticsEventType = "view"
+	AnalyticsEventReadPage    AnalyticsEventType = "read_page"
+	AnalyticsEventAudioPlay   AnalyticsEventType = "audio_play"
+	AnalyticsEventSearch      AnalyticsEventType = "search"
+	AnalyticsEventBannerClick AnalyticsEventType = "banner_click"
+)
+
+// analyticsEventFields is the event schema: the fields each type must carry. Any other of
+// ebook_id, banner_id, page_number, position_seconds and query must be left out.
+var analyticsEventFields = map[AnalyticsEventType][]string{
+	AnalyticsEventView:        {"ebook_id"},
+	AnalyticsEventReadPage:    {"ebook_id", "page_number"},
+	AnalyticsEventAudioPlay:   {"ebook_id", "position_seconds"},
+	AnalyticsEventSearch:      {"query"},
+	AnalyticsEventBannerClick: {"banner_id"},
+}
+
+func (t AnalyticsEventType) IsValid() bool {
+	_, ok := analyticsEventFields[t]
+	return ok
+}
+
+// Fields returns the fields an event of this type must carry
+func (t AnalyticsEventType) Fields() []string {
+	return analyticsEventFields[t]
+}
+
+// AnalyticsEvent is a usage event sent by a client, enriched with the request it arrived in
+type AnalyticsEvent struct {
+	ID              string             `db:"id" json:"id"`
+	Type            AnalyticsEventType `db:"event_type" json:"type"`
+	OccurredAt      time.Time          `db:"occurred_at" json:"occurred_at"`
+	ReceivedAt      time.Time          `db:"received_at" json:"received_at"`
+	UserID          *string            `db:"user_id" json:"user_id,omitempty"`
+	SessionID       *string            `db:"session_id" json:"session_id,omitempty"`
+	EbookID         *string            `db:"ebook_id" json:"ebook_id,omitempty"`
+	BannerID        *string            `db:"banner_id" json:"banner_id,omitempty"`
+	PageNumber      *int               `db:"page_number" json:"page_number,omitempty"`
+	PositionSeconds *int               `db:"position_seconds" json:"position_seconds,omitempty"`
+	Query           *string            `db:"query" json:"query,omitempty"`
+	Properties      json.RawMessage    `db:"properties" json:"properties,omitempty"` // Free-form JSON object
+	Locale          string             `db:"locale" json:"locale"`
+	IPAddress       string             `db:"ip_address" json:"ip_address"`
+	UserAgent       string             `db:"user_agent" json:"user_agent"`
+}
+
+// AnalyticsClient is the request context events are enriched with
+type AnalyticsClient struct {
+	UserID    string // Empty for anonymous clients
+	Locale    string
+	IPAddress string
+	UserAgent string
+}
+
+// AnalyticsFilter narrows aggregate queries to a time range [From, To) and optionally an event type and ebook
+type AnalyticsFilter struct {
+	From    time.Time
+	To      time.Time
+	Type    AnalyticsEventType
+	EbookID string
+}
+
+// AnalyticsDimension is what top lists group events by
+type AnalyticsDimension string
+
+const (
+	AnalyticsDimensionEbook  AnalyticsDimension = "ebook"
+	AnalyticsDimensionSearch AnalyticsDimension = "search"
+	AnalyticsDimensionBanner AnalyticsDimension = "banner"
+)
+
+func (d AnalyticsDimension) IsValid() bool {
+	switch d {
+	case AnalyticsDimensionEbook, AnalyticsDimensionSearch, AnalyticsDimensionBanner:
+		return true
+	default:
+		return false
+	}
+}
+
+// AnalyticsTotal counts the events of one type with their distinct users and sessions
+type AnalyticsTotal struct {
+	Type     AnalyticsEventType `db:"event_type"`
+	Events   int64              `db:"events"`
+	Users    int64              `db:"users"`
+	Sessions int64              `db:"sessions"`
+}
+
+// AnalyticsDailyCount counts the events of one type on one UTC day (YYYY-MM-DD)
+type AnalyticsDailyCount struct {
+	Day    string             `db:"day"`
+	Type   AnalyticsEventType `db:"event_type"`
+	Events int64              `db:"events"`
+}
+
+// AnalyticsTopItem is an ebook, search query or banner with its number of events and distinct users
+type AnalyticsTopItem struct {
+	Key    string `db:"item_key"`
+	Label  string `db:"label"` // Ebook or banner title, or the query itself
+	Events int64  `db:"events"`
+	Users  int64  `db:"users"`
+}
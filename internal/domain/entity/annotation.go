@@ -0,0 +1,48 @@
+package entity
+
+import "time"
+
+// AnnotationType represents the kind of annotation a reader made
+type AnnotationType string
+
+const (
+	AnnotationBookmark  AnnotationType = "bookmark"
+	AnnotationHighlight AnnotationType = "highlight"
+	AnnotationNote      AnnotationType = "note"
+)
+
+// IsValid reports whether the annotation type is supported
+func (t AnnotationType) IsValid() bool {
+	switch t {
+	case AnnotationBookmark, AnnotationHighlight, AnnotationNote:
+		return true
+	}
+	return false
+}
+
+// Annotation is a bookmark, highlight or note a user made in an ebook
+// The location is a page for page-based formats or a CFI range for EPUB.
+// Deleted annotations are kept as tombstones so other devices can sync the deletion.
+type Annotation struct {
+	ID           string         `db:"id" json:"id"`
+	UserID       string         `db:"user_id" json:"user_id"`
+	EbookID      string         `db:"ebook_id" json:"ebook_id"`
+	Type         AnnotationType `db:"type" json:"type"`
+	Page         *int           `db:"page" json:"page,omitempty"`
+	CFIStart     *string        `db:"cfi_start" json:"cfi_start,omitempty"`
+	CFIEnd       *string        `db:"cfi_end" json:"cfi_end,omitempty"`
+	SelectedText *string        `db:"selected_text" json:"selected_text,omitempty"`
+	Color        *string        `db:"color" json:"color,omitempty"`
+	Note         *string        `db:"note" json:"note,omitempty"`
+	CreatedAt    time.Time      `db:"created_at" json:"created_at"`
+	UpdatedAt    time.Time      `db:"updated_at" json:"updated_at"`
+	DeletedAt    *time.Time     `db:"deleted_at" json:"deleted_at,omitempty"`
+}
+
+// AnnotationFilter narrows a user's annotations
+// With UpdatedSince set, deleted annotations are included so clients can sync deletions
+type AnnotationFilter struct {
+	UserID       string
+	EbookID      string
+	UpdatedSince *time.Time
+}
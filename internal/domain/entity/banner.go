@@ -14,8 +14,17 @@ type Banner struct {
 	CTALabel        *string    `db:"cta_label" json:"cta_label"`
 	BackgroundColor *string    `db:"background_color" json:"background_color"`
 	IsActive        bool       `db:"is_active" json:"is_active"`
+	ImageMediaID    *string    `db:"image_media_id" json:"image_media_id"`
 	CreatedAt       time.Time  `db:"created_at" json:"created_at"`
 	UpdatedAt       time.Time  `db:"updated_at" json:"updated_at"`
+
+	ImageMediaKey *string       `db:"image_media_key" json:"image_media_key,omitempty"`
+	ImageVariants MediaVariants `db:"image_variants" json:"image_variants,omitempty"`
+}
+
+func (b *Banner) ResolveMediaURLs(url func(key string) string) {
+	b.ImageURL = ResolveMediaURL(b.ImageMediaKey, b.ImageURL, url)
+	b.ImageVariants.ResolveURLs(url)
 }
 
 
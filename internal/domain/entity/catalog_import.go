@@ -0,0 +1,71 @@
+package entity
+
+import "time"
+
+// CatalogEntry is one ebook of an imported catalog, matched to existing ebooks by slug.
+// Optional fields left nil get a default when the ebook is created and keep their stored value when it is updated.
+type CatalogEntry struct {
+	Line        int // Line or position of the entry in the catalog file
+	Slug        string
+	Title       string
+	AuthorName  string
+	Category    string // Name or slug of the category
+	Price       int
+	Synopsis    *string
+	Language    *string
+	Format      *EbookFormat
+	PageCount   *int16
+	PreviewPage *int16
+	Duration    *int
+	Filesize    *int64
+	URL         *string
+	CoverImage  *string
+	Status      *string // Content status name
+	PublishedAt *time.Time
+
+	// Resolved by the import
+	EbookID         string
+	Exists          bool // Whether EbookID is an existing ebook to update
+	AuthorID        string
+	CategoryID      string
+	ContentStatusID *string
+}
+
+// CatalogImportBatch is the part of a catalog written in one transaction: the authors and
+// categories it introduces and the ebooks it creates or updates
+type CatalogImportBatch struct {
+	Authors    []*Author
+	Categories []*Category
+	Entries    []*CatalogEntry
+}
+
+// CatalogImportAction is what happened, or in a dry run would happen, to a catalog entry
+type CatalogImportAction string
+
+const (
+	CatalogImportCreated CatalogImportAction = "created"
+	CatalogImportUpdated CatalogImportAction = "updated"
+	CatalogImportFailed  CatalogImportAction = "failed"
+)
+
+// CatalogImportRow is the outcome of one catalog entry
+type CatalogImportRow struct {
+	Line    int                 `json:"line"`
+	Slug    string              `json:"slug"`
+	Action  CatalogImportAction `json:"action"`
+	EbookID string              `json:"ebook_id,omitempty"`
+	Errors  []string            `json:"errors,omitempty"`
+}
+
+// CatalogImportReport summarizes an import. In a dry run nothing is written and the report
+// shows what the import would do.
+type CatalogImportReport struct {
+	DryRun            bool                `json:"dry_run"`
+	Total             int                 `json:"total"`
+	Created           int                 `json:"created"`
+	Updated           int                 `json:"updated"`
+	Failed            int                 `json:"failed"`
+	AuthorsCreated    []string            `json:"authors_created"`
+	CategoriesCreated []string            `json:"categories_created"`
+	Rows              []*CatalogImportRow `json:"rows"`
+}
@@ -13,6 +13,13 @@ type Category struct {
 	ParentID    *string   `db:"parent_id" json:"parent_id"`
 	OrderNumber int8      `db:"order_number" json:"order_number"`
 	IsActive    bool      `db:"is_active" json:"is_active"`
+	IconMediaID *string   `db:"icon_media_id" json:"icon_media_id"`
 	CreatedAt   time.Time `db:"created_at" json:"created_at"`
 	UpdatedAt   time.Time `db:"updated_at" json:"updated_at"`
+
+	IconMediaKey *string `db:"icon_media_key" json:"icon_media_key,omitempty"`
+}
+
+func (c *Category) ResolveMediaURLs(url func(key string) string) {
+	c.IconLink = ResolveMediaURL(c.IconMediaKey, c.IconLink, url)
 }
@@ -0,0 +1,76 @@
+package entity
+
+import "time"
+
+// SchedulableContent is a kind of content whose publication can be scheduled
+type SchedulableContent string
+
+const (
+	SchedulableEbook       SchedulableContent = "ebook"
+	SchedulableArticle     SchedulableContent = "article"
+	SchedulableInspiration SchedulableContent = "inspiration"
+)
+
+// ScheduleAction is what happens to the content when a schedule is due
+type ScheduleAction string
+
+const (
+	SchedulePublish   ScheduleAction = "publish"
+	ScheduleUnpublish ScheduleAction = "unpublish"
+)
+
+// Content status names, as seeded in content_statuses
+const (
+	ContentStatusDraft     = "draft"
+	ContentStatusPublished = "published"
+	ContentStatusArchived  = "archived"
+)
+
+// ContentSchedule publishes or unpublishes a piece of content at RunAt
+type ContentSchedule struct {
+	ID          string             `db:"id" json:"id"`
+	ContentType SchedulableContent `db:"content_type" json:"content_type"`
+	ContentID   string             `db:"content_id" json:"content_id"`
+	Action      ScheduleAction     `db:"action" json:"action"`
+	RunAt       time.Time          `db:"run_at" json:"run_at"`
+	CreatedBy   *string            `db:"created_by" json:"created_by"`
+	AppliedAt   *time.Time         `db:"applied_at" json:"applied_at"` // Set once the scheduler has carried it out
+	CreatedAt   time.Time          `db:"created_at" json:"created_at"`
+}
+
+func (c SchedulableContent) IsValid() bool {
+	switch c {
+	case SchedulableEbook, SchedulableArticle, SchedulableInspiration:
+		return true
+	}
+	return false
+}
+
+// UpdatePermission is the permission needed to schedule the content: updating it
+func (c SchedulableContent) UpdatePermission() string {
+	switch c {
+	case SchedulableArticle:
+		return PermissionArticleUpdate
+	case SchedulableInspiration:
+		return PermissionInspirationUpdate
+	}
+	return PermissionEbookUpdate
+}
+
+func (a ScheduleAction) IsValid() bool {
+	return a == SchedulePublish || a == ScheduleUnpublish
+}
+
+// ContentStatus is the status the content is given when the action is applied.
+// Unpublished content is archived rather than returned to draft, so it is not mistaken for unfinished work.
+func (a ScheduleAction) ContentStatus() string {
+	if a == SchedulePublish {
+		return ContentStatusPublished
+	}
+	return ContentStatusArchived
+}
+
+// IsPending reports whether the schedule has yet to be applied
+func (s *ContentSchedule) IsPending() bool {
+	return s.AppliedAt == nil
+}
@@ -0,0 +1,133 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+// Cursor sort keys identify the list (and its ordering) a cursor was issued for,
+// so a cursor taken from one endpoint or sort order cannot be replayed against another
+const (
+	CursorSortSummaries  = "summaries.created_at"
+	CursorSortCategories = "categories.order_number"
+	CursorSortPayments   = "payments.created_at"
+)
+
+// EbookCursorSort returns the cursor sort key for a catalog sort order
+func EbookCursorSort(sort EbookSort) string {
+	if sort == "" {
+		sort = EbookSortNewest
+	}
+	return "ebooks." + string(sort)
+}
+
+// PageCursor marks a position in a keyset-paginated list ordered by (sort column, id)
+// Value holds the sort column of the boundary row and ID its primary key
+// Filter is a digest of the normalised filter set the list was issued for, empty when unfiltered
+// Backward cursors select the rows before the boundary instead of after it
+type PageCursor struct {
+	Sort     string `json:"s"`
+	Filter   string `json:"f,omitempty"`
+	Value    string `json:"v"`
+	ID       string `json:"id"`
+	Backward bool   `json:"b,omitempty"`
+}
+
+// CursorFilterHash digests a normalised filter key so a cursor cannot be replayed
+// against the same list with other filters; an empty key (no filters) yields an empty hash
+func CursorFilterHash(key string) string {
+	if key == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:12])
+}
+
+// Matches reports whether the cursor was issued for the given sort key and filter hash
+func (c *PageCursor) Matches(sort, filterHash string) bool {
+	return c.Sort == sort && c.Filter == filterHash
+}
+
+// CursorPageInfo holds the cursors of the neighbouring pages, nil when there is none
+type CursorPageInfo struct {
+	Next *PageCursor
+	Prev *PageCursor
+}
+
+// BindFilter stamps the filter hash of the list onto the neighbouring cursors
+func (p *CursorPageInfo) BindFilter(filterHash string) {
+	if p == nil {
+		return
+	}
+	if p.Next != nil {
+		p.Next.Filter = filterHash
+	}
+	if p.Prev != nil {
+		p.Prev.Filter = filterHash
+	}
+}
+
+// NewTimeCursor creates a cursor whose sort column is a timestamp
+func NewTimeCursor(sort string, value time.Time, id string) PageCursor {
+	return PageCursor{Sort: sort, Value: value.Format(time.RFC3339Nano), ID: id}
+}
+
+// NewIntCursor creates a cursor whose sort column is an integer
+func NewIntCursor(sort string, value int64, id string) PageCursor {
+	return PageCursor{Sort: sort, Value: strconv.FormatInt(value, 10), ID: id}
+}
+
+// TimeValue parses the sort value of a timestamp cursor
+func (c *PageCursor) TimeValue() (time.Time, error) {
+	return time.Parse(time.RFC3339Nano, c.Value)
+}
+
+// IntValue parses the sort value of an integer cursor
+func (c *PageCursor) IntValue() (int64, error) {
+	return strconv.ParseInt(c.Value, 10, 64)
+}
+
+// BuildCursorPage trims a keyset query result to the page size and works out the neighbouring cursors.
+// rows must hold up to limit+1 items ordered nearest-to-cursor first, which for backward cursors
+// is the reverse of the display order; the returned rows are always in display order.
+func BuildCursorPage[T any](rows []T, limit int, cursor *PageCursor, key func(T) PageCursor) ([]T, *CursorPageInfo) {
+	page := &CursorPageInfo{}
+	hasMore := len(rows) > limit
+	if hasMore {
+		rows = rows[:limit]
+	}
+
+	backward := cursor != nil && cursor.Backward
+	if backward {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+	}
+
+	if len(rows) == 0 {
+		return rows, page
+	}
+
+	first := key(rows[0])
+	first.Backward = true
+	last := key(rows[len(rows)-1])
+
+	if backward {
+		// We came from the page after this one, and there is a page before only if we over-fetched
+		page.Next = &last
+		if hasMore {
+			page.Prev = &first
+		}
+		return rows, page
+	}
+
+	if hasMore {
+		page.Next = &last
+	}
+	if cursor != nil {
+		page.Prev = &first
+	}
+	return rows, page
+}
@@ -1,6 +1,9 @@
 package entity
 
-import "time"
+import (
+	"math"
+	"time"
+)
 
 // EbookFormat represents the format of an ebook
 type EbookFormat string
@@ -29,19 +32,87 @@ type Ebook struct {
 	Format          EbookFormat `db:"format" json:"format"`
 	PageCount       int16       `db:"page_count" json:"page_count"`
 	PreviewPage     int16       `db:"preview_page" json:"preview_page"`
-	URL             string      `db:"url" json:"url"`
+	URL             string      `db:"url" json:"url,omitempty"`
+	CoverMediaID    *string     `db:"cover_media_id" json:"cover_media_id"`
+	FileMediaID     *string     `db:"file_media_id" json:"file_media_id"`
 	PublishedAt     *time.Time  `db:"published_at" json:"published_at"`
 	CreatedAt       time.Time   `db:"created_at" json:"created_at"`
 	UpdatedAt       time.Time   `db:"updated_at" json:"updated_at"`
+
+	// Storage keys of the attached media, kept so cached ebooks can still be resolved
+	CoverMediaKey *string `db:"cover_media_key" json:"cover_media_key,omitempty"`
+	FileMediaKey  *string `db:"file_media_key" json:"file_media_key,omitempty"`
+
+	// Name of the content status, loaded with the ebook by ID
+	ContentStatus string `db:"content_status" json:"content_status,omitempty"`
+}
+
+// ResolveMediaURLs points the cover at the attached media, if any
+func (e *Ebook) ResolveMediaURLs(url func(key string) string) {
+	e.CoverImage = ResolveMediaURL(e.CoverMediaKey, e.CoverImage, url)
+}
+
+// IsPublished reports whether the ebook has the published status and its publication time has come
+func (e *Ebook) IsPublished(now time.Time) bool {
+	return e.ContentStatus == ContentStatusPublished && e.PublishedAt != nil && !e.PublishedAt.After(now)
+}
+
+// PreviewPercentage is how far into the ebook its preview pages reach, or 0 if the page count is unknown
+func (e *Ebook) PreviewPercentage() float64 {
+	if e.PageCount <= 0 {
+		return 0
+	}
+	return math.Min(100, float64(e.PreviewPage)*100/float64(e.PageCount))
+}
+
+// HasFile reports whether there is a file to download, either uploaded media or a hand-entered location
+func (e *Ebook) HasFile() bool {
+	return (e.FileMediaKey != nil && *e.FileMediaKey != "") || e.URL != ""
 }
 
 type EbookList struct {
-	ID         string `db:"id"`
-	Title      string `db:"title"`
-	Slug       string `db:"slug"`
-	CoverImage string `db:"cover_image"`
-	Price      int    `db:"price"`
-	Discount   *int   `db:"discount"`
+	ID              string        `db:"id"`
+	Title           string        `db:"title"`
+	Slug            string        `db:"slug"`
+	CoverImage      string        `db:"cover_image"`
+	Price           int           `db:"price"`
+	Discount        *int          `db:"discount"`
+	PublishedAt     *time.Time    `db:"published_at"`
+	PopularityScore int           `db:"popularity_score"`
+	RatingAverage   float64       `db:"rating_average"`
+	RatingCount     int           `db:"rating_count"`
+	CoverMediaKey   *string       `db:"cover_media_key"`
+	CoverVariants   MediaVariants `db:"cover_variants"`
+}
+
+func (e *EbookList) ResolveMediaURLs(url func(key string) string) {
+	e.CoverImage = ResolveMediaURL(e.CoverMediaKey, e.CoverImage, url)
+	e.CoverVariants.ResolveURLs(url)
+}
+
+// EbookFeedEntry is a published ebook as listed in syndication feeds such as the OPDS catalog
+type EbookFeedEntry struct {
+	ID            string      `db:"id"`
+	Title         string      `db:"title"`
+	Synopsis      string      `db:"synopsis"`
+	Slug          string      `db:"slug"`
+	CoverImage    string      `db:"cover_image"`
+	Price         int         `db:"price"`
+	Discount      *int        `db:"discount"`
+	Language      string      `db:"language"`
+	Format        EbookFormat `db:"format"`
+	AuthorID      string      `db:"author_id"`
+	AuthorName    string      `db:"author_name"`
+	CategoryID    string      `db:"category_id"`
+	CategoryName  string      `db:"category_name"`
+	HasFile       bool        `db:"has_file"`
+	PublishedAt   *time.Time  `db:"published_at"`
+	UpdatedAt     time.Time   `db:"updated_at"`
+	CoverMediaKey *string     `db:"cover_media_key"`
+}
+
+func (e *EbookFeedEntry) ResolveMediaURLs(url func(key string) string) {
+	e.CoverImage = ResolveMediaURL(e.CoverMediaKey, e.CoverImage, url)
 }
 
 type EbookDetail struct {
@@ -62,14 +133,32 @@ type EbookDetail struct {
 	CreatedAt   time.Time   `db:"created_at"`
 	UpdatedAt   time.Time   `db:"updated_at"`
 
+	RatingAverage float64 `db:"rating_average"`
+	RatingCount   int     `db:"rating_count"`
+
 	AuthorID     string  `db:"author_id"`
 	AuthorName   string  `db:"author_name"`
 	AuthorAvatar *string `db:"author_avatar"`
 
+	CategoryID string `db:"category_id"`
+
+	Contributors []*EbookContributor `db:"-"` // Everyone who contributed, the primary author included
+
 	ContentStatus *string `db:"content_status"`
 
 	SummaryID *string `db:"summary_id"`
 	SummaryContent *string `db:"summary_content"`
 	SummaryAudioURL *string `db:"summary_audio_url"`
 	SummaryDuration *int `db:"summary_duration"`
+
+	CoverMediaKey        *string `db:"cover_media_key"`
+	SummaryAudioMediaKey *string `db:"summary_audio_media_key"`
+}
+
+func (e *EbookDetail) ResolveMediaURLs(url func(key string) string) {
+	e.CoverImage = ResolveMediaURL(e.CoverMediaKey, e.CoverImage, url)
+	if e.SummaryAudioMediaKey != nil && *e.SummaryAudioMediaKey != "" {
+		audioURL := url(*e.SummaryAudioMediaKey)
+		e.SummaryAudioURL = &audioURL
+	}
 }
@@ -0,0 +1,31 @@
+package entity
+
+// ContributorRole is what a person did for an ebook
+type ContributorRole string
+
+const (
+	ContributorAuthor     ContributorRole = "author"
+	ContributorTranslator ContributorRole = "translator"
+	ContributorEditor     ContributorRole = "editor"
+	ContributorNarrator   ContributorRole = "narrator" // Reads the audio summary
+)
+
+func (r ContributorRole) IsValid() bool {
+	switch r {
+	case ContributorAuthor, ContributorTranslator, ContributorEditor, ContributorNarrator:
+		return true
+	}
+	return false
+}
+
+// EbookContributor is a contribution of an author to an ebook. The ebook's AuthorID is its primary
+// author: the first contributor with the author role, who is always listed here too.
+type EbookContributor struct {
+	EbookID      string          `db:"ebook_id" json:"ebook_id"`
+	AuthorID     string          `db:"author_id" json:"author_id"`
+	Role         ContributorRole `db:"role" json:"role"`
+	DisplayOrder int             `db:"display_order" json:"display_order"`
+
+	AuthorName   string  `db:"author_name" json:"author_name"`
+	AuthorAvatar *string `db:"author_avatar" json:"author_avatar"`
+}
@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+// EbookDownload records a file download by a user
+// Clean Architecture: Entity layer, no dependencies on infrastructure
+type EbookDownload struct {
+	ID        string    `db:"id" json:"id"`
+	UserID    string    `db:"user_id" json:"user_id"`
+	EbookID   string    `db:"ebook_id" json:"ebook_id"`
+	IPAddress string    `db:"ip_address" json:"ip_address"`
+	UserAgent string    `db:"user_agent" json:"user_agent"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
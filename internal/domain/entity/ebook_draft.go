@@ -0,0 +1,11 @@
+package entity
+
+// EbookDraft is an ebook pre-filled from an uploaded file, for an editor to review before creating it.
+// TableOfContents is a tree in the shape accepted when replacing an ebook's table of contents.
+type EbookDraft struct {
+	Ebook           *Ebook
+	Author          *Author
+	AuthorCreated   bool
+	TableOfContents []*TableOfContent
+	Warnings        []string
+}
@@ -0,0 +1,155 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// EbookSort represents the ordering applied to the public ebook catalog
+type EbookSort string
+
+const (
+	EbookSortNewest     EbookSort = "newest"
+	EbookSortPriceAsc   EbookSort = "price_asc"
+	EbookSortPriceDesc  EbookSort = "price_desc"
+	EbookSortTitle      EbookSort = "title"
+	EbookSortPopularity EbookSort = "popularity" // popularity_score, 0 until the trending snapshot job first sets it
+)
+
+// IsValid reports whether the sort value is supported by the catalog
+func (s EbookSort) IsValid() bool {
+	switch s {
+	case EbookSortNewest, EbookSortPriceAsc, EbookSortPriceDesc, EbookSortTitle, EbookSortPopularity:
+		return true
+	}
+	return false
+}
+
+// MaxEbookQueryLength caps the free-text catalog search
+const MaxEbookQueryLength = 100
+
+// EbookFilter holds the faceted filters and sort order for the ebook catalog
+// A nil pointer field means the filter is not applied
+type EbookFilter struct {
+	Query         string      `json:"q,omitempty"`           // Matches the title or the author name
+	CategoryID    string      `json:"category_id,omitempty"` // Matches the category and all of its descendants
+	AuthorID      string      `json:"author_id,omitempty"`   // Matches the ebooks the author contributed to in any role
+	Language      string      `json:"language,omitempty"`
+	Format        EbookFormat `json:"format,omitempty"`
+	MinPrice      *int        `json:"min_price,omitempty"`
+	MaxPrice      *int        `json:"max_price,omitempty"`
+	FreeOnly      bool        `json:"free_only,omitempty"`
+	Discounted    bool        `json:"discounted,omitempty"`
+	PublishedFrom *time.Time  `json:"published_from,omitempty"`
+	PublishedTo   *time.Time  `json:"published_to,omitempty"` // Exclusive end of the published range
+	Sort          EbookSort   `json:"sort,omitempty"`
+}
+
+// Normalize trims and lowercases the free-text values and applies the default sort
+// so that equivalent filters produce the same cache key
+func (f *EbookFilter) Normalize() {
+	f.Query = strings.Join(strings.Fields(f.Query), " ")
+	f.CategoryID = strings.TrimSpace(f.CategoryID)
+	f.AuthorID = strings.TrimSpace(f.AuthorID)
+	f.Language = strings.ToLower(strings.TrimSpace(f.Language))
+	f.Format = EbookFormat(strings.ToLower(strings.TrimSpace(string(f.Format))))
+	f.Sort = EbookSort(strings.ToLower(strings.TrimSpace(string(f.Sort))))
+	if f.Sort == "" {
+		f.Sort = EbookSortNewest
+	}
+	if f.MinPrice != nil && f.MaxPrice != nil && *f.MinPrice > *f.MaxPrice {
+		f.MinPrice, f.MaxPrice = f.MaxPrice, f.MinPrice
+	}
+	if f.PublishedFrom != nil && f.PublishedTo != nil && f.PublishedFrom.After(*f.PublishedTo) {
+		f.PublishedFrom, f.PublishedTo = f.PublishedTo, f.PublishedFrom
+	}
+}
+
+// Validate checks that enumerated values are supported
+func (f *EbookFilter) Validate() error {
+	if len(f.Query) > MaxEbookQueryLength {
+		return fmt.Errorf("q must be at most %d characters", MaxEbookQueryLength)
+	}
+	if f.Format != "" && f.Format != FormatPDF && f.Format != FormatEPUB && f.Format != FormatMOBI {
+		return fmt.Errorf("unsupported format: %s", f.Format)
+	}
+	if f.Sort != "" && !f.Sort.IsValid() {
+		return fmt.Errorf("unsupported sort: %s", f.Sort)
+	}
+	if f.MinPrice != nil && *f.MinPrice < 0 {
+		return fmt.Errorf("min_price must not be negative")
+	}
+	if f.MaxPrice != nil && *f.MaxPrice < 0 {
+		return fmt.Errorf("max_price must not be negative")
+	}
+	return nil
+}
+
+// CacheKey returns a deterministic representation of the normalised filter set
+// Fields are emitted in a fixed order and empty filters are omitted
+func (f *EbookFilter) CacheKey() string {
+	if f == nil {
+		return "sort=" + string(EbookSortNewest)
+	}
+
+	parts := []string{}
+	if f.Query != "" {
+		parts = append(parts, "q="+strings.ToLower(f.Query))
+	}
+	if f.CategoryID != "" {
+		parts = append(parts, "cat="+f.CategoryID)
+	}
+	if f.AuthorID != "" {
+		parts = append(parts, "author="+f.AuthorID)
+	}
+	if f.Language != "" {
+		parts = append(parts, "lang="+f.Language)
+	}
+	if f.Format != "" {
+		parts = append(parts, "fmt="+string(f.Format))
+	}
+	if f.MinPrice != nil {
+		parts = append(parts, fmt.Sprintf("min=%d", *f.MinPrice))
+	}
+	if f.MaxPrice != nil {
+		parts = append(parts, fmt.Sprintf("max=%d", *f.MaxPrice))
+	}
+	if f.FreeOnly {
+		parts = append(parts, "free=1")
+	}
+	if f.Discounted {
+		parts = append(parts, "disc=1")
+	}
+	if f.PublishedFrom != nil {
+		parts = append(parts, fmt.Sprintf("from=%d", f.PublishedFrom.Unix()))
+	}
+	if f.PublishedTo != nil {
+		parts = append(parts, fmt.Sprintf("to=%d", f.PublishedTo.Unix()))
+	}
+
+	sort := f.Sort
+	if sort == "" {
+		sort = EbookSortNewest
+	}
+	parts = append(parts, "sort="+string(sort))
+
+	return strings.Join(parts, "|")
+}
+
+// FacetCount is the number of catalog entries sharing a facet value
+type FacetCount struct {
+	Value string `db:"value" json:"value"`
+	Label string `db:"label" json:"label"`
+	Count int64  `db:"count" json:"count"`
+}
+
+// EbookFacets groups facet counts for the ebooks matching a filter
+type EbookFacets struct {
+	Categories []FacetCount `json:"categories"`
+	Authors    []FacetCount `json:"authors"`
+	Languages  []FacetCount `json:"languages"`
+	Formats    []FacetCount `json:"formats"`
+	Free       int64        `json:"free"`
+	Discounted int64        `json:"discounted"`
+}
@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+// EbookPage is one readable unit of an ebook: a rendered PDF page or an EPUB spine item
+// PageNumber starts at 1 and is what preview_page is compared against
+type EbookPage struct {
+	ID          string    `db:"id" json:"id"`
+	EbookID     string    `db:"ebook_id" json:"ebook_id"`
+	PageNumber  int       `db:"page_number" json:"page_number"`
+	Title       *string   `db:"title" json:"title,omitempty"`
+	ContentType string    `db:"content_type" json:"content_type"`
+	Content     string    `db:"content" json:"content"`
+	CreatedAt   time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at" json:"updated_at"`
+}
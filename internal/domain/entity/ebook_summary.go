@@ -4,13 +4,20 @@ import "time"
 
 // EbookSummary represents a summary for an ebook
 type EbookSummary struct {
-	ID          string    `db:"id" json:"id"`
-	EbookID     string    `db:"ebook_id" json:"ebook_id"`
-	Description string    `db:"description" json:"description"`
-	URL         string    `db:"url" json:"url"`
-	AudioURL    string    `db:"audio_url" json:"audio_url"`
-	CreatedAt   time.Time `db:"created_at" json:"created_at"`
-	UpdatedAt   time.Time `db:"updated_at" json:"updated_at"`
+	ID           string    `db:"id" json:"id"`
+	EbookID      string    `db:"ebook_id" json:"ebook_id"`
+	Description  string    `db:"description" json:"description"`
+	URL          string    `db:"url" json:"url"`
+	AudioURL     string    `db:"audio_url" json:"audio_url"`
+	AudioMediaID *string   `db:"audio_media_id" json:"audio_media_id"`
+	CreatedAt    time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt    time.Time `db:"updated_at" json:"updated_at"`
+
+	AudioMediaKey *string `db:"audio_media_key" json:"audio_media_key,omitempty"`
+}
+
+func (s *EbookSummary) ResolveMediaURLs(url func(key string) string) {
+	s.AudioURL = ResolveMediaURL(s.AudioMediaKey, s.AudioURL, url)
 }
 
 type EbookSummaryList struct {
@@ -21,7 +28,13 @@ type EbookSummaryList struct {
 	Description string    `db:"description" json:"description"`
 	URL         string    `db:"url" json:"url"`
 	AudioURL    string    `db:"audio_url" json:"audio_url"`
-	Duration    int	      `db:"duration" json:"duration"`
+	Duration    int       `db:"duration" json:"duration"`
 	CreatedAt   time.Time `db:"created_at" json:"created_at"`
 	UpdatedAt   time.Time `db:"updated_at" json:"updated_at"`
+
+	AudioMediaKey *string `db:"audio_media_key" json:"audio_media_key,omitempty"`
+}
+
+func (s *EbookSummaryList) ResolveMediaURLs(url func(key string) string) {
+	s.AudioURL = ResolveMediaURL(s.AudioMediaKey, s.AudioURL, url)
 }
@@ -0,0 +1,61 @@
+package entity
+
+import "time"
+
+// FeedContent is a kind of content syndicated through RSS and Atom feeds
+type FeedContent string
+
+const (
+	FeedArticles     FeedContent = "articles"
+	FeedInspirations FeedContent = "inspirations"
+	FeedEbooks       FeedContent = "ebooks"
+)
+
+// IsValid reports whether the content has feeds
+func (c FeedContent) IsValid() bool {
+	switch c {
+	case FeedArticles, FeedInspirations, FeedEbooks:
+		return true
+	}
+	return false
+}
+
+// FeedFilter narrows a feed down to a category or an author; empty fields are not applied
+type FeedFilter struct {
+	CategoryID string
+	AuthorID   string
+}
+
+// FeedItem is a published article, inspiration or ebook as listed in a feed
+type FeedItem struct {
+	ID           string    `db:"id"`
+	Title        string    `db:"title"`
+	Slug         string    `db:"slug"`
+	Excerpt      string    `db:"excerpt"`
+	CoverImage   string    `db:"cover_image"`
+	AuthorID     string    `db:"author_id"`
+	AuthorName   string    `db:"author_name"`
+	CategoryID   string    `db:"category_id"`
+	CategoryName string    `db:"category_name"`
+	PublishedAt  time.Time `db:"published_at"`
+	UpdatedAt    time.Time `db:"updated_at"`
+}
+
+// Feed is the newest published content of a kind, optionally of a single category or author
+type Feed struct {
+	Content  FeedContent
+	Category *Category
+	Author   *Author
+	Items    []*FeedItem
+}
+
+// Updated is when the latest item changed, zero for an empty feed
+func (f *Feed) Updated() time.Time {
+	var updated time.Time
+	for _, item := range f.Items {
+		if item.UpdatedAt.After(updated) {
+			updated = item.UpdatedAt
+		}
+	}
+	return updated
+}
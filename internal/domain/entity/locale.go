@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"context"
+	"strings"
+)
+
+// LocaleSet is the language content is stored in and the languages it can be translated into
+type LocaleSet struct {
+	Default   string
+	Supported []string // Includes Default
+}
+
+// Match returns the supported locale for a language tag, comparing the full tag first
+// and then its primary subtag, so "en-US" matches "en"
+func (s LocaleSet) Match(tag string) (string, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if tag == "" {
+		return "", false
+	}
+	primary, _, _ := strings.Cut(tag, "-")
+	for _, candidate := range []string{tag, primary} {
+		for _, locale := range s.Supported {
+			if locale == candidate {
+				return locale, true
+			}
+		}
+	}
+	return "", false
+}
+
+// IsTranslation reports whether locale is a supported language other than the default
+func (s LocaleSet) IsTranslation(locale string) bool {
+	matched, ok := s.Match(locale)
+	return ok && matched == locale && locale != s.Default
+}
+
+type translationLocaleKey struct{}
+
+// ContextWithTranslationLocale returns a context whose reads are translated into locale.
+// An empty locale means content is read as stored, in the default language.
+func ContextWithTranslationLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, translationLocaleKey{}, locale)
+}
+
+// TranslationLocale returns the locale reads are translated into, or "" for the default language
+func TranslationLocale(ctx context.Context) string {
+	locale, _ := ctx.Value(translationLocaleKey{}).(string)
+	return locale
+}
@@ -0,0 +1,184 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// MediaKind is what an uploaded file is used for; it decides the allowed types, the size limit and who may fetch it
+type MediaKind string
+
+const (
+	MediaCover     MediaKind = "cover"
+	MediaEbookFile MediaKind = "ebook_file"
+	MediaAudio     MediaKind = "audio"
+	MediaBanner    MediaKind = "banner"
+	MediaIcon      MediaKind = "icon"
+)
+
+const (
+	mediaImageMaxSize = 10 << 20
+	mediaIconMaxSize  = 1 << 20
+	mediaFileMaxSize  = 500 << 20
+)
+
+// Media is an uploaded file. Entities reference it by ID and its URL is resolved from StorageKey when responding,
+// so switching storage drivers or CDNs does not require rewriting stored URLs.
+type Media struct {
+	ID          string    `db:"id" json:"id"`
+	Kind        MediaKind `db:"kind" json:"kind"`
+	StorageKey  string    `db:"storage_key" json:"storage_key"`
+	Filename    string    `db:"filename" json:"filename"`
+	ContentType string    `db:"content_type" json:"content_type"`
+	Size        int64     `db:"size" json:"size"`
+	Checksum    string    `db:"checksum" json:"checksum"` // Hex SHA-256 of the content
+	UploadedBy  *string   `db:"uploaded_by" json:"uploaded_by"`
+	CreatedAt   time.Time `db:"created_at" json:"created_at"`
+}
+
+func (k MediaKind) IsValid() bool {
+	switch k {
+	case MediaCover, MediaEbookFile, MediaAudio, MediaBanner, MediaIcon:
+		return true
+	}
+	return false
+}
+
+// IsPublic reports whether anyone may fetch the media by URL.
+// Ebook files are private and only streamed to users entitled to the ebook.
+func (k MediaKind) IsPublic() bool {
+	return k.IsValid() && k != MediaEbookFile
+}
+
+// UploadPermission is the permission needed to upload media of the kind: creating the content it belongs to
+func (k MediaKind) UploadPermission() string {
+	switch k {
+	case MediaCover, MediaEbookFile:
+		return PermissionEbookCreate
+	case MediaAudio:
+		return PermissionSummaryCreate
+	case MediaBanner:
+		return PermissionBannerCreate
+	case MediaIcon:
+		return PermissionCategoryCreate
+	}
+	return ""
+}
+
+// Dir is the storage key prefix for the kind
+func (k MediaKind) Dir() string {
+	switch k {
+	case MediaCover:
+		return "covers"
+	case MediaEbookFile:
+		return "ebooks"
+	case MediaBanner:
+		return "banners"
+	case MediaIcon:
+		return "icons"
+	default:
+		return string(k)
+	}
+}
+
+// MaxSize is the largest upload accepted for the kind, in bytes
+func (k MediaKind) MaxSize() int64 {
+	switch k {
+	case MediaIcon:
+		return mediaIconMaxSize
+	case MediaCover, MediaBanner:
+		return mediaImageMaxSize
+	default:
+		return mediaFileMaxSize
+	}
+}
+
+// AllowedContentTypes lists the detected content types accepted for the kind
+func (k MediaKind) AllowedContentTypes() []string {
+	switch k {
+	case MediaCover, MediaBanner:
+		return []string{"image/jpeg", "image/png", "image/webp"}
+	case MediaIcon:
+		return []string{"image/png", "image/webp"}
+	case MediaEbookFile:
+		return []string{"application/pdf", "application/epub+zip", "application/x-mobipocket-ebook"}
+	case MediaAudio:
+		return []string{"audio/mpeg", "audio/mp4", "audio/ogg", "audio/wav"}
+	}
+	return nil
+}
+
+// ResolveMediaURL returns the URL of the attached media when there is one, otherwise the hand-entered URL
+func ResolveMediaURL(mediaKey *string, fallback string, url func(key string) string) string {
+	if mediaKey == nil || *mediaKey == "" {
+		return fallback
+	}
+	return url(*mediaKey)
+}
+
+// HasImageVariants reports whether resized variants are generated for uploads of the kind
+func (k MediaKind) HasImageVariants() bool {
+	return k == MediaCover || k == MediaBanner
+}
+
+// ImageVariantSize is a named width images are resized to; heights keep the aspect ratio
+type ImageVariantSize struct {
+	Name  string
+	Width int
+}
+
+// ImageVariantSizes are generated for every cover and banner wider than the size, smallest first
+var ImageVariantSizes = []ImageVariantSize{
+	{Name: "thumbnail", Width: 200},
+	{Name: "medium", Width: 600},
+	{Name: "large", Width: 1200},
+}
+
+// ImageVariantFormats are the encodings every variant is stored in
+var ImageVariantFormats = []string{"jpeg", "png"}
+
+// MediaVariant is a resized copy of an uploaded image
+type MediaVariant struct {
+	ID         string    `db:"id" json:"id,omitempty"`
+	MediaID    string    `db:"media_id" json:"media_id,omitempty"`
+	Name       string    `db:"name" json:"name"`
+	Format     string    `db:"format" json:"format"`
+	StorageKey string    `db:"storage_key" json:"storage_key"`
+	Width      int       `db:"width" json:"width"`
+	Height     int       `db:"height" json:"height"`
+	Size       int64     `db:"size" json:"size"`
+	Checksum   string    `db:"checksum" json:"checksum,omitempty"`
+	CreatedAt  time.Time `db:"created_at" json:"created_at,omitempty"`
+
+	URL string `json:"url,omitempty"` // Resolved from StorageKey when responding
+}
+
+// ContentType is the MIME type of the variant's encoding
+func (v *MediaVariant) ContentType() string {
+	return "image/" + v.Format
+}
+
+// MediaVariants are the variants of one image, read from the JSON array the catalog queries aggregate
+type MediaVariants []MediaVariant
+
+// Scan implements sql.Scanner; NULL (no variants) scans to nil
+func (v *MediaVariants) Scan(src any) error {
+	switch data := src.(type) {
+	case nil:
+		*v = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(data, v)
+	case string:
+		return json.Unmarshal([]byte(data), v)
+	}
+	return fmt.Errorf("cannot scan %T into MediaVariants", src)
+}
+
+// ResolveURLs sets the URL of every variant from its storage key
+func (v MediaVariants) ResolveURLs(url func(key string) string) {
+	for i := range v {
+		v[i].URL = url(v[i].StorageKey)
+	}
+}
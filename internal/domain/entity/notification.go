@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// NotificationType identifies the kind of event a user is notified about
+type NotificationType string
+
+const (
+	NotificationPriceDrop NotificationType = "price_drop"
+)
+
+// PriceDropReason tells whether a price drop comes from a discount or a lower list price
+type PriceDropReason string
+
+const (
+	PriceDropDiscount    PriceDropReason = "discount"
+	PriceDropPriceChange PriceDropReason = "price_change"
+)
+
+// NotificationEvent is a message queued for a user
+// Events are written to an outbox table that delivery channels and clients read from.
+type NotificationEvent struct {
+	ID        string           `db:"id" json:"id"`
+	UserID    string           `db:"user_id" json:"user_id"`
+	Type      NotificationType `db:"type" json:"type"`
+	EbookID   *string          `db:"ebook_id" json:"ebook_id,omitempty"`
+	Payload   json.RawMessage  `db:"payload" json:"payload"`
+	CreatedAt time.Time        `db:"created_at" json:"created_at"`
+}
+
+// PriceDrop describes a wishlisted ebook getting cheaper; it is the payload of price_drop events
+type PriceDrop struct {
+	EbookID    string          `json:"ebook_id"`
+	Title      string          `json:"title"`
+	Slug       string          `json:"slug"`
+	CoverImage string          `json:"cover_image"`
+	Reason     PriceDropReason `json:"reason"`
+	OldPrice   int             `json:"old_price"`
+	NewPrice   int             `json:"new_price"`
+	DiscountID *string         `json:"discount_id,omitempty"`
+	EndsAt     *time.Time      `json:"ends_at,omitempty"`
+}
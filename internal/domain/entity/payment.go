@@ -15,10 +15,12 @@ const (
 // Clean Architecture: Entity layer, no dependencies on infrastructure
 // XenditReference can store invoice ID or payment reference from Xendit
 // UserID links to the user making the payment
+// EbookID links to the purchased ebook; a paid payment for an ebook grants ownership
 // Amount is in smallest currency unit (e.g., cents)
 type Payment struct {
 	ID              string        `db:"id" json:"id"`
 	UserID          string        `db:"user_id" json:"user_id"`
+	EbookID         *string       `db:"ebook_id" json:"ebook_id,omitempty"`
 	Amount          int64         `db:"amount" json:"amount"`
 	Currency        string        `db:"currency" json:"currency"`
 	Status          PaymentStatus `db:"status" json:"status"`
@@ -30,6 +30,7 @@ const (
 	ResourceAuthor      ResourceType = "author"
 	ResourcePayment     ResourceType = "payment"
 	ResourceComment     ResourceType = "comment"
+	ResourceReview      ResourceType = "review"
 	ResourceSEO         ResourceType = "seo"
 )
 
@@ -143,6 +144,14 @@ const (
 	PermissionCommentList   = "comment:list"
 	PermissionCommentManage = "comment:manage"
 
+	// Review permissions
+	PermissionReviewCreate = "review:create"
+	PermissionReviewRead   = "review:read"
+	PermissionReviewUpdate = "review:update"
+	PermissionReviewDelete = "review:delete"
+	PermissionReviewList   = "review:list"
+	PermissionReviewManage = "review:manage" // Moderation: hide and show reviews
+
 	// SEO permissions
 	PermissionSEOCreate = "seo:create"
 	PermissionSEORead   = "seo:read"
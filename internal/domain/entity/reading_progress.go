@@ -0,0 +1,39 @@
+package entity
+
+import "time"
+
+// ReadingProgress is where a user is in an ebook, synced across their devices
+// ClientUpdatedAt is the device's time of the reading event and decides which write wins
+type ReadingProgress struct {
+	UserID          string    `db:"user_id" json:"user_id"`
+	EbookID         string    `db:"ebook_id" json:"ebook_id"`
+	Page            int       `db:"page" json:"page"`
+	CFI             *string   `db:"cfi" json:"cfi,omitempty"` // EPUB canonical fragment identifier
+	Percentage      float64   `db:"percentage" json:"percentage"`
+	ClientUpdatedAt time.Time `db:"client_updated_at" json:"client_updated_at"`
+	UpdatedAt       time.Time `db:"updated_at" json:"updated_at"`
+}
+
+// IsNewerThan reports whether this progress should replace other under last-writer-wins
+func (p *ReadingProgress) IsNewerThan(other *ReadingProgress) bool {
+	return other == nil || !p.ClientUpdatedAt.Before(other.ClientUpdatedAt)
+}
+
+// PastPreview reports whether the progress goes beyond the preview of the ebook,
+// by page for paged formats or by percentage for reflowable ones
+func (p *ReadingProgress) PastPreview(ebook *Ebook) bool {
+	return p.Page > int(ebook.PreviewPage) || (ebook.PageCount > 0 && p.Percentage > ebook.PreviewPercentage())
+}
+
+// ReadingProgressItem is an entry of the continue reading list
+type ReadingProgressItem struct {
+	ReadingProgress
+	Title         string  `db:"title"`
+	Slug          string  `db:"slug"`
+	CoverImage    string  `db:"cover_image"`
+	CoverMediaKey *string `db:"cover_media_key"`
+}
+
+func (i *ReadingProgressItem) ResolveMediaURLs(url func(key string) string) {
+	i.CoverImage = ResolveMediaURL(i.CoverMediaKey, i.CoverImage, url)
+}
@@ -0,0 +1,28 @@
+package entity
+
+// RecommendationCandidate is a published ebook with the attributes the recommender scores on
+type RecommendationCandidate struct {
+	EbookID         string `db:"id"`
+	AuthorID        string `db:"author_id"`
+	CategoryID      string `db:"category_id"`
+	PopularityScore int    `db:"popularity_score"`
+}
+
+// EbookPairCount is the number of users who bought, or read, both ebooks of a pair
+type EbookPairCount struct {
+	EbookID      string `db:"ebook_id"`
+	OtherEbookID string `db:"other_ebook_id"`
+	Users        int    `db:"users"`
+}
+
+// UserEbook links a user to an ebook they bought or started reading
+type UserEbook struct {
+	UserID  string `db:"user_id"`
+	EbookID string `db:"ebook_id"`
+}
+
+// ScoredEbook is a recommended ebook with its relevance score, highest first in a list
+type ScoredEbook struct {
+	EbookID string  `json:"ebook_id"`
+	Score   float64 `json:"score"`
+}
@@ -0,0 +1,39 @@
+package entity
+
+import "time"
+
+// ReviewStatus represents whether a review is shown to other readers
+type ReviewStatus string
+
+const (
+	ReviewVisible ReviewStatus = "visible"
+	ReviewHidden  ReviewStatus = "hidden"
+)
+
+// Review is a 1–5 star rating with an optional text a reader left for an ebook
+// Only visible reviews count towards the ebook's rating aggregate.
+type Review struct {
+	ID          string       `db:"id" json:"id"`
+	UserID      string       `db:"user_id" json:"user_id"`
+	EbookID     string       `db:"ebook_id" json:"ebook_id"`
+	Rating      int          `db:"rating" json:"rating"`
+	Body        *string      `db:"body" json:"body,omitempty"`
+	Status      ReviewStatus `db:"status" json:"status"`
+	ReportCount int          `db:"report_count" json:"report_count"`
+	HiddenAt    *time.Time   `db:"hidden_at" json:"hidden_at,omitempty"`
+	HiddenBy    *string      `db:"hidden_by" json:"hidden_by,omitempty"`
+	CreatedAt   time.Time    `db:"created_at" json:"created_at"`
+	UpdatedAt   time.Time    `db:"updated_at" json:"updated_at"`
+
+	ReviewerName   string  `db:"reviewer_name" json:"reviewer_name"`
+	ReviewerAvatar *string `db:"reviewer_avatar" json:"reviewer_avatar,omitempty"`
+}
+
+// ReviewReport is a reader's report of an inappropriate review
+type ReviewReport struct {
+	ID        string    `db:"id" json:"id"`
+	ReviewID  string    `db:"review_id" json:"review_id"`
+	UserID    string    `db:"user_id" json:"user_id"`
+	Reason    *string   `db:"reason" json:"reason,omitempty"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
+}
@@ -20,6 +20,7 @@ type SeoMeta struct {
 	Title       string        `db:"title" json:"title"`
 	Description *string       `db:"description" json:"description"`
 	Keywords    *string       `db:"keywords" json:"keywords"`
+	NoIndex     bool          `db:"noindex" json:"noindex"` // Keeps the page out of search engines and the sitemaps
 	Entity      SeoEntityType `db:"entity" json:"entity"`
 	EntityID    string        `db:"entity_id" json:"entity_id"`
 	CreatedAt   time.Time     `db:"created_at" json:"created_at"`
@@ -0,0 +1,48 @@
+package entity
+
+import "time"
+
+// Series is a set of ebooks meant to be read in order
+type Series struct {
+	ID           string    `db:"id" json:"id"`
+	Name         string    `db:"name" json:"name"`
+	Slug         string    `db:"slug" json:"slug"`
+	Description  *string   `db:"description" json:"description"`
+	CoverImage   string    `db:"cover_image" json:"cover_image"`
+	CoverMediaID *string   `db:"cover_media_id" json:"cover_media_id"`
+	CreatedAt    time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt    time.Time `db:"updated_at" json:"updated_at"`
+
+	VolumeCount   int     `db:"volume_count" json:"volume_count"` // Published volumes
+	CoverMediaKey *string `db:"cover_media_key" json:"cover_media_key,omitempty"`
+}
+
+func (s *Series) ResolveMediaURLs(url func(key string) string) {
+	s.CoverImage = ResolveMediaURL(s.CoverMediaKey, s.CoverImage, url)
+}
+
+// SeriesVolume is an ebook of a series and its volume number
+type SeriesVolume struct {
+	SeriesID string `db:"series_id"`
+	EbookID  string `db:"ebook_id"`
+	Volume   int    `db:"volume"`
+
+	Title         string  `db:"title"`
+	Slug          string  `db:"slug"`
+	CoverImage    string  `db:"cover_image"`
+	Price         int     `db:"price"`
+	CoverMediaKey *string `db:"cover_media_key"`
+}
+
+func (v *SeriesVolume) ResolveMediaURLs(url func(key string) string) {
+	v.CoverImage = ResolveMediaURL(v.CoverMediaKey, v.CoverImage, url)
+}
+
+// SeriesMembership places an ebook in its series: part Volume of the series
+type SeriesMembership struct {
+	SeriesID    string `db:"series_id"`
+	SeriesName  string `db:"series_name"`
+	SeriesSlug  string `db:"series_slug"`
+	Volume      int    `db:"volume"`
+	VolumeCount int    `db:"volume_count"` // Published volumes
+}
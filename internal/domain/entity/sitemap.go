@@ -0,0 +1,33 @@
+package entity
+
+import "time"
+
+// SitemapContent is a kind of public page listed in the sitemaps. Its value is the first
+// segment of the storefront path of its pages, /{content}/{slug}.
+type SitemapContent string
+
+const (
+	SitemapEbook       SitemapContent = "ebooks"
+	SitemapArticle     SitemapContent = "articles"
+	SitemapInspiration SitemapContent = "inspirations"
+	SitemapCategory    SitemapContent = "categories"
+)
+
+// SitemapContents lists the kinds of pages in the order their sitemaps appear in the index
+var SitemapContents = []SitemapContent{SitemapEbook, SitemapArticle, SitemapInspiration, SitemapCategory}
+
+// SitemapIndexName is the name the sitemap index is stored under
+const SitemapIndexName = "sitemap.xml"
+
+// SitemapEntry is a public page listed in a sitemap
+type SitemapEntry struct {
+	Slug      string    `db:"slug"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// SitemapSet is a generated sitemap index and its child sitemaps by file name.
+// Version identifies the content it was generated from.
+type SitemapSet struct {
+	Version   string
+	Documents map[string][]byte
+}
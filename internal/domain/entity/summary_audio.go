@@ -0,0 +1,34 @@
+package entity
+
+import "time"
+
+// SummaryAudio is the audio of a regular or premium summary. Premium summaries are only played to
+// users with premium access; regular ones to anyone.
+type SummaryAudio struct {
+	SummaryID        string    `db:"id" json:"summary_id"`
+	EbookID          string    `db:"ebook_id" json:"ebook_id"`
+	Premium          bool      `db:"premium" json:"premium"`
+	AudioURL         string    `db:"audio_url" json:"-"` // Hand-entered location, used when no audio is uploaded
+	AudioMediaKey    *string   `db:"audio_media_key" json:"-"`
+	AudioContentType *string   `db:"audio_content_type" json:"-"`
+	UpdatedAt        time.Time `db:"updated_at" json:"updated_at"`
+
+	// Location is where the audio is streamed from, resolved from the media or the audio URL
+	Location string `json:"-"`
+}
+
+// ListeningProgress is where a user is in a summary's audio, synced across their devices
+// ClientUpdatedAt is the device's time of the position and decides which write wins
+type ListeningProgress struct {
+	UserID          string    `db:"user_id" json:"user_id"`
+	SummaryID       string    `db:"summary_id" json:"summary_id"`
+	PositionSeconds int       `db:"position_seconds" json:"position_seconds"`
+	DurationSeconds *int      `db:"duration_seconds" json:"duration_seconds,omitempty"` // As reported by the player
+	ClientUpdatedAt time.Time `db:"client_updated_at" json:"client_updated_at"`
+	UpdatedAt       time.Time `db:"updated_at" json:"updated_at"`
+}
+
+// IsNewerThan reports whether this progress should replace other under last-writer-wins
+func (p *ListeningProgress) IsNewerThan(other *ListeningProgress) bool {
+	return other == nil || !p.ClientUpdatedAt.Before(other.ClientUpdatedAt)
+}
@@ -4,11 +4,40 @@ import "time"
 
 // TableOfContent represents a table of content entry in the system
 // Clean Architecture: Entity layer, no dependencies on infrastructure
+// Entries form a tree through ParentID; OrderNumber orders siblings and Depth is 0 for top-level chapters.
 type TableOfContent struct {
 	ID          string    `db:"id" json:"id"`
 	EbookID     string    `db:"ebook_id" json:"ebook_id"`
+	ParentID    *string   `db:"parent_id" json:"parent_id,omitempty"`
 	Title       string    `db:"title" json:"title"`
 	PageNumber  int16     `db:"page_number" json:"page_number"`
+	OrderNumber int       `db:"order_number" json:"order_number"`
+	Depth       int       `db:"depth" json:"depth"`
 	CreatedAt   time.Time `db:"created_at" json:"created_at"`
 	UpdatedAt   time.Time `db:"updated_at" json:"updated_at"`
+
+	Children []*TableOfContent `db:"-" json:"children,omitempty"`
+}
+
+// BuildTableOfContentTree nests flat entries under their parents and returns the top-level entries.
+// Siblings keep the order of entries, so pass them sorted by depth and order number.
+// Entries whose parent is missing are treated as top-level.
+func BuildTableOfContentTree(entries []*TableOfContent) []*TableOfContent {
+	byID := make(map[string]*TableOfContent, len(entries))
+	for _, entry := range entries {
+		byID[entry.ID] = entry
+	}
+
+	roots := make([]*TableOfContent, 0)
+	for _, entry := range entries {
+		if entry.ParentID != nil {
+			if parent, ok := byID[*entry.ParentID]; ok {
+				parent.Children = append(parent.Children, entry)
+				continue
+			}
+		}
+		roots = append(roots, entry)
+	}
+
+	return roots
 }
@@ -0,0 +1,60 @@
+package entity
+
+import "time"
+
+// TranslatableContent is a kind of content whose text can be translated
+type TranslatableContent string
+
+const (
+	TranslatableEbook    TranslatableContent = "ebook"
+	TranslatableCategory TranslatableContent = "category"
+	TranslatableArticle  TranslatableContent = "article"
+	TranslatableBanner   TranslatableContent = "banner"
+)
+
+// Translation is the text of a piece of content in one locale. Fields holds the translated
+// text by field name; a field left out falls back to the text in the default language.
+type Translation struct {
+	ContentType TranslatableContent `json:"content_type"`
+	ContentID   string              `json:"content_id"`
+	Locale      string              `json:"locale"`
+	Fields      map[string]string   `json:"fields"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+}
+
+func (c TranslatableContent) IsValid() bool {
+	switch c {
+	case TranslatableEbook, TranslatableCategory, TranslatableArticle, TranslatableBanner:
+		return true
+	}
+	return false
+}
+
+// Fields lists the translatable text fields of the content with their maximum length in characters;
+// 0 means the field is a long text without a limit
+func (c TranslatableContent) Fields() map[string]int {
+	switch c {
+	case TranslatableEbook:
+		return map[string]int{"title": 255, "synopsis": 0}
+	case TranslatableCategory:
+		return map[string]int{"name": 255, "description": 0}
+	case TranslatableArticle:
+		return map[string]int{"title": 255, "excerpt": 255, "content": 0}
+	case TranslatableBanner:
+		return map[string]int{"title": 255, "cta_label": 255}
+	}
+	return nil
+}
+
+// UpdatePermission is the permission needed to translate the content: updating it
+func (c TranslatableContent) UpdatePermission() string {
+	switch c {
+	case TranslatableCategory:
+		return PermissionCategoryUpdate
+	case TranslatableArticle:
+		return PermissionArticleUpdate
+	case TranslatableBanner:
+		return PermissionBannerUpdate
+	}
+	return PermissionEbookUpdate
+}
@@ -0,0 +1,82 @@
+package entity
+
+import "time"
+
+// EngagementEvent is something a reader did with an ebook that counts toward trending
+type EngagementEvent string
+
+const (
+	EngagementView     EngagementEvent = "view"
+	EngagementRead     EngagementEvent = "read"
+	EngagementWishlist EngagementEvent = "wishlist"
+	EngagementPurchase EngagementEvent = "purchase"
+)
+
+// Weight is how much the event adds to an ebook's trending score; a purchase says more than a view
+func (e EngagementEvent) Weight() float64 {
+	switch e {
+	case EngagementView:
+		return 1
+	case EngagementRead:
+		return 3
+	case EngagementWishlist:
+		return 5
+	case EngagementPurchase:
+		return 10
+	default:
+		return 0
+	}
+}
+
+// TrendingWindow is how far back the trending ranking looks
+type TrendingWindow string
+
+const (
+	TrendingWindowDay   TrendingWindow = "24h"
+	TrendingWindowWeek  TrendingWindow = "7d"
+	TrendingWindowMonth TrendingWindow = "30d"
+)
+
+// MaxTrendingWindow is the longest window, and so how long daily scores have to be kept
+const MaxTrendingWindow = 30 * 24 * time.Hour
+
+func (w TrendingWindow) IsValid() bool {
+	switch w {
+	case TrendingWindowDay, TrendingWindowWeek, TrendingWindowMonth:
+		return true
+	default:
+		return false
+	}
+}
+
+// Duration is the length of the window
+func (w TrendingWindow) Duration() time.Duration {
+	switch w {
+	case TrendingWindowWeek:
+		return 7 * 24 * time.Hour
+	case TrendingWindowMonth:
+		return MaxTrendingWindow
+	default:
+		return 24 * time.Hour
+	}
+}
+
+// HalfLife is the age at which an event counts half as much as one that just happened
+func (w TrendingWindow) HalfLife() time.Duration {
+	switch w {
+	case TrendingWindowWeek:
+		return 2 * 24 * time.Hour
+	case TrendingWindowMonth:
+		return 7 * 24 * time.Hour
+	default:
+		return 12 * time.Hour
+	}
+}
+
+// TrendingScore is the engagement score of an ebook on one day (UTC)
+type TrendingScore struct {
+	Day        time.Time `db:"day"`
+	EbookID    string    `db:"ebook_id"`
+	CategoryID string    `db:"category_id"`
+	Score      float64   `db:"score"`
+}
@@ -0,0 +1,29 @@
+package entity
+
+import "time"
+
+// Upload is a resumable upload (tus 1.0) in progress. Its data is kept on the local disk
+// until the last chunk arrives, then it is stored as media.
+type Upload struct {
+	ID         string    `db:"id" json:"id"`
+	Kind       MediaKind `db:"kind" json:"kind"`
+	Filename   string    `db:"filename" json:"filename"`
+	Length     int64     `db:"upload_length" json:"length"` // Total size declared when the upload was created
+	Offset     int64     `db:"upload_offset" json:"offset"` // Bytes received so far
+	Checksum   *string   `db:"checksum" json:"checksum"`    // Hex SHA-256 of the whole file, verified when complete
+	UploadedBy string    `db:"uploaded_by" json:"uploaded_by"`
+	MediaID    *string   `db:"media_id" json:"media_id"` // Set once the upload is complete
+	ExpiresAt  time.Time `db:"expires_at" json:"expires_at"`
+	CreatedAt  time.Time `db:"created_at" json:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at" json:"updated_at"`
+}
+
+// IsComplete reports whether every byte has arrived and the upload was stored as media
+func (u *Upload) IsComplete() bool {
+	return u.MediaID != nil
+}
+
+// IsExpired reports whether the upload was abandoned; completed uploads are kept until then so clients can look them up
+func (u *Upload) IsExpired(now time.Time) bool {
+	return !now.Before(u.ExpiresAt)
+}
@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+// WishlistItem is an ebook a user saved for later, with the catalog fields shown in the list
+type WishlistItem struct {
+	UserID        string    `db:"user_id"`
+	EbookID       string    `db:"ebook_id"`
+	CreatedAt     time.Time `db:"created_at"`
+	Title         string    `db:"title"`
+	Slug          string    `db:"slug"`
+	CoverImage    string    `db:"cover_image"`
+	Price         int       `db:"price"`
+	DiscountPrice *int      `db:"discount_price"`
+	CoverMediaKey *string   `db:"cover_media_key"`
+}
+
+func (i *WishlistItem) ResolveMediaURLs(url func(key string) string) {
+	i.CoverImage = ResolveMediaURL(i.CoverMediaKey, i.CoverImage, url)
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+	"time"
+)
+
+// AnalyticsRepository stores usage events in a table partitioned by month and aggregates them
+// Clean Architecture: Domain layer, no infrastructure dependencies
+type AnalyticsRepository interface {
+	// InsertEvents writes the events; events already stored under the same ID are skipped
+	InsertEvents(ctx context.Context, events []*entity.AnalyticsEvent) error
+	// EnsurePartitions creates the missing monthly partitions up to and including the month of through
+	EnsurePartitions(ctx context.Context, through time.Time) (int, error)
+	// DropPartitionsBefore drops the monthly partitions of months before the month of before,
+	// and empties the partition of older rows once it has expired as a whole
+	DropPartitionsBefore(ctx context.Context, before time.Time) (int, error)
+	// SummarizeEvents counts the events per type
+	SummarizeEvents(ctx context.Context, filter *entity.AnalyticsFilter) ([]*entity.AnalyticsTotal, error)
+	// CountDaily counts the events per UTC day and type
+	CountDaily(ctx context.Context, filter *entity.AnalyticsFilter) ([]*entity.AnalyticsDailyCount, error)
+	// ListTop returns the items of the dimension with the most events, most first
+	ListTop(ctx context.Context, filter *entity.AnalyticsFilter, dimension entity.AnalyticsDimension, limit int) ([]*entity.AnalyticsTopItem, error)
+}
+
+// AnalyticsRedisRepository buffers events in Redis until they are written to MySQL
+type AnalyticsRedisRepository interface {
+	// PushEvents appends events to the buffer, or returns ErrBufferFull and appends none
+	// when they would take it past its capacity
+	PushEvents(ctx context.Context, events []*entity.AnalyticsEvent) error
+	// RequeueEvents puts events taken off the buffer back at its front, regardless of its capacity
+	RequeueEvents(ctx context.Context, events []*entity.AnalyticsEvent) error
+	// PopEvents removes and returns up to count of the oldest buffered events
+	PopEvents(ctx context.Context, count int64) ([]*entity.AnalyticsEvent, error)
+}
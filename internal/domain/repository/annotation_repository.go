@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// AnnotationRepository defines the interface for annotation data operations
+// Clean Architecture: Domain layer, no infrastructure dependencies
+// Every method is scoped to the owning user
+type AnnotationRepository interface {
+	Create(ctx context.Context, annotation *entity.Annotation) error
+	GetByID(ctx context.Context, userID, id string) (*entity.Annotation, error)
+	Update(ctx context.Context, annotation *entity.Annotation) error
+	Delete(ctx context.Context, userID, id string) error
+	List(ctx context.Context, filter *entity.AnnotationFilter, limit, offset int) ([]*entity.Annotation, error)
+	Count(ctx context.Context, filter *entity.AnnotationFilter) (int64, error)
+	// ListByEbook returns the live annotations of an ebook in reading order
+	ListByEbook(ctx context.Context, userID, ebookID string) ([]*entity.Annotation, error)
+}
@@ -17,6 +17,7 @@ type ArticleRepository interface {
 	ListByAuthor(ctx context.Context, authorID string, limit, offset int) ([]*entity.Article, error)
 	ListByCategory(ctx context.Context, categoryID string, limit, offset int) ([]*entity.Article, error)
 	ListPublished(ctx context.Context, limit, offset int) ([]*entity.Article, error)
+	ListFeedItems(ctx context.Context, filter *entity.FeedFilter, limit int) ([]*entity.FeedItem, error)
 	Count(ctx context.Context) (int64, error)
 	CountByAuthor(ctx context.Context, authorID string) (int64, error)
 	CountByCategory(ctx context.Context, categoryID string) (int64, error)
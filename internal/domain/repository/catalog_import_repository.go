@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// CatalogImportRepository defines the interface for bulk catalog import data operations
+// Clean Architecture: Domain layer, no infrastructure dependencies
+type CatalogImportRepository interface {
+	// FindEbookIDsBySlug returns the ids of the ebooks with the given slugs, by slug
+	FindEbookIDsBySlug(ctx context.Context, slugs []string) (map[string]string, error)
+	// FindAuthorIDsByName returns the ids of the authors with the given names, by lowercased name
+	FindAuthorIDsByName(ctx context.Context, names []string) (map[string]string, error)
+	// FindCategoryIDs returns the ids of the categories whose name or slug is one of keys, by lowercased key
+	FindCategoryIDs(ctx context.Context, keys []string) (map[string]string, error)
+	// ContentStatusIDs returns the content status ids by name
+	ContentStatusIDs(ctx context.Context) (map[string]string, error)
+	// ImportBatch creates the batch's authors and categories and creates or updates its ebooks in one transaction
+	ImportBatch(ctx context.Context, batch *entity.CatalogImportBatch) error
+}
@@ -15,6 +15,7 @@ type CategoryRepository interface {
 	Delete(ctx context.Context, id string) error
 	List(ctx context.Context, limit, offset int) ([]*entity.Category, error)
 	ListActive(ctx context.Context, limit, offset int) ([]*entity.Category, error)
+	ListActiveByCursor(ctx context.Context, cursor *entity.PageCursor, limit int) ([]*entity.Category, error)
 	ListByParent(ctx context.Context, parentID string, limit, offset int) ([]*entity.Category, error)
 	Count(ctx context.Context) (int64, error)
 	CountActive(ctx context.Context) (int64, error)
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+	"time"
+)
+
+// ContentScheduleRepository defines the interface for scheduled publishing data operations
+// Clean Architecture: Domain layer, no infrastructure dependencies
+type ContentScheduleRepository interface {
+	Create(ctx context.Context, schedule *entity.ContentSchedule) error
+	GetByID(ctx context.Context, id string) (*entity.ContentSchedule, error)
+	// ListByContent returns the schedules of a piece of content, pending first and then by run time
+	ListByContent(ctx context.Context, contentType entity.SchedulableContent, contentID string) ([]*entity.ContentSchedule, error)
+	// ListDue returns pending schedules whose run time is not after now, earliest first
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*entity.ContentSchedule, error)
+	Delete(ctx context.Context, id string) error
+	// ContentExists reports whether the content a schedule refers to exists
+	ContentExists(ctx context.Context, contentType entity.SchedulableContent, contentID string) (bool, error)
+	// Apply sets the content status for the schedule's action and marks the schedule applied in one transaction.
+	// Publishing also sets published_at to the run time unless the content was published earlier.
+	// It returns false if the schedule was already applied or deleted.
+	Apply(ctx context.Context, id string, appliedAt time.Time) (bool, error)
+}
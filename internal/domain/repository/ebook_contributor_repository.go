@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// EbookContributorRepository defines the interface for the contributors of ebooks
+// Clean Architecture: Domain layer, no infrastructure dependencies
+type EbookContributorRepository interface {
+	// ListByEbook returns the ebook's contributors in display order, with their names
+	ListByEbook(ctx context.Context, ebookID string) ([]*entity.EbookContributor, error)
+	// ReplaceByEbook atomically swaps the ebook's contributors and makes the first author its primary author
+	ReplaceByEbook(ctx context.Context, ebookID string, contributors []*entity.EbookContributor) error
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"buku-pintar/internal/domain/entity"
 	"context"
+	"time"
 )
 
 // EbookDiscountRepository defines the interface for ebook discount data operations
@@ -19,4 +20,7 @@ type EbookDiscountRepository interface {
 	Count(ctx context.Context) (int64, error)
 	CountByEbookID(ctx context.Context, ebookID string) (int64, error)
 	CountActiveDiscounts(ctx context.Context) (int64, error)
+	// ListStartedUnnotified returns started discounts whose price drop has not been announced yet
+	ListStartedUnnotified(ctx context.Context, now time.Time, limit int) ([]*entity.EbookDiscount, error)
+	MarkNotified(ctx context.Context, id string, notifiedAt time.Time) error
 }
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// EbookDownloadRepository defines the interface for ebook download log operations
+// Clean Architecture: Domain layer, no infrastructure dependencies
+type EbookDownloadRepository interface {
+	Create(ctx context.Context, download *entity.EbookDownload) error
+	ListByUserID(ctx context.Context, userID string, limit, offset int) ([]*entity.EbookDownload, error)
+	CountByUserID(ctx context.Context, userID string) (int64, error)
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// EbookPageRepository defines the interface for ebook reader page operations
+// Clean Architecture: Domain layer, no infrastructure dependencies
+type EbookPageRepository interface {
+	Create(ctx context.Context, page *entity.EbookPage) error
+	// ReplaceByEbookID swaps all pages of the ebook for the given ones in a single transaction
+	ReplaceByEbookID(ctx context.Context, ebookID string, pages []*entity.EbookPage) error
+	GetByNumber(ctx context.Context, ebookID string, pageNumber int) (*entity.EbookPage, error)
+	CountByEbookID(ctx context.Context, ebookID string) (int64, error)
+}
@@ -13,20 +13,25 @@ type EbookRepository interface {
 	GetBySlug(ctx context.Context, slug string) (*entity.EbookDetail, error)
 	Update(ctx context.Context, ebook *entity.Ebook) error
 	Delete(ctx context.Context, id string) error
-	List(ctx context.Context, limit, offset int) ([]*entity.EbookList, error)
+	List(ctx context.Context, filter *entity.EbookFilter, limit, offset int) ([]*entity.EbookList, error)
+	ListByCursor(ctx context.Context, filter *entity.EbookFilter, cursor *entity.PageCursor, limit int) ([]*entity.EbookList, error)
+	ListFeedEntries(ctx context.Context, filter *entity.EbookFilter, limit, offset int) ([]*entity.EbookFeedEntry, error)
 	ListByCategory(ctx context.Context, categoryID string, limit, offset int) ([]*entity.Ebook, error)
 	ListByAuthor(ctx context.Context, authorID string, limit, offset int) ([]*entity.Ebook, error)
-	Count(ctx context.Context) (int64, error)
+	Count(ctx context.Context, filter *entity.EbookFilter) (int64, error)
+	Facets(ctx context.Context, filter *entity.EbookFilter) (*entity.EbookFacets, error)
 	CountByCategory(ctx context.Context, categoryID string) (int64, error)
 	CountByAuthor(ctx context.Context, authorID string) (int64, error)
 }
 
 // EbookRedisRepository defines the interface for ebook Redis operations
 type EbookRedisRepository interface {
-	GetEbookList(ctx context.Context, limit, offset int) ([]*entity.EbookList, error)
-	SetEbookList(ctx context.Context, ebooks []*entity.EbookList, limit, offset int) error
-	GetEbookTotal(ctx context.Context) (int64, error)
-	SetEbookTotal(ctx context.Context, count int64) error
+	GetEbookList(ctx context.Context, filter *entity.EbookFilter, limit, offset int) ([]*entity.EbookList, error)
+	SetEbookList(ctx context.Context, ebooks []*entity.EbookList, filter *entity.EbookFilter, limit, offset int) error
+	GetEbookTotal(ctx context.Context, filter *entity.EbookFilter) (int64, error)
+	SetEbookTotal(ctx context.Context, filter *entity.EbookFilter, count int64) error
+	GetEbookFacets(ctx context.Context, filter *entity.EbookFilter) (*entity.EbookFacets, error)
+	SetEbookFacets(ctx context.Context, filter *entity.EbookFilter, facets *entity.EbookFacets) error
 	GetEbookByID(ctx context.Context, id string) (*entity.Ebook, error)
 	SetEbookByID(ctx context.Context, ebook *entity.Ebook) error
 	GetEbookBySlug(ctx context.Context, slug string) (*entity.EbookDetail, error)
@@ -0,0 +1,12 @@
+package repository
+
+import "errors"
+
+// ErrDuplicateKey is returned when a write would break a unique key
+var ErrDuplicateKey = errors.New("duplicate key")
+
+// ErrBufferFull is returned when a bounded buffer cannot take more entries
+var ErrBufferFull = errors.New("buffer is full")
+
+// ErrInvalidCursorValue is returned when the sort value of a cursor cannot be bound to its column
+var ErrInvalidCursorValue = errors.New("invalid cursor value")
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// MediaRepository defines the interface for media data operations
+// Clean Architecture: Domain layer, no infrastructure dependencies
+type MediaRepository interface {
+	// Create records uploaded media and returns false if media of the same kind and checksum already exists
+	Create(ctx context.Context, media *entity.Media) (bool, error)
+	GetByID(ctx context.Context, id string) (*entity.Media, error)
+	GetByStorageKey(ctx context.Context, key string) (*entity.Media, error)
+	GetByChecksum(ctx context.Context, kind entity.MediaKind, checksum string) (*entity.Media, error)
+	// ListByKind pages through media of a kind in ID order, starting after afterID
+	ListByKind(ctx context.Context, kind entity.MediaKind, afterID string, limit int) ([]*entity.Media, error)
+
+	// SaveVariant records a variant, replacing the one of the same media, name and format
+	SaveVariant(ctx context.Context, variant *entity.MediaVariant) error
+	ListVariants(ctx context.Context, mediaID string) ([]*entity.MediaVariant, error)
+	GetVariantByStorageKey(ctx context.Context, key string) (*entity.MediaVariant, error)
+}
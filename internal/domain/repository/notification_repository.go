@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+	"encoding/json"
+)
+
+// NotificationRepository defines the interface for the notification event outbox
+// Clean Architecture: Domain layer, no infrastructure dependencies
+type NotificationRepository interface {
+	// CreateForWishlisters emits one event to every user who wishlisted the ebook and returns how many were emitted
+	CreateForWishlisters(ctx context.Context, ebookID string, eventType entity.NotificationType, payload json.RawMessage) (int64, error)
+	ListByUser(ctx context.Context, userID string, limit, offset int) ([]*entity.NotificationEvent, error)
+	CountByUser(ctx context.Context, userID string) (int64, error)
+}
@@ -12,5 +12,8 @@ type PaymentRepository interface {
 	GetByID(ctx context.Context, id string) (*entity.Payment, error)
 	GetByXenditReference(ctx context.Context, ref string) (*entity.Payment, error)
 	Update(ctx context.Context, payment *entity.Payment) error
-	ListByUserID(ctx context.Context, userID string) ([]*entity.Payment, error)
+	ListByUserID(ctx context.Context, userID string, limit, offset int) ([]*entity.Payment, error)
+	ListByUserIDCursor(ctx context.Context, userID string, cursor *entity.PageCursor, limit int) ([]*entity.Payment, error)
+	CountByUserID(ctx context.Context, userID string) (int64, error)
+	HasPaidForEbook(ctx context.Context, userID, ebookID string) (bool, error)
 }
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// ReadingProgressRepository defines the interface for durable reading progress storage
+// Clean Architecture: Domain layer, no infrastructure dependencies
+type ReadingProgressRepository interface {
+	// Upsert stores the progress unless the stored row has a later client timestamp
+	Upsert(ctx context.Context, progress *entity.ReadingProgress) error
+	Get(ctx context.Context, userID, ebookID string) (*entity.ReadingProgress, error)
+	ListInProgressByUserID(ctx context.Context, userID string, limit, offset int) ([]*entity.ReadingProgressItem, error)
+	CountInProgressByUserID(ctx context.Context, userID string) (int64, error)
+}
+
+// ReadingProgressRedisRepository defines the interface for hot reading progress kept in Redis
+// until it is flushed to MySQL
+type ReadingProgressRedisRepository interface {
+	GetProgress(ctx context.Context, userID, ebookID string) (*entity.ReadingProgress, error)
+	// SetProgress stores the progress unless a later one is already hot, and queues the user for flushing
+	SetProgress(ctx context.Context, progress *entity.ReadingProgress) (bool, error)
+	GetUserProgress(ctx context.Context, userID string) ([]*entity.ReadingProgress, error)
+	// RemoveFlushed drops hot entries that have not changed since they were read for flushing
+	RemoveFlushed(ctx context.Context, progress []*entity.ReadingProgress) error
+	PopDirtyUsers(ctx context.Context, count int64) ([]string, error)
+	MarkDirty(ctx context.Context, userID string) error
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+	"time"
+)
+
+// RecommendationRepository reads the signals the recommender scores on
+// Clean Architecture: Domain layer, no infrastructure dependencies
+type RecommendationRepository interface {
+	// ListCandidates returns every ebook that is currently published
+	ListCandidates(ctx context.Context) ([]*entity.RecommendationCandidate, error)
+	// ListCategoryParents maps each category ID to its parent ID, empty for root categories
+	ListCategoryParents(ctx context.Context) (map[string]string, error)
+	// ListCoPurchases counts the users with paid payments for both ebooks, once per unordered pair
+	ListCoPurchases(ctx context.Context) ([]*entity.EbookPairCount, error)
+	// ListCoReads counts the users with reading progress in both ebooks, once per unordered pair
+	ListCoReads(ctx context.Context) ([]*entity.EbookPairCount, error)
+	// ListActiveUserEbooks returns the bought and read ebooks of users who paid or read since the given time
+	ListActiveUserEbooks(ctx context.Context, since time.Time) ([]*entity.UserEbook, error)
+	// ListPublishedByIDs returns the published ebooks among ids, in the order of ids
+	ListPublishedByIDs(ctx context.Context, ids []string) ([]*entity.EbookList, error)
+}
+
+// RecommendationRedisRepository stores precomputed recommendations
+// A missing entry means nothing was computed yet and is returned as nil
+type RecommendationRedisRepository interface {
+	GetRelated(ctx context.Context, ebookID string) ([]*entity.ScoredEbook, error)
+	SetRelated(ctx context.Context, related map[string][]*entity.ScoredEbook) error
+	GetUserRecommendations(ctx context.Context, userID string) ([]*entity.ScoredEbook, error)
+	SetUserRecommendations(ctx context.Context, recommendations map[string][]*entity.ScoredEbook) error
+}
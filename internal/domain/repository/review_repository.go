@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// ReviewRepository defines the interface for review data operations
+// Clean Architecture: Domain layer, no infrastructure dependencies
+// Writes that change what counts towards an ebook's rating also update its aggregate in the same transaction
+type ReviewRepository interface {
+	Create(ctx context.Context, review *entity.Review) error
+	GetByID(ctx context.Context, id string) (*entity.Review, error)
+	GetByUserAndEbook(ctx context.Context, userID, ebookID string) (*entity.Review, error)
+	// Update changes the rating and body and applies the rating delta when the review is visible
+	Update(ctx context.Context, review *entity.Review) error
+	// SetStatus hides or shows a review and moves its rating out of or into the aggregate
+	SetStatus(ctx context.Context, id string, status entity.ReviewStatus, moderatorID string) error
+	// Report records a report once per user and returns whether it was new
+	Report(ctx context.Context, report *entity.ReviewReport) (bool, error)
+	ListVisibleByEbook(ctx context.Context, ebookID string, limit, offset int) ([]*entity.Review, error)
+	CountVisibleByEbook(ctx context.Context, ebookID string) (int64, error)
+	ListReported(ctx context.Context, limit, offset int) ([]*entity.Review, error)
+	CountReported(ctx context.Context) (int64, error)
+}
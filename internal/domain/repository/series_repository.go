@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// SeriesRepository defines the interface for series and their volumes
+// Clean Architecture: Domain layer, no infrastructure dependencies
+type SeriesRepository interface {
+	Create(ctx context.Context, series *entity.Series) error
+	GetByID(ctx context.Context, id string) (*entity.Series, error)
+	GetBySlug(ctx context.Context, slug string) (*entity.Series, error)
+	Update(ctx context.Context, series *entity.Series) error
+	Delete(ctx context.Context, id string) error
+	// ListPublished lists the series with at least one published volume, by name
+	ListPublished(ctx context.Context, limit, offset int) ([]*entity.Series, error)
+	CountPublished(ctx context.Context) (int64, error)
+	// ListVolumes lists the published volumes of a series in reading order
+	ListVolumes(ctx context.Context, seriesID string) ([]*entity.SeriesVolume, error)
+	// ReplaceVolumes replaces the whole membership of a series
+	ReplaceVolumes(ctx context.Context, seriesID string, volumes []*entity.SeriesVolume) error
+	// GetMembership returns the series an ebook belongs to, or nil if none
+	GetMembership(ctx context.Context, ebookID string) (*entity.SeriesMembership, error)
+	// GetNextVolume returns the first published volume after the ebook's, or nil if there is none
+	GetNextVolume(ctx context.Context, ebookID string) (*entity.SeriesVolume, error)
+}
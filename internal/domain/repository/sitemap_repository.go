@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+	"time"
+)
+
+// SitemapRepository reads the public pages listed in the sitemaps
+// Clean Architecture: Domain layer, no infrastructure dependencies
+type SitemapRepository interface {
+	// ListEntries returns the pages of a kind that are public at now, leaving out those marked noindex
+	ListEntries(ctx context.Context, content entity.SitemapContent, now time.Time) ([]*entity.SitemapEntry, error)
+	// Version summarises the public pages at now; it changes whenever a page is added, removed or updated
+	Version(ctx context.Context, now time.Time) (string, error)
+}
+
+// SitemapRedisRepository stores the generated sitemaps
+// A missing sitemap or version is returned as nil or ""
+type SitemapRedisRepository interface {
+	GetSitemap(ctx context.Context, name string) ([]byte, error)
+	GetSitemapVersion(ctx context.Context) (string, error)
+	// SetSitemaps replaces all stored sitemaps with the set
+	SetSitemaps(ctx context.Context, set *entity.SitemapSet) error
+}
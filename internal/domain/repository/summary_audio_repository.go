@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// SummaryAudioRepository defines the interface for summary audio and listening progress storage
+// Clean Architecture: Domain layer, no infrastructure dependencies
+type SummaryAudioRepository interface {
+	// GetAudio looks the ID up among regular and premium summaries
+	GetAudio(ctx context.Context, summaryID string) (*entity.SummaryAudio, error)
+	// UpsertProgress stores the progress unless the stored row has a later client timestamp
+	UpsertProgress(ctx context.Context, progress *entity.ListeningProgress) error
+	GetProgress(ctx context.Context, userID, summaryID string) (*entity.ListeningProgress, error)
+}
@@ -12,6 +12,7 @@ type SummaryRepository interface {
 	UpdateSummary(ctx context.Context, summary *entity.EbookSummary) error
 	DeleteSummary(ctx context.Context, id string) error
 	ListSummaries(ctx context.Context, limit, offset int) ([]*entity.EbookSummaryList, error)
+	ListSummariesByCursor(ctx context.Context, cursor *entity.PageCursor, limit int) ([]*entity.EbookSummaryList, error)
 	GetSummariesByEbookID(ctx context.Context, ebookID string, limit, offset int) ([]*entity.EbookSummary, error)
 	CountSummaries(ctx context.Context) (int64, error)
 	CountSummariesByEbookID(ctx context.Context, ebookID string) (int64, error)
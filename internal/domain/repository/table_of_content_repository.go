@@ -12,7 +12,10 @@ type TableOfContentRepository interface {
 	GetByID(ctx context.Context, id string) (*entity.TableOfContent, error)
 	Update(ctx context.Context, toc *entity.TableOfContent) error
 	Delete(ctx context.Context, id string) error
+	// ListByEbook returns the entries flat, ordered by depth and then by order among siblings
 	ListByEbook(ctx context.Context, ebookID string) ([]*entity.TableOfContent, error)
 	DeleteByEbook(ctx context.Context, ebookID string) error
+	// ReplaceByEbook atomically swaps the ebook's whole table of contents for entries
+	ReplaceByEbook(ctx context.Context, ebookID string, entries []*entity.TableOfContent) error
 	CountByEbook(ctx context.Context, ebookID string) (int64, error)
 }
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// TranslationRepository defines the interface for content translation data operations
+// Clean Architecture: Domain layer, no infrastructure dependencies
+type TranslationRepository interface {
+	// Upsert replaces the translation of the content in its locale; fields left out are cleared
+	Upsert(ctx context.Context, translation *entity.Translation) error
+	// ListByContent returns the translations of the content, ordered by locale
+	ListByContent(ctx context.Context, contentType entity.TranslatableContent, contentID string) ([]*entity.Translation, error)
+	// Delete removes a translation and reports whether it existed
+	Delete(ctx context.Context, contentType entity.TranslatableContent, contentID, locale string) (bool, error)
+	ContentExists(ctx context.Context, contentType entity.TranslatableContent, contentID string) (bool, error)
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+	"time"
+)
+
+// TrendingRepository persists daily trending scores
+// Clean Architecture: Domain layer, no infrastructure dependencies
+type TrendingRepository interface {
+	// SaveSnapshot replaces the stored scores of a day
+	SaveSnapshot(ctx context.Context, day time.Time, scores []*entity.TrendingScore) error
+	// ListSnapshot returns the stored scores of a day with the current category of each ebook
+	ListSnapshot(ctx context.Context, day time.Time) ([]*entity.TrendingScore, error)
+	// DeleteSnapshotsBefore removes the snapshots of days before the given one
+	DeleteSnapshotsBefore(ctx context.Context, day time.Time) error
+	// UpdatePopularityScores sets the popularity score of the given ebooks and resets every other ebook to 0
+	UpdatePopularityScores(ctx context.Context, scores map[string]int) error
+	// ListPublishedByIDs returns the published ebooks among ids, in the order of ids
+	ListPublishedByIDs(ctx context.Context, ids []string) ([]*entity.EbookList, error)
+}
+
+// TrendingRedisRepository keeps daily engagement scores in sorted sets, globally and per category
+// Days are UTC dates; a bucket expires once it is older than the longest trending window
+type TrendingRedisRepository interface {
+	// IncrementScore adds to the ebook's score of the day, and to its category's when categoryID is set
+	IncrementScore(ctx context.Context, day time.Time, ebookID, categoryID string, score float64) error
+	// MarkEngaged records the actor's event on the ebook for the day and reports false if it was already recorded
+	MarkEngaged(ctx context.Context, day time.Time, event entity.EngagementEvent, ebookID, actorID string) (bool, error)
+	// Rank combines the daily scores with the given weight per day, globally or within categoryID, and
+	// returns a page of the highest combined scores with the number of ranked ebooks.
+	// The combined ranking is cached briefly per window and category.
+	Rank(ctx context.Context, window entity.TrendingWindow, weights map[time.Time]float64, categoryID string, limit, offset int) ([]*entity.ScoredEbook, int64, error)
+	// GetDayScores returns the global scores of a day
+	GetDayScores(ctx context.Context, day time.Time) ([]*entity.TrendingScore, error)
+	// HasDay reports whether the global scores of a day are in Redis
+	HasDay(ctx context.Context, day time.Time) (bool, error)
+	// RestoreDay writes the scores of a day back into its global and category buckets
+	RestoreDay(ctx context.Context, day time.Time, scores []*entity.TrendingScore) error
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+	"time"
+)
+
+// UploadRepository defines the interface for resumable upload data operations
+// Clean Architecture: Domain layer, no infrastructure dependencies
+type UploadRepository interface {
+	Create(ctx context.Context, upload *entity.Upload) error
+	GetByID(ctx context.Context, id string) (*entity.Upload, error)
+	// UpdateOffset moves the offset from one value to another and extends the expiry.
+	// It returns false if the offset was no longer from, or the upload is already complete.
+	UpdateOffset(ctx context.Context, id string, from, to int64, expiresAt time.Time) (bool, error)
+	SetMedia(ctx context.Context, id, mediaID string) error
+	Delete(ctx context.Context, id string) error
+	// ListExpired returns uploads that expired before the given time, oldest first
+	ListExpired(ctx context.Context, before time.Time, limit int) ([]*entity.Upload, error)
+}
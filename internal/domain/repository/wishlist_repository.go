@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// WishlistRepository defines the interface for wishlist data operations
+// Clean Architecture: Domain layer, no infrastructure dependencies
+type WishlistRepository interface {
+	// Add saves the ebook to the user's wishlist and returns false if it was already there
+	Add(ctx context.Context, userID, ebookID string) (bool, error)
+	Remove(ctx context.Context, userID, ebookID string) error
+	ListByUser(ctx context.Context, userID string, limit, offset int) ([]*entity.WishlistItem, error)
+	CountByUser(ctx context.Context, userID string) (int64, error)
+	CountByEbook(ctx context.Context, ebookID string) (int64, error)
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// AnalyticsService defines the interface for buffering, storing and aggregating usage events
+type AnalyticsService interface {
+	// BufferEvents queues validated events for FlushEvents; they are written directly if the buffer is unavailable
+	BufferEvents(ctx context.Context, events []*entity.AnalyticsEvent) error
+	// FlushEvents writes the buffered events to MySQL, returning the number written
+	FlushEvents(ctx context.Context) (int, error)
+	// MaintainPartitions creates the partitions of the coming months and drops those past the retention
+	MaintainPartitions(ctx context.Context) error
+	GetTotals(ctx context.Context, filter *entity.AnalyticsFilter) ([]*entity.AnalyticsTotal, error)
+	GetDailyCounts(ctx context.Context, filter *entity.AnalyticsFilter) ([]*entity.AnalyticsDailyCount, error)
+	GetTopItems(ctx context.Context, filter *entity.AnalyticsFilter, dimension entity.AnalyticsDimension, limit int) ([]*entity.AnalyticsTopItem, error)
+}
@@ -0,0 +1,17 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// AnnotationService defines the interface for annotation business operations
+type AnnotationService interface {
+	CreateAnnotation(ctx context.Context, annotation *entity.Annotation) error
+	GetAnnotationByID(ctx context.Context, userID, id string) (*entity.Annotation, error)
+	UpdateAnnotation(ctx context.Context, annotation *entity.Annotation) error
+	DeleteAnnotation(ctx context.Context, userID, id string) error
+	GetAnnotationList(ctx context.Context, filter *entity.AnnotationFilter, limit, offset int) ([]*entity.Annotation, error)
+	GetAnnotationCount(ctx context.Context, filter *entity.AnnotationFilter) (int64, error)
+	GetAnnotationsByEbook(ctx context.Context, userID, ebookID string) ([]*entity.Annotation, error)
+}
@@ -0,0 +1,13 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// AuthorService defines the interface for author business operations
+type AuthorService interface {
+	CreateAuthor(ctx context.Context, author *entity.Author) error
+	GetAuthorByID(ctx context.Context, id string) (*entity.Author, error)
+	GetAuthorByName(ctx context.Context, name string) (*entity.Author, error)
+}
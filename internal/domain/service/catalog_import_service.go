@@ -0,0 +1,17 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// CatalogImportService defines the interface for bulk catalog import business operations
+type CatalogImportService interface {
+	FindEbookIDsBySlug(ctx context.Context, slugs []string) (map[string]string, error)
+	FindAuthorIDsByName(ctx context.Context, names []string) (map[string]string, error)
+	FindCategoryIDs(ctx context.Context, keys []string) (map[string]string, error)
+	ContentStatusIDs(ctx context.Context) (map[string]string, error)
+	ImportBatch(ctx context.Context, batch *entity.CatalogImportBatch) error
+	// InvalidateCatalogCache clears the cached ebooks and categories once an import has written something
+	InvalidateCatalogCache(ctx context.Context)
+}
@@ -9,6 +9,7 @@ import (
 type CategoryService interface {
 	GetCategoryList(ctx context.Context, limit, offset int) ([]*entity.Category, error)
 	GetActiveCategoryList(ctx context.Context, limit, offset int) ([]*entity.Category, error)
+	GetActiveCategoryListByCursor(ctx context.Context, cursor *entity.PageCursor, limit int) ([]*entity.Category, *entity.CursorPageInfo, error)
 	GetCategoryByID(ctx context.Context, id string) (*entity.Category, error)
 	GetCategoryByName(ctx context.Context, name string) (*entity.Category, error)
 	CreateCategory(ctx context.Context, category *entity.Category) error
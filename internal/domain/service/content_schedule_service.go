@@ -0,0 +1,19 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+	"time"
+)
+
+// ContentScheduleService defines the interface for scheduled publishing business operations
+type ContentScheduleService interface {
+	CreateSchedule(ctx context.Context, schedule *entity.ContentSchedule) error
+	GetSchedule(ctx context.Context, id string) (*entity.ContentSchedule, error)
+	ListSchedules(ctx context.Context, contentType entity.SchedulableContent, contentID string) ([]*entity.ContentSchedule, error)
+	DeleteSchedule(ctx context.Context, id string) error
+	ContentExists(ctx context.Context, contentType entity.SchedulableContent, contentID string) (bool, error)
+	// ApplyDueSchedules carries out the schedules due by now, clears the caches of the content
+	// that changed and returns how many were applied
+	ApplyDueSchedules(ctx context.Context, now time.Time) (int, error)
+}
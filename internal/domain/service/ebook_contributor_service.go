@@ -0,0 +1,13 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// EbookContributorService defines the interface for the contributors of ebooks
+type EbookContributorService interface {
+	// GetContributors returns the ebook's contributors in display order
+	GetContributors(ctx context.Context, ebookID string) ([]*entity.EbookContributor, error)
+	ReplaceContributors(ctx context.Context, ebookID string, contributors []*entity.EbookContributor) error
+}
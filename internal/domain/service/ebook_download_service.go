@@ -0,0 +1,13 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// EbookDownloadService defines the interface for the ebook download log
+type EbookDownloadService interface {
+	LogDownload(ctx context.Context, download *entity.EbookDownload) error
+	GetDownloadsByUserID(ctx context.Context, userID string, limit, offset int) ([]*entity.EbookDownload, error)
+	GetDownloadCountByUserID(ctx context.Context, userID string) (int64, error)
+}
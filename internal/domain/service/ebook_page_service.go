@@ -0,0 +1,15 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// EbookPageService defines the interface for ebook reader page business operations
+type EbookPageService interface {
+	CreatePage(ctx context.Context, page *entity.EbookPage) error
+	// ReplacePages swaps all pages of the ebook for the given ones
+	ReplacePages(ctx context.Context, ebookID string, pages []*entity.EbookPage) error
+	GetPage(ctx context.Context, ebookID string, pageNumber int) (*entity.EbookPage, error)
+	GetPageCount(ctx context.Context, ebookID string) (int64, error)
+}
@@ -12,10 +12,13 @@ type EbookService interface {
 	GetEbookBySlug(ctx context.Context, slug string) (*entity.EbookDetail, error)
 	UpdateEbook(ctx context.Context, ebook *entity.Ebook) error
 	DeleteEbook(ctx context.Context, id string) error
-	GetEbookList(ctx context.Context, limit, offset int) ([]*entity.EbookList, error)
+	GetEbookList(ctx context.Context, filter *entity.EbookFilter, limit, offset int) ([]*entity.EbookList, error)
+	GetEbookListByCursor(ctx context.Context, filter *entity.EbookFilter, cursor *entity.PageCursor, limit int) ([]*entity.EbookList, *entity.CursorPageInfo, error)
+	GetEbookFeedEntries(ctx context.Context, filter *entity.EbookFilter, limit, offset int) ([]*entity.EbookFeedEntry, error)
 	GetEbookListByCategory(ctx context.Context, categoryID string, limit, offset int) ([]*entity.Ebook, error)
 	GetEbookListByAuthor(ctx context.Context, authorID string, limit, offset int) ([]*entity.Ebook, error)
-	GetEbookCount(ctx context.Context) (int64, error)
+	GetEbookCount(ctx context.Context, filter *entity.EbookFilter) (int64, error)
+	GetEbookFacets(ctx context.Context, filter *entity.EbookFilter) (*entity.EbookFacets, error)
 	GetEbookCountByCategory(ctx context.Context, categoryID string) (int64, error)
 	GetEbookCountByAuthor(ctx context.Context, authorID string) (int64, error)
 }
@@ -0,0 +1,18 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// EntitlementService decides which users may access the full content of an ebook
+type EntitlementService interface {
+	// OwnsEbook reports whether the user has a paid payment for the ebook
+	OwnsEbook(ctx context.Context, userID, ebookID string) (bool, error)
+	// HasPremiumAccess reports whether the user's role grants access to all premium content
+	HasPremiumAccess(ctx context.Context, user *entity.User) (bool, error)
+	// CanAccessEbook combines free pricing, ownership and premium access
+	CanAccessEbook(ctx context.Context, user *entity.User, ebook *entity.Ebook) (bool, error)
+	// CanEditEbooks reports whether the user may update ebooks, and so see them before they are published
+	CanEditEbooks(ctx context.Context, user *entity.User) (bool, error)
+}
@@ -0,0 +1,12 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// FeedService defines the interface for reading the articles and inspirations syndicated in feeds
+type FeedService interface {
+	// GetFeedItems lists the newest published articles or inspirations, optionally of a single category or author
+	GetFeedItems(ctx context.Context, content entity.FeedContent, filter *entity.FeedFilter, limit int) ([]*entity.FeedItem, error)
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+	"io"
+)
+
+// MediaURLResolver turns the storage key of public media into the URL clients fetch it from
+type MediaURLResolver interface {
+	URL(key string) string
+}
+
+// MediaService defines the interface for media business operations
+type MediaService interface {
+	MediaURLResolver
+	// StoreMedia writes the content under media.StorageKey and records it.
+	// If the same content was recorded concurrently, the existing media is returned instead.
+	StoreMedia(ctx context.Context, media *entity.Media, body io.Reader) (*entity.Media, error)
+	GetMediaByID(ctx context.Context, id string) (*entity.Media, error)
+	GetMediaByStorageKey(ctx context.Context, key string) (*entity.Media, error)
+	GetMediaByChecksum(ctx context.Context, kind entity.MediaKind, checksum string) (*entity.Media, error)
+	OpenMedia(ctx context.Context, key string) (io.ReadCloser, error)
+	// FileLocation is where private media is streamed from: a path inside the storage directory or a signed URL
+	FileLocation(key string) (string, error)
+	ListMediaByKind(ctx context.Context, kind entity.MediaKind, afterID string, limit int) ([]*entity.Media, error)
+
+	// StoreMediaVariant writes the encoded variant under variant.StorageKey and records it
+	StoreMediaVariant(ctx context.Context, variant *entity.MediaVariant, body io.Reader) error
+	ListMediaVariants(ctx context.Context, mediaID string) ([]*entity.MediaVariant, error)
+	GetMediaVariantByStorageKey(ctx context.Context, key string) (*entity.MediaVariant, error)
+}
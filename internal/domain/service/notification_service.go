@@ -0,0 +1,17 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// NotificationService defines the interface for emitting and reading notification events
+type NotificationService interface {
+	// NotifyPriceDrop emits a price_drop event to everyone who wishlisted the ebook
+	NotifyPriceDrop(ctx context.Context, drop *entity.PriceDrop) (int64, error)
+	// NotifyStartedDiscounts announces discounts that have become active since the last run
+	// and returns the number of events emitted
+	NotifyStartedDiscounts(ctx context.Context) (int64, error)
+	GetNotifications(ctx context.Context, userID string, limit, offset int) ([]*entity.NotificationEvent, error)
+	GetNotificationCount(ctx context.Context, userID string) (int64, error)
+}
@@ -11,5 +11,7 @@ type PaymentService interface {
 	GetPaymentByID(ctx context.Context, id string) (*entity.Payment, error)
 	GetPaymentByXenditReference(ctx context.Context, ref string) (*entity.Payment, error)
 	UpdatePaymentStatus(ctx context.Context, id string, status entity.PaymentStatus) error
-	ListPaymentsByUserID(ctx context.Context, userID string) ([]*entity.Payment, error)
+	ListPaymentsByUserID(ctx context.Context, userID string, limit, offset int) ([]*entity.Payment, error)
+	ListPaymentsByUserIDCursor(ctx context.Context, userID string, cursor *entity.PageCursor, limit int) ([]*entity.Payment, *entity.CursorPageInfo, error)
+	CountPaymentsByUserID(ctx context.Context, userID string) (int64, error)
 }
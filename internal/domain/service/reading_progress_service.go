@@ -0,0 +1,18 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// ReadingProgressService defines the interface for cross-device reading progress
+type ReadingProgressService interface {
+	// SaveProgress applies the progress under last-writer-wins and returns the stored progress
+	// with whether the given one was applied
+	SaveProgress(ctx context.Context, progress *entity.ReadingProgress) (*entity.ReadingProgress, bool, error)
+	GetProgress(ctx context.Context, userID, ebookID string) (*entity.ReadingProgress, error)
+	GetContinueReadingList(ctx context.Context, userID string, limit, offset int) ([]*entity.ReadingProgressItem, error)
+	GetContinueReadingCount(ctx context.Context, userID string) (int64, error)
+	// FlushProgress writes hot progress from Redis to MySQL and returns the number of users flushed
+	FlushProgress(ctx context.Context) (int, error)
+}
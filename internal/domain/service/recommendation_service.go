@@ -0,0 +1,17 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// RecommendationService defines the interface for precomputing and serving recommendations
+type RecommendationService interface {
+	// RefreshRecommendations rescores related ebooks and personal recommendations and stores them,
+	// returning the number of lists written
+	RefreshRecommendations(ctx context.Context) (int, error)
+	// GetRelatedEbooks returns the precomputed related ebooks, or nil if none were computed
+	GetRelatedEbooks(ctx context.Context, ebookID string, limit int) ([]*entity.EbookList, error)
+	// GetUserRecommendations returns the precomputed recommendations, or nil if none were computed
+	GetUserRecommendations(ctx context.Context, userID string, limit int) ([]*entity.EbookList, error)
+}
@@ -0,0 +1,20 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// ReviewService defines the interface for review business operations
+type ReviewService interface {
+	CreateReview(ctx context.Context, review *entity.Review) error
+	GetReviewByID(ctx context.Context, id string) (*entity.Review, error)
+	GetUserReview(ctx context.Context, userID, ebookID string) (*entity.Review, error)
+	UpdateReview(ctx context.Context, review *entity.Review) error
+	SetReviewStatus(ctx context.Context, id string, status entity.ReviewStatus, moderatorID string) error
+	ReportReview(ctx context.Context, report *entity.ReviewReport) (bool, error)
+	GetEbookReviews(ctx context.Context, ebookID string, limit, offset int) ([]*entity.Review, error)
+	GetEbookReviewCount(ctx context.Context, ebookID string) (int64, error)
+	GetReportedReviews(ctx context.Context, limit, offset int) ([]*entity.Review, error)
+	GetReportedReviewCount(ctx context.Context) (int64, error)
+}
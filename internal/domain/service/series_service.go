@@ -0,0 +1,25 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// SeriesService defines the interface for series business operations
+type SeriesService interface {
+	CreateSeries(ctx context.Context, series *entity.Series) error
+	GetSeriesByID(ctx context.Context, id string) (*entity.Series, error)
+	GetSeriesBySlug(ctx context.Context, slug string) (*entity.Series, error)
+	UpdateSeries(ctx context.Context, series *entity.Series) error
+	DeleteSeries(ctx context.Context, id string) error
+	// GetSeriesList lists the series with at least one published volume
+	GetSeriesList(ctx context.Context, limit, offset int) ([]*entity.Series, error)
+	GetSeriesCount(ctx context.Context) (int64, error)
+	// GetVolumes lists the published volumes of a series in reading order
+	GetVolumes(ctx context.Context, seriesID string) ([]*entity.SeriesVolume, error)
+	ReplaceVolumes(ctx context.Context, seriesID string, volumes []*entity.SeriesVolume) error
+	// GetMembership returns the series an ebook belongs to, or nil if none
+	GetMembership(ctx context.Context, ebookID string) (*entity.SeriesMembership, error)
+	// GetNextVolume returns the first published volume after the ebook's, or nil if there is none
+	GetNextVolume(ctx context.Context, ebookID string) (*entity.SeriesVolume, error)
+}
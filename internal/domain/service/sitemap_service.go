@@ -0,0 +1,12 @@
+package service
+
+import "context"
+
+// SitemapService defines the interface for generating and serving the sitemaps
+type SitemapService interface {
+	// RefreshSitemaps regenerates and stores the sitemaps if the public content changed since they were
+	// generated, reporting whether they were regenerated
+	RefreshSitemaps(ctx context.Context) (bool, error)
+	// GetSitemap returns a stored sitemap by file name, or nil if there is none
+	GetSitemap(ctx context.Context, name string) ([]byte, error)
+}
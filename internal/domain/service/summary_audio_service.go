@@ -0,0 +1,15 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// SummaryAudioService defines the interface for summary audio and cross-device listening progress
+type SummaryAudioService interface {
+	GetAudio(ctx context.Context, summaryID string) (*entity.SummaryAudio, error)
+	// SaveProgress applies the progress under last-writer-wins and returns the stored progress
+	// with whether the given one was applied
+	SaveProgress(ctx context.Context, progress *entity.ListeningProgress) (*entity.ListeningProgress, bool, error)
+	GetProgress(ctx context.Context, userID, summaryID string) (*entity.ListeningProgress, error)
+}
@@ -0,0 +1,13 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// TableOfContentService defines the interface for table of content business operations
+type TableOfContentService interface {
+	// GetTableOfContents returns the ebook's entries flat, ordered by depth and then by order among siblings
+	GetTableOfContents(ctx context.Context, ebookID string) ([]*entity.TableOfContent, error)
+	ReplaceTableOfContents(ctx context.Context, ebookID string, entries []*entity.TableOfContent) error
+}
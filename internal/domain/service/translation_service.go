@@ -0,0 +1,16 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// TranslationService defines the interface for content translation business operations
+type TranslationService interface {
+	// SaveTranslation creates or replaces a translation and clears the caches showing it
+	SaveTranslation(ctx context.Context, translation *entity.Translation) error
+	ListTranslations(ctx context.Context, contentType entity.TranslatableContent, contentID string) ([]*entity.Translation, error)
+	// DeleteTranslation removes a translation, clears the caches showing it and reports whether it existed
+	DeleteTranslation(ctx context.Context, contentType entity.TranslatableContent, contentID, locale string) (bool, error)
+	ContentExists(ctx context.Context, contentType entity.TranslatableContent, contentID string) (bool, error)
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// TrendingService defines the interface for scoring engagement and ranking trending ebooks
+type TrendingService interface {
+	// RecordEngagement adds the event to the ebook's score of today, globally and in its category.
+	// With an actorID the same actor's event on the same ebook counts once a day.
+	RecordEngagement(ctx context.Context, ebookID, categoryID string, event entity.EngagementEvent, actorID string) error
+	// GetTrendingEbooks returns a page of the published ebooks with the highest decayed score in the window,
+	// within categoryID if set, and the number of ranked ebooks
+	GetTrendingEbooks(ctx context.Context, window entity.TrendingWindow, categoryID string, limit, offset int) ([]*entity.EbookList, int64, error)
+	// SnapshotScores restores daily scores missing from Redis, saves the current ones to MySQL and
+	// refreshes the popularity score of ebooks, returning the number of ebooks scored
+	SnapshotScores(ctx context.Context) (int, error)
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+	"io"
+	"time"
+)
+
+// UploadData is the partial data of a resumable upload, kept on the local disk
+type UploadData interface {
+	io.ReadWriteSeeker
+	io.Closer
+	Truncate(size int64) error
+}
+
+// UploadService defines the interface for resumable upload business operations
+type UploadService interface {
+	// CreateUpload records the upload and creates its empty data file
+	CreateUpload(ctx context.Context, upload *entity.Upload) error
+	GetUpload(ctx context.Context, id string) (*entity.Upload, error)
+	OpenUploadData(id string) (UploadData, error)
+	// RecordUploadOffset moves the offset after data was written; false means another request moved it first
+	RecordUploadOffset(ctx context.Context, id string, from, to int64, expiresAt time.Time) (bool, error)
+	// CompleteUpload links the stored media and removes the partial data
+	CompleteUpload(ctx context.Context, id, mediaID string) error
+	// DeleteUpload removes the record and the partial data
+	DeleteUpload(ctx context.Context, id string) error
+	// DeleteExpiredUploads removes uploads that expired before now and returns how many were removed
+	DeleteExpiredUploads(ctx context.Context, now time.Time) (int, error)
+}
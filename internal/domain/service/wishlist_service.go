@@ -0,0 +1,15 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// WishlistService defines the interface for wishlist business operations
+type WishlistService interface {
+	AddToWishlist(ctx context.Context, userID, ebookID string) (bool, error)
+	RemoveFromWishlist(ctx context.Context, userID, ebookID string) error
+	GetWishlist(ctx context.Context, userID string, limit, offset int) ([]*entity.WishlistItem, error)
+	GetWishlistCount(ctx context.Context, userID string) (int64, error)
+	GetEbookWishlistCount(ctx context.Context, ebookID string) (int64, error)
+}
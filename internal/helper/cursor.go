@@ -0,0 +1,82 @@
+package helper
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// ErrInvalidCursor is returned when a cursor token is malformed or its signature does not match
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// CursorCodec encodes page cursors into opaque tokens signed with HMAC-SHA256
+// so clients cannot forge positions or tamper with the sort values
+type CursorCodec struct {
+	secret []byte
+}
+
+func NewCursorCodec(secret string) *CursorCodec {
+	return &CursorCodec{secret: []byte(secret)}
+}
+
+// Encode returns the token for a cursor, or an empty string for a nil cursor
+func (c *CursorCodec) Encode(cursor *entity.PageCursor) string {
+	if cursor == nil {
+		return ""
+	}
+
+	payload, err := json.Marshal(cursor)
+	if err != nil {
+		return ""
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(c.sign(encoded))
+}
+
+// Decode verifies and parses a token; an empty token yields a nil cursor (first page)
+func (c *CursorCodec) Decode(token string) (*entity.PageCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+
+	expected, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(expected, c.sign(encoded)) {
+		return nil, ErrInvalidCursor
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	cursor := &entity.PageCursor{}
+	if err := json.Unmarshal(payload, cursor); err != nil || cursor.Sort == "" || cursor.ID == "" {
+		return nil, ErrInvalidCursor
+	}
+
+	return cursor, nil
+}
+
+func (c *CursorCodec) sign(encoded string) []byte {
+	mac := hmac.New(sha256.New, c.secret)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
+
+// IsCursorPagination reports whether the request asks for cursor pagination,
+// either with a cursor parameter (empty for the first page) or pagination=cursor
+func IsCursorPagination(r *http.Request) bool {
+	query := r.URL.Query()
+	return query.Has("cursor") || query.Get("pagination") == "cursor"
+}
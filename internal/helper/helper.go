@@ -1,8 +1,12 @@
 package helper
 
 import (
+	"buku-pintar/internal/domain/entity"
+	"fmt"
+	"net"
 	"net/http"
 	"strconv"
+	"time"
 )
 
 func HandlePagination(r *http.Request) (int, int) {
@@ -31,3 +35,127 @@ func HandlePagination(r *http.Request) (int, int) {
 
 	return limit, offset
 }
+
+// HandleEbookFilter parses the catalog filter and sort query parameters
+// Dates accept either YYYY-MM-DD or RFC3339; booleans accept strconv.ParseBool values
+func HandleEbookFilter(r *http.Request) (*entity.EbookFilter, error) {
+	query := r.URL.Query()
+	filter := &entity.EbookFilter{
+		Query:      query.Get("q"),
+		CategoryID: query.Get("category_id"),
+		AuthorID:   query.Get("author_id"),
+		Language:   query.Get("language"),
+		Format:     entity.EbookFormat(query.Get("format")),
+		Sort:       entity.EbookSort(query.Get("sort")),
+	}
+
+	var err error
+	if filter.MinPrice, err = parseOptionalInt(query.Get("min_price"), "min_price"); err != nil {
+		return nil, err
+	}
+	if filter.MaxPrice, err = parseOptionalInt(query.Get("max_price"), "max_price"); err != nil {
+		return nil, err
+	}
+	if filter.FreeOnly, err = parseOptionalBool(query.Get("free"), "free"); err != nil {
+		return nil, err
+	}
+	if filter.Discounted, err = parseOptionalBool(query.Get("discounted"), "discounted"); err != nil {
+		return nil, err
+	}
+	if filter.PublishedFrom, err = parseOptionalDate(query.Get("published_from"), "published_from"); err != nil {
+		return nil, err
+	}
+	if filter.PublishedTo, err = parseOptionalEndDate(query.Get("published_to"), "published_to"); err != nil {
+		return nil, err
+	}
+
+	return filter, nil
+}
+
+// HandleAnalyticsFilter parses the range and filters of analytics queries. from and to accept
+// either YYYY-MM-DD or RFC3339; a to date includes that whole day.
+func HandleAnalyticsFilter(r *http.Request) (*entity.AnalyticsFilter, error) {
+	query := r.URL.Query()
+	filter := &entity.AnalyticsFilter{
+		Type:    entity.AnalyticsEventType(query.Get("type")),
+		EbookID: query.Get("ebook_id"),
+	}
+
+	from, err := parseOptionalDate(query.Get("from"), "from")
+	if err != nil {
+		return nil, err
+	}
+	if from != nil {
+		filter.From = *from
+	}
+
+	to, err := parseOptionalEndDate(query.Get("to"), "to")
+	if err != nil {
+		return nil, err
+	}
+	if to != nil {
+		filter.To = *to
+	}
+
+	return filter, nil
+}
+
+// ClientIP returns the address of the client. Forwarding headers are not read here, since any client
+// can send them; ClientIPMiddleware rewrites RemoteAddr for requests coming through a trusted proxy.
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func parseOptionalInt(value, name string) (*int, error) {
+	if value == "" {
+		return nil, nil
+	}
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be an integer", name)
+	}
+	return &i, nil
+}
+
+func parseOptionalBool(value, name string) (bool, error) {
+	if value == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%s must be a boolean", name)
+	}
+	return b, nil
+}
+
+func parseOptionalDate(value, name string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be a date (YYYY-MM-DD or RFC3339)", name)
+	}
+	return &t, nil
+}
+
+// parseOptionalEndDate parses the exclusive end of a date range; a YYYY-MM-DD value
+// includes that whole day, so it ends at midnight of the next day
+func parseOptionalEndDate(value, name string) (*time.Time, error) {
+	t, err := parseOptionalDate(value, name)
+	if err != nil || t == nil {
+		return t, err
+	}
+	if len(value) == len("2006-01-02") {
+		end := t.AddDate(0, 0, 1)
+		return &end, nil
+	}
+	return t, nil
+}
@@ -0,0 +1,356 @@
+package mysql
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// analyticsInsertBatch is how many events are written per INSERT
+	analyticsInsertBatch = 500
+
+	// Monthly partitions are named after their month, e.g. p202610 holds rows before 2026-11-01
+	analyticsPartitionPrefix = "p"
+	analyticsPartitionLayout = "200601"
+
+	// analyticsPastPartition holds the rows before the first monthly partition
+	analyticsPastPartition = "p_past"
+)
+
+type analyticsRepository struct {
+	db *sql.DB
+}
+
+func NewAnalyticsRepository(db *sql.DB) repository.AnalyticsRepository {
+	return &analyticsRepository{db: db}
+}
+
+func (r *analyticsRepository) InsertEvents(ctx context.Context, events []*entity.AnalyticsEvent) error {
+	for start := 0; start < len(events); start += analyticsInsertBatch {
+		batch := events[start:min(start+analyticsInsertBatch, len(events))]
+
+		query := `INSERT IGNORE INTO analytics_events (id, event_type, occurred_at, received_at, user_id, session_id,
+				ebook_id, banner_id, page_number, position_seconds, query, properties, locale, ip_address, user_agent)
+			VALUES ` + strings.TrimSuffix(strings.Repeat(`(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?), `, len(batch)), `, `)
+
+		args := make([]any, 0, 15*len(batch))
+		for _, event := range batch {
+			var properties any
+			if len(event.Properties) > 0 {
+				properties = string(event.Properties)
+			}
+			args = append(args,
+				event.ID,
+				event.Type,
+				event.OccurredAt.UTC(),
+				event.ReceivedAt.UTC(),
+				event.UserID,
+				event.SessionID,
+				event.EbookID,
+				event.BannerID,
+				event.PageNumber,
+				event.PositionSeconds,
+				event.Query,
+				properties,
+				event.Locale,
+				event.IPAddress,
+				event.UserAgent,
+			)
+		}
+
+		if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// listMonthlyPartitions returns the months that have their own partition, oldest first
+func (r *analyticsRepository) listMonthlyPartitions(ctx context.Context) ([]time.Time, error) {
+	query := `SELECT PARTITION_NAME FROM information_schema.PARTITIONS
+		WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'analytics_events' AND PARTITION_NAME IS NOT NULL`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var months []time.Time
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		// p_past and p_future are not monthly
+		month, err := time.Parse(analyticsPartitionLayout, strings.TrimPrefix(name, analyticsPartitionPrefix))
+		if err != nil {
+			continue
+		}
+		months = append(months, month)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(months, func(i, j int) bool { return months[i].Before(months[j]) })
+	return months, nil
+}
+
+// pastPartitionBound returns the upper bound of p_past, or the zero time if the table has no p_past
+func (r *analyticsRepository) pastPartitionBound(ctx context.Context) (time.Time, error) {
+	query := `SELECT PARTITION_DESCRIPTION FROM information_schema.PARTITIONS
+		WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'analytics_events' AND PARTITION_NAME = ?`
+
+	var description string
+	err := r.db.QueryRowContext(ctx, query, analyticsPastPartition).Scan(&description)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return time.Time{}, nil
+		}
+		return time.Time{}, err
+	}
+
+	// RANGE COLUMNS bounds are quoted literals, e.g. '2026-01-01 00:00:00'
+	description = strings.Trim(description, "'")
+	for _, layout := range []string{"2006-01-02 15:04:05", "2006-01-02"} {
+		if bound, err := time.Parse(layout, description); err == nil {
+			return bound, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unexpected %s bound %q", analyticsPastPartition, description)
+}
+
+func analyticsPartitionName(month time.Time) string {
+	return analyticsPartitionPrefix + month.Format(analyticsPartitionLayout)
+}
+
+func analyticsMonth(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// EnsurePartitions splits the new months off p_future in one statement. Starting after the newest
+// monthly partition keeps the ranges increasing. The first run starts where p_past ends, so every
+// month already in p_future gets its own partition and expires on time; months before the
+// retention period are dropped right after by DropPartitionsBefore.
+func (r *analyticsRepository) EnsurePartitions(ctx context.Context, through time.Time) (int, error) {
+	months, err := r.listMonthlyPartitions(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	next := analyticsMonth(time.Now())
+	if len(months) > 0 {
+		next = months[len(months)-1].AddDate(0, 1, 0)
+	} else {
+		bound, err := r.pastPartitionBound(ctx)
+		if err != nil {
+			return 0, err
+		}
+		if !bound.IsZero() && bound.Before(next) {
+			next = analyticsMonth(bound)
+		}
+	}
+
+	var partitions []string
+	for month := next; !month.After(analyticsMonth(through)); month = month.AddDate(0, 1, 0) {
+		partitions = append(partitions, fmt.Sprintf("PARTITION %s VALUES LESS THAN ('%s')",
+			analyticsPartitionName(month), month.AddDate(0, 1, 0).Format("2006-01-02")))
+	}
+	if len(partitions) == 0 {
+		return 0, nil
+	}
+
+	query := `ALTER TABLE analytics_events REORGANIZE PARTITION p_future INTO (` +
+		strings.Join(partitions, ", ") + `, PARTITION p_future VALUES LESS THAN (MAXVALUE))`
+	if _, err := r.db.ExecContext(ctx, query); err != nil {
+		return 0, err
+	}
+
+	return len(partitions), nil
+}
+
+// DropPartitionsBefore drops the expired monthly partitions. p_past stays, since the first monthly
+// partition is split off at its bound, but is emptied once everything in it has expired.
+func (r *analyticsRepository) DropPartitionsBefore(ctx context.Context, before time.Time) (int, error) {
+	months, err := r.listMonthlyPartitions(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var expired []string
+	for _, month := range months {
+		if month.Before(analyticsMonth(before)) {
+			expired = append(expired, analyticsPartitionName(month))
+		}
+	}
+
+	if len(expired) > 0 {
+		if _, err := r.db.ExecContext(ctx, `ALTER TABLE analytics_events DROP PARTITION `+strings.Join(expired, ", ")); err != nil {
+			return 0, err
+		}
+	}
+
+	truncated, err := r.truncatePastPartition(ctx, before)
+	if err != nil {
+		return len(expired), err
+	}
+	if truncated {
+		return len(expired) + 1, nil
+	}
+	return len(expired), nil
+}
+
+// truncatePastPartition empties p_past if it ends before the month of before and still has rows
+func (r *analyticsRepository) truncatePastPartition(ctx context.Context, before time.Time) (bool, error) {
+	bound, err := r.pastPartitionBound(ctx)
+	if err != nil || bound.IsZero() || bound.After(analyticsMonth(before)) {
+		return false, err
+	}
+
+	var exists int
+	err = r.db.QueryRowContext(ctx, `SELECT 1 FROM analytics_events PARTITION (`+analyticsPastPartition+`) LIMIT 1`).Scan(&exists)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if _, err := r.db.ExecContext(ctx, `ALTER TABLE analytics_events TRUNCATE PARTITION `+analyticsPastPartition); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// analyticsConditions builds the WHERE clause of a filter on analytics_events aliased as a
+func analyticsConditions(filter *entity.AnalyticsFilter) (string, []any) {
+	where := `a.occurred_at >= ? AND a.occurred_at < ?`
+	args := []any{filter.From.UTC(), filter.To.UTC()}
+	if filter.Type != "" {
+		where += ` AND a.event_type = ?`
+		args = append(args, filter.Type)
+	}
+	if filter.EbookID != "" {
+		where += ` AND a.ebook_id = ?`
+		args = append(args, filter.EbookID)
+	}
+	return where, args
+}
+
+func (r *analyticsRepository) SummarizeEvents(ctx context.Context, filter *entity.AnalyticsFilter) ([]*entity.AnalyticsTotal, error) {
+	where, args := analyticsConditions(filter)
+	query := `SELECT a.event_type, COUNT(*), COUNT(DISTINCT a.user_id), COUNT(DISTINCT a.session_id)
+		FROM analytics_events a
+		WHERE ` + where + `
+		GROUP BY a.event_type
+		ORDER BY a.event_type`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var totals []*entity.AnalyticsTotal
+	for rows.Next() {
+		total := &entity.AnalyticsTotal{}
+		if err := rows.Scan(&total.Type, &total.Events, &total.Users, &total.Sessions); err != nil {
+			return nil, err
+		}
+		totals = append(totals, total)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return totals, nil
+}
+
+func (r *analyticsRepository) CountDaily(ctx context.Context, filter *entity.AnalyticsFilter) ([]*entity.AnalyticsDailyCount, error) {
+	where, args := analyticsConditions(filter)
+	query := `SELECT DATE_FORMAT(a.occurred_at, '%Y-%m-%d') AS day, a.event_type, COUNT(*)
+		FROM analytics_events a
+		WHERE ` + where + `
+		GROUP BY day, a.event_type
+		ORDER BY day, a.event_type`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var counts []*entity.AnalyticsDailyCount
+	for rows.Next() {
+		count := &entity.AnalyticsDailyCount{}
+		if err := rows.Scan(&count.Day, &count.Type, &count.Events); err != nil {
+			return nil, err
+		}
+		counts = append(counts, count)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// analyticsDimensions groups events by an ebook, a case-insensitive query or a banner, labelled with the title
+var analyticsDimensions = map[entity.AnalyticsDimension]struct {
+	key   string
+	label string
+	join  string
+}{
+	entity.AnalyticsDimensionEbook:  {key: `a.ebook_id`, label: `COALESCE(MAX(e.title), '')`, join: `LEFT JOIN ebooks e ON e.id = a.ebook_id`},
+	entity.AnalyticsDimensionSearch: {key: `LOWER(a.query)`, label: `LOWER(a.query)`},
+	entity.AnalyticsDimensionBanner: {key: `a.banner_id`, label: `COALESCE(MAX(b.title), '')`, join: `LEFT JOIN banners b ON b.id = a.banner_id`},
+}
+
+func (r *analyticsRepository) ListTop(ctx context.Context, filter *entity.AnalyticsFilter, dimension entity.AnalyticsDimension, limit int) ([]*entity.AnalyticsTopItem, error) {
+	d, ok := analyticsDimensions[dimension]
+	if !ok {
+		return nil, fmt.Errorf("unknown analytics dimension %q", dimension)
+	}
+
+	where, args := analyticsConditions(filter)
+	query := `SELECT ` + d.key + ` AS item_key, ` + d.label + `, COUNT(*) AS events, COUNT(DISTINCT a.user_id)
+		FROM analytics_events a ` + d.join + `
+		WHERE ` + where + ` AND ` + d.key + ` IS NOT NULL
+		GROUP BY item_key
+		ORDER BY events DESC, item_key
+		LIMIT ?`
+	args = append(args, limit)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []*entity.AnalyticsTopItem
+	for rows.Next() {
+		item := &entity.AnalyticsTopItem{}
+		if err := rows.Scan(&item.Key, &item.Label, &item.Events, &item.Users); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
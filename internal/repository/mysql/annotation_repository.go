@@ -0,0 +1,195 @@
+package mysql
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+)
+
+const annotationColumns = `id, user_id, ebook_id, type, page, cfi_start, cfi_end, selected_text, color, note, created_at, updated_at, deleted_at`
+
+type annotationRepository struct {
+	db *sql.DB
+}
+
+func NewAnnotationRepository(db *sql.DB) repository.AnnotationRepository {
+	return &annotationRepository{db: db}
+}
+
+func (r *annotationRepository) Create(ctx context.Context, annotation *entity.Annotation) error {
+	query := `INSERT INTO annotations (id, user_id, ebook_id, type, page, cfi_start, cfi_end, selected_text, color, note, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	annotation.CreatedAt = now
+	annotation.UpdatedAt = now
+
+	_, err := r.db.ExecContext(ctx, query,
+		annotation.ID,
+		annotation.UserID,
+		annotation.EbookID,
+		annotation.Type,
+		annotation.Page,
+		annotation.CFIStart,
+		annotation.CFIEnd,
+		annotation.SelectedText,
+		annotation.Color,
+		annotation.Note,
+		annotation.CreatedAt,
+		annotation.UpdatedAt,
+	)
+	return err
+}
+
+func (r *annotationRepository) GetByID(ctx context.Context, userID, id string) (*entity.Annotation, error) {
+	query := `SELECT ` + annotationColumns + ` FROM annotations WHERE id = ? AND user_id = ? AND deleted_at IS NULL`
+
+	annotation, err := scanAnnotation(r.db.QueryRowContext(ctx, query, id, userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return annotation, nil
+}
+
+func (r *annotationRepository) Update(ctx context.Context, annotation *entity.Annotation) error {
+	query := `UPDATE annotations
+		SET page = ?, cfi_start = ?, cfi_end = ?, selected_text = ?, color = ?, note = ?, updated_at = ?
+		WHERE id = ? AND user_id = ? AND deleted_at IS NULL`
+
+	annotation.UpdatedAt = time.Now().UTC().Truncate(time.Millisecond)
+
+	_, err := r.db.ExecContext(ctx, query,
+		annotation.Page,
+		annotation.CFIStart,
+		annotation.CFIEnd,
+		annotation.SelectedText,
+		annotation.Color,
+		annotation.Note,
+		annotation.UpdatedAt,
+		annotation.ID,
+		annotation.UserID,
+	)
+	return err
+}
+
+// Delete leaves a tombstone so devices syncing with updated_since learn about the deletion
+func (r *annotationRepository) Delete(ctx context.Context, userID, id string) error {
+	query := `UPDATE annotations SET deleted_at = ?, updated_at = ? WHERE id = ? AND user_id = ? AND deleted_at IS NULL`
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	_, err := r.db.ExecContext(ctx, query, now, now, id, userID)
+	return err
+}
+
+func (r *annotationRepository) List(ctx context.Context, filter *entity.AnnotationFilter, limit, offset int) ([]*entity.Annotation, error) {
+	where, args := annotationFilterClause(filter)
+
+	// Sync pages are walked oldest change first so a client can resume from the last updated_at
+	order := "created_at DESC, id DESC"
+	if filter.UpdatedSince != nil {
+		order = "updated_at ASC, id ASC"
+	}
+
+	query := `SELECT ` + annotationColumns + ` FROM annotations WHERE ` + where + ` ORDER BY ` + order + ` LIMIT ? OFFSET ?`
+	args = append(args, limit, offset)
+
+	return r.queryAnnotations(ctx, query, args...)
+}
+
+func (r *annotationRepository) Count(ctx context.Context, filter *entity.AnnotationFilter) (int64, error) {
+	where, args := annotationFilterClause(filter)
+	query := `SELECT COUNT(*) FROM annotations WHERE ` + where
+
+	var count int64
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *annotationRepository) ListByEbook(ctx context.Context, userID, ebookID string) ([]*entity.Annotation, error) {
+	// NULL pages (EPUB) sort after paged annotations, then by CFI
+	query := `SELECT ` + annotationColumns + ` FROM annotations
+		WHERE user_id = ? AND ebook_id = ? AND deleted_at IS NULL
+		ORDER BY page IS NULL, page ASC, cfi_start ASC, created_at ASC`
+
+	return r.queryAnnotations(ctx, query, userID, ebookID)
+}
+
+func (r *annotationRepository) queryAnnotations(ctx context.Context, query string, args ...any) ([]*entity.Annotation, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var annotations []*entity.Annotation
+	for rows.Next() {
+		annotation, err := scanAnnotation(rows)
+		if err != nil {
+			return nil, err
+		}
+		annotations = append(annotations, annotation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return annotations, nil
+}
+
+func annotationFilterClause(filter *entity.AnnotationFilter) (string, []any) {
+	conditions := []string{"user_id = ?"}
+	args := []any{filter.UserID}
+
+	if filter.EbookID != "" {
+		conditions = append(conditions, "ebook_id = ?")
+		args = append(args, filter.EbookID)
+	}
+	if filter.UpdatedSince != nil {
+		conditions = append(conditions, "updated_at > ?")
+		args = append(args, filter.UpdatedSince.UTC())
+	} else {
+		conditions = append(conditions, "deleted_at IS NULL")
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanAnnotation(row rowScanner) (*entity.Annotation, error) {
+	annotation := &entity.Annotation{}
+	err := row.Scan(
+		&annotation.ID,
+		&annotation.UserID,
+		&annotation.EbookID,
+		&annotation.Type,
+		&annotation.Page,
+		&annotation.CFIStart,
+		&annotation.CFIEnd,
+		&annotation.SelectedText,
+		&annotation.Color,
+		&annotation.Note,
+		&annotation.CreatedAt,
+		&annotation.UpdatedAt,
+		&annotation.DeletedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return annotation, nil
+}
@@ -72,12 +72,17 @@ func (r *articleRepository) GetByID(ctx context.Context, id string) (*entity.Art
 	return article, nil
 }
 
+// articleTextColumns selects the title, content, slug and excerpt in the locale of the request
+var articleTextColumns = translatedColumn(entity.TranslatableArticle, "articles", "title") + `, ` +
+	translatedColumn(entity.TranslatableArticle, "articles", "content") + `, slug, ` +
+	translatedColumn(entity.TranslatableArticle, "articles", "excerpt")
+
 func (r *articleRepository) GetBySlug(ctx context.Context, slug string) (*entity.Article, error) {
-	query := `SELECT id, author_id, title, content, slug, excerpt, cover_image, category_id, content_status_id, reading_time, published_at, created_at, updated_at 
+	query := `SELECT id, author_id, ` + articleTextColumns + `, cover_image, category_id, content_status_id, reading_time, published_at, created_at, updated_at 
 		FROM articles WHERE slug = ?`
 
 	article := &entity.Article{}
-	err := r.db.QueryRowContext(ctx, query, slug).Scan(
+	err := r.db.QueryRowContext(ctx, query, localeArgs(ctx, 3, slug)...).Scan(
 		&article.ID,
 		&article.AuthorID,
 		&article.Title,
@@ -256,10 +261,10 @@ func (r *articleRepository) ListByCategory(ctx context.Context, categoryID strin
 }
 
 func (r *articleRepository) ListPublished(ctx context.Context, limit, offset int) ([]*entity.Article, error) {
-	query := `SELECT id, author_id, title, content, slug, excerpt, cover_image, category_id, content_status_id, reading_time, published_at, created_at, updated_at 
-		FROM articles WHERE published_at IS NOT NULL ORDER BY published_at DESC LIMIT ? OFFSET ?`
+	query := `SELECT id, author_id, ` + articleTextColumns + `, cover_image, category_id, content_status_id, reading_time, published_at, created_at, updated_at 
+		FROM articles WHERE ` + publishedContentCondition + ` ORDER BY published_at DESC LIMIT ? OFFSET ?`
 
-	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	rows, err := r.db.QueryContext(ctx, query, localeArgs(ctx, 3, time.Now(), limit, offset)...)
 	if err != nil {
 		return nil, err
 	}
@@ -296,6 +301,16 @@ func (r *articleRepository) ListPublished(ctx context.Context, limit, offset int
 	return articles, nil
 }
 
+// ListFeedItems lists the newest published articles for a feed, in the locale of the request
+func (r *articleRepository) ListFeedItems(ctx context.Context, filter *entity.FeedFilter, limit int) ([]*entity.FeedItem, error) {
+	return listContentFeedItems(ctx, r.db, contentFeedColumns{
+		table:      "articles",
+		title:      translatedColumn(entity.TranslatableArticle, "t", "title"),
+		excerpt:    translatedColumn(entity.TranslatableArticle, "t", "excerpt"),
+		translated: 2,
+	}, filter, limit)
+}
+
 func (r *articleRepository) Count(ctx context.Context) (int64, error) {
 	query := `SELECT COUNT(*) FROM articles`
 	var count int64
@@ -318,8 +333,8 @@ func (r *articleRepository) CountByCategory(ctx context.Context, categoryID stri
 }
 
 func (r *articleRepository) CountPublished(ctx context.Context) (int64, error) {
-	query := `SELECT COUNT(*) FROM articles WHERE published_at IS NOT NULL`
+	query := `SELECT COUNT(*) FROM articles WHERE ` + publishedContentCondition
 	var count int64
-	err := r.db.QueryRowContext(ctx, query).Scan(&count)
+	err := r.db.QueryRowContext(ctx, query, time.Now()).Scan(&count)
 	return count, err
 }
@@ -17,8 +17,8 @@ func NewBannerRepository(db *sql.DB) repository.BannerRepository {
 }
 
 func (r *bannerRepository) Create(ctx context.Context, banner *entity.Banner) error {
-	query := `INSERT INTO banners (id, title, image_url, link, cta_label, background_color, is_active, created_at, updated_at) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	query := `INSERT INTO banners (id, title, image_url, link, cta_label, background_color, is_active, image_media_id, created_at, updated_at) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	now := time.Now()
 	banner.CreatedAt = now
@@ -32,18 +32,25 @@ func (r *bannerRepository) Create(ctx context.Context, banner *entity.Banner) er
 		banner.CTALabel,
 		banner.BackgroundColor,
 		banner.IsActive,
+		banner.ImageMediaID,
 		banner.CreatedAt,
 		banner.UpdatedAt,
 	)
 	return err
 }
 
+// bannerTextColumns selects the title and call to action label in the locale of the request
+var bannerTextColumns = translatedColumn(entity.TranslatableBanner, "banners", "title") + `, image_url, link, ` +
+	translatedColumn(entity.TranslatableBanner, "banners", "cta_label")
+
 func (r *bannerRepository) GetByID(ctx context.Context, id string) (*entity.Banner, error) {
-	query := `SELECT id, title, image_url, link, cta_label, background_color, is_active, created_at, updated_at 
+	query := `SELECT id, ` + bannerTextColumns + `, background_color, is_active, image_media_id, created_at, updated_at,
+			(SELECT m.storage_key FROM media m WHERE m.id = banners.image_media_id) AS image_media_key,
+			` + mediaVariantsColumn("banners.image_media_id") + ` AS image_variants
 		FROM banners WHERE id = ?`
 
 	banner := &entity.Banner{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := r.db.QueryRowContext(ctx, query, localeArgs(ctx, 2, id)...).Scan(
 		&banner.ID,
 		&banner.Title,
 		&banner.ImageURL,
@@ -51,8 +58,11 @@ func (r *bannerRepository) GetByID(ctx context.Context, id string) (*entity.Bann
 		&banner.CTALabel,
 		&banner.BackgroundColor,
 		&banner.IsActive,
+		&banner.ImageMediaID,
 		&banner.CreatedAt,
 		&banner.UpdatedAt,
+		&banner.ImageMediaKey,
+		&banner.ImageVariants,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -66,7 +76,7 @@ func (r *bannerRepository) GetByID(ctx context.Context, id string) (*entity.Bann
 
 func (r *bannerRepository) Update(ctx context.Context, banner *entity.Banner) error {
 	query := `UPDATE banners 
-		SET title = ?, image_url = ?, link = ?, cta_label = ?, background_color = ?, is_active = ?, updated_at = ?
+		SET title = ?, image_url = ?, link = ?, cta_label = ?, background_color = ?, is_active = ?, image_media_id = ?, updated_at = ?
 		WHERE id = ?`
 
 	banner.UpdatedAt = time.Now()
@@ -78,6 +88,7 @@ func (r *bannerRepository) Update(ctx context.Context, banner *entity.Banner) er
 		banner.CTALabel,
 		banner.BackgroundColor,
 		banner.IsActive,
+		banner.ImageMediaID,
 		banner.UpdatedAt,
 		banner.ID,
 	)
@@ -91,10 +102,12 @@ func (r *bannerRepository) Delete(ctx context.Context, id string) error {
 }
 
 func (r *bannerRepository) List(ctx context.Context, limit, offset int) ([]*entity.Banner, error) {
-	query := `SELECT id, title, image_url, link, cta_label, background_color, is_active, created_at, updated_at 
+	query := `SELECT id, ` + bannerTextColumns + `, background_color, is_active, image_media_id, created_at, updated_at,
+			(SELECT m.storage_key FROM media m WHERE m.id = banners.image_media_id) AS image_media_key,
+			` + mediaVariantsColumn("banners.image_media_id") + ` AS image_variants
 		FROM banners ORDER BY created_at DESC LIMIT ? OFFSET ?`
 
-	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	rows, err := r.db.QueryContext(ctx, query, localeArgs(ctx, 2, limit, offset)...)
 	if err != nil {
 		return nil, err
 	}
@@ -111,8 +124,11 @@ func (r *bannerRepository) List(ctx context.Context, limit, offset int) ([]*enti
 			&banner.CTALabel,
 			&banner.BackgroundColor,
 			&banner.IsActive,
+			&banner.ImageMediaID,
 			&banner.CreatedAt,
 			&banner.UpdatedAt,
+			&banner.ImageMediaKey,
+			&banner.ImageVariants,
 		)
 		if err != nil {
 			return nil, err
@@ -128,10 +144,12 @@ func (r *bannerRepository) List(ctx context.Context, limit, offset int) ([]*enti
 }
 
 func (r *bannerRepository) ListActive(ctx context.Context, limit, offset int) ([]*entity.Banner, error) {
-	query := `SELECT id, title, image_url, link, cta_label, background_color, is_active, created_at, updated_at 
+	query := `SELECT id, ` + bannerTextColumns + `, background_color, is_active, image_media_id, created_at, updated_at,
+			(SELECT m.storage_key FROM media m WHERE m.id = banners.image_media_id) AS image_media_key,
+			` + mediaVariantsColumn("banners.image_media_id") + ` AS image_variants
 		FROM banners WHERE is_active = true ORDER BY created_at DESC LIMIT ? OFFSET ?`
 
-	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	rows, err := r.db.QueryContext(ctx, query, localeArgs(ctx, 2, limit, offset)...)
 	if err != nil {
 		return nil, err
 	}
@@ -148,8 +166,11 @@ func (r *bannerRepository) ListActive(ctx context.Context, limit, offset int) ([
 			&banner.CTALabel,
 			&banner.BackgroundColor,
 			&banner.IsActive,
+			&banner.ImageMediaID,
 			&banner.CreatedAt,
 			&banner.UpdatedAt,
+			&banner.ImageMediaKey,
+			&banner.ImageVariants,
 		)
 		if err != nil {
 			return nil, err
@@ -0,0 +1,219 @@
+package mysql
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"cmp"
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+)
+
+// catalogLookupChunkSize bounds the number of values in one IN list
+const catalogLookupChunkSize = 500
+
+type catalogImportRepository struct {
+	db *sql.DB
+}
+
+func NewCatalogImportRepository(db *sql.DB) repository.CatalogImportRepository {
+	return &catalogImportRepository{db: db}
+}
+
+func (r *catalogImportRepository) FindEbookIDsBySlug(ctx context.Context, slugs []string) (map[string]string, error) {
+	return r.findIDs(ctx, `SELECT LOWER(slug), id FROM ebooks WHERE slug IN (%s)`, slugs)
+}
+
+func (r *catalogImportRepository) FindAuthorIDsByName(ctx context.Context, names []string) (map[string]string, error) {
+	return r.findIDs(ctx, `SELECT LOWER(name), id FROM authors WHERE name IN (%s)`, names)
+}
+
+// FindCategoryIDs matches names before slugs, so a category named like another one's slug is found by name
+func (r *catalogImportRepository) FindCategoryIDs(ctx context.Context, keys []string) (map[string]string, error) {
+	bySlug, err := r.findIDs(ctx, `SELECT LOWER(slug), id FROM categories WHERE slug IN (%s)`, keys)
+	if err != nil {
+		return nil, err
+	}
+	byName, err := r.findIDs(ctx, `SELECT LOWER(name), id FROM categories WHERE name IN (%s)`, keys)
+	if err != nil {
+		return nil, err
+	}
+	for key, id := range byName {
+		bySlug[key] = id
+	}
+	return bySlug, nil
+}
+
+// findIDs runs query, whose %s is replaced by the placeholders of one chunk of values, and maps
+// the first selected column to the second. The collation makes the IN comparisons case-insensitive.
+func (r *catalogImportRepository) findIDs(ctx context.Context, query string, values []string) (map[string]string, error) {
+	ids := map[string]string{}
+	for start := 0; start < len(values); start += catalogLookupChunkSize {
+		chunk := values[start:min(start+catalogLookupChunkSize, len(values))]
+		args := make([]any, len(chunk))
+		for i, value := range chunk {
+			args[i] = value
+		}
+
+		rows, err := r.db.QueryContext(ctx, strings.Replace(query, "%s", "?"+strings.Repeat(", ?", len(chunk)-1), 1), args...)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var key, id string
+			if err := rows.Scan(&key, &id); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			if _, ok := ids[key]; !ok {
+				ids[key] = id
+			}
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ids, nil
+}
+
+func (r *catalogImportRepository) ContentStatusIDs(ctx context.Context) (map[string]string, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT name, id FROM content_statuses WHERE name IS NOT NULL ORDER BY created_at ASC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := map[string]string{}
+	for rows.Next() {
+		var name, id string
+		if err := rows.Scan(&name, &id); err != nil {
+			return nil, err
+		}
+		if _, ok := ids[name]; !ok {
+			ids[name] = id
+		}
+	}
+	return ids, rows.Err()
+}
+
+func (r *catalogImportRepository) ImportBatch(ctx context.Context, batch *entity.CatalogImportBatch) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		now := time.Now()
+
+		for _, author := range batch.Authors {
+			author.CreatedAt, author.UpdatedAt = now, now
+			_, err := tx.ExecContext(ctx, `INSERT INTO authors (id, name, avatar, created_at, updated_at) VALUES (?, ?, ?, ?, ?)`,
+				author.ID, author.Name, author.Avatar, author.CreatedAt, author.UpdatedAt)
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, category := range batch.Categories {
+			category.CreatedAt, category.UpdatedAt = now, now
+			_, err := tx.ExecContext(ctx, `INSERT INTO categories (id, name, slug, description, icon_link, parent_id, order_number, is_active, created_at, updated_at)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				category.ID, category.Name, category.Slug, category.Description, category.IconLink,
+				category.ParentID, category.OrderNumber, category.IsActive, category.CreatedAt, category.UpdatedAt)
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, entry := range batch.Entries {
+			var err error
+			if entry.Exists {
+				err = updateCatalogEntry(ctx, tx, entry, now)
+			} else {
+				err = createCatalogEntry(ctx, tx, entry, now)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func createCatalogEntry(ctx context.Context, tx *sql.Tx, entry *entity.CatalogEntry, now time.Time) error {
+	format := entity.FormatPDF
+	if entry.Format != nil {
+		format = *entry.Format
+	}
+
+	query := `INSERT INTO ebooks (id, author_id, title, synopsis, slug, cover_image, category_id, content_status_id, price, language, duration, filesize, format, page_count, preview_page, url, published_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	_, err := tx.ExecContext(ctx, query,
+		entry.EbookID,
+		entry.AuthorID,
+		entry.Title,
+		valueOrZero(entry.Synopsis),
+		entry.Slug,
+		valueOrZero(entry.CoverImage),
+		entry.CategoryID,
+		entry.ContentStatusID,
+		entry.Price,
+		valueOrZero(entry.Language),
+		valueOrZero(entry.Duration),
+		valueOrZero(entry.Filesize),
+		format,
+		valueOrZero(entry.PageCount),
+		valueOrZero(entry.PreviewPage),
+		valueOrZero(entry.URL),
+		cmp.Or(entry.PublishedAt, publishFallback(entry, now)),
+		now,
+		now,
+	)
+	if err != nil {
+		return err
+	}
+
+	return syncPrimaryAuthor(ctx, tx, entry.EbookID, entry.AuthorID)
+}
+
+// updateCatalogEntry overwrites the required fields and the optional fields the entry sets
+func updateCatalogEntry(ctx context.Context, tx *sql.Tx, entry *entity.CatalogEntry, now time.Time) error {
+	if err := syncPrimaryAuthor(ctx, tx, entry.EbookID, entry.AuthorID); err != nil {
+		return err
+	}
+
+	query := `UPDATE ebooks SET
+			author_id = ?, title = ?, category_id = ?, price = ?,
+			synopsis = COALESCE(?, synopsis), language = COALESCE(?, language), format = COALESCE(?, format),
+			page_count = COALESCE(?, page_count), preview_page = COALESCE(?, preview_page),
+			duration = COALESCE(?, duration), filesize = COALESCE(?, filesize),
+			url = COALESCE(?, url), cover_image = COALESCE(?, cover_image),
+			content_status_id = COALESCE(?, content_status_id), published_at = COALESCE(?, published_at, ?),
+			updated_at = ?
+		WHERE id = ?`
+	_, err := tx.ExecContext(ctx, query,
+		entry.AuthorID, entry.Title, entry.CategoryID, entry.Price,
+		entry.Synopsis, entry.Language, entry.Format,
+		entry.PageCount, entry.PreviewPage,
+		entry.Duration, entry.Filesize,
+		entry.URL, entry.CoverImage,
+		entry.ContentStatusID, entry.PublishedAt, publishFallback(entry, now),
+		now,
+		entry.EbookID,
+	)
+	return err
+}
+
+// publishFallback is the publication time of entries published without one
+func publishFallback(entry *entity.CatalogEntry, now time.Time) *time.Time {
+	if entry.Status != nil && *entry.Status == entity.ContentStatusPublished {
+		return &now
+	}
+	return nil
+}
+
+func valueOrZero[T any](value *T) T {
+	if value == nil {
+		var zero T
+		return zero
+	}
+	return *value
+}
@@ -17,8 +17,8 @@ func NewCategoryRepository(db *sql.DB) repository.CategoryRepository {
 }
 
 func (r *categoryRepository) Create(ctx context.Context, category *entity.Category) error {
-	query := `INSERT INTO categories (id, name, description, icon_link, parent_id, order_number, is_active, created_at, updated_at) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	query := `INSERT INTO categories (id, name, description, icon_link, parent_id, order_number, is_active, icon_media_id, created_at, updated_at) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	now := time.Now()
 	category.CreatedAt = now
@@ -32,18 +32,24 @@ func (r *categoryRepository) Create(ctx context.Context, category *entity.Catego
 		category.ParentID,
 		category.OrderNumber,
 		category.IsActive,
+		category.IconMediaID,
 		category.CreatedAt,
 		category.UpdatedAt,
 	)
 	return err
 }
 
+// categoryTextColumns selects the name and description in the locale of the request
+var categoryTextColumns = translatedColumn(entity.TranslatableCategory, "categories", "name") + `, ` +
+	translatedColumn(entity.TranslatableCategory, "categories", "description")
+
 func (r *categoryRepository) GetByID(ctx context.Context, id string) (*entity.Category, error) {
-	query := `SELECT id, name, description, icon_link, parent_id, order_number, is_active, created_at, updated_at 
+	query := `SELECT id, ` + categoryTextColumns + `, icon_link, parent_id, order_number, is_active, icon_media_id, created_at, updated_at,
+			(SELECT m.storage_key FROM media m WHERE m.id = categories.icon_media_id) AS icon_media_key
 		FROM categories WHERE id = ?`
 
 	category := &entity.Category{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := r.db.QueryRowContext(ctx, query, localeArgs(ctx, 2, id)...).Scan(
 		&category.ID,
 		&category.Name,
 		&category.Description,
@@ -51,8 +57,10 @@ func (r *categoryRepository) GetByID(ctx context.Context, id string) (*entity.Ca
 		&category.ParentID,
 		&category.OrderNumber,
 		&category.IsActive,
+		&category.IconMediaID,
 		&category.CreatedAt,
 		&category.UpdatedAt,
+		&category.IconMediaKey,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -65,7 +73,8 @@ func (r *categoryRepository) GetByID(ctx context.Context, id string) (*entity.Ca
 }
 
 func (r *categoryRepository) GetByName(ctx context.Context, name string) (*entity.Category, error) {
-	query := `SELECT id, name, description, icon_link, parent_id, order_number, is_active, created_at, updated_at 
+	query := `SELECT id, name, description, icon_link, parent_id, order_number, is_active, icon_media_id, created_at, updated_at,
+			(SELECT m.storage_key FROM media m WHERE m.id = categories.icon_media_id) AS icon_media_key
 		FROM categories WHERE name = ?`
 
 	category := &entity.Category{}
@@ -77,8 +86,10 @@ func (r *categoryRepository) GetByName(ctx context.Context, name string) (*entit
 		&category.ParentID,
 		&category.OrderNumber,
 		&category.IsActive,
+		&category.IconMediaID,
 		&category.CreatedAt,
 		&category.UpdatedAt,
+		&category.IconMediaKey,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -92,7 +103,7 @@ func (r *categoryRepository) GetByName(ctx context.Context, name string) (*entit
 
 func (r *categoryRepository) Update(ctx context.Context, category *entity.Category) error {
 	query := `UPDATE categories 
-		SET name = ?, description = ?, icon_link = ?, parent_id = ?, order_number = ?, is_active = ?, updated_at = ?
+		SET name = ?, description = ?, icon_link = ?, parent_id = ?, order_number = ?, is_active = ?, icon_media_id = ?, updated_at = ?
 		WHERE id = ?`
 
 	category.UpdatedAt = time.Now()
@@ -104,6 +115,7 @@ func (r *categoryRepository) Update(ctx context.Context, category *entity.Catego
 		category.ParentID,
 		category.OrderNumber,
 		category.IsActive,
+		category.IconMediaID,
 		category.UpdatedAt,
 		category.ID,
 	)
@@ -117,10 +129,11 @@ func (r *categoryRepository) Delete(ctx context.Context, id string) error {
 }
 
 func (r *categoryRepository) List(ctx context.Context, limit, offset int) ([]*entity.Category, error) {
-	query := `SELECT id, name, description, icon_link, slug, parent_id, order_number, is_active, created_at, updated_at 
+	query := `SELECT id, ` + categoryTextColumns + `, icon_link, slug, parent_id, order_number, is_active, icon_media_id, created_at, updated_at,
+			(SELECT m.storage_key FROM media m WHERE m.id = categories.icon_media_id) AS icon_media_key
 		FROM categories ORDER BY order_number ASC, created_at DESC LIMIT ? OFFSET ?`
 
-	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	rows, err := r.db.QueryContext(ctx, query, localeArgs(ctx, 2, limit, offset)...)
 	if err != nil {
 		return nil, err
 	}
@@ -138,8 +151,10 @@ func (r *categoryRepository) List(ctx context.Context, limit, offset int) ([]*en
 			&category.ParentID,
 			&category.OrderNumber,
 			&category.IsActive,
+			&category.IconMediaID,
 			&category.CreatedAt,
 			&category.UpdatedAt,
+			&category.IconMediaKey,
 		)
 		if err != nil {
 			return nil, err
@@ -155,10 +170,65 @@ func (r *categoryRepository) List(ctx context.Context, limit, offset int) ([]*en
 }
 
 func (r *categoryRepository) ListActive(ctx context.Context, limit, offset int) ([]*entity.Category, error) {
-	query := `SELECT id, name, description, icon_link, slug, parent_id, order_number, is_active, created_at, updated_at 
+	query := `SELECT id, ` + categoryTextColumns + `, icon_link, slug, parent_id, order_number, is_active, icon_media_id, created_at, updated_at,
+			(SELECT m.storage_key FROM media m WHERE m.id = categories.icon_media_id) AS icon_media_key
 		FROM categories WHERE is_active = true ORDER BY order_number ASC, created_at DESC LIMIT ? OFFSET ?`
 
-	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	rows, err := r.db.QueryContext(ctx, query, localeArgs(ctx, 2, limit, offset)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var categories []*entity.Category
+	for rows.Next() {
+		category := &entity.Category{}
+		err = rows.Scan(
+			&category.ID,
+			&category.Name,
+			&category.Description,
+			&category.IconLink,
+			&category.Slug,
+			&category.ParentID,
+			&category.OrderNumber,
+			&category.IsActive,
+			&category.IconMediaID,
+			&category.CreatedAt,
+			&category.UpdatedAt,
+			&category.IconMediaKey,
+		)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, category)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+// ListActiveByCursor lists active categories after the cursor, keyed on (order_number, id).
+// Rows are returned nearest to the cursor first, so backward pages come back in reverse order.
+func (r *categoryRepository) ListActiveByCursor(ctx context.Context, cursor *entity.PageCursor, limit int) ([]*entity.Category, error) {
+	value, err := keysetIntValue(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	order, condition, args := keysetPage("order_number", "id", false, cursor, value)
+	query := `SELECT id, ` + categoryTextColumns + `, icon_link, slug, parent_id, order_number, is_active, icon_media_id, created_at, updated_at,
+			(SELECT m.storage_key FROM media m WHERE m.id = categories.icon_media_id) AS icon_media_key
+		FROM categories WHERE is_active = true`
+	if condition != "" {
+		query += ` AND ` + condition
+	}
+	query += ` ORDER BY ` + order + ` LIMIT ?`
+	args = localeArgs(ctx, 2, append(args, limit)...)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -176,8 +246,10 @@ func (r *categoryRepository) ListActive(ctx context.Context, limit, offset int)
 			&category.ParentID,
 			&category.OrderNumber,
 			&category.IsActive,
+			&category.IconMediaID,
 			&category.CreatedAt,
 			&category.UpdatedAt,
+			&category.IconMediaKey,
 		)
 		if err != nil {
 			return nil, err
@@ -193,10 +265,11 @@ func (r *categoryRepository) ListActive(ctx context.Context, limit, offset int)
 }
 
 func (r *categoryRepository) ListByParent(ctx context.Context, parentID string, limit, offset int) ([]*entity.Category, error) {
-	query := `SELECT id, name, description, icon_link, parent_id, order_number, is_active, created_at, updated_at 
+	query := `SELECT id, ` + categoryTextColumns + `, icon_link, parent_id, order_number, is_active, icon_media_id, created_at, updated_at,
+			(SELECT m.storage_key FROM media m WHERE m.id = categories.icon_media_id) AS icon_media_key
 		FROM categories WHERE parent_id = ? ORDER BY order_number ASC, created_at DESC LIMIT ? OFFSET ?`
 
-	rows, err := r.db.QueryContext(ctx, query, parentID, limit, offset)
+	rows, err := r.db.QueryContext(ctx, query, localeArgs(ctx, 2, parentID, limit, offset)...)
 	if err != nil {
 		return nil, err
 	}
@@ -213,8 +286,10 @@ func (r *categoryRepository) ListByParent(ctx context.Context, parentID string,
 			&category.ParentID,
 			&category.OrderNumber,
 			&category.IsActive,
+			&category.IconMediaID,
 			&category.CreatedAt,
 			&category.UpdatedAt,
+			&category.IconMediaKey,
 		)
 		if err != nil {
 			return nil, err
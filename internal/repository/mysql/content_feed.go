@@ -0,0 +1,74 @@
+package mysql
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+	"database/sql"
+	"time"
+)
+
+// contentFeedColumns are the columns of a content table (articles, inspirations) listed in a feed,
+// selected as t. title and excerpt are passed in so that translatable content can localize them.
+type contentFeedColumns struct {
+	table      string
+	title      string
+	excerpt    string
+	translated int // Locale placeholders in title and excerpt
+}
+
+// listContentFeedItems reads the newest published rows of a content table with their author
+// and category names, optionally of a single category or author
+func listContentFeedItems(ctx context.Context, db *sql.DB, columns contentFeedColumns, filter *entity.FeedFilter, limit int) ([]*entity.FeedItem, error) {
+	query := `SELECT t.id, ` + columns.title + `, t.slug, ` + columns.excerpt + `, t.cover_image,
+			t.author_id, COALESCE(a.name, ''), t.category_id,
+			COALESCE(` + translatedColumn(entity.TranslatableCategory, "c", "name") + `, ''), t.published_at, t.updated_at
+		FROM ` + columns.table + ` t
+		LEFT JOIN authors a ON a.id = t.author_id
+		LEFT JOIN categories c ON c.id = t.category_id
+		WHERE ` + publishedContentCondition
+	args := []any{time.Now()}
+	if filter != nil && filter.CategoryID != "" {
+		query += ` AND t.category_id = ?`
+		args = append(args, filter.CategoryID)
+	}
+	if filter != nil && filter.AuthorID != "" {
+		query += ` AND t.author_id = ?`
+		args = append(args, filter.AuthorID)
+	}
+	query += ` ORDER BY t.published_at DESC, t.id DESC LIMIT ?`
+	args = append(args, limit)
+
+	rows, err := db.QueryContext(ctx, query, localeArgs(ctx, columns.translated+1, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []*entity.FeedItem
+	for rows.Next() {
+		item := &entity.FeedItem{}
+		err = rows.Scan(
+			&item.ID,
+			&item.Title,
+			&item.Slug,
+			&item.Excerpt,
+			&item.CoverImage,
+			&item.AuthorID,
+			&item.AuthorName,
+			&item.CategoryID,
+			&item.CategoryName,
+			&item.PublishedAt,
+			&item.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
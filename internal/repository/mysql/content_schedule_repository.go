@@ -0,0 +1,183 @@
+package mysql
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type contentScheduleRepository struct {
+	db *sql.DB
+}
+
+func NewContentScheduleRepository(db *sql.DB) repository.ContentScheduleRepository {
+	return &contentScheduleRepository{db: db}
+}
+
+const contentScheduleColumns = `id, content_type, content_id, action, run_at, created_by, applied_at, created_at`
+
+// scheduledContentTables maps each schedulable content type to its table
+var scheduledContentTables = map[entity.SchedulableContent]string{
+	entity.SchedulableEbook:       "ebooks",
+	entity.SchedulableArticle:     "articles",
+	entity.SchedulableInspiration: "inspirations",
+}
+
+func scheduledContentTable(contentType entity.SchedulableContent) (string, error) {
+	table, ok := scheduledContentTables[contentType]
+	if !ok {
+		return "", fmt.Errorf("unknown content type %q", contentType)
+	}
+	return table, nil
+}
+
+func (r *contentScheduleRepository) Create(ctx context.Context, schedule *entity.ContentSchedule) error {
+	query := `INSERT INTO content_schedules (id, content_type, content_id, action, run_at, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+	schedule.CreatedAt = time.Now()
+
+	_, err := r.db.ExecContext(ctx, query,
+		schedule.ID,
+		schedule.ContentType,
+		schedule.ContentID,
+		schedule.Action,
+		schedule.RunAt,
+		schedule.CreatedBy,
+		schedule.CreatedAt,
+	)
+	return err
+}
+
+func (r *contentScheduleRepository) GetByID(ctx context.Context, id string) (*entity.ContentSchedule, error) {
+	query := `SELECT ` + contentScheduleColumns + ` FROM content_schedules WHERE id = ?`
+
+	schedule, err := scanContentSchedule(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+func (r *contentScheduleRepository) ListByContent(ctx context.Context, contentType entity.SchedulableContent, contentID string) ([]*entity.ContentSchedule, error) {
+	query := `SELECT ` + contentScheduleColumns + ` FROM content_schedules
+		WHERE content_type = ? AND content_id = ?
+		ORDER BY applied_at IS NOT NULL, run_at`
+
+	return r.query(ctx, query, contentType, contentID)
+}
+
+func (r *contentScheduleRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*entity.ContentSchedule, error) {
+	query := `SELECT ` + contentScheduleColumns + ` FROM content_schedules
+		WHERE applied_at IS NULL AND run_at <= ?
+		ORDER BY run_at
+		LIMIT ?`
+
+	return r.query(ctx, query, now, limit)
+}
+
+func (r *contentScheduleRepository) query(ctx context.Context, query string, args ...any) ([]*entity.ContentSchedule, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var schedules []*entity.ContentSchedule
+	for rows.Next() {
+		schedule, err := scanContentSchedule(rows)
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, schedule)
+	}
+
+	return schedules, rows.Err()
+}
+
+func (r *contentScheduleRepository) Delete(ctx context.Context, id string) error {
+	query := `DELETE FROM content_schedules WHERE id = ?`
+
+	_, err := r.db.ExecContext(ctx, query, id)
+	return err
+}
+
+func (r *contentScheduleRepository) ContentExists(ctx context.Context, contentType entity.SchedulableContent, contentID string) (bool, error) {
+	table, err := scheduledContentTable(contentType)
+	if err != nil {
+		return false, err
+	}
+
+	var exists bool
+	err = r.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM `+table+` WHERE id = ?)`, contentID).Scan(&exists)
+	return exists, err
+}
+
+func (r *contentScheduleRepository) Apply(ctx context.Context, id string, appliedAt time.Time) (bool, error) {
+	applied := false
+	err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+		// Lock the schedule so two instances of the job cannot apply it twice
+		query := `SELECT ` + contentScheduleColumns + ` FROM content_schedules WHERE id = ? FOR UPDATE`
+		schedule, err := scanContentSchedule(tx.QueryRowContext(ctx, query, id))
+		if err == sql.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !schedule.IsPending() {
+			return nil
+		}
+
+		table, err := scheduledContentTable(schedule.ContentType)
+		if err != nil {
+			return err
+		}
+		// Content deleted since it was scheduled matches no row; the schedule is still marked applied
+		update := `UPDATE ` + table + `
+			SET content_status_id = (SELECT id FROM content_statuses WHERE name = ? LIMIT 1), updated_at = ?`
+		args := []any{schedule.Action.ContentStatus(), appliedAt}
+		if schedule.Action == entity.SchedulePublish {
+			update += `, published_at = CASE WHEN published_at IS NULL OR published_at > ? THEN ? ELSE published_at END`
+			args = append(args, schedule.RunAt, schedule.RunAt)
+		}
+		update += ` WHERE id = ?`
+		args = append(args, schedule.ContentID)
+		if _, err := tx.ExecContext(ctx, update, args...); err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `UPDATE content_schedules SET applied_at = ? WHERE id = ?`, appliedAt, id)
+		if err != nil {
+			return err
+		}
+		applied = true
+		return nil
+	})
+	return applied, err
+}
+
+func scanContentSchedule(row rowScanner) (*entity.ContentSchedule, error) {
+	schedule := &entity.ContentSchedule{}
+	err := row.Scan(
+		&schedule.ID,
+		&schedule.ContentType,
+		&schedule.ContentID,
+		&schedule.Action,
+		&schedule.RunAt,
+		&schedule.CreatedBy,
+		&schedule.AppliedAt,
+		&schedule.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
@@ -134,3 +134,8 @@ func (r *contentStatusRepository) Count(ctx context.Context) (int64, error) {
 	err := r.db.QueryRowContext(ctx, query).Scan(&count)
 	return count, err
 }
+
+// publishedContentCondition matches rows of a content table (articles, inspirations) that are published
+// and whose publication time has come; it takes the current time as its only argument
+const publishedContentCondition = `published_at IS NOT NULL AND published_at <= ?
+		AND content_status_id IN (SELECT id FROM content_statuses WHERE name = "published")`
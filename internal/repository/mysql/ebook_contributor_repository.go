@@ -0,0 +1,100 @@
+package mysql
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"context"
+	"database/sql"
+	"time"
+)
+
+type ebookContributorRepository struct {
+	db *sql.DB
+}
+
+func NewEbookContributorRepository(db *sql.DB) repository.EbookContributorRepository {
+	return &ebookContributorRepository{db: db}
+}
+
+// ebookContributorsByEbookQuery lists an ebook's contributors in display order
+const ebookContributorsByEbookQuery = `SELECT ec.ebook_id, ec.author_id, ec.role, ec.display_order, a.name, a.avatar
+		FROM ebook_contributors ec
+		JOIN authors a ON a.id = ec.author_id
+		WHERE ec.ebook_id = ?
+		ORDER BY ec.display_order, a.name`
+
+func (r *ebookContributorRepository) ListByEbook(ctx context.Context, ebookID string) ([]*entity.EbookContributor, error) {
+	return listEbookContributors(ctx, r.db, ebookID)
+}
+
+func listEbookContributors(ctx context.Context, db *sql.DB, ebookID string) ([]*entity.EbookContributor, error) {
+	rows, err := db.QueryContext(ctx, ebookContributorsByEbookQuery, ebookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	contributors := make([]*entity.EbookContributor, 0)
+	for rows.Next() {
+		contributor := &entity.EbookContributor{}
+		err := rows.Scan(
+			&contributor.EbookID,
+			&contributor.AuthorID,
+			&contributor.Role,
+			&contributor.DisplayOrder,
+			&contributor.AuthorName,
+			&contributor.AuthorAvatar,
+		)
+		if err != nil {
+			return nil, err
+		}
+		contributors = append(contributors, contributor)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return contributors, nil
+}
+
+func (r *ebookContributorRepository) ReplaceByEbook(ctx context.Context, ebookID string, contributors []*entity.EbookContributor) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM ebook_contributors WHERE ebook_id = ?`, ebookID); err != nil {
+			return err
+		}
+
+		primaryAuthorID := ""
+		for _, contributor := range contributors {
+			_, err := tx.ExecContext(ctx, `INSERT INTO ebook_contributors (ebook_id, author_id, role, display_order) VALUES (?, ?, ?, ?)`,
+				ebookID, contributor.AuthorID, contributor.Role, contributor.DisplayOrder)
+			if err != nil {
+				return err
+			}
+			if primaryAuthorID == "" && contributor.Role == entity.ContributorAuthor {
+				primaryAuthorID = contributor.AuthorID
+			}
+		}
+
+		_, err := tx.ExecContext(ctx, `UPDATE ebooks SET author_id = COALESCE(NULLIF(?, ''), author_id), updated_at = ? WHERE id = ?`,
+			primaryAuthorID, time.Now(), ebookID)
+		return err
+	})
+}
+
+// syncPrimaryAuthor keeps ebooks.author_id listed as an author of the ebook when it is written
+// directly. Run it after inserting an ebook, or before changing its author_id, so the previous
+// primary author's contribution can be found and dropped.
+func syncPrimaryAuthor(ctx context.Context, exec sqlExecutor, ebookID, authorID string) error {
+	_, err := exec.ExecContext(ctx, `DELETE ec FROM ebook_contributors ec
+		JOIN ebooks e ON e.id = ec.ebook_id
+		WHERE ec.ebook_id = ? AND ec.role = ? AND ec.author_id = e.author_id AND e.author_id <> ?`,
+		ebookID, entity.ContributorAuthor, authorID)
+	if err != nil {
+		return err
+	}
+
+	_, err = exec.ExecContext(ctx, `INSERT IGNORE INTO ebook_contributors (ebook_id, author_id, role, display_order) VALUES (?, ?, ?, 0)`,
+		ebookID, authorID, entity.ContributorAuthor)
+	return err
+}
@@ -70,12 +70,17 @@ func (r *ebookDiscountRepository) GetByID(ctx context.Context, id string) (*enti
 func (r *ebookDiscountRepository) Update(ctx context.Context, discount *entity.EbookDiscount) error {
 	query := `
 		UPDATE ebook_discounts
-		SET ebook_id = ?, discount_price = ?, started_at = ?, ended_at = ?, updated_at = ?
+		SET notified_at = IF(started_at = ? AND discount_price = ?, notified_at, NULL),
+			ebook_id = ?, discount_price = ?, started_at = ?, ended_at = ?, updated_at = ?
 		WHERE id = ?
 	`
 	
+	// Moving the start or changing the price makes the discount announce itself again.
+	// notified_at is assigned first so it compares against the old values.
 	now := time.Now()
 	_, err := r.db.ExecContext(ctx, query,
+		discount.StartedAt,
+		discount.DiscountPrice,
 		discount.EbookID,
 		discount.DiscountPrice,
 		discount.StartedAt,
@@ -255,3 +260,46 @@ func (r *ebookDiscountRepository) CountActiveDiscounts(ctx context.Context) (int
 	err := r.db.QueryRowContext(ctx, query, now, now).Scan(&count)
 	return count, err
 }
+
+// ListStartedUnnotified returns discounts that have started but have not been announced to wishlisting users yet
+func (r *ebookDiscountRepository) ListStartedUnnotified(ctx context.Context, now time.Time, limit int) ([]*entity.EbookDiscount, error) {
+	query := `
+		SELECT id, ebook_id, discount_price, started_at, ended_at, created_at, updated_at
+		FROM ebook_discounts
+		WHERE notified_at IS NULL AND started_at <= ?
+		ORDER BY started_at ASC
+		LIMIT ?
+	`
+	
+	rows, err := r.db.QueryContext(ctx, query, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	
+	var discounts []*entity.EbookDiscount
+	for rows.Next() {
+		discount := &entity.EbookDiscount{}
+		err := rows.Scan(
+			&discount.ID,
+			&discount.EbookID,
+			&discount.DiscountPrice,
+			&discount.StartedAt,
+			&discount.EndedAt,
+			&discount.CreatedAt,
+			&discount.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		discounts = append(discounts, discount)
+	}
+	
+	return discounts, rows.Err()
+}
+
+func (r *ebookDiscountRepository) MarkNotified(ctx context.Context, id string, notifiedAt time.Time) error {
+	query := `UPDATE ebook_discounts SET notified_at = ?, updated_at = updated_at WHERE id = ?`
+	_, err := r.db.ExecContext(ctx, query, notifiedAt, id)
+	return err
+}
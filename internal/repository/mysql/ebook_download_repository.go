@@ -0,0 +1,80 @@
+package mysql
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"context"
+	"database/sql"
+	"time"
+)
+
+type ebookDownloadRepository struct {
+	db *sql.DB
+}
+
+func NewEbookDownloadRepository(db *sql.DB) repository.EbookDownloadRepository {
+	return &ebookDownloadRepository{db: db}
+}
+
+func (r *ebookDownloadRepository) Create(ctx context.Context, download *entity.EbookDownload) error {
+	query := `INSERT INTO ebook_downloads (id, user_id, ebook_id, ip_address, user_agent, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)`
+
+	download.CreatedAt = time.Now()
+
+	_, err := r.db.ExecContext(ctx, query,
+		download.ID,
+		download.UserID,
+		download.EbookID,
+		download.IPAddress,
+		download.UserAgent,
+		download.CreatedAt,
+	)
+	return err
+}
+
+func (r *ebookDownloadRepository) ListByUserID(ctx context.Context, userID string, limit, offset int) ([]*entity.EbookDownload, error) {
+	query := `SELECT id, user_id, ebook_id, ip_address, user_agent, created_at
+		FROM ebook_downloads WHERE user_id = ? ORDER BY created_at DESC LIMIT ? OFFSET ?`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var downloads []*entity.EbookDownload
+	for rows.Next() {
+		download := &entity.EbookDownload{}
+		err = rows.Scan(
+			&download.ID,
+			&download.UserID,
+			&download.EbookID,
+			&download.IPAddress,
+			&download.UserAgent,
+			&download.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		downloads = append(downloads, download)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return downloads, nil
+}
+
+func (r *ebookDownloadRepository) CountByUserID(ctx context.Context, userID string) (int64, error) {
+	query := `SELECT COUNT(*) FROM ebook_downloads WHERE user_id = ?`
+
+	var count int64
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
@@ -0,0 +1,95 @@
+package mysql
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"context"
+	"database/sql"
+	"time"
+)
+
+type ebookPageRepository struct {
+	db *sql.DB
+}
+
+func NewEbookPageRepository(db *sql.DB) repository.EbookPageRepository {
+	return &ebookPageRepository{db: db}
+}
+
+func (r *ebookPageRepository) Create(ctx context.Context, page *entity.EbookPage) error {
+	return createEbookPage(ctx, r.db, page)
+}
+
+func (r *ebookPageRepository) ReplaceByEbookID(ctx context.Context, ebookID string, pages []*entity.EbookPage) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM ebook_pages WHERE ebook_id = ?`, ebookID); err != nil {
+			return err
+		}
+
+		for _, page := range pages {
+			page.EbookID = ebookID
+			if err := createEbookPage(ctx, tx, page); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func createEbookPage(ctx context.Context, exec sqlExecutor, page *entity.EbookPage) error {
+	query := `INSERT INTO ebook_pages (id, ebook_id, page_number, title, content_type, content, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+
+	now := time.Now()
+	page.CreatedAt = now
+	page.UpdatedAt = now
+
+	_, err := exec.ExecContext(ctx, query,
+		page.ID,
+		page.EbookID,
+		page.PageNumber,
+		page.Title,
+		page.ContentType,
+		page.Content,
+		page.CreatedAt,
+		page.UpdatedAt,
+	)
+	return err
+}
+
+func (r *ebookPageRepository) GetByNumber(ctx context.Context, ebookID string, pageNumber int) (*entity.EbookPage, error) {
+	query := `SELECT id, ebook_id, page_number, title, content_type, content, created_at, updated_at
+		FROM ebook_pages WHERE ebook_id = ? AND page_number = ?`
+
+	page := &entity.EbookPage{}
+	err := r.db.QueryRowContext(ctx, query, ebookID, pageNumber).Scan(
+		&page.ID,
+		&page.EbookID,
+		&page.PageNumber,
+		&page.Title,
+		&page.ContentType,
+		&page.Content,
+		&page.CreatedAt,
+		&page.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return page, nil
+}
+
+func (r *ebookPageRepository) CountByEbookID(ctx context.Context, ebookID string) (int64, error) {
+	query := `SELECT COUNT(*) FROM ebook_pages WHERE ebook_id = ?`
+
+	var count int64
+	err := r.db.QueryRowContext(ctx, query, ebookID).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
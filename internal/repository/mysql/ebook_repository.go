@@ -5,7 +5,7 @@ import (
 	"buku-pintar/internal/domain/repository"
 	"context"
 	"database/sql"
-	"log"
+	"strings"
 	"time"
 )
 
@@ -18,39 +18,48 @@ func NewEbookRepository(db *sql.DB) repository.EbookRepository {
 }
 
 func (r *ebookRepository) Create(ctx context.Context, ebook *entity.Ebook) error {
-	query := `INSERT INTO ebooks (id, author_id, title, synopsis, slug, cover_image, category_id, content_status_id, price, language, duration, filesize, format, page_count, preview_page, url, published_at, created_at, updated_at) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	query := `INSERT INTO ebooks (id, author_id, title, synopsis, slug, cover_image, category_id, content_status_id, price, language, duration, filesize, format, page_count, preview_page, url, cover_media_id, file_media_id, published_at, created_at, updated_at) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	now := time.Now()
 	ebook.CreatedAt = now
 	ebook.UpdatedAt = now
 
-	_, err := r.db.ExecContext(ctx, query,
-		ebook.ID,
-		ebook.AuthorID,
-		ebook.Title,
-		ebook.Synopsis,
-		ebook.Slug,
-		ebook.CoverImage,
-		ebook.CategoryID,
-		ebook.ContentStatusID,
-		ebook.Price,
-		ebook.Language,
-		ebook.Duration,
-		ebook.Filesize,
-		ebook.Format,
-		ebook.PageCount,
-		ebook.PreviewPage,
-		ebook.URL,
-		ebook.PublishedAt,
-		ebook.CreatedAt,
-		ebook.UpdatedAt,
-	)
-	return err
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, query,
+			ebook.ID,
+			ebook.AuthorID,
+			ebook.Title,
+			ebook.Synopsis,
+			ebook.Slug,
+			ebook.CoverImage,
+			ebook.CategoryID,
+			ebook.ContentStatusID,
+			ebook.Price,
+			ebook.Language,
+			ebook.Duration,
+			ebook.Filesize,
+			ebook.Format,
+			ebook.PageCount,
+			ebook.PreviewPage,
+			ebook.URL,
+			ebook.CoverMediaID,
+			ebook.FileMediaID,
+			ebook.PublishedAt,
+			ebook.CreatedAt,
+			ebook.UpdatedAt,
+		)
+		if err != nil {
+			return err
+		}
+
+		return syncPrimaryAuthor(ctx, tx, ebook.ID, ebook.AuthorID)
+	})
 }
 
 func (r *ebookRepository) GetByID(ctx context.Context, id string) (*entity.Ebook, error) {
-	query := `SELECT id, author_id, title, synopsis, slug, cover_image, category_id, content_status_id, price, language, duration, filesize, format, page_count, preview_page, url, published_at, created_at, updated_at 
+	query := `SELECT id, author_id, title, synopsis, slug, cover_image, category_id, content_status_id, price, language, duration, filesize, format, page_count, preview_page, url, cover_media_id, file_media_id, published_at, created_at, updated_at, ` + ebookMediaKeyColumns + `,
+		COALESCE((SELECT cs.name FROM content_statuses cs WHERE cs.id = ebooks.content_status_id), '') AS content_status
 		FROM ebooks WHERE id = ?`
 
 	ebook := &entity.Ebook{}
@@ -71,9 +80,14 @@ func (r *ebookRepository) GetByID(ctx context.Context, id string) (*entity.Ebook
 		&ebook.PageCount,
 		&ebook.PreviewPage,
 		&ebook.URL,
+		&ebook.CoverMediaID,
+		&ebook.FileMediaID,
 		&ebook.PublishedAt,
 		&ebook.CreatedAt,
 		&ebook.UpdatedAt,
+		&ebook.CoverMediaKey,
+		&ebook.FileMediaKey,
+		&ebook.ContentStatus,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -87,21 +101,26 @@ func (r *ebookRepository) GetByID(ctx context.Context, id string) (*entity.Ebook
 
 func (r *ebookRepository) GetBySlug(ctx context.Context, slug string) (*entity.EbookDetail, error) {
 	query := `SELECT
-				e.id as id, e.author_id, e.title, e.synopsis, e.slug, e.cover_image, e.price,
+				e.id as id, e.author_id, e.category_id, COALESCE(et.title, e.title), COALESCE(et.synopsis, e.synopsis), e.slug, e.cover_image, e.price,
 				e.language, e.duration, e.filesize, e.format, e.page_count, e.preview_page, e.url,
-				e.published_at, e.created_at, e.updated_at, a.name as author_name, a.avatar as author_avatar,
+				e.published_at, e.created_at, e.updated_at, e.rating_average, e.rating_count, a.name as author_name, a.avatar as author_avatar,
 				cs.name as content_status, es.id as summary_id, es.description as summary_content,
-				es.audio_url as summary_audio_url, es.duration as summary_duration
+				es.audio_url as summary_audio_url, es.duration as summary_duration,
+				cm.storage_key as cover_media_key, am.storage_key as summary_audio_media_key
 		FROM ebooks e
 		LEFT JOIN authors a ON a.id = e.author_id
 		LEFT JOIN ebook_summaries es ON es.ebook_id = e.id
 		LEFT JOIN content_statuses cs ON cs.id = e.content_status_id
-		WHERE e.slug = ? AND cs.name = "published"`
+		LEFT JOIN media cm ON cm.id = e.cover_media_id
+		LEFT JOIN media am ON am.id = es.audio_media_id
+		LEFT JOIN ebook_translations et ON et.ebook_id = e.id AND et.locale = ?
+		WHERE e.slug = ? AND cs.name = "published" AND e.published_at IS NOT NULL AND e.published_at <= ?`
 
 	ebook := &entity.EbookDetail{}
-	err := r.db.QueryRowContext(ctx, query, slug).Scan(
+	err := r.db.QueryRowContext(ctx, query, entity.TranslationLocale(ctx), slug, time.Now()).Scan(
 		&ebook.ID,
 		&ebook.AuthorID,
+		&ebook.CategoryID,
 		&ebook.Title,
 		&ebook.Synopsis,
 		&ebook.Slug,
@@ -117,6 +136,8 @@ func (r *ebookRepository) GetBySlug(ctx context.Context, slug string) (*entity.E
 		&ebook.PublishedAt,
 		&ebook.CreatedAt,
 		&ebook.UpdatedAt,
+		&ebook.RatingAverage,
+		&ebook.RatingCount,
 		&ebook.AuthorName,
 		&ebook.AuthorAvatar,
 		&ebook.ContentStatus,
@@ -124,6 +145,8 @@ func (r *ebookRepository) GetBySlug(ctx context.Context, slug string) (*entity.E
 		&ebook.SummaryContent,
 		&ebook.SummaryAudioURL,
 		&ebook.SummaryDuration,
+		&ebook.CoverMediaKey,
+		&ebook.SummaryAudioMediaKey,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -132,37 +155,51 @@ func (r *ebookRepository) GetBySlug(ctx context.Context, slug string) (*entity.E
 		return nil, err
 	}
 
+	ebook.Contributors, err = listEbookContributors(ctx, r.db, ebook.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	return ebook, nil
 }
 
 func (r *ebookRepository) Update(ctx context.Context, ebook *entity.Ebook) error {
 	query := `UPDATE ebooks 
-		SET author_id = ?, title = ?, synopsis = ?, slug = ?, cover_image = ?, category_id = ?, content_status_id = ?, price = ?, language = ?, duration = ?, filesize = ?, format = ?, page_count = ?, preview_page = ?, url = ?, published_at = ?, updated_at = ?
+		SET author_id = ?, title = ?, synopsis = ?, slug = ?, cover_image = ?, category_id = ?, content_status_id = ?, price = ?, language = ?, duration = ?, filesize = ?, format = ?, page_count = ?, preview_page = ?, url = ?, cover_media_id = ?, file_media_id = ?, published_at = ?, updated_at = ?
 		WHERE id = ?`
 
 	ebook.UpdatedAt = time.Now()
 
-	_, err := r.db.ExecContext(ctx, query,
-		ebook.AuthorID,
-		ebook.Title,
-		ebook.Synopsis,
-		ebook.Slug,
-		ebook.CoverImage,
-		ebook.CategoryID,
-		ebook.ContentStatusID,
-		ebook.Price,
-		ebook.Language,
-		ebook.Duration,
-		ebook.Filesize,
-		ebook.Format,
-		ebook.PageCount,
-		ebook.PreviewPage,
-		ebook.URL,
-		ebook.PublishedAt,
-		ebook.UpdatedAt,
-		ebook.ID,
-	)
-	return err
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		// The primary author is synced first, while the previous one can still be read from the ebook
+		if err := syncPrimaryAuthor(ctx, tx, ebook.ID, ebook.AuthorID); err != nil {
+			return err
+		}
+
+		_, err := tx.ExecContext(ctx, query,
+			ebook.AuthorID,
+			ebook.Title,
+			ebook.Synopsis,
+			ebook.Slug,
+			ebook.CoverImage,
+			ebook.CategoryID,
+			ebook.ContentStatusID,
+			ebook.Price,
+			ebook.Language,
+			ebook.Duration,
+			ebook.Filesize,
+			ebook.Format,
+			ebook.PageCount,
+			ebook.PreviewPage,
+			ebook.URL,
+			ebook.CoverMediaID,
+			ebook.FileMediaID,
+			ebook.PublishedAt,
+			ebook.UpdatedAt,
+			ebook.ID,
+		)
+		return err
+	})
 }
 
 func (r *ebookRepository) Delete(ctx context.Context, id string) error {
@@ -171,20 +208,109 @@ func (r *ebookRepository) Delete(ctx context.Context, id string) error {
 	return err
 }
 
-func (r *ebookRepository) List(ctx context.Context, limit, offset int) ([]*entity.EbookList, error) {
-	now := time.Now().Format("2006-01-02 15:04:05")
+func (r *ebookRepository) List(ctx context.Context, filter *entity.EbookFilter, limit, offset int) ([]*entity.EbookList, error) {
+	from, args := buildEbookCatalogQuery(filter, entity.TranslationLocale(ctx), time.Now())
+	column, desc := ebookCatalogSortColumn(filter)
+	order, _, _ := keysetPage(column, "e.id", desc, nil, nil)
+	query := ebookCatalogSelect + from + `
+			ORDER BY ` + order + ` LIMIT ? OFFSET ?`
+	args = append(args, limit, offset)
+
+	return r.queryEbookList(ctx, query, args...)
+}
+
+// ListByCursor lists catalog entries after the cursor position, keyed on (sort column, id).
+// Rows are returned nearest to the cursor first, so backward pages come back in reverse order.
+func (r *ebookRepository) ListByCursor(ctx context.Context, filter *entity.EbookFilter, cursor *entity.PageCursor, limit int) ([]*entity.EbookList, error) {
+	column, desc := ebookCatalogSortColumn(filter)
+	value, err := ebookCursorValue(filter, cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	from, args := buildEbookCatalogQuery(filter, entity.TranslationLocale(ctx), time.Now())
+	order, condition, keysetArgs := keysetPage(column, "e.id", desc, cursor, value)
+	if condition != "" {
+		from += ` AND ` + condition
+		args = append(args, keysetArgs...)
+	}
+	query := ebookCatalogSelect + from + `
+			ORDER BY ` + order + ` LIMIT ?`
+	args = append(args, limit)
+
+	return r.queryEbookList(ctx, query, args...)
+}
+
+// ListFeedEntries lists catalog entries with the author, category and synopsis that feeds describe them with
+func (r *ebookRepository) ListFeedEntries(ctx context.Context, filter *entity.EbookFilter, limit, offset int) ([]*entity.EbookFeedEntry, error) {
+	from, args := buildEbookCatalogQuery(filter, entity.TranslationLocale(ctx), time.Now(),
+		"LEFT JOIN authors a ON a.id = e.author_id",
+		"LEFT JOIN categories c ON c.id = e.category_id")
+	column, desc := ebookCatalogSortColumn(filter)
+	order, _, _ := keysetPage(column, "e.id", desc, nil, nil)
 	query := `SELECT
-				e.id, e.title, e.slug, e.cover_image, e.price, ed.discount_price AS discount
-			FROM ebooks e
-			LEFT JOIN content_statuses cs ON cs.id = e.content_status_id
-			LEFT JOIN
-				ebook_discounts ed
-					ON ed.ebook_id = e.id AND ed.started_at <= ? AND ed.ended_at >= ? 
-			WHERE e.published_at IS NOT NULL AND e.published_at <= ? AND cs.name = "published"
-			ORDER BY e.published_at DESC LIMIT ? OFFSET ?`
+				e.id, COALESCE(et.title, e.title), COALESCE(et.synopsis, e.synopsis), e.slug, e.cover_image, e.price,
+				ed.discount_price AS discount, e.language, e.format, e.author_id, COALESCE(a.name, ''),
+				e.category_id, COALESCE(` + translatedColumn(entity.TranslatableCategory, "c", "name") + `, ''),
+				(e.file_media_id IS NOT NULL OR e.url <> '') AS has_file, e.published_at, e.updated_at,
+				(SELECT m.storage_key FROM media m WHERE m.id = e.cover_media_id) AS cover_media_key
+			` + from + `
+			ORDER BY ` + order + ` LIMIT ? OFFSET ?`
+	args = append(localeArgs(ctx, 1, args...), limit, offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*entity.EbookFeedEntry
+	for rows.Next() {
+		entry := &entity.EbookFeedEntry{}
+		err = rows.Scan(
+			&entry.ID,
+			&entry.Title,
+			&entry.Synopsis,
+			&entry.Slug,
+			&entry.CoverImage,
+			&entry.Price,
+			&entry.Discount,
+			&entry.Language,
+			&entry.Format,
+			&entry.AuthorID,
+			&entry.AuthorName,
+			&entry.CategoryID,
+			&entry.CategoryName,
+			&entry.HasFile,
+			&entry.PublishedAt,
+			&entry.UpdatedAt,
+			&entry.CoverMediaKey,
+		)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
 
-	rows, err := r.db.QueryContext(ctx, query, now, now, now, limit, offset)
-	log.Println(now)
+	return entries, nil
+}
+
+// ebookMediaKeyColumns selects the storage keys of the media attached to an ebook
+const ebookMediaKeyColumns = `(SELECT m.storage_key FROM media m WHERE m.id = ebooks.cover_media_id) AS cover_media_key,
+		(SELECT m.storage_key FROM media m WHERE m.id = ebooks.file_media_id) AS file_media_key`
+
+var ebookCatalogSelect = `SELECT
+				e.id, COALESCE(et.title, e.title), e.slug, e.cover_image, e.price, ed.discount_price AS discount, e.published_at, e.popularity_score,
+				e.rating_average, e.rating_count, (SELECT m.storage_key FROM media m WHERE m.id = e.cover_media_id) AS cover_media_key,
+				` + mediaVariantsColumn("e.cover_media_id") + ` AS cover_variants
+			`
+
+func (r *ebookRepository) queryEbookList(ctx context.Context, query string, args ...any) ([]*entity.EbookList, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -200,6 +326,12 @@ func (r *ebookRepository) List(ctx context.Context, limit, offset int) ([]*entit
 			&ebook.CoverImage,
 			&ebook.Price,
 			&ebook.Discount,
+			&ebook.PublishedAt,
+			&ebook.PopularityScore,
+			&ebook.RatingAverage,
+			&ebook.RatingCount,
+			&ebook.CoverMediaKey,
+			&ebook.CoverVariants,
 		)
 		if err != nil {
 			return nil, err
@@ -215,7 +347,7 @@ func (r *ebookRepository) List(ctx context.Context, limit, offset int) ([]*entit
 }
 
 func (r *ebookRepository) ListByCategory(ctx context.Context, categoryID string, limit, offset int) ([]*entity.Ebook, error) {
-	query := `SELECT id, author_id, title, synopsis, slug, cover_image, category_id, content_status_id, price, language, duration, filesize, format, page_count, preview_page, url, published_at, created_at, updated_at 
+	query := `SELECT id, author_id, title, synopsis, slug, cover_image, category_id, content_status_id, price, language, duration, filesize, format, page_count, preview_page, url, cover_media_id, file_media_id, published_at, created_at, updated_at, ` + ebookMediaKeyColumns + `
 		FROM ebooks WHERE category_id = ? ORDER BY created_at DESC LIMIT ? OFFSET ?`
 
 	rows, err := r.db.QueryContext(ctx, query, categoryID, limit, offset)
@@ -244,9 +376,13 @@ func (r *ebookRepository) ListByCategory(ctx context.Context, categoryID string,
 			&ebook.PageCount,
 			&ebook.PreviewPage,
 			&ebook.URL,
+			&ebook.CoverMediaID,
+			&ebook.FileMediaID,
 			&ebook.PublishedAt,
 			&ebook.CreatedAt,
 			&ebook.UpdatedAt,
+			&ebook.CoverMediaKey,
+			&ebook.FileMediaKey,
 		)
 		if err != nil {
 			return nil, err
@@ -262,8 +398,9 @@ func (r *ebookRepository) ListByCategory(ctx context.Context, categoryID string,
 }
 
 func (r *ebookRepository) ListByAuthor(ctx context.Context, authorID string, limit, offset int) ([]*entity.Ebook, error) {
-	query := `SELECT id, author_id, title, synopsis, slug, cover_image, category_id, content_status_id, price, language, duration, filesize, format, page_count, preview_page, url, published_at, created_at, updated_at 
-		FROM ebooks WHERE author_id = ? ORDER BY created_at DESC LIMIT ? OFFSET ?`
+	query := `SELECT id, author_id, title, synopsis, slug, cover_image, category_id, content_status_id, price, language, duration, filesize, format, page_count, preview_page, url, cover_media_id, file_media_id, published_at, created_at, updated_at, ` + ebookMediaKeyColumns + `
+		FROM ebooks WHERE id IN (SELECT ebook_id FROM ebook_contributors WHERE author_id = ?)
+		ORDER BY created_at DESC LIMIT ? OFFSET ?`
 
 	rows, err := r.db.QueryContext(ctx, query, authorID, limit, offset)
 	if err != nil {
@@ -291,9 +428,13 @@ func (r *ebookRepository) ListByAuthor(ctx context.Context, authorID string, lim
 			&ebook.PageCount,
 			&ebook.PreviewPage,
 			&ebook.URL,
+			&ebook.CoverMediaID,
+			&ebook.FileMediaID,
 			&ebook.PublishedAt,
 			&ebook.CreatedAt,
 			&ebook.UpdatedAt,
+			&ebook.CoverMediaKey,
+			&ebook.FileMediaKey,
 		)
 		if err != nil {
 			return nil, err
@@ -308,13 +449,216 @@ func (r *ebookRepository) ListByAuthor(ctx context.Context, authorID string, lim
 	return ebooks, nil
 }
 
-func (r *ebookRepository) Count(ctx context.Context) (int64, error) {
-	query := `SELECT COUNT(*) FROM ebooks`
+func (r *ebookRepository) Count(ctx context.Context, filter *entity.EbookFilter) (int64, error) {
+	from, args := buildEbookCatalogQuery(filter, entity.TranslationLocale(ctx), time.Now())
+	query := `SELECT COUNT(DISTINCT e.id) ` + from
 	var count int64
-	err := r.db.QueryRowContext(ctx, query).Scan(&count)
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&count)
 	return count, err
 }
 
+// Facets returns the facet counts of the published ebooks matching the filter
+func (r *ebookRepository) Facets(ctx context.Context, filter *entity.EbookFilter) (*entity.EbookFacets, error) {
+	now := time.Now()
+	facets := &entity.EbookFacets{}
+
+	var err error
+	facets.Categories, err = r.facetCounts(ctx, filter, now,
+		"e.category_id", "COALESCE("+translatedColumn(entity.TranslatableCategory, "c", "name")+", '')", localeArgs(ctx, 1),
+		"LEFT JOIN categories c ON c.id = e.category_id")
+	if err != nil {
+		return nil, err
+	}
+	// An ebook counts for each of its contributors, as filtering by any of them finds it
+	facets.Authors, err = r.facetCounts(ctx, filter, now,
+		"ec.author_id", "COALESCE(a.name, '')", nil,
+		"JOIN ebook_contributors ec ON ec.ebook_id = e.id LEFT JOIN authors a ON a.id = ec.author_id")
+	if err != nil {
+		return nil, err
+	}
+	facets.Languages, err = r.facetCounts(ctx, filter, now, "e.language", "e.language", nil, "")
+	if err != nil {
+		return nil, err
+	}
+	facets.Formats, err = r.facetCounts(ctx, filter, now, "e.format", "e.format", nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	from, args := buildEbookCatalogQuery(filter, entity.TranslationLocale(ctx), now)
+	query := `SELECT
+				COUNT(DISTINCT CASE WHEN e.price = 0 THEN e.id END),
+				COUNT(DISTINCT CASE WHEN ed.id IS NOT NULL THEN e.id END)
+			` + from
+	err = r.db.QueryRowContext(ctx, query, args...).Scan(&facets.Free, &facets.Discounted)
+	if err != nil {
+		return nil, err
+	}
+
+	return facets, nil
+}
+
+// facetCounts counts the matching ebooks per value of valueColumn. labelArgs are the arguments
+// of the placeholders in labelColumn, which precede the ones of the FROM part.
+func (r *ebookRepository) facetCounts(ctx context.Context, filter *entity.EbookFilter, now time.Time, valueColumn, labelColumn string, labelArgs []any, join string) ([]entity.FacetCount, error) {
+	from, args := buildEbookCatalogQuery(filter, entity.TranslationLocale(ctx), now, join)
+	query := `SELECT ` + valueColumn + ` AS value, ` + labelColumn + ` AS label, COUNT(DISTINCT e.id) AS total
+			` + from + `
+			GROUP BY value, label
+			ORDER BY total DESC, label ASC`
+	args = append(labelArgs, args...)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := []entity.FacetCount{}
+	for rows.Next() {
+		var facet entity.FacetCount
+		if err = rows.Scan(&facet.Value, &facet.Label, &facet.Count); err != nil {
+			return nil, err
+		}
+		counts = append(counts, facet)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// buildEbookCatalogQuery builds the FROM/WHERE part shared by the catalog list, count and facet queries.
+// Only published ebooks whose published_at is not in the future are included.
+// Translations in locale are joined as et, see ebookCatalogSelect. Extra joins are placed before the WHERE clause.
+// Times are bound as UTC literals, the zone DATETIME columns are stored in.
+func buildEbookCatalogQuery(filter *entity.EbookFilter, locale string, now time.Time, joins ...string) (string, []any) {
+	nowStr := now.UTC().Format("2006-01-02 15:04:05")
+	query := `FROM ebooks e
+			LEFT JOIN content_statuses cs ON cs.id = e.content_status_id
+			LEFT JOIN
+				ebook_discounts ed
+					ON ed.ebook_id = e.id AND ed.started_at <= ? AND ed.ended_at >= ?
+			LEFT JOIN ebook_translations et ON et.ebook_id = e.id AND et.locale = ?`
+	for _, join := range joins {
+		if join != "" {
+			query += `
+			` + join
+		}
+	}
+	query += `
+			WHERE e.published_at IS NOT NULL AND e.published_at <= ? AND cs.name = "published"`
+	args := []any{nowStr, nowStr, locale, nowStr}
+
+	if filter == nil {
+		return query, args
+	}
+
+	if filter.Query != "" {
+		pattern := "%" + escapeLike(filter.Query) + "%"
+		query += ` AND (COALESCE(et.title, e.title) LIKE ? OR e.author_id IN (SELECT au.id FROM authors au WHERE au.name LIKE ?))`
+		args = append(args, pattern, pattern)
+	}
+	if filter.CategoryID != "" {
+		query += `
+				AND e.category_id IN (
+					WITH RECURSIVE category_tree AS (
+						SELECT id FROM categories WHERE id = ?
+						UNION ALL
+						SELECT c.id FROM categories c INNER JOIN category_tree ct ON c.parent_id = ct.id
+					)
+					SELECT id FROM category_tree
+				)`
+		args = append(args, filter.CategoryID)
+	}
+	if filter.AuthorID != "" {
+		query += ` AND e.id IN (SELECT ebook_id FROM ebook_contributors WHERE author_id = ?)`
+		args = append(args, filter.AuthorID)
+	}
+	if filter.Language != "" {
+		query += ` AND LOWER(e.language) = ?`
+		args = append(args, filter.Language)
+	}
+	if filter.Format != "" {
+		query += ` AND e.format = ?`
+		args = append(args, filter.Format)
+	}
+	if filter.MinPrice != nil {
+		query += ` AND COALESCE(ed.discount_price, e.price) >= ?`
+		args = append(args, *filter.MinPrice)
+	}
+	if filter.MaxPrice != nil {
+		query += ` AND COALESCE(ed.discount_price, e.price) <= ?`
+		args = append(args, *filter.MaxPrice)
+	}
+	if filter.FreeOnly {
+		query += ` AND e.price = 0`
+	}
+	if filter.Discounted {
+		query += ` AND ed.id IS NOT NULL`
+	}
+	if filter.PublishedFrom != nil {
+		query += ` AND e.published_at >= ?`
+		args = append(args, filter.PublishedFrom.UTC().Format("2006-01-02 15:04:05"))
+	}
+	if filter.PublishedTo != nil {
+		query += ` AND e.published_at < ?`
+		args = append(args, filter.PublishedTo.UTC().Format("2006-01-02 15:04:05"))
+	}
+
+	return query, args
+}
+
+// escapeLike escapes the LIKE wildcards so the value is matched literally
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
+
+// ebookCatalogSortColumn maps the requested sort to its sort column and direction.
+// Every order uses e.id in the same direction as a stable tie-breaker so it can be used as a keyset.
+func ebookCatalogSortColumn(filter *entity.EbookFilter) (string, bool) {
+	sort := entity.EbookSortNewest
+	if filter != nil && filter.Sort != "" {
+		sort = filter.Sort
+	}
+
+	switch sort {
+	case entity.EbookSortPriceAsc:
+		return "COALESCE(ed.discount_price, e.price)", false
+	case entity.EbookSortPriceDesc:
+		return "COALESCE(ed.discount_price, e.price)", true
+	case entity.EbookSortTitle:
+		return "COALESCE(et.title, e.title)", false
+	case entity.EbookSortPopularity:
+		return "e.popularity_score", true
+	default:
+		return "e.published_at", true
+	}
+}
+
+// ebookCursorValue converts the cursor sort value to the type of the sort column
+func ebookCursorValue(filter *entity.EbookFilter, cursor *entity.PageCursor) (any, error) {
+	if cursor == nil {
+		return nil, nil
+	}
+
+	sort := entity.EbookSortNewest
+	if filter != nil && filter.Sort != "" {
+		sort = filter.Sort
+	}
+
+	switch sort {
+	case entity.EbookSortPriceAsc, entity.EbookSortPriceDesc, entity.EbookSortPopularity:
+		return keysetIntValue(cursor)
+	case entity.EbookSortTitle:
+		return cursor.Value, nil
+	default:
+		return keysetTimeValue(cursor)
+	}
+}
+
 func (r *ebookRepository) CountByCategory(ctx context.Context, categoryID string) (int64, error) {
 	query := `SELECT COUNT(*) FROM ebooks WHERE category_id = ?`
 	var count int64
@@ -323,7 +667,7 @@ func (r *ebookRepository) CountByCategory(ctx context.Context, categoryID string
 }
 
 func (r *ebookRepository) CountByAuthor(ctx context.Context, authorID string) (int64, error) {
-	query := `SELECT COUNT(*) FROM ebooks WHERE author_id = ?`
+	query := `SELECT COUNT(DISTINCT ebook_id) FROM ebook_contributors WHERE author_id = ?`
 	var count int64
 	err := r.db.QueryRowContext(ctx, query, authorID).Scan(&count)
 	return count, err
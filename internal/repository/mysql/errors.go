@@ -0,0 +1,21 @@
+package mysql
+
+import (
+	"buku-pintar/internal/domain/repository"
+	"errors"
+	"fmt"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// mysqlDuplicateEntry is the MySQL error number for a duplicate value in a unique key
+const mysqlDuplicateEntry = 1062
+
+// translateDuplicateKey wraps a duplicate entry error in repository.ErrDuplicateKey and returns other errors unchanged
+func translateDuplicateKey(err error) error {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateEntry {
+		return fmt.Errorf("%w: %s", repository.ErrDuplicateKey, mysqlErr.Message)
+	}
+	return err
+}
@@ -257,9 +257,9 @@ func (r *inspirationRepository) ListByCategory(ctx context.Context, categoryID s
 
 func (r *inspirationRepository) ListPublished(ctx context.Context, limit, offset int) ([]*entity.Inspiration, error) {
 	query := `SELECT id, author_id, title, content, slug, excerpt, cover_image, category_id, content_status_id, reading_time, published_at, created_at, updated_at 
-		FROM inspirations WHERE published_at IS NOT NULL ORDER BY published_at DESC LIMIT ? OFFSET ?`
+		FROM inspirations WHERE ` + publishedContentCondition + ` ORDER BY published_at DESC LIMIT ? OFFSET ?`
 
-	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	rows, err := r.db.QueryContext(ctx, query, time.Now(), limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -296,6 +296,15 @@ func (r *inspirationRepository) ListPublished(ctx context.Context, limit, offset
 	return inspirations, nil
 }
 
+// ListFeedItems lists the newest published inspirations for a feed
+func (r *inspirationRepository) ListFeedItems(ctx context.Context, filter *entity.FeedFilter, limit int) ([]*entity.FeedItem, error) {
+	return listContentFeedItems(ctx, r.db, contentFeedColumns{
+		table:   "inspirations",
+		title:   "t.title",
+		excerpt: "t.excerpt",
+	}, filter, limit)
+}
+
 func (r *inspirationRepository) Count(ctx context.Context) (int64, error) {
 	query := `SELECT COUNT(*) FROM inspirations`
 	var count int64
@@ -318,8 +327,8 @@ func (r *inspirationRepository) CountByCategory(ctx context.Context, categoryID
 }
 
 func (r *inspirationRepository) CountPublished(ctx context.Context) (int64, error) {
-	query := `SELECT COUNT(*) FROM inspirations WHERE published_at IS NOT NULL`
+	query := `SELECT COUNT(*) FROM inspirations WHERE ` + publishedContentCondition
 	var count int64
-	err := r.db.QueryRowContext(ctx, query).Scan(&count)
+	err := r.db.QueryRowContext(ctx, query, time.Now()).Scan(&count)
 	return count, err
 }
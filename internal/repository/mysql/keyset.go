@@ -0,0 +1,53 @@
+package mysql
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"fmt"
+)
+
+// keysetPage returns the ORDER BY clause and, when a cursor is given, the WHERE condition and args
+// selecting the rows after it in a list ordered by (column, idColumn).
+// Backward cursors walk the list in reverse so the rows nearest to the cursor come first.
+func keysetPage(column, idColumn string, desc bool, cursor *entity.PageCursor, value any) (string, string, []any) {
+	if cursor != nil && cursor.Backward {
+		desc = !desc
+	}
+
+	op, dir := ">", "ASC"
+	if desc {
+		op, dir = "<", "DESC"
+	}
+	order := fmt.Sprintf("%s %s, %s %s", column, dir, idColumn, dir)
+
+	if cursor == nil {
+		return order, "", nil
+	}
+
+	condition := fmt.Sprintf("(%s %s ? OR (%s = ? AND %s %s ?))", column, op, column, idColumn, op)
+	return order, condition, []any{value, value, cursor.ID}
+}
+
+// keysetTimeValue converts the sort value of a timestamp cursor to a DATETIME literal
+func keysetTimeValue(cursor *entity.PageCursor) (any, error) {
+	if cursor == nil {
+		return nil, nil
+	}
+	t, err := cursor.TimeValue()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", repository.ErrInvalidCursorValue, err)
+	}
+	return t.Format("2006-01-02 15:04:05.999999"), nil
+}
+
+// keysetIntValue converts the sort value of an integer cursor
+func keysetIntValue(cursor *entity.PageCursor) (any, error) {
+	if cursor == nil {
+		return nil, nil
+	}
+	v, err := cursor.IntValue()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", repository.ErrInvalidCursorValue, err)
+	}
+	return v, nil
+}
@@ -0,0 +1,221 @@
+package mysql
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type mediaRepository struct {
+	db *sql.DB
+}
+
+func NewMediaRepository(db *sql.DB) repository.MediaRepository {
+	return &mediaRepository{db: db}
+}
+
+func (r *mediaRepository) Create(ctx context.Context, media *entity.Media) (bool, error) {
+	query := `INSERT IGNORE INTO media (id, kind, storage_key, filename, content_type, size, checksum, uploaded_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	media.CreatedAt = time.Now()
+
+	result, err := r.db.ExecContext(ctx, query,
+		media.ID,
+		media.Kind,
+		media.StorageKey,
+		media.Filename,
+		media.ContentType,
+		media.Size,
+		media.Checksum,
+		media.UploadedBy,
+		media.CreatedAt,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func (r *mediaRepository) GetByID(ctx context.Context, id string) (*entity.Media, error) {
+	return r.getOne(ctx, `WHERE id = ?`, id)
+}
+
+func (r *mediaRepository) GetByStorageKey(ctx context.Context, key string) (*entity.Media, error) {
+	return r.getOne(ctx, `WHERE storage_key = ?`, key)
+}
+
+func (r *mediaRepository) GetByChecksum(ctx context.Context, kind entity.MediaKind, checksum string) (*entity.Media, error) {
+	return r.getOne(ctx, `WHERE kind = ? AND checksum = ?`, kind, checksum)
+}
+
+func (r *mediaRepository) ListByKind(ctx context.Context, kind entity.MediaKind, afterID string, limit int) ([]*entity.Media, error) {
+	query := `SELECT id, kind, storage_key, filename, content_type, size, checksum, uploaded_by, created_at
+		FROM media
+		WHERE kind = ? AND id > ?
+		ORDER BY id
+		LIMIT ?`
+
+	rows, err := r.db.QueryContext(ctx, query, kind, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var media []*entity.Media
+	for rows.Next() {
+		item := &entity.Media{}
+		err := rows.Scan(
+			&item.ID,
+			&item.Kind,
+			&item.StorageKey,
+			&item.Filename,
+			&item.ContentType,
+			&item.Size,
+			&item.Checksum,
+			&item.UploadedBy,
+			&item.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		media = append(media, item)
+	}
+
+	return media, rows.Err()
+}
+
+func (r *mediaRepository) SaveVariant(ctx context.Context, variant *entity.MediaVariant) error {
+	query := `INSERT INTO media_variants (id, media_id, name, format, storage_key, width, height, size, checksum, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			storage_key = VALUES(storage_key),
+			width = VALUES(width),
+			height = VALUES(height),
+			size = VALUES(size),
+			checksum = VALUES(checksum),
+			created_at = VALUES(created_at)`
+
+	if variant.ID == "" {
+		variant.ID = uuid.New().String()
+	}
+	variant.CreatedAt = time.Now()
+
+	_, err := r.db.ExecContext(ctx, query,
+		variant.ID,
+		variant.MediaID,
+		variant.Name,
+		variant.Format,
+		variant.StorageKey,
+		variant.Width,
+		variant.Height,
+		variant.Size,
+		variant.Checksum,
+		variant.CreatedAt,
+	)
+	return err
+}
+
+func (r *mediaRepository) ListVariants(ctx context.Context, mediaID string) ([]*entity.MediaVariant, error) {
+	query := `SELECT id, media_id, name, format, storage_key, width, height, size, checksum, created_at
+		FROM media_variants
+		WHERE media_id = ?
+		ORDER BY format, width`
+
+	rows, err := r.db.QueryContext(ctx, query, mediaID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var variants []*entity.MediaVariant
+	for rows.Next() {
+		variant, err := scanMediaVariant(rows)
+		if err != nil {
+			return nil, err
+		}
+		variants = append(variants, variant)
+	}
+
+	return variants, rows.Err()
+}
+
+func (r *mediaRepository) GetVariantByStorageKey(ctx context.Context, key string) (*entity.MediaVariant, error) {
+	query := `SELECT id, media_id, name, format, storage_key, width, height, size, checksum, created_at
+		FROM media_variants
+		WHERE storage_key = ?`
+
+	variant, err := scanMediaVariant(r.db.QueryRowContext(ctx, query, key))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return variant, nil
+}
+
+func scanMediaVariant(row rowScanner) (*entity.MediaVariant, error) {
+	variant := &entity.MediaVariant{}
+	err := row.Scan(
+		&variant.ID,
+		&variant.MediaID,
+		&variant.Name,
+		&variant.Format,
+		&variant.StorageKey,
+		&variant.Width,
+		&variant.Height,
+		&variant.Size,
+		&variant.Checksum,
+		&variant.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return variant, nil
+}
+
+// mediaVariantsColumn aggregates the variants of the media referenced by mediaIDColumn into a JSON array,
+// NULL when there are none, for scanning into entity.MediaVariants
+func mediaVariantsColumn(mediaIDColumn string) string {
+	return `(SELECT JSON_ARRAYAGG(JSON_OBJECT('name', v.name, 'format', v.format, 'storage_key', v.storage_key,
+			'width', v.width, 'height', v.height, 'size', v.size))
+		FROM media_variants v WHERE v.media_id = ` + mediaIDColumn + `)`
+}
+
+func (r *mediaRepository) getOne(ctx context.Context, where string, args ...any) (*entity.Media, error) {
+	query := `SELECT id, kind, storage_key, filename, content_type, size, checksum, uploaded_by, created_at
+		FROM media ` + where
+
+	media := &entity.Media{}
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(
+		&media.ID,
+		&media.Kind,
+		&media.StorageKey,
+		&media.Filename,
+		&media.ContentType,
+		&media.Size,
+		&media.Checksum,
+		&media.UploadedBy,
+		&media.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return media, nil
+}
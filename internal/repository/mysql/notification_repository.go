@@ -0,0 +1,81 @@
+package mysql
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+type notificationRepository struct {
+	db *sql.DB
+}
+
+func NewNotificationRepository(db *sql.DB) repository.NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+// CreateForWishlisters fans the event out in a single INSERT ... SELECT so large wishlists
+// do not need a round trip per user
+func (r *notificationRepository) CreateForWishlisters(ctx context.Context, ebookID string, eventType entity.NotificationType, payload json.RawMessage) (int64, error) {
+	query := `INSERT INTO notification_events (id, user_id, type, ebook_id, payload, created_at)
+		SELECT UUID(), w.user_id, ?, w.ebook_id, ?, ?
+		FROM wishlists w
+		WHERE w.ebook_id = ?`
+
+	// The payload is sent as a string; MySQL rejects JSON values sent with the binary charset
+	result, err := r.db.ExecContext(ctx, query, eventType, string(payload), time.Now(), ebookID)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (r *notificationRepository) ListByUser(ctx context.Context, userID string, limit, offset int) ([]*entity.NotificationEvent, error) {
+	query := `SELECT id, user_id, type, ebook_id, payload, created_at
+		FROM notification_events
+		WHERE user_id = ?
+		ORDER BY created_at DESC, id DESC
+		LIMIT ? OFFSET ?`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*entity.NotificationEvent
+	for rows.Next() {
+		event := &entity.NotificationEvent{}
+		var payload []byte
+		err = rows.Scan(
+			&event.ID,
+			&event.UserID,
+			&event.Type,
+			&event.EbookID,
+			&payload,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		event.Payload = json.RawMessage(payload)
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (r *notificationRepository) CountByUser(ctx context.Context, userID string) (int64, error) {
+	query := `SELECT COUNT(*) FROM notification_events WHERE user_id = ?`
+	var count int64
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&count)
+	return count, err
+}
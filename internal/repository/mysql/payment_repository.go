@@ -17,8 +17,8 @@ func NewPaymentRepository(db *sql.DB) repository.PaymentRepository {
 }
 
 func (r *paymentRepository) Create(ctx context.Context, payment *entity.Payment) error {
-	query := `INSERT INTO payments (id, user_id, amount, currency, status, xendit_reference, description, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	query := `INSERT INTO payments (id, user_id, ebook_id, amount, currency, status, xendit_reference, description, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	now := time.Now()
 	payment.CreatedAt = now
@@ -27,6 +27,7 @@ func (r *paymentRepository) Create(ctx context.Context, payment *entity.Payment)
 	_, err := r.db.ExecContext(ctx, query,
 		payment.ID,
 		payment.UserID,
+		payment.EbookID,
 		payment.Amount,
 		payment.Currency,
 		payment.Status,
@@ -39,13 +40,14 @@ func (r *paymentRepository) Create(ctx context.Context, payment *entity.Payment)
 }
 
 func (r *paymentRepository) GetByID(ctx context.Context, id string) (*entity.Payment, error) {
-	query := `SELECT id, user_id, amount, currency, status, xendit_reference, description, created_at, updated_at
+	query := `SELECT id, user_id, ebook_id, amount, currency, status, xendit_reference, description, created_at, updated_at
 		FROM payments WHERE id = ?`
 
 	payment := &entity.Payment{}
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
 		&payment.ID,
 		&payment.UserID,
+		&payment.EbookID,
 		&payment.Amount,
 		&payment.Currency,
 		&payment.Status,
@@ -64,13 +66,14 @@ func (r *paymentRepository) GetByID(ctx context.Context, id string) (*entity.Pay
 }
 
 func (r *paymentRepository) GetByXenditReference(ctx context.Context, ref string) (*entity.Payment, error) {
-	query := `SELECT id, user_id, amount, currency, status, xendit_reference, description, created_at, updated_at
+	query := `SELECT id, user_id, ebook_id, amount, currency, status, xendit_reference, description, created_at, updated_at
 		FROM payments WHERE xendit_reference = ?`
 
 	payment := &entity.Payment{}
 	err := r.db.QueryRowContext(ctx, query, ref).Scan(
 		&payment.ID,
 		&payment.UserID,
+		&payment.EbookID,
 		&payment.Amount,
 		&payment.Currency,
 		&payment.Status,
@@ -90,13 +93,14 @@ func (r *paymentRepository) GetByXenditReference(ctx context.Context, ref string
 
 func (r *paymentRepository) Update(ctx context.Context, payment *entity.Payment) error {
 	query := `UPDATE payments
-		SET user_id = ?, amount = ?, currency = ?, status = ?, xendit_reference = ?, description = ?, updated_at = ?
+		SET user_id = ?, ebook_id = ?, amount = ?, currency = ?, status = ?, xendit_reference = ?, description = ?, updated_at = ?
 		WHERE id = ?`
 
 	payment.UpdatedAt = time.Now()
 
 	_, err := r.db.ExecContext(ctx, query,
 		payment.UserID,
+		payment.EbookID,
 		payment.Amount,
 		payment.Currency,
 		payment.Status,
@@ -108,11 +112,62 @@ func (r *paymentRepository) Update(ctx context.Context, payment *entity.Payment)
 	return err
 }
 
-func (r *paymentRepository) ListByUserID(ctx context.Context, userID string) ([]*entity.Payment, error) {
-	query := `SELECT id, user_id, amount, currency, status, xendit_reference, description, created_at, updated_at
+func (r *paymentRepository) ListByUserID(ctx context.Context, userID string, limit, offset int) ([]*entity.Payment, error) {
+	query := `SELECT id, user_id, ebook_id, amount, currency, status, xendit_reference, description, created_at, updated_at
+		FROM payments WHERE user_id = ? ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?`
+
+	return r.queryPayments(ctx, query, userID, limit, offset)
+}
+
+// ListByUserIDCursor lists a user's payments after the cursor, keyed on (created_at, id) newest first.
+// Rows are returned nearest to the cursor first, so backward pages come back in reverse order.
+func (r *paymentRepository) ListByUserIDCursor(ctx context.Context, userID string, cursor *entity.PageCursor, limit int) ([]*entity.Payment, error) {
+	value, err := keysetTimeValue(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	order, condition, keysetArgs := keysetPage("created_at", "id", true, cursor, value)
+	query := `SELECT id, user_id, ebook_id, amount, currency, status, xendit_reference, description, created_at, updated_at
 		FROM payments WHERE user_id = ?`
+	args := []any{userID}
+	if condition != "" {
+		query += ` AND ` + condition
+		args = append(args, keysetArgs...)
+	}
+	query += ` ORDER BY ` + order + ` LIMIT ?`
+	args = append(args, limit)
+
+	return r.queryPayments(ctx, query, args...)
+}
+
+func (r *paymentRepository) CountByUserID(ctx context.Context, userID string) (int64, error) {
+	query := `SELECT COUNT(*) FROM payments WHERE user_id = ?`
+
+	var count int64
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// HasPaidForEbook reports whether the user has a paid payment for the ebook
+func (r *paymentRepository) HasPaidForEbook(ctx context.Context, userID, ebookID string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM payments WHERE user_id = ? AND ebook_id = ? AND status = ?)`
+
+	var paid bool
+	err := r.db.QueryRowContext(ctx, query, userID, ebookID, entity.PaymentStatusPaid).Scan(&paid)
+	if err != nil {
+		return false, err
+	}
+
+	return paid, nil
+}
 
-	rows, err := r.db.QueryContext(ctx, query, userID)
+func (r *paymentRepository) queryPayments(ctx context.Context, query string, args ...any) ([]*entity.Payment, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -124,6 +179,7 @@ func (r *paymentRepository) ListByUserID(ctx context.Context, userID string) ([]
 		err = rows.Scan(
 			&payment.ID,
 			&payment.UserID,
+			&payment.EbookID,
 			&payment.Amount,
 			&payment.Currency,
 			&payment.Status,
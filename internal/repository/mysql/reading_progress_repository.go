@@ -0,0 +1,125 @@
+package mysql
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"context"
+	"database/sql"
+	"time"
+)
+
+type readingProgressRepository struct {
+	db *sql.DB
+}
+
+func NewReadingProgressRepository(db *sql.DB) repository.ReadingProgressRepository {
+	return &readingProgressRepository{db: db}
+}
+
+func (r *readingProgressRepository) Upsert(ctx context.Context, progress *entity.ReadingProgress) error {
+	// Last writer wins on the client timestamp. client_updated_at is assigned last
+	// because MySQL evaluates the assignments left to right.
+	query := `INSERT INTO reading_progress (user_id, ebook_id, page, cfi, percentage, client_updated_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			page = IF(VALUES(client_updated_at) >= client_updated_at, VALUES(page), page),
+			cfi = IF(VALUES(client_updated_at) >= client_updated_at, VALUES(cfi), cfi),
+			percentage = IF(VALUES(client_updated_at) >= client_updated_at, VALUES(percentage), percentage),
+			updated_at = IF(VALUES(client_updated_at) >= client_updated_at, VALUES(updated_at), updated_at),
+			client_updated_at = GREATEST(client_updated_at, VALUES(client_updated_at))`
+
+	now := time.Now()
+	progress.UpdatedAt = now
+
+	_, err := r.db.ExecContext(ctx, query,
+		progress.UserID,
+		progress.EbookID,
+		progress.Page,
+		progress.CFI,
+		progress.Percentage,
+		progress.ClientUpdatedAt.UTC(),
+		now,
+		now,
+	)
+	return err
+}
+
+func (r *readingProgressRepository) Get(ctx context.Context, userID, ebookID string) (*entity.ReadingProgress, error) {
+	query := `SELECT user_id, ebook_id, page, cfi, percentage, client_updated_at, updated_at
+		FROM reading_progress WHERE user_id = ? AND ebook_id = ?`
+
+	progress := &entity.ReadingProgress{}
+	err := r.db.QueryRowContext(ctx, query, userID, ebookID).Scan(
+		&progress.UserID,
+		&progress.EbookID,
+		&progress.Page,
+		&progress.CFI,
+		&progress.Percentage,
+		&progress.ClientUpdatedAt,
+		&progress.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return progress, nil
+}
+
+func (r *readingProgressRepository) ListInProgressByUserID(ctx context.Context, userID string, limit, offset int) ([]*entity.ReadingProgressItem, error) {
+	query := `SELECT rp.user_id, rp.ebook_id, rp.page, rp.cfi, rp.percentage, rp.client_updated_at, rp.updated_at,
+			e.title, e.slug, e.cover_image, (SELECT m.storage_key FROM media m WHERE m.id = e.cover_media_id) AS cover_media_key
+		FROM reading_progress rp
+		JOIN ebooks e ON e.id = rp.ebook_id
+		WHERE rp.user_id = ? AND rp.percentage < 100
+		ORDER BY rp.client_updated_at DESC
+		LIMIT ? OFFSET ?`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []*entity.ReadingProgressItem
+	for rows.Next() {
+		item := &entity.ReadingProgressItem{}
+		err = rows.Scan(
+			&item.UserID,
+			&item.EbookID,
+			&item.Page,
+			&item.CFI,
+			&item.Percentage,
+			&item.ClientUpdatedAt,
+			&item.UpdatedAt,
+			&item.Title,
+			&item.Slug,
+			&item.CoverImage,
+			&item.CoverMediaKey,
+		)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (r *readingProgressRepository) CountInProgressByUserID(ctx context.Context, userID string) (int64, error) {
+	query := `SELECT COUNT(*) FROM reading_progress WHERE user_id = ? AND percentage < 100`
+
+	var count int64
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
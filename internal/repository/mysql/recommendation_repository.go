@@ -0,0 +1,182 @@
+package mysql
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+)
+
+type recommendationRepository struct {
+	db     *sql.DB
+	ebooks *ebookRepository
+}
+
+func NewRecommendationRepository(db *sql.DB) repository.RecommendationRepository {
+	return &recommendationRepository{
+		db:     db,
+		ebooks: &ebookRepository{db: db},
+	}
+}
+
+func (r *recommendationRepository) ListCandidates(ctx context.Context) ([]*entity.RecommendationCandidate, error) {
+	query := `SELECT e.id, e.author_id, e.category_id, e.popularity_score
+		FROM ebooks e
+		LEFT JOIN content_statuses cs ON cs.id = e.content_status_id
+		WHERE e.published_at IS NOT NULL AND e.published_at <= ? AND cs.name = "published"`
+
+	rows, err := r.db.QueryContext(ctx, query, time.Now().Format("2006-01-02 15:04:05"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var candidates []*entity.RecommendationCandidate
+	for rows.Next() {
+		candidate := &entity.RecommendationCandidate{}
+		if err := rows.Scan(&candidate.EbookID, &candidate.AuthorID, &candidate.CategoryID, &candidate.PopularityScore); err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return candidates, nil
+}
+
+func (r *recommendationRepository) ListCategoryParents(ctx context.Context) (map[string]string, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id, COALESCE(parent_id, '') FROM categories`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	parents := make(map[string]string)
+	for rows.Next() {
+		var id, parentID string
+		if err := rows.Scan(&id, &parentID); err != nil {
+			return nil, err
+		}
+		parents[id] = parentID
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return parents, nil
+}
+
+func (r *recommendationRepository) ListCoPurchases(ctx context.Context) ([]*entity.EbookPairCount, error) {
+	query := `SELECT a.ebook_id, b.ebook_id, COUNT(DISTINCT a.user_id)
+		FROM payments a
+		INNER JOIN payments b ON b.user_id = a.user_id AND b.ebook_id > a.ebook_id
+		WHERE a.status = ? AND b.status = ?
+		GROUP BY a.ebook_id, b.ebook_id`
+
+	return r.queryPairCounts(ctx, query, entity.PaymentStatusPaid, entity.PaymentStatusPaid)
+}
+
+func (r *recommendationRepository) ListCoReads(ctx context.Context) ([]*entity.EbookPairCount, error) {
+	query := `SELECT a.ebook_id, b.ebook_id, COUNT(*)
+		FROM reading_progress a
+		INNER JOIN reading_progress b ON b.user_id = a.user_id AND b.ebook_id > a.ebook_id
+		GROUP BY a.ebook_id, b.ebook_id`
+
+	return r.queryPairCounts(ctx, query)
+}
+
+func (r *recommendationRepository) ListActiveUserEbooks(ctx context.Context, since time.Time) ([]*entity.UserEbook, error) {
+	query := `SELECT owned.user_id, owned.ebook_id FROM (
+			SELECT user_id, ebook_id FROM payments WHERE status = ? AND ebook_id IS NOT NULL
+			UNION
+			SELECT user_id, ebook_id FROM reading_progress
+		) owned
+		WHERE owned.user_id IN (
+			SELECT user_id FROM payments WHERE created_at >= ?
+			UNION
+			SELECT user_id FROM reading_progress WHERE client_updated_at >= ?
+		)
+		ORDER BY owned.user_id`
+
+	rows, err := r.db.QueryContext(ctx, query, entity.PaymentStatusPaid, since, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var userEbooks []*entity.UserEbook
+	for rows.Next() {
+		userEbook := &entity.UserEbook{}
+		if err := rows.Scan(&userEbook.UserID, &userEbook.EbookID); err != nil {
+			return nil, err
+		}
+		userEbooks = append(userEbooks, userEbook)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return userEbooks, nil
+}
+
+func (r *recommendationRepository) ListPublishedByIDs(ctx context.Context, ids []string) ([]*entity.EbookList, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	from, args := buildEbookCatalogQuery(nil, entity.TranslationLocale(ctx), time.Now())
+	from += ` AND e.id IN (?` + strings.Repeat(`, ?`, len(ids)-1) + `)`
+	for _, id := range ids {
+		args = append(args, id)
+	}
+
+	ebooks, err := r.ebooks.queryEbookList(ctx, ebookCatalogSelect+from, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Restore the ranking of ids; ebooks that are no longer published are left out
+	byID := make(map[string]*entity.EbookList, len(ebooks))
+	for _, ebook := range ebooks {
+		byID[ebook.ID] = ebook
+	}
+	ordered := make([]*entity.EbookList, 0, len(ebooks))
+	for _, id := range ids {
+		if ebook, ok := byID[id]; ok {
+			ordered = append(ordered, ebook)
+			delete(byID, id)
+		}
+	}
+
+	return ordered, nil
+}
+
+func (r *recommendationRepository) queryPairCounts(ctx context.Context, query string, args ...any) ([]*entity.EbookPairCount, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pairs []*entity.EbookPairCount
+	for rows.Next() {
+		pair := &entity.EbookPairCount{}
+		if err := rows.Scan(&pair.EbookID, &pair.OtherEbookID, &pair.Users); err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, pair)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return pairs, nil
+}
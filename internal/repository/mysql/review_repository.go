@@ -0,0 +1,257 @@
+package mysql
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"context"
+	"database/sql"
+	"time"
+)
+
+const reviewSelect = `SELECT r.id, r.user_id, r.ebook_id, r.rating, r.body, r.status, r.report_count, r.hidden_at, r.hidden_by,
+		r.created_at, r.updated_at, COALESCE(u.name, ''), u.avatar
+		FROM reviews r
+		LEFT JOIN users u ON u.id = r.user_id`
+
+// ratingAggregateUpdate shifts an ebook's rating aggregate by a count and sum delta.
+// MySQL applies single-table assignments left to right, so the average sees the new count and sum.
+const ratingAggregateUpdate = `UPDATE ebooks
+		SET rating_count = rating_count + ?, rating_sum = rating_sum + ?,
+			rating_average = IF(rating_count > 0, rating_sum / rating_count, 0)
+		WHERE id = ?`
+
+type reviewRepository struct {
+	db *sql.DB
+}
+
+func NewReviewRepository(db *sql.DB) repository.ReviewRepository {
+	return &reviewRepository{db: db}
+}
+
+func (r *reviewRepository) Create(ctx context.Context, review *entity.Review) error {
+	now := time.Now()
+	review.CreatedAt = now
+	review.UpdatedAt = now
+	review.Status = entity.ReviewVisible
+
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		query := `INSERT INTO reviews (id, user_id, ebook_id, rating, body, status, created_at, updated_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+		_, err := tx.ExecContext(ctx, query,
+			review.ID,
+			review.UserID,
+			review.EbookID,
+			review.Rating,
+			review.Body,
+			review.Status,
+			review.CreatedAt,
+			review.UpdatedAt,
+		)
+		if err != nil {
+			// uk_reviews_user_ebook: the user reviewed the ebook in a concurrent request
+			return translateDuplicateKey(err)
+		}
+
+		_, err = tx.ExecContext(ctx, ratingAggregateUpdate, 1, review.Rating, review.EbookID)
+		return err
+	})
+}
+
+func (r *reviewRepository) GetByID(ctx context.Context, id string) (*entity.Review, error) {
+	query := reviewSelect + ` WHERE r.id = ?`
+
+	review, err := scanReview(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return review, nil
+}
+
+func (r *reviewRepository) GetByUserAndEbook(ctx context.Context, userID, ebookID string) (*entity.Review, error) {
+	query := reviewSelect + ` WHERE r.user_id = ? AND r.ebook_id = ?`
+
+	review, err := scanReview(r.db.QueryRowContext(ctx, query, userID, ebookID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return review, nil
+}
+
+func (r *reviewRepository) Update(ctx context.Context, review *entity.Review) error {
+	review.UpdatedAt = time.Now()
+
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		// Lock the row so concurrent edits apply their deltas against the rating they replace
+		var previousRating int
+		var status entity.ReviewStatus
+		err := tx.QueryRowContext(ctx, `SELECT rating, status FROM reviews WHERE id = ? FOR UPDATE`, review.ID).
+			Scan(&previousRating, &status)
+		if err != nil {
+			return err
+		}
+
+		query := `UPDATE reviews SET rating = ?, body = ?, updated_at = ? WHERE id = ?`
+		_, err = tx.ExecContext(ctx, query, review.Rating, review.Body, review.UpdatedAt, review.ID)
+		if err != nil {
+			return err
+		}
+
+		if status != entity.ReviewVisible || previousRating == review.Rating {
+			return nil
+		}
+		_, err = tx.ExecContext(ctx, ratingAggregateUpdate, 0, review.Rating-previousRating, review.EbookID)
+		return err
+	})
+}
+
+func (r *reviewRepository) SetStatus(ctx context.Context, id string, status entity.ReviewStatus, moderatorID string) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		var ebookID string
+		var rating int
+		var current entity.ReviewStatus
+		err := tx.QueryRowContext(ctx, `SELECT ebook_id, rating, status FROM reviews WHERE id = ? FOR UPDATE`, id).
+			Scan(&ebookID, &rating, &current)
+		if err != nil {
+			return err
+		}
+		if current == status {
+			return nil
+		}
+
+		now := time.Now()
+		if status == entity.ReviewHidden {
+			_, err = tx.ExecContext(ctx, `UPDATE reviews SET status = ?, hidden_at = ?, hidden_by = ?, updated_at = ? WHERE id = ?`,
+				status, now, moderatorID, now, id)
+			if err != nil {
+				return err
+			}
+			_, err = tx.ExecContext(ctx, ratingAggregateUpdate, -1, -rating, ebookID)
+			return err
+		}
+
+		// Showing a review again also clears its reports so it leaves the moderation queue
+		_, err = tx.ExecContext(ctx, `UPDATE reviews SET status = ?, hidden_at = NULL, hidden_by = NULL, report_count = 0, updated_at = ? WHERE id = ?`,
+			status, now, id)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `DELETE FROM review_reports WHERE review_id = ?`, id)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, ratingAggregateUpdate, 1, rating, ebookID)
+		return err
+	})
+}
+
+func (r *reviewRepository) Report(ctx context.Context, report *entity.ReviewReport) (bool, error) {
+	report.CreatedAt = time.Now()
+
+	created := false
+	err := withTx(ctx, r.db, func(tx *sql.Tx) error {
+		query := `INSERT IGNORE INTO review_reports (id, review_id, user_id, reason, created_at) VALUES (?, ?, ?, ?, ?)`
+		result, err := tx.ExecContext(ctx, query, report.ID, report.ReviewID, report.UserID, report.Reason, report.CreatedAt)
+		if err != nil {
+			return err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil || affected == 0 {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `UPDATE reviews SET report_count = report_count + 1, updated_at = updated_at WHERE id = ?`, report.ReviewID)
+		created = err == nil
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return created, nil
+}
+
+func (r *reviewRepository) ListVisibleByEbook(ctx context.Context, ebookID string, limit, offset int) ([]*entity.Review, error) {
+	query := reviewSelect + ` WHERE r.ebook_id = ? AND r.status = ?
+		ORDER BY r.created_at DESC, r.id DESC LIMIT ? OFFSET ?`
+
+	return r.queryReviews(ctx, query, ebookID, entity.ReviewVisible, limit, offset)
+}
+
+func (r *reviewRepository) CountVisibleByEbook(ctx context.Context, ebookID string) (int64, error) {
+	query := `SELECT COUNT(*) FROM reviews WHERE ebook_id = ? AND status = ?`
+
+	var count int64
+	err := r.db.QueryRowContext(ctx, query, ebookID, entity.ReviewVisible).Scan(&count)
+	return count, err
+}
+
+// ListReported returns the moderation queue: visible reviews with reports, most reported first
+func (r *reviewRepository) ListReported(ctx context.Context, limit, offset int) ([]*entity.Review, error) {
+	query := reviewSelect + ` WHERE r.report_count > 0 AND r.status = ?
+		ORDER BY r.report_count DESC, r.updated_at DESC, r.id DESC LIMIT ? OFFSET ?`
+
+	return r.queryReviews(ctx, query, entity.ReviewVisible, limit, offset)
+}
+
+func (r *reviewRepository) CountReported(ctx context.Context) (int64, error) {
+	query := `SELECT COUNT(*) FROM reviews WHERE report_count > 0 AND status = ?`
+
+	var count int64
+	err := r.db.QueryRowContext(ctx, query, entity.ReviewVisible).Scan(&count)
+	return count, err
+}
+
+func (r *reviewRepository) queryReviews(ctx context.Context, query string, args ...any) ([]*entity.Review, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reviews []*entity.Review
+	for rows.Next() {
+		review, err := scanReview(rows)
+		if err != nil {
+			return nil, err
+		}
+		reviews = append(reviews, review)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return reviews, nil
+}
+
+func scanReview(row rowScanner) (*entity.Review, error) {
+	review := &entity.Review{}
+	err := row.Scan(
+		&review.ID,
+		&review.UserID,
+		&review.EbookID,
+		&review.Rating,
+		&review.Body,
+		&review.Status,
+		&review.ReportCount,
+		&review.HiddenAt,
+		&review.HiddenBy,
+		&review.CreatedAt,
+		&review.UpdatedAt,
+		&review.ReviewerName,
+		&review.ReviewerAvatar,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return review, nil
+}
@@ -17,8 +17,8 @@ func NewSeoMetaRepository(db *sql.DB) repository.SeoMetaRepository {
 }
 
 func (r *seoMetaRepository) Create(ctx context.Context, seoMeta *entity.SeoMeta) error {
-	query := `INSERT INTO seo_metadatas (id, title, description, keywords, entity, entity_id, created_at, updated_at) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+	query := `INSERT INTO seo_metadatas (id, title, description, keywords, noindex, entity, entity_id, created_at, updated_at) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	now := time.Now()
 	seoMeta.CreatedAt = now
@@ -29,6 +29,7 @@ func (r *seoMetaRepository) Create(ctx context.Context, seoMeta *entity.SeoMeta)
 		seoMeta.Title,
 		seoMeta.Description,
 		seoMeta.Keywords,
+		seoMeta.NoIndex,
 		seoMeta.Entity,
 		seoMeta.EntityID,
 		seoMeta.CreatedAt,
@@ -38,7 +39,7 @@ func (r *seoMetaRepository) Create(ctx context.Context, seoMeta *entity.SeoMeta)
 }
 
 func (r *seoMetaRepository) GetByID(ctx context.Context, id string) (*entity.SeoMeta, error) {
-	query := `SELECT id, title, description, keywords, entity, entity_id, created_at, updated_at 
+	query := `SELECT id, title, description, keywords, noindex, entity, entity_id, created_at, updated_at 
 		FROM seo_metadatas WHERE id = ?`
 
 	seoMeta := &entity.SeoMeta{}
@@ -47,6 +48,7 @@ func (r *seoMetaRepository) GetByID(ctx context.Context, id string) (*entity.Seo
 		&seoMeta.Title,
 		&seoMeta.Description,
 		&seoMeta.Keywords,
+		&seoMeta.NoIndex,
 		&seoMeta.Entity,
 		&seoMeta.EntityID,
 		&seoMeta.CreatedAt,
@@ -63,7 +65,7 @@ func (r *seoMetaRepository) GetByID(ctx context.Context, id string) (*entity.Seo
 }
 
 func (r *seoMetaRepository) GetByEntity(ctx context.Context, entityType entity.SeoEntityType, entityID string) (*entity.SeoMeta, error) {
-	query := `SELECT id, title, description, keywords, entity, entity_id, created_at, updated_at 
+	query := `SELECT id, title, description, keywords, noindex, entity, entity_id, created_at, updated_at 
 		FROM seo_metadatas WHERE entity = ? AND entity_id = ?`
 
 	seoMeta := &entity.SeoMeta{}
@@ -72,6 +74,7 @@ func (r *seoMetaRepository) GetByEntity(ctx context.Context, entityType entity.S
 		&seoMeta.Title,
 		&seoMeta.Description,
 		&seoMeta.Keywords,
+		&seoMeta.NoIndex,
 		&seoMeta.Entity,
 		&seoMeta.EntityID,
 		&seoMeta.CreatedAt,
@@ -89,7 +92,7 @@ func (r *seoMetaRepository) GetByEntity(ctx context.Context, entityType entity.S
 
 func (r *seoMetaRepository) Update(ctx context.Context, seoMeta *entity.SeoMeta) error {
 	query := `UPDATE seo_metadatas 
-		SET title = ?, description = ?, keywords = ?, entity = ?, entity_id = ?, updated_at = ?
+		SET title = ?, description = ?, keywords = ?, noindex = ?, entity = ?, entity_id = ?, updated_at = ?
 		WHERE id = ?`
 
 	seoMeta.UpdatedAt = time.Now()
@@ -98,6 +101,7 @@ func (r *seoMetaRepository) Update(ctx context.Context, seoMeta *entity.SeoMeta)
 		seoMeta.Title,
 		seoMeta.Description,
 		seoMeta.Keywords,
+		seoMeta.NoIndex,
 		seoMeta.Entity,
 		seoMeta.EntityID,
 		seoMeta.UpdatedAt,
@@ -113,7 +117,7 @@ func (r *seoMetaRepository) Delete(ctx context.Context, id string) error {
 }
 
 func (r *seoMetaRepository) ListByEntity(ctx context.Context, entityType entity.SeoEntityType, limit, offset int) ([]*entity.SeoMeta, error) {
-	query := `SELECT id, title, description, keywords, entity, entity_id, created_at, updated_at 
+	query := `SELECT id, title, description, keywords, noindex, entity, entity_id, created_at, updated_at 
 		FROM seo_metadatas WHERE entity = ? ORDER BY created_at DESC LIMIT ? OFFSET ?`
 
 	rows, err := r.db.QueryContext(ctx, query, entityType, limit, offset)
@@ -130,6 +134,7 @@ func (r *seoMetaRepository) ListByEntity(ctx context.Context, entityType entity.
 			&seoMeta.Title,
 			&seoMeta.Description,
 			&seoMeta.Keywords,
+			&seoMeta.NoIndex,
 			&seoMeta.Entity,
 			&seoMeta.EntityID,
 			&seoMeta.CreatedAt,
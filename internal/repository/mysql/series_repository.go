@@ -0,0 +1,259 @@
+package mysql
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"context"
+	"database/sql"
+	"time"
+)
+
+type seriesRepository struct {
+	db *sql.DB
+}
+
+func NewSeriesRepository(db *sql.DB) repository.SeriesRepository {
+	return &seriesRepository{db: db}
+}
+
+// seriesColumns selects a series with the number of its published volumes; it takes the current time
+const seriesColumns = `s.id, s.name, s.slug, s.description, s.cover_image, s.cover_media_id, s.created_at, s.updated_at,
+		(SELECT COUNT(*) FROM series_ebooks sv JOIN ebooks e ON e.id = sv.ebook_id
+			WHERE sv.series_id = s.id AND ` + publishedContentCondition + `) AS volume_count,
+		(SELECT m.storage_key FROM media m WHERE m.id = s.cover_media_id) AS cover_media_key`
+
+func scanSeries(row interface{ Scan(dest ...any) error }) (*entity.Series, error) {
+	series := &entity.Series{}
+	err := row.Scan(
+		&series.ID,
+		&series.Name,
+		&series.Slug,
+		&series.Description,
+		&series.CoverImage,
+		&series.CoverMediaID,
+		&series.CreatedAt,
+		&series.UpdatedAt,
+		&series.VolumeCount,
+		&series.CoverMediaKey,
+	)
+	return series, err
+}
+
+func (r *seriesRepository) Create(ctx context.Context, series *entity.Series) error {
+	query := `INSERT INTO series (id, name, slug, description, cover_image, cover_media_id, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+
+	now := time.Now()
+	series.CreatedAt = now
+	series.UpdatedAt = now
+
+	_, err := r.db.ExecContext(ctx, query,
+		series.ID,
+		series.Name,
+		series.Slug,
+		series.Description,
+		series.CoverImage,
+		series.CoverMediaID,
+		series.CreatedAt,
+		series.UpdatedAt,
+	)
+	return err
+}
+
+func (r *seriesRepository) GetByID(ctx context.Context, id string) (*entity.Series, error) {
+	query := `SELECT ` + seriesColumns + ` FROM series s WHERE s.id = ?`
+
+	series, err := scanSeries(r.db.QueryRowContext(ctx, query, time.Now(), id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return series, nil
+}
+
+func (r *seriesRepository) GetBySlug(ctx context.Context, slug string) (*entity.Series, error) {
+	query := `SELECT ` + seriesColumns + ` FROM series s WHERE s.slug = ?`
+
+	series, err := scanSeries(r.db.QueryRowContext(ctx, query, time.Now(), slug))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return series, nil
+}
+
+func (r *seriesRepository) Update(ctx context.Context, series *entity.Series) error {
+	query := `UPDATE series
+		SET name = ?, slug = ?, description = ?, cover_image = ?, cover_media_id = ?, updated_at = ?
+		WHERE id = ?`
+
+	series.UpdatedAt = time.Now()
+
+	_, err := r.db.ExecContext(ctx, query,
+		series.Name,
+		series.Slug,
+		series.Description,
+		series.CoverImage,
+		series.CoverMediaID,
+		series.UpdatedAt,
+		series.ID,
+	)
+	return err
+}
+
+func (r *seriesRepository) Delete(ctx context.Context, id string) error {
+	query := `DELETE FROM series WHERE id = ?`
+	_, err := r.db.ExecContext(ctx, query, id)
+	return err
+}
+
+func (r *seriesRepository) ListPublished(ctx context.Context, limit, offset int) ([]*entity.Series, error) {
+	query := `SELECT ` + seriesColumns + ` FROM series s
+		HAVING volume_count > 0 ORDER BY s.name, s.id LIMIT ? OFFSET ?`
+
+	rows, err := r.db.QueryContext(ctx, query, time.Now(), limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []*entity.Series
+	for rows.Next() {
+		series, err := scanSeries(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, series)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (r *seriesRepository) CountPublished(ctx context.Context) (int64, error) {
+	query := `SELECT COUNT(DISTINCT sv.series_id) FROM series_ebooks sv JOIN ebooks e ON e.id = sv.ebook_id
+		WHERE ` + publishedContentCondition
+	var count int64
+	err := r.db.QueryRowContext(ctx, query, time.Now()).Scan(&count)
+	return count, err
+}
+
+// seriesVolumeSelect selects published volumes (series_ebooks sv joined with ebooks e) with the title
+// in the locale of the request; it takes the locale and then the current time
+var seriesVolumeSelect = `SELECT sv.series_id, sv.ebook_id, sv.volume,
+			` + translatedColumn(entity.TranslatableEbook, "e", "title") + `, e.slug, e.cover_image, e.price,
+			(SELECT m.storage_key FROM media m WHERE m.id = e.cover_media_id) AS cover_media_key
+		FROM series_ebooks sv
+		JOIN ebooks e ON e.id = sv.ebook_id
+		WHERE ` + publishedContentCondition
+
+func scanSeriesVolume(row interface{ Scan(dest ...any) error }) (*entity.SeriesVolume, error) {
+	volume := &entity.SeriesVolume{}
+	err := row.Scan(
+		&volume.SeriesID,
+		&volume.EbookID,
+		&volume.Volume,
+		&volume.Title,
+		&volume.Slug,
+		&volume.CoverImage,
+		&volume.Price,
+		&volume.CoverMediaKey,
+	)
+	return volume, err
+}
+
+func (r *seriesRepository) ListVolumes(ctx context.Context, seriesID string) ([]*entity.SeriesVolume, error) {
+	query := seriesVolumeSelect + ` AND sv.series_id = ? ORDER BY sv.volume`
+
+	rows, err := r.db.QueryContext(ctx, query, localeArgs(ctx, 1, time.Now(), seriesID)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var volumes []*entity.SeriesVolume
+	for rows.Next() {
+		volume, err := scanSeriesVolume(rows)
+		if err != nil {
+			return nil, err
+		}
+		volumes = append(volumes, volume)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return volumes, nil
+}
+
+func (r *seriesRepository) ReplaceVolumes(ctx context.Context, seriesID string, volumes []*entity.SeriesVolume) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM series_ebooks WHERE series_id = ?`, seriesID); err != nil {
+			return err
+		}
+
+		for _, volume := range volumes {
+			_, err := tx.ExecContext(ctx, `INSERT INTO series_ebooks (series_id, ebook_id, volume) VALUES (?, ?, ?)`,
+				seriesID, volume.EbookID, volume.Volume)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err := tx.ExecContext(ctx, `UPDATE series SET updated_at = ? WHERE id = ?`, time.Now(), seriesID)
+		return err
+	})
+}
+
+func (r *seriesRepository) GetMembership(ctx context.Context, ebookID string) (*entity.SeriesMembership, error) {
+	query := `SELECT s.id, s.name, s.slug, se.volume,
+			(SELECT COUNT(*) FROM series_ebooks sv JOIN ebooks e ON e.id = sv.ebook_id
+				WHERE sv.series_id = s.id AND ` + publishedContentCondition + `) AS volume_count
+		FROM series_ebooks se
+		JOIN series s ON s.id = se.series_id
+		WHERE se.ebook_id = ?`
+
+	membership := &entity.SeriesMembership{}
+	err := r.db.QueryRowContext(ctx, query, time.Now(), ebookID).Scan(
+		&membership.SeriesID,
+		&membership.SeriesName,
+		&membership.SeriesSlug,
+		&membership.Volume,
+		&membership.VolumeCount,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return membership, nil
+}
+
+func (r *seriesRepository) GetNextVolume(ctx context.Context, ebookID string) (*entity.SeriesVolume, error) {
+	query := seriesVolumeSelect + `
+			AND sv.series_id = (SELECT series_id FROM series_ebooks WHERE ebook_id = ?)
+			AND sv.volume > (SELECT volume FROM series_ebooks WHERE ebook_id = ?)
+		ORDER BY sv.volume LIMIT 1`
+
+	volume, err := scanSeriesVolume(r.db.QueryRowContext(ctx, query, localeArgs(ctx, 1, time.Now(), ebookID, ebookID)...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return volume, nil
+}
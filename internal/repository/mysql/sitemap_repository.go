@@ -0,0 +1,99 @@
+package mysql
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// sitemapSource is where the public pages of a kind are stored.
+// Published content needs a published status and a publication time that has passed; categories need to be active.
+type sitemapSource struct {
+	table     string
+	seoEntity entity.SeoEntityType
+	published bool
+}
+
+var sitemapSources = map[entity.SitemapContent]sitemapSource{
+	entity.SitemapEbook:       {table: "ebooks", seoEntity: entity.SeoEntityEbook, published: true},
+	entity.SitemapArticle:     {table: "articles", seoEntity: entity.SeoEntityArticle, published: true},
+	entity.SitemapInspiration: {table: "inspirations", seoEntity: entity.SeoEntityInspiration, published: true},
+	entity.SitemapCategory:    {table: "categories", seoEntity: entity.SeoEntityCategory},
+}
+
+// from returns the FROM and WHERE clauses selecting the public pages as x
+func (s sitemapSource) from(now time.Time) (string, []any) {
+	query := `FROM ` + s.table + ` x`
+	args := []any{}
+	if s.published {
+		query += `
+			INNER JOIN content_statuses cs ON cs.id = x.content_status_id
+			WHERE cs.name = "published" AND x.published_at IS NOT NULL AND x.published_at <= ?`
+		args = append(args, now.Format("2006-01-02 15:04:05"))
+	} else {
+		query += `
+			WHERE x.is_active = TRUE`
+	}
+	query += ` AND x.slug IS NOT NULL AND x.slug <> ''
+			AND NOT EXISTS (
+				SELECT 1 FROM seo_metadatas sm WHERE sm.entity = ? AND sm.entity_id = x.id AND sm.noindex = TRUE
+			)`
+	return query, append(args, s.seoEntity)
+}
+
+type sitemapRepository struct {
+	db *sql.DB
+}
+
+func NewSitemapRepository(db *sql.DB) repository.SitemapRepository {
+	return &sitemapRepository{db: db}
+}
+
+func (r *sitemapRepository) ListEntries(ctx context.Context, content entity.SitemapContent, now time.Time) ([]*entity.SitemapEntry, error) {
+	source, ok := sitemapSources[content]
+	if !ok {
+		return nil, fmt.Errorf("unknown sitemap content: %s", content)
+	}
+
+	from, args := source.from(now)
+	rows, err := r.db.QueryContext(ctx, `SELECT x.slug, x.updated_at `+from+` ORDER BY x.id`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*entity.SitemapEntry
+	for rows.Next() {
+		entry := &entity.SitemapEntry{}
+		if err := rows.Scan(&entry.Slug, &entry.UpdatedAt); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// Version combines the number of public pages of each kind with their latest update.
+// Removing a page, or a page becoming public or noindex, changes a count; editing one changes the latest update.
+func (r *sitemapRepository) Version(ctx context.Context, now time.Time) (string, error) {
+	columns := make([]string, 0, len(entity.SitemapContents))
+	args := []any{}
+	for _, content := range entity.SitemapContents {
+		from, sourceArgs := sitemapSources[content].from(now)
+		columns = append(columns, `(SELECT CONCAT(COUNT(*), ':', COALESCE(UNIX_TIMESTAMP(MAX(x.updated_at)), 0)) `+from+`)`)
+		args = append(args, sourceArgs...)
+	}
+
+	var version string
+	err := r.db.QueryRowContext(ctx, `SELECT CONCAT_WS(',', `+strings.Join(columns, `, `)+`)`, args...).Scan(&version)
+	return version, err
+}
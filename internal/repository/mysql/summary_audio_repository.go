@@ -0,0 +1,98 @@
+package mysql
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"context"
+	"database/sql"
+	"time"
+)
+
+type summaryAudioRepository struct {
+	db *sql.DB
+}
+
+func NewSummaryAudioRepository(db *sql.DB) repository.SummaryAudioRepository {
+	return &summaryAudioRepository{db: db}
+}
+
+func (r *summaryAudioRepository) GetAudio(ctx context.Context, summaryID string) (*entity.SummaryAudio, error) {
+	// Premium summaries have no uploaded audio, only their audio URL
+	query := `SELECT s.id, s.ebook_id, FALSE AS premium, s.audio_url, m.storage_key, m.content_type, s.updated_at
+		FROM ebook_summaries s
+		LEFT JOIN media m ON m.id = s.audio_media_id
+		WHERE s.id = ?
+		UNION ALL
+		SELECT p.id, p.ebook_id, TRUE AS premium, p.audio_url, NULL, NULL, p.updated_at
+		FROM ebook_premium_summaries p
+		WHERE p.id = ?
+		LIMIT 1`
+
+	audio := &entity.SummaryAudio{}
+	err := r.db.QueryRowContext(ctx, query, summaryID, summaryID).Scan(
+		&audio.SummaryID,
+		&audio.EbookID,
+		&audio.Premium,
+		&audio.AudioURL,
+		&audio.AudioMediaKey,
+		&audio.AudioContentType,
+		&audio.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return audio, nil
+}
+
+func (r *summaryAudioRepository) UpsertProgress(ctx context.Context, progress *entity.ListeningProgress) error {
+	// Last writer wins on the client timestamp. client_updated_at is assigned last
+	// because MySQL evaluates the assignments left to right.
+	query := `INSERT INTO summary_listening_progress (user_id, summary_id, position_seconds, duration_seconds, client_updated_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			position_seconds = IF(VALUES(client_updated_at) >= client_updated_at, VALUES(position_seconds), position_seconds),
+			duration_seconds = IF(VALUES(client_updated_at) >= client_updated_at, COALESCE(VALUES(duration_seconds), duration_seconds), duration_seconds),
+			updated_at = IF(VALUES(client_updated_at) >= client_updated_at, VALUES(updated_at), updated_at),
+			client_updated_at = GREATEST(client_updated_at, VALUES(client_updated_at))`
+
+	now := time.Now()
+	progress.UpdatedAt = now
+
+	_, err := r.db.ExecContext(ctx, query,
+		progress.UserID,
+		progress.SummaryID,
+		progress.PositionSeconds,
+		progress.DurationSeconds,
+		progress.ClientUpdatedAt.UTC(),
+		now,
+		now,
+	)
+	return err
+}
+
+func (r *summaryAudioRepository) GetProgress(ctx context.Context, userID, summaryID string) (*entity.ListeningProgress, error) {
+	query := `SELECT user_id, summary_id, position_seconds, duration_seconds, client_updated_at, updated_at
+		FROM summary_listening_progress WHERE user_id = ? AND summary_id = ?`
+
+	progress := &entity.ListeningProgress{}
+	err := r.db.QueryRowContext(ctx, query, userID, summaryID).Scan(
+		&progress.UserID,
+		&progress.SummaryID,
+		&progress.PositionSeconds,
+		&progress.DurationSeconds,
+		&progress.ClientUpdatedAt,
+		&progress.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return progress, nil
+}
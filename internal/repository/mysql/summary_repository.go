@@ -28,8 +28,8 @@ func (r *SummaryRepositoryImpl) CreateSummary(ctx context.Context, summary *enti
 	}
 
 	query := `
-		INSERT INTO ebook_summaries (id, ebook_id, description, url, audio_url, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO ebook_summaries (id, ebook_id, description, url, audio_url, audio_media_id, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	now := time.Now()
@@ -42,6 +42,7 @@ func (r *SummaryRepositoryImpl) CreateSummary(ctx context.Context, summary *enti
 		summary.Description,
 		summary.URL,
 		summary.AudioURL,
+		summary.AudioMediaID,
 		summary.CreatedAt,
 		summary.UpdatedAt,
 	)
@@ -51,7 +52,8 @@ func (r *SummaryRepositoryImpl) CreateSummary(ctx context.Context, summary *enti
 
 func (r *SummaryRepositoryImpl) GetSummaryByID(ctx context.Context, id string) (*entity.EbookSummary, error) {
 	query := `
-		SELECT id, ebook_id, description, url, audio_url, created_at, updated_at
+		SELECT id, ebook_id, description, url, audio_url, audio_media_id, created_at, updated_at,
+			(SELECT m.storage_key FROM media m WHERE m.id = ebook_summaries.audio_media_id) AS audio_media_key
 		FROM ebook_summaries
 		WHERE id = ?
 	`
@@ -65,8 +67,10 @@ func (r *SummaryRepositoryImpl) GetSummaryByID(ctx context.Context, id string) (
 		&summary.Description,
 		&summary.URL,
 		&summary.AudioURL,
+		&summary.AudioMediaID,
 		&summary.CreatedAt,
 		&summary.UpdatedAt,
+		&summary.AudioMediaKey,
 	)
 
 	if err != nil {
@@ -82,7 +86,7 @@ func (r *SummaryRepositoryImpl) GetSummaryByID(ctx context.Context, id string) (
 func (r *SummaryRepositoryImpl) UpdateSummary(ctx context.Context, summary *entity.EbookSummary) error {
 	query := `
 		UPDATE ebook_summaries
-		SET ebook_id = ?, description = ?, url = ?, audio_url = ?, updated_at = ?
+		SET ebook_id = ?, description = ?, url = ?, audio_url = ?, audio_media_id = ?, updated_at = ?
 		WHERE id = ?
 	`
 
@@ -93,6 +97,7 @@ func (r *SummaryRepositoryImpl) UpdateSummary(ctx context.Context, summary *enti
 		summary.Description,
 		summary.URL,
 		summary.AudioURL,
+		summary.AudioMediaID,
 		summary.UpdatedAt,
 		summary.ID,
 	)
@@ -145,10 +150,12 @@ func (r *SummaryRepositoryImpl) ListSummaries(ctx context.Context, limit, offset
 			es.audio_url,
 			e.duration,
 			es.created_at, 
-			es.updated_at
+			es.updated_at,
+			m.storage_key AS audio_media_key
 		FROM ebook_summaries es
 		JOIN ebooks e ON es.ebook_id = e.id
-		ORDER BY es.created_at DESC
+		LEFT JOIN media m ON m.id = es.audio_media_id
+		ORDER BY es.created_at DESC, es.id DESC
 		LIMIT ? OFFSET ?
 	`
 
@@ -172,6 +179,7 @@ func (r *SummaryRepositoryImpl) ListSummaries(ctx context.Context, limit, offset
 			&summary.Duration,
 			&summary.CreatedAt,
 			&summary.UpdatedAt,
+			&summary.AudioMediaKey,
 		)
 		if err != nil {
 			return nil, err
@@ -182,9 +190,80 @@ func (r *SummaryRepositoryImpl) ListSummaries(ctx context.Context, limit, offset
 	return summaries, nil
 }
 
+// ListSummariesByCursor lists summaries after the cursor, keyed on (created_at, id) newest first.
+// Rows are returned nearest to the cursor first, so backward pages come back in reverse order.
+func (r *SummaryRepositoryImpl) ListSummariesByCursor(ctx context.Context, cursor *entity.PageCursor, limit int) ([]*entity.EbookSummaryList, error) {
+	value, err := keysetTimeValue(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	order, condition, args := keysetPage("es.created_at", "es.id", true, cursor, value)
+	where := ""
+	if condition != "" {
+		where = "WHERE " + condition
+	}
+	query := `
+		SELECT 
+			es.id, 
+			es.ebook_id, 
+			e.title as ebook_title,
+			e.slug,
+			es.description, 
+			es.url, 
+			es.audio_url,
+			e.duration,
+			es.created_at, 
+			es.updated_at,
+			m.storage_key AS audio_media_key
+		FROM ebook_summaries es
+		JOIN ebooks e ON es.ebook_id = e.id
+		LEFT JOIN media m ON m.id = es.audio_media_id
+		` + where + `
+		ORDER BY ` + order + `
+		LIMIT ?
+	`
+	args = append(args, limit)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var summaries []*entity.EbookSummaryList
+	for rows.Next() {
+		var summary entity.EbookSummaryList
+		err := rows.Scan(
+			&summary.ID,
+			&summary.EbookID,
+			&summary.EbookTitle,
+			&summary.Slug,
+			&summary.Description,
+			&summary.URL,
+			&summary.AudioURL,
+			&summary.Duration,
+			&summary.CreatedAt,
+			&summary.UpdatedAt,
+			&summary.AudioMediaKey,
+		)
+		if err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, &summary)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return summaries, nil
+}
+
 func (r *SummaryRepositoryImpl) GetSummariesByEbookID(ctx context.Context, ebookID string, limit, offset int) ([]*entity.EbookSummary, error) {
 	query := `
-		SELECT id, ebook_id, description, url, audio_url, created_at, updated_at
+		SELECT id, ebook_id, description, url, audio_url, audio_media_id, created_at, updated_at,
+			(SELECT m.storage_key FROM media m WHERE m.id = ebook_summaries.audio_media_id) AS audio_media_key
 		FROM ebook_summaries
 		WHERE ebook_id = ?
 		ORDER BY created_at DESC
@@ -206,8 +285,10 @@ func (r *SummaryRepositoryImpl) GetSummariesByEbookID(ctx context.Context, ebook
 			&summary.Description,
 			&summary.URL,
 			&summary.AudioURL,
+			&summary.AudioMediaID,
 			&summary.CreatedAt,
 			&summary.UpdatedAt,
+			&summary.AudioMediaKey,
 		)
 		if err != nil {
 			return nil, err
@@ -17,18 +17,25 @@ func NewTableOfContentRepository(db *sql.DB) repository.TableOfContentRepository
 }
 
 func (r *tableOfContentRepository) Create(ctx context.Context, toc *entity.TableOfContent) error {
-	query := `INSERT INTO table_of_contents (id, ebook_id, title, page_number, created_at, updated_at) 
-		VALUES (?, ?, ?, ?, ?, ?)`
+	return createTableOfContent(ctx, r.db, toc)
+}
+
+func createTableOfContent(ctx context.Context, exec sqlExecutor, toc *entity.TableOfContent) error {
+	query := `INSERT INTO table_of_contents (id, ebook_id, parent_id, title, page_number, order_number, depth, created_at, updated_at) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	now := time.Now()
 	toc.CreatedAt = now
 	toc.UpdatedAt = now
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := exec.ExecContext(ctx, query,
 		toc.ID,
 		toc.EbookID,
+		toc.ParentID,
 		toc.Title,
 		toc.PageNumber,
+		toc.OrderNumber,
+		toc.Depth,
 		toc.CreatedAt,
 		toc.UpdatedAt,
 	)
@@ -36,15 +43,18 @@ func (r *tableOfContentRepository) Create(ctx context.Context, toc *entity.Table
 }
 
 func (r *tableOfContentRepository) GetByID(ctx context.Context, id string) (*entity.TableOfContent, error) {
-	query := `SELECT id, ebook_id, title, page_number, created_at, updated_at 
+	query := `SELECT id, ebook_id, parent_id, title, page_number, order_number, depth, created_at, updated_at 
 		FROM table_of_contents WHERE id = ?`
 
 	toc := &entity.TableOfContent{}
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
 		&toc.ID,
 		&toc.EbookID,
+		&toc.ParentID,
 		&toc.Title,
 		&toc.PageNumber,
+		&toc.OrderNumber,
+		&toc.Depth,
 		&toc.CreatedAt,
 		&toc.UpdatedAt,
 	)
@@ -60,15 +70,18 @@ func (r *tableOfContentRepository) GetByID(ctx context.Context, id string) (*ent
 
 func (r *tableOfContentRepository) Update(ctx context.Context, toc *entity.TableOfContent) error {
 	query := `UPDATE table_of_contents 
-		SET ebook_id = ?, title = ?, page_number = ?, updated_at = ?
+		SET ebook_id = ?, parent_id = ?, title = ?, page_number = ?, order_number = ?, depth = ?, updated_at = ?
 		WHERE id = ?`
 
 	toc.UpdatedAt = time.Now()
 
 	_, err := r.db.ExecContext(ctx, query,
 		toc.EbookID,
+		toc.ParentID,
 		toc.Title,
 		toc.PageNumber,
+		toc.OrderNumber,
+		toc.Depth,
 		toc.UpdatedAt,
 		toc.ID,
 	)
@@ -82,8 +95,8 @@ func (r *tableOfContentRepository) Delete(ctx context.Context, id string) error
 }
 
 func (r *tableOfContentRepository) ListByEbook(ctx context.Context, ebookID string) ([]*entity.TableOfContent, error) {
-	query := `SELECT id, ebook_id, title, page_number, created_at, updated_at 
-		FROM table_of_contents WHERE ebook_id = ? ORDER BY page_number ASC`
+	query := `SELECT id, ebook_id, parent_id, title, page_number, order_number, depth, created_at, updated_at 
+		FROM table_of_contents WHERE ebook_id = ? ORDER BY depth ASC, order_number ASC, page_number ASC`
 
 	rows, err := r.db.QueryContext(ctx, query, ebookID)
 	if err != nil {
@@ -97,8 +110,11 @@ func (r *tableOfContentRepository) ListByEbook(ctx context.Context, ebookID stri
 		err = rows.Scan(
 			&toc.ID,
 			&toc.EbookID,
+			&toc.ParentID,
 			&toc.Title,
 			&toc.PageNumber,
+			&toc.OrderNumber,
+			&toc.Depth,
 			&toc.CreatedAt,
 			&toc.UpdatedAt,
 		)
@@ -116,11 +132,32 @@ func (r *tableOfContentRepository) ListByEbook(ctx context.Context, ebookID stri
 }
 
 func (r *tableOfContentRepository) DeleteByEbook(ctx context.Context, ebookID string) error {
+	return deleteTableOfContentsByEbook(ctx, r.db, ebookID)
+}
+
+func deleteTableOfContentsByEbook(ctx context.Context, exec sqlExecutor, ebookID string) error {
 	query := `DELETE FROM table_of_contents WHERE ebook_id = ?`
-	_, err := r.db.ExecContext(ctx, query, ebookID)
+	_, err := exec.ExecContext(ctx, query, ebookID)
 	return err
 }
 
+// ReplaceByEbook deletes the ebook's entries and creates the new ones in a single transaction.
+// Entries must list every parent before its children.
+func (r *tableOfContentRepository) ReplaceByEbook(ctx context.Context, ebookID string, entries []*entity.TableOfContent) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		if err := deleteTableOfContentsByEbook(ctx, tx, ebookID); err != nil {
+			return err
+		}
+
+		for _, entry := range entries {
+			if err := createTableOfContent(ctx, tx, entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 func (r *tableOfContentRepository) CountByEbook(ctx context.Context, ebookID string) (int64, error) {
 	query := `SELECT COUNT(*) FROM table_of_contents WHERE ebook_id = ?`
 	var count int64
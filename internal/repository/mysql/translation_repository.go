@@ -0,0 +1,166 @@
+package mysql
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+type translationRepository struct {
+	db *sql.DB
+}
+
+func NewTranslationRepository(db *sql.DB) repository.TranslationRepository {
+	return &translationRepository{db: db}
+}
+
+// translationTable is where the translations of a kind of content are kept
+type translationTable struct {
+	contentTable string // Table of the content itself
+	table        string
+	ownerColumn  string // Column referencing the content's id
+}
+
+var translationTables = map[entity.TranslatableContent]translationTable{
+	entity.TranslatableEbook:    {contentTable: "ebooks", table: "ebook_translations", ownerColumn: "ebook_id"},
+	entity.TranslatableCategory: {contentTable: "categories", table: "category_translations", ownerColumn: "category_id"},
+	entity.TranslatableArticle:  {contentTable: "articles", table: "article_translations", ownerColumn: "article_id"},
+	entity.TranslatableBanner:   {contentTable: "banners", table: "banner_translations", ownerColumn: "banner_id"},
+}
+
+func lookupTranslationTable(contentType entity.TranslatableContent) (translationTable, []string, error) {
+	table, ok := translationTables[contentType]
+	if !ok {
+		return translationTable{}, nil, fmt.Errorf("unknown content type %q", contentType)
+	}
+	fields := make([]string, 0, len(contentType.Fields()))
+	for field := range contentType.Fields() {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return table, fields, nil
+}
+
+// translatedColumn selects a text column of the content row aliased as alias in the locale
+// of the request, falling back to the stored text. Each use takes the locale as one argument,
+// see entity.TranslationLocale.
+func translatedColumn(contentType entity.TranslatableContent, alias, column string) string {
+	table := translationTables[contentType]
+	return `COALESCE((SELECT tr.` + column + ` FROM ` + table.table + ` tr WHERE tr.` + table.ownerColumn + ` = ` + alias + `.id AND tr.locale = ?), ` + alias + `.` + column + `)`
+}
+
+// localeArgs repeats the request's translation locale once for each translated column
+func localeArgs(ctx context.Context, columns int, args ...any) []any {
+	locale := entity.TranslationLocale(ctx)
+	localized := make([]any, 0, columns+len(args))
+	for i := 0; i < columns; i++ {
+		localized = append(localized, locale)
+	}
+	return append(localized, args...)
+}
+
+func (r *translationRepository) Upsert(ctx context.Context, translation *entity.Translation) error {
+	table, fields, err := lookupTranslationTable(translation.ContentType)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	translation.UpdatedAt = now
+
+	columns := append([]string{table.ownerColumn, "locale"}, fields...)
+	columns = append(columns, "created_at", "updated_at")
+	args := []any{translation.ContentID, translation.Locale}
+	updates := make([]string, 0, len(fields)+1)
+	for _, field := range fields {
+		var value *string
+		if text, ok := translation.Fields[field]; ok {
+			value = &text
+		}
+		args = append(args, value)
+		updates = append(updates, field+` = VALUES(`+field+`)`)
+	}
+	args = append(args, now, now)
+	updates = append(updates, `updated_at = VALUES(updated_at)`)
+
+	query := `INSERT INTO ` + table.table + ` (` + strings.Join(columns, ", ") + `)
+		VALUES (?` + strings.Repeat(", ?", len(columns)-1) + `)
+		ON DUPLICATE KEY UPDATE ` + strings.Join(updates, ", ")
+
+	_, err = r.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+func (r *translationRepository) ListByContent(ctx context.Context, contentType entity.TranslatableContent, contentID string) ([]*entity.Translation, error) {
+	table, fields, err := lookupTranslationTable(contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `SELECT locale, ` + strings.Join(fields, ", ") + `, updated_at
+		FROM ` + table.table + ` WHERE ` + table.ownerColumn + ` = ? ORDER BY locale`
+
+	rows, err := r.db.QueryContext(ctx, query, contentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var translations []*entity.Translation
+	for rows.Next() {
+		translation := &entity.Translation{ContentType: contentType, ContentID: contentID, Fields: map[string]string{}}
+		values := make([]sql.NullString, len(fields))
+		dest := []any{&translation.Locale}
+		for i := range values {
+			dest = append(dest, &values[i])
+		}
+		dest = append(dest, &translation.UpdatedAt)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		for i, value := range values {
+			if value.Valid {
+				translation.Fields[fields[i]] = value.String
+			}
+		}
+		translations = append(translations, translation)
+	}
+
+	return translations, rows.Err()
+}
+
+func (r *translationRepository) Delete(ctx context.Context, contentType entity.TranslatableContent, contentID, locale string) (bool, error) {
+	table, _, err := lookupTranslationTable(contentType)
+	if err != nil {
+		return false, err
+	}
+
+	query := `DELETE FROM ` + table.table + ` WHERE ` + table.ownerColumn + ` = ? AND locale = ?`
+	result, err := r.db.ExecContext(ctx, query, contentID, locale)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func (r *translationRepository) ContentExists(ctx context.Context, contentType entity.TranslatableContent, contentID string) (bool, error) {
+	table, _, err := lookupTranslationTable(contentType)
+	if err != nil {
+		return false, err
+	}
+
+	var exists bool
+	err = r.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM `+table.contentTable+` WHERE id = ?)`, contentID).Scan(&exists)
+	return exists, err
+}
@@ -0,0 +1,117 @@
+package mysql
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+)
+
+// trendingSnapshotBatch is how many scores are written per INSERT
+const trendingSnapshotBatch = 500
+
+type trendingRepository struct {
+	db      *sql.DB
+	catalog *recommendationRepository
+}
+
+func NewTrendingRepository(db *sql.DB) repository.TrendingRepository {
+	return &trendingRepository{
+		db:      db,
+		catalog: &recommendationRepository{db: db, ebooks: &ebookRepository{db: db}},
+	}
+}
+
+// snapshotDay formats a day for the DATE column, independent of the connection's time zone
+func snapshotDay(day time.Time) string {
+	return day.Format("2006-01-02")
+}
+
+func (r *trendingRepository) SaveSnapshot(ctx context.Context, day time.Time, scores []*entity.TrendingScore) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM ebook_trending_snapshots WHERE day = ?`, snapshotDay(day)); err != nil {
+			return err
+		}
+
+		for start := 0; start < len(scores); start += trendingSnapshotBatch {
+			batch := scores[start:min(start+trendingSnapshotBatch, len(scores))]
+
+			// Ebooks deleted since the event are skipped instead of failing the foreign key
+			query := `INSERT INTO ebook_trending_snapshots (day, ebook_id, score)
+				SELECT ?, e.id, s.score FROM ebooks e JOIN (` +
+				strings.TrimSuffix(strings.Repeat(`SELECT ? AS ebook_id, ? AS score UNION ALL `, len(batch)), ` UNION ALL `) +
+				`) s ON s.ebook_id = e.id`
+			args := make([]any, 0, 1+2*len(batch))
+			args = append(args, snapshotDay(day))
+			for _, score := range batch {
+				args = append(args, score.EbookID, score.Score)
+			}
+
+			if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *trendingRepository) ListSnapshot(ctx context.Context, day time.Time) ([]*entity.TrendingScore, error) {
+	query := `SELECT s.ebook_id, e.category_id, s.score
+		FROM ebook_trending_snapshots s
+		JOIN ebooks e ON e.id = s.ebook_id
+		WHERE s.day = ?`
+
+	rows, err := r.db.QueryContext(ctx, query, snapshotDay(day))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var scores []*entity.TrendingScore
+	for rows.Next() {
+		score := &entity.TrendingScore{Day: day}
+		if err := rows.Scan(&score.EbookID, &score.CategoryID, &score.Score); err != nil {
+			return nil, err
+		}
+		scores = append(scores, score)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return scores, nil
+}
+
+func (r *trendingRepository) DeleteSnapshotsBefore(ctx context.Context, day time.Time) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM ebook_trending_snapshots WHERE day < ?`, snapshotDay(day))
+	return err
+}
+
+// UpdatePopularityScores leaves updated_at alone; a changed score is not a change to the ebook
+func (r *trendingRepository) UpdatePopularityScores(ctx context.Context, scores map[string]int) error {
+	return withTx(ctx, r.db, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `UPDATE ebooks SET popularity_score = 0, updated_at = updated_at WHERE popularity_score <> 0`); err != nil {
+			return err
+		}
+
+		for ebookID, score := range scores {
+			if score == 0 {
+				continue
+			}
+			_, err := tx.ExecContext(ctx, `UPDATE ebooks SET popularity_score = ?, updated_at = updated_at WHERE id = ?`, score, ebookID)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *trendingRepository) ListPublishedByIDs(ctx context.Context, ids []string) ([]*entity.EbookList, error) {
+	return r.catalog.ListPublishedByIDs(ctx, ids)
+}
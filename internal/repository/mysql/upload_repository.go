@@ -0,0 +1,132 @@
+package mysql
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"context"
+	"database/sql"
+	"time"
+)
+
+type uploadRepository struct {
+	db *sql.DB
+}
+
+func NewUploadRepository(db *sql.DB) repository.UploadRepository {
+	return &uploadRepository{db: db}
+}
+
+const uploadColumns = `id, kind, filename, upload_length, upload_offset, checksum, uploaded_by, media_id, expires_at, created_at, updated_at`
+
+func (r *uploadRepository) Create(ctx context.Context, upload *entity.Upload) error {
+	query := `INSERT INTO uploads (id, kind, filename, upload_length, upload_offset, checksum, uploaded_by, expires_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	now := time.Now()
+	upload.CreatedAt = now
+	upload.UpdatedAt = now
+
+	_, err := r.db.ExecContext(ctx, query,
+		upload.ID,
+		upload.Kind,
+		upload.Filename,
+		upload.Length,
+		upload.Offset,
+		upload.Checksum,
+		upload.UploadedBy,
+		upload.ExpiresAt,
+		upload.CreatedAt,
+		upload.UpdatedAt,
+	)
+	return err
+}
+
+func (r *uploadRepository) GetByID(ctx context.Context, id string) (*entity.Upload, error) {
+	query := `SELECT ` + uploadColumns + ` FROM uploads WHERE id = ?`
+
+	upload, err := scanUpload(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return upload, nil
+}
+
+func (r *uploadRepository) UpdateOffset(ctx context.Context, id string, from, to int64, expiresAt time.Time) (bool, error) {
+	query := `UPDATE uploads SET upload_offset = ?, expires_at = ?, updated_at = ?
+		WHERE id = ? AND upload_offset = ? AND media_id IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, to, expiresAt, time.Now(), id, from)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func (r *uploadRepository) SetMedia(ctx context.Context, id, mediaID string) error {
+	query := `UPDATE uploads SET media_id = ?, updated_at = ? WHERE id = ?`
+
+	_, err := r.db.ExecContext(ctx, query, mediaID, time.Now(), id)
+	return err
+}
+
+func (r *uploadRepository) Delete(ctx context.Context, id string) error {
+	query := `DELETE FROM uploads WHERE id = ?`
+
+	_, err := r.db.ExecContext(ctx, query, id)
+	return err
+}
+
+func (r *uploadRepository) ListExpired(ctx context.Context, before time.Time, limit int) ([]*entity.Upload, error) {
+	query := `SELECT ` + uploadColumns + ` FROM uploads
+		WHERE expires_at <= ?
+		ORDER BY expires_at
+		LIMIT ?`
+
+	rows, err := r.db.QueryContext(ctx, query, before, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var uploads []*entity.Upload
+	for rows.Next() {
+		upload, err := scanUpload(rows)
+		if err != nil {
+			return nil, err
+		}
+		uploads = append(uploads, upload)
+	}
+
+	return uploads, rows.Err()
+}
+
+func scanUpload(row rowScanner) (*entity.Upload, error) {
+	upload := &entity.Upload{}
+	err := row.Scan(
+		&upload.ID,
+		&upload.Kind,
+		&upload.Filename,
+		&upload.Length,
+		&upload.Offset,
+		&upload.Checksum,
+		&upload.UploadedBy,
+		&upload.MediaID,
+		&upload.ExpiresAt,
+		&upload.CreatedAt,
+		&upload.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return upload, nil
+}
@@ -0,0 +1,100 @@
+package mysql
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"context"
+	"database/sql"
+	"time"
+)
+
+type wishlistRepository struct {
+	db *sql.DB
+}
+
+func NewWishlistRepository(db *sql.DB) repository.WishlistRepository {
+	return &wishlistRepository{db: db}
+}
+
+func (r *wishlistRepository) Add(ctx context.Context, userID, ebookID string) (bool, error) {
+	query := `INSERT IGNORE INTO wishlists (user_id, ebook_id, created_at) VALUES (?, ?, ?)`
+
+	result, err := r.db.ExecContext(ctx, query, userID, ebookID, time.Now())
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func (r *wishlistRepository) Remove(ctx context.Context, userID, ebookID string) error {
+	query := `DELETE FROM wishlists WHERE user_id = ? AND ebook_id = ?`
+	_, err := r.db.ExecContext(ctx, query, userID, ebookID)
+	return err
+}
+
+// ListByUser returns the wishlist newest first, with the discount price of any active discount
+func (r *wishlistRepository) ListByUser(ctx context.Context, userID string, limit, offset int) ([]*entity.WishlistItem, error) {
+	query := `SELECT w.user_id, w.ebook_id, w.created_at, e.title, e.slug, e.cover_image, e.price,
+			(SELECT ed.discount_price FROM ebook_discounts ed
+				WHERE ed.ebook_id = e.id AND ed.started_at <= ? AND ed.ended_at >= ?
+				ORDER BY ed.created_at DESC LIMIT 1) AS discount_price,
+			(SELECT m.storage_key FROM media m WHERE m.id = e.cover_media_id) AS cover_media_key
+		FROM wishlists w
+		INNER JOIN ebooks e ON e.id = w.ebook_id
+		WHERE w.user_id = ?
+		ORDER BY w.created_at DESC, w.ebook_id ASC
+		LIMIT ? OFFSET ?`
+
+	now := time.Now()
+	rows, err := r.db.QueryContext(ctx, query, now, now, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []*entity.WishlistItem
+	for rows.Next() {
+		item := &entity.WishlistItem{}
+		err = rows.Scan(
+			&item.UserID,
+			&item.EbookID,
+			&item.CreatedAt,
+			&item.Title,
+			&item.Slug,
+			&item.CoverImage,
+			&item.Price,
+			&item.DiscountPrice,
+			&item.CoverMediaKey,
+		)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (r *wishlistRepository) CountByUser(ctx context.Context, userID string) (int64, error) {
+	query := `SELECT COUNT(*) FROM wishlists WHERE user_id = ?`
+	var count int64
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&count)
+	return count, err
+}
+
+func (r *wishlistRepository) CountByEbook(ctx context.Context, ebookID string) (int64, error) {
+	query := `SELECT COUNT(*) FROM wishlists WHERE ebook_id = ?`
+	var count int64
+	err := r.db.QueryRowContext(ctx, query, ebookID).Scan(&count)
+	return count, err
+}
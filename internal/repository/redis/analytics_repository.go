@@ -0,0 +1,107 @@
+package redis
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"context"
+	"encoding/json"
+	"log"
+	"slices"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Buffered events wait in one list, oldest first, until the flush job writes them to MySQL
+const analyticsEventsKey = "analytics:events"
+
+// pushEventsScript appends the events only if the list stays within the capacity in ARGV[1]
+var pushEventsScript = redis.NewScript(`
+if redis.call('LLEN', KEYS[1]) + #ARGV - 1 > tonumber(ARGV[1]) then
+	return -1
+end
+return redis.call('RPUSH', KEYS[1], unpack(ARGV, 2))
+`)
+
+type analyticsRedisRepository struct {
+	client      *redis.Client
+	maxBuffered int64
+}
+
+// NewAnalyticsRedisRepository buffers at most maxBuffered events, so a flood of events
+// is refused instead of growing Redis memory faster than the flush job drains it
+func NewAnalyticsRedisRepository(client *redis.Client, maxBuffered int64) repository.AnalyticsRedisRepository {
+	return &analyticsRedisRepository{
+		client:      client,
+		maxBuffered: maxBuffered,
+	}
+}
+
+func (r *analyticsRedisRepository) PushEvents(ctx context.Context, events []*entity.AnalyticsEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	values, err := encodeAnalyticsEvents(events)
+	if err != nil {
+		return err
+	}
+
+	length, err := pushEventsScript.Run(ctx, r.client, []string{analyticsEventsKey}, append([]any{r.maxBuffered}, values...)...).Int64()
+	if err != nil {
+		return err
+	}
+	if length < 0 {
+		return repository.ErrBufferFull
+	}
+	return nil
+}
+
+func (r *analyticsRedisRepository) RequeueEvents(ctx context.Context, events []*entity.AnalyticsEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	values, err := encodeAnalyticsEvents(events)
+	if err != nil {
+		return err
+	}
+
+	// LPUSH prepends one at a time, so push newest first to keep the oldest at the front
+	slices.Reverse(values)
+	return r.client.LPush(ctx, analyticsEventsKey, values...).Err()
+}
+
+func (r *analyticsRedisRepository) PopEvents(ctx context.Context, count int64) ([]*entity.AnalyticsEvent, error) {
+	entries, err := r.client.LPopCount(ctx, analyticsEventsKey, int(count)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil // Buffer is empty
+		}
+		return nil, err
+	}
+
+	events := make([]*entity.AnalyticsEvent, 0, len(entries))
+	for _, data := range entries {
+		var event entity.AnalyticsEvent
+		if err := json.Unmarshal([]byte(data), &event); err != nil {
+			// A malformed entry would fail every retry, so it is dropped
+			log.Printf("Dropping malformed buffered analytics event: %v", err)
+			continue
+		}
+		events = append(events, &event)
+	}
+
+	return events, nil
+}
+
+func encodeAnalyticsEvents(events []*entity.AnalyticsEvent) ([]any, error) {
+	values := make([]any, 0, len(events))
+	for _, event := range events {
+		data, err := json.Marshal(event)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, data)
+	}
+	return values, nil
+}
@@ -22,7 +22,7 @@ func NewCategoryRedisRepository(client *redis.Client) repository.CategoryRedisRe
 }
 
 func (r *categoryRedisRepository) GetCategoryList(ctx context.Context, limit, offset int) ([]*entity.Category, error) {
-	key := fmt.Sprintf("category:list:%d:%d", limit, offset)
+	key := localizedKey(ctx, fmt.Sprintf("category:list:%d:%d", limit, offset))
 	
 	data, err := r.client.Get(ctx, key).Result()
 	if err != nil {
@@ -42,7 +42,7 @@ func (r *categoryRedisRepository) GetCategoryList(ctx context.Context, limit, of
 }
 
 func (r *categoryRedisRepository) SetCategoryList(ctx context.Context, categories []*entity.Category, limit, offset int) error {
-	key := fmt.Sprintf("category:list:%d:%d", limit, offset)
+	key := localizedKey(ctx, fmt.Sprintf("category:list:%d:%d", limit, offset))
 	
 	data, err := json.Marshal(categories)
 	if err != nil {
@@ -75,7 +75,7 @@ func (r *categoryRedisRepository) SetCategoryTotal(ctx context.Context, count in
 }
 
 func (r *categoryRedisRepository) GetActiveCategoryList(ctx context.Context, limit, offset int) ([]*entity.Category, error) {
-	key := fmt.Sprintf("category:active:list:%d:%d", limit, offset)
+	key := localizedKey(ctx, fmt.Sprintf("category:active:list:%d:%d", limit, offset))
 	
 	data, err := r.client.Get(ctx, key).Result()
 	if err != nil {
@@ -95,7 +95,7 @@ func (r *categoryRedisRepository) GetActiveCategoryList(ctx context.Context, lim
 }
 
 func (r *categoryRedisRepository) SetActiveCategoryList(ctx context.Context, categories []*entity.Category, limit, offset int) error {
-	key := fmt.Sprintf("category:active:list:%d:%d", limit, offset)
+	key := localizedKey(ctx, fmt.Sprintf("category:active:list:%d:%d", limit, offset))
 	
 	data, err := json.Marshal(categories)
 	if err != nil {
@@ -128,7 +128,7 @@ func (r *categoryRedisRepository) SetActiveCategoryTotal(ctx context.Context, co
 }
 
 func (r *categoryRedisRepository) GetCategoryByID(ctx context.Context, id string) (*entity.Category, error) {
-	key := fmt.Sprintf("category:id:%s", id)
+	key := localizedKey(ctx, fmt.Sprintf("category:id:%s", id))
 	
 	data, err := r.client.Get(ctx, key).Result()
 	if err != nil {
@@ -148,7 +148,7 @@ func (r *categoryRedisRepository) GetCategoryByID(ctx context.Context, id string
 }
 
 func (r *categoryRedisRepository) SetCategoryByID(ctx context.Context, category *entity.Category) error {
-	key := fmt.Sprintf("category:id:%s", category.ID)
+	key := localizedKey(ctx, fmt.Sprintf("category:id:%s", category.ID))
 	
 	data, err := json.Marshal(category)
 	if err != nil {
@@ -21,9 +21,9 @@ func NewEbookRedisRepository(client *redis.Client) repository.EbookRedisReposito
 	}
 }
 
-func (r *ebookRedisRepository) GetEbookList(ctx context.Context, limit, offset int) ([]*entity.EbookList, error) {
-	key := fmt.Sprintf("ebook:list:%d:%d", limit, offset)
-	
+func (r *ebookRedisRepository) GetEbookList(ctx context.Context, filter *entity.EbookFilter, limit, offset int) ([]*entity.EbookList, error) {
+	key := localizedKey(ctx, fmt.Sprintf("ebook:list:%s:%d:%d", filter.CacheKey(), limit, offset))
+
 	data, err := r.client.Get(ctx, key).Result()
 	if err != nil {
 		if err == redis.Nil {
@@ -41,9 +41,9 @@ func (r *ebookRedisRepository) GetEbookList(ctx context.Context, limit, offset i
 	return ebooks, nil
 }
 
-func (r *ebookRedisRepository) SetEbookList(ctx context.Context, ebooks []*entity.EbookList, limit, offset int) error {
-	key := fmt.Sprintf("ebook:list:%d:%d", limit, offset)
-	
+func (r *ebookRedisRepository) SetEbookList(ctx context.Context, ebooks []*entity.EbookList, filter *entity.EbookFilter, limit, offset int) error {
+	key := localizedKey(ctx, fmt.Sprintf("ebook:list:%s:%d:%d", filter.CacheKey(), limit, offset))
+
 	data, err := json.Marshal(ebooks)
 	if err != nil {
 		return err
@@ -53,9 +53,9 @@ func (r *ebookRedisRepository) SetEbookList(ctx context.Context, ebooks []*entit
 	return r.client.Set(ctx, key, data, 15*time.Minute).Err()
 }
 
-func (r *ebookRedisRepository) GetEbookTotal(ctx context.Context) (int64, error) {
-	key := "ebook:count:total"
-	
+func (r *ebookRedisRepository) GetEbookTotal(ctx context.Context, filter *entity.EbookFilter) (int64, error) {
+	key := localizedKey(ctx, fmt.Sprintf("ebook:count:%s", filter.CacheKey()))
+
 	count, err := r.client.Get(ctx, key).Int64()
 	if err != nil {
 		if err == redis.Nil {
@@ -67,13 +67,45 @@ func (r *ebookRedisRepository) GetEbookTotal(ctx context.Context) (int64, error)
 	return count, nil
 }
 
-func (r *ebookRedisRepository) SetEbookTotal(ctx context.Context, count int64) error {
-	key := "ebook:count:total"
-	
+func (r *ebookRedisRepository) SetEbookTotal(ctx context.Context, filter *entity.EbookFilter, count int64) error {
+	key := localizedKey(ctx, fmt.Sprintf("ebook:count:%s", filter.CacheKey()))
+
 	// Cache for 15 minutes
 	return r.client.Set(ctx, key, count, 15*time.Minute).Err()
 }
 
+func (r *ebookRedisRepository) GetEbookFacets(ctx context.Context, filter *entity.EbookFilter) (*entity.EbookFacets, error) {
+	key := localizedKey(ctx, fmt.Sprintf("ebook:facets:%s", filter.CacheKey()))
+
+	data, err := r.client.Get(ctx, key).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil // Cache miss
+		}
+		return nil, err
+	}
+
+	var facets entity.EbookFacets
+	err = json.Unmarshal([]byte(data), &facets)
+	if err != nil {
+		return nil, err
+	}
+
+	return &facets, nil
+}
+
+func (r *ebookRedisRepository) SetEbookFacets(ctx context.Context, filter *entity.EbookFilter, facets *entity.EbookFacets) error {
+	key := localizedKey(ctx, fmt.Sprintf("ebook:facets:%s", filter.CacheKey()))
+
+	data, err := json.Marshal(facets)
+	if err != nil {
+		return err
+	}
+
+	// Cache for 15 minutes
+	return r.client.Set(ctx, key, data, 15*time.Minute).Err()
+}
+
 func (r *ebookRedisRepository) GetEbookByID(ctx context.Context, id string) (*entity.Ebook, error) {
 	key := fmt.Sprintf("ebook:id:%s", id)
 	
@@ -107,7 +139,7 @@ func (r *ebookRedisRepository) SetEbookByID(ctx context.Context, ebook *entity.E
 }
 
 func (r *ebookRedisRepository) GetEbookBySlug(ctx context.Context, slug string) (*entity.EbookDetail, error) {
-	key := fmt.Sprintf("ebook:slug:%s", slug)
+	key := localizedKey(ctx, fmt.Sprintf("ebook:slug:%s", slug))
 	
 	data, err := r.client.Get(ctx, key).Result()
 	if err != nil {
@@ -127,7 +159,7 @@ func (r *ebookRedisRepository) GetEbookBySlug(ctx context.Context, slug string)
 }
 
 func (r *ebookRedisRepository) SetEbookBySlug(ctx context.Context, ebook *entity.EbookDetail) error {
-	key := fmt.Sprintf("ebook:slug:%s", ebook.Slug)
+	key := localizedKey(ctx, fmt.Sprintf("ebook:slug:%s", ebook.Slug))
 	
 	data, err := json.Marshal(ebook)
 	if err != nil {
@@ -0,0 +1,15 @@
+package redis
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// localizedKey suffixes the key with the locale reads are translated into, so translated and
+// stored text are cached apart. Keys keep their prefix, so invalidation patterns still match.
+func localizedKey(ctx context.Context, key string) string {
+	if locale := entity.TranslationLocale(ctx); locale != "" {
+		return key + ":" + locale
+	}
+	return key
+}
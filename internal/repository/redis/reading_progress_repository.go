@@ -0,0 +1,140 @@
+package redis
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Hot progress lives in one hash per user, field = ebook ID, value = "<client ts millis>|<json>".
+// The timestamp prefix lets the scripts below compare writes without decoding JSON.
+const readingProgressDirtyKey = "reading_progress:dirty"
+
+// setProgressScript writes the progress only if it is not older than the hot entry, then queues the user
+var setProgressScript = redis.NewScript(`
+local current = redis.call('HGET', KEYS[1], ARGV[1])
+if current then
+	local ts = tonumber(string.match(current, '^(%d+)|'))
+	if ts and ts > tonumber(ARGV[2]) then
+		return 0
+	end
+end
+redis.call('HSET', KEYS[1], ARGV[1], ARGV[2] .. '|' .. ARGV[3])
+redis.call('SADD', KEYS[2], ARGV[4])
+return 1
+`)
+
+// removeFlushedScript deletes a hot entry only if it still holds the flushed timestamp
+var removeFlushedScript = redis.NewScript(`
+local current = redis.call('HGET', KEYS[1], ARGV[1])
+if current and string.match(current, '^(%d+)|') == ARGV[2] then
+	return redis.call('HDEL', KEYS[1], ARGV[1])
+end
+return 0
+`)
+
+type readingProgressRedisRepository struct {
+	client *redis.Client
+}
+
+func NewReadingProgressRedisRepository(client *redis.Client) repository.ReadingProgressRedisRepository {
+	return &readingProgressRedisRepository{
+		client: client,
+	}
+}
+
+func readingProgressKey(userID string) string {
+	return fmt.Sprintf("reading_progress:hot:%s", userID)
+}
+
+func (r *readingProgressRedisRepository) GetProgress(ctx context.Context, userID, ebookID string) (*entity.ReadingProgress, error) {
+	data, err := r.client.HGet(ctx, readingProgressKey(userID), ebookID).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil // Not hot
+		}
+		return nil, err
+	}
+
+	return decodeReadingProgress(data)
+}
+
+func (r *readingProgressRedisRepository) SetProgress(ctx context.Context, progress *entity.ReadingProgress) (bool, error) {
+	data, err := json.Marshal(progress)
+	if err != nil {
+		return false, err
+	}
+
+	applied, err := setProgressScript.Run(ctx, r.client,
+		[]string{readingProgressKey(progress.UserID), readingProgressDirtyKey},
+		progress.EbookID,
+		progress.ClientUpdatedAt.UnixMilli(),
+		string(data),
+		progress.UserID,
+	).Int()
+	if err != nil {
+		return false, err
+	}
+
+	return applied == 1, nil
+}
+
+func (r *readingProgressRedisRepository) GetUserProgress(ctx context.Context, userID string) ([]*entity.ReadingProgress, error) {
+	entries, err := r.client.HGetAll(ctx, readingProgressKey(userID)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	progress := make([]*entity.ReadingProgress, 0, len(entries))
+	for _, data := range entries {
+		p, err := decodeReadingProgress(data)
+		if err != nil {
+			return nil, err
+		}
+		progress = append(progress, p)
+	}
+
+	return progress, nil
+}
+
+func (r *readingProgressRedisRepository) RemoveFlushed(ctx context.Context, progress []*entity.ReadingProgress) error {
+	for _, p := range progress {
+		err := removeFlushedScript.Run(ctx, r.client,
+			[]string{readingProgressKey(p.UserID)},
+			p.EbookID,
+			strconv.FormatInt(p.ClientUpdatedAt.UnixMilli(), 10),
+		).Err()
+		if err != nil && err != redis.Nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *readingProgressRedisRepository) PopDirtyUsers(ctx context.Context, count int64) ([]string, error) {
+	return r.client.SPopN(ctx, readingProgressDirtyKey, count).Result()
+}
+
+func (r *readingProgressRedisRepository) MarkDirty(ctx context.Context, userID string) error {
+	return r.client.SAdd(ctx, readingProgressDirtyKey, userID).Err()
+}
+
+func decodeReadingProgress(data string) (*entity.ReadingProgress, error) {
+	_, payload, ok := strings.Cut(data, "|")
+	if !ok {
+		return nil, fmt.Errorf("malformed reading progress entry")
+	}
+
+	var progress entity.ReadingProgress
+	if err := json.Unmarshal([]byte(payload), &progress); err != nil {
+		return nil, err
+	}
+
+	return &progress, nil
+}
@@ -0,0 +1,85 @@
+package redis
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Recommendations are kept for a day, so a failed refresh keeps serving the previous results
+const recommendationTTL = 24 * time.Hour
+
+type recommendationRedisRepository struct {
+	client *redis.Client
+}
+
+func NewRecommendationRedisRepository(client *redis.Client) repository.RecommendationRedisRepository {
+	return &recommendationRedisRepository{
+		client: client,
+	}
+}
+
+func relatedEbooksKey(ebookID string) string {
+	return fmt.Sprintf("recommendation:related:%s", ebookID)
+}
+
+func userRecommendationsKey(userID string) string {
+	return fmt.Sprintf("recommendation:user:%s", userID)
+}
+
+func (r *recommendationRedisRepository) GetRelated(ctx context.Context, ebookID string) ([]*entity.ScoredEbook, error) {
+	return r.getScoredEbooks(ctx, relatedEbooksKey(ebookID))
+}
+
+func (r *recommendationRedisRepository) SetRelated(ctx context.Context, related map[string][]*entity.ScoredEbook) error {
+	return r.setScoredEbooks(ctx, related, relatedEbooksKey)
+}
+
+func (r *recommendationRedisRepository) GetUserRecommendations(ctx context.Context, userID string) ([]*entity.ScoredEbook, error) {
+	return r.getScoredEbooks(ctx, userRecommendationsKey(userID))
+}
+
+func (r *recommendationRedisRepository) SetUserRecommendations(ctx context.Context, recommendations map[string][]*entity.ScoredEbook) error {
+	return r.setScoredEbooks(ctx, recommendations, userRecommendationsKey)
+}
+
+func (r *recommendationRedisRepository) getScoredEbooks(ctx context.Context, key string) ([]*entity.ScoredEbook, error) {
+	data, err := r.client.Get(ctx, key).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil // Not computed yet
+		}
+		return nil, err
+	}
+
+	var scored []*entity.ScoredEbook
+	if err := json.Unmarshal([]byte(data), &scored); err != nil {
+		return nil, err
+	}
+
+	return scored, nil
+}
+
+// setScoredEbooks writes all lists in one pipeline; each key is replaced as a whole
+func (r *recommendationRedisRepository) setScoredEbooks(ctx context.Context, lists map[string][]*entity.ScoredEbook, key func(string) string) error {
+	if len(lists) == 0 {
+		return nil
+	}
+
+	pipe := r.client.Pipeline()
+	for id, scored := range lists {
+		data, err := json.Marshal(scored)
+		if err != nil {
+			return err
+		}
+		pipe.Set(ctx, key(id), data, recommendationTTL)
+	}
+
+	_, err := pipe.Exec(ctx)
+	return err
+}
@@ -0,0 +1,61 @@
+package redis
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Sitemaps are regenerated when content changes, the TTL only drops sets nobody asks for anymore
+const sitemapTTL = 7 * 24 * time.Hour
+
+// sitemapKey is a hash of the stored sitemaps by file name, plus their version
+const (
+	sitemapKey          = "sitemap"
+	sitemapVersionField = "_version"
+)
+
+type sitemapRedisRepository struct {
+	client *redis.Client
+}
+
+func NewSitemapRedisRepository(client *redis.Client) repository.SitemapRedisRepository {
+	return &sitemapRedisRepository{
+		client: client,
+	}
+}
+
+func (r *sitemapRedisRepository) GetSitemap(ctx context.Context, name string) ([]byte, error) {
+	data, err := r.client.HGet(ctx, sitemapKey, name).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	return data, err
+}
+
+func (r *sitemapRedisRepository) GetSitemapVersion(ctx context.Context) (string, error) {
+	version, err := r.client.HGet(ctx, sitemapKey, sitemapVersionField).Result()
+	if err == redis.Nil {
+		return "", nil
+	}
+	return version, err
+}
+
+// SetSitemaps swaps the whole set in a transaction, so sitemaps from different versions are never mixed
+func (r *sitemapRedisRepository) SetSitemaps(ctx context.Context, set *entity.SitemapSet) error {
+	values := make(map[string]any, len(set.Documents)+1)
+	for name, document := range set.Documents {
+		values[name] = document
+	}
+	values[sitemapVersionField] = set.Version
+
+	pipe := r.client.TxPipeline()
+	pipe.Del(ctx, sitemapKey)
+	pipe.HSet(ctx, sitemapKey, values)
+	pipe.Expire(ctx, sitemapKey, sitemapTTL)
+	_, err := pipe.Exec(ctx)
+	return err
+}
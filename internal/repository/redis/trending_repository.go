@@ -0,0 +1,165 @@
+package redis
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// A daily bucket outlives the longest window by a day, so the partly covered oldest day is still there
+	trendingBucketTTL = entity.MaxTrendingWindow + 2*24*time.Hour
+	// Combined rankings are cached briefly; they drift as the day goes on, not from one request to the next
+	trendingRankTTL = time.Minute
+
+	trendingDayLayout = "2006-01-02"
+)
+
+type trendingRedisRepository struct {
+	client *redis.Client
+}
+
+func NewTrendingRedisRepository(client *redis.Client) repository.TrendingRedisRepository {
+	return &trendingRedisRepository{
+		client: client,
+	}
+}
+
+func trendingDayKey(day time.Time, categoryID string) string {
+	if categoryID == "" {
+		return fmt.Sprintf("trending:day:%s", day.Format(trendingDayLayout))
+	}
+	return fmt.Sprintf("trending:day:%s:category:%s", day.Format(trendingDayLayout), categoryID)
+}
+
+func trendingRankKey(window entity.TrendingWindow, categoryID string) string {
+	if categoryID == "" {
+		return fmt.Sprintf("trending:rank:%s", window)
+	}
+	return fmt.Sprintf("trending:rank:%s:category:%s", window, categoryID)
+}
+
+func trendingEngagedKey(day time.Time, event entity.EngagementEvent, ebookID, actorID string) string {
+	return fmt.Sprintf("trending:engaged:%s:%s:%s:%s", day.Format(trendingDayLayout), event, ebookID, actorID)
+}
+
+func (r *trendingRedisRepository) IncrementScore(ctx context.Context, day time.Time, ebookID, categoryID string, score float64) error {
+	expireAt := day.Add(trendingBucketTTL)
+
+	pipe := r.client.Pipeline()
+	pipe.ZIncrBy(ctx, trendingDayKey(day, ""), score, ebookID)
+	pipe.ExpireAt(ctx, trendingDayKey(day, ""), expireAt)
+	if categoryID != "" {
+		pipe.ZIncrBy(ctx, trendingDayKey(day, categoryID), score, ebookID)
+		pipe.ExpireAt(ctx, trendingDayKey(day, categoryID), expireAt)
+	}
+
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (r *trendingRedisRepository) MarkEngaged(ctx context.Context, day time.Time, event entity.EngagementEvent, ebookID, actorID string) (bool, error) {
+	// The mark only has to last until the day's bucket stops taking events
+	ttl := time.Until(day.Add(24 * time.Hour))
+	if ttl <= 0 {
+		ttl = time.Minute
+	}
+
+	return r.client.SetNX(ctx, trendingEngagedKey(day, event, ebookID, actorID), 1, ttl).Result()
+}
+
+func (r *trendingRedisRepository) Rank(ctx context.Context, window entity.TrendingWindow, weights map[time.Time]float64, categoryID string, limit, offset int) ([]*entity.ScoredEbook, int64, error) {
+	key := trendingRankKey(window, categoryID)
+
+	cached, err := r.client.Exists(ctx, key).Result()
+	if err != nil {
+		return nil, 0, err
+	}
+	if cached == 0 && len(weights) > 0 {
+		store := &redis.ZStore{Aggregate: "SUM"}
+		for day, weight := range weights {
+			store.Keys = append(store.Keys, trendingDayKey(day, categoryID))
+			store.Weights = append(store.Weights, weight)
+		}
+
+		pipe := r.client.TxPipeline()
+		pipe.ZUnionStore(ctx, key, store)
+		pipe.Expire(ctx, key, trendingRankTTL)
+		if _, err := pipe.Exec(ctx); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	pipe := r.client.Pipeline()
+	rangeCmd := pipe.ZRevRangeWithScores(ctx, key, int64(offset), int64(offset+limit-1))
+	countCmd := pipe.ZCard(ctx, key)
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, 0, err
+	}
+
+	members := rangeCmd.Val()
+	scored := make([]*entity.ScoredEbook, 0, len(members))
+	for _, member := range members {
+		ebookID, ok := member.Member.(string)
+		if !ok {
+			continue
+		}
+		scored = append(scored, &entity.ScoredEbook{EbookID: ebookID, Score: member.Score})
+	}
+
+	return scored, countCmd.Val(), nil
+}
+
+func (r *trendingRedisRepository) GetDayScores(ctx context.Context, day time.Time) ([]*entity.TrendingScore, error) {
+	members, err := r.client.ZRangeWithScores(ctx, trendingDayKey(day, ""), 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	scores := make([]*entity.TrendingScore, 0, len(members))
+	for _, member := range members {
+		ebookID, ok := member.Member.(string)
+		if !ok {
+			continue
+		}
+		scores = append(scores, &entity.TrendingScore{Day: day, EbookID: ebookID, Score: member.Score})
+	}
+
+	return scores, nil
+}
+
+func (r *trendingRedisRepository) HasDay(ctx context.Context, day time.Time) (bool, error) {
+	count, err := r.client.Exists(ctx, trendingDayKey(day, "")).Result()
+	return count > 0, err
+}
+
+// RestoreDay replaces the day's buckets as a whole, so restoring twice does not count anything double
+func (r *trendingRedisRepository) RestoreDay(ctx context.Context, day time.Time, scores []*entity.TrendingScore) error {
+	if len(scores) == 0 {
+		return nil
+	}
+
+	buckets := make(map[string][]redis.Z)
+	for _, score := range scores {
+		member := redis.Z{Score: score.Score, Member: score.EbookID}
+		buckets[trendingDayKey(day, "")] = append(buckets[trendingDayKey(day, "")], member)
+		if score.CategoryID != "" {
+			buckets[trendingDayKey(day, score.CategoryID)] = append(buckets[trendingDayKey(day, score.CategoryID)], member)
+		}
+	}
+
+	expireAt := day.Add(trendingBucketTTL)
+	pipe := r.client.TxPipeline()
+	for key, members := range buckets {
+		pipe.Del(ctx, key)
+		pipe.ZAdd(ctx, key, members...)
+		pipe.ExpireAt(ctx, key, expireAt)
+	}
+
+	_, err := pipe.Exec(ctx)
+	return err
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"buku-pintar/internal/domain/service"
+	"context"
+	"errors"
+	"log"
+	"time"
+)
+
+const (
+	// analyticsFlushBatchSize is how many events are taken off the buffer per write
+	analyticsFlushBatchSize = 500
+	// analyticsPartitionsAhead is how many months past the current one have a partition ready
+	analyticsPartitionsAhead = 2
+)
+
+type analyticsService struct {
+	analyticsRepo      repository.AnalyticsRepository
+	analyticsRedisRepo repository.AnalyticsRedisRepository
+	retentionMonths    int
+}
+
+// NewAnalyticsService keeps events for retentionMonths full months besides the current one
+func NewAnalyticsService(
+	analyticsRepo repository.AnalyticsRepository,
+	analyticsRedisRepo repository.AnalyticsRedisRepository,
+	retentionMonths int,
+) service.AnalyticsService {
+	return &analyticsService{
+		analyticsRepo:      analyticsRepo,
+		analyticsRedisRepo: analyticsRedisRepo,
+		retentionMonths:    retentionMonths,
+	}
+}
+
+func (s *analyticsService) BufferEvents(ctx context.Context, events []*entity.AnalyticsEvent) error {
+	err := s.analyticsRedisRepo.PushEvents(ctx, events)
+	if errors.Is(err, repository.ErrBufferFull) {
+		// Writing through would hand a flood straight to the database, so the events are refused
+		return err
+	}
+	if err != nil {
+		// Redis is unavailable, write through so the events are not lost
+		log.Printf("Failed to buffer analytics events, writing to database: %v", err)
+		return s.analyticsRepo.InsertEvents(ctx, events)
+	}
+	return nil
+}
+
+func (s *analyticsService) FlushEvents(ctx context.Context) (int, error) {
+	flushed := 0
+	for {
+		events, err := s.analyticsRedisRepo.PopEvents(ctx, analyticsFlushBatchSize)
+		if err != nil {
+			return flushed, err
+		}
+		if len(events) == 0 {
+			return flushed, nil
+		}
+
+		if err := s.analyticsRepo.InsertEvents(ctx, events); err != nil {
+			// Put the events back so the next run retries; the IDs keep a retry from storing them twice
+			if pushErr := s.analyticsRedisRepo.RequeueEvents(ctx, events); pushErr != nil {
+				log.Printf("Failed to requeue %d analytics events: %v", len(events), pushErr)
+			}
+			return flushed, err
+		}
+		flushed += len(events)
+	}
+}
+
+func (s *analyticsService) MaintainPartitions(ctx context.Context) error {
+	now := time.Now().UTC()
+
+	created, err := s.analyticsRepo.EnsurePartitions(ctx, now.AddDate(0, analyticsPartitionsAhead, 0))
+	if err != nil {
+		return err
+	}
+
+	dropped, err := s.analyticsRepo.DropPartitionsBefore(ctx, now.AddDate(0, -s.retentionMonths, 0))
+	if err != nil {
+		return err
+	}
+
+	if created > 0 || dropped > 0 {
+		log.Printf("Created %d and dropped %d analytics event partitions", created, dropped)
+	}
+	return nil
+}
+
+func (s *analyticsService) GetTotals(ctx context.Context, filter *entity.AnalyticsFilter) ([]*entity.AnalyticsTotal, error) {
+	return s.analyticsRepo.SummarizeEvents(ctx, filter)
+}
+
+func (s *analyticsService) GetDailyCounts(ctx context.Context, filter *entity.AnalyticsFilter) ([]*entity.AnalyticsDailyCount, error) {
+	return s.analyticsRepo.CountDaily(ctx, filter)
+}
+
+func (s *analyticsService) GetTopItems(ctx context.Context, filter *entity.AnalyticsFilter, dimension entity.AnalyticsDimension, limit int) ([]*entity.AnalyticsTopItem, error) {
+	return s.analyticsRepo.ListTop(ctx, filter, dimension, limit)
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"buku-pintar/internal/domain/service"
+	"context"
+)
+
+// annotationService is not cached: annotations are private, small and read right after being written
+type annotationService struct {
+	annotationRepo repository.AnnotationRepository
+}
+
+func NewAnnotationService(annotationRepo repository.AnnotationRepository) service.AnnotationService {
+	return &annotationService{
+		annotationRepo: annotationRepo,
+	}
+}
+
+func (s *annotationService) CreateAnnotation(ctx context.Context, annotation *entity.Annotation) error {
+	return s.annotationRepo.Create(ctx, annotation)
+}
+
+func (s *annotationService) GetAnnotationByID(ctx context.Context, userID, id string) (*entity.Annotation, error) {
+	return s.annotationRepo.GetByID(ctx, userID, id)
+}
+
+func (s *annotationService) UpdateAnnotation(ctx context.Context, annotation *entity.Annotation) error {
+	return s.annotationRepo.Update(ctx, annotation)
+}
+
+func (s *annotationService) DeleteAnnotation(ctx context.Context, userID, id string) error {
+	return s.annotationRepo.Delete(ctx, userID, id)
+}
+
+func (s *annotationService) GetAnnotationList(ctx context.Context, filter *entity.AnnotationFilter, limit, offset int) ([]*entity.Annotation, error) {
+	return s.annotationRepo.List(ctx, filter, limit, offset)
+}
+
+func (s *annotationService) GetAnnotationCount(ctx context.Context, filter *entity.AnnotationFilter) (int64, error) {
+	return s.annotationRepo.Count(ctx, filter)
+}
+
+func (s *annotationService) GetAnnotationsByEbook(ctx context.Context, userID, ebookID string) ([]*entity.Annotation, error) {
+	return s.annotationRepo.ListByEbook(ctx, userID, ebookID)
+}
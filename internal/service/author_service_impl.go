@@ -0,0 +1,30 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"buku-pintar/internal/domain/service"
+	"context"
+)
+
+type authorService struct {
+	authorRepo repository.AuthorRepository
+}
+
+func NewAuthorService(authorRepo repository.AuthorRepository) service.AuthorService {
+	return &authorService{
+		authorRepo: authorRepo,
+	}
+}
+
+func (s *authorService) CreateAuthor(ctx context.Context, author *entity.Author) error {
+	return s.authorRepo.Create(ctx, author)
+}
+
+func (s *authorService) GetAuthorByID(ctx context.Context, id string) (*entity.Author, error) {
+	return s.authorRepo.GetByID(ctx, id)
+}
+
+func (s *authorService) GetAuthorByName(ctx context.Context, name string) (*entity.Author, error) {
+	return s.authorRepo.GetByName(ctx, name)
+}
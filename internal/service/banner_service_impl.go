@@ -12,6 +12,7 @@ import (
 type bannerService struct {
 	bannerRepo     repository.BannerRepository
 	bannerRedisRepo repository.BannerRedisRepository
+	mediaURLs      service.MediaURLResolver
 	cacheTTL       time.Duration
 }
 
@@ -19,10 +20,12 @@ type bannerService struct {
 func NewBannerService(
 	bannerRepo repository.BannerRepository,
 	bannerRedisRepo repository.BannerRedisRepository,
+	mediaURLs service.MediaURLResolver,
 ) service.BannerService {
 	return &bannerService{
 		bannerRepo:      bannerRepo,
 		bannerRedisRepo: bannerRedisRepo,
+		mediaURLs:       mediaURLs,
 		cacheTTL:        5 * time.Minute, // 5 minutes cache TTL
 	}
 }
@@ -32,6 +35,7 @@ func (s *bannerService) GetBannerList(ctx context.Context, limit, offset int) ([
 	cachedBanners, err := s.bannerRedisRepo.GetBannerList(ctx, limit, offset)
 	if err == nil && cachedBanners != nil && len(cachedBanners) > 0 {
 		log.Println("Banner list retrieved from cache")
+		resolveMediaURLs(s.mediaURLs, cachedBanners...)
 		return cachedBanners, nil
 	}
 
@@ -49,13 +53,20 @@ func (s *bannerService) GetBannerList(ctx context.Context, limit, offset int) ([
 		}
 	}
 
+	resolveMediaURLs(s.mediaURLs, banners...)
 	return banners, nil
 }
 
 func (s *bannerService) GetBannerByID(ctx context.Context, id string) (*entity.Banner, error) {
 	// For individual banner, we'll get directly from database
 	// as caching individual banners might not be as beneficial
-	return s.bannerRepo.GetByID(ctx, id)
+	banner, err := s.bannerRepo.GetByID(ctx, id)
+	if err != nil || banner == nil {
+		return nil, err
+	}
+
+	banner.ResolveMediaURLs(s.mediaURLs.URL)
+	return banner, nil
 }
 
 func (s *bannerService) CreateBanner(ctx context.Context, banner *entity.Banner) error {
@@ -120,7 +131,13 @@ func (s *bannerService) GetBannerCount(ctx context.Context) (int64, error) {
 func (s *bannerService) GetActiveBannerList(ctx context.Context, limit, offset int) ([]*entity.Banner, error) {
 	// For active banners, we'll get directly from database
 	// as they might change frequently and caching might not be beneficial
-	return s.bannerRepo.ListActive(ctx, limit, offset)
+	banners, err := s.bannerRepo.ListActive(ctx, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	resolveMediaURLs(s.mediaURLs, banners...)
+	return banners, nil
 }
 
 func (s *bannerService) GetActiveBannerCount(ctx context.Context) (int64, error) {
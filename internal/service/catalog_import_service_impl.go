@@ -0,0 +1,57 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"buku-pintar/internal/domain/service"
+	"context"
+	"log"
+)
+
+type catalogImportService struct {
+	importRepo        repository.CatalogImportRepository
+	ebookRedisRepo    repository.EbookRedisRepository
+	categoryRedisRepo repository.CategoryRedisRepository
+}
+
+// NewCatalogImportService creates a new instance of CatalogImportService
+func NewCatalogImportService(
+	importRepo repository.CatalogImportRepository,
+	ebookRedisRepo repository.EbookRedisRepository,
+	categoryRedisRepo repository.CategoryRedisRepository,
+) service.CatalogImportService {
+	return &catalogImportService{
+		importRepo:        importRepo,
+		ebookRedisRepo:    ebookRedisRepo,
+		categoryRedisRepo: categoryRedisRepo,
+	}
+}
+
+func (s *catalogImportService) FindEbookIDsBySlug(ctx context.Context, slugs []string) (map[string]string, error) {
+	return s.importRepo.FindEbookIDsBySlug(ctx, slugs)
+}
+
+func (s *catalogImportService) FindAuthorIDsByName(ctx context.Context, names []string) (map[string]string, error) {
+	return s.importRepo.FindAuthorIDsByName(ctx, names)
+}
+
+func (s *catalogImportService) FindCategoryIDs(ctx context.Context, keys []string) (map[string]string, error) {
+	return s.importRepo.FindCategoryIDs(ctx, keys)
+}
+
+func (s *catalogImportService) ContentStatusIDs(ctx context.Context) (map[string]string, error) {
+	return s.importRepo.ContentStatusIDs(ctx)
+}
+
+func (s *catalogImportService) ImportBatch(ctx context.Context, batch *entity.CatalogImportBatch) error {
+	return s.importRepo.ImportBatch(ctx, batch)
+}
+
+func (s *catalogImportService) InvalidateCatalogCache(ctx context.Context) {
+	if err := s.ebookRedisRepo.InvalidateEbookCache(ctx); err != nil {
+		log.Printf("Failed to invalidate ebook cache after catalog import: %v", err)
+	}
+	if err := s.categoryRedisRepo.InvalidateCategoryCache(ctx); err != nil {
+		log.Printf("Failed to invalidate category cache after catalog import: %v", err)
+	}
+}
@@ -12,6 +12,7 @@ import (
 type categoryService struct {
 	categoryRepo     repository.CategoryRepository
 	categoryRedisRepo repository.CategoryRedisRepository
+	mediaURLs        service.MediaURLResolver
 	cacheTTL         time.Duration
 }
 
@@ -19,10 +20,12 @@ type categoryService struct {
 func NewCategoryService(
 	categoryRepo repository.CategoryRepository,
 	categoryRedisRepo repository.CategoryRedisRepository,
+	mediaURLs service.MediaURLResolver,
 ) service.CategoryService {
 	return &categoryService{
 		categoryRepo:      categoryRepo,
 		categoryRedisRepo: categoryRedisRepo,
+		mediaURLs:         mediaURLs,
 		cacheTTL:          10 * time.Minute, // 10 minutes cache TTL
 	}
 }
@@ -32,6 +35,7 @@ func (s *categoryService) GetCategoryList(ctx context.Context, limit, offset int
 	cachedCategories, err := s.categoryRedisRepo.GetCategoryList(ctx, limit, offset)
 	if err == nil && cachedCategories != nil && len(cachedCategories) > 0 {
 		log.Println("Category list retrieved from cache")
+		resolveMediaURLs(s.mediaURLs, cachedCategories...)
 		return cachedCategories, nil
 	}
 
@@ -49,6 +53,7 @@ func (s *categoryService) GetCategoryList(ctx context.Context, limit, offset int
 		}
 	}
 
+	resolveMediaURLs(s.mediaURLs, categories...)
 	return categories, nil
 }
 
@@ -57,6 +62,7 @@ func (s *categoryService) GetActiveCategoryList(ctx context.Context, limit, offs
 	cachedCategories, err := s.categoryRedisRepo.GetActiveCategoryList(ctx, limit, offset)
 	if err == nil && cachedCategories != nil && len(cachedCategories) > 0 {
 		log.Println("Active category list retrieved from cache")
+		resolveMediaURLs(s.mediaURLs, cachedCategories...)
 		return cachedCategories, nil
 	}
 
@@ -74,14 +80,31 @@ func (s *categoryService) GetActiveCategoryList(ctx context.Context, limit, offs
 		}
 	}
 
+	resolveMediaURLs(s.mediaURLs, categories...)
 	return categories, nil
 }
 
+// GetActiveCategoryListByCursor reads straight from the database; keyset pages are not cached
+func (s *categoryService) GetActiveCategoryListByCursor(ctx context.Context, cursor *entity.PageCursor, limit int) ([]*entity.Category, *entity.CursorPageInfo, error) {
+	// Fetch one extra row to know whether another page follows
+	categories, err := s.categoryRepo.ListActiveByCursor(ctx, cursor, limit+1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	categories, page := entity.BuildCursorPage(categories, limit, cursor, func(category *entity.Category) entity.PageCursor {
+		return entity.NewIntCursor(entity.CursorSortCategories, int64(category.OrderNumber), category.ID)
+	})
+	resolveMediaURLs(s.mediaURLs, categories...)
+	return categories, page, nil
+}
+
 func (s *categoryService) GetCategoryByID(ctx context.Context, id string) (*entity.Category, error) {
 	// Try to get from cache first
 	cachedCategory, err := s.categoryRedisRepo.GetCategoryByID(ctx, id)
 	if err == nil && cachedCategory != nil {
 		log.Println("Category retrieved from cache")
+		cachedCategory.ResolveMediaURLs(s.mediaURLs.URL)
 		return cachedCategory, nil
 	}
 
@@ -97,6 +120,7 @@ func (s *categoryService) GetCategoryByID(ctx context.Context, id string) (*enti
 		if err != nil {
 			log.Printf("Failed to cache category: %v", err)
 		}
+		category.ResolveMediaURLs(s.mediaURLs.URL)
 	}
 
 	return category, nil
@@ -105,7 +129,13 @@ func (s *categoryService) GetCategoryByID(ctx context.Context, id string) (*enti
 func (s *categoryService) GetCategoryByName(ctx context.Context, name string) (*entity.Category, error) {
 	// For name-based queries, we'll get directly from database
 	// as caching by name might not be as beneficial
-	return s.categoryRepo.GetByName(ctx, name)
+	category, err := s.categoryRepo.GetByName(ctx, name)
+	if err != nil || category == nil {
+		return nil, err
+	}
+
+	category.ResolveMediaURLs(s.mediaURLs.URL)
+	return category, nil
 }
 
 func (s *categoryService) CreateCategory(ctx context.Context, category *entity.Category) error {
@@ -193,7 +223,13 @@ func (s *categoryService) GetActiveCategoryCount(ctx context.Context) (int64, er
 func (s *categoryService) GetCategoriesByParent(ctx context.Context, parentID string, limit, offset int) ([]*entity.Category, error) {
 	// For parent-based queries, we'll get directly from database
 	// as caching by parent might not be as beneficial
-	return s.categoryRepo.ListByParent(ctx, parentID, limit, offset)
+	categories, err := s.categoryRepo.ListByParent(ctx, parentID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	resolveMediaURLs(s.mediaURLs, categories...)
+	return categories, nil
 }
 
 func (s *categoryService) GetCategoryCountByParent(ctx context.Context, parentID string) (int64, error) {
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"buku-pintar/internal/domain/service"
+	"context"
+	"log"
+	"time"
+)
+
+// dueScheduleBatchSize is how many due schedules are loaded per query
+const dueScheduleBatchSize = 100
+
+type contentScheduleService struct {
+	scheduleRepo   repository.ContentScheduleRepository
+	ebookRedisRepo repository.EbookRedisRepository
+}
+
+// NewContentScheduleService creates a new instance of ContentScheduleService.
+// Ebooks are the only scheduled content cached in Redis, so theirs is the only cache cleared.
+func NewContentScheduleService(
+	scheduleRepo repository.ContentScheduleRepository,
+	ebookRedisRepo repository.EbookRedisRepository,
+) service.ContentScheduleService {
+	return &contentScheduleService{
+		scheduleRepo:   scheduleRepo,
+		ebookRedisRepo: ebookRedisRepo,
+	}
+}
+
+func (s *contentScheduleService) CreateSchedule(ctx context.Context, schedule *entity.ContentSchedule) error {
+	return s.scheduleRepo.Create(ctx, schedule)
+}
+
+func (s *contentScheduleService) GetSchedule(ctx context.Context, id string) (*entity.ContentSchedule, error) {
+	return s.scheduleRepo.GetByID(ctx, id)
+}
+
+func (s *contentScheduleService) ListSchedules(ctx context.Context, contentType entity.SchedulableContent, contentID string) ([]*entity.ContentSchedule, error) {
+	return s.scheduleRepo.ListByContent(ctx, contentType, contentID)
+}
+
+func (s *contentScheduleService) DeleteSchedule(ctx context.Context, id string) error {
+	return s.scheduleRepo.Delete(ctx, id)
+}
+
+func (s *contentScheduleService) ContentExists(ctx context.Context, contentType entity.SchedulableContent, contentID string) (bool, error) {
+	return s.scheduleRepo.ContentExists(ctx, contentType, contentID)
+}
+
+func (s *contentScheduleService) ApplyDueSchedules(ctx context.Context, now time.Time) (int, error) {
+	applied := 0
+	ebooksChanged := false
+	// The cache is cleared even when a later schedule fails, so the ones already applied show up
+	defer func() {
+		if ebooksChanged {
+			s.invalidateEbookCache(ctx)
+		}
+	}()
+
+	for {
+		schedules, err := s.scheduleRepo.ListDue(ctx, now, dueScheduleBatchSize)
+		if err != nil {
+			return applied, err
+		}
+		for _, schedule := range schedules {
+			ok, err := s.scheduleRepo.Apply(ctx, schedule.ID, now)
+			if err != nil {
+				return applied, err
+			}
+			if !ok {
+				continue
+			}
+			applied++
+			if schedule.ContentType == entity.SchedulableEbook {
+				ebooksChanged = true
+			}
+		}
+		if len(schedules) < dueScheduleBatchSize {
+			return applied, nil
+		}
+	}
+}
+
+func (s *contentScheduleService) invalidateEbookCache(ctx context.Context) {
+	if err := s.ebookRedisRepo.InvalidateEbookCache(ctx); err != nil {
+		log.Printf("Failed to invalidate ebook cache after scheduled publishing: %v", err)
+	}
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+	"sort"
+	"testing"
+	"time"
+)
+
+// MockContentScheduleRepository keeps schedules in memory and records the statuses applied to content
+type MockContentScheduleRepository struct {
+	schedules map[string]*entity.ContentSchedule
+	statuses  map[string]string
+}
+
+func NewMockContentScheduleRepository(schedules ...*entity.ContentSchedule) *MockContentScheduleRepository {
+	m := &MockContentScheduleRepository{schedules: map[string]*entity.ContentSchedule{}, statuses: map[string]string{}}
+	for _, schedule := range schedules {
+		m.schedules[schedule.ID] = schedule
+	}
+	return m
+}
+
+func (m *MockContentScheduleRepository) Create(ctx context.Context, schedule *entity.ContentSchedule) error {
+	m.schedules[schedule.ID] = schedule
+	return nil
+}
+
+func (m *MockContentScheduleRepository) GetByID(ctx context.Context, id string) (*entity.ContentSchedule, error) {
+	return m.schedules[id], nil
+}
+
+func (m *MockContentScheduleRepository) ListByContent(ctx context.Context, contentType entity.SchedulableContent, contentID string) ([]*entity.ContentSchedule, error) {
+	var schedules []*entity.ContentSchedule
+	for _, schedule := range m.schedules {
+		if schedule.ContentType == contentType && schedule.ContentID == contentID {
+			schedules = append(schedules, schedule)
+		}
+	}
+	return schedules, nil
+}
+
+func (m *MockContentScheduleRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*entity.ContentSchedule, error) {
+	var due []*entity.ContentSchedule
+	for _, schedule := range m.schedules {
+		if schedule.IsPending() && !schedule.RunAt.After(now) {
+			due = append(due, schedule)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].RunAt.Before(due[j].RunAt) })
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+func (m *MockContentScheduleRepository) Delete(ctx context.Context, id string) error {
+	delete(m.schedules, id)
+	return nil
+}
+
+func (m *MockContentScheduleRepository) ContentExists(ctx context.Context, contentType entity.SchedulableContent, contentID string) (bool, error) {
+	return true, nil
+}
+
+func (m *MockContentScheduleRepository) Apply(ctx context.Context, id string, appliedAt time.Time) (bool, error) {
+	schedule := m.schedules[id]
+	if schedule == nil || !schedule.IsPending() {
+		return false, nil
+	}
+	m.statuses[schedule.ContentID] = schedule.Action.ContentStatus()
+	schedule.AppliedAt = &appliedAt
+	return true, nil
+}
+
+func TestContentScheduleService_ApplyDueSchedules(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	repo := NewMockContentScheduleRepository(
+		&entity.ContentSchedule{ID: "s1", ContentType: entity.SchedulableEbook, ContentID: "ebook-1", Action: entity.SchedulePublish, RunAt: now.Add(-time.Minute)},
+		&entity.ContentSchedule{ID: "s2", ContentType: entity.SchedulableArticle, ContentID: "article-1", Action: entity.ScheduleUnpublish, RunAt: now},
+		&entity.ContentSchedule{ID: "s3", ContentType: entity.SchedulableEbook, ContentID: "ebook-2", Action: entity.SchedulePublish, RunAt: now.Add(time.Hour)},
+	)
+	redisRepo := &MockEbookRedisRepository{}
+	s := NewContentScheduleService(repo, redisRepo)
+
+	applied, err := s.ApplyDueSchedules(ctx, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if applied != 2 {
+		t.Errorf("expected 2 schedules applied, got %d", applied)
+	}
+	if repo.statuses["ebook-1"] != entity.ContentStatusPublished || repo.statuses["article-1"] != entity.ContentStatusArchived {
+		t.Errorf("unexpected statuses %v", repo.statuses)
+	}
+	if _, ok := repo.statuses["ebook-2"]; ok {
+		t.Error("expected the future schedule to be left alone")
+	}
+	if redisRepo.invalidations != 1 {
+		t.Errorf("expected the ebook cache to be invalidated once, got %d", redisRepo.invalidations)
+	}
+
+	// Nothing is due any more, so the cache is left alone
+	applied, err = s.ApplyDueSchedules(ctx, now)
+	if err != nil || applied != 0 {
+		t.Errorf("expected nothing to apply, got %d, %v", applied, err)
+	}
+	if redisRepo.invalidations != 1 {
+		t.Errorf("expected no further invalidation, got %d", redisRepo.invalidations)
+	}
+}
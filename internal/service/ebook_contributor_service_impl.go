@@ -0,0 +1,37 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"buku-pintar/internal/domain/service"
+	"context"
+	"log"
+)
+
+type ebookContributorService struct {
+	contributorRepo repository.EbookContributorRepository
+	ebookRedisRepo  repository.EbookRedisRepository
+}
+
+func NewEbookContributorService(contributorRepo repository.EbookContributorRepository, ebookRedisRepo repository.EbookRedisRepository) service.EbookContributorService {
+	return &ebookContributorService{
+		contributorRepo: contributorRepo,
+		ebookRedisRepo:  ebookRedisRepo,
+	}
+}
+
+func (s *ebookContributorService) GetContributors(ctx context.Context, ebookID string) ([]*entity.EbookContributor, error) {
+	return s.contributorRepo.ListByEbook(ctx, ebookID)
+}
+
+// ReplaceContributors clears the ebook cache, as the cached detail and author listings show the contributors
+func (s *ebookContributorService) ReplaceContributors(ctx context.Context, ebookID string, contributors []*entity.EbookContributor) error {
+	if err := s.contributorRepo.ReplaceByEbook(ctx, ebookID, contributors); err != nil {
+		return err
+	}
+
+	if err := s.ebookRedisRepo.InvalidateEbookCache(ctx); err != nil {
+		log.Printf("Failed to invalidate ebook cache after contributor change: %v", err)
+	}
+	return nil
+}
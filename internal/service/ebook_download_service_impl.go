@@ -0,0 +1,30 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"buku-pintar/internal/domain/service"
+	"context"
+)
+
+type ebookDownloadService struct {
+	downloadRepo repository.EbookDownloadRepository
+}
+
+func NewEbookDownloadService(downloadRepo repository.EbookDownloadRepository) service.EbookDownloadService {
+	return &ebookDownloadService{
+		downloadRepo: downloadRepo,
+	}
+}
+
+func (s *ebookDownloadService) LogDownload(ctx context.Context, download *entity.EbookDownload) error {
+	return s.downloadRepo.Create(ctx, download)
+}
+
+func (s *ebookDownloadService) GetDownloadsByUserID(ctx context.Context, userID string, limit, offset int) ([]*entity.EbookDownload, error) {
+	return s.downloadRepo.ListByUserID(ctx, userID, limit, offset)
+}
+
+func (s *ebookDownloadService) GetDownloadCountByUserID(ctx context.Context, userID string) (int64, error) {
+	return s.downloadRepo.CountByUserID(ctx, userID)
+}
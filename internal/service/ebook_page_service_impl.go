@@ -0,0 +1,34 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"buku-pintar/internal/domain/service"
+	"context"
+)
+
+type ebookPageService struct {
+	pageRepo repository.EbookPageRepository
+}
+
+func NewEbookPageService(pageRepo repository.EbookPageRepository) service.EbookPageService {
+	return &ebookPageService{
+		pageRepo: pageRepo,
+	}
+}
+
+func (s *ebookPageService) CreatePage(ctx context.Context, page *entity.EbookPage) error {
+	return s.pageRepo.Create(ctx, page)
+}
+
+func (s *ebookPageService) ReplacePages(ctx context.Context, ebookID string, pages []*entity.EbookPage) error {
+	return s.pageRepo.ReplaceByEbookID(ctx, ebookID, pages)
+}
+
+func (s *ebookPageService) GetPage(ctx context.Context, ebookID string, pageNumber int) (*entity.EbookPage, error) {
+	return s.pageRepo.GetByNumber(ctx, ebookID, pageNumber)
+}
+
+func (s *ebookPageService) GetPageCount(ctx context.Context, ebookID string) (int64, error) {
+	return s.pageRepo.CountByEbookID(ctx, ebookID)
+}
@@ -12,17 +12,21 @@ import (
 type ebookService struct {
 	ebookRepo      repository.EbookRepository
 	ebookRedisRepo repository.EbookRedisRepository
+	mediaURLs      service.MediaURLResolver
 	cacheTTL       time.Duration
 }
 
 // NewEbookService creates a new instance of EbookService
+// Covers are cached with their media keys and resolved to URLs on the way out
 func NewEbookService(
 	ebookRepo repository.EbookRepository,
 	ebookRedisRepo repository.EbookRedisRepository,
+	mediaURLs service.MediaURLResolver,
 ) service.EbookService {
 	return &ebookService{
 		ebookRepo:      ebookRepo,
 		ebookRedisRepo: ebookRedisRepo,
+		mediaURLs:      mediaURLs,
 		cacheTTL:       15 * time.Minute, // 15 minutes cache TTL
 	}
 }
@@ -44,6 +48,7 @@ func (s *ebookService) GetEbookByID(ctx context.Context, id string) (*entity.Ebo
 	cachedEbook, err := s.ebookRedisRepo.GetEbookByID(ctx, id)
 	if err == nil && cachedEbook != nil {
 		log.Println("Ebook retrieved from cache by ID")
+		cachedEbook.ResolveMediaURLs(s.mediaURLs.URL)
 		return cachedEbook, nil
 	}
 
@@ -59,6 +64,7 @@ func (s *ebookService) GetEbookByID(ctx context.Context, id string) (*entity.Ebo
 		if err != nil {
 			log.Printf("Failed to cache ebook by ID: %v", err)
 		}
+		ebook.ResolveMediaURLs(s.mediaURLs.URL)
 	}
 
 	return ebook, nil
@@ -69,6 +75,7 @@ func (s *ebookService) GetEbookBySlug(ctx context.Context, slug string) (*entity
 	cachedEbook, err := s.ebookRedisRepo.GetEbookBySlug(ctx, slug)
 	if err == nil && cachedEbook != nil {
 		log.Println("Ebook retrieved from cache by slug")
+		cachedEbook.ResolveMediaURLs(s.mediaURLs.URL)
 		return cachedEbook, nil
 	}
 
@@ -84,6 +91,7 @@ func (s *ebookService) GetEbookBySlug(ctx context.Context, slug string) (*entity
 		if err != nil {
 			log.Printf("Failed to cache ebook by slug: %v", err)
 		}
+		ebook.ResolveMediaURLs(s.mediaURLs.URL)
 	}
 
 	return ebook, nil
@@ -113,36 +121,95 @@ func (s *ebookService) DeleteEbook(ctx context.Context, id string) error {
 	return nil
 }
 
-func (s *ebookService) GetEbookList(ctx context.Context, limit, offset int) ([]*entity.EbookList, error) {
+func (s *ebookService) GetEbookList(ctx context.Context, filter *entity.EbookFilter, limit, offset int) ([]*entity.EbookList, error) {
 	// Try to get from cache first
-	cachedEbooks, err := s.ebookRedisRepo.GetEbookList(ctx, limit, offset)
+	cachedEbooks, err := s.ebookRedisRepo.GetEbookList(ctx, filter, limit, offset)
 	if err == nil && cachedEbooks != nil && len(cachedEbooks) > 0 {
 		log.Println("Ebook list retrieved from cache")
+		resolveMediaURLs(s.mediaURLs, cachedEbooks...)
 		return cachedEbooks, nil
 	}
 
 	// If not in cache, get from database
-	ebooks, err := s.ebookRepo.List(ctx, limit, offset)
+	ebooks, err := s.ebookRepo.List(ctx, filter, limit, offset)
 	if err != nil {
 		return nil, err
 	}
 
 	// Cache the result
 	if len(ebooks) > 0 {
-		err = s.ebookRedisRepo.SetEbookList(ctx, ebooks, limit, offset)
+		err = s.ebookRedisRepo.SetEbookList(ctx, ebooks, filter, limit, offset)
 		if err != nil {
 			log.Printf("Failed to cache ebook list: %v", err)
 		}
 	}
 
+	resolveMediaURLs(s.mediaURLs, ebooks...)
 	return ebooks, nil
 }
 
+// GetEbookListByCursor reads straight from the database; keyset pages stay stable while
+// new ebooks are published, which a cached page would not
+func (s *ebookService) GetEbookListByCursor(ctx context.Context, filter *entity.EbookFilter, cursor *entity.PageCursor, limit int) ([]*entity.EbookList, *entity.CursorPageInfo, error) {
+	// Fetch one extra row to know whether another page follows
+	ebooks, err := s.ebookRepo.ListByCursor(ctx, filter, cursor, limit+1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sort := entity.EbookSortNewest
+	if filter != nil && filter.Sort != "" {
+		sort = filter.Sort
+	}
+
+	ebooks, page := entity.BuildCursorPage(ebooks, limit, cursor, func(ebook *entity.EbookList) entity.PageCursor {
+		return ebookPageCursor(sort, ebook)
+	})
+	resolveMediaURLs(s.mediaURLs, ebooks...)
+	return ebooks, page, nil
+}
+
+// ebookPageCursor builds the cursor of a catalog row from the column the catalog is sorted on
+func ebookPageCursor(sort entity.EbookSort, ebook *entity.EbookList) entity.PageCursor {
+	key := entity.EbookCursorSort(sort)
+	switch sort {
+	case entity.EbookSortPriceAsc, entity.EbookSortPriceDesc:
+		price := ebook.Price
+		if ebook.Discount != nil {
+			price = *ebook.Discount
+		}
+		return entity.NewIntCursor(key, int64(price), ebook.ID)
+	case entity.EbookSortTitle:
+		return entity.PageCursor{Sort: key, Value: ebook.Title, ID: ebook.ID}
+	case entity.EbookSortPopularity:
+		return entity.NewIntCursor(key, int64(ebook.PopularityScore), ebook.ID)
+	default:
+		var publishedAt time.Time
+		if ebook.PublishedAt != nil {
+			publishedAt = *ebook.PublishedAt
+		}
+		return entity.NewTimeCursor(key, publishedAt, ebook.ID)
+	}
+}
+
+// GetEbookFeedEntries reads straight from the database; feeds are polled by clients that
+// already cache them and are expected to reflect new releases promptly
+func (s *ebookService) GetEbookFeedEntries(ctx context.Context, filter *entity.EbookFilter, limit, offset int) ([]*entity.EbookFeedEntry, error) {
+	entries, err := s.ebookRepo.ListFeedEntries(ctx, filter, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	resolveMediaURLs(s.mediaURLs, entries...)
+	return entries, nil
+}
+
 func (s *ebookService) GetEbookListByCategory(ctx context.Context, categoryID string, limit, offset int) ([]*entity.Ebook, error) {
 	// Try to get from cache first
 	cachedEbooks, err := s.ebookRedisRepo.GetEbookListByCategory(ctx, categoryID, limit, offset)
 	if err == nil && cachedEbooks != nil && len(cachedEbooks) > 0 {
 		log.Println("Ebook list by category retrieved from cache")
+		resolveMediaURLs(s.mediaURLs, cachedEbooks...)
 		return cachedEbooks, nil
 	}
 
@@ -160,6 +227,7 @@ func (s *ebookService) GetEbookListByCategory(ctx context.Context, categoryID st
 		}
 	}
 
+	resolveMediaURLs(s.mediaURLs, ebooks...)
 	return ebooks, nil
 }
 
@@ -168,6 +236,7 @@ func (s *ebookService) GetEbookListByAuthor(ctx context.Context, authorID string
 	cachedEbooks, err := s.ebookRedisRepo.GetEbookListByAuthor(ctx, authorID, limit, offset)
 	if err == nil && cachedEbooks != nil && len(cachedEbooks) > 0 {
 		log.Println("Ebook list by author retrieved from cache")
+		resolveMediaURLs(s.mediaURLs, cachedEbooks...)
 		return cachedEbooks, nil
 	}
 
@@ -185,25 +254,26 @@ func (s *ebookService) GetEbookListByAuthor(ctx context.Context, authorID string
 		}
 	}
 
+	resolveMediaURLs(s.mediaURLs, ebooks...)
 	return ebooks, nil
 }
 
-func (s *ebookService) GetEbookCount(ctx context.Context) (int64, error) {
+func (s *ebookService) GetEbookCount(ctx context.Context, filter *entity.EbookFilter) (int64, error) {
 	// Try to get count from cache first
-	cachedCount, err := s.ebookRedisRepo.GetEbookTotal(ctx)
+	cachedCount, err := s.ebookRedisRepo.GetEbookTotal(ctx, filter)
 	if err == nil && cachedCount > 0 {
 		log.Println("Ebook count retrieved from cache")
 		return cachedCount, nil
 	}
 
 	// If not in cache, get from database
-	count, err := s.ebookRepo.Count(ctx)
+	count, err := s.ebookRepo.Count(ctx, filter)
 	if err != nil {
 		return 0, err
 	}
 
 	// Cache the count
-	err = s.ebookRedisRepo.SetEbookTotal(ctx, count)
+	err = s.ebookRedisRepo.SetEbookTotal(ctx, filter, count)
 	if err != nil {
 		log.Printf("Failed to cache ebook count: %v", err)
 	}
@@ -211,6 +281,29 @@ func (s *ebookService) GetEbookCount(ctx context.Context) (int64, error) {
 	return count, nil
 }
 
+func (s *ebookService) GetEbookFacets(ctx context.Context, filter *entity.EbookFilter) (*entity.EbookFacets, error) {
+	// Try to get facets from cache first
+	cachedFacets, err := s.ebookRedisRepo.GetEbookFacets(ctx, filter)
+	if err == nil && cachedFacets != nil {
+		log.Println("Ebook facets retrieved from cache")
+		return cachedFacets, nil
+	}
+
+	// If not in cache, get from database
+	facets, err := s.ebookRepo.Facets(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cache the facets
+	err = s.ebookRedisRepo.SetEbookFacets(ctx, filter, facets)
+	if err != nil {
+		log.Printf("Failed to cache ebook facets: %v", err)
+	}
+
+	return facets, nil
+}
+
 func (s *ebookService) GetEbookCountByCategory(ctx context.Context, categoryID string) (int64, error) {
 	// Try to get count from cache first
 	cachedCount, err := s.ebookRedisRepo.GetEbookCountByCategory(ctx, categoryID)
@@ -39,10 +39,21 @@ func (m *MockEbookRepository) Delete(ctx context.Context, id string) error {
 	return m.err
 }
 
-func (m *MockEbookRepository) List(ctx context.Context, limit, offset int) ([]*entity.EbookList, error) {
+func (m *MockEbookRepository) List(ctx context.Context, filter *entity.EbookFilter, limit, offset int) ([]*entity.EbookList, error) {
 	return m.ebookList, m.err
 }
 
+func (m *MockEbookRepository) ListByCursor(ctx context.Context, filter *entity.EbookFilter, cursor *entity.PageCursor, limit int) ([]*entity.EbookList, error) {
+	if len(m.ebookList) > limit {
+		return m.ebookList[:limit], m.err
+	}
+	return m.ebookList, m.err
+}
+
+func (m *MockEbookRepository) ListFeedEntries(ctx context.Context, filter *entity.EbookFilter, limit, offset int) ([]*entity.EbookFeedEntry, error) {
+	return nil, m.err
+}
+
 func (m *MockEbookRepository) ListByCategory(ctx context.Context, categoryID string, limit, offset int) ([]*entity.Ebook, error) {
 	return m.ebooks, m.err
 }
@@ -51,10 +62,14 @@ func (m *MockEbookRepository) ListByAuthor(ctx context.Context, authorID string,
 	return m.ebooks, m.err
 }
 
-func (m *MockEbookRepository) Count(ctx context.Context) (int64, error) {
+func (m *MockEbookRepository) Count(ctx context.Context, filter *entity.EbookFilter) (int64, error) {
 	return m.count, m.err
 }
 
+func (m *MockEbookRepository) Facets(ctx context.Context, filter *entity.EbookFilter) (*entity.EbookFacets, error) {
+	return &entity.EbookFacets{}, m.err
+}
+
 func (m *MockEbookRepository) CountByCategory(ctx context.Context, categoryID string) (int64, error) {
 	return m.count, m.err
 }
@@ -72,27 +87,36 @@ type MockEbookRedisRepository struct {
 	err       error
 	count     int64
 	cacheHit  bool
+	invalidations int
 }
 
-func (m *MockEbookRedisRepository) GetEbookList(ctx context.Context, limit, offset int) ([]*entity.EbookList, error) {
+func (m *MockEbookRedisRepository) GetEbookList(ctx context.Context, filter *entity.EbookFilter, limit, offset int) ([]*entity.EbookList, error) {
 	if m.cacheHit {
 		return m.ebookList, nil
 	}
 	return nil, m.err
 }
 
-func (m *MockEbookRedisRepository) SetEbookList(ctx context.Context, ebooks []*entity.EbookList, limit, offset int) error {
+func (m *MockEbookRedisRepository) SetEbookList(ctx context.Context, ebooks []*entity.EbookList, filter *entity.EbookFilter, limit, offset int) error {
 	return m.err
 }
 
-func (m *MockEbookRedisRepository) GetEbookTotal(ctx context.Context) (int64, error) {
+func (m *MockEbookRedisRepository) GetEbookTotal(ctx context.Context, filter *entity.EbookFilter) (int64, error) {
 	if m.cacheHit {
 		return m.count, nil
 	}
 	return 0, m.err
 }
 
-func (m *MockEbookRedisRepository) SetEbookTotal(ctx context.Context, count int64) error {
+func (m *MockEbookRedisRepository) SetEbookTotal(ctx context.Context, filter *entity.EbookFilter, count int64) error {
+	return m.err
+}
+
+func (m *MockEbookRedisRepository) GetEbookFacets(ctx context.Context, filter *entity.EbookFilter) (*entity.EbookFacets, error) {
+	return nil, m.err
+}
+
+func (m *MockEbookRedisRepository) SetEbookFacets(ctx context.Context, filter *entity.EbookFilter, facets *entity.EbookFacets) error {
 	return m.err
 }
 
@@ -163,9 +187,17 @@ func (m *MockEbookRedisRepository) SetEbookCountByAuthor(ctx context.Context, au
 }
 
 func (m *MockEbookRedisRepository) InvalidateEbookCache(ctx context.Context) error {
+	m.invalidations++
 	return m.err
 }
 
+// testMediaURLs serves media from a fixed CDN host
+type testMediaURLs struct{}
+
+func (testMediaURLs) URL(key string) string {
+	return "https://cdn.test/" + key
+}
+
 func TestEbookService_GetEbookByID_CacheHit(t *testing.T) {
 	// Arrange
 	expectedEbook := &entity.Ebook{
@@ -186,7 +218,7 @@ func TestEbookService_GetEbookByID_CacheHit(t *testing.T) {
 		cacheHit: true,
 	}
 
-	service := NewEbookService(mockRepo, mockRedisRepo)
+	service := NewEbookService(mockRepo, mockRedisRepo, testMediaURLs{})
 	ctx := context.Background()
 
 	// Act
@@ -226,7 +258,7 @@ func TestEbookService_GetEbookByID_CacheMiss(t *testing.T) {
 		cacheHit: false,
 	}
 
-	service := NewEbookService(mockRepo, mockRedisRepo)
+	service := NewEbookService(mockRepo, mockRedisRepo, testMediaURLs{})
 	ctx := context.Background()
 
 	// Act
@@ -270,11 +302,11 @@ func TestEbookService_GetEbookList_CacheHit(t *testing.T) {
 		cacheHit:  true,
 	}
 
-	service := NewEbookService(mockRepo, mockRedisRepo)
+	service := NewEbookService(mockRepo, mockRedisRepo, testMediaURLs{})
 	ctx := context.Background()
 
 	// Act
-	result, err := service.GetEbookList(ctx, 10, 0)
+	result, err := service.GetEbookList(ctx, &entity.EbookFilter{}, 10, 0)
 
 	// Assert
 	if err != nil {
@@ -295,11 +327,11 @@ func TestEbookService_GetEbookCount_CacheHit(t *testing.T) {
 		cacheHit: true,
 	}
 
-	service := NewEbookService(mockRepo, mockRedisRepo)
+	service := NewEbookService(mockRepo, mockRedisRepo, testMediaURLs{})
 	ctx := context.Background()
 
 	// Act
-	result, err := service.GetEbookCount(ctx)
+	result, err := service.GetEbookCount(ctx, &entity.EbookFilter{})
 
 	// Assert
 	if err != nil {
@@ -309,3 +341,84 @@ func TestEbookService_GetEbookCount_CacheHit(t *testing.T) {
 		t.Errorf("expected count %d, got %d", expectedCount, result)
 	}
 }
+
+func TestEbookService_GetEbookListByCursor(t *testing.T) {
+	publishedAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	rows := []*entity.EbookList{
+		{ID: "ebook-3", Title: "Third", PublishedAt: &publishedAt},
+		{ID: "ebook-2", Title: "Second", PublishedAt: &publishedAt},
+		{ID: "ebook-1", Title: "First", PublishedAt: &publishedAt},
+	}
+
+	t.Run("first page trims the extra row and sets only the next cursor", func(t *testing.T) {
+		service := NewEbookService(&MockEbookRepository{ebookList: rows}, &MockEbookRedisRepository{}, testMediaURLs{})
+
+		result, page, err := service.GetEbookListByCursor(context.Background(), &entity.EbookFilter{Sort: entity.EbookSortNewest}, nil, 2)
+		if err != nil {
+			t.Fatalf("expected no error but got: %v", err)
+		}
+		if len(result) != 2 {
+			t.Fatalf("expected 2 ebooks, got %d", len(result))
+		}
+		if page.Prev != nil {
+			t.Errorf("expected no previous cursor on the first page")
+		}
+		if page.Next == nil || page.Next.ID != "ebook-2" || page.Next.Backward {
+			t.Errorf("expected forward next cursor at ebook-2, got %+v", page.Next)
+		}
+		if page.Next.Sort != entity.EbookCursorSort(entity.EbookSortNewest) {
+			t.Errorf("expected cursor sort %q, got %q", entity.EbookCursorSort(entity.EbookSortNewest), page.Next.Sort)
+		}
+	})
+
+	t.Run("backward page is returned in display order", func(t *testing.T) {
+		// Backward queries return the nearest rows first, i.e. reversed
+		reversed := []*entity.EbookList{rows[2], rows[1]}
+		service := NewEbookService(&MockEbookRepository{ebookList: reversed}, &MockEbookRedisRepository{}, testMediaURLs{})
+		cursor := &entity.PageCursor{Sort: entity.EbookCursorSort(entity.EbookSortNewest), ID: "ebook-0", Backward: true}
+
+		result, page, err := service.GetEbookListByCursor(context.Background(), &entity.EbookFilter{Sort: entity.EbookSortNewest}, cursor, 2)
+		if err != nil {
+			t.Fatalf("expected no error but got: %v", err)
+		}
+		if len(result) != 2 || result[0].ID != "ebook-2" || result[1].ID != "ebook-1" {
+			t.Fatalf("expected ebook-2, ebook-1 in display order, got %+v", result)
+		}
+		if page.Prev != nil {
+			t.Errorf("expected no previous cursor when the backward query did not over-fetch")
+		}
+		if page.Next == nil || page.Next.ID != "ebook-1" {
+			t.Errorf("expected next cursor at ebook-1, got %+v", page.Next)
+		}
+	})
+}
+
+func TestEbookService_ResolvesCoverMedia(t *testing.T) {
+	coverKey := "covers/ab/ab12.jpg"
+	ebook := &entity.Ebook{ID: "ebook-1", CoverImage: "https://old.test/cover.jpg", CoverMediaKey: &coverKey}
+	list := []*entity.EbookList{
+		{ID: "ebook-1", CoverImage: "https://old.test/cover.jpg", CoverMediaKey: &coverKey},
+		{ID: "ebook-2", CoverImage: "https://old.test/other.jpg"},
+	}
+
+	service := NewEbookService(&MockEbookRepository{ebook: ebook, ebookList: list}, &MockEbookRedisRepository{}, testMediaURLs{})
+
+	result, err := service.GetEbookByID(context.Background(), "ebook-1")
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	if result.CoverImage != "https://cdn.test/covers/ab/ab12.jpg" {
+		t.Errorf("expected the cover to point at the uploaded media, got %s", result.CoverImage)
+	}
+
+	ebooks, err := service.GetEbookList(context.Background(), nil, 10, 0)
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+	if ebooks[0].CoverImage != "https://cdn.test/covers/ab/ab12.jpg" {
+		t.Errorf("expected the listed cover to point at the uploaded media, got %s", ebooks[0].CoverImage)
+	}
+	if ebooks[1].CoverImage != "https://old.test/other.jpg" {
+		t.Errorf("expected an ebook without media to keep its cover, got %s", ebooks[1].CoverImage)
+	}
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"buku-pintar/internal/domain/service"
+	"context"
+	"strings"
+)
+
+type entitlementService struct {
+	paymentRepo       repository.PaymentRepository
+	roleService       service.RoleService
+	permissionService service.PermissionService
+}
+
+func NewEntitlementService(
+	paymentRepo repository.PaymentRepository,
+	roleService service.RoleService,
+	permissionService service.PermissionService,
+) service.EntitlementService {
+	return &entitlementService{
+		paymentRepo:       paymentRepo,
+		roleService:       roleService,
+		permissionService: permissionService,
+	}
+}
+
+func (s *entitlementService) OwnsEbook(ctx context.Context, userID, ebookID string) (bool, error) {
+	if userID == "" || ebookID == "" {
+		return false, nil
+	}
+	return s.paymentRepo.HasPaidForEbook(ctx, userID, ebookID)
+}
+
+func (s *entitlementService) HasPremiumAccess(ctx context.Context, user *entity.User) (bool, error) {
+	if user == nil || user.RoleID == nil {
+		return false, nil
+	}
+
+	role, err := s.roleService.GetRoleByID(ctx, *user.RoleID)
+	if err != nil || role == nil {
+		return false, err
+	}
+
+	// Premium subscribers and admins can open every book
+	return strings.EqualFold(role.Name, string(entity.RoleTypePremium)) ||
+		strings.EqualFold(role.Name, string(entity.RoleTypeAdmin)), nil
+}
+
+func (s *entitlementService) CanAccessEbook(ctx context.Context, user *entity.User, ebook *entity.Ebook) (bool, error) {
+	if ebook == nil || user == nil {
+		return false, nil
+	}
+
+	// Free books only need an account
+	if ebook.Price == 0 {
+		return true, nil
+	}
+
+	owns, err := s.OwnsEbook(ctx, user.ID, ebook.ID)
+	if err != nil || owns {
+		return owns, err
+	}
+
+	return s.HasPremiumAccess(ctx, user)
+}
+
+func (s *entitlementService) CanEditEbooks(ctx context.Context, user *entity.User) (bool, error) {
+	if user == nil {
+		return false, nil
+	}
+	return s.permissionService.HasPermission(ctx, user.ID, entity.PermissionEbookUpdate)
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"buku-pintar/internal/domain/service"
+	"context"
+	"fmt"
+)
+
+type feedService struct {
+	articleRepo     repository.ArticleRepository
+	inspirationRepo repository.InspirationRepository
+}
+
+// NewFeedService creates a new instance of FeedService
+func NewFeedService(articleRepo repository.ArticleRepository, inspirationRepo repository.InspirationRepository) service.FeedService {
+	return &feedService{
+		articleRepo:     articleRepo,
+		inspirationRepo: inspirationRepo,
+	}
+}
+
+// GetFeedItems reads straight from the database; feed readers poll with conditional requests,
+// so unchanged feeds cost a query but no transfer
+func (s *feedService) GetFeedItems(ctx context.Context, content entity.FeedContent, filter *entity.FeedFilter, limit int) ([]*entity.FeedItem, error) {
+	switch content {
+	case entity.FeedArticles:
+		return s.articleRepo.ListFeedItems(ctx, filter, limit)
+	case entity.FeedInspirations:
+		return s.inspirationRepo.ListFeedItems(ctx, filter, limit)
+	}
+	return nil, fmt.Errorf("no feed items for content %q", content)
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"buku-pintar/internal/domain/service"
+	"buku-pintar/pkg/storage"
+	"context"
+	"io"
+	"time"
+)
+
+type mediaService struct {
+	mediaRepo   repository.MediaRepository
+	storage     storage.Storage
+	locationTTL time.Duration
+}
+
+// NewMediaService creates a new instance of MediaService.
+// locationTTL is how long signed locations of private media stay valid.
+func NewMediaService(
+	mediaRepo repository.MediaRepository,
+	storage storage.Storage,
+	locationTTL time.Duration,
+) service.MediaService {
+	return &mediaService{
+		mediaRepo:   mediaRepo,
+		storage:     storage,
+		locationTTL: locationTTL,
+	}
+}
+
+func (s *mediaService) StoreMedia(ctx context.Context, media *entity.Media, body io.Reader) (*entity.Media, error) {
+	// Keys are derived from the checksum, so rewriting an existing object stores identical bytes
+	if err := s.storage.Put(ctx, media.StorageKey, body, media.Size, media.ContentType); err != nil {
+		return nil, err
+	}
+
+	created, err := s.mediaRepo.Create(ctx, media)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return s.mediaRepo.GetByChecksum(ctx, media.Kind, media.Checksum)
+	}
+
+	return media, nil
+}
+
+func (s *mediaService) GetMediaByID(ctx context.Context, id string) (*entity.Media, error) {
+	return s.mediaRepo.GetByID(ctx, id)
+}
+
+func (s *mediaService) GetMediaByStorageKey(ctx context.Context, key string) (*entity.Media, error) {
+	return s.mediaRepo.GetByStorageKey(ctx, key)
+}
+
+func (s *mediaService) GetMediaByChecksum(ctx context.Context, kind entity.MediaKind, checksum string) (*entity.Media, error) {
+	return s.mediaRepo.GetByChecksum(ctx, kind, checksum)
+}
+
+func (s *mediaService) OpenMedia(ctx context.Context, key string) (io.ReadCloser, error) {
+	return s.storage.Open(ctx, key)
+}
+
+func (s *mediaService) URL(key string) string {
+	return s.storage.URL(key)
+}
+
+func (s *mediaService) FileLocation(key string) (string, error) {
+	return s.storage.Location(key, s.locationTTL)
+}
+
+func (s *mediaService) ListMediaByKind(ctx context.Context, kind entity.MediaKind, afterID string, limit int) ([]*entity.Media, error) {
+	return s.mediaRepo.ListByKind(ctx, kind, afterID, limit)
+}
+
+func (s *mediaService) StoreMediaVariant(ctx context.Context, variant *entity.MediaVariant, body io.Reader) error {
+	if err := s.storage.Put(ctx, variant.StorageKey, body, variant.Size, variant.ContentType()); err != nil {
+		return err
+	}
+	return s.mediaRepo.SaveVariant(ctx, variant)
+}
+
+func (s *mediaService) ListMediaVariants(ctx context.Context, mediaID string) ([]*entity.MediaVariant, error) {
+	return s.mediaRepo.ListVariants(ctx, mediaID)
+}
+
+func (s *mediaService) GetMediaVariantByStorageKey(ctx context.Context, key string) (*entity.MediaVariant, error) {
+	return s.mediaRepo.GetVariantByStorageKey(ctx, key)
+}
+
+// mediaResolvable is an entity whose URLs can point at attached media
+type mediaResolvable interface {
+	ResolveMediaURLs(url func(key string) string)
+}
+
+// resolveMediaURLs points every item at its attached media, if any
+func resolveMediaURLs[T mediaResolvable](resolver service.MediaURLResolver, items ...T) {
+	for _, item := range items {
+		item.ResolveMediaURLs(resolver.URL)
+	}
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"buku-pintar/internal/domain/service"
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+)
+
+// discountNotifyBatchSize bounds how many started discounts one run announces
+const discountNotifyBatchSize = 100
+
+type notificationService struct {
+	notificationRepo  repository.NotificationRepository
+	ebookDiscountRepo repository.EbookDiscountRepository
+	ebookRepo         repository.EbookRepository
+}
+
+func NewNotificationService(
+	notificationRepo repository.NotificationRepository,
+	ebookDiscountRepo repository.EbookDiscountRepository,
+	ebookRepo repository.EbookRepository,
+) service.NotificationService {
+	return &notificationService{
+		notificationRepo:  notificationRepo,
+		ebookDiscountRepo: ebookDiscountRepo,
+		ebookRepo:         ebookRepo,
+	}
+}
+
+func (s *notificationService) NotifyPriceDrop(ctx context.Context, drop *entity.PriceDrop) (int64, error) {
+	payload, err := json.Marshal(drop)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.notificationRepo.CreateForWishlisters(ctx, drop.EbookID, entity.NotificationPriceDrop, payload)
+}
+
+// NotifyStartedDiscounts marks each discount only after its events are written, so a failed run
+// is retried on the next tick; a crash in between can announce a discount twice, never zero times.
+// Discounts that already ended, or do not lower the price, are marked without an event.
+func (s *notificationService) NotifyStartedDiscounts(ctx context.Context) (int64, error) {
+	now := time.Now()
+	discounts, err := s.ebookDiscountRepo.ListStartedUnnotified(ctx, now, discountNotifyBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var emitted int64
+	for _, discount := range discounts {
+		ebook, err := s.ebookRepo.GetByID(ctx, discount.EbookID)
+		if err != nil {
+			return emitted, err
+		}
+
+		if ebook != nil && discount.EndedAt.After(now) && discount.DiscountPrice < ebook.Price {
+			discountID := discount.ID
+			endsAt := discount.EndedAt
+			count, err := s.NotifyPriceDrop(ctx, &entity.PriceDrop{
+				EbookID:    ebook.ID,
+				Title:      ebook.Title,
+				Slug:       ebook.Slug,
+				CoverImage: ebook.CoverImage,
+				Reason:     entity.PriceDropDiscount,
+				OldPrice:   ebook.Price,
+				NewPrice:   discount.DiscountPrice,
+				DiscountID: &discountID,
+				EndsAt:     &endsAt,
+			})
+			if err != nil {
+				return emitted, err
+			}
+			emitted += count
+		}
+
+		if err := s.ebookDiscountRepo.MarkNotified(ctx, discount.ID, now); err != nil {
+			return emitted, err
+		}
+	}
+
+	if emitted > 0 {
+		log.Printf("Emitted %d price drop notifications for %d started discounts", emitted, len(discounts))
+	}
+	return emitted, nil
+}
+
+func (s *notificationService) GetNotifications(ctx context.Context, userID string, limit, offset int) ([]*entity.NotificationEvent, error) {
+	return s.notificationRepo.ListByUser(ctx, userID, limit, offset)
+}
+
+func (s *notificationService) GetNotificationCount(ctx context.Context, userID string) (int64, error) {
+	return s.notificationRepo.CountByUser(ctx, userID)
+}
@@ -67,6 +67,23 @@ func (s *paymentService) UpdatePaymentStatus(ctx context.Context, id string, sta
 	return s.paymentRepo.Update(ctx, payment)
 }
 
-func (s *paymentService) ListPaymentsByUserID(ctx context.Context, userID string) ([]*entity.Payment, error) {
-	return s.paymentRepo.ListByUserID(ctx, userID)
+func (s *paymentService) ListPaymentsByUserID(ctx context.Context, userID string, limit, offset int) ([]*entity.Payment, error) {
+	return s.paymentRepo.ListByUserID(ctx, userID, limit, offset)
+}
+
+func (s *paymentService) ListPaymentsByUserIDCursor(ctx context.Context, userID string, cursor *entity.PageCursor, limit int) ([]*entity.Payment, *entity.CursorPageInfo, error) {
+	// Fetch one extra row to know whether another page follows
+	payments, err := s.paymentRepo.ListByUserIDCursor(ctx, userID, cursor, limit+1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	payments, page := entity.BuildCursorPage(payments, limit, cursor, func(payment *entity.Payment) entity.PageCursor {
+		return entity.NewTimeCursor(entity.CursorSortPayments, payment.CreatedAt, payment.ID)
+	})
+	return payments, page, nil
+}
+
+func (s *paymentService) CountPaymentsByUserID(ctx context.Context, userID string) (int64, error) {
+	return s.paymentRepo.CountByUserID(ctx, userID)
 } 
\ No newline at end of file
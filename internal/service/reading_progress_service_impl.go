@@ -0,0 +1,135 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"buku-pintar/internal/domain/service"
+	"context"
+	"log"
+)
+
+// flushBatchSize is how many users are taken off the dirty set per round trip
+const flushBatchSize = 100
+
+type readingProgressService struct {
+	progressRepo      repository.ReadingProgressRepository
+	progressRedisRepo repository.ReadingProgressRedisRepository
+	mediaURLs         service.MediaURLResolver
+}
+
+// NewReadingProgressService creates a new instance of ReadingProgressService
+// Progress is written to Redis first and flushed to MySQL by FlushProgress
+func NewReadingProgressService(
+	progressRepo repository.ReadingProgressRepository,
+	progressRedisRepo repository.ReadingProgressRedisRepository,
+	mediaURLs service.MediaURLResolver,
+) service.ReadingProgressService {
+	return &readingProgressService{
+		progressRepo:      progressRepo,
+		progressRedisRepo: progressRedisRepo,
+		mediaURLs:         mediaURLs,
+	}
+}
+
+func (s *readingProgressService) SaveProgress(ctx context.Context, progress *entity.ReadingProgress) (*entity.ReadingProgress, bool, error) {
+	// A flushed entry is no longer hot, so compare against the stored progress as well
+	current, err := s.GetProgress(ctx, progress.UserID, progress.EbookID)
+	if err != nil {
+		return nil, false, err
+	}
+	if !progress.IsNewerThan(current) {
+		return current, false, nil
+	}
+
+	applied, err := s.progressRedisRepo.SetProgress(ctx, progress)
+	if err != nil {
+		// Redis is unavailable, write through to MySQL which applies the same rule
+		log.Printf("Failed to store hot reading progress, writing to database: %v", err)
+		if err := s.progressRepo.Upsert(ctx, progress); err != nil {
+			return nil, false, err
+		}
+		return progress, true, nil
+	}
+
+	if !applied {
+		// Another device wrote a later position in the meantime
+		current, err = s.progressRedisRepo.GetProgress(ctx, progress.UserID, progress.EbookID)
+		if err != nil {
+			return nil, false, err
+		}
+		return current, false, nil
+	}
+
+	return progress, true, nil
+}
+
+func (s *readingProgressService) GetProgress(ctx context.Context, userID, ebookID string) (*entity.ReadingProgress, error) {
+	hot, err := s.progressRedisRepo.GetProgress(ctx, userID, ebookID)
+	if err != nil {
+		log.Printf("Failed to get hot reading progress: %v", err)
+	}
+	if hot != nil {
+		return hot, nil
+	}
+
+	return s.progressRepo.Get(ctx, userID, ebookID)
+}
+
+func (s *readingProgressService) GetContinueReadingList(ctx context.Context, userID string, limit, offset int) ([]*entity.ReadingProgressItem, error) {
+	// The list is ordered by the latest positions, so flush this user's hot progress first
+	if err := s.flushUser(ctx, userID); err != nil {
+		log.Printf("Failed to flush reading progress for user %s: %v", userID, err)
+	}
+
+	items, err := s.progressRepo.ListInProgressByUserID(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	resolveMediaURLs(s.mediaURLs, items...)
+	return items, nil
+}
+
+func (s *readingProgressService) GetContinueReadingCount(ctx context.Context, userID string) (int64, error) {
+	return s.progressRepo.CountInProgressByUserID(ctx, userID)
+}
+
+func (s *readingProgressService) FlushProgress(ctx context.Context) (int, error) {
+	flushed := 0
+	for {
+		userIDs, err := s.progressRedisRepo.PopDirtyUsers(ctx, flushBatchSize)
+		if err != nil {
+			return flushed, err
+		}
+		if len(userIDs) == 0 {
+			return flushed, nil
+		}
+
+		for _, userID := range userIDs {
+			if err := s.flushUser(ctx, userID); err != nil {
+				// Put the user back so the next run retries
+				if markErr := s.progressRedisRepo.MarkDirty(ctx, userID); markErr != nil {
+					log.Printf("Failed to requeue reading progress for user %s: %v", userID, markErr)
+				}
+				return flushed, err
+			}
+			flushed++
+		}
+	}
+}
+
+func (s *readingProgressService) flushUser(ctx context.Context, userID string) error {
+	hot, err := s.progressRedisRepo.GetUserProgress(ctx, userID)
+	if err != nil || len(hot) == 0 {
+		return err
+	}
+
+	for _, progress := range hot {
+		if err := s.progressRepo.Upsert(ctx, progress); err != nil {
+			return err
+		}
+	}
+
+	// Entries rewritten since they were read stay hot and are picked up by the next flush
+	return s.progressRedisRepo.RemoveFlushed(ctx, hot)
+}
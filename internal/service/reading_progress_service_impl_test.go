@@ -0,0 +1,150 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+	"testing"
+	"time"
+)
+
+// MockReadingProgressRepository keeps rows in memory and applies last-writer-wins like the MySQL upsert
+type MockReadingProgressRepository struct {
+	rows map[string]*entity.ReadingProgress
+}
+
+func (m *MockReadingProgressRepository) Upsert(ctx context.Context, progress *entity.ReadingProgress) error {
+	key := progress.UserID + ":" + progress.EbookID
+	if progress.IsNewerThan(m.rows[key]) {
+		stored := *progress
+		m.rows[key] = &stored
+	}
+	return nil
+}
+
+func (m *MockReadingProgressRepository) Get(ctx context.Context, userID, ebookID string) (*entity.ReadingProgress, error) {
+	return m.rows[userID+":"+ebookID], nil
+}
+
+func (m *MockReadingProgressRepository) ListInProgressByUserID(ctx context.Context, userID string, limit, offset int) ([]*entity.ReadingProgressItem, error) {
+	var items []*entity.ReadingProgressItem
+	for _, row := range m.rows {
+		if row.UserID == userID {
+			items = append(items, &entity.ReadingProgressItem{ReadingProgress: *row})
+		}
+	}
+	return items, nil
+}
+
+func (m *MockReadingProgressRepository) CountInProgressByUserID(ctx context.Context, userID string) (int64, error) {
+	items, _ := m.ListInProgressByUserID(ctx, userID, 0, 0)
+	return int64(len(items)), nil
+}
+
+// MockReadingProgressRedisRepository keeps hot entries in memory
+type MockReadingProgressRedisRepository struct {
+	hot   map[string]*entity.ReadingProgress
+	dirty map[string]bool
+}
+
+func (m *MockReadingProgressRedisRepository) GetProgress(ctx context.Context, userID, ebookID string) (*entity.ReadingProgress, error) {
+	return m.hot[userID+":"+ebookID], nil
+}
+
+func (m *MockReadingProgressRedisRepository) SetProgress(ctx context.Context, progress *entity.ReadingProgress) (bool, error) {
+	key := progress.UserID + ":" + progress.EbookID
+	if !progress.IsNewerThan(m.hot[key]) {
+		return false, nil
+	}
+	m.hot[key] = progress
+	m.dirty[progress.UserID] = true
+	return true, nil
+}
+
+func (m *MockReadingProgressRedisRepository) GetUserProgress(ctx context.Context, userID string) ([]*entity.ReadingProgress, error) {
+	var progress []*entity.ReadingProgress
+	for _, p := range m.hot {
+		if p.UserID == userID {
+			progress = append(progress, p)
+		}
+	}
+	return progress, nil
+}
+
+func (m *MockReadingProgressRedisRepository) RemoveFlushed(ctx context.Context, progress []*entity.ReadingProgress) error {
+	for _, p := range progress {
+		key := p.UserID + ":" + p.EbookID
+		if current := m.hot[key]; current != nil && current.ClientUpdatedAt.Equal(p.ClientUpdatedAt) {
+			delete(m.hot, key)
+		}
+	}
+	return nil
+}
+
+func (m *MockReadingProgressRedisRepository) PopDirtyUsers(ctx context.Context, count int64) ([]string, error) {
+	var users []string
+	for userID := range m.dirty {
+		users = append(users, userID)
+		delete(m.dirty, userID)
+	}
+	return users, nil
+}
+
+func (m *MockReadingProgressRedisRepository) MarkDirty(ctx context.Context, userID string) error {
+	m.dirty[userID] = true
+	return nil
+}
+
+func TestReadingProgressService_LastWriterWins(t *testing.T) {
+	repo := &MockReadingProgressRepository{rows: map[string]*entity.ReadingProgress{}}
+	redisRepo := &MockReadingProgressRedisRepository{hot: map[string]*entity.ReadingProgress{}, dirty: map[string]bool{}}
+	svc := NewReadingProgressService(repo, redisRepo, testMediaURLs{})
+	ctx := context.Background()
+
+	base := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	phone := &entity.ReadingProgress{UserID: "user-1", EbookID: "ebook-1", Page: 40, ClientUpdatedAt: base.Add(time.Minute)}
+	tablet := &entity.ReadingProgress{UserID: "user-1", EbookID: "ebook-1", Page: 12, ClientUpdatedAt: base}
+
+	if _, applied, err := svc.SaveProgress(ctx, phone); err != nil || !applied {
+		t.Fatalf("expected phone progress to be applied, got %v, %v", applied, err)
+	}
+
+	// The tablet syncs later but read earlier, so the phone's position is kept
+	stored, applied, err := svc.SaveProgress(ctx, tablet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if applied || stored.Page != 40 {
+		t.Errorf("expected stale progress to be rejected with page 40 kept, got applied=%v page=%d", applied, stored.Page)
+	}
+
+	flushed, err := svc.FlushProgress(ctx)
+	if err != nil || flushed != 1 {
+		t.Fatalf("expected 1 user flushed, got %d, %v", flushed, err)
+	}
+	if len(redisRepo.hot) != 0 {
+		t.Errorf("expected hot progress to be cleared after flush, got %d entries", len(redisRepo.hot))
+	}
+	if row := repo.rows["user-1:ebook-1"]; row == nil || row.Page != 40 {
+		t.Fatalf("expected page 40 in database, got %+v", row)
+	}
+
+	// Once flushed, a stale write is still checked against the database
+	if _, applied, _ := svc.SaveProgress(ctx, tablet); applied {
+		t.Errorf("expected stale progress to be rejected after flush")
+	}
+
+	newer := &entity.ReadingProgress{UserID: "user-1", EbookID: "ebook-1", Page: 55, ClientUpdatedAt: base.Add(2 * time.Minute)}
+	if _, applied, _ := svc.SaveProgress(ctx, newer); !applied {
+		t.Errorf("expected newer progress to be applied")
+	}
+	progress, err := svc.GetProgress(ctx, "user-1", "ebook-1")
+	if err != nil || progress.Page != 55 {
+		t.Errorf("expected hot page 55, got %+v, %v", progress, err)
+	}
+
+	// The continue reading list flushes the user's hot progress first
+	items, err := svc.GetContinueReadingList(ctx, "user-1", 10, 0)
+	if err != nil || len(items) != 1 || items[0].Page != 55 {
+		t.Errorf("expected continue reading to show page 55, got %+v, %v", items, err)
+	}
+}
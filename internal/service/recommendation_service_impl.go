@@ -0,0 +1,316 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"buku-pintar/internal/domain/service"
+	"context"
+	"log"
+	"math"
+	"sort"
+	"time"
+)
+
+// Signal weights. Category affinity decays with the distance between two categories in the tree,
+// co-purchase and co-reading grow with the logarithm of the number of shared users.
+const (
+	categoryWeight      = 3.0
+	maxCategoryDistance = 4
+	authorWeight        = 2.0
+	coPurchaseWeight    = 2.5
+	coReadWeight        = 1.5
+
+	// relatedPoolSize is how many related ebooks per seed feed the personal recommendations
+	relatedPoolSize = 50
+)
+
+type recommendationService struct {
+	recommendationRepo      repository.RecommendationRepository
+	recommendationRedisRepo repository.RecommendationRedisRepository
+	mediaURLs               service.MediaURLResolver
+	maxResults              int
+	activeUserWindow        time.Duration
+}
+
+// NewRecommendationService stores up to maxResults recommendations per ebook and per user.
+// Personal recommendations are computed for users who bought or read something within activeUserWindow.
+func NewRecommendationService(
+	recommendationRepo repository.RecommendationRepository,
+	recommendationRedisRepo repository.RecommendationRedisRepository,
+	mediaURLs service.MediaURLResolver,
+	maxResults int,
+	activeUserWindow time.Duration,
+) service.RecommendationService {
+	return &recommendationService{
+		recommendationRepo:      recommendationRepo,
+		recommendationRedisRepo: recommendationRedisRepo,
+		mediaURLs:               mediaURLs,
+		maxResults:              maxResults,
+		activeUserWindow:        activeUserWindow,
+	}
+}
+
+func (s *recommendationService) RefreshRecommendations(ctx context.Context) (int, error) {
+	candidates, err := s.recommendationRepo.ListCandidates(ctx)
+	if err != nil {
+		return 0, err
+	}
+	parents, err := s.recommendationRepo.ListCategoryParents(ctx)
+	if err != nil {
+		return 0, err
+	}
+	coPurchases, err := s.recommendationRepo.ListCoPurchases(ctx)
+	if err != nil {
+		return 0, err
+	}
+	coReads, err := s.recommendationRepo.ListCoReads(ctx)
+	if err != nil {
+		return 0, err
+	}
+	userEbooks, err := s.recommendationRepo.ListActiveUserEbooks(ctx, time.Now().Add(-s.activeUserWindow))
+	if err != nil {
+		return 0, err
+	}
+
+	related := scoreRelatedEbooks(candidates, parents, coPurchases, coReads)
+	personal := scoreUserRecommendations(candidates, related, userEbooks, s.maxResults)
+
+	stored := make(map[string][]*entity.ScoredEbook, len(related))
+	for ebookID, scored := range related {
+		stored[ebookID] = truncateScored(scored, s.maxResults)
+	}
+	if err := s.recommendationRedisRepo.SetRelated(ctx, stored); err != nil {
+		return 0, err
+	}
+	if err := s.recommendationRedisRepo.SetUserRecommendations(ctx, personal); err != nil {
+		return len(stored), err
+	}
+
+	log.Printf("Refreshed related ebooks for %d ebooks and recommendations for %d users", len(stored), len(personal))
+	return len(stored) + len(personal), nil
+}
+
+func (s *recommendationService) GetRelatedEbooks(ctx context.Context, ebookID string, limit int) ([]*entity.EbookList, error) {
+	scored, err := s.recommendationRedisRepo.GetRelated(ctx, ebookID)
+	if err != nil {
+		log.Printf("Failed to get related ebooks from cache: %v", err)
+		return nil, nil
+	}
+
+	return s.hydrate(ctx, scored, limit)
+}
+
+func (s *recommendationService) GetUserRecommendations(ctx context.Context, userID string, limit int) ([]*entity.EbookList, error) {
+	scored, err := s.recommendationRedisRepo.GetUserRecommendations(ctx, userID)
+	if err != nil {
+		log.Printf("Failed to get user recommendations from cache: %v", err)
+		return nil, nil
+	}
+
+	return s.hydrate(ctx, scored, limit)
+}
+
+// hydrate loads the catalog entries of the scored ebooks, skipping any that were unpublished since the last refresh
+func (s *recommendationService) hydrate(ctx context.Context, scored []*entity.ScoredEbook, limit int) ([]*entity.EbookList, error) {
+	if len(scored) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, 0, len(scored))
+	for _, item := range scored {
+		ids = append(ids, item.EbookID)
+	}
+
+	ebooks, err := s.recommendationRepo.ListPublishedByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	if len(ebooks) > limit {
+		ebooks = ebooks[:limit]
+	}
+
+	resolveMediaURLs(s.mediaURLs, ebooks...)
+	return ebooks, nil
+}
+
+// scoreRelatedEbooks ranks, for every candidate, the other candidates that share its category tree,
+// author, buyers or readers. Each list keeps the relatedPoolSize best, highest score first.
+func scoreRelatedEbooks(
+	candidates []*entity.RecommendationCandidate,
+	parents map[string]string,
+	coPurchases, coReads []*entity.EbookPairCount,
+) map[string][]*entity.ScoredEbook {
+	byID := make(map[string]*entity.RecommendationCandidate, len(candidates))
+	byCategory := make(map[string][]string)
+	byAuthor := make(map[string][]string)
+	for _, candidate := range candidates {
+		byID[candidate.EbookID] = candidate
+		byCategory[candidate.CategoryID] = append(byCategory[candidate.CategoryID], candidate.EbookID)
+		if candidate.AuthorID != "" {
+			byAuthor[candidate.AuthorID] = append(byAuthor[candidate.AuthorID], candidate.EbookID)
+		}
+	}
+
+	children := make(map[string][]string)
+	for id, parentID := range parents {
+		if parentID != "" {
+			children[parentID] = append(children[parentID], id)
+		}
+	}
+
+	purchasedTogether := pairCountIndex(coPurchases)
+	readTogether := pairCountIndex(coReads)
+	nearbyCategories := make(map[string]map[string]int)
+
+	related := make(map[string][]*entity.ScoredEbook, len(candidates))
+	for _, candidate := range candidates {
+		scores := make(map[string]float64)
+
+		if candidate.CategoryID != "" {
+			nearby, ok := nearbyCategories[candidate.CategoryID]
+			if !ok {
+				nearby = categoryDistances(parents, children, candidate.CategoryID, maxCategoryDistance)
+				nearbyCategories[candidate.CategoryID] = nearby
+			}
+			for categoryID, distance := range nearby {
+				for _, other := range byCategory[categoryID] {
+					scores[other] += categoryWeight / float64(1+distance)
+				}
+			}
+		}
+		if candidate.AuthorID != "" {
+			for _, other := range byAuthor[candidate.AuthorID] {
+				scores[other] += authorWeight
+			}
+		}
+		for other, users := range purchasedTogether[candidate.EbookID] {
+			if _, ok := byID[other]; ok {
+				scores[other] += coPurchaseWeight * math.Log1p(float64(users))
+			}
+		}
+		for other, users := range readTogether[candidate.EbookID] {
+			if _, ok := byID[other]; ok {
+				scores[other] += coReadWeight * math.Log1p(float64(users))
+			}
+		}
+		delete(scores, candidate.EbookID)
+
+		if len(scores) > 0 {
+			related[candidate.EbookID] = rankScores(scores, byID, relatedPoolSize)
+		}
+	}
+
+	return related
+}
+
+// scoreUserRecommendations sums the related scores of everything a user bought or read
+// and leaves those ebooks themselves out
+func scoreUserRecommendations(
+	candidates []*entity.RecommendationCandidate,
+	related map[string][]*entity.ScoredEbook,
+	userEbooks []*entity.UserEbook,
+	limit int,
+) map[string][]*entity.ScoredEbook {
+	byID := make(map[string]*entity.RecommendationCandidate, len(candidates))
+	for _, candidate := range candidates {
+		byID[candidate.EbookID] = candidate
+	}
+
+	seeds := make(map[string][]string)
+	for _, userEbook := range userEbooks {
+		seeds[userEbook.UserID] = append(seeds[userEbook.UserID], userEbook.EbookID)
+	}
+
+	recommendations := make(map[string][]*entity.ScoredEbook, len(seeds))
+	for userID, ebookIDs := range seeds {
+		scores := make(map[string]float64)
+		for _, ebookID := range ebookIDs {
+			for _, item := range related[ebookID] {
+				scores[item.EbookID] += item.Score
+			}
+		}
+		for _, ebookID := range ebookIDs {
+			delete(scores, ebookID)
+		}
+
+		if len(scores) > 0 {
+			recommendations[userID] = rankScores(scores, byID, limit)
+		}
+	}
+
+	return recommendations
+}
+
+// categoryDistances walks the category tree from categoryID in both directions and returns
+// the number of edges to every category within maxDistance, including categoryID itself at 0
+func categoryDistances(parents map[string]string, children map[string][]string, categoryID string, maxDistance int) map[string]int {
+	distances := map[string]int{categoryID: 0}
+	frontier := []string{categoryID}
+	for distance := 1; distance <= maxDistance && len(frontier) > 0; distance++ {
+		var next []string
+		for _, id := range frontier {
+			neighbours := children[id]
+			if parentID := parents[id]; parentID != "" {
+				neighbours = append([]string{parentID}, neighbours...)
+			}
+			for _, neighbour := range neighbours {
+				if _, seen := distances[neighbour]; !seen {
+					distances[neighbour] = distance
+					next = append(next, neighbour)
+				}
+			}
+		}
+		frontier = next
+	}
+
+	return distances
+}
+
+// pairCountIndex turns unordered pair counts into a symmetric lookup
+func pairCountIndex(pairs []*entity.EbookPairCount) map[string]map[string]int {
+	index := make(map[string]map[string]int)
+	add := func(from, to string, users int) {
+		if index[from] == nil {
+			index[from] = make(map[string]int)
+		}
+		index[from][to] += users
+	}
+	for _, pair := range pairs {
+		add(pair.EbookID, pair.OtherEbookID, pair.Users)
+		add(pair.OtherEbookID, pair.EbookID, pair.Users)
+	}
+	return index
+}
+
+// rankScores orders by score, then popularity, then ID so equal scores rank the same on every run
+func rankScores(scores map[string]float64, byID map[string]*entity.RecommendationCandidate, limit int) []*entity.ScoredEbook {
+	ranked := make([]*entity.ScoredEbook, 0, len(scores))
+	for ebookID, score := range scores {
+		ranked = append(ranked, &entity.ScoredEbook{EbookID: ebookID, Score: math.Round(score*1000) / 1000})
+	}
+
+	popularity := func(ebookID string) int {
+		if candidate, ok := byID[ebookID]; ok {
+			return candidate.PopularityScore
+		}
+		return 0
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		if pi, pj := popularity(ranked[i].EbookID), popularity(ranked[j].EbookID); pi != pj {
+			return pi > pj
+		}
+		return ranked[i].EbookID < ranked[j].EbookID
+	})
+
+	return truncateScored(ranked, limit)
+}
+
+func truncateScored(scored []*entity.ScoredEbook, limit int) []*entity.ScoredEbook {
+	if len(scored) > limit {
+		return scored[:limit]
+	}
+	return scored
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+	"testing"
+	"time"
+)
+
+// MockRecommendationRepository serves fixed signals
+type MockRecommendationRepository struct {
+	candidates  []*entity.RecommendationCandidate
+	parents     map[string]string
+	coPurchases []*entity.EbookPairCount
+	coReads     []*entity.EbookPairCount
+	userEbooks  []*entity.UserEbook
+	published   map[string]bool
+}
+
+func (m *MockRecommendationRepository) ListCandidates(ctx context.Context) ([]*entity.RecommendationCandidate, error) {
+	return m.candidates, nil
+}
+
+func (m *MockRecommendationRepository) ListCategoryParents(ctx context.Context) (map[string]string, error) {
+	return m.parents, nil
+}
+
+func (m *MockRecommendationRepository) ListCoPurchases(ctx context.Context) ([]*entity.EbookPairCount, error) {
+	return m.coPurchases, nil
+}
+
+func (m *MockRecommendationRepository) ListCoReads(ctx context.Context) ([]*entity.EbookPairCount, error) {
+	return m.coReads, nil
+}
+
+func (m *MockRecommendationRepository) ListActiveUserEbooks(ctx context.Context, since time.Time) ([]*entity.UserEbook, error) {
+	return m.userEbooks, nil
+}
+
+func (m *MockRecommendationRepository) ListPublishedByIDs(ctx context.Context, ids []string) ([]*entity.EbookList, error) {
+	var ebooks []*entity.EbookList
+	for _, id := range ids {
+		if m.published[id] {
+			ebooks = append(ebooks, &entity.EbookList{ID: id})
+		}
+	}
+	return ebooks, nil
+}
+
+// MockRecommendationRedisRepository keeps the stored lists in memory
+type MockRecommendationRedisRepository struct {
+	related  map[string][]*entity.ScoredEbook
+	personal map[string][]*entity.ScoredEbook
+}
+
+func (m *MockRecommendationRedisRepository) GetRelated(ctx context.Context, ebookID string) ([]*entity.ScoredEbook, error) {
+	return m.related[ebookID], nil
+}
+
+func (m *MockRecommendationRedisRepository) SetRelated(ctx context.Context, related map[string][]*entity.ScoredEbook) error {
+	m.related = related
+	return nil
+}
+
+func (m *MockRecommendationRedisRepository) GetUserRecommendations(ctx context.Context, userID string) ([]*entity.ScoredEbook, error) {
+	return m.personal[userID], nil
+}
+
+func (m *MockRecommendationRedisRepository) SetUserRecommendations(ctx context.Context, recommendations map[string][]*entity.ScoredEbook) error {
+	m.personal = recommendations
+	return nil
+}
+
+// newRecommendationFixture builds a small catalog:
+// fantasy and scifi are children of fiction, cooking is a separate root.
+func newRecommendationFixture() *MockRecommendationRepository {
+	return &MockRecommendationRepository{
+		candidates: []*entity.RecommendationCandidate{
+			{EbookID: "dragons", AuthorID: "author-1", CategoryID: "fantasy", PopularityScore: 10},
+			{EbookID: "wizards", AuthorID: "author-2", CategoryID: "fantasy", PopularityScore: 5},
+			{EbookID: "rockets", AuthorID: "author-1", CategoryID: "scifi", PopularityScore: 20},
+			{EbookID: "pasta", AuthorID: "author-3", CategoryID: "cooking", PopularityScore: 1},
+			{EbookID: "bread", AuthorID: "author-3", CategoryID: "cooking", PopularityScore: 50},
+		},
+		parents: map[string]string{
+			"fiction": "",
+			"fantasy": "fiction",
+			"scifi":   "fiction",
+			"cooking": "",
+		},
+		coPurchases: []*entity.EbookPairCount{
+			{EbookID: "dragons", OtherEbookID: "pasta", Users: 3},
+			// Pairs with ebooks that are no longer published are ignored
+			{EbookID: "dragons", OtherEbookID: "retired", Users: 100},
+		},
+		coReads: []*entity.EbookPairCount{
+			{EbookID: "bread", OtherEbookID: "wizards", Users: 1},
+		},
+		userEbooks: []*entity.UserEbook{
+			{UserID: "reader", EbookID: "dragons"},
+			{UserID: "reader", EbookID: "pasta"},
+		},
+		published: map[string]bool{"dragons": true, "wizards": true, "rockets": true, "pasta": true, "bread": true},
+	}
+}
+
+func scoredIDs(scored []*entity.ScoredEbook) []string {
+	ids := make([]string, 0, len(scored))
+	for _, item := range scored {
+		ids = append(ids, item.EbookID)
+	}
+	return ids
+}
+
+func equalIDs(got, want []string) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestRecommendationService_RefreshRecommendations(t *testing.T) {
+	repo := newRecommendationFixture()
+	redisRepo := &MockRecommendationRedisRepository{}
+	s := NewRecommendationService(repo, redisRepo, testMediaURLs{}, 3, 24*time.Hour)
+
+	if _, err := s.RefreshRecommendations(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// pasta: co-purchased by 3 users (2.5*ln 4 ≈ 3.47)
+	// wizards: same category (3.0); rockets: sibling category (1.0) plus same author (2.0),
+	// so the tie is broken by popularity
+	if got, want := scoredIDs(redisRepo.related["dragons"]), []string{"pasta", "rockets", "wizards"}; !equalIDs(got, want) {
+		t.Errorf("related to dragons = %v, want %v", got, want)
+	}
+	// pasta shares the category and author, wizards was read by the same user
+	if got, want := scoredIDs(redisRepo.related["bread"]), []string{"pasta", "wizards"}; !equalIDs(got, want) {
+		t.Errorf("related to bread = %v, want %v", got, want)
+	}
+
+	// The reader's own ebooks are left out of their recommendations
+	personal := scoredIDs(redisRepo.personal["reader"])
+	for _, id := range personal {
+		if id == "dragons" || id == "pasta" {
+			t.Errorf("recommendations %v include an ebook the reader already has", personal)
+		}
+	}
+	if len(personal) == 0 || personal[0] != "bread" {
+		t.Errorf("expected bread first for the reader, got %v", personal)
+	}
+}
+
+func TestRecommendationService_GetRelatedEbooks(t *testing.T) {
+	repo := newRecommendationFixture()
+	redisRepo := &MockRecommendationRedisRepository{}
+	s := NewRecommendationService(repo, redisRepo, testMediaURLs{}, 3, 24*time.Hour)
+	ctx := context.Background()
+
+	// Nothing is served before the first refresh
+	ebooks, err := s.GetRelatedEbooks(ctx, "dragons", 10)
+	if err != nil || ebooks != nil {
+		t.Fatalf("expected nil before refresh, got %v, %v", ebooks, err)
+	}
+
+	if _, err := s.RefreshRecommendations(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Ebooks unpublished after the refresh are skipped and the limit still applies
+	repo.published["pasta"] = false
+	ebooks, err = s.GetRelatedEbooks(ctx, "dragons", 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ebooks) != 1 || ebooks[0].ID != "rockets" {
+		t.Errorf("expected only rockets, got %+v", ebooks)
+	}
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"buku-pintar/internal/domain/service"
+	"context"
+	"log"
+)
+
+// reviewService invalidates the ebook cache whenever a write moves an ebook's rating aggregate,
+// since the average and count are served from cached ebook lists and details
+type reviewService struct {
+	reviewRepo     repository.ReviewRepository
+	ebookRedisRepo repository.EbookRedisRepository
+}
+
+func NewReviewService(reviewRepo repository.ReviewRepository, ebookRedisRepo repository.EbookRedisRepository) service.ReviewService {
+	return &reviewService{
+		reviewRepo:     reviewRepo,
+		ebookRedisRepo: ebookRedisRepo,
+	}
+}
+
+func (s *reviewService) CreateReview(ctx context.Context, review *entity.Review) error {
+	if err := s.reviewRepo.Create(ctx, review); err != nil {
+		return err
+	}
+
+	s.invalidateEbookCache(ctx)
+	return nil
+}
+
+func (s *reviewService) GetReviewByID(ctx context.Context, id string) (*entity.Review, error) {
+	return s.reviewRepo.GetByID(ctx, id)
+}
+
+func (s *reviewService) GetUserReview(ctx context.Context, userID, ebookID string) (*entity.Review, error) {
+	return s.reviewRepo.GetByUserAndEbook(ctx, userID, ebookID)
+}
+
+func (s *reviewService) UpdateReview(ctx context.Context, review *entity.Review) error {
+	if err := s.reviewRepo.Update(ctx, review); err != nil {
+		return err
+	}
+
+	s.invalidateEbookCache(ctx)
+	return nil
+}
+
+func (s *reviewService) SetReviewStatus(ctx context.Context, id string, status entity.ReviewStatus, moderatorID string) error {
+	if err := s.reviewRepo.SetStatus(ctx, id, status, moderatorID); err != nil {
+		return err
+	}
+
+	s.invalidateEbookCache(ctx)
+	return nil
+}
+
+func (s *reviewService) ReportReview(ctx context.Context, report *entity.ReviewReport) (bool, error) {
+	return s.reviewRepo.Report(ctx, report)
+}
+
+func (s *reviewService) GetEbookReviews(ctx context.Context, ebookID string, limit, offset int) ([]*entity.Review, error) {
+	return s.reviewRepo.ListVisibleByEbook(ctx, ebookID, limit, offset)
+}
+
+func (s *reviewService) GetEbookReviewCount(ctx context.Context, ebookID string) (int64, error) {
+	return s.reviewRepo.CountVisibleByEbook(ctx, ebookID)
+}
+
+func (s *reviewService) GetReportedReviews(ctx context.Context, limit, offset int) ([]*entity.Review, error) {
+	return s.reviewRepo.ListReported(ctx, limit, offset)
+}
+
+func (s *reviewService) GetReportedReviewCount(ctx context.Context) (int64, error) {
+	return s.reviewRepo.CountReported(ctx)
+}
+
+func (s *reviewService) invalidateEbookCache(ctx context.Context) {
+	if err := s.ebookRedisRepo.InvalidateEbookCache(ctx); err != nil {
+		log.Printf("Failed to invalidate ebook cache after review change: %v", err)
+	}
+}
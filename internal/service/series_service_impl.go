@@ -0,0 +1,95 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"buku-pintar/internal/domain/service"
+	"context"
+)
+
+type seriesService struct {
+	seriesRepo repository.SeriesRepository
+	mediaURLs  service.MediaURLResolver
+}
+
+// NewSeriesService creates a new instance of SeriesService
+func NewSeriesService(seriesRepo repository.SeriesRepository, mediaURLs service.MediaURLResolver) service.SeriesService {
+	return &seriesService{
+		seriesRepo: seriesRepo,
+		mediaURLs:  mediaURLs,
+	}
+}
+
+func (s *seriesService) CreateSeries(ctx context.Context, series *entity.Series) error {
+	return s.seriesRepo.Create(ctx, series)
+}
+
+func (s *seriesService) GetSeriesByID(ctx context.Context, id string) (*entity.Series, error) {
+	series, err := s.seriesRepo.GetByID(ctx, id)
+	if err != nil || series == nil {
+		return nil, err
+	}
+
+	series.ResolveMediaURLs(s.mediaURLs.URL)
+	return series, nil
+}
+
+func (s *seriesService) GetSeriesBySlug(ctx context.Context, slug string) (*entity.Series, error) {
+	series, err := s.seriesRepo.GetBySlug(ctx, slug)
+	if err != nil || series == nil {
+		return nil, err
+	}
+
+	series.ResolveMediaURLs(s.mediaURLs.URL)
+	return series, nil
+}
+
+func (s *seriesService) UpdateSeries(ctx context.Context, series *entity.Series) error {
+	return s.seriesRepo.Update(ctx, series)
+}
+
+func (s *seriesService) DeleteSeries(ctx context.Context, id string) error {
+	return s.seriesRepo.Delete(ctx, id)
+}
+
+func (s *seriesService) GetSeriesList(ctx context.Context, limit, offset int) ([]*entity.Series, error) {
+	list, err := s.seriesRepo.ListPublished(ctx, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	resolveMediaURLs(s.mediaURLs, list...)
+	return list, nil
+}
+
+func (s *seriesService) GetSeriesCount(ctx context.Context) (int64, error) {
+	return s.seriesRepo.CountPublished(ctx)
+}
+
+func (s *seriesService) GetVolumes(ctx context.Context, seriesID string) ([]*entity.SeriesVolume, error) {
+	volumes, err := s.seriesRepo.ListVolumes(ctx, seriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	resolveMediaURLs(s.mediaURLs, volumes...)
+	return volumes, nil
+}
+
+func (s *seriesService) ReplaceVolumes(ctx context.Context, seriesID string, volumes []*entity.SeriesVolume) error {
+	return s.seriesRepo.ReplaceVolumes(ctx, seriesID, volumes)
+}
+
+func (s *seriesService) GetMembership(ctx context.Context, ebookID string) (*entity.SeriesMembership, error) {
+	return s.seriesRepo.GetMembership(ctx, ebookID)
+}
+
+func (s *seriesService) GetNextVolume(ctx context.Context, ebookID string) (*entity.SeriesVolume, error) {
+	volume, err := s.seriesRepo.GetNextVolume(ctx, ebookID)
+	if err != nil || volume == nil {
+		return nil, err
+	}
+
+	volume.ResolveMediaURLs(s.mediaURLs.URL)
+	return volume, nil
+}
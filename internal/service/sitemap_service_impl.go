@@ -0,0 +1,121 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"buku-pintar/internal/domain/service"
+	"buku-pintar/pkg/sitemap"
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type sitemapService struct {
+	sitemapRepo      repository.SitemapRepository
+	sitemapRedisRepo repository.SitemapRedisRepository
+	siteURL          string
+	urlsPerSitemap   int
+	now              func() time.Time
+}
+
+// NewSitemapService creates a new instance of SitemapService.
+// Pages are listed as {siteURL}/{content}/{slug} and child sitemaps as {siteURL}/sitemaps/{name}.
+func NewSitemapService(
+	sitemapRepo repository.SitemapRepository,
+	sitemapRedisRepo repository.SitemapRedisRepository,
+	siteURL string,
+	urlsPerSitemap int,
+) service.SitemapService {
+	if urlsPerSitemap <= 0 || urlsPerSitemap > sitemap.MaxURLs {
+		urlsPerSitemap = sitemap.MaxURLs
+	}
+	return &sitemapService{
+		sitemapRepo:      sitemapRepo,
+		sitemapRedisRepo: sitemapRedisRepo,
+		siteURL:          strings.TrimRight(siteURL, "/"),
+		urlsPerSitemap:   urlsPerSitemap,
+		now:              time.Now,
+	}
+}
+
+func (s *sitemapService) RefreshSitemaps(ctx context.Context) (bool, error) {
+	now := s.now()
+	contentVersion, err := s.sitemapRepo.Version(ctx, now)
+	if err != nil {
+		return false, err
+	}
+	// The settings are part of the version so that changing them regenerates the sitemaps too
+	version := fmt.Sprintf("%s|%d|%s", s.siteURL, s.urlsPerSitemap, contentVersion)
+
+	stored, err := s.sitemapRedisRepo.GetSitemapVersion(ctx)
+	if err != nil {
+		return false, err
+	}
+	if stored == version {
+		return false, nil
+	}
+
+	set, err := s.generateSitemaps(ctx, version, now)
+	if err != nil {
+		return false, err
+	}
+	if err := s.sitemapRedisRepo.SetSitemaps(ctx, set); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s *sitemapService) GetSitemap(ctx context.Context, name string) ([]byte, error) {
+	return s.sitemapRedisRepo.GetSitemap(ctx, name)
+}
+
+// generateSitemaps splits the pages of each kind into sitemaps named {content}-{n}.xml and indexes them.
+// Kinds without public pages get no sitemap.
+func (s *sitemapService) generateSitemaps(ctx context.Context, version string, now time.Time) (*entity.SitemapSet, error) {
+	set := &entity.SitemapSet{Version: version, Documents: map[string][]byte{}}
+	index := &sitemap.Index{}
+
+	for _, content := range entity.SitemapContents {
+		entries, err := s.sitemapRepo.ListEntries(ctx, content, now)
+		if err != nil {
+			return nil, err
+		}
+
+		for page := 0; page*s.urlsPerSitemap < len(entries); page++ {
+			chunk := entries[page*s.urlsPerSitemap : min((page+1)*s.urlsPerSitemap, len(entries))]
+			urls := &sitemap.URLSet{URLs: make([]sitemap.URL, 0, len(chunk))}
+			lastMod := time.Time{}
+			for _, entry := range chunk {
+				urls.URLs = append(urls.URLs, sitemap.URL{
+					Loc:     s.siteURL + "/" + string(content) + "/" + url.PathEscape(entry.Slug),
+					LastMod: entry.UpdatedAt.UTC(),
+				})
+				if entry.UpdatedAt.After(lastMod) {
+					lastMod = entry.UpdatedAt
+				}
+			}
+
+			name := fmt.Sprintf("%s-%d.xml", content, page+1)
+			document, err := sitemap.Encode(urls)
+			if err != nil {
+				return nil, err
+			}
+			set.Documents[name] = document
+			index.Sitemaps = append(index.Sitemaps, sitemap.Sitemap{
+				Loc:     s.siteURL + "/sitemaps/" + name,
+				LastMod: lastMod.UTC(),
+			})
+		}
+	}
+
+	document, err := sitemap.Encode(index)
+	if err != nil {
+		return nil, err
+	}
+	set.Documents[entity.SitemapIndexName] = document
+
+	return set, nil
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// MockSitemapRepository serves fixed entries; its version is set by the test
+type MockSitemapRepository struct {
+	entries map[entity.SitemapContent][]*entity.SitemapEntry
+	version string
+	listed  int
+}
+
+func (m *MockSitemapRepository) ListEntries(ctx context.Context, content entity.SitemapContent, now time.Time) ([]*entity.SitemapEntry, error) {
+	m.listed++
+	return m.entries[content], nil
+}
+
+func (m *MockSitemapRepository) Version(ctx context.Context, now time.Time) (string, error) {
+	return m.version, nil
+}
+
+// MockSitemapRedisRepository keeps the stored sitemap set in memory
+type MockSitemapRedisRepository struct {
+	set *entity.SitemapSet
+}
+
+func (m *MockSitemapRedisRepository) GetSitemap(ctx context.Context, name string) ([]byte, error) {
+	if m.set == nil {
+		return nil, nil
+	}
+	return m.set.Documents[name], nil
+}
+
+func (m *MockSitemapRedisRepository) GetSitemapVersion(ctx context.Context) (string, error) {
+	if m.set == nil {
+		return "", nil
+	}
+	return m.set.Version, nil
+}
+
+func (m *MockSitemapRedisRepository) SetSitemaps(ctx context.Context, set *entity.SitemapSet) error {
+	m.set = set
+	return nil
+}
+
+func TestSitemapService_RefreshSitemaps(t *testing.T) {
+	ctx := context.Background()
+	older := time.Date(2026, 9, 1, 8, 0, 0, 0, time.UTC)
+	newer := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	repo := &MockSitemapRepository{
+		version: "v1",
+		entries: map[entity.SitemapContent][]*entity.SitemapEntry{
+			entity.SitemapEbook: {
+				{Slug: "laskar-pelangi", UpdatedAt: older},
+				{Slug: "bumi-manusia", UpdatedAt: newer},
+				{Slug: "negeri-5-menara", UpdatedAt: older},
+			},
+			entity.SitemapCategory: {{Slug: "fiksi", UpdatedAt: older}},
+		},
+	}
+	redisRepo := &MockSitemapRedisRepository{}
+	s := NewSitemapService(repo, redisRepo, "https://app.com/", 2)
+
+	regenerated, err := s.RefreshSitemaps(ctx)
+	if err != nil || !regenerated {
+		t.Fatalf("expected the sitemaps to be generated, got %v, %v", regenerated, err)
+	}
+
+	t.Run("should split the pages of each kind into sitemaps", func(t *testing.T) {
+		if len(redisRepo.set.Documents) != 4 {
+			t.Fatalf("expected the index and 3 sitemaps, got %d documents", len(redisRepo.set.Documents))
+		}
+
+		first, _ := s.GetSitemap(ctx, "ebooks-1.xml")
+		second, _ := s.GetSitemap(ctx, "ebooks-2.xml")
+		if !strings.Contains(string(first), "<loc>https://app.com/ebooks/bumi-manusia</loc>") ||
+			!strings.Contains(string(second), "<loc>https://app.com/ebooks/negeri-5-menara</loc>") {
+			t.Errorf("unexpected ebook sitemaps:\n%s\n%s", first, second)
+		}
+		if !strings.Contains(string(first), "<lastmod>2026-10-01T08:00:00Z</lastmod>") {
+			t.Errorf("expected lastmod from the update time:\n%s", first)
+		}
+		if articles, _ := s.GetSitemap(ctx, "articles-1.xml"); articles != nil {
+			t.Error("expected no sitemap for a kind without pages")
+		}
+	})
+
+	t.Run("should index the sitemaps with their latest change", func(t *testing.T) {
+		index, _ := s.GetSitemap(ctx, entity.SitemapIndexName)
+		for _, want := range []string{
+			"<sitemapindex xmlns=\"http://www.sitemaps.org/schemas/sitemap/0.9\">",
+			"<loc>https://app.com/sitemaps/ebooks-1.xml</loc>\n    <lastmod>2026-10-01T08:00:00Z</lastmod>",
+			"<loc>https://app.com/sitemaps/ebooks-2.xml</loc>\n    <lastmod>2026-09-01T08:00:00Z</lastmod>",
+			"<loc>https://app.com/sitemaps/categories-1.xml</loc>",
+		} {
+			if !strings.Contains(string(index), want) {
+				t.Errorf("expected %q in the index:\n%s", want, index)
+			}
+		}
+	})
+
+	t.Run("should only regenerate when the content changed", func(t *testing.T) {
+		listed := repo.listed
+		if regenerated, _ := s.RefreshSitemaps(ctx); regenerated || repo.listed != listed {
+			t.Error("expected unchanged content not to be regenerated")
+		}
+
+		repo.version = "v2"
+		if regenerated, _ := s.RefreshSitemaps(ctx); !regenerated {
+			t.Error("expected changed content to be regenerated")
+		}
+	})
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"buku-pintar/internal/domain/service"
+	"context"
+)
+
+type summaryAudioService struct {
+	summaryAudioRepo repository.SummaryAudioRepository
+}
+
+func NewSummaryAudioService(summaryAudioRepo repository.SummaryAudioRepository) service.SummaryAudioService {
+	return &summaryAudioService{
+		summaryAudioRepo: summaryAudioRepo,
+	}
+}
+
+func (s *summaryAudioService) GetAudio(ctx context.Context, summaryID string) (*entity.SummaryAudio, error) {
+	return s.summaryAudioRepo.GetAudio(ctx, summaryID)
+}
+
+func (s *summaryAudioService) SaveProgress(ctx context.Context, progress *entity.ListeningProgress) (*entity.ListeningProgress, bool, error) {
+	if err := s.summaryAudioRepo.UpsertProgress(ctx, progress); err != nil {
+		return nil, false, err
+	}
+
+	stored, err := s.summaryAudioRepo.GetProgress(ctx, progress.UserID, progress.SummaryID)
+	if err != nil {
+		return nil, false, err
+	}
+	if stored == nil {
+		return progress, true, nil
+	}
+
+	// The stored position is another device's if it was reported later
+	return stored, progress.IsNewerThan(stored), nil
+}
+
+func (s *summaryAudioService) GetProgress(ctx context.Context, userID, summaryID string) (*entity.ListeningProgress, error) {
+	return s.summaryAudioRepo.GetProgress(ctx, userID, summaryID)
+}
@@ -12,15 +12,18 @@ import (
 type SummaryServiceImpl struct {
 	summaryRepository repository.SummaryRepository
 	summaryRedisRepository repository.SummaryRedisRepository
+	mediaURLs service.MediaURLResolver
 }
 
 func NewSummaryServiceImpl(
 	summaryRepository repository.SummaryRepository,
 	summaryRedisRepository repository.SummaryRedisRepository,
+	mediaURLs service.MediaURLResolver,
 ) service.SummaryService {
 	return &SummaryServiceImpl{
 		summaryRepository: summaryRepository,
 		summaryRedisRepository: summaryRedisRepository,
+		mediaURLs: mediaURLs,
 	}
 }
 
@@ -41,6 +44,7 @@ func (s *SummaryServiceImpl) GetSummaryByID(ctx context.Context, id string) (*en
 	// Try to get from cache first
 	summary, err := s.summaryRedisRepository.GetSummaryByID(ctx, id)
 	if err == nil && summary != nil {
+		summary.ResolveMediaURLs(s.mediaURLs.URL)
 		return summary, nil
 	}
 
@@ -53,6 +57,7 @@ func (s *SummaryServiceImpl) GetSummaryByID(ctx context.Context, id string) (*en
 	// Cache the result
 	if summary != nil {
 		_ = s.summaryRedisRepository.SetSummaryByID(ctx, id, summary)
+		summary.ResolveMediaURLs(s.mediaURLs.URL)
 	}
 
 	return summary, nil
@@ -107,10 +112,26 @@ func (s *SummaryServiceImpl) ListSummaries(ctx context.Context, limit, offset in
 	return s.convertToSummaryResponses(summaries), nil
 }
 
+// ListSummariesByCursor reads straight from the database; keyset pages are cheap and
+// caching them would serve stale positions while summaries are being added
+func (s *SummaryServiceImpl) ListSummariesByCursor(ctx context.Context, cursor *entity.PageCursor, limit int) ([]*response.EbookSummaryResponse, *entity.CursorPageInfo, error) {
+	// Fetch one extra row to know whether another page follows
+	summaries, err := s.summaryRepository.ListSummariesByCursor(ctx, cursor, limit+1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	summaries, page := entity.BuildCursorPage(summaries, limit, cursor, func(summary *entity.EbookSummaryList) entity.PageCursor {
+		return entity.NewTimeCursor(entity.CursorSortSummaries, summary.CreatedAt, summary.ID)
+	})
+	return s.convertToSummaryResponses(summaries), page, nil
+}
+
 func (s *SummaryServiceImpl) GetSummariesByEbookID(ctx context.Context, ebookID string, limit, offset int) ([]*entity.EbookSummary, error) {
 	// Try to get from cache first
 	summaries, err := s.summaryRedisRepository.GetSummariesByEbookID(ctx, ebookID, limit, offset)
 	if err == nil && summaries != nil {
+		resolveMediaURLs(s.mediaURLs, summaries...)
 		return summaries, nil
 	}
 
@@ -125,6 +146,7 @@ func (s *SummaryServiceImpl) GetSummariesByEbookID(ctx context.Context, ebookID
 		_ = s.summaryRedisRepository.SetSummariesByEbookID(ctx, ebookID, limit, offset, summaries)
 	}
 
+	resolveMediaURLs(s.mediaURLs, summaries...)
 	return summaries, nil
 }
 
@@ -170,6 +192,7 @@ func (s *SummaryServiceImpl) CountSummariesByEbookID(ctx context.Context, ebookI
 func (s *SummaryServiceImpl) convertToSummaryResponses(summaries []*entity.EbookSummaryList) []*response.EbookSummaryResponse {
 	var responses []*response.EbookSummaryResponse
 	for _, summary := range summaries {
+		summary.ResolveMediaURLs(s.mediaURLs.URL)
 		responses = append(responses, &response.EbookSummaryResponse{
 			ID:          summary.ID,
 			EbookID:     summary.EbookID,
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"buku-pintar/internal/domain/service"
+	"context"
+)
+
+type tableOfContentService struct {
+	tableOfContentRepo repository.TableOfContentRepository
+}
+
+func NewTableOfContentService(tableOfContentRepo repository.TableOfContentRepository) service.TableOfContentService {
+	return &tableOfContentService{
+		tableOfContentRepo: tableOfContentRepo,
+	}
+}
+
+func (s *tableOfContentService) GetTableOfContents(ctx context.Context, ebookID string) ([]*entity.TableOfContent, error) {
+	return s.tableOfContentRepo.ListByEbook(ctx, ebookID)
+}
+
+func (s *tableOfContentService) ReplaceTableOfContents(ctx context.Context, ebookID string, entries []*entity.TableOfContent) error {
+	return s.tableOfContentRepo.ReplaceByEbook(ctx, ebookID, entries)
+}
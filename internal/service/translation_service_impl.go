@@ -0,0 +1,70 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"buku-pintar/internal/domain/service"
+	"context"
+	"log"
+)
+
+type translationService struct {
+	translationRepo   repository.TranslationRepository
+	ebookRedisRepo    repository.EbookRedisRepository
+	categoryRedisRepo repository.CategoryRedisRepository
+}
+
+// NewTranslationService creates a new instance of TranslationService.
+// Ebooks and categories are the only translated content cached in Redis.
+func NewTranslationService(
+	translationRepo repository.TranslationRepository,
+	ebookRedisRepo repository.EbookRedisRepository,
+	categoryRedisRepo repository.CategoryRedisRepository,
+) service.TranslationService {
+	return &translationService{
+		translationRepo:   translationRepo,
+		ebookRedisRepo:    ebookRedisRepo,
+		categoryRedisRepo: categoryRedisRepo,
+	}
+}
+
+func (s *translationService) SaveTranslation(ctx context.Context, translation *entity.Translation) error {
+	if err := s.translationRepo.Upsert(ctx, translation); err != nil {
+		return err
+	}
+	s.invalidateCache(ctx, translation.ContentType)
+	return nil
+}
+
+func (s *translationService) ListTranslations(ctx context.Context, contentType entity.TranslatableContent, contentID string) ([]*entity.Translation, error) {
+	return s.translationRepo.ListByContent(ctx, contentType, contentID)
+}
+
+func (s *translationService) DeleteTranslation(ctx context.Context, contentType entity.TranslatableContent, contentID, locale string) (bool, error) {
+	deleted, err := s.translationRepo.Delete(ctx, contentType, contentID, locale)
+	if err != nil || !deleted {
+		return deleted, err
+	}
+	s.invalidateCache(ctx, contentType)
+	return true, nil
+}
+
+func (s *translationService) ContentExists(ctx context.Context, contentType entity.TranslatableContent, contentID string) (bool, error) {
+	return s.translationRepo.ContentExists(ctx, contentType, contentID)
+}
+
+// invalidateCache clears the cached content showing the translated text. Category names
+// also appear in the ebook catalog facets, so their translations clear the ebook cache too.
+func (s *translationService) invalidateCache(ctx context.Context, contentType entity.TranslatableContent) {
+	switch contentType {
+	case entity.TranslatableCategory:
+		if err := s.categoryRedisRepo.InvalidateCategoryCache(ctx); err != nil {
+			log.Printf("Failed to invalidate category cache after translation change: %v", err)
+		}
+		fallthrough
+	case entity.TranslatableEbook:
+		if err := s.ebookRedisRepo.InvalidateEbookCache(ctx); err != nil {
+			log.Printf("Failed to invalidate ebook cache after translation change: %v", err)
+		}
+	}
+}
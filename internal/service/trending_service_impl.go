@@ -0,0 +1,185 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"buku-pintar/internal/domain/service"
+	"context"
+	"log"
+	"math"
+	"time"
+)
+
+// minTrendingRetentionDays keeps enough snapshots to rebuild the longest window
+const minTrendingRetentionDays = 31
+
+type trendingService struct {
+	trendingRepo      repository.TrendingRepository
+	trendingRedisRepo repository.TrendingRedisRepository
+	mediaURLs         service.MediaURLResolver
+	retentionDays     int
+}
+
+// NewTrendingService keeps daily snapshots in MySQL for retentionDays, and at least for the longest window
+func NewTrendingService(
+	trendingRepo repository.TrendingRepository,
+	trendingRedisRepo repository.TrendingRedisRepository,
+	mediaURLs service.MediaURLResolver,
+	retentionDays int,
+) service.TrendingService {
+	return &trendingService{
+		trendingRepo:      trendingRepo,
+		trendingRedisRepo: trendingRedisRepo,
+		mediaURLs:         mediaURLs,
+		retentionDays:     max(retentionDays, minTrendingRetentionDays),
+	}
+}
+
+func (s *trendingService) RecordEngagement(ctx context.Context, ebookID, categoryID string, event entity.EngagementEvent, actorID string) error {
+	weight := event.Weight()
+	if ebookID == "" || weight == 0 {
+		return nil
+	}
+
+	today := trendingDay(time.Now())
+	if actorID != "" {
+		first, err := s.trendingRedisRepo.MarkEngaged(ctx, today, event, ebookID, actorID)
+		if err != nil || !first {
+			return err
+		}
+	}
+
+	return s.trendingRedisRepo.IncrementScore(ctx, today, ebookID, categoryID, weight)
+}
+
+// GetTrendingEbooks returns nothing when Redis fails, like a window without engagement.
+// Ranked ebooks that were unpublished since are left out, so a page can come back short.
+func (s *trendingService) GetTrendingEbooks(ctx context.Context, window entity.TrendingWindow, categoryID string, limit, offset int) ([]*entity.EbookList, int64, error) {
+	scored, total, err := s.trendingRedisRepo.Rank(ctx, window, trendingWeights(window, time.Now()), categoryID, limit, offset)
+	if err != nil {
+		log.Printf("Failed to rank trending ebooks: %v", err)
+		return nil, 0, nil
+	}
+	if len(scored) == 0 {
+		return nil, total, nil
+	}
+
+	ids := make([]string, 0, len(scored))
+	for _, item := range scored {
+		ids = append(ids, item.EbookID)
+	}
+
+	ebooks, err := s.trendingRepo.ListPublishedByIDs(ctx, ids)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	resolveMediaURLs(s.mediaURLs, ebooks...)
+	return ebooks, total, nil
+}
+
+func (s *trendingService) SnapshotScores(ctx context.Context) (int, error) {
+	now := time.Now()
+	today := trendingDay(now)
+	weights := trendingWeights(entity.TrendingWindowMonth, now)
+
+	// Days missing from Redis, e.g. after a flush, are restored first so the windows are whole again
+	restored := 0
+	for day := range weights {
+		exists, err := s.trendingRedisRepo.HasDay(ctx, day)
+		if err != nil {
+			return 0, err
+		}
+		if exists {
+			continue
+		}
+
+		scores, err := s.trendingRepo.ListSnapshot(ctx, day)
+		if err != nil {
+			return 0, err
+		}
+		if len(scores) == 0 {
+			continue
+		}
+		if err := s.trendingRedisRepo.RestoreDay(ctx, day, scores); err != nil {
+			return 0, err
+		}
+		restored++
+	}
+
+	// Today is still taking events and yesterday took some after the last run; older days are final
+	for _, day := range []time.Time{today.AddDate(0, 0, -1), today} {
+		scores, err := s.trendingRedisRepo.GetDayScores(ctx, day)
+		if err != nil {
+			return 0, err
+		}
+		if len(scores) == 0 {
+			continue
+		}
+		if err := s.trendingRepo.SaveSnapshot(ctx, day, scores); err != nil {
+			return 0, err
+		}
+	}
+
+	// The popularity score used by sort=popular is the 30-day trending score
+	totals := make(map[string]float64)
+	for day, weight := range weights {
+		scores, err := s.trendingRedisRepo.GetDayScores(ctx, day)
+		if err != nil {
+			return 0, err
+		}
+		for _, score := range scores {
+			totals[score.EbookID] += weight * score.Score
+		}
+	}
+	popularity := make(map[string]int, len(totals))
+	for ebookID, total := range totals {
+		popularity[ebookID] = int(math.Round(total))
+	}
+	if err := s.trendingRepo.UpdatePopularityScores(ctx, popularity); err != nil {
+		return 0, err
+	}
+
+	if err := s.trendingRepo.DeleteSnapshotsBefore(ctx, today.AddDate(0, 0, -s.retentionDays)); err != nil {
+		return len(popularity), err
+	}
+
+	log.Printf("Snapshotted trending scores of %d ebooks, restored %d days", len(popularity), restored)
+	return len(popularity), nil
+}
+
+// trendingDay is the UTC date an event at t is counted on
+func trendingDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// trendingWeights gives every daily bucket overlapping the window ending at now a weight: the share of
+// the bucket that lies inside the window, halved for every half-life between now and the middle of that share.
+// Events are assumed to be spread evenly over a day, which is all a daily bucket can tell.
+func trendingWeights(window entity.TrendingWindow, now time.Time) map[time.Time]float64 {
+	now = now.UTC()
+	start := now.Add(-window.Duration())
+	halfLife := float64(window.HalfLife())
+
+	weights := make(map[time.Time]float64)
+	for day := trendingDay(start); day.Before(now); day = day.AddDate(0, 0, 1) {
+		bucketEnd := day.Add(24 * time.Hour)
+		if bucketEnd.After(now) {
+			bucketEnd = now
+		}
+		from := day
+		if start.After(from) {
+			from = start
+		}
+		if !bucketEnd.After(from) {
+			continue
+		}
+
+		share := float64(bucketEnd.Sub(from)) / float64(bucketEnd.Sub(day))
+		age := float64(now.Sub(from.Add(bucketEnd.Sub(from) / 2)))
+		weights[day] = share * math.Pow(0.5, age/halfLife)
+	}
+
+	return weights
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+	"math"
+	"testing"
+	"time"
+)
+
+// MockTrendingRedisRepository keeps increments and engagement marks in memory
+type MockTrendingRedisRepository struct {
+	scores  map[string]float64
+	engaged map[string]bool
+}
+
+func (m *MockTrendingRedisRepository) IncrementScore(ctx context.Context, day time.Time, ebookID, categoryID string, score float64) error {
+	if m.scores == nil {
+		m.scores = map[string]float64{}
+	}
+	m.scores[ebookID] += score
+	if categoryID != "" {
+		m.scores[categoryID+"/"+ebookID] += score
+	}
+	return nil
+}
+
+func (m *MockTrendingRedisRepository) MarkEngaged(ctx context.Context, day time.Time, event entity.EngagementEvent, ebookID, actorID string) (bool, error) {
+	if m.engaged == nil {
+		m.engaged = map[string]bool{}
+	}
+	key := string(event) + ebookID + actorID
+	if m.engaged[key] {
+		return false, nil
+	}
+	m.engaged[key] = true
+	return true, nil
+}
+
+func (m *MockTrendingRedisRepository) Rank(ctx context.Context, window entity.TrendingWindow, weights map[time.Time]float64, categoryID string, limit, offset int) ([]*entity.ScoredEbook, int64, error) {
+	return nil, 0, nil
+}
+
+func (m *MockTrendingRedisRepository) GetDayScores(ctx context.Context, day time.Time) ([]*entity.TrendingScore, error) {
+	return nil, nil
+}
+
+func (m *MockTrendingRedisRepository) HasDay(ctx context.Context, day time.Time) (bool, error) {
+	return true, nil
+}
+
+func (m *MockTrendingRedisRepository) RestoreDay(ctx context.Context, day time.Time, scores []*entity.TrendingScore) error {
+	return nil
+}
+
+func TestTrendingService_RecordEngagement(t *testing.T) {
+	ctx := context.Background()
+	redisRepo := &MockTrendingRedisRepository{}
+	s := NewTrendingService(nil, redisRepo, nil, 90)
+
+	// A signed-in reader counts once a day per event, anonymous views count every time
+	for i := 0; i < 2; i++ {
+		if err := s.RecordEngagement(ctx, "ebook-1", "category-1", entity.EngagementRead, "user-1"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := s.RecordEngagement(ctx, "ebook-1", "category-1", entity.EngagementView, ""); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := s.RecordEngagement(ctx, "ebook-1", "", entity.EngagementPurchase, "user-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := redisRepo.scores["ebook-1"]; got != 15 {
+		t.Errorf("expected a global score of 15, got %v", got)
+	}
+	if got := redisRepo.scores["category-1/ebook-1"]; got != 5 {
+		t.Errorf("expected a category score of 5, got %v", got)
+	}
+}
+
+func TestTrendingWeights(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, time.March, d, 0, 0, 0, 0, time.UTC) }
+	now := day(10).Add(6 * time.Hour)
+
+	t.Run("24h covers today and the last 18 hours of yesterday", func(t *testing.T) {
+		weights := trendingWeights(entity.TrendingWindowDay, now)
+		if len(weights) != 2 {
+			t.Fatalf("expected 2 buckets, got %v", weights)
+		}
+		// Today: whole bucket so far, midpoint 3h ago. Yesterday: 75% inside, midpoint 15h ago.
+		if want := math.Pow(0.5, 3.0/12); math.Abs(weights[day(10)]-want) > 1e-9 {
+			t.Errorf("expected today's weight %v, got %v", want, weights[day(10)])
+		}
+		if want := 0.75 * math.Pow(0.5, 15.0/12); math.Abs(weights[day(9)]-want) > 1e-9 {
+			t.Errorf("expected yesterday's weight %v, got %v", want, weights[day(9)])
+		}
+	})
+
+	t.Run("older buckets weigh less and nothing before the window counts", func(t *testing.T) {
+		weights := trendingWeights(entity.TrendingWindowWeek, now)
+		if len(weights) != 8 {
+			t.Fatalf("expected 8 buckets, got %d", len(weights))
+		}
+		for d := 4; d < 10; d++ {
+			if weights[day(d)] >= weights[day(d+1)] {
+				t.Errorf("expected %d March to weigh less than the day after", d)
+			}
+		}
+		if _, ok := weights[day(2)]; ok {
+			t.Error("expected 2 March to be outside the window")
+		}
+	})
+
+	t.Run("a window starting at midnight leaves out the empty bucket", func(t *testing.T) {
+		weights := trendingWeights(entity.TrendingWindowDay, day(10))
+		if len(weights) != 1 || weights[day(9)] == 0 {
+			t.Errorf("expected only yesterday, got %v", weights)
+		}
+	})
+}
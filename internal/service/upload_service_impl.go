@@ -0,0 +1,102 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"buku-pintar/internal/domain/service"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// expiredUploadBatchSize is how many abandoned uploads are deleted per query
+const expiredUploadBatchSize = 100
+
+type uploadService struct {
+	uploadRepo repository.UploadRepository
+	dir        string
+}
+
+// NewUploadService creates a new instance of UploadService that keeps partial data under dir
+func NewUploadService(uploadRepo repository.UploadRepository, dir string) service.UploadService {
+	return &uploadService{
+		uploadRepo: uploadRepo,
+		dir:        dir,
+	}
+}
+
+func (s *uploadService) CreateUpload(ctx context.Context, upload *entity.Upload) error {
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(s.path(upload.ID), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	file.Close()
+
+	if err := s.uploadRepo.Create(ctx, upload); err != nil {
+		os.Remove(s.path(upload.ID))
+		return err
+	}
+	return nil
+}
+
+func (s *uploadService) GetUpload(ctx context.Context, id string) (*entity.Upload, error) {
+	return s.uploadRepo.GetByID(ctx, id)
+}
+
+func (s *uploadService) OpenUploadData(id string) (service.UploadData, error) {
+	return os.OpenFile(s.path(id), os.O_RDWR, 0)
+}
+
+func (s *uploadService) RecordUploadOffset(ctx context.Context, id string, from, to int64, expiresAt time.Time) (bool, error) {
+	return s.uploadRepo.UpdateOffset(ctx, id, from, to, expiresAt)
+}
+
+func (s *uploadService) CompleteUpload(ctx context.Context, id, mediaID string) error {
+	if err := s.uploadRepo.SetMedia(ctx, id, mediaID); err != nil {
+		return err
+	}
+	return s.removeData(id)
+}
+
+func (s *uploadService) DeleteUpload(ctx context.Context, id string) error {
+	if err := s.removeData(id); err != nil {
+		return err
+	}
+	return s.uploadRepo.Delete(ctx, id)
+}
+
+func (s *uploadService) DeleteExpiredUploads(ctx context.Context, now time.Time) (int, error) {
+	deleted := 0
+	for {
+		uploads, err := s.uploadRepo.ListExpired(ctx, now, expiredUploadBatchSize)
+		if err != nil {
+			return deleted, err
+		}
+		for _, upload := range uploads {
+			if err := s.DeleteUpload(ctx, upload.ID); err != nil {
+				return deleted, err
+			}
+			deleted++
+		}
+		if len(uploads) < expiredUploadBatchSize {
+			return deleted, nil
+		}
+	}
+}
+
+func (s *uploadService) removeData(id string) error {
+	if err := os.Remove(s.path(id)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// path is where the partial data of an upload is kept; IDs are generated UUIDs, never client input
+func (s *uploadService) path(id string) string {
+	return filepath.Join(s.dir, id)
+}
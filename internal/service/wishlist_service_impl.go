@@ -0,0 +1,47 @@
+package service
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"buku-pintar/internal/domain/service"
+	"context"
+)
+
+// wishlistService is not cached: wishlists are per user and change right after being read
+type wishlistService struct {
+	wishlistRepo repository.WishlistRepository
+	mediaURLs    service.MediaURLResolver
+}
+
+func NewWishlistService(wishlistRepo repository.WishlistRepository, mediaURLs service.MediaURLResolver) service.WishlistService {
+	return &wishlistService{
+		wishlistRepo: wishlistRepo,
+		mediaURLs:    mediaURLs,
+	}
+}
+
+func (s *wishlistService) AddToWishlist(ctx context.Context, userID, ebookID string) (bool, error) {
+	return s.wishlistRepo.Add(ctx, userID, ebookID)
+}
+
+func (s *wishlistService) RemoveFromWishlist(ctx context.Context, userID, ebookID string) error {
+	return s.wishlistRepo.Remove(ctx, userID, ebookID)
+}
+
+func (s *wishlistService) GetWishlist(ctx context.Context, userID string, limit, offset int) ([]*entity.WishlistItem, error) {
+	items, err := s.wishlistRepo.ListByUser(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	resolveMediaURLs(s.mediaURLs, items...)
+	return items, nil
+}
+
+func (s *wishlistService) GetWishlistCount(ctx context.Context, userID string) (int64, error) {
+	return s.wishlistRepo.CountByUser(ctx, userID)
+}
+
+func (s *wishlistService) GetEbookWishlistCount(ctx context.Context, ebookID string) (int64, error) {
+	return s.wishlistRepo.CountByEbook(ctx, ebookID)
+}
@@ -0,0 +1,20 @@
+package usecase
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// AnalyticsUsecase defines the interface for usage event ingestion and aggregate use cases
+type AnalyticsUsecase interface {
+	// TrackEvents validates a batch against the event schema, enriches it with the client's request
+	// context and queues it for storage, returning the number of accepted events. A batch with an
+	// invalid event is rejected as a whole.
+	TrackEvents(ctx context.Context, client *entity.AnalyticsClient, events []*entity.AnalyticsEvent) (int, error)
+	// GetTotals counts events per type; the range defaults to the last 7 days
+	GetTotals(ctx context.Context, filter *entity.AnalyticsFilter) ([]*entity.AnalyticsTotal, error)
+	// GetDailyCounts counts events per UTC day and type
+	GetDailyCounts(ctx context.Context, filter *entity.AnalyticsFilter) ([]*entity.AnalyticsDailyCount, error)
+	// GetTopItems lists the ebooks, search queries or banners with the most events
+	GetTopItems(ctx context.Context, filter *entity.AnalyticsFilter, dimension string, limit int) ([]*entity.AnalyticsTopItem, error)
+}
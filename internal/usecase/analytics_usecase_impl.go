@@ -0,0 +1,227 @@
+package usecase
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"buku-pintar/internal/domain/service"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxAnalyticsBatch      = 100
+	maxAnalyticsProperties = 2048
+	maxAnalyticsQuery      = 255
+	maxAnalyticsSessionID  = 64
+	maxAnalyticsContentID  = 36
+
+	// Clients may send events they queued while offline, up to a week old
+	maxAnalyticsEventAge  = 7 * 24 * time.Hour
+	maxAnalyticsClockSkew = 5 * time.Minute
+
+	defaultAnalyticsRange = 7 * 24 * time.Hour
+	maxAnalyticsRange     = 366 * 24 * time.Hour
+)
+
+// ErrAnalyticsBusy is returned when the event buffer is full; the client should retry later
+var ErrAnalyticsBusy = errors.New(constant.ERR_ANALYTICS_BUSY)
+
+type analyticsUsecase struct {
+	analyticsService service.AnalyticsService
+}
+
+func NewAnalyticsUsecase(analyticsService service.AnalyticsService) AnalyticsUsecase {
+	return &analyticsUsecase{
+		analyticsService: analyticsService,
+	}
+}
+
+func (u *analyticsUsecase) TrackEvents(ctx context.Context, client *entity.AnalyticsClient, events []*entity.AnalyticsEvent) (int, error) {
+	if len(events) == 0 {
+		return 0, &ValidationError{Message: constant.ERR_ANALYTICS_NO_EVENTS}
+	}
+	if len(events) > maxAnalyticsBatch {
+		return 0, &ValidationError{Message: constant.ERR_ANALYTICS_TOO_MANY}
+	}
+
+	now := time.Now().UTC()
+	for i, event := range events {
+		if message := validateAnalyticsEvent(event, now); message != "" {
+			return 0, &ValidationError{Message: fmt.Sprintf("events[%d]: %s", i, message)}
+		}
+
+		event.ID = uuid.New().String()
+		event.ReceivedAt = now
+		enrichAnalyticsEvent(event, client)
+	}
+
+	if err := u.analyticsService.BufferEvents(ctx, events); err != nil {
+		if errors.Is(err, repository.ErrBufferFull) {
+			return 0, ErrAnalyticsBusy
+		}
+		return 0, err
+	}
+
+	return len(events), nil
+}
+
+// validateAnalyticsEvent checks an event against the schema of its type, trimming it on the way,
+// and returns what is wrong with it or an empty string
+func validateAnalyticsEvent(event *entity.AnalyticsEvent, now time.Time) string {
+	event.Type = entity.AnalyticsEventType(strings.TrimSpace(string(event.Type)))
+	if !event.Type.IsValid() {
+		return constant.ERR_ANALYTICS_TYPE_INVALID
+	}
+
+	event.SessionID = trimOptional(event.SessionID)
+	event.EbookID = trimOptional(event.EbookID)
+	event.BannerID = trimOptional(event.BannerID)
+	event.Query = trimOptional(event.Query)
+
+	fields := []struct {
+		name    string
+		present bool
+	}{
+		{"ebook_id", event.EbookID != nil},
+		{"banner_id", event.BannerID != nil},
+		{"page_number", event.PageNumber != nil},
+		{"position_seconds", event.PositionSeconds != nil},
+		{"query", event.Query != nil},
+	}
+	required := make(map[string]bool)
+	for _, name := range event.Type.Fields() {
+		required[name] = true
+	}
+	for _, field := range fields {
+		if required[field.name] && !field.present {
+			return fmt.Sprintf(constant.ERR_ANALYTICS_FIELD_REQUIRED, field.name, event.Type)
+		}
+		if !required[field.name] && field.present {
+			return fmt.Sprintf(constant.ERR_ANALYTICS_FIELD_UNKNOWN, field.name, event.Type)
+		}
+	}
+
+	for _, limit := range []struct {
+		name  string
+		value *string
+		max   int
+	}{
+		{"session_id", event.SessionID, maxAnalyticsSessionID},
+		{"ebook_id", event.EbookID, maxAnalyticsContentID},
+		{"banner_id", event.BannerID, maxAnalyticsContentID},
+		{"query", event.Query, maxAnalyticsQuery},
+	} {
+		if limit.value != nil && utf8.RuneCountInString(*limit.value) > limit.max {
+			return fmt.Sprintf(constant.ERR_ANALYTICS_FIELD_TOO_LONG, limit.name, limit.max)
+		}
+	}
+	if event.PageNumber != nil && *event.PageNumber < 1 {
+		return constant.ERR_ANALYTICS_PAGE_INVALID
+	}
+	if event.PositionSeconds != nil && *event.PositionSeconds < 0 {
+		return constant.ERR_ANALYTICS_POSITION
+	}
+
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = now
+	}
+	event.OccurredAt = event.OccurredAt.UTC()
+	if event.OccurredAt.After(now.Add(maxAnalyticsClockSkew)) || event.OccurredAt.Before(now.Add(-maxAnalyticsEventAge)) {
+		return constant.ERR_ANALYTICS_TIME_INVALID
+	}
+
+	if len(event.Properties) > 0 {
+		var properties map[string]json.RawMessage
+		if len(event.Properties) > maxAnalyticsProperties || json.Unmarshal(event.Properties, &properties) != nil {
+			return constant.ERR_ANALYTICS_PROPERTIES
+		}
+		if properties == nil {
+			event.Properties = nil // null
+		}
+	}
+
+	return ""
+}
+
+// enrichAnalyticsEvent adds the request context, kept within the column sizes
+func enrichAnalyticsEvent(event *entity.AnalyticsEvent, client *entity.AnalyticsClient) {
+	event.UserID = nil
+	if client == nil {
+		return
+	}
+	if client.UserID != "" {
+		userID := client.UserID
+		event.UserID = &userID
+	}
+
+	event.Locale = client.Locale
+	event.IPAddress = client.IPAddress
+	event.UserAgent = client.UserAgent
+	if len(event.IPAddress) > 45 {
+		event.IPAddress = event.IPAddress[:45]
+	}
+	if len(event.UserAgent) > 255 {
+		event.UserAgent = event.UserAgent[:255]
+	}
+}
+
+func (u *analyticsUsecase) GetTotals(ctx context.Context, filter *entity.AnalyticsFilter) ([]*entity.AnalyticsTotal, error) {
+	if err := normalizeAnalyticsFilter(filter); err != nil {
+		return nil, err
+	}
+
+	return u.analyticsService.GetTotals(ctx, filter)
+}
+
+func (u *analyticsUsecase) GetDailyCounts(ctx context.Context, filter *entity.AnalyticsFilter) ([]*entity.AnalyticsDailyCount, error) {
+	if err := normalizeAnalyticsFilter(filter); err != nil {
+		return nil, err
+	}
+
+	return u.analyticsService.GetDailyCounts(ctx, filter)
+}
+
+func (u *analyticsUsecase) GetTopItems(ctx context.Context, filter *entity.AnalyticsFilter, dimension string, limit int) ([]*entity.AnalyticsTopItem, error) {
+	if err := normalizeAnalyticsFilter(filter); err != nil {
+		return nil, err
+	}
+
+	topDimension := entity.AnalyticsDimension(strings.TrimSpace(dimension))
+	if topDimension == "" {
+		topDimension = entity.AnalyticsDimensionEbook
+	}
+	if !topDimension.IsValid() {
+		return nil, &ValidationError{Message: constant.ERR_ANALYTICS_DIMENSION}
+	}
+	if limit <= 0 {
+		limit = 10 // default limit
+	}
+
+	return u.analyticsService.GetTopItems(ctx, filter, topDimension, limit)
+}
+
+// normalizeAnalyticsFilter defaults the range to the 7 days up to now and bounds it to a year
+func normalizeAnalyticsFilter(filter *entity.AnalyticsFilter) error {
+	if filter.To.IsZero() {
+		filter.To = time.Now().UTC()
+	}
+	if filter.From.IsZero() {
+		filter.From = filter.To.Add(-defaultAnalyticsRange)
+	}
+	if !filter.From.Before(filter.To) || filter.To.Sub(filter.From) > maxAnalyticsRange {
+		return &ValidationError{Message: constant.ERR_ANALYTICS_RANGE_INVALID}
+	}
+	if filter.Type != "" && !filter.Type.IsValid() {
+		return &ValidationError{Message: constant.ERR_ANALYTICS_TYPE_INVALID}
+	}
+
+	return nil
+}
@@ -0,0 +1,246 @@
+package usecase
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// MockAnalyticsService records buffered events and the filter and dimension of the last query
+type MockAnalyticsService struct {
+	buffered  []*entity.AnalyticsEvent
+	filter    *entity.AnalyticsFilter
+	dimension entity.AnalyticsDimension
+	limit     int
+	err       error
+}
+
+func (m *MockAnalyticsService) BufferEvents(ctx context.Context, events []*entity.AnalyticsEvent) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.buffered = append(m.buffered, events...)
+	return nil
+}
+
+func (m *MockAnalyticsService) FlushEvents(ctx context.Context) (int, error) {
+	return 0, m.err
+}
+
+func (m *MockAnalyticsService) MaintainPartitions(ctx context.Context) error {
+	return m.err
+}
+
+func (m *MockAnalyticsService) GetTotals(ctx context.Context, filter *entity.AnalyticsFilter) ([]*entity.AnalyticsTotal, error) {
+	m.filter = filter
+	return nil, m.err
+}
+
+func (m *MockAnalyticsService) GetDailyCounts(ctx context.Context, filter *entity.AnalyticsFilter) ([]*entity.AnalyticsDailyCount, error) {
+	m.filter = filter
+	return nil, m.err
+}
+
+func (m *MockAnalyticsService) GetTopItems(ctx context.Context, filter *entity.AnalyticsFilter, dimension entity.AnalyticsDimension, limit int) ([]*entity.AnalyticsTopItem, error) {
+	m.filter = filter
+	m.dimension = dimension
+	m.limit = limit
+	return nil, m.err
+}
+
+func analyticsString(value string) *string {
+	return &value
+}
+
+func analyticsInt(value int) *int {
+	return &value
+}
+
+func TestAnalyticsUsecase_TrackEvents(t *testing.T) {
+	ctx := context.Background()
+	client := &entity.AnalyticsClient{UserID: "user-1", Locale: "id", IPAddress: "10.0.0.1", UserAgent: "reader/1.0"}
+
+	t.Run("should enrich and buffer a valid batch", func(t *testing.T) {
+		analyticsService := &MockAnalyticsService{}
+		u := NewAnalyticsUsecase(analyticsService)
+
+		occurredAt := time.Now().Add(-time.Hour)
+		events := []*entity.AnalyticsEvent{
+			{Type: entity.AnalyticsEventView, EbookID: analyticsString(" ebook-1 "), OccurredAt: occurredAt},
+			{Type: entity.AnalyticsEventReadPage, EbookID: analyticsString("ebook-1"), PageNumber: analyticsInt(3)},
+			{Type: entity.AnalyticsEventSearch, Query: analyticsString("laskar pelangi"), Properties: json.RawMessage(`{"results":4}`)},
+			{Type: entity.AnalyticsEventBannerClick, BannerID: analyticsString("banner-1"), UserID: analyticsString("spoofed")},
+		}
+
+		accepted, err := u.TrackEvents(ctx, client, events)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if accepted != 4 || len(analyticsService.buffered) != 4 {
+			t.Fatalf("expected 4 buffered events, got %d (accepted %d)", len(analyticsService.buffered), accepted)
+		}
+
+		for _, event := range analyticsService.buffered {
+			if event.ID == "" || event.ReceivedAt.IsZero() || event.OccurredAt.IsZero() {
+				t.Errorf("event was not stamped: %+v", event)
+			}
+			if event.UserID == nil || *event.UserID != "user-1" || event.IPAddress != "10.0.0.1" || event.UserAgent != "reader/1.0" || event.Locale != "id" {
+				t.Errorf("event was not enriched with the request: %+v", event)
+			}
+		}
+		if *events[0].EbookID != "ebook-1" || !events[0].OccurredAt.Equal(occurredAt) {
+			t.Errorf("unexpected view event %+v", events[0])
+		}
+	})
+
+	t.Run("should not attribute anonymous events to a user", func(t *testing.T) {
+		analyticsService := &MockAnalyticsService{}
+		u := NewAnalyticsUsecase(analyticsService)
+
+		events := []*entity.AnalyticsEvent{
+			{Type: entity.AnalyticsEventView, EbookID: analyticsString("ebook-1"), UserID: analyticsString("spoofed")},
+		}
+		if _, err := u.TrackEvents(ctx, &entity.AnalyticsClient{UserAgent: strings.Repeat("a", 300)}, events); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if events[0].UserID != nil || len(events[0].UserAgent) != 255 {
+			t.Errorf("unexpected anonymous event %+v", events[0])
+		}
+	})
+
+	tests := []struct {
+		name  string
+		event *entity.AnalyticsEvent
+	}{
+		{"unknown type", &entity.AnalyticsEvent{Type: "purchase", EbookID: analyticsString("ebook-1")}},
+		{"missing ebook", &entity.AnalyticsEvent{Type: entity.AnalyticsEventView, EbookID: analyticsString("  ")}},
+		{"missing page", &entity.AnalyticsEvent{Type: entity.AnalyticsEventReadPage, EbookID: analyticsString("ebook-1")}},
+		{"missing position", &entity.AnalyticsEvent{Type: entity.AnalyticsEventAudioPlay, EbookID: analyticsString("ebook-1")}},
+		{"field of another type", &entity.AnalyticsEvent{Type: entity.AnalyticsEventSearch, Query: analyticsString("novel"), EbookID: analyticsString("ebook-1")}},
+		{"page below one", &entity.AnalyticsEvent{Type: entity.AnalyticsEventReadPage, EbookID: analyticsString("ebook-1"), PageNumber: analyticsInt(0)}},
+		{"negative position", &entity.AnalyticsEvent{Type: entity.AnalyticsEventAudioPlay, EbookID: analyticsString("ebook-1"), PositionSeconds: analyticsInt(-1)}},
+		{"long query", &entity.AnalyticsEvent{Type: entity.AnalyticsEventSearch, Query: analyticsString(strings.Repeat("q", 256))}},
+		{"long session", &entity.AnalyticsEvent{Type: entity.AnalyticsEventSearch, Query: analyticsString("novel"), SessionID: analyticsString(strings.Repeat("s", 65))}},
+		{"too old", &entity.AnalyticsEvent{Type: entity.AnalyticsEventView, EbookID: analyticsString("ebook-1"), OccurredAt: time.Now().AddDate(0, 0, -8)}},
+		{"in the future", &entity.AnalyticsEvent{Type: entity.AnalyticsEventView, EbookID: analyticsString("ebook-1"), OccurredAt: time.Now().Add(time.Hour)}},
+		{"properties not an object", &entity.AnalyticsEvent{Type: entity.AnalyticsEventView, EbookID: analyticsString("ebook-1"), Properties: json.RawMessage(`[1,2]`)}},
+		{"properties too large", &entity.AnalyticsEvent{Type: entity.AnalyticsEventView, EbookID: analyticsString("ebook-1"), Properties: json.RawMessage(`{"a":"` + strings.Repeat("x", 2048) + `"}`)}},
+	}
+
+	for _, tt := range tests {
+		t.Run("should reject "+tt.name, func(t *testing.T) {
+			analyticsService := &MockAnalyticsService{}
+			u := NewAnalyticsUsecase(analyticsService)
+
+			events := []*entity.AnalyticsEvent{
+				{Type: entity.AnalyticsEventView, EbookID: analyticsString("ebook-1")},
+				tt.event,
+			}
+			var validationErr *ValidationError
+			_, err := u.TrackEvents(ctx, client, events)
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("expected a validation error, got %v", err)
+			}
+			if !strings.HasPrefix(validationErr.Message, "events[1]: ") {
+				t.Errorf("expected the error to point at the event, got %q", validationErr.Message)
+			}
+			if len(analyticsService.buffered) != 0 {
+				t.Errorf("expected nothing buffered, got %d events", len(analyticsService.buffered))
+			}
+		})
+	}
+
+	t.Run("should reject an empty or oversized batch", func(t *testing.T) {
+		u := NewAnalyticsUsecase(&MockAnalyticsService{})
+
+		var validationErr *ValidationError
+		if _, err := u.TrackEvents(ctx, client, nil); !errors.As(err, &validationErr) {
+			t.Errorf("expected a validation error for an empty batch, got %v", err)
+		}
+
+		events := make([]*entity.AnalyticsEvent, 101)
+		for i := range events {
+			events[i] = &entity.AnalyticsEvent{Type: entity.AnalyticsEventView, EbookID: analyticsString("ebook-1")}
+		}
+		if _, err := u.TrackEvents(ctx, client, events); !errors.As(err, &validationErr) {
+			t.Errorf("expected a validation error for 101 events, got %v", err)
+		}
+	})
+
+	t.Run("should return buffer errors", func(t *testing.T) {
+		u := NewAnalyticsUsecase(&MockAnalyticsService{err: errors.New("database down")})
+
+		events := []*entity.AnalyticsEvent{{Type: entity.AnalyticsEventView, EbookID: analyticsString("ebook-1")}}
+		if accepted, err := u.TrackEvents(ctx, client, events); err == nil || accepted != 0 {
+			t.Errorf("expected the error to be returned, got %d accepted", accepted)
+		}
+	})
+
+	t.Run("should report a full buffer as busy", func(t *testing.T) {
+		u := NewAnalyticsUsecase(&MockAnalyticsService{err: repository.ErrBufferFull})
+
+		events := []*entity.AnalyticsEvent{{Type: entity.AnalyticsEventView, EbookID: analyticsString("ebook-1")}}
+		if _, err := u.TrackEvents(ctx, client, events); !errors.Is(err, ErrAnalyticsBusy) {
+			t.Errorf("expected ErrAnalyticsBusy, got %v", err)
+		}
+	})
+}
+
+func TestAnalyticsUsecase_Queries(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should default to the last 7 days", func(t *testing.T) {
+		analyticsService := &MockAnalyticsService{}
+		u := NewAnalyticsUsecase(analyticsService)
+
+		if _, err := u.GetTotals(ctx, &entity.AnalyticsFilter{}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := analyticsService.filter.To.Sub(analyticsService.filter.From); got != 7*24*time.Hour {
+			t.Errorf("expected a 7 day range, got %s", got)
+		}
+	})
+
+	t.Run("should reject invalid ranges and types", func(t *testing.T) {
+		u := NewAnalyticsUsecase(&MockAnalyticsService{})
+		now := time.Now()
+
+		filters := []*entity.AnalyticsFilter{
+			{From: now, To: now.Add(-time.Hour)},
+			{From: now.AddDate(-2, 0, 0), To: now},
+			{Type: "purchase"},
+		}
+		for _, filter := range filters {
+			var validationErr *ValidationError
+			if _, err := u.GetDailyCounts(ctx, filter); !errors.As(err, &validationErr) {
+				t.Errorf("expected a validation error for %+v, got %v", filter, err)
+			}
+		}
+	})
+
+	t.Run("should default the top list to ebooks", func(t *testing.T) {
+		analyticsService := &MockAnalyticsService{}
+		u := NewAnalyticsUsecase(analyticsService)
+
+		if _, err := u.GetTopItems(ctx, &entity.AnalyticsFilter{}, "", 0); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if analyticsService.dimension != entity.AnalyticsDimensionEbook || analyticsService.limit != 10 {
+			t.Errorf("unexpected dimension %s and limit %d", analyticsService.dimension, analyticsService.limit)
+		}
+	})
+
+	t.Run("should reject an unknown dimension", func(t *testing.T) {
+		u := NewAnalyticsUsecase(&MockAnalyticsService{})
+
+		var validationErr *ValidationError
+		if _, err := u.GetTopItems(ctx, &entity.AnalyticsFilter{}, "author", 10); !errors.As(err, &validationErr) {
+			t.Errorf("expected a validation error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,18 @@
+package usecase
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// AnnotationUsecase defines the interface for annotation use cases
+// All operations are scoped to the annotation owner
+type AnnotationUsecase interface {
+	CreateAnnotation(ctx context.Context, annotation *entity.Annotation) error
+	GetAnnotation(ctx context.Context, userID, id string) (*entity.Annotation, error)
+	UpdateAnnotation(ctx context.Context, annotation *entity.Annotation) (*entity.Annotation, error)
+	DeleteAnnotation(ctx context.Context, userID, id string) error
+	ListAnnotations(ctx context.Context, filter *entity.AnnotationFilter, limit, offset int) ([]*entity.Annotation, error)
+	CountAnnotations(ctx context.Context, filter *entity.AnnotationFilter) (int64, error)
+	ExportAnnotations(ctx context.Context, userID, ebookID string) (*entity.Ebook, []*entity.Annotation, error)
+}
@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/service"
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ErrAnnotationNotFound is returned when an annotation does not exist or belongs to another user
+var ErrAnnotationNotFound = errors.New(constant.ERR_ANNOTATION_NOT_FOUND)
+
+// defaultHighlightColor is used when a highlight is created without a color
+const defaultHighlightColor = "yellow"
+
+var (
+	annotationColors   = map[string]bool{"yellow": true, "green": true, "blue": true, "pink": true, "purple": true, "orange": true}
+	annotationHexColor = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+)
+
+type annotationUsecase struct {
+	ebookService      service.EbookService
+	annotationService service.AnnotationService
+}
+
+func NewAnnotationUsecase(ebookService service.EbookService, annotationService service.AnnotationService) AnnotationUsecase {
+	return &annotationUsecase{
+		ebookService:      ebookService,
+		annotationService: annotationService,
+	}
+}
+
+func (u *annotationUsecase) CreateAnnotation(ctx context.Context, annotation *entity.Annotation) error {
+	if annotation.EbookID == "" {
+		return &ValidationError{Message: constant.EBOOK_ID_REQUIRED_VALIDATION}
+	}
+	if !annotation.Type.IsValid() {
+		return &ValidationError{Message: constant.ERR_ANNOTATION_TYPE}
+	}
+	if annotation.Type == entity.AnnotationHighlight && annotation.Color == nil {
+		color := defaultHighlightColor
+		annotation.Color = &color
+	}
+	if err := validateAnnotation(annotation); err != nil {
+		return err
+	}
+
+	ebook, err := u.ebookService.GetEbookByID(ctx, annotation.EbookID)
+	if err != nil {
+		return err
+	}
+	if ebook == nil {
+		return &ValidationError{Message: constant.EBOOK_NOT_FOUND}
+	}
+
+	annotation.ID = uuid.New().String()
+	return u.annotationService.CreateAnnotation(ctx, annotation)
+}
+
+func (u *annotationUsecase) GetAnnotation(ctx context.Context, userID, id string) (*entity.Annotation, error) {
+	if id == "" {
+		return nil, &ValidationError{Message: constant.ERR_ID_REQUIRED}
+	}
+
+	return u.annotationService.GetAnnotationByID(ctx, userID, id)
+}
+
+// UpdateAnnotation changes the location, text, color and note; the ebook and type are fixed
+func (u *annotationUsecase) UpdateAnnotation(ctx context.Context, annotation *entity.Annotation) (*entity.Annotation, error) {
+	if annotation.ID == "" {
+		return nil, &ValidationError{Message: constant.ERR_ID_REQUIRED}
+	}
+
+	existing, err := u.annotationService.GetAnnotationByID(ctx, annotation.UserID, annotation.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, ErrAnnotationNotFound
+	}
+
+	annotation.EbookID = existing.EbookID
+	annotation.Type = existing.Type
+	annotation.CreatedAt = existing.CreatedAt
+	if err := validateAnnotation(annotation); err != nil {
+		return nil, err
+	}
+
+	if err := u.annotationService.UpdateAnnotation(ctx, annotation); err != nil {
+		return nil, err
+	}
+
+	return annotation, nil
+}
+
+func (u *annotationUsecase) DeleteAnnotation(ctx context.Context, userID, id string) error {
+	if id == "" {
+		return &ValidationError{Message: constant.ERR_ID_REQUIRED}
+	}
+
+	existing, err := u.annotationService.GetAnnotationByID(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return ErrAnnotationNotFound
+	}
+
+	return u.annotationService.DeleteAnnotation(ctx, userID, id)
+}
+
+func (u *annotationUsecase) ListAnnotations(ctx context.Context, filter *entity.AnnotationFilter, limit, offset int) ([]*entity.Annotation, error) {
+	// Validate pagination parameters
+	if limit <= 0 {
+		limit = 10 // default limit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return u.annotationService.GetAnnotationList(ctx, filter, limit, offset)
+}
+
+func (u *annotationUsecase) CountAnnotations(ctx context.Context, filter *entity.AnnotationFilter) (int64, error) {
+	return u.annotationService.GetAnnotationCount(ctx, filter)
+}
+
+// ExportAnnotations returns the ebook and the user's annotations in reading order, or a nil ebook if it does not exist
+func (u *annotationUsecase) ExportAnnotations(ctx context.Context, userID, ebookID string) (*entity.Ebook, []*entity.Annotation, error) {
+	if ebookID == "" {
+		return nil, nil, &ValidationError{Message: constant.EBOOK_ID_REQUIRED}
+	}
+
+	ebook, err := u.ebookService.GetEbookByID(ctx, ebookID)
+	if err != nil || ebook == nil {
+		return nil, nil, err
+	}
+
+	annotations, err := u.annotationService.GetAnnotationsByEbook(ctx, userID, ebookID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ebook, annotations, nil
+}
+
+func validateAnnotation(annotation *entity.Annotation) error {
+	annotation.CFIStart = trimOptional(annotation.CFIStart)
+	annotation.CFIEnd = trimOptional(annotation.CFIEnd)
+	annotation.SelectedText = trimOptional(annotation.SelectedText)
+	annotation.Note = trimOptional(annotation.Note)
+	annotation.Color = trimOptional(annotation.Color)
+
+	if annotation.Page == nil && annotation.CFIStart == nil {
+		return &ValidationError{Message: constant.ERR_ANNOTATION_LOCATION}
+	}
+	if annotation.Page != nil && *annotation.Page < 1 {
+		return &ValidationError{Message: constant.ERR_ANNOTATION_PAGE}
+	}
+	if (annotation.CFIStart != nil && len(*annotation.CFIStart) > maxCFILength) ||
+		(annotation.CFIEnd != nil && len(*annotation.CFIEnd) > maxCFILength) {
+		return &ValidationError{Message: constant.ERR_ANNOTATION_CFI_TOO_LONG}
+	}
+	if annotation.Type == entity.AnnotationHighlight && annotation.SelectedText == nil {
+		return &ValidationError{Message: constant.ERR_ANNOTATION_TEXT}
+	}
+	if annotation.Type == entity.AnnotationNote && annotation.Note == nil {
+		return &ValidationError{Message: constant.ERR_ANNOTATION_NOTE}
+	}
+	if annotation.Color != nil {
+		color := strings.ToLower(*annotation.Color)
+		if !annotationColors[color] && !annotationHexColor.MatchString(color) {
+			return &ValidationError{Message: constant.ERR_ANNOTATION_COLOR}
+		}
+		annotation.Color = &color
+	}
+
+	return nil
+}
+
+// trimOptional trims an optional string and treats blank values as absent
+func trimOptional(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+	"errors"
+	"testing"
+)
+
+// MockAnnotationService stores annotations in memory keyed by ID
+type MockAnnotationService struct {
+	annotations map[string]*entity.Annotation
+}
+
+func (m *MockAnnotationService) CreateAnnotation(ctx context.Context, annotation *entity.Annotation) error {
+	m.annotations[annotation.ID] = annotation
+	return nil
+}
+
+func (m *MockAnnotationService) GetAnnotationByID(ctx context.Context, userID, id string) (*entity.Annotation, error) {
+	annotation := m.annotations[id]
+	if annotation == nil || annotation.UserID != userID {
+		return nil, nil
+	}
+	return annotation, nil
+}
+
+func (m *MockAnnotationService) UpdateAnnotation(ctx context.Context, annotation *entity.Annotation) error {
+	m.annotations[annotation.ID] = annotation
+	return nil
+}
+
+func (m *MockAnnotationService) DeleteAnnotation(ctx context.Context, userID, id string) error {
+	delete(m.annotations, id)
+	return nil
+}
+
+func (m *MockAnnotationService) GetAnnotationList(ctx context.Context, filter *entity.AnnotationFilter, limit, offset int) ([]*entity.Annotation, error) {
+	return nil, nil
+}
+
+func (m *MockAnnotationService) GetAnnotationCount(ctx context.Context, filter *entity.AnnotationFilter) (int64, error) {
+	return int64(len(m.annotations)), nil
+}
+
+func (m *MockAnnotationService) GetAnnotationsByEbook(ctx context.Context, userID, ebookID string) ([]*entity.Annotation, error) {
+	return nil, nil
+}
+
+func stringPtr(value string) *string {
+	return &value
+}
+
+func intPtr(value int) *int {
+	return &value
+}
+
+func TestAnnotationUsecase_CreateAnnotation(t *testing.T) {
+	tests := []struct {
+		name       string
+		annotation *entity.Annotation
+		ebook      *entity.Ebook
+		wantErr    bool
+	}{
+		{
+			name:       "should create a bookmark on a page",
+			annotation: &entity.Annotation{UserID: "user-1", EbookID: "ebook-1", Type: entity.AnnotationBookmark, Page: intPtr(3)},
+			ebook:      &entity.Ebook{ID: "ebook-1"},
+		},
+		{
+			name: "should create a highlight on a CFI range",
+			annotation: &entity.Annotation{UserID: "user-1", EbookID: "ebook-1", Type: entity.AnnotationHighlight,
+				CFIStart: stringPtr("epubcfi(/6/4!/4/2/1:0)"), CFIEnd: stringPtr("epubcfi(/6/4!/4/2/1:24)"), SelectedText: stringPtr("Quoted text")},
+			ebook: &entity.Ebook{ID: "ebook-1"},
+		},
+		{
+			name:       "should reject an unknown type",
+			annotation: &entity.Annotation{UserID: "user-1", EbookID: "ebook-1", Type: "underline", Page: intPtr(3)},
+			ebook:      &entity.Ebook{ID: "ebook-1"},
+			wantErr:    true,
+		},
+		{
+			name:       "should reject an annotation without location",
+			annotation: &entity.Annotation{UserID: "user-1", EbookID: "ebook-1", Type: entity.AnnotationBookmark},
+			ebook:      &entity.Ebook{ID: "ebook-1"},
+			wantErr:    true,
+		},
+		{
+			name:       "should reject a highlight without text",
+			annotation: &entity.Annotation{UserID: "user-1", EbookID: "ebook-1", Type: entity.AnnotationHighlight, Page: intPtr(3)},
+			ebook:      &entity.Ebook{ID: "ebook-1"},
+			wantErr:    true,
+		},
+		{
+			name:       "should reject a note with a blank body",
+			annotation: &entity.Annotation{UserID: "user-1", EbookID: "ebook-1", Type: entity.AnnotationNote, Page: intPtr(3), Note: stringPtr("  ")},
+			ebook:      &entity.Ebook{ID: "ebook-1"},
+			wantErr:    true,
+		},
+		{
+			name: "should reject an invalid color",
+			annotation: &entity.Annotation{UserID: "user-1", EbookID: "ebook-1", Type: entity.AnnotationHighlight, Page: intPtr(3),
+				SelectedText: stringPtr("Quoted text"), Color: stringPtr("url(javascript:alert(1))")},
+			ebook:   &entity.Ebook{ID: "ebook-1"},
+			wantErr: true,
+		},
+		{
+			name:       "should reject a missing ebook",
+			annotation: &entity.Annotation{UserID: "user-1", EbookID: "missing", Type: entity.AnnotationBookmark, Page: intPtr(3)},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewAnnotationUsecase(&MockEbookService{ebook: tt.ebook}, &MockAnnotationService{annotations: map[string]*entity.Annotation{}})
+
+			err := u.CreateAnnotation(context.Background(), tt.annotation)
+
+			var validationErr *ValidationError
+			if tt.wantErr {
+				if !errors.As(err, &validationErr) {
+					t.Errorf("expected validation error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.annotation.ID == "" {
+				t.Error("expected annotation ID to be generated")
+			}
+			if tt.annotation.Type == entity.AnnotationHighlight && (tt.annotation.Color == nil || *tt.annotation.Color != defaultHighlightColor) {
+				t.Errorf("expected default highlight color, got %v", tt.annotation.Color)
+			}
+		})
+	}
+}
+
+func TestAnnotationUsecase_OwnerScope(t *testing.T) {
+	annotationService := &MockAnnotationService{annotations: map[string]*entity.Annotation{
+		"annotation-1": {ID: "annotation-1", UserID: "owner", EbookID: "ebook-1", Type: entity.AnnotationNote, Page: intPtr(2), Note: stringPtr("Mine")},
+	}}
+	u := NewAnnotationUsecase(&MockEbookService{}, annotationService)
+	ctx := context.Background()
+
+	update := &entity.Annotation{ID: "annotation-1", UserID: "intruder", Page: intPtr(2), Note: stringPtr("Not yours")}
+	if _, err := u.UpdateAnnotation(ctx, update); !errors.Is(err, ErrAnnotationNotFound) {
+		t.Errorf("expected another user's update to be not found, got %v", err)
+	}
+	if err := u.DeleteAnnotation(ctx, "intruder", "annotation-1"); !errors.Is(err, ErrAnnotationNotFound) {
+		t.Errorf("expected another user's delete to be not found, got %v", err)
+	}
+
+	// The owner can edit, but not move the annotation to another ebook or type
+	update = &entity.Annotation{ID: "annotation-1", UserID: "owner", EbookID: "ebook-2", Type: entity.AnnotationBookmark, Page: intPtr(5), Note: stringPtr("Edited")}
+	updated, err := u.UpdateAnnotation(ctx, update)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.EbookID != "ebook-1" || updated.Type != entity.AnnotationNote || *updated.Page != 5 {
+		t.Errorf("unexpected update result %+v", updated)
+	}
+}
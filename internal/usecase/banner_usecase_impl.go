@@ -98,6 +98,8 @@ func (uc *bannerUsecase) convertEntityToResponse(banner *entity.Banner) response
 		ID:    banner.ID,
 		Title: banner.Title,
 		Image: banner.ImageURL,
+
+		ImageVariants: response.ParseImageVariantsResponse(banner.ImageVariants),
 	}
 	
 	if banner.Link != nil {
@@ -0,0 +1,172 @@
+package usecase
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// catalogResolver matches catalog entries to existing ebooks, authors, categories and content
+// statuses, and keeps track of the authors and categories the import introduces
+type catalogResolver struct {
+	ebookIDs    map[string]string // By slug
+	authorIDs   map[string]string // By lowercased name, new authors included
+	categoryIDs map[string]string // By lowercased name or slug, new categories included
+	statusIDs   map[string]string // By name
+
+	newAuthors    []*entity.Author
+	newCategories []*entity.Category
+	unwritten     map[string]bool // Ids of new authors and categories not yet in a batch
+	created       map[string]bool // Ids of new authors and categories written
+}
+
+func (u *catalogImportUsecase) newCatalogResolver(ctx context.Context, rows []pendingCatalogRow) (*catalogResolver, error) {
+	var slugs, authorNames, categoryKeys []string
+	for _, p := range rows {
+		slugs = append(slugs, p.entry.Slug)
+		authorNames = append(authorNames, p.entry.AuthorName)
+		categoryKeys = append(categoryKeys, p.entry.Category)
+		if slug := catalogSlug(p.entry.Category); slug != "" {
+			categoryKeys = append(categoryKeys, slug)
+		}
+	}
+
+	r := &catalogResolver{unwritten: map[string]bool{}, created: map[string]bool{}}
+	var err error
+	if r.ebookIDs, err = u.importService.FindEbookIDsBySlug(ctx, slugs); err != nil {
+		return nil, err
+	}
+	if r.authorIDs, err = u.importService.FindAuthorIDsByName(ctx, authorNames); err != nil {
+		return nil, err
+	}
+	if r.categoryIDs, err = u.importService.FindCategoryIDs(ctx, categoryKeys); err != nil {
+		return nil, err
+	}
+	if r.statusIDs, err = u.importService.ContentStatusIDs(ctx); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// resolve fills in the ids the entry is written with, returning the problem if it cannot be written
+func (r *catalogResolver) resolve(entry *entity.CatalogEntry) string {
+	entry.EbookID, entry.Exists = r.ebookIDs[entry.Slug]
+	if !entry.Exists {
+		entry.EbookID = uuid.New().String()
+	}
+
+	status := entry.Status
+	if status == nil && !entry.Exists {
+		draft := entity.ContentStatusDraft
+		status = &draft
+	}
+	if status != nil {
+		id, ok := r.statusIDs[*status]
+		if !ok {
+			return fmt.Sprintf("content status %s does not exist", *status)
+		}
+		entry.ContentStatusID = &id
+	}
+
+	categoryKey := strings.ToLower(entry.Category)
+	slug := catalogSlug(entry.Category)
+	if id, ok := r.categoryIDs[categoryKey]; ok {
+		entry.CategoryID = id
+	} else if id, ok := r.categoryIDs[slug]; ok && slug != "" {
+		entry.CategoryID = id
+	} else if slug == "" {
+		return fmt.Sprintf("category %s has no letters or digits to make a slug from", entry.Category)
+	} else {
+		category := &entity.Category{ID: uuid.New().String(), Name: entry.Category, Slug: slug, IsActive: true}
+		r.newCategories = append(r.newCategories, category)
+		r.unwritten[category.ID] = true
+		r.categoryIDs[categoryKey] = category.ID
+		r.categoryIDs[slug] = category.ID
+		entry.CategoryID = category.ID
+	}
+
+	authorKey := strings.ToLower(entry.AuthorName)
+	if id, ok := r.authorIDs[authorKey]; ok {
+		entry.AuthorID = id
+	} else {
+		author := &entity.Author{ID: uuid.New().String(), Name: entry.AuthorName}
+		r.newAuthors = append(r.newAuthors, author)
+		r.unwritten[author.ID] = true
+		r.authorIDs[authorKey] = author.ID
+		entry.AuthorID = author.ID
+	}
+
+	return ""
+}
+
+// takeNewAuthor returns the author with the id if it is new and not yet in a batch
+func (r *catalogResolver) takeNewAuthor(id string) *entity.Author {
+	if !r.unwritten[id] {
+		return nil
+	}
+	for _, author := range r.newAuthors {
+		if author.ID == id {
+			delete(r.unwritten, id)
+			return author
+		}
+	}
+	return nil
+}
+
+// takeNewCategory returns the category with the id if it is new and not yet in a batch
+func (r *catalogResolver) takeNewCategory(id string) *entity.Category {
+	if !r.unwritten[id] {
+		return nil
+	}
+	for _, category := range r.newCategories {
+		if category.ID == id {
+			delete(r.unwritten, id)
+			return category
+		}
+	}
+	return nil
+}
+
+// returnNew puts back the authors and categories of a batch that was rolled back
+func (r *catalogResolver) returnNew(batch *entity.CatalogImportBatch) {
+	for _, author := range batch.Authors {
+		r.unwritten[author.ID] = true
+	}
+	for _, category := range batch.Categories {
+		r.unwritten[category.ID] = true
+	}
+}
+
+func (r *catalogResolver) markCreated(batch *entity.CatalogImportBatch) {
+	for _, author := range batch.Authors {
+		r.created[author.ID] = true
+	}
+	for _, category := range batch.Categories {
+		r.created[category.ID] = true
+	}
+}
+
+// createdAuthorNames lists the authors the import created, or in a dry run would create
+func (r *catalogResolver) createdAuthorNames(dryRun bool) []string {
+	names := []string{}
+	for _, author := range r.newAuthors {
+		if dryRun || r.created[author.ID] {
+			names = append(names, author.Name)
+		}
+	}
+	return names
+}
+
+// createdCategoryNames lists the categories the import created, or in a dry run would create
+func (r *catalogResolver) createdCategoryNames(dryRun bool) []string {
+	names := []string{}
+	for _, category := range r.newCategories {
+		if dryRun || r.created[category.ID] {
+			names = append(names, category.Name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,16 @@
+package usecase
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/pkg/catalog"
+	"context"
+	"io"
+)
+
+// CatalogImportUsecase defines the interface for bulk catalog import use cases
+type CatalogImportUsecase interface {
+	// ImportCatalog creates or updates the ebooks of a CSV or JSON catalog by slug, creating the
+	// authors and categories it names that do not exist yet. Entries with problems are reported
+	// and skipped; a dry run validates everything and reports what would happen without writing.
+	ImportCatalog(ctx context.Context, file io.Reader, format catalog.Format, dryRun bool) (*entity.CatalogImportReport, error)
+}
@@ -0,0 +1,306 @@
+package usecase
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/service"
+	"buku-pintar/pkg/catalog"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// maxCatalogImportEntries bounds how many ebooks one catalog may hold
+	maxCatalogImportEntries = 5000
+	// catalogImportBatchSize is how many ebooks are written per transaction
+	catalogImportBatchSize = 100
+	// maxCatalogTextLength matches the VARCHAR(255) columns the text is stored in
+	maxCatalogTextLength = 255
+)
+
+// catalogColumns are the columns a catalog may have
+var catalogColumns = map[string]bool{
+	"slug": true, "title": true, "author": true, "category": true, "price": true,
+	"synopsis": true, "language": true, "format": true, "page_count": true, "preview_page": true,
+	"duration": true, "filesize": true, "url": true, "cover_image": true, "status": true, "published_at": true,
+}
+
+var catalogSlugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+type catalogImportUsecase struct {
+	importService service.CatalogImportService
+}
+
+// NewCatalogImportUsecase creates a new instance of CatalogImportUsecase
+func NewCatalogImportUsecase(importService service.CatalogImportService) CatalogImportUsecase {
+	return &catalogImportUsecase{
+		importService: importService,
+	}
+}
+
+// pendingCatalogRow is an entry that passed validation, with the row it is reported in
+type pendingCatalogRow struct {
+	entry *entity.CatalogEntry
+	row   *entity.CatalogImportRow
+}
+
+func (u *catalogImportUsecase) ImportCatalog(ctx context.Context, file io.Reader, format catalog.Format, dryRun bool) (*entity.CatalogImportReport, error) {
+	if format != catalog.FormatCSV && format != catalog.FormatJSON {
+		return nil, &ValidationError{Message: constant.ERR_CATALOG_FORMAT}
+	}
+	records, err := catalog.Read(file, format)
+	if err != nil {
+		return nil, &ValidationError{Message: constant.ERR_CATALOG_UNREADABLE + ": " + err.Error()}
+	}
+	if len(records) == 0 {
+		return nil, &ValidationError{Message: constant.ERR_CATALOG_EMPTY}
+	}
+	if len(records) > maxCatalogImportEntries {
+		return nil, &ValidationError{Message: fmt.Sprintf("%s, the maximum is %d", constant.ERR_CATALOG_TOO_LARGE, maxCatalogImportEntries)}
+	}
+
+	report := &entity.CatalogImportReport{
+		DryRun:            dryRun,
+		Total:             len(records),
+		AuthorsCreated:    []string{},
+		CategoriesCreated: []string{},
+		Rows:              make([]*entity.CatalogImportRow, 0, len(records)),
+	}
+
+	var pending []pendingCatalogRow
+	seenSlugs := map[string]int{}
+	for _, record := range records {
+		entry, problems := parseCatalogRecord(record)
+		row := &entity.CatalogImportRow{Line: record.Line, Slug: entry.Slug}
+		report.Rows = append(report.Rows, row)
+
+		if line, ok := seenSlugs[entry.Slug]; ok && entry.Slug != "" {
+			problems = append(problems, fmt.Sprintf("slug is already used on line %d", line))
+		} else if entry.Slug != "" {
+			seenSlugs[entry.Slug] = record.Line
+		}
+		if len(problems) > 0 {
+			row.Action = entity.CatalogImportFailed
+			row.Errors = problems
+			continue
+		}
+		pending = append(pending, pendingCatalogRow{entry: entry, row: row})
+	}
+
+	resolver, err := u.newCatalogResolver(ctx, pending)
+	if err != nil {
+		return nil, err
+	}
+	var valid []pendingCatalogRow
+	for _, p := range pending {
+		if problem := resolver.resolve(p.entry); problem != "" {
+			p.row.Action = entity.CatalogImportFailed
+			p.row.Errors = []string{problem}
+			continue
+		}
+		p.row.EbookID = p.entry.EbookID
+		p.row.Action = entity.CatalogImportCreated
+		if p.entry.Exists {
+			p.row.Action = entity.CatalogImportUpdated
+		}
+		valid = append(valid, p)
+	}
+
+	if !dryRun {
+		u.writeBatches(ctx, resolver, valid)
+	}
+
+	for _, row := range report.Rows {
+		switch row.Action {
+		case entity.CatalogImportCreated:
+			report.Created++
+		case entity.CatalogImportUpdated:
+			report.Updated++
+		default:
+			report.Failed++
+		}
+	}
+	report.AuthorsCreated = resolver.createdAuthorNames(dryRun)
+	report.CategoriesCreated = resolver.createdCategoryNames(dryRun)
+	return report, nil
+}
+
+// writeBatches writes the entries a batch at a time. A batch that fails is rolled back as a whole
+// and its entries are reported as failed; the authors and categories it introduced are retried
+// with the next batch that needs them.
+func (u *catalogImportUsecase) writeBatches(ctx context.Context, resolver *catalogResolver, rows []pendingCatalogRow) {
+	written := false
+	for start := 0; start < len(rows); start += catalogImportBatchSize {
+		chunk := rows[start:min(start+catalogImportBatchSize, len(rows))]
+		batch := &entity.CatalogImportBatch{}
+		for _, p := range chunk {
+			if author := resolver.takeNewAuthor(p.entry.AuthorID); author != nil {
+				batch.Authors = append(batch.Authors, author)
+			}
+			if category := resolver.takeNewCategory(p.entry.CategoryID); category != nil {
+				batch.Categories = append(batch.Categories, category)
+			}
+			batch.Entries = append(batch.Entries, p.entry)
+		}
+
+		if err := u.importService.ImportBatch(ctx, batch); err != nil {
+			resolver.returnNew(batch)
+			for _, p := range chunk {
+				p.row.Action = entity.CatalogImportFailed
+				p.row.EbookID = ""
+				p.row.Errors = []string{"could not be saved: " + err.Error()}
+			}
+			continue
+		}
+		resolver.markCreated(batch)
+		written = true
+	}
+
+	if written {
+		u.importService.InvalidateCatalogCache(ctx)
+	}
+}
+
+// parseCatalogRecord converts a record to an entry, listing every problem with it
+func parseCatalogRecord(record catalog.Record) (*entity.CatalogEntry, []string) {
+	var problems []string
+	entry := &entity.CatalogEntry{
+		Line:       record.Line,
+		Slug:       strings.ToLower(record.Get("slug")),
+		Title:      record.Get("title"),
+		AuthorName: record.Get("author"),
+		Category:   record.Get("category"),
+	}
+
+	for column := range record.Values {
+		if !catalogColumns[column] {
+			problems = append(problems, fmt.Sprintf("unknown column %s", column))
+		}
+	}
+
+	required := func(column, value string) {
+		if value == "" {
+			problems = append(problems, column+" is required")
+		} else if utf8.RuneCountInString(value) > maxCatalogTextLength {
+			problems = append(problems, fmt.Sprintf("%s must be at most %d characters", column, maxCatalogTextLength))
+		}
+	}
+	required("slug", entry.Slug)
+	if entry.Slug != "" && !catalogSlugPattern.MatchString(entry.Slug) {
+		problems = append(problems, "slug must be lowercase letters and digits separated by single hyphens")
+	}
+	required("title", entry.Title)
+	required("author", entry.AuthorName)
+	required("category", entry.Category)
+
+	optionalText := func(column string, limit int) *string {
+		value, ok := record.Values[column]
+		if !ok {
+			return nil
+		}
+		if limit > 0 && utf8.RuneCountInString(value) > limit {
+			problems = append(problems, fmt.Sprintf("%s must be at most %d characters", column, limit))
+		}
+		return &value
+	}
+	optionalInt := func(column string, max int64) *int64 {
+		value, ok := record.Values[column]
+		if !ok {
+			return nil
+		}
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || n < 0 || n > max {
+			problems = append(problems, fmt.Sprintf("%s must be a whole number from 0 to %d", column, max))
+			return nil
+		}
+		return &n
+	}
+
+	if price := optionalInt("price", math.MaxInt32); price != nil {
+		entry.Price = int(*price)
+	} else if _, ok := record.Values["price"]; !ok {
+		problems = append(problems, "price is required")
+	}
+	entry.Synopsis = optionalText("synopsis", 0)
+	entry.Language = optionalText("language", maxCatalogTextLength)
+	entry.URL = optionalText("url", maxCatalogTextLength)
+	entry.CoverImage = optionalText("cover_image", maxCatalogTextLength)
+
+	if format, ok := record.Values["format"]; ok {
+		f := entity.EbookFormat(strings.ToLower(format))
+		switch f {
+		case entity.FormatPDF, entity.FormatEPUB, entity.FormatMOBI:
+			entry.Format = &f
+		default:
+			problems = append(problems, "format must be one of pdf, epub or mobi")
+		}
+	}
+	if n := optionalInt("page_count", math.MaxInt16); n != nil {
+		pageCount := int16(*n)
+		entry.PageCount = &pageCount
+	}
+	if n := optionalInt("preview_page", math.MaxInt16); n != nil {
+		previewPage := int16(*n)
+		entry.PreviewPage = &previewPage
+	}
+	if entry.PageCount != nil && entry.PreviewPage != nil && *entry.PreviewPage > *entry.PageCount {
+		problems = append(problems, "preview_page must not be greater than page_count")
+	}
+	if n := optionalInt("duration", math.MaxInt32); n != nil {
+		duration := int(*n)
+		entry.Duration = &duration
+	}
+	entry.Filesize = optionalInt("filesize", math.MaxInt64)
+
+	if status, ok := record.Values["status"]; ok {
+		status = strings.ToLower(status)
+		switch status {
+		case entity.ContentStatusDraft, entity.ContentStatusPublished, entity.ContentStatusArchived:
+			entry.Status = &status
+		default:
+			problems = append(problems, "status must be one of draft, published or archived")
+		}
+	}
+	if value, ok := record.Values["published_at"]; ok {
+		publishedAt, err := parseCatalogTime(value)
+		if err != nil {
+			problems = append(problems, "published_at must be a date (YYYY-MM-DD) or an RFC 3339 time")
+		} else {
+			entry.PublishedAt = &publishedAt
+		}
+	}
+
+	return entry, problems
+}
+
+func parseCatalogTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+	return time.Parse(time.DateOnly, value)
+}
+
+// catalogSlug turns a category name into a slug, keeping ASCII letters and digits
+func catalogSlug(name string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if hyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			hyphen = false
+		} else {
+			hyphen = true
+		}
+	}
+	return b.String()
+}
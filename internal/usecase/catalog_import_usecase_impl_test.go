@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/pkg/catalog"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// MockCatalogImportService knows one ebook, author and category and records the batches written
+type MockCatalogImportService struct {
+	batches     []*entity.CatalogImportBatch
+	failBatches int // Number of batches to fail before succeeding
+	invalidated bool
+}
+
+func (m *MockCatalogImportService) FindEbookIDsBySlug(ctx context.Context, slugs []string) (map[string]string, error) {
+	return map[string]string{"laskar-pelangi": "ebook-1"}, nil
+}
+
+func (m *MockCatalogImportService) FindAuthorIDsByName(ctx context.Context, names []string) (map[string]string, error) {
+	return map[string]string{"andrea hirata": "author-1"}, nil
+}
+
+func (m *MockCatalogImportService) FindCategoryIDs(ctx context.Context, keys []string) (map[string]string, error) {
+	return map[string]string{"novel": "category-1", "fiksi": "category-1"}, nil
+}
+
+func (m *MockCatalogImportService) ContentStatusIDs(ctx context.Context) (map[string]string, error) {
+	return map[string]string{"draft": "status-draft", "published": "status-published"}, nil
+}
+
+func (m *MockCatalogImportService) ImportBatch(ctx context.Context, batch *entity.CatalogImportBatch) error {
+	if m.failBatches > 0 {
+		m.failBatches--
+		return errors.New("deadlock")
+	}
+	m.batches = append(m.batches, batch)
+	return nil
+}
+
+func (m *MockCatalogImportService) InvalidateCatalogCache(ctx context.Context) {
+	m.invalidated = true
+}
+
+const testCatalog = `slug,title,author,category,price,format,status
+laskar-pelangi,Laskar Pelangi,Andrea Hirata,Fiksi,60000,,published
+sang-pemimpi,Sang Pemimpi,ANDREA HIRATA,Novel,55000,epub,
+bumi,Bumi,Tere Liye,Fantasi Remaja,45000,,
+bad-row,,Someone,Novel,free,doc,
+bumi,Bumi Lagi,Tere Liye,Fantasi Remaja,45000,,
+`
+
+func TestCatalogImportUsecase_ImportCatalog(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should create, update and report invalid entries", func(t *testing.T) {
+		importService := &MockCatalogImportService{}
+		u := NewCatalogImportUsecase(importService)
+
+		report, err := u.ImportCatalog(ctx, strings.NewReader(testCatalog), catalog.FormatCSV, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if report.Total != 5 || report.Created != 2 || report.Updated != 1 || report.Failed != 2 {
+			t.Fatalf("unexpected report %+v", report)
+		}
+
+		rows := report.Rows
+		if rows[0].Action != entity.CatalogImportUpdated || rows[0].EbookID != "ebook-1" {
+			t.Errorf("expected the existing ebook to be updated, got %+v", rows[0])
+		}
+		if rows[3].Action != entity.CatalogImportFailed || len(rows[3].Errors) != 3 {
+			t.Errorf("expected title, price and format problems, got %+v", rows[3])
+		}
+		if rows[4].Action != entity.CatalogImportFailed || !strings.Contains(rows[4].Errors[0], "line 4") {
+			t.Errorf("expected a duplicate slug problem, got %+v", rows[4])
+		}
+
+		if len(importService.batches) != 1 || !importService.invalidated {
+			t.Fatalf("expected one batch and the cache cleared, got %d batches", len(importService.batches))
+		}
+		batch := importService.batches[0]
+		if len(batch.Authors) != 1 || batch.Authors[0].Name != "Tere Liye" {
+			t.Errorf("expected only Tere Liye to be created, got %+v", batch.Authors)
+		}
+		if len(batch.Categories) != 1 || batch.Categories[0].Slug != "fantasi-remaja" {
+			t.Errorf("expected the Fantasi Remaja category to be created, got %+v", batch.Categories)
+		}
+		for _, entry := range batch.Entries {
+			if entry.Slug == "sang-pemimpi" && (entry.AuthorID != "author-1" || *entry.ContentStatusID != "status-draft") {
+				t.Errorf("expected the existing author and a draft status, got %+v", entry)
+			}
+		}
+		if report.AuthorsCreated[0] != "Tere Liye" || report.CategoriesCreated[0] != "Fantasi Remaja" {
+			t.Errorf("unexpected created names %v %v", report.AuthorsCreated, report.CategoriesCreated)
+		}
+	})
+
+	t.Run("should not write anything in a dry run", func(t *testing.T) {
+		importService := &MockCatalogImportService{}
+		u := NewCatalogImportUsecase(importService)
+
+		report, err := u.ImportCatalog(ctx, strings.NewReader(testCatalog), catalog.FormatCSV, true)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !report.DryRun || report.Created != 2 || report.Updated != 1 || len(report.AuthorsCreated) != 1 {
+			t.Errorf("unexpected report %+v", report)
+		}
+		if len(importService.batches) != 0 || importService.invalidated {
+			t.Error("expected nothing to be written")
+		}
+	})
+
+	t.Run("should retry new authors after a failed batch", func(t *testing.T) {
+		var b strings.Builder
+		b.WriteString("slug,title,author,category,price\n")
+		for i := 0; i < catalogImportBatchSize+1; i++ {
+			fmt.Fprintf(&b, "book-%d,Book %d,New Author,Novel,1000\n", i, i)
+		}
+		importService := &MockCatalogImportService{failBatches: 1}
+		u := NewCatalogImportUsecase(importService)
+
+		report, err := u.ImportCatalog(ctx, strings.NewReader(b.String()), catalog.FormatCSV, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if report.Failed != catalogImportBatchSize || report.Created != 1 {
+			t.Errorf("expected the first batch to fail, got %+v", report)
+		}
+		if len(importService.batches) != 1 || len(importService.batches[0].Authors) != 1 {
+			t.Error("expected the author to be created with the second batch")
+		}
+	})
+
+	t.Run("should reject unreadable catalogs", func(t *testing.T) {
+		u := NewCatalogImportUsecase(&MockCatalogImportService{})
+		var validationErr *ValidationError
+		if _, err := u.ImportCatalog(ctx, strings.NewReader("slug\n"), catalog.FormatCSV, false); !errors.As(err, &validationErr) {
+			t.Errorf("expected validation error for an empty catalog, got %v", err)
+		}
+		if _, err := u.ImportCatalog(ctx, strings.NewReader("{}"), catalog.FormatJSON, false); !errors.As(err, &validationErr) {
+			t.Errorf("expected validation error for malformed JSON, got %v", err)
+		}
+		if _, err := u.ImportCatalog(ctx, strings.NewReader(""), "xlsx", false); !errors.As(err, &validationErr) {
+			t.Errorf("expected validation error for an unknown format, got %v", err)
+		}
+	})
+}
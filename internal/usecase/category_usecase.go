@@ -8,6 +8,7 @@ import (
 
 type CategoryUsecase interface {
 	ListCategory(ctx context.Context, limit, offset int) ([]*response.CategoryResponse, error)
+	ListCategoryByCursor(ctx context.Context, cursor *entity.PageCursor, limit int) ([]*response.CategoryResponse, *entity.CursorPageInfo, error)
 	GetCategoryByID(ctx context.Context, id string) (*response.CategoryResponse, error)
 	CreateCategory(ctx context.Context, category *entity.Category) error
 	UpdateCategory(ctx context.Context, category *entity.Category) error
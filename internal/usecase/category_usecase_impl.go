@@ -36,6 +36,26 @@ func (uc *categoryUsecase) ListCategory(ctx context.Context, limit, offset int)
 	return response, nil
 }
 
+func (uc *categoryUsecase) ListCategoryByCursor(ctx context.Context, cursor *entity.PageCursor, limit int) ([]*response.CategoryResponse, *entity.CursorPageInfo, error) {
+	if err := checkCursor(cursor, entity.CursorSortCategories, ""); err != nil {
+		return nil, nil, err
+	}
+
+	categories, page, err := uc.categoryService.GetActiveCategoryListByCursor(ctx, cursor, limit)
+	if err != nil {
+		return nil, nil, cursorValueError(err)
+	}
+
+	// Convert entities to response DTOs
+	responses := []*response.CategoryResponse{}
+	for _, category := range categories {
+		categoryResponse := uc.convertEntityToResponse(category)
+		responses = append(responses, &categoryResponse)
+	}
+
+	return responses, page, nil
+}
+
 func (uc *categoryUsecase) GetCategoryByID(ctx context.Context, id string) (*response.CategoryResponse, error) {
 	// Get category from service layer
 	category, err := uc.categoryService.GetCategoryByID(ctx, id)
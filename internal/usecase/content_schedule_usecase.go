@@ -0,0 +1,18 @@
+package usecase
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// ContentScheduleUsecase defines the interface for scheduled publishing use cases.
+// Editors need the update permission of the content they schedule.
+type ContentScheduleUsecase interface {
+	// ScheduleContent schedules the content to be published or unpublished at RunAt
+	ScheduleContent(ctx context.Context, userID string, schedule *entity.ContentSchedule) error
+	ListSchedules(ctx context.Context, userID string, contentType entity.SchedulableContent, contentID string) ([]*entity.ContentSchedule, error)
+	// CancelSchedule removes a schedule that has not been applied yet
+	CancelSchedule(ctx context.Context, userID, id string) error
+	// ApplyDueSchedules carries out the schedules that are due and returns how many were applied
+	ApplyDueSchedules(ctx context.Context) (int, error)
+}
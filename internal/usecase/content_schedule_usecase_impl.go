@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/service"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrScheduleNotFound is returned for unknown schedules
+	ErrScheduleNotFound = errors.New(constant.ERR_SCHEDULE_NOT_FOUND)
+	// ErrScheduledContentNotFound is returned when the content to schedule does not exist
+	ErrScheduledContentNotFound = errors.New(constant.ERR_SCHEDULE_CONTENT_MISSING)
+	// ErrScheduleApplied is returned when cancelling a schedule that has already run
+	ErrScheduleApplied = errors.New(constant.ERR_SCHEDULE_APPLIED)
+	// ErrScheduleForbidden is returned when the user may not update the scheduled content
+	ErrScheduleForbidden = errors.New(constant.ERR_SCHEDULE_FORBIDDEN)
+)
+
+type contentScheduleUsecase struct {
+	scheduleService   service.ContentScheduleService
+	permissionService service.PermissionService
+	now               func() time.Time
+}
+
+// NewContentScheduleUsecase creates a new instance of ContentScheduleUsecase
+func NewContentScheduleUsecase(
+	scheduleService service.ContentScheduleService,
+	permissionService service.PermissionService,
+) ContentScheduleUsecase {
+	return &contentScheduleUsecase{
+		scheduleService:   scheduleService,
+		permissionService: permissionService,
+		now:               time.Now,
+	}
+}
+
+func (u *contentScheduleUsecase) ScheduleContent(ctx context.Context, userID string, schedule *entity.ContentSchedule) error {
+	if !schedule.ContentType.IsValid() {
+		return &ValidationError{Message: constant.ERR_SCHEDULE_CONTENT_TYPE}
+	}
+	if schedule.ContentID == "" {
+		return &ValidationError{Message: constant.ERR_SCHEDULE_CONTENT_ID}
+	}
+	if !schedule.Action.IsValid() {
+		return &ValidationError{Message: constant.ERR_SCHEDULE_ACTION}
+	}
+	if !schedule.RunAt.After(u.now()) {
+		return &ValidationError{Message: constant.ERR_SCHEDULE_RUN_AT}
+	}
+
+	if err := u.checkPermission(ctx, userID, schedule.ContentType); err != nil {
+		return err
+	}
+
+	exists, err := u.scheduleService.ContentExists(ctx, schedule.ContentType, schedule.ContentID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrScheduledContentNotFound
+	}
+
+	schedule.ID = uuid.New().String()
+	schedule.CreatedBy = &userID
+	schedule.AppliedAt = nil
+	// MySQL TIMESTAMP columns have second precision
+	schedule.RunAt = schedule.RunAt.UTC().Truncate(time.Second)
+	return u.scheduleService.CreateSchedule(ctx, schedule)
+}
+
+func (u *contentScheduleUsecase) ListSchedules(ctx context.Context, userID string, contentType entity.SchedulableContent, contentID string) ([]*entity.ContentSchedule, error) {
+	if !contentType.IsValid() {
+		return nil, &ValidationError{Message: constant.ERR_SCHEDULE_CONTENT_TYPE}
+	}
+	if contentID == "" {
+		return nil, &ValidationError{Message: constant.ERR_SCHEDULE_CONTENT_ID}
+	}
+	if err := u.checkPermission(ctx, userID, contentType); err != nil {
+		return nil, err
+	}
+
+	return u.scheduleService.ListSchedules(ctx, contentType, contentID)
+}
+
+func (u *contentScheduleUsecase) CancelSchedule(ctx context.Context, userID, id string) error {
+	if id == "" {
+		return &ValidationError{Message: constant.ERR_ID_REQUIRED}
+	}
+
+	schedule, err := u.scheduleService.GetSchedule(ctx, id)
+	if err != nil {
+		return err
+	}
+	if schedule == nil {
+		return ErrScheduleNotFound
+	}
+	if err := u.checkPermission(ctx, userID, schedule.ContentType); err != nil {
+		return err
+	}
+	if !schedule.IsPending() {
+		return ErrScheduleApplied
+	}
+
+	return u.scheduleService.DeleteSchedule(ctx, id)
+}
+
+func (u *contentScheduleUsecase) ApplyDueSchedules(ctx context.Context) (int, error) {
+	return u.scheduleService.ApplyDueSchedules(ctx, u.now())
+}
+
+func (u *contentScheduleUsecase) checkPermission(ctx context.Context, userID string, contentType entity.SchedulableContent) error {
+	allowed, err := u.permissionService.HasPermission(ctx, userID, contentType.UpdatePermission())
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrScheduleForbidden
+	}
+	return nil
+}
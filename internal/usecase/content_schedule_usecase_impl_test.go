@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// MockContentScheduleService keeps schedules in memory; only ids in content exist
+type MockContentScheduleService struct {
+	schedules map[string]*entity.ContentSchedule
+	content   map[string]bool
+}
+
+func NewMockContentScheduleService(contentIDs ...string) *MockContentScheduleService {
+	m := &MockContentScheduleService{schedules: map[string]*entity.ContentSchedule{}, content: map[string]bool{}}
+	for _, id := range contentIDs {
+		m.content[id] = true
+	}
+	return m
+}
+
+func (m *MockContentScheduleService) CreateSchedule(ctx context.Context, schedule *entity.ContentSchedule) error {
+	m.schedules[schedule.ID] = schedule
+	return nil
+}
+
+func (m *MockContentScheduleService) GetSchedule(ctx context.Context, id string) (*entity.ContentSchedule, error) {
+	return m.schedules[id], nil
+}
+
+func (m *MockContentScheduleService) ListSchedules(ctx context.Context, contentType entity.SchedulableContent, contentID string) ([]*entity.ContentSchedule, error) {
+	var schedules []*entity.ContentSchedule
+	for _, schedule := range m.schedules {
+		if schedule.ContentType == contentType && schedule.ContentID == contentID {
+			schedules = append(schedules, schedule)
+		}
+	}
+	return schedules, nil
+}
+
+func (m *MockContentScheduleService) DeleteSchedule(ctx context.Context, id string) error {
+	delete(m.schedules, id)
+	return nil
+}
+
+func (m *MockContentScheduleService) ContentExists(ctx context.Context, contentType entity.SchedulableContent, contentID string) (bool, error) {
+	return m.content[contentID], nil
+}
+
+func (m *MockContentScheduleService) ApplyDueSchedules(ctx context.Context, now time.Time) (int, error) {
+	applied := 0
+	for _, schedule := range m.schedules {
+		if schedule.IsPending() && !schedule.RunAt.After(now) {
+			schedule.AppliedAt = &now
+			applied++
+		}
+	}
+	return applied, nil
+}
+
+func TestContentScheduleUsecase(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	scheduleService := NewMockContentScheduleService("ebook-1", "article-1")
+	permissions := &stubPermissionService{granted: map[string]bool{entity.PermissionEbookUpdate: true}}
+	u := NewContentScheduleUsecase(scheduleService, permissions).(*contentScheduleUsecase)
+	u.now = func() time.Time { return now }
+
+	schedule := &entity.ContentSchedule{
+		ContentType: entity.SchedulableEbook,
+		ContentID:   "ebook-1",
+		Action:      entity.SchedulePublish,
+		RunAt:       now.Add(time.Hour + 500*time.Millisecond),
+	}
+	if err := u.ScheduleContent(ctx, "editor-1", schedule); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if schedule.ID == "" || *schedule.CreatedBy != "editor-1" || !schedule.RunAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("unexpected schedule %+v", schedule)
+	}
+
+	t.Run("should validate the schedule", func(t *testing.T) {
+		cases := []*entity.ContentSchedule{
+			{ContentType: "podcast", ContentID: "ebook-1", Action: entity.SchedulePublish, RunAt: now.Add(time.Hour)},
+			{ContentType: entity.SchedulableEbook, Action: entity.SchedulePublish, RunAt: now.Add(time.Hour)},
+			{ContentType: entity.SchedulableEbook, ContentID: "ebook-1", Action: "delete", RunAt: now.Add(time.Hour)},
+			{ContentType: entity.SchedulableEbook, ContentID: "ebook-1", Action: entity.SchedulePublish, RunAt: now},
+		}
+		for _, c := range cases {
+			var validationErr *ValidationError
+			if err := u.ScheduleContent(ctx, "editor-1", c); !errors.As(err, &validationErr) {
+				t.Errorf("expected validation error for %+v, got %v", c, err)
+			}
+		}
+
+		missing := &entity.ContentSchedule{ContentType: entity.SchedulableEbook, ContentID: "ebook-2", Action: entity.SchedulePublish, RunAt: now.Add(time.Hour)}
+		if err := u.ScheduleContent(ctx, "editor-1", missing); !errors.Is(err, ErrScheduledContentNotFound) {
+			t.Errorf("expected ErrScheduledContentNotFound, got %v", err)
+		}
+	})
+
+	t.Run("should require the update permission of the content type", func(t *testing.T) {
+		article := &entity.ContentSchedule{ContentType: entity.SchedulableArticle, ContentID: "article-1", Action: entity.SchedulePublish, RunAt: now.Add(time.Hour)}
+		if err := u.ScheduleContent(ctx, "editor-1", article); !errors.Is(err, ErrScheduleForbidden) {
+			t.Errorf("expected ErrScheduleForbidden, got %v", err)
+		}
+		if _, err := u.ListSchedules(ctx, "editor-1", entity.SchedulableArticle, "article-1"); !errors.Is(err, ErrScheduleForbidden) {
+			t.Errorf("expected ErrScheduleForbidden, got %v", err)
+		}
+	})
+
+	t.Run("should only cancel pending schedules", func(t *testing.T) {
+		u.now = func() time.Time { return now.Add(2 * time.Hour) }
+		if applied, _ := u.ApplyDueSchedules(ctx); applied != 1 {
+			t.Fatalf("expected the schedule to be applied, got %d", applied)
+		}
+		if err := u.CancelSchedule(ctx, "editor-1", schedule.ID); !errors.Is(err, ErrScheduleApplied) {
+			t.Errorf("expected ErrScheduleApplied, got %v", err)
+		}
+		if err := u.CancelSchedule(ctx, "editor-1", "unknown"); !errors.Is(err, ErrScheduleNotFound) {
+			t.Errorf("expected ErrScheduleNotFound, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"errors"
+)
+
+var (
+	// ErrCursorMismatch is returned for a cursor issued for another list, sort order or filter
+	ErrCursorMismatch = &ValidationError{Message: constant.ERR_CURSOR_MISMATCH}
+	// ErrCursorValueInvalid is returned when the position of a signed cursor cannot be bound to the sort column
+	ErrCursorValueInvalid = &ValidationError{Message: constant.ERR_CURSOR_VALUE_INVALID}
+)
+
+// IsCursorError reports whether err rejects the cursor rather than the rest of the request
+func IsCursorError(err error) bool {
+	return errors.Is(err, ErrCursorMismatch) || errors.Is(err, ErrCursorValueInvalid)
+}
+
+// checkCursor rejects a cursor that was not issued for the given sort key and filter hash
+func checkCursor(cursor *entity.PageCursor, sort, filterHash string) error {
+	if cursor != nil && !cursor.Matches(sort, filterHash) {
+		return ErrCursorMismatch
+	}
+	return nil
+}
+
+// cursorValueError turns a cursor value the repository could not bind into a validation error
+func cursorValueError(err error) error {
+	if errors.Is(err, repository.ErrInvalidCursorValue) {
+		return ErrCursorValueInvalid
+	}
+	return err
+}
@@ -0,0 +1,15 @@
+package usecase
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// EbookContributorUsecase defines the interface for the contributors of ebooks
+type EbookContributorUsecase interface {
+	// GetContributors returns the ebook's contributors in display order
+	GetContributors(ctx context.Context, ebookID string) ([]*entity.EbookContributor, error)
+	// ReplaceContributors swaps all contributors of the ebook, displayed in the given order.
+	// The first author becomes the ebook's primary author.
+	ReplaceContributors(ctx context.Context, ebookID string, contributors []*entity.EbookContributor) ([]*entity.EbookContributor, error)
+}
@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/service"
+	"context"
+	"strings"
+)
+
+const maxEbookContributors = 50
+
+type ebookContributorUsecase struct {
+	ebookService       service.EbookService
+	authorService      service.AuthorService
+	contributorService service.EbookContributorService
+}
+
+func NewEbookContributorUsecase(ebookService service.EbookService, authorService service.AuthorService, contributorService service.EbookContributorService) EbookContributorUsecase {
+	return &ebookContributorUsecase{
+		ebookService:       ebookService,
+		authorService:      authorService,
+		contributorService: contributorService,
+	}
+}
+
+func (u *ebookContributorUsecase) GetContributors(ctx context.Context, ebookID string) ([]*entity.EbookContributor, error) {
+	if err := u.requireEbook(ctx, ebookID); err != nil {
+		return nil, err
+	}
+
+	return u.contributorService.GetContributors(ctx, ebookID)
+}
+
+func (u *ebookContributorUsecase) ReplaceContributors(ctx context.Context, ebookID string, contributors []*entity.EbookContributor) ([]*entity.EbookContributor, error) {
+	if err := u.requireEbook(ctx, ebookID); err != nil {
+		return nil, err
+	}
+	if len(contributors) > maxEbookContributors {
+		return nil, &ValidationError{Message: constant.ERR_CONTRIBUTOR_TOO_MANY}
+	}
+
+	seen := make(map[string]bool, len(contributors))
+	hasAuthor := false
+	for i, contributor := range contributors {
+		contributor.AuthorID = strings.TrimSpace(contributor.AuthorID)
+		if contributor.AuthorID == "" {
+			return nil, &ValidationError{Message: constant.ERR_CONTRIBUTOR_AUTHOR_ID}
+		}
+		if !contributor.Role.IsValid() {
+			return nil, &ValidationError{Message: constant.ERR_CONTRIBUTOR_ROLE_INVALID}
+		}
+		key := contributor.AuthorID + " " + string(contributor.Role)
+		if seen[key] {
+			return nil, &ValidationError{Message: constant.ERR_CONTRIBUTOR_DUPLICATE}
+		}
+		seen[key] = true
+		hasAuthor = hasAuthor || contributor.Role == entity.ContributorAuthor
+
+		author, err := u.authorService.GetAuthorByID(ctx, contributor.AuthorID)
+		if err != nil {
+			return nil, err
+		}
+		if author == nil {
+			return nil, &ValidationError{Message: constant.ERR_CONTRIBUTOR_NOT_FOUND}
+		}
+
+		contributor.EbookID = ebookID
+		contributor.DisplayOrder = i
+		contributor.AuthorName = author.Name
+		contributor.AuthorAvatar = author.Avatar
+	}
+	// ebooks.author_id requires a primary author
+	if !hasAuthor {
+		return nil, &ValidationError{Message: constant.ERR_CONTRIBUTOR_NO_AUTHOR}
+	}
+
+	if err := u.contributorService.ReplaceContributors(ctx, ebookID, contributors); err != nil {
+		return nil, err
+	}
+
+	return contributors, nil
+}
+
+func (u *ebookContributorUsecase) requireEbook(ctx context.Context, ebookID string) error {
+	if ebookID == "" {
+		return &ValidationError{Message: constant.EBOOK_ID_REQUIRED}
+	}
+
+	ebook, err := u.ebookService.GetEbookByID(ctx, ebookID)
+	if err != nil {
+		return err
+	}
+	if ebook == nil {
+		return ErrEbookNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+	"errors"
+	"testing"
+)
+
+// MockEbookContributorService records the last replaced contributors
+type MockEbookContributorService struct {
+	contributors []*entity.EbookContributor
+	err          error
+}
+
+func (m *MockEbookContributorService) GetContributors(ctx context.Context, ebookID string) ([]*entity.EbookContributor, error) {
+	return m.contributors, m.err
+}
+
+func (m *MockEbookContributorService) ReplaceContributors(ctx context.Context, ebookID string, contributors []*entity.EbookContributor) error {
+	m.contributors = contributors
+	return m.err
+}
+
+func TestEbookContributorUsecase_ReplaceContributors(t *testing.T) {
+	ctx := context.Background()
+	ebookService := &MockEbookService{
+		getByIDFunc: func(ctx context.Context, id string) (*entity.Ebook, error) {
+			if id == "missing" {
+				return nil, nil
+			}
+			return &entity.Ebook{ID: id, AuthorID: "author-1"}, nil
+		},
+	}
+	authors := &MockAuthorService{authors: map[string]*entity.Author{
+		"Pramoedya Ananta Toer": {ID: "author-1", Name: "Pramoedya Ananta Toer"},
+		"Max Lane":              {ID: "author-2", Name: "Max Lane"},
+	}}
+
+	t.Run("should keep the given order and fill in the names", func(t *testing.T) {
+		contributorService := &MockEbookContributorService{}
+		u := NewEbookContributorUsecase(ebookService, authors, contributorService)
+
+		contributors, err := u.ReplaceContributors(ctx, "ebook-1", []*entity.EbookContributor{
+			{AuthorID: "author-1", Role: entity.ContributorAuthor},
+			{AuthorID: " author-2 ", Role: entity.ContributorTranslator},
+			{AuthorID: "author-1", Role: entity.ContributorNarrator},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(contributorService.contributors) != 3 {
+			t.Fatalf("expected 3 contributors to be saved, got %d", len(contributorService.contributors))
+		}
+		translator := contributors[1]
+		if translator.AuthorID != "author-2" || translator.AuthorName != "Max Lane" || translator.DisplayOrder != 1 || translator.EbookID != "ebook-1" {
+			t.Errorf("unexpected translator %+v", translator)
+		}
+	})
+
+	tests := []struct {
+		name         string
+		contributors []*entity.EbookContributor
+	}{
+		{name: "no author", contributors: []*entity.EbookContributor{{AuthorID: "author-2", Role: entity.ContributorTranslator}}},
+		{name: "unknown role", contributors: []*entity.EbookContributor{{AuthorID: "author-1", Role: "illustrator"}}},
+		{name: "missing author_id", contributors: []*entity.EbookContributor{{AuthorID: " ", Role: entity.ContributorAuthor}}},
+		{name: "unknown author", contributors: []*entity.EbookContributor{{AuthorID: "author-9", Role: entity.ContributorAuthor}}},
+		{name: "the same role twice", contributors: []*entity.EbookContributor{
+			{AuthorID: "author-1", Role: entity.ContributorAuthor},
+			{AuthorID: "author-1", Role: entity.ContributorAuthor},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run("should reject "+tt.name, func(t *testing.T) {
+			contributorService := &MockEbookContributorService{}
+			u := NewEbookContributorUsecase(ebookService, authors, contributorService)
+
+			var validationErr *ValidationError
+			if _, err := u.ReplaceContributors(ctx, "ebook-1", tt.contributors); !errors.As(err, &validationErr) {
+				t.Errorf("expected a validation error, got %v", err)
+			}
+			if contributorService.contributors != nil {
+				t.Errorf("expected nothing to be saved, got %+v", contributorService.contributors)
+			}
+		})
+	}
+
+	t.Run("should reject unknown ebooks", func(t *testing.T) {
+		u := NewEbookContributorUsecase(ebookService, authors, &MockEbookContributorService{})
+		if _, err := u.ReplaceContributors(ctx, "missing", nil); !errors.Is(err, ErrEbookNotFound) {
+			t.Errorf("expected ErrEbookNotFound, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,15 @@
+package usecase
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// EbookDownloadUsecase defines the interface for entitled ebook file downloads
+type EbookDownloadUsecase interface {
+	AuthorizeDownload(ctx context.Context, user *entity.User, ebookID string) (*entity.Ebook, error)
+	GetDownloadFile(ctx context.Context, userID, ebookID string) (*entity.Ebook, error)
+	LogDownload(ctx context.Context, userID, ebookID, ipAddress, userAgent string) error
+	ListDownloads(ctx context.Context, userID string, limit, offset int) ([]*entity.EbookDownload, error)
+	CountDownloads(ctx context.Context, userID string) (int64, error)
+}
@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/service"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrEbookAccessDenied is returned when a user neither owns an ebook nor has premium access
+	ErrEbookAccessDenied = errors.New(constant.ERR_EBOOK_ACCESS_DENIED)
+	// ErrEbookFileUnavailable is returned when an ebook has no file to download
+	ErrEbookFileUnavailable = errors.New(constant.ERR_EBOOK_FILE_UNAVAILABLE)
+)
+
+type ebookDownloadUsecase struct {
+	ebookService       service.EbookService
+	entitlementService service.EntitlementService
+	downloadService    service.EbookDownloadService
+	mediaService       service.MediaService
+}
+
+func NewEbookDownloadUsecase(
+	ebookService service.EbookService,
+	entitlementService service.EntitlementService,
+	downloadService service.EbookDownloadService,
+	mediaService service.MediaService,
+) EbookDownloadUsecase {
+	return &ebookDownloadUsecase{
+		ebookService:       ebookService,
+		entitlementService: entitlementService,
+		downloadService:    downloadService,
+		mediaService:       mediaService,
+	}
+}
+
+// AuthorizeDownload returns the ebook if the user may download it, or nil if it does not exist
+func (u *ebookDownloadUsecase) AuthorizeDownload(ctx context.Context, user *entity.User, ebookID string) (*entity.Ebook, error) {
+	if ebookID == "" {
+		return nil, &ValidationError{Message: constant.EBOOK_ID_REQUIRED}
+	}
+
+	ebook, err := u.visibleEbook(ctx, user, ebookID)
+	if err != nil || ebook == nil {
+		return nil, err
+	}
+
+	allowed, err := u.entitlementService.CanAccessEbook(ctx, user, ebook)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, ErrEbookAccessDenied
+	}
+
+	if !ebook.HasFile() {
+		return nil, ErrEbookFileUnavailable
+	}
+
+	return ebook, nil
+}
+
+// GetDownloadFile returns the ebook a verified signed URL points to; the signature already proves entitlement,
+// but an ebook unpublished since the URL was issued is only served to editors
+func (u *ebookDownloadUsecase) GetDownloadFile(ctx context.Context, userID, ebookID string) (*entity.Ebook, error) {
+	if ebookID == "" {
+		return nil, &ValidationError{Message: constant.EBOOK_ID_REQUIRED}
+	}
+
+	ebook, err := u.visibleEbook(ctx, &entity.User{ID: userID}, ebookID)
+	if err != nil || ebook == nil {
+		return nil, err
+	}
+
+	// An uploaded file takes precedence over a hand-entered location
+	if ebook.FileMediaKey != nil && *ebook.FileMediaKey != "" {
+		ebook.URL, err = u.mediaService.FileLocation(*ebook.FileMediaKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ebook, nil
+}
+
+// visibleEbook returns the ebook if it is published or the user may edit ebooks, and nil otherwise
+// so drafts and scheduled ebooks look like they do not exist
+func (u *ebookDownloadUsecase) visibleEbook(ctx context.Context, user *entity.User, ebookID string) (*entity.Ebook, error) {
+	ebook, err := u.ebookService.GetEbookByID(ctx, ebookID)
+	if err != nil || ebook == nil {
+		return nil, err
+	}
+	if ebook.IsPublished(time.Now()) {
+		return ebook, nil
+	}
+
+	editor, err := u.entitlementService.CanEditEbooks(ctx, user)
+	if err != nil || !editor {
+		return nil, err
+	}
+	return ebook, nil
+}
+
+func (u *ebookDownloadUsecase) LogDownload(ctx context.Context, userID, ebookID, ipAddress, userAgent string) error {
+	// Keep within the column sizes; the user agent is informational only
+	if len(userAgent) > 255 {
+		userAgent = userAgent[:255]
+	}
+	if len(ipAddress) > 45 {
+		ipAddress = ipAddress[:45]
+	}
+
+	return u.downloadService.LogDownload(ctx, &entity.EbookDownload{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		EbookID:   ebookID,
+		IPAddress: ipAddress,
+		UserAgent: userAgent,
+	})
+}
+
+func (u *ebookDownloadUsecase) ListDownloads(ctx context.Context, userID string, limit, offset int) ([]*entity.EbookDownload, error) {
+	if userID == "" {
+		return nil, &ValidationError{Message: constant.ERR_ID_REQUIRED}
+	}
+
+	// Validate pagination parameters
+	if limit <= 0 {
+		limit = 10 // default limit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return u.downloadService.GetDownloadsByUserID(ctx, userID, limit, offset)
+}
+
+func (u *ebookDownloadUsecase) CountDownloads(ctx context.Context, userID string) (int64, error) {
+	return u.downloadService.GetDownloadCountByUserID(ctx, userID)
+}
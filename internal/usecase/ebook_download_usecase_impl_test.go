@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEbookDownloadUsecase_AuthorizeDownload(t *testing.T) {
+	ctx := context.Background()
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	url := "https://cdn.example.com/ebook.pdf"
+
+	freeDraft := &entity.Ebook{ID: "ebook-1", URL: url, Price: 0, ContentStatus: entity.ContentStatusDraft, PublishedAt: &past}
+	paidDraft := &entity.Ebook{ID: "ebook-1", URL: url, Price: 50000, ContentStatus: entity.ContentStatusDraft}
+	scheduled := &entity.Ebook{ID: "ebook-1", URL: url, Price: 0, ContentStatus: entity.ContentStatusPublished, PublishedAt: &future}
+	published := &entity.Ebook{ID: "ebook-1", URL: url, Price: 50000, ContentStatus: entity.ContentStatusPublished, PublishedAt: &past}
+	entitlementService := &MockEntitlementService{
+		owners:  map[string]bool{"owner": true},
+		premium: map[string]bool{"premium": true},
+		editors: map[string]bool{"editor": true},
+	}
+
+	tests := []struct {
+		name      string
+		ebook     *entity.Ebook
+		userID    string
+		wantEbook bool
+		wantErr   error
+	}{
+		{name: "free draft for a signed-in reader", ebook: freeDraft, userID: "reader"},
+		{name: "draft for a premium user", ebook: paidDraft, userID: "premium"},
+		{name: "free ebook scheduled for later", ebook: scheduled, userID: "reader"},
+		{name: "draft for an editor", ebook: paidDraft, userID: "editor", wantErr: ErrEbookAccessDenied},
+		{name: "free draft for an editor", ebook: freeDraft, userID: "editor", wantEbook: true},
+		{name: "published ebook for its owner", ebook: published, userID: "owner", wantEbook: true},
+		{name: "published ebook for a premium user", ebook: published, userID: "premium", wantEbook: true},
+		{name: "published ebook for another reader", ebook: published, userID: "reader", wantErr: ErrEbookAccessDenied},
+		{name: "published ebook without a file", ebook: &entity.Ebook{ID: "ebook-1", ContentStatus: entity.ContentStatusPublished, PublishedAt: &past}, userID: "reader", wantErr: ErrEbookFileUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewEbookDownloadUsecase(&MockEbookService{ebook: tt.ebook}, entitlementService, nil, NewMockMediaService())
+
+			ebook, err := u.AuthorizeDownload(ctx, &entity.User{ID: tt.userID}, "ebook-1")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if (ebook != nil) != tt.wantEbook {
+				t.Errorf("expected ebook returned %v, got %+v", tt.wantEbook, ebook)
+			}
+		})
+	}
+}
+
+func TestEbookDownloadUsecase_GetDownloadFile(t *testing.T) {
+	ctx := context.Background()
+	draft := &entity.Ebook{ID: "ebook-1", URL: "https://cdn.example.com/ebook.pdf", ContentStatus: entity.ContentStatusDraft}
+	entitlementService := &MockEntitlementService{editors: map[string]bool{"editor": true}}
+	u := NewEbookDownloadUsecase(&MockEbookService{ebook: draft}, entitlementService, nil, NewMockMediaService())
+
+	ebook, err := u.GetDownloadFile(ctx, "premium", "ebook-1")
+	if err != nil || ebook != nil {
+		t.Errorf("expected a draft to be hidden from a signed URL holder, got %+v, %v", ebook, err)
+	}
+
+	ebook, err = u.GetDownloadFile(ctx, "editor", "ebook-1")
+	if err != nil || ebook == nil {
+		t.Errorf("expected an editor to get the draft, got %+v, %v", ebook, err)
+	}
+}
@@ -0,0 +1,12 @@
+package usecase
+
+import (
+	"buku-pintar/internal/delivery/http/response"
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// EbookReaderUsecase defines the interface for reading ebooks page by page
+type EbookReaderUsecase interface {
+	ReadPage(ctx context.Context, user *entity.User, ebookID string, pageNumber int) (*response.EbookPageResponse, error)
+}
@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/delivery/http/response"
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/service"
+	"context"
+	"errors"
+	"log"
+	"time"
+)
+
+var (
+	// ErrEbookPageNotFound is returned when the requested page is past the end of the ebook
+	ErrEbookPageNotFound = errors.New(constant.ERR_EBOOK_PAGE_NOT_FOUND)
+	// ErrLoginRequiredToRead is returned when an anonymous reader goes past the preview of a free ebook
+	ErrLoginRequiredToRead = errors.New(constant.ERR_LOGIN_REQUIRED_TO_READ)
+)
+
+// PurchaseRequiredError is returned when a non-owner asks for a page beyond the preview
+type PurchaseRequiredError struct {
+	Pricing *response.EbookPricingResponse
+}
+
+func (e *PurchaseRequiredError) Error() string {
+	return constant.ERR_PURCHASE_REQUIRED
+}
+
+type ebookReaderUsecase struct {
+	ebookService         service.EbookService
+	pageService          service.EbookPageService
+	entitlementService   service.EntitlementService
+	ebookDiscountService service.EbookDiscountService
+	trendingService      service.TrendingService
+}
+
+func NewEbookReaderUsecase(
+	ebookService service.EbookService,
+	pageService service.EbookPageService,
+	entitlementService service.EntitlementService,
+	ebookDiscountService service.EbookDiscountService,
+	trendingService service.TrendingService,
+) EbookReaderUsecase {
+	return &ebookReaderUsecase{
+		ebookService:         ebookService,
+		pageService:          pageService,
+		entitlementService:   entitlementService,
+		ebookDiscountService: ebookDiscountService,
+		trendingService:      trendingService,
+	}
+}
+
+// ReadPage returns a page of an ebook, or nil if the ebook does not exist or is not published yet.
+// Pages up to preview_page are open to everyone (including anonymous users);
+// the rest need ownership or premium access. Editors read unpublished ebooks in full.
+func (u *ebookReaderUsecase) ReadPage(ctx context.Context, user *entity.User, ebookID string, pageNumber int) (*response.EbookPageResponse, error) {
+	if ebookID == "" {
+		return nil, &ValidationError{Message: constant.EBOOK_ID_REQUIRED}
+	}
+	if pageNumber < 1 {
+		return nil, &ValidationError{Message: constant.ERR_PAGE_NUMBER_INVALID}
+	}
+
+	ebook, err := u.ebookService.GetEbookByID(ctx, ebookID)
+	if err != nil || ebook == nil {
+		return nil, err
+	}
+
+	entitled := false
+	published := ebook.IsPublished(time.Now())
+	if !published {
+		// Only editors may see an ebook before it is published; everyone else gets a not found
+		entitled, err = u.entitlementService.CanEditEbooks(ctx, user)
+		if err != nil || !entitled {
+			return nil, err
+		}
+	} else if user != nil {
+		entitled, err = u.entitlementService.CanAccessEbook(ctx, user, ebook)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Gate before loading the page so non-owners cannot probe content past the preview
+	if !entitled && pageNumber > int(ebook.PreviewPage) {
+		if user == nil && ebook.Price == 0 {
+			return nil, ErrLoginRequiredToRead
+		}
+
+		discount, _ := u.ebookDiscountService.GetActiveDiscountByEbookID(ctx, ebook.ID)
+		return nil, &PurchaseRequiredError{
+			Pricing: response.ParseEbookPricingResponse(ebook, discount, constant.DEFAULT_CURRENCY),
+		}
+	}
+
+	page, err := u.pageService.GetPage(ctx, ebook.ID, pageNumber)
+	if err != nil {
+		return nil, err
+	}
+	if page == nil {
+		return nil, ErrEbookPageNotFound
+	}
+
+	totalPages, err := u.pageService.GetPageCount(ctx, ebook.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Editors checking a draft are not readers
+	if published {
+		u.recordRead(ctx, user, ebook, pageNumber)
+	}
+
+	return response.ParseEbookPageResponse(page, totalPages, ebook.PreviewPage, !entitled), nil
+}
+
+// recordRead counts a signed-in reader once a day per ebook, however many pages they turn.
+// Anonymous readers cannot be told apart, so only opening the first page counts for them.
+func (u *ebookReaderUsecase) recordRead(ctx context.Context, user *entity.User, ebook *entity.Ebook, pageNumber int) {
+	actorID := ""
+	if user != nil {
+		actorID = user.ID
+	} else if pageNumber != 1 {
+		return
+	}
+
+	if err := u.trendingService.RecordEngagement(ctx, ebook.ID, ebook.CategoryID, entity.EngagementRead, actorID); err != nil {
+		log.Printf("Failed to record ebook read: %v", err)
+	}
+}
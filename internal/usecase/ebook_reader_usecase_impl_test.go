@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// MockEbookPageService is a simple mock implementation for testing
+type MockEbookPageService struct {
+	pages map[int]*entity.EbookPage
+	err   error
+}
+
+func (m *MockEbookPageService) CreatePage(ctx context.Context, page *entity.EbookPage) error {
+	return m.err
+}
+
+func (m *MockEbookPageService) ReplacePages(ctx context.Context, ebookID string, pages []*entity.EbookPage) error {
+	m.pages = map[int]*entity.EbookPage{}
+	for _, page := range pages {
+		m.pages[page.PageNumber] = page
+	}
+	return m.err
+}
+
+func (m *MockEbookPageService) GetPage(ctx context.Context, ebookID string, pageNumber int) (*entity.EbookPage, error) {
+	return m.pages[pageNumber], m.err
+}
+
+func (m *MockEbookPageService) GetPageCount(ctx context.Context, ebookID string) (int64, error) {
+	return int64(len(m.pages)), m.err
+}
+
+// MockEntitlementService grants access to the users listed in owners, premium access to those in premium
+// and lets those in editors edit ebooks
+type MockEntitlementService struct {
+	owners  map[string]bool
+	premium map[string]bool
+	editors map[string]bool
+	err     error
+}
+
+func (m *MockEntitlementService) OwnsEbook(ctx context.Context, userID, ebookID string) (bool, error) {
+	return m.owners[userID], m.err
+}
+
+func (m *MockEntitlementService) HasPremiumAccess(ctx context.Context, user *entity.User) (bool, error) {
+	if user == nil {
+		return false, m.err
+	}
+	return m.premium[user.ID], m.err
+}
+
+func (m *MockEntitlementService) CanAccessEbook(ctx context.Context, user *entity.User, ebook *entity.Ebook) (bool, error) {
+	if user == nil {
+		return false, m.err
+	}
+	return ebook.Price == 0 || m.owners[user.ID] || m.premium[user.ID], m.err
+}
+
+func (m *MockEntitlementService) CanEditEbooks(ctx context.Context, user *entity.User) (bool, error) {
+	if user == nil {
+		return false, m.err
+	}
+	return m.editors[user.ID], m.err
+}
+
+func TestEbookReaderUsecase_ReadPage(t *testing.T) {
+	pages := map[int]*entity.EbookPage{}
+	for i := 1; i <= 5; i++ {
+		pages[i] = &entity.EbookPage{ID: "page", EbookID: "ebook-1", PageNumber: i, ContentType: "text/html", Content: "<p>page</p>"}
+	}
+	publishedAt := time.Now().Add(-time.Hour)
+	scheduledAt := time.Now().Add(time.Hour)
+	paidEbook := &entity.Ebook{ID: "ebook-1", Title: "Paid Ebook", Price: 50000, PreviewPage: 2, PageCount: 5, ContentStatus: entity.ContentStatusPublished, PublishedAt: &publishedAt}
+	freeEbook := &entity.Ebook{ID: "ebook-1", Title: "Free Ebook", Price: 0, PreviewPage: 2, PageCount: 5, ContentStatus: entity.ContentStatusPublished, PublishedAt: &publishedAt}
+	draftEbook := &entity.Ebook{ID: "ebook-1", Title: "Draft Ebook", Price: 50000, PreviewPage: 2, PageCount: 5, ContentStatus: entity.ContentStatusDraft}
+	scheduledEbook := &entity.Ebook{ID: "ebook-1", Title: "Scheduled Ebook", Price: 50000, PreviewPage: 2, PageCount: 5, ContentStatus: entity.ContentStatusPublished, PublishedAt: &scheduledAt}
+	owner := &entity.User{ID: "owner"}
+	reader := &entity.User{ID: "reader"}
+	editor := &entity.User{ID: "editor"}
+
+	tests := []struct {
+		name           string
+		ebook          *entity.Ebook
+		discount       *entity.EbookDiscount
+		user           *entity.User
+		page           int
+		wantErr        error
+		wantPurchase   bool
+		wantFinalPrice int
+		wantPreview    bool
+		wantNil        bool
+		wantValidation bool
+	}{
+		{name: "anonymous reads preview page", ebook: paidEbook, page: 2, wantPreview: true},
+		{name: "anonymous past preview needs purchase", ebook: paidEbook, page: 3, wantPurchase: true, wantFinalPrice: 50000},
+		{name: "non-owner past preview gets discounted pricing", ebook: paidEbook, discount: &entity.EbookDiscount{DiscountPrice: 25000}, user: reader, page: 3, wantPurchase: true, wantFinalPrice: 25000},
+		{name: "owner reads the full book", ebook: paidEbook, user: owner, page: 5},
+		{name: "owner past the last page", ebook: paidEbook, user: owner, page: 6, wantErr: ErrEbookPageNotFound},
+		{name: "anonymous past preview of free ebook must sign in", ebook: freeEbook, page: 3, wantErr: ErrLoginRequiredToRead},
+		{name: "signed in user reads free ebook", ebook: freeEbook, user: reader, page: 4},
+		{name: "invalid page number", ebook: paidEbook, page: 0, wantValidation: true},
+		{name: "ebook not found", ebook: nil, page: 1, wantNil: true},
+		{name: "draft ebook is hidden from readers", ebook: draftEbook, user: owner, page: 1, wantNil: true},
+		{name: "scheduled ebook is hidden from readers", ebook: scheduledEbook, page: 1, wantNil: true},
+		{name: "editor reads a draft ebook in full", ebook: draftEbook, user: editor, page: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewEbookReaderUsecase(
+				&MockEbookService{ebook: tt.ebook},
+				&MockEbookPageService{pages: pages},
+				&MockEntitlementService{owners: map[string]bool{"owner": true}, editors: map[string]bool{"editor": true}},
+				&MockEbookDiscountService{discount: tt.discount},
+				&MockTrendingService{},
+			)
+
+			page, err := u.ReadPage(context.Background(), tt.user, "ebook-1", tt.page)
+
+			var purchaseErr *PurchaseRequiredError
+			var validationErr *ValidationError
+			switch {
+			case tt.wantPurchase:
+				if !errors.As(err, &purchaseErr) {
+					t.Fatalf("expected purchase required error, got %v", err)
+				}
+				if purchaseErr.Pricing.FinalPrice != tt.wantFinalPrice || purchaseErr.Pricing.PreviewPage != 2 {
+					t.Errorf("unexpected pricing %+v", purchaseErr.Pricing)
+				}
+			case tt.wantValidation:
+				if !errors.As(err, &validationErr) {
+					t.Fatalf("expected validation error, got %v", err)
+				}
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+			case tt.wantNil:
+				if err != nil || page != nil {
+					t.Fatalf("expected nil page and error, got %v, %v", page, err)
+				}
+			default:
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if page.PageNumber != tt.page || page.Preview != tt.wantPreview || page.TotalPages != 5 {
+					t.Errorf("unexpected page %+v", page)
+				}
+			}
+		})
+	}
+}
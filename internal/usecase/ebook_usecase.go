@@ -10,13 +10,17 @@ import (
 type EbookUsecase interface {
 	CreateEbook(ctx context.Context, ebook *entity.Ebook) error
 	GetEbookByID(ctx context.Context, id string) (*entity.Ebook, error)
-	GetEbookBySlug(ctx context.Context, slug string) (*response.EbookResponse, error)
+	// GetEbookBySlug returns a published ebook and counts the view; viewerKey identifies the viewer
+	// (a user ID, or a client key for anonymous viewers) so each counts once a day
+	GetEbookBySlug(ctx context.Context, slug, viewerKey string) (*response.EbookResponse, error)
 	UpdateEbook(ctx context.Context, ebook *entity.Ebook) error
 	DeleteEbook(ctx context.Context, id string) error
-	ListEbooks(ctx context.Context, limit, offset int) ([]*response.EbookListResponse, error)
+	ListEbooks(ctx context.Context, filter *entity.EbookFilter, limit, offset int) ([]*response.EbookListResponse, error)
+	ListEbooksByCursor(ctx context.Context, filter *entity.EbookFilter, cursor *entity.PageCursor, limit int) ([]*response.EbookListResponse, *entity.CursorPageInfo, error)
 	ListEbooksByCategory(ctx context.Context, categoryID string, limit, offset int) ([]*entity.Ebook, error)
 	ListEbooksByAuthor(ctx context.Context, authorID string, limit, offset int) ([]*entity.Ebook, error)
-	CountEbooks(ctx context.Context) (int64, error)
+	CountEbooks(ctx context.Context, filter *entity.EbookFilter) (int64, error)
+	GetEbookFacets(ctx context.Context, filter *entity.EbookFilter) (*response.EbookFacetsResponse, error)
 	CountEbooksByCategory(ctx context.Context, categoryID string) (int64, error)
 	CountEbooksByAuthor(ctx context.Context, authorID string) (int64, error)
 }
@@ -7,18 +7,25 @@ import (
 	"buku-pintar/internal/domain/service"
 	"context"
 	"errors"
+	"log"
 )
 
 type ebookUsecase struct {
 	ebookService service.EbookService
 	ebookDiscountService service.EbookDiscountService
+	notificationService service.NotificationService
+	seriesService service.SeriesService
+	trendingService service.TrendingService
 }
 
 // NewEbookUsecase creates a new instance of EbookUsecase
-func NewEbookUsecase(ebookService service.EbookService, ebookDiscountService service.EbookDiscountService) EbookUsecase {
+func NewEbookUsecase(ebookService service.EbookService, ebookDiscountService service.EbookDiscountService, notificationService service.NotificationService, seriesService service.SeriesService, trendingService service.TrendingService) EbookUsecase {
 	return &ebookUsecase{
 		ebookService: ebookService,
 		ebookDiscountService: ebookDiscountService,
+		notificationService: notificationService,
+		seriesService: seriesService,
+		trendingService: trendingService,
 	}
 }
 
@@ -57,7 +64,7 @@ func (u *ebookUsecase) GetEbookByID(ctx context.Context, id string) (*entity.Ebo
 	return u.ebookService.GetEbookByID(ctx, id)
 }
 
-func (u *ebookUsecase) GetEbookBySlug(ctx context.Context, slug string) (*response.EbookResponse, error) {
+func (u *ebookUsecase) GetEbookBySlug(ctx context.Context, slug, viewerKey string) (*response.EbookResponse, error) {
 	if slug == "" {
 		return nil, errors.New("slug is required")
 	}
@@ -76,6 +83,15 @@ func (u *ebookUsecase) GetEbookBySlug(ctx context.Context, slug string) (*respon
 	if discount != nil {
 		res.Discount = response.ParseDiscountResponse(discount)
 	}
+	series, _ := u.seriesService.GetMembership(ctx, ebook.ID)
+	if series != nil {
+		res.Series = response.ParseEbookSeriesResponse(series)
+	}
+
+	// Each viewer counts toward trending once a day; a failure to count it must not fail the view
+	if err := u.trendingService.RecordEngagement(ctx, ebook.ID, ebook.CategoryID, entity.EngagementView, viewerKey); err != nil {
+		log.Printf("Failed to record ebook view: %v", err)
+	}
 
 	return res, nil
 }
@@ -105,7 +121,45 @@ func (u *ebookUsecase) UpdateEbook(ctx context.Context, ebook *entity.Ebook) err
 		}
 	}
 
-	return u.ebookService.UpdateEbook(ctx, ebook)
+	if err := u.ebookService.UpdateEbook(ctx, ebook); err != nil {
+		return err
+	}
+
+	if ebook.Price < existingEbook.Price {
+		u.notifyPriceDrop(ctx, existingEbook, ebook)
+	}
+	return nil
+}
+
+// notifyPriceDrop tells wishlisting users about a lower list price. An active discount below both
+// prices means the price they pay does not change, so nobody is notified.
+// Failures are logged; the price change itself has already been saved.
+func (u *ebookUsecase) notifyPriceDrop(ctx context.Context, before, after *entity.Ebook) {
+	oldPrice, newPrice := before.Price, after.Price
+	discount, err := u.ebookDiscountService.GetActiveDiscountByEbookID(ctx, after.ID)
+	if err != nil {
+		log.Printf("Failed to get active discount for price drop of ebook %s: %v", after.ID, err)
+	}
+	if discount != nil {
+		oldPrice = min(oldPrice, discount.DiscountPrice)
+		newPrice = min(newPrice, discount.DiscountPrice)
+	}
+	if newPrice >= oldPrice {
+		return
+	}
+
+	_, err = u.notificationService.NotifyPriceDrop(ctx, &entity.PriceDrop{
+		EbookID:    after.ID,
+		Title:      after.Title,
+		Slug:       after.Slug,
+		CoverImage: after.CoverImage,
+		Reason:     entity.PriceDropPriceChange,
+		OldPrice:   oldPrice,
+		NewPrice:   newPrice,
+	})
+	if err != nil {
+		log.Printf("Failed to emit price drop notifications for ebook %s: %v", after.ID, err)
+	}
 }
 
 func (u *ebookUsecase) DeleteEbook(ctx context.Context, id string) error {
@@ -125,7 +179,7 @@ func (u *ebookUsecase) DeleteEbook(ctx context.Context, id string) error {
 	return u.ebookService.DeleteEbook(ctx, id)
 }
 
-func (u *ebookUsecase) ListEbooks(ctx context.Context, limit, offset int) ([]*response.EbookListResponse, error) {
+func (u *ebookUsecase) ListEbooks(ctx context.Context, filter *entity.EbookFilter, limit, offset int) ([]*response.EbookListResponse, error) {
 	// Validate pagination parameters
 	if limit <= 0 {
 		limit = 10 // default limit
@@ -134,8 +188,13 @@ func (u *ebookUsecase) ListEbooks(ctx context.Context, limit, offset int) ([]*re
 		offset = 0
 	}
 
+	filter, err := normalizeEbookFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
 	ebooks := []*response.EbookListResponse{}
-	ebookList, err := u.ebookService.GetEbookList(ctx, limit, offset)
+	ebookList, err := u.ebookService.GetEbookList(ctx, filter, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -147,6 +206,37 @@ func (u *ebookUsecase) ListEbooks(ctx context.Context, limit, offset int) ([]*re
 	return ebooks, nil
 }
 
+func (u *ebookUsecase) ListEbooksByCursor(ctx context.Context, filter *entity.EbookFilter, cursor *entity.PageCursor, limit int) ([]*response.EbookListResponse, *entity.CursorPageInfo, error) {
+	// Validate pagination parameters
+	if limit <= 0 {
+		limit = 10 // default limit
+	}
+
+	filter, err := normalizeEbookFilter(filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// A cursor only makes sense for the sort order and filters it was issued for
+	filterHash := entity.CursorFilterHash(filter.CacheKey())
+	if err := checkCursor(cursor, entity.EbookCursorSort(filter.Sort), filterHash); err != nil {
+		return nil, nil, err
+	}
+
+	ebookList, page, err := u.ebookService.GetEbookListByCursor(ctx, filter, cursor, limit)
+	if err != nil {
+		return nil, nil, cursorValueError(err)
+	}
+	page.BindFilter(filterHash)
+
+	ebooks := []*response.EbookListResponse{}
+	for _, ebook := range ebookList {
+		ebooks = append(ebooks, response.ParseEbookListResponse(ebook))
+	}
+
+	return ebooks, page, nil
+}
+
 func (u *ebookUsecase) ListEbooksByCategory(ctx context.Context, categoryID string, limit, offset int) ([]*entity.Ebook, error) {
 	if categoryID == "" {
 		return nil, errors.New(constant.ERR_CATEGORY_ID_REQUIRED)
@@ -179,8 +269,27 @@ func (u *ebookUsecase) ListEbooksByAuthor(ctx context.Context, authorID string,
 	return u.ebookService.GetEbookListByAuthor(ctx, authorID, limit, offset)
 }
 
-func (u *ebookUsecase) CountEbooks(ctx context.Context) (int64, error) {
-	return u.ebookService.GetEbookCount(ctx)
+func (u *ebookUsecase) CountEbooks(ctx context.Context, filter *entity.EbookFilter) (int64, error) {
+	filter, err := normalizeEbookFilter(filter)
+	if err != nil {
+		return 0, err
+	}
+
+	return u.ebookService.GetEbookCount(ctx, filter)
+}
+
+func (u *ebookUsecase) GetEbookFacets(ctx context.Context, filter *entity.EbookFilter) (*response.EbookFacetsResponse, error) {
+	filter, err := normalizeEbookFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	facets, err := u.ebookService.GetEbookFacets(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.ParseEbookFacetsResponse(facets), nil
 }
 
 func (u *ebookUsecase) CountEbooksByCategory(ctx context.Context, categoryID string) (int64, error) {
@@ -198,3 +307,18 @@ func (u *ebookUsecase) CountEbooksByAuthor(ctx context.Context, authorID string)
 
 	return u.ebookService.GetEbookCountByAuthor(ctx, authorID)
 }
+
+// normalizeEbookFilter returns a normalised copy of the filter so cache keys stay stable
+func normalizeEbookFilter(filter *entity.EbookFilter) (*entity.EbookFilter, error) {
+	normalized := entity.EbookFilter{}
+	if filter != nil {
+		normalized = *filter
+	}
+	normalized.Normalize()
+
+	if err := normalized.Validate(); err != nil {
+		return nil, &ValidationError{Message: err.Error()}
+	}
+
+	return &normalized, nil
+}
@@ -2,9 +2,11 @@ package usecase
 
 import (
 	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
 	"buku-pintar/internal/domain/service"
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -20,7 +22,8 @@ type MockEbookService struct {
 	err           error
 	count         int64
 	createFunc    func(ctx context.Context, ebook *entity.Ebook) error
-	listFunc      func(ctx context.Context, limit, offset int) ([]*entity.EbookList, error)
+	listFunc      func(ctx context.Context, filter *entity.EbookFilter, limit, offset int) ([]*entity.EbookList, error)
+	feedFunc      func(ctx context.Context, filter *entity.EbookFilter, limit, offset int) ([]*entity.EbookFeedEntry, error)
 	getByIDFunc   func(ctx context.Context, id string) (*entity.Ebook, error)
 	getBySlugFunc func(ctx context.Context, slug string) (*entity.EbookDetail, error)
 	// For testing specific scenarios
@@ -147,13 +150,24 @@ func (m *MockEbookService) DeleteEbook(ctx context.Context, id string) error {
 	return m.err
 }
 
-func (m *MockEbookService) GetEbookList(ctx context.Context, limit, offset int) ([]*entity.EbookList, error) {
+func (m *MockEbookService) GetEbookList(ctx context.Context, filter *entity.EbookFilter, limit, offset int) ([]*entity.EbookList, error) {
 	if m.listFunc != nil {
-		return m.listFunc(ctx, limit, offset)
+		return m.listFunc(ctx, filter, limit, offset)
 	}
 	return m.ebookList, m.err
 }
 
+func (m *MockEbookService) GetEbookListByCursor(ctx context.Context, filter *entity.EbookFilter, cursor *entity.PageCursor, limit int) ([]*entity.EbookList, *entity.CursorPageInfo, error) {
+	return m.ebookList, &entity.CursorPageInfo{}, m.err
+}
+
+func (m *MockEbookService) GetEbookFeedEntries(ctx context.Context, filter *entity.EbookFilter, limit, offset int) ([]*entity.EbookFeedEntry, error) {
+	if m.feedFunc != nil {
+		return m.feedFunc(ctx, filter, limit, offset)
+	}
+	return nil, m.err
+}
+
 func (m *MockEbookService) GetEbookListByCategory(ctx context.Context, categoryID string, limit, offset int) ([]*entity.Ebook, error) {
 	return m.ebooks, m.err
 }
@@ -162,10 +176,14 @@ func (m *MockEbookService) GetEbookListByAuthor(ctx context.Context, authorID st
 	return m.ebooks, m.err
 }
 
-func (m *MockEbookService) GetEbookCount(ctx context.Context) (int64, error) {
+func (m *MockEbookService) GetEbookCount(ctx context.Context, filter *entity.EbookFilter) (int64, error) {
 	return m.count, m.err
 }
 
+func (m *MockEbookService) GetEbookFacets(ctx context.Context, filter *entity.EbookFilter) (*entity.EbookFacets, error) {
+	return &entity.EbookFacets{}, m.err
+}
+
 func (m *MockEbookService) GetEbookCountByCategory(ctx context.Context, categoryID string) (int64, error) {
 	return m.count, m.err
 }
@@ -180,6 +198,28 @@ var _ service.EbookService = (*MockEbookService)(nil)
 // Ensure MockEbookDiscountService implements service.EbookDiscountService interface
 var _ service.EbookDiscountService = (*MockEbookDiscountService)(nil)
 
+// MockNotificationService records the price drops it is asked to announce
+type MockNotificationService struct {
+	drops []*entity.PriceDrop
+}
+
+func (m *MockNotificationService) NotifyPriceDrop(ctx context.Context, drop *entity.PriceDrop) (int64, error) {
+	m.drops = append(m.drops, drop)
+	return 1, nil
+}
+
+func (m *MockNotificationService) NotifyStartedDiscounts(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
+func (m *MockNotificationService) GetNotifications(ctx context.Context, userID string, limit, offset int) ([]*entity.NotificationEvent, error) {
+	return nil, nil
+}
+
+func (m *MockNotificationService) GetNotificationCount(ctx context.Context, userID string) (int64, error) {
+	return 0, nil
+}
+
 func TestEbookUsecase_ListEbooks(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -273,11 +313,11 @@ func TestEbookUsecase_ListEbooks(t *testing.T) {
 				discountList: tt.mockDiscountList,
 				err:       tt.mockError,
 			}
-			usecase := NewEbookUsecase(mockRepo, mockRepoDiscount)
+			usecase := NewEbookUsecase(mockRepo, mockRepoDiscount, &MockNotificationService{}, &MockSeriesService{}, &MockTrendingService{})
 			ctx := context.Background()
 
 			// Act
-			result, err := usecase.ListEbooks(ctx, tt.limit, tt.offset)
+			result, err := usecase.ListEbooks(ctx, nil, tt.limit, tt.offset)
 
 			// Assert
 			if tt.expectedError {
@@ -296,6 +336,119 @@ func TestEbookUsecase_ListEbooks(t *testing.T) {
 	}
 }
 
+func TestEbookUsecase_ListEbooks_Filter(t *testing.T) {
+	t.Run("should pass normalised filter to service", func(t *testing.T) {
+		var received *entity.EbookFilter
+		mockService := &MockEbookService{
+			listFunc: func(ctx context.Context, filter *entity.EbookFilter, limit, offset int) ([]*entity.EbookList, error) {
+				received = filter
+				return []*entity.EbookList{}, nil
+			},
+		}
+		usecase := NewEbookUsecase(mockService, &MockEbookDiscountService{}, &MockNotificationService{}, &MockSeriesService{}, &MockTrendingService{})
+
+		minPrice, maxPrice := 50000, 10000
+		_, err := usecase.ListEbooks(context.Background(), &entity.EbookFilter{
+			Language: " ID ",
+			Format:   "EPUB",
+			MinPrice: &minPrice,
+			MaxPrice: &maxPrice,
+		}, 10, 0)
+		if err != nil {
+			t.Fatalf("expected no error but got: %v", err)
+		}
+
+		if received == nil {
+			t.Fatal("expected filter to be passed to service")
+		}
+		if received.Language != "id" || received.Format != entity.FormatEPUB {
+			t.Errorf("expected language and format to be normalised, got %q and %q", received.Language, received.Format)
+		}
+		if received.Sort != entity.EbookSortNewest {
+			t.Errorf("expected default sort %q, got %q", entity.EbookSortNewest, received.Sort)
+		}
+		if *received.MinPrice != 10000 || *received.MaxPrice != 50000 {
+			t.Errorf("expected swapped price range, got %d-%d", *received.MinPrice, *received.MaxPrice)
+		}
+		if received.CacheKey() != "lang=id|fmt=epub|min=10000|max=50000|sort=newest" {
+			t.Errorf("unexpected cache key: %s", received.CacheKey())
+		}
+	})
+
+	t.Run("should reject unsupported sort", func(t *testing.T) {
+		usecase := NewEbookUsecase(&MockEbookService{}, &MockEbookDiscountService{}, &MockNotificationService{}, &MockSeriesService{}, &MockTrendingService{})
+
+		_, err := usecase.ListEbooks(context.Background(), &entity.EbookFilter{Sort: "random"}, 10, 0)
+
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("expected validation error, got: %v", err)
+		}
+	})
+}
+
+func TestEbookUsecase_ListEbooksByCursor(t *testing.T) {
+	t.Run("should reject cursor issued for another sort", func(t *testing.T) {
+		usecase := NewEbookUsecase(&MockEbookService{}, &MockEbookDiscountService{}, &MockNotificationService{}, &MockSeriesService{}, &MockTrendingService{})
+		cursor := &entity.PageCursor{Sort: entity.EbookCursorSort(entity.EbookSortNewest), Value: "2024-01-01T00:00:00Z", ID: "ebook-1"}
+
+		_, _, err := usecase.ListEbooksByCursor(context.Background(), &entity.EbookFilter{Sort: entity.EbookSortTitle}, cursor, 10)
+
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("expected validation error, got: %v", err)
+		}
+	})
+
+	t.Run("should accept cursor for the default sort", func(t *testing.T) {
+		mockService := &MockEbookService{ebookList: []*entity.EbookList{{ID: "ebook-2", Title: "Second"}}}
+		usecase := NewEbookUsecase(mockService, &MockEbookDiscountService{}, &MockNotificationService{}, &MockSeriesService{}, &MockTrendingService{})
+		filterHash := entity.CursorFilterHash((&entity.EbookFilter{Sort: entity.EbookSortNewest}).CacheKey())
+		cursor := &entity.PageCursor{Sort: entity.EbookCursorSort(entity.EbookSortNewest), Filter: filterHash, Value: "2024-01-01T00:00:00Z", ID: "ebook-1"}
+
+		result, page, err := usecase.ListEbooksByCursor(context.Background(), nil, cursor, 10)
+		if err != nil {
+			t.Fatalf("expected no error but got: %v", err)
+		}
+		if len(result) != 1 || page == nil {
+			t.Errorf("expected 1 ebook and page info, got %d and %v", len(result), page)
+		}
+	})
+
+	t.Run("should reject cursor issued for other filters", func(t *testing.T) {
+		usecase := NewEbookUsecase(&MockEbookService{}, &MockEbookDiscountService{}, &MockNotificationService{}, &MockSeriesService{}, &MockTrendingService{})
+		filterHash := entity.CursorFilterHash((&entity.EbookFilter{Language: "id", Sort: entity.EbookSortNewest}).CacheKey())
+		cursor := &entity.PageCursor{Sort: entity.EbookCursorSort(entity.EbookSortNewest), Filter: filterHash, Value: "2024-01-01T00:00:00Z", ID: "ebook-1"}
+
+		_, _, err := usecase.ListEbooksByCursor(context.Background(), &entity.EbookFilter{Language: "en"}, cursor, 10)
+		if !errors.Is(err, ErrCursorMismatch) {
+			t.Errorf("expected cursor mismatch, got: %v", err)
+		}
+	})
+
+	t.Run("should accept cursor for an equivalent filter", func(t *testing.T) {
+		usecase := NewEbookUsecase(&MockEbookService{}, &MockEbookDiscountService{}, &MockNotificationService{}, &MockSeriesService{}, &MockTrendingService{})
+		filterHash := entity.CursorFilterHash((&entity.EbookFilter{Language: "id", Sort: entity.EbookSortNewest}).CacheKey())
+		cursor := &entity.PageCursor{Sort: entity.EbookCursorSort(entity.EbookSortNewest), Filter: filterHash, Value: "2024-01-01T00:00:00Z", ID: "ebook-1"}
+
+		if _, _, err := usecase.ListEbooksByCursor(context.Background(), &entity.EbookFilter{Language: " ID "}, cursor, 10); err != nil {
+			t.Errorf("expected no error but got: %v", err)
+		}
+	})
+
+	t.Run("should reject cursor value that cannot be bound", func(t *testing.T) {
+		mockService := &MockEbookService{err: fmt.Errorf("%w: bad time", repository.ErrInvalidCursorValue)}
+		usecase := NewEbookUsecase(mockService, &MockEbookDiscountService{}, &MockNotificationService{}, &MockSeriesService{}, &MockTrendingService{})
+		filterHash := entity.CursorFilterHash((&entity.EbookFilter{Sort: entity.EbookSortNewest}).CacheKey())
+		cursor := &entity.PageCursor{Sort: entity.EbookCursorSort(entity.EbookSortNewest), Filter: filterHash, Value: "42", ID: "ebook-1"}
+
+		_, _, err := usecase.ListEbooksByCursor(context.Background(), nil, cursor, 10)
+		if !errors.Is(err, ErrCursorValueInvalid) {
+			t.Errorf("expected invalid cursor value, got: %v", err)
+		}
+	})
+}
+
 func TestEbookUsecase_ListEbooksByCategory(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -373,7 +526,7 @@ func TestEbookUsecase_ListEbooksByCategory(t *testing.T) {
 				discountList: tt.mockDiscountList,
 				err:       tt.mockError,
 			}
-			usecase := NewEbookUsecase(mockRepo, mockRepoDiscount)
+			usecase := NewEbookUsecase(mockRepo, mockRepoDiscount, &MockNotificationService{}, &MockSeriesService{}, &MockTrendingService{})
 			ctx := context.Background()
 
 			// Act
@@ -461,7 +614,7 @@ func TestEbookUsecase_GetEbookByID(t *testing.T) {
 				discount: tt.mockDiscount,
 				err:       tt.mockError,
 			}
-			usecase := NewEbookUsecase(mockRepo, mockRepoDiscount)
+			usecase := NewEbookUsecase(mockRepo, mockRepoDiscount, &MockNotificationService{}, &MockSeriesService{}, &MockTrendingService{})
 			ctx := context.Background()
 
 			// Act
@@ -557,11 +710,12 @@ func TestEbookUsecase_GetEbookBySlug(t *testing.T) {
 				discount: tt.mockDiscount,
 				err:       tt.mockError,
 			}
-			usecase := NewEbookUsecase(mockRepo, mockRepoDiscount)
+			trendingService := &MockTrendingService{}
+			usecase := NewEbookUsecase(mockRepo, mockRepoDiscount, &MockNotificationService{}, &MockSeriesService{}, trendingService)
 			ctx := context.Background()
 
 			// Act
-			result, err := usecase.GetEbookBySlug(ctx, tt.slug)
+			result, err := usecase.GetEbookBySlug(ctx, tt.slug, "ip:203.0.113.7")
 
 			// Assert
 			if tt.expectedError {
@@ -578,6 +732,9 @@ func TestEbookUsecase_GetEbookBySlug(t *testing.T) {
 					} else if result.Slug != tt.slug {
 						t.Errorf("expected ebook slug %s, got %s", tt.slug, result.Slug)
 					}
+					if len(trendingService.actors) != 1 || trendingService.actors[0] != "ip:203.0.113.7" {
+						t.Errorf("expected the view to be counted for the viewer, got %v", trendingService.actors)
+					}
 				} else {
 					if result != nil {
 						t.Errorf("expected nil but got ebook response")
@@ -707,7 +864,7 @@ func TestEbookUsecase_CreateEbook(t *testing.T) {
 				discount: tt.mockDiscount,
 				err:       tt.mockError,
 			}
-			usecase := NewEbookUsecase(mockRepo, mockRepoDiscount)
+			usecase := NewEbookUsecase(mockRepo, mockRepoDiscount, &MockNotificationService{}, &MockSeriesService{}, &MockTrendingService{})
 			ctx := context.Background()
 
 			// Act
@@ -824,7 +981,7 @@ func TestEbookUsecase_UpdateEbook(t *testing.T) {
 					}, nil
 				}
 			}
-			usecase := NewEbookUsecase(mockRepo, mockRepoDiscount)
+			usecase := NewEbookUsecase(mockRepo, mockRepoDiscount, &MockNotificationService{}, &MockSeriesService{}, &MockTrendingService{})
 			ctx := context.Background()
 
 			// Act
@@ -844,6 +1001,83 @@ func TestEbookUsecase_UpdateEbook(t *testing.T) {
 	}
 }
 
+func TestEbookUsecase_UpdateEbook_PriceDrop(t *testing.T) {
+	tests := []struct {
+		name         string
+		oldPrice     int
+		newPrice     int
+		discount     *entity.EbookDiscount
+		wantNotified bool
+		wantOld      int
+		wantNew      int
+	}{
+		{
+			name:         "should notify when the price is lowered",
+			oldPrice:     100000,
+			newPrice:     80000,
+			wantNotified: true,
+			wantOld:      100000,
+			wantNew:      80000,
+		},
+		{
+			name:     "should not notify when the price is raised",
+			oldPrice: 80000,
+			newPrice: 100000,
+		},
+		{
+			name:         "should compare against an active discount",
+			oldPrice:     100000,
+			newPrice:     60000,
+			discount:     &entity.EbookDiscount{DiscountPrice: 70000},
+			wantNotified: true,
+			wantOld:      70000,
+			wantNew:      60000,
+		},
+		{
+			name:     "should not notify when a cheaper discount still applies",
+			oldPrice: 100000,
+			newPrice: 80000,
+			discount: &entity.EbookDiscount{DiscountPrice: 50000},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockEbookService{
+				ebook: &entity.Ebook{ID: "ebook-1", Slug: "ebook", Price: tt.oldPrice},
+			}
+			notificationService := &MockNotificationService{}
+			usecase := NewEbookUsecase(mockService, &MockEbookDiscountService{discount: tt.discount}, notificationService, &MockSeriesService{}, &MockTrendingService{})
+
+			err := usecase.UpdateEbook(context.Background(), &entity.Ebook{
+				ID:         "ebook-1",
+				Title:      "Ebook",
+				AuthorID:   "author-1",
+				CategoryID: "category-1",
+				Slug:       "ebook",
+				Price:      tt.newPrice,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !tt.wantNotified {
+				if len(notificationService.drops) != 0 {
+					t.Errorf("expected no notification, got %+v", notificationService.drops[0])
+				}
+				return
+			}
+			if len(notificationService.drops) != 1 {
+				t.Fatalf("expected one notification, got %d", len(notificationService.drops))
+			}
+			drop := notificationService.drops[0]
+			if drop.Reason != entity.PriceDropPriceChange || drop.OldPrice != tt.wantOld || drop.NewPrice != tt.wantNew {
+				t.Errorf("unexpected price drop %+v", drop)
+			}
+		})
+	}
+}
+
 func TestEbookUsecase_DeleteEbook(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -898,7 +1132,7 @@ func TestEbookUsecase_DeleteEbook(t *testing.T) {
 				discount: tt.mockDiscount,
 				err:       tt.mockError,
 			}
-			usecase := NewEbookUsecase(mockRepo, mockRepoDiscount)
+			usecase := NewEbookUsecase(mockRepo, mockRepoDiscount, &MockNotificationService{}, &MockSeriesService{}, &MockTrendingService{})
 			ctx := context.Background()
 
 			// Act
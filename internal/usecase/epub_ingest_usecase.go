@@ -0,0 +1,16 @@
+package usecase
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+	"io"
+)
+
+// EpubIngestUsecase defines the interface for turning uploaded EPUB files into ebook drafts
+type EpubIngestUsecase interface {
+	// IngestEpub reads the EPUB's metadata and navigation into a draft. The author is looked up by name
+	// and created when missing; nothing else is saved.
+	IngestEpub(ctx context.Context, file io.ReaderAt, size int64) (*entity.EbookDraft, error)
+	// ImportPages replaces the reader pages of an existing ebook with the EPUB's spine items, in reading order
+	ImportPages(ctx context.Context, ebookID string, file io.ReaderAt, size int64) ([]*entity.EbookPage, error)
+}
@@ -0,0 +1,290 @@
+package usecase
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/service"
+	"buku-pintar/pkg/epub"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// maxEpubMetadataLength matches the ebook title and author name columns
+	maxEpubMetadataLength = 255
+	// defaultEpubPageContentType is assumed for spine items whose manifest entry has no media type
+	defaultEpubPageContentType = "application/xhtml+xml"
+	// maxImportedPages and maxImportedContentSize bound the pages held in memory while importing,
+	// since each spine document is decompressed in full
+	maxImportedPages       = 5000
+	maxImportedContentSize = 64 << 20
+)
+
+// InvalidEpubError is returned when an uploaded file cannot be read as an EPUB
+type InvalidEpubError struct {
+	Problems []string
+}
+
+func (e *InvalidEpubError) Error() string {
+	return constant.ERR_EPUB_INVALID
+}
+
+type epubIngestUsecase struct {
+	authorService service.AuthorService
+	ebookService  service.EbookService
+	pageService   service.EbookPageService
+}
+
+func NewEpubIngestUsecase(
+	authorService service.AuthorService,
+	ebookService service.EbookService,
+	pageService service.EbookPageService,
+) EpubIngestUsecase {
+	return &epubIngestUsecase{
+		authorService: authorService,
+		ebookService:  ebookService,
+		pageService:   pageService,
+	}
+}
+
+func (u *epubIngestUsecase) IngestEpub(ctx context.Context, file io.ReaderAt, size int64) (*entity.EbookDraft, error) {
+	book, err := readEpub(file, size)
+	if err != nil {
+		return nil, err
+	}
+
+	// EPUBs reflow, so every content document in the reading order counts as one page
+	pageCount := len(book.Spine)
+	if pageCount > math.MaxInt16 {
+		pageCount = math.MaxInt16
+	}
+
+	draft := &entity.EbookDraft{
+		Ebook: &entity.Ebook{
+			Title:     truncateRunes(book.Title, maxEpubMetadataLength),
+			Synopsis:  book.Description,
+			Language:  book.Language,
+			Filesize:  size,
+			Format:    entity.FormatEPUB,
+			PageCount: int16(pageCount),
+		},
+		Warnings: book.Warnings,
+	}
+
+	if len(book.Creators) > 0 {
+		author, created, err := u.findOrCreateAuthor(ctx, book.Creators[0])
+		if err != nil {
+			return nil, err
+		}
+		draft.Author = author
+		draft.AuthorCreated = created
+		draft.Ebook.AuthorID = author.ID
+		if len(book.Creators) > 1 {
+			draft.Warnings = append(draft.Warnings, fmt.Sprintf("only the first creator, %s, was used as the author", author.Name))
+		}
+	} else {
+		draft.Warnings = append(draft.Warnings, "package metadata has no dc:creator, choose the author by hand")
+	}
+
+	draft.TableOfContents, draft.Warnings = buildDraftTableOfContents(book, draft.Warnings)
+	return draft, nil
+}
+
+func (u *epubIngestUsecase) ImportPages(ctx context.Context, ebookID string, file io.ReaderAt, size int64) ([]*entity.EbookPage, error) {
+	if ebookID == "" {
+		return nil, &ValidationError{Message: constant.EBOOK_ID_REQUIRED}
+	}
+
+	ebook, err := u.ebookService.GetEbookByID(ctx, ebookID)
+	if err != nil {
+		return nil, err
+	}
+	if ebook == nil {
+		return nil, ErrEbookNotFound
+	}
+
+	book, err := readEpub(file, size)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(book.Spine) > maxImportedPages {
+		return nil, &ValidationError{Message: constant.ERR_EPUB_TOO_MANY_PAGES}
+	}
+
+	// Pages are numbered like the table of contents of the draft: one per spine item
+	titles := spineTitles(book.TOC)
+	pages := make([]*entity.EbookPage, 0, len(book.Spine))
+	var total int64
+	for i, href := range book.Spine {
+		content, contentType, err := book.ReadContent(href)
+		if err != nil {
+			return nil, &InvalidEpubError{Problems: []string{err.Error()}}
+		}
+		total += int64(len(content))
+		if total > maxImportedContentSize {
+			return nil, &ValidationError{Message: constant.ERR_EPUB_CONTENT_TOO_LARGE}
+		}
+		if contentType == "" {
+			contentType = defaultEpubPageContentType
+		}
+
+		page := &entity.EbookPage{
+			ID:          uuid.New().String(),
+			EbookID:     ebook.ID,
+			PageNumber:  i + 1,
+			ContentType: contentType,
+			Content:     string(content),
+		}
+		if title, ok := titles[href]; ok {
+			title = truncateRunes(title, maxTableOfContentTitle)
+			page.Title = &title
+		}
+		pages = append(pages, page)
+	}
+
+	if err := u.pageService.ReplacePages(ctx, ebook.ID, pages); err != nil {
+		return nil, err
+	}
+	return pages, nil
+}
+
+func readEpub(file io.ReaderAt, size int64) (*epub.Book, error) {
+	book, err := epub.Read(file, size)
+	if err != nil {
+		var validationErr *epub.ValidationError
+		if errors.As(err, &validationErr) {
+			return nil, &InvalidEpubError{Problems: validationErr.Problems}
+		}
+		return nil, err
+	}
+	return book, nil
+}
+
+// spineTitles maps each content document to the title of the first table of contents entry pointing at it
+func spineTitles(points []*epub.NavPoint) map[string]string {
+	titles := map[string]string{}
+	var walk func(points []*epub.NavPoint)
+	walk = func(points []*epub.NavPoint) {
+		for _, point := range points {
+			if _, ok := titles[point.Href]; !ok && point.Href != "" && point.Title != "" {
+				titles[point.Href] = point.Title
+			}
+			walk(point.Children)
+		}
+	}
+	walk(points)
+	return titles
+}
+
+func (u *epubIngestUsecase) findOrCreateAuthor(ctx context.Context, name string) (*entity.Author, bool, error) {
+	name = truncateRunes(name, maxEpubMetadataLength)
+	author, err := u.authorService.GetAuthorByName(ctx, name)
+	if err != nil {
+		return nil, false, err
+	}
+	if author != nil {
+		return author, false, nil
+	}
+
+	author = &entity.Author{
+		ID:   uuid.New().String(),
+		Name: name,
+	}
+	if err := u.authorService.CreateAuthor(ctx, author); err != nil {
+		return nil, false, err
+	}
+	return author, true, nil
+}
+
+// buildDraftTableOfContents converts the EPUB navigation into entries that pass table of contents validation.
+// Entries point at the spine position of their content document; headings without a link take the page of their
+// first linked sub-chapter. Untitled entries, levels beyond the depth limit and entries beyond the count limit are
+// dropped with a warning instead of failing the upload.
+func buildDraftTableOfContents(book *epub.Book, warnings []string) ([]*entity.TableOfContent, []string) {
+	var firstPage func(point *epub.NavPoint) int
+	firstPage = func(point *epub.NavPoint) int {
+		if page := book.SpinePosition(point.Href); page > 0 {
+			return page
+		}
+		for _, child := range point.Children {
+			if page := firstPage(child); page > 0 {
+				return page
+			}
+		}
+		return 0
+	}
+
+	count := 0
+	lastPage := 1
+	var droppedUntitled, droppedDeep, droppedExtra, unresolved bool
+	var walk func(points []*epub.NavPoint, depth int) []*entity.TableOfContent
+	walk = func(points []*epub.NavPoint, depth int) []*entity.TableOfContent {
+		var entries []*entity.TableOfContent
+		for _, point := range points {
+			if point.Title == "" {
+				droppedUntitled = true
+				continue
+			}
+			if count >= maxTableOfContentEntries {
+				droppedExtra = true
+				break
+			}
+			count++
+
+			if page := firstPage(point); page > 0 {
+				lastPage = page
+			} else if point.Href != "" {
+				unresolved = true
+			}
+			entry := &entity.TableOfContent{
+				Title:      truncateRunes(point.Title, maxTableOfContentTitle),
+				PageNumber: int16(lastPage),
+				Depth:      depth,
+			}
+			if len(point.Children) > 0 {
+				if depth+1 < maxTableOfContentDepth {
+					entry.Children = walk(point.Children, depth+1)
+				} else {
+					droppedDeep = true
+				}
+			}
+			entries = append(entries, entry)
+		}
+		return entries
+	}
+	entries := walk(book.TOC, 0)
+
+	if droppedUntitled {
+		warnings = append(warnings, "table of contents entries without a title were left out")
+	}
+	if droppedDeep {
+		warnings = append(warnings, fmt.Sprintf("table of contents levels deeper than %d were left out", maxTableOfContentDepth))
+	}
+	if droppedExtra {
+		warnings = append(warnings, fmt.Sprintf("only the first %d table of contents entries were kept", maxTableOfContentEntries))
+	}
+	if unresolved {
+		warnings = append(warnings, "some table of contents entries link outside the reading order and use the previous entry's page")
+	}
+
+	if entries == nil {
+		entries = []*entity.TableOfContent{}
+	}
+	return entries, warnings
+}
+
+func truncateRunes(s string, max int) string {
+	s = strings.TrimSpace(s)
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return strings.TrimSpace(string(runes[:max]))
+}
@@ -0,0 +1,236 @@
+package usecase
+
+import (
+	"archive/zip"
+	"buku-pintar/internal/domain/entity"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// MockAuthorService keeps authors by name
+type MockAuthorService struct {
+	authors map[string]*entity.Author
+}
+
+func (m *MockAuthorService) CreateAuthor(ctx context.Context, author *entity.Author) error {
+	m.authors[author.Name] = author
+	return nil
+}
+
+func (m *MockAuthorService) GetAuthorByID(ctx context.Context, id string) (*entity.Author, error) {
+	for _, author := range m.authors {
+		if author.ID == id {
+			return author, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockAuthorService) GetAuthorByName(ctx context.Context, name string) (*entity.Author, error) {
+	return m.authors[name], nil
+}
+
+func buildTestEpub(t *testing.T, creators, nav string) *bytes.Reader {
+	t.Helper()
+
+	files := []struct{ name, content string }{
+		{"mimetype", "application/epub+zip"},
+		{"META-INF/container.xml", `<container><rootfiles><rootfile full-path="content.opf" media-type="application/oebps-package+xml"/></rootfiles></container>`},
+		{"content.opf", `<package version="3.0"><metadata><title>Ronggeng Dukuh Paruk</title><language>id</language>` + creators + `</metadata>
+			<manifest><item id="nav" href="nav.xhtml" properties="nav"/><item id="c1" href="c1.xhtml"/><item id="c2" href="c2.xhtml"/><item id="c3" href="c3.xhtml"/></manifest>
+			<spine><itemref idref="c1"/><itemref idref="c2"/><itemref idref="c3"/></spine></package>`},
+		{"nav.xhtml", `<html><body><nav type="toc">` + nav + `</nav></body></html>`},
+		{"c1.xhtml", "<html/>"},
+		{"c2.xhtml", "<html><p>Srintil</p></html>"},
+		{"c3.xhtml", "<html/>"},
+	}
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, file := range files {
+		f, err := w.Create(file.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write([]byte(file.content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return bytes.NewReader(buf.Bytes())
+}
+
+// buildSpineEpub builds an EPUB whose spine lists documents content documents of documentSize bytes each
+func buildSpineEpub(t *testing.T, documents, documentSize int) *bytes.Reader {
+	t.Helper()
+
+	var manifest, spine strings.Builder
+	for i := 1; i <= documents; i++ {
+		fmt.Fprintf(&manifest, `<item id="c%d" href="c%d.xhtml"/>`, i, i)
+		fmt.Fprintf(&spine, `<itemref idref="c%d"/>`, i)
+	}
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	write := func(name string, content []byte) {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write(content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("mimetype", []byte("application/epub+zip"))
+	write("META-INF/container.xml", []byte(`<container><rootfiles><rootfile full-path="content.opf" media-type="application/oebps-package+xml"/></rootfiles></container>`))
+	write("content.opf", []byte(`<package version="3.0"><metadata><title>Ronggeng Dukuh Paruk</title><language>id</language></metadata>
+		<manifest>`+manifest.String()+`</manifest><spine>`+spine.String()+`</spine></package>`))
+	content := bytes.Repeat([]byte("a"), documentSize)
+	for i := 1; i <= documents; i++ {
+		write(fmt.Sprintf("c%d.xhtml", i), content)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestEpubIngestUsecase_IngestEpub(t *testing.T) {
+	existing := &entity.Author{ID: "author-1", Name: "Ahmad Tohari"}
+	authorService := &MockAuthorService{authors: map[string]*entity.Author{existing.Name: existing}}
+	u := NewEpubIngestUsecase(authorService, &MockEbookService{}, &MockEbookPageService{})
+
+	file := buildTestEpub(t, "<creator>Ahmad Tohari</creator>", `<ol>
+		<li><span>Buku Satu</span><ol>
+			<li><a href="c2.xhtml">Bab 1</a></li>
+			<li><a href="missing.xhtml">Bab 2</a></li>
+		</ol></li>
+		<li><a href="c3.xhtml#end">Penutup</a></li>
+		<li><a href="c1.xhtml"></a></li>
+	</ol>`)
+
+	draft, err := u.IngestEpub(context.Background(), file, file.Size())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ebook := draft.Ebook
+	if ebook.Title != "Ronggeng Dukuh Paruk" || ebook.Language != "id" || ebook.Format != entity.FormatEPUB || ebook.PageCount != 3 || ebook.Filesize != file.Size() {
+		t.Errorf("unexpected draft %+v", ebook)
+	}
+	if ebook.AuthorID != existing.ID || draft.AuthorCreated {
+		t.Errorf("expected the existing author to be reused, got %q created=%v", ebook.AuthorID, draft.AuthorCreated)
+	}
+
+	// The heading takes its first chapter's page, the unresolved link keeps the previous page
+	// and the untitled entry is dropped
+	toc := draft.TableOfContents
+	if len(toc) != 2 || len(toc[0].Children) != 2 {
+		t.Fatalf("unexpected tree shape %+v", toc)
+	}
+	pages := []int16{toc[0].PageNumber, toc[0].Children[0].PageNumber, toc[0].Children[1].PageNumber, toc[1].PageNumber}
+	if want := []int16{2, 2, 2, 3}; pages[0] != want[0] || pages[1] != want[1] || pages[2] != want[2] || pages[3] != want[3] {
+		t.Errorf("pages = %v, want %v", pages, want)
+	}
+	if len(draft.Warnings) != 2 {
+		t.Errorf("expected warnings for the untitled and unresolved entries, got %v", draft.Warnings)
+	}
+}
+
+func TestEpubIngestUsecase_IngestEpub_CreatesAuthor(t *testing.T) {
+	authorService := &MockAuthorService{authors: map[string]*entity.Author{}}
+	u := NewEpubIngestUsecase(authorService, &MockEbookService{}, &MockEbookPageService{})
+
+	file := buildTestEpub(t, "<creator> Pramoedya Ananta Toer </creator><creator>Translator</creator>", "")
+	draft, err := u.IngestEpub(context.Background(), file, file.Size())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	author := authorService.authors["Pramoedya Ananta Toer"]
+	if author == nil || !draft.AuthorCreated || draft.Ebook.AuthorID != author.ID {
+		t.Errorf("expected a new author to be created and linked, got %+v", draft.Author)
+	}
+	if len(draft.TableOfContents) != 0 {
+		t.Errorf("expected an empty table of contents, got %+v", draft.TableOfContents)
+	}
+}
+
+func TestEpubIngestUsecase_IngestEpub_Invalid(t *testing.T) {
+	u := NewEpubIngestUsecase(&MockAuthorService{authors: map[string]*entity.Author{}}, &MockEbookService{}, &MockEbookPageService{})
+
+	file := bytes.NewReader([]byte("not an epub"))
+	_, err := u.IngestEpub(context.Background(), file, file.Size())
+
+	var invalidErr *InvalidEpubError
+	if !errors.As(err, &invalidErr) || len(invalidErr.Problems) == 0 {
+		t.Errorf("expected invalid EPUB error with problems, got %v", err)
+	}
+}
+
+func TestEpubIngestUsecase_ImportPages(t *testing.T) {
+	ctx := context.Background()
+	publishedAt := time.Now().Add(-time.Hour)
+	ebook := &entity.Ebook{ID: "ebook-1", Title: "Ronggeng Dukuh Paruk", Price: 50000, PreviewPage: 2, ContentStatus: entity.ContentStatusPublished, PublishedAt: &publishedAt}
+	ebookService := &MockEbookService{ebook: ebook}
+	pageService := &MockEbookPageService{pages: map[int]*entity.EbookPage{9: {PageNumber: 9}}}
+	u := NewEpubIngestUsecase(&MockAuthorService{authors: map[string]*entity.Author{}}, ebookService, pageService)
+
+	file := buildTestEpub(t, "", `<ol><li><a href="c2.xhtml">Bab 1</a></li><li><a href="c2.xhtml#s2">Bab 1 lanjutan</a></li></ol>`)
+	pages, err := u.ImportPages(ctx, "ebook-1", file, file.Size())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pages) != 3 || len(pageService.pages) != 3 {
+		t.Fatalf("expected the 3 spine items to replace the old pages, got %d stored", len(pageService.pages))
+	}
+
+	// The imported pages are what the reader serves
+	reader := NewEbookReaderUsecase(ebookService, pageService, &MockEntitlementService{}, &MockEbookDiscountService{}, &MockTrendingService{})
+	page, err := reader.ReadPage(ctx, nil, "ebook-1", 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if page.Content != "<html><p>Srintil</p></html>" || page.ContentType != "application/xhtml+xml" || page.Title != "Bab 1" || page.TotalPages != 3 {
+		t.Errorf("unexpected page %+v", page)
+	}
+
+	t.Run("should not import into an unknown ebook", func(t *testing.T) {
+		u := NewEpubIngestUsecase(&MockAuthorService{}, &MockEbookService{}, &MockEbookPageService{})
+		if _, err := u.ImportPages(ctx, "missing", file, file.Size()); !errors.Is(err, ErrEbookNotFound) {
+			t.Errorf("expected ErrEbookNotFound, got %v", err)
+		}
+	})
+
+	t.Run("should keep the old pages when the file is not an EPUB", func(t *testing.T) {
+		invalid := bytes.NewReader([]byte("not an epub"))
+		var invalidErr *InvalidEpubError
+		if _, err := u.ImportPages(ctx, "ebook-1", invalid, invalid.Size()); !errors.As(err, &invalidErr) || len(pageService.pages) != 3 {
+			t.Errorf("expected invalid EPUB error and untouched pages, got %v", err)
+		}
+	})
+
+	t.Run("should refuse a spine with too many documents", func(t *testing.T) {
+		long := buildSpineEpub(t, maxImportedPages+1, 16)
+		var validationErr *ValidationError
+		if _, err := u.ImportPages(ctx, "ebook-1", long, long.Size()); !errors.As(err, &validationErr) || len(pageService.pages) != 3 {
+			t.Errorf("expected a validation error and untouched pages, got %v", err)
+		}
+	})
+
+	t.Run("should refuse content larger than the import limit", func(t *testing.T) {
+		documentSize := 8 << 20
+		large := buildSpineEpub(t, maxImportedContentSize/documentSize+1, documentSize)
+		var validationErr *ValidationError
+		if _, err := u.ImportPages(ctx, "ebook-1", large, large.Size()); !errors.As(err, &validationErr) || len(pageService.pages) != 3 {
+			t.Errorf("expected a validation error and untouched pages, got %v", err)
+		}
+	})
+}
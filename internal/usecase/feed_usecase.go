@@ -0,0 +1,13 @@
+package usecase
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// FeedUsecase defines the interface for the RSS and Atom feeds of published content
+type FeedUsecase interface {
+	// GetFeed returns the newest published content of a kind, optionally of a single active
+	// category or an author
+	GetFeed(ctx context.Context, content entity.FeedContent, filter *entity.FeedFilter) (*entity.Feed, error)
+}
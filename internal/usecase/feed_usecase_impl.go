@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/service"
+	"context"
+	"errors"
+)
+
+var (
+	ErrFeedNotFound         = errors.New(constant.ERR_FEED_NOT_FOUND)
+	ErrFeedCategoryNotFound = errors.New(constant.ERR_FEED_CATEGORY_NOT_FOUND)
+	ErrFeedAuthorNotFound   = errors.New(constant.ERR_FEED_AUTHOR_NOT_FOUND)
+)
+
+type feedUsecase struct {
+	feedService     service.FeedService
+	ebookService    service.EbookService
+	categoryService service.CategoryService
+	authorService   service.AuthorService
+	items           int
+}
+
+// NewFeedUsecase creates the feed usecase; every feed lists the newest items published
+func NewFeedUsecase(
+	feedService service.FeedService,
+	ebookService service.EbookService,
+	categoryService service.CategoryService,
+	authorService service.AuthorService,
+	items int,
+) FeedUsecase {
+	return &feedUsecase{
+		feedService:     feedService,
+		ebookService:    ebookService,
+		categoryService: categoryService,
+		authorService:   authorService,
+		items:           items,
+	}
+}
+
+func (u *feedUsecase) GetFeed(ctx context.Context, content entity.FeedContent, filter *entity.FeedFilter) (*entity.Feed, error) {
+	if !content.IsValid() {
+		return nil, ErrFeedNotFound
+	}
+	if filter == nil {
+		filter = &entity.FeedFilter{}
+	}
+
+	feed := &entity.Feed{Content: content}
+	if filter.CategoryID != "" {
+		category, err := u.categoryService.GetCategoryByID(ctx, filter.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+		if category == nil || !category.IsActive {
+			return nil, ErrFeedCategoryNotFound
+		}
+		feed.Category = category
+	}
+	if filter.AuthorID != "" {
+		author, err := u.authorService.GetAuthorByID(ctx, filter.AuthorID)
+		if err != nil {
+			return nil, err
+		}
+		if author == nil {
+			return nil, ErrFeedAuthorNotFound
+		}
+		feed.Author = author
+	}
+
+	var err error
+	if content == entity.FeedEbooks {
+		feed.Items, err = u.listEbooks(ctx, filter)
+	} else {
+		feed.Items, err = u.feedService.GetFeedItems(ctx, content, filter, u.items)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return feed, nil
+}
+
+// listEbooks lists the newest ebooks as feed items, with the synopsis as the excerpt
+func (u *feedUsecase) listEbooks(ctx context.Context, filter *entity.FeedFilter) ([]*entity.FeedItem, error) {
+	ebooks, err := u.ebookService.GetEbookFeedEntries(ctx, &entity.EbookFilter{
+		CategoryID: filter.CategoryID,
+		AuthorID:   filter.AuthorID,
+		Sort:       entity.EbookSortNewest,
+	}, u.items, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*entity.FeedItem, 0, len(ebooks))
+	for _, ebook := range ebooks {
+		item := &entity.FeedItem{
+			ID:           ebook.ID,
+			Title:        ebook.Title,
+			Slug:         ebook.Slug,
+			Excerpt:      ebook.Synopsis,
+			CoverImage:   ebook.CoverImage,
+			AuthorID:     ebook.AuthorID,
+			AuthorName:   ebook.AuthorName,
+			CategoryID:   ebook.CategoryID,
+			CategoryName: ebook.CategoryName,
+			UpdatedAt:    ebook.UpdatedAt,
+		}
+		if ebook.PublishedAt != nil {
+			item.PublishedAt = *ebook.PublishedAt
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// MockFeedService records the content and filter of the last feed read
+type MockFeedService struct {
+	items   []*entity.FeedItem
+	content entity.FeedContent
+	limit   int
+}
+
+func (m *MockFeedService) GetFeedItems(ctx context.Context, content entity.FeedContent, filter *entity.FeedFilter, limit int) ([]*entity.FeedItem, error) {
+	m.content = content
+	m.limit = limit
+	return m.items, nil
+}
+
+func TestFeedUsecase_GetFeed(t *testing.T) {
+	ctx := context.Background()
+	published := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+
+	var received *entity.EbookFilter
+	ebookService := &MockEbookService{
+		feedFunc: func(ctx context.Context, filter *entity.EbookFilter, limit, offset int) ([]*entity.EbookFeedEntry, error) {
+			received = filter
+			return []*entity.EbookFeedEntry{
+				{ID: "ebook-1", Title: "Laskar Pelangi", Synopsis: "Sepuluh anak Belitung", AuthorID: "author-1", PublishedAt: &published, UpdatedAt: published},
+			}, nil
+		},
+	}
+	feedService := &MockFeedService{items: []*entity.FeedItem{{ID: "article-1", UpdatedAt: published}}}
+	authors := &MockAuthorService{authors: map[string]*entity.Author{"Andrea Hirata": {ID: "author-1", Name: "Andrea Hirata"}}}
+	u := NewFeedUsecase(feedService, ebookService, nil, authors, 20)
+
+	t.Run("should list new ebooks with their synopsis as the excerpt", func(t *testing.T) {
+		feed, err := u.GetFeed(ctx, entity.FeedEbooks, &entity.FeedFilter{AuthorID: "author-1"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if feed.Author == nil || feed.Author.Name != "Andrea Hirata" {
+			t.Errorf("expected the author of the feed, got %+v", feed.Author)
+		}
+		if len(feed.Items) != 1 || feed.Items[0].Excerpt != "Sepuluh anak Belitung" || !feed.Items[0].PublishedAt.Equal(published) {
+			t.Errorf("unexpected items %+v", feed.Items)
+		}
+		if received.AuthorID != "author-1" || received.Sort != entity.EbookSortNewest {
+			t.Errorf("expected the newest ebooks of the author, got %+v", received)
+		}
+	})
+
+	t.Run("should read articles and inspirations from the feed service", func(t *testing.T) {
+		feed, err := u.GetFeed(ctx, entity.FeedInspirations, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if feedService.content != entity.FeedInspirations || feedService.limit != 20 || !feed.Updated().Equal(published) {
+			t.Errorf("unexpected feed %+v from %s limited to %d", feed, feedService.content, feedService.limit)
+		}
+	})
+
+	t.Run("should reject unknown content and authors", func(t *testing.T) {
+		if _, err := u.GetFeed(ctx, "podcasts", nil); !errors.Is(err, ErrFeedNotFound) {
+			t.Errorf("expected ErrFeedNotFound, got %v", err)
+		}
+		if _, err := u.GetFeed(ctx, entity.FeedArticles, &entity.FeedFilter{AuthorID: "missing"}); !errors.Is(err, ErrFeedAuthorNotFound) {
+			t.Errorf("expected ErrFeedAuthorNotFound, got %v", err)
+		}
+	})
+}
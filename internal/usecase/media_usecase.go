@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+	"io"
+)
+
+// MediaUsecase defines the interface for uploading and serving media
+type MediaUsecase interface {
+	// UploadMedia checks the detected type and size of an upload for its kind, then stores it under its checksum.
+	// Content already uploaded as the same kind is not stored twice; the existing media is returned with created false.
+	UploadMedia(ctx context.Context, uploadedBy string, kind entity.MediaKind, filename string, file io.ReadSeeker, size int64) (media *entity.Media, created bool, err error)
+	GetMedia(ctx context.Context, id string) (*entity.Media, error)
+	// OpenPublicMedia opens public media by storage key; unknown keys and private media return nil
+	OpenPublicMedia(ctx context.Context, key string) (*entity.Media, io.ReadCloser, error)
+	// MediaURL is where clients fetch the media, or empty for private media
+	MediaURL(media *entity.Media) string
+	// ListImageVariants returns the resized variants of an image with their URLs
+	ListImageVariants(ctx context.Context, media *entity.Media) (entity.MediaVariants, error)
+
+	// GenerateImageVariants resizes a cover or banner to every variant size narrower than the original and
+	// stores each as JPEG and PNG, replacing earlier variants. Images that cannot be decoded with the
+	// standard library, such as WebP, return ErrImageVariantsUnsupported.
+	GenerateImageVariants(ctx context.Context, media *entity.Media) (entity.MediaVariants, error)
+	// BackfillImageVariants generates variants for covers and banners uploaded before variants existed,
+	// or for every cover and banner when force is set
+	BackfillImageVariants(ctx context.Context, force bool) (*ImageVariantBackfill, error)
+}
+
+// ImageVariantBackfill counts the images a backfill went through
+type ImageVariantBackfill struct {
+	Generated int // Images that got variants
+	Skipped   int // Images that already had variants or cannot be decoded
+	Failed    int // Images whose variants could not be generated; see the log
+}
@@ -0,0 +1,439 @@
+package usecase
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/service"
+	"buku-pintar/pkg/imaging"
+	"buku-pintar/pkg/storage"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// sniffLength is how much of an upload is read to detect its type, as much as http.DetectContentType considers
+const sniffLength = 512
+
+// maxMediaFilenameLength matches the filename column
+const maxMediaFilenameLength = 255
+
+// ErrMediaTooLarge is returned when an upload exceeds the size limit of its kind
+var ErrMediaTooLarge = errors.New(constant.ERR_MEDIA_TOO_LARGE)
+
+// UnsupportedMediaTypeError is returned when the detected type of an upload is not allowed for its kind
+type UnsupportedMediaTypeError struct {
+	ContentType string
+	Allowed     []string
+}
+
+func (e *UnsupportedMediaTypeError) Error() string {
+	return fmt.Sprintf("%s: detected %s, expected one of %s", constant.ERR_MEDIA_TYPE_NOT_ALLOWED, e.ContentType, strings.Join(e.Allowed, ", "))
+}
+
+const (
+	// maxVariantSourcePixels guards against images that are small files but decode to huge bitmaps
+	maxVariantSourcePixels = 50_000_000
+	variantJPEGQuality     = 85
+	// backfillPageSize is how many images a backfill loads at a time
+	backfillPageSize = 100
+)
+
+// ErrImageVariantsUnsupported is returned for images the standard library cannot decode, such as WebP
+var ErrImageVariantsUnsupported = errors.New("image format does not support variants")
+
+// mediaExtensions names stored objects after their detected type rather than the uploaded filename
+var mediaExtensions = map[string]string{
+	"image/jpeg":                     ".jpg",
+	"image/png":                      ".png",
+	"image/webp":                     ".webp",
+	"application/pdf":                ".pdf",
+	"application/epub+zip":           ".epub",
+	"application/x-mobipocket-ebook": ".mobi",
+	"audio/mpeg":                     ".mp3",
+	"audio/mp4":                      ".m4a",
+	"audio/ogg":                      ".ogg",
+	"audio/wav":                      ".wav",
+}
+
+type mediaUsecase struct {
+	mediaService service.MediaService
+}
+
+func NewMediaUsecase(mediaService service.MediaService) MediaUsecase {
+	return &mediaUsecase{
+		mediaService: mediaService,
+	}
+}
+
+func (u *mediaUsecase) UploadMedia(ctx context.Context, uploadedBy string, kind entity.MediaKind, filename string, file io.ReadSeeker, size int64) (*entity.Media, bool, error) {
+	if !kind.IsValid() {
+		return nil, false, &ValidationError{Message: constant.ERR_MEDIA_KIND_INVALID}
+	}
+	if size == 0 {
+		return nil, false, &ValidationError{Message: constant.ERR_MEDIA_EMPTY}
+	}
+	if size > kind.MaxSize() {
+		return nil, false, ErrMediaTooLarge
+	}
+
+	// The declared content type and extension are ignored; only the bytes decide what the file is
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, false, err
+	}
+	contentType := detectMediaType(head[:n])
+	allowed := kind.AllowedContentTypes()
+	if !slices.Contains(allowed, contentType) {
+		return nil, false, &UnsupportedMediaTypeError{ContentType: contentType, Allowed: allowed}
+	}
+
+	checksum, size, err := hashUpload(file, kind.MaxSize())
+	if err != nil {
+		return nil, false, err
+	}
+	if size == 0 {
+		return nil, false, &ValidationError{Message: constant.ERR_MEDIA_EMPTY}
+	}
+
+	existing, err := u.mediaService.GetMediaByChecksum(ctx, kind, checksum)
+	if err != nil {
+		return nil, false, err
+	}
+	if existing != nil {
+		return existing, false, nil
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, false, err
+	}
+
+	media := &entity.Media{
+		ID:          uuid.New().String(),
+		Kind:        kind,
+		StorageKey:  mediaStorageKey(kind, checksum, contentType),
+		Filename:    truncateRunes(filepath.Base(filepath.ToSlash(filename)), maxMediaFilenameLength),
+		ContentType: contentType,
+		Size:        size,
+		Checksum:    checksum,
+	}
+	if uploadedBy != "" {
+		media.UploadedBy = &uploadedBy
+	}
+
+	stored, err := u.mediaService.StoreMedia(ctx, media, file)
+	if err != nil {
+		return nil, false, err
+	}
+	created := stored.ID == media.ID
+
+	// The original is already stored, so failing variants only leave it for the backfill
+	if created && kind.HasImageVariants() {
+		if _, err := u.GenerateImageVariants(ctx, stored); err != nil && !errors.Is(err, ErrImageVariantsUnsupported) {
+			log.Printf("Failed to generate variants for media %s: %v", stored.ID, err)
+		}
+	}
+	return stored, created, nil
+}
+
+func (u *mediaUsecase) GetMedia(ctx context.Context, id string) (*entity.Media, error) {
+	if id == "" {
+		return nil, &ValidationError{Message: constant.ERR_ID_REQUIRED}
+	}
+	return u.mediaService.GetMediaByID(ctx, id)
+}
+
+func (u *mediaUsecase) OpenPublicMedia(ctx context.Context, key string) (*entity.Media, io.ReadCloser, error) {
+	media, err := u.mediaService.GetMediaByStorageKey(ctx, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	if media == nil {
+		media, err = u.variantAsMedia(ctx, key)
+	}
+	if err != nil || media == nil || !media.Kind.IsPublic() {
+		return nil, nil, err
+	}
+
+	body, err := u.mediaService.OpenMedia(ctx, media.StorageKey)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return media, body, nil
+}
+
+// variantAsMedia describes a stored variant as media of the same kind as its original
+func (u *mediaUsecase) variantAsMedia(ctx context.Context, key string) (*entity.Media, error) {
+	variant, err := u.mediaService.GetMediaVariantByStorageKey(ctx, key)
+	if err != nil || variant == nil {
+		return nil, err
+	}
+	original, err := u.mediaService.GetMediaByID(ctx, variant.MediaID)
+	if err != nil || original == nil {
+		return nil, err
+	}
+
+	return &entity.Media{
+		ID:          original.ID,
+		Kind:        original.Kind,
+		StorageKey:  variant.StorageKey,
+		Filename:    original.Filename,
+		ContentType: variant.ContentType(),
+		Size:        variant.Size,
+		Checksum:    variant.Checksum,
+		CreatedAt:   variant.CreatedAt,
+	}, nil
+}
+
+func (u *mediaUsecase) MediaURL(media *entity.Media) string {
+	if media == nil || !media.Kind.IsPublic() {
+		return ""
+	}
+	return u.mediaService.URL(media.StorageKey)
+}
+
+func (u *mediaUsecase) ListImageVariants(ctx context.Context, media *entity.Media) (entity.MediaVariants, error) {
+	if media == nil || !media.Kind.HasImageVariants() {
+		return nil, nil
+	}
+
+	stored, err := u.mediaService.ListMediaVariants(ctx, media.ID)
+	if err != nil {
+		return nil, err
+	}
+	variants := make(entity.MediaVariants, 0, len(stored))
+	for _, variant := range stored {
+		variants = append(variants, *variant)
+	}
+	variants.ResolveURLs(u.mediaService.URL)
+	return variants, nil
+}
+
+func (u *mediaUsecase) GenerateImageVariants(ctx context.Context, media *entity.Media) (entity.MediaVariants, error) {
+	if !media.Kind.HasImageVariants() {
+		return nil, nil
+	}
+
+	src, err := u.decodeImage(ctx, media)
+	if err != nil {
+		return nil, err
+	}
+
+	var variants entity.MediaVariants
+	for _, size := range entity.ImageVariantSizes {
+		if src.Bounds().Dx() <= size.Width {
+			break
+		}
+		width, height := imaging.Fit(src.Bounds(), size.Width)
+		resized := imaging.Resize(src, width, height)
+
+		for _, format := range entity.ImageVariantFormats {
+			variant := entity.MediaVariant{
+				MediaID:    media.ID,
+				Name:       size.Name,
+				Format:     format,
+				StorageKey: variantStorageKey(media, size.Name, format),
+				Width:      width,
+				Height:     height,
+			}
+			if err := u.storeVariant(ctx, &variant, resized); err != nil {
+				return nil, fmt.Errorf("%s %s: %w", size.Name, format, err)
+			}
+			variants = append(variants, variant)
+		}
+	}
+
+	variants.ResolveURLs(u.mediaService.URL)
+	return variants, nil
+}
+
+func (u *mediaUsecase) BackfillImageVariants(ctx context.Context, force bool) (*ImageVariantBackfill, error) {
+	result := &ImageVariantBackfill{}
+	for _, kind := range []entity.MediaKind{entity.MediaCover, entity.MediaBanner} {
+		afterID := ""
+		for {
+			page, err := u.mediaService.ListMediaByKind(ctx, kind, afterID, backfillPageSize)
+			if err != nil {
+				return result, err
+			}
+			for _, media := range page {
+				u.backfillImage(ctx, media, force, result)
+			}
+			if len(page) < backfillPageSize {
+				break
+			}
+			afterID = page[len(page)-1].ID
+		}
+	}
+	return result, nil
+}
+
+func (u *mediaUsecase) backfillImage(ctx context.Context, media *entity.Media, force bool, result *ImageVariantBackfill) {
+	if !force {
+		existing, err := u.mediaService.ListMediaVariants(ctx, media.ID)
+		if err != nil {
+			log.Printf("Failed to list variants of media %s: %v", media.ID, err)
+			result.Failed++
+			return
+		}
+		if len(existing) > 0 {
+			result.Skipped++
+			return
+		}
+	}
+
+	_, err := u.GenerateImageVariants(ctx, media)
+	switch {
+	case errors.Is(err, ErrImageVariantsUnsupported):
+		result.Skipped++
+	case err != nil:
+		log.Printf("Failed to generate variants for media %s: %v", media.ID, err)
+		result.Failed++
+	default:
+		result.Generated++
+	}
+}
+
+// decodeImage reads the stored original, refusing formats and sizes variants cannot be made from
+func (u *mediaUsecase) decodeImage(ctx context.Context, media *entity.Media) (image.Image, error) {
+	body, err := u.mediaService.OpenMedia(ctx, media.StorageKey)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(body, media.Kind.MaxSize()+1))
+	if err != nil {
+		return nil, err
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if errors.Is(err, image.ErrFormat) {
+		return nil, ErrImageVariantsUnsupported
+	}
+	if err != nil {
+		return nil, err
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > maxVariantSourcePixels {
+		return nil, fmt.Errorf("image of %dx%d pixels cannot be resized", config.Width, config.Height)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	return img, err
+}
+
+// storeVariant encodes the resized image in the variant's format and stores it
+func (u *mediaUsecase) storeVariant(ctx context.Context, variant *entity.MediaVariant, img image.Image) error {
+	var buf bytes.Buffer
+	var err error
+	switch variant.Format {
+	case "jpeg":
+		err = jpeg.Encode(&buf, imaging.Flatten(img, color.White), &jpeg.Options{Quality: variantJPEGQuality})
+	case "png":
+		err = png.Encode(&buf, img)
+	default:
+		err = fmt.Errorf("unknown variant format %s", variant.Format)
+	}
+	if err != nil {
+		return err
+	}
+
+	sum := sha256.Sum256(buf.Bytes())
+	variant.Checksum = hex.EncodeToString(sum[:])
+	variant.Size = int64(buf.Len())
+	return u.mediaService.StoreMediaVariant(ctx, variant, &buf)
+}
+
+// variantStorageKey keeps variants next to their original, e.g. covers/ab/ab12.../thumbnail.jpg
+func variantStorageKey(media *entity.Media, name, format string) string {
+	return media.Kind.Dir() + "/" + media.Checksum[:2] + "/" + media.Checksum + "/" + name + variantExtensions[format]
+}
+
+var variantExtensions = map[string]string{
+	"jpeg": ".jpg",
+	"png":  ".png",
+}
+
+// hashUpload reads the upload from the start and returns its hex SHA-256 and size, failing past maxSize
+func hashUpload(file io.ReadSeeker, maxSize int64) (string, int64, error) {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", 0, err
+	}
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, io.LimitReader(file, maxSize+1))
+	if err != nil {
+		return "", 0, err
+	}
+	if size > maxSize {
+		return "", 0, ErrMediaTooLarge
+	}
+	return hex.EncodeToString(hash.Sum(nil)), size, nil
+}
+
+// mediaStorageKey addresses media by content, fanned out by the first checksum byte to keep directories small
+func mediaStorageKey(kind entity.MediaKind, checksum, contentType string) string {
+	return kind.Dir() + "/" + checksum[:2] + "/" + checksum + mediaExtensions[contentType]
+}
+
+// detectMediaType identifies an upload from its first bytes, covering the ebook and audio containers
+// http.DetectContentType does not know or reports under a generic type
+func detectMediaType(head []byte) string {
+	if isEpub(head) {
+		return "application/epub+zip"
+	}
+	// MOBI files are Palm databases with their type and creator at offset 60
+	if len(head) >= 68 && string(head[60:68]) == "BOOKMOBI" {
+		return "application/x-mobipocket-ebook"
+	}
+
+	// MP4 audio is told apart from video by its major brand
+	if len(head) >= 12 && string(head[4:8]) == "ftyp" && string(head[8:12]) == "M4A " {
+		return "audio/mp4"
+	}
+
+	contentType, _, err := mime.ParseMediaType(http.DetectContentType(head))
+	if err != nil {
+		return "application/octet-stream"
+	}
+	switch contentType {
+	case "application/ogg":
+		return "audio/ogg"
+	case "audio/wave":
+		return "audio/wav"
+	}
+	return contentType
+}
+
+// isEpub reports whether the first ZIP entry is the uncompressed "mimetype" file every EPUB starts with
+func isEpub(head []byte) bool {
+	if len(head) < 30 || string(head[:4]) != "PK\x03\x04" {
+		return false
+	}
+	nameLength := int(binary.LittleEndian.Uint16(head[26:28]))
+	extraLength := int(binary.LittleEndian.Uint16(head[28:30]))
+	data := 30 + nameLength + extraLength
+	const mimetype = "application/epub+zip"
+	return string(head[30:min(30+nameLength, len(head))]) == "mimetype" &&
+		len(head) >= data+len(mimetype) && string(head[data:data+len(mimetype)]) == mimetype
+}
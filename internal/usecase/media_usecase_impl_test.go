@@ -0,0 +1,327 @@
+package usecase
+
+import (
+	"archive/zip"
+	"buku-pintar/internal/domain/entity"
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+)
+
+// MockMediaService keeps stored media, variants and their content in memory
+type MockMediaService struct {
+	media    map[string]*entity.Media
+	variants map[string]*entity.MediaVariant
+	content  map[string][]byte
+}
+
+func NewMockMediaService() *MockMediaService {
+	return &MockMediaService{
+		media:    map[string]*entity.Media{},
+		variants: map[string]*entity.MediaVariant{},
+		content:  map[string][]byte{},
+	}
+}
+
+func (m *MockMediaService) StoreMedia(ctx context.Context, media *entity.Media, body io.Reader) (*entity.Media, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	m.content[media.StorageKey] = data
+	m.media[media.ID] = media
+	return media, nil
+}
+
+func (m *MockMediaService) GetMediaByID(ctx context.Context, id string) (*entity.Media, error) {
+	return m.media[id], nil
+}
+
+func (m *MockMediaService) GetMediaByStorageKey(ctx context.Context, key string) (*entity.Media, error) {
+	for _, media := range m.media {
+		if media.StorageKey == key {
+			return media, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockMediaService) GetMediaByChecksum(ctx context.Context, kind entity.MediaKind, checksum string) (*entity.Media, error) {
+	for _, media := range m.media {
+		if media.Kind == kind && media.Checksum == checksum {
+			return media, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockMediaService) OpenMedia(ctx context.Context, key string) (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(m.content[key])), nil
+}
+
+func (m *MockMediaService) URL(key string) string {
+	return "https://cdn.test/" + key
+}
+
+func (m *MockMediaService) FileLocation(key string) (string, error) {
+	return key, nil
+}
+
+func (m *MockMediaService) ListMediaByKind(ctx context.Context, kind entity.MediaKind, afterID string, limit int) ([]*entity.Media, error) {
+	var media []*entity.Media
+	for _, item := range m.media {
+		if item.Kind == kind && item.ID > afterID {
+			media = append(media, item)
+		}
+	}
+	return media, nil
+}
+
+func (m *MockMediaService) StoreMediaVariant(ctx context.Context, variant *entity.MediaVariant, body io.Reader) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	m.content[variant.StorageKey] = data
+	m.variants[variant.StorageKey] = variant
+	return nil
+}
+
+func (m *MockMediaService) ListMediaVariants(ctx context.Context, mediaID string) ([]*entity.MediaVariant, error) {
+	var variants []*entity.MediaVariant
+	for _, variant := range m.variants {
+		if variant.MediaID == mediaID {
+			variants = append(variants, variant)
+		}
+	}
+	return variants, nil
+}
+
+func (m *MockMediaService) GetMediaVariantByStorageKey(ctx context.Context, key string) (*entity.MediaVariant, error) {
+	return m.variants[key], nil
+}
+
+// encodeTestPNG returns a decodable PNG of the given size
+func encodeTestPNG(width, height int) []byte {
+	var buf bytes.Buffer
+	png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height)))
+	return buf.Bytes()
+}
+
+var testPNG = encodeTestPNG(8, 8)
+
+func TestMediaUsecase_UploadMedia(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should store by checksum under the detected type and ignore the uploaded name", func(t *testing.T) {
+		mediaService := NewMockMediaService()
+		u := NewMediaUsecase(mediaService)
+
+		media, created, err := u.UploadMedia(ctx, "user-1", entity.MediaCover, "../../cover.exe", bytes.NewReader(testPNG), int64(len(testPNG)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !created {
+			t.Error("expected new media to be created")
+		}
+		if media.ContentType != "image/png" || media.Filename != "cover.exe" || *media.UploadedBy != "user-1" {
+			t.Errorf("unexpected media %+v", media)
+		}
+		if !strings.HasPrefix(media.StorageKey, "covers/"+media.Checksum[:2]+"/"+media.Checksum) || !strings.HasSuffix(media.StorageKey, ".png") {
+			t.Errorf("unexpected storage key %s", media.StorageKey)
+		}
+		if !bytes.Equal(mediaService.content[media.StorageKey], testPNG) {
+			t.Error("expected the whole file to be stored")
+		}
+		if u.MediaURL(media) != "https://cdn.test/"+media.StorageKey {
+			t.Errorf("unexpected URL %s", u.MediaURL(media))
+		}
+	})
+
+	t.Run("should return the existing media for repeated content", func(t *testing.T) {
+		mediaService := NewMockMediaService()
+		u := NewMediaUsecase(mediaService)
+
+		first, _, err := u.UploadMedia(ctx, "user-1", entity.MediaBanner, "a.png", bytes.NewReader(testPNG), int64(len(testPNG)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		second, created, err := u.UploadMedia(ctx, "user-2", entity.MediaBanner, "b.png", bytes.NewReader(testPNG), int64(len(testPNG)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if created || second.ID != first.ID {
+			t.Errorf("expected the first upload back, got %+v", second)
+		}
+	})
+
+	t.Run("should reject content not allowed for the kind", func(t *testing.T) {
+		u := NewMediaUsecase(NewMockMediaService())
+		pdf := []byte("%PDF-1.7\n%âãÏÓ\n")
+
+		_, _, err := u.UploadMedia(ctx, "user-1", entity.MediaCover, "cover.png", bytes.NewReader(pdf), int64(len(pdf)))
+		var typeErr *UnsupportedMediaTypeError
+		if !errors.As(err, &typeErr) || typeErr.ContentType != "application/pdf" {
+			t.Errorf("expected unsupported media type error, got %v", err)
+		}
+	})
+
+	t.Run("should reject uploads over the size limit of the kind", func(t *testing.T) {
+		u := NewMediaUsecase(NewMockMediaService())
+		icon := append(append([]byte{}, testPNG...), make([]byte, entity.MediaIcon.MaxSize())...)
+
+		_, _, err := u.UploadMedia(ctx, "user-1", entity.MediaIcon, "icon.png", bytes.NewReader(icon), int64(len(icon)))
+		if !errors.Is(err, ErrMediaTooLarge) {
+			t.Errorf("expected ErrMediaTooLarge, got %v", err)
+		}
+	})
+
+	t.Run("should reject an unknown kind and empty files", func(t *testing.T) {
+		u := NewMediaUsecase(NewMockMediaService())
+		var validationErr *ValidationError
+
+		_, _, err := u.UploadMedia(ctx, "user-1", entity.MediaKind("avatar"), "a.png", bytes.NewReader(testPNG), int64(len(testPNG)))
+		if !errors.As(err, &validationErr) {
+			t.Errorf("expected validation error for unknown kind, got %v", err)
+		}
+		_, _, err = u.UploadMedia(ctx, "user-1", entity.MediaCover, "a.png", bytes.NewReader(nil), 0)
+		if !errors.As(err, &validationErr) {
+			t.Errorf("expected validation error for empty file, got %v", err)
+		}
+	})
+}
+
+func TestMediaUsecase_ImageVariants(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should generate every variant narrower than the original in both formats", func(t *testing.T) {
+		mediaService := NewMockMediaService()
+		u := NewMediaUsecase(mediaService)
+		cover := encodeTestPNG(800, 1200)
+
+		media, _, err := u.UploadMedia(ctx, "user-1", entity.MediaCover, "cover.png", bytes.NewReader(cover), int64(len(cover)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		variants, err := u.ListImageVariants(ctx, media)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(variants) != 4 {
+			t.Fatalf("expected thumbnail and medium in JPEG and PNG, got %d variants", len(variants))
+		}
+		for _, variant := range variants {
+			if variant.Name == "large" {
+				t.Error("expected no variant wider than the original")
+			}
+			if variant.Name == "thumbnail" && (variant.Width != 200 || variant.Height != 300) {
+				t.Errorf("unexpected thumbnail size %dx%d", variant.Width, variant.Height)
+			}
+			if variant.URL != "https://cdn.test/"+variant.StorageKey || !strings.HasPrefix(variant.StorageKey, "covers/"+media.Checksum[:2]+"/"+media.Checksum+"/") {
+				t.Errorf("unexpected key %s or URL %s", variant.StorageKey, variant.URL)
+			}
+			if _, _, err := image.Decode(bytes.NewReader(mediaService.content[variant.StorageKey])); err != nil {
+				t.Errorf("stored %s %s does not decode: %v", variant.Name, variant.Format, err)
+			}
+		}
+
+		served, body, err := u.OpenPublicMedia(ctx, variants[0].StorageKey)
+		if err != nil || served == nil {
+			t.Fatalf("expected the variant to be served, got %v", err)
+		}
+		body.Close()
+		if served.Kind != entity.MediaCover || served.ContentType != variants[0].ContentType() {
+			t.Errorf("unexpected served media %+v", served)
+		}
+	})
+
+	t.Run("should not generate variants for other kinds or undecodable images", func(t *testing.T) {
+		u := NewMediaUsecase(NewMockMediaService())
+		icon := encodeTestPNG(400, 400)
+
+		media, _, err := u.UploadMedia(ctx, "user-1", entity.MediaIcon, "icon.png", bytes.NewReader(icon), int64(len(icon)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if variants, _ := u.GenerateImageVariants(ctx, media); variants != nil {
+			t.Errorf("expected no variants for icons, got %d", len(variants))
+		}
+
+		webp := []byte("RIFF\x24\x00\x00\x00WEBPVP8 ")
+		media, _, err = u.UploadMedia(ctx, "user-1", entity.MediaBanner, "banner.webp", bytes.NewReader(webp), int64(len(webp)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := u.GenerateImageVariants(ctx, media); !errors.Is(err, ErrImageVariantsUnsupported) {
+			t.Errorf("expected ErrImageVariantsUnsupported, got %v", err)
+		}
+	})
+
+	t.Run("should backfill images without variants and skip the rest", func(t *testing.T) {
+		mediaService := NewMockMediaService()
+		u := NewMediaUsecase(mediaService)
+		banner := encodeTestPNG(300, 100)
+		media, _, err := u.UploadMedia(ctx, "user-1", entity.MediaBanner, "banner.png", bytes.NewReader(banner), int64(len(banner)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// Drop the variants generated on upload, as if the image predates them
+		clear(mediaService.variants)
+		result, err := u.BackfillImageVariants(ctx, false)
+		if err != nil || result.Generated != 1 {
+			t.Fatalf("expected one image backfilled, got %+v, %v", result, err)
+		}
+		if variants, _ := mediaService.ListMediaVariants(ctx, media.ID); len(variants) != 2 {
+			t.Errorf("expected a JPEG and PNG thumbnail, got %d variants", len(variants))
+		}
+
+		result, err = u.BackfillImageVariants(ctx, false)
+		if err != nil || result.Skipped != 1 || result.Generated != 0 {
+			t.Errorf("expected the image to be skipped, got %+v, %v", result, err)
+		}
+	})
+}
+
+func TestDetectMediaType(t *testing.T) {
+	var epub bytes.Buffer
+	w := zip.NewWriter(&epub)
+	f, err := w.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Write([]byte("application/epub+zip"))
+	w.Close()
+
+	mobi := make([]byte, 80)
+	copy(mobi[60:], "BOOKMOBI")
+
+	tests := []struct {
+		name string
+		head []byte
+		want string
+	}{
+		{"epub", epub.Bytes(), "application/epub+zip"},
+		{"mobi", mobi, "application/x-mobipocket-ebook"},
+		{"m4a", []byte("\x00\x00\x00\x18ftypM4A \x00\x00\x00\x00M4A isom"), "audio/mp4"},
+		{"mp3", []byte("ID3\x04\x00\x00\x00\x00\x00\x00"), "audio/mpeg"},
+		{"ogg", []byte("OggS\x00\x02\x00\x00"), "audio/ogg"},
+		{"wav", []byte("RIFF\x24\x00\x00\x00WAVEfmt "), "audio/wav"},
+		{"png", testPNG, "image/png"},
+		{"svg is not an image upload", []byte(`<svg xmlns="http://www.w3.org/2000/svg"></svg>`), "text/plain"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectMediaType(tt.head); got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
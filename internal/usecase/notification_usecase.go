@@ -0,0 +1,12 @@
+package usecase
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// NotificationUsecase defines the interface for reading a user's notification events
+type NotificationUsecase interface {
+	ListNotifications(ctx context.Context, userID string, limit, offset int) ([]*entity.NotificationEvent, error)
+	CountNotifications(ctx context.Context, userID string) (int64, error)
+}
@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/service"
+	"context"
+)
+
+type notificationUsecase struct {
+	notificationService service.NotificationService
+}
+
+func NewNotificationUsecase(notificationService service.NotificationService) NotificationUsecase {
+	return &notificationUsecase{
+		notificationService: notificationService,
+	}
+}
+
+func (u *notificationUsecase) ListNotifications(ctx context.Context, userID string, limit, offset int) ([]*entity.NotificationEvent, error) {
+	// Validate pagination parameters
+	if limit <= 0 {
+		limit = 10 // default limit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return u.notificationService.GetNotifications(ctx, userID, limit, offset)
+}
+
+func (u *notificationUsecase) CountNotifications(ctx context.Context, userID string) (int64, error) {
+	return u.notificationService.GetNotificationCount(ctx, userID)
+}
@@ -0,0 +1,23 @@
+package usecase
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// OPDSEntry is a catalog entry together with whether the user may download it
+type OPDSEntry struct {
+	Ebook    *entity.EbookFeedEntry
+	Entitled bool // Free for a signed-in user, bought, or covered by premium access
+}
+
+// OPDSUsecase defines the interface for the OPDS catalog browsed from e-reader apps
+type OPDSUsecase interface {
+	// ListCategories returns a page of the active categories and their total count
+	ListCategories(ctx context.Context, limit, offset int) ([]*entity.Category, int64, error)
+	// GetCategory returns an active category
+	GetCategory(ctx context.Context, id string) (*entity.Category, error)
+	// ListEntries returns a page of the published ebooks matching the filter and their total count.
+	// The user may be nil for anonymous clients, who are not entitled to any download.
+	ListEntries(ctx context.Context, user *entity.User, filter *entity.EbookFilter, limit, offset int) ([]*OPDSEntry, int64, error)
+}
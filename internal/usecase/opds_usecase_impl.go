@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/service"
+	"context"
+	"errors"
+)
+
+var ErrOPDSCategoryNotFound = errors.New(constant.ERR_OPDS_CATEGORY_NOT_FOUND)
+
+type opdsUsecase struct {
+	ebookService       service.EbookService
+	categoryService    service.CategoryService
+	entitlementService service.EntitlementService
+}
+
+func NewOPDSUsecase(ebookService service.EbookService, categoryService service.CategoryService, entitlementService service.EntitlementService) OPDSUsecase {
+	return &opdsUsecase{
+		ebookService:       ebookService,
+		categoryService:    categoryService,
+		entitlementService: entitlementService,
+	}
+}
+
+func (u *opdsUsecase) ListCategories(ctx context.Context, limit, offset int) ([]*entity.Category, int64, error) {
+	categories, err := u.categoryService.GetActiveCategoryList(ctx, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := u.categoryService.GetActiveCategoryCount(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return categories, total, nil
+}
+
+func (u *opdsUsecase) GetCategory(ctx context.Context, id string) (*entity.Category, error) {
+	category, err := u.categoryService.GetCategoryByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if category == nil || !category.IsActive {
+		return nil, ErrOPDSCategoryNotFound
+	}
+
+	return category, nil
+}
+
+func (u *opdsUsecase) ListEntries(ctx context.Context, user *entity.User, filter *entity.EbookFilter, limit, offset int) ([]*OPDSEntry, int64, error) {
+	normalized, err := normalizeEbookFilter(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ebooks, err := u.ebookService.GetEbookFeedEntries(ctx, normalized, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := u.ebookService.GetEbookCount(ctx, normalized)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	entries := make([]*OPDSEntry, len(ebooks))
+	for i, ebook := range ebooks {
+		entries[i] = &OPDSEntry{Ebook: ebook}
+	}
+	if err := u.markEntitled(ctx, user, entries); err != nil {
+		return nil, 0, err
+	}
+
+	return entries, total, nil
+}
+
+// markEntitled applies the rules of EntitlementService.CanAccessEbook to a whole page,
+// looking up premium access once instead of for every paid ebook
+func (u *opdsUsecase) markEntitled(ctx context.Context, user *entity.User, entries []*OPDSEntry) error {
+	if user == nil || len(entries) == 0 {
+		return nil
+	}
+
+	premium, err := u.entitlementService.HasPremiumAccess(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if premium || entry.Ebook.Price == 0 {
+			entry.Entitled = true
+			continue
+		}
+		if entry.Entitled, err = u.entitlementService.OwnsEbook(ctx, user.ID, entry.Ebook.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
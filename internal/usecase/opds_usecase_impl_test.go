@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestOPDSUsecase_ListEntries(t *testing.T) {
+	ctx := context.Background()
+	ebooks := []*entity.EbookFeedEntry{
+		{ID: "free", Title: "Free Ebook", Price: 0},
+		{ID: "paid", Title: "Paid Ebook", Price: 50000},
+	}
+
+	var received *entity.EbookFilter
+	ebookService := &MockEbookService{
+		count: 2,
+		feedFunc: func(ctx context.Context, filter *entity.EbookFilter, limit, offset int) ([]*entity.EbookFeedEntry, error) {
+			received = filter
+			return ebooks, nil
+		},
+	}
+	entitlements := &MockEntitlementService{owners: map[string]bool{"owner": true}}
+	u := NewOPDSUsecase(ebookService, nil, entitlements)
+
+	entitled := func(entries []*OPDSEntry) map[string]bool {
+		result := map[string]bool{}
+		for _, entry := range entries {
+			result[entry.Ebook.ID] = entry.Entitled
+		}
+		return result
+	}
+
+	t.Run("should entitle owners to paid ebooks and signed-in users to free ones", func(t *testing.T) {
+		entries, total, err := u.ListEntries(ctx, &entity.User{ID: "owner"}, &entity.EbookFilter{Query: "  laskar   pelangi "}, 25, 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if total != 2 || !entitled(entries)["free"] || !entitled(entries)["paid"] {
+			t.Errorf("expected both ebooks to be entitled, got %v (total %d)", entitled(entries), total)
+		}
+		if received.Query != "laskar pelangi" || received.Sort != entity.EbookSortNewest {
+			t.Errorf("expected a normalized filter, got %+v", received)
+		}
+
+		entries, _, _ = u.ListEntries(ctx, &entity.User{ID: "reader"}, nil, 25, 0)
+		if got := entitled(entries); !got["free"] || got["paid"] {
+			t.Errorf("expected only the free ebook to be entitled, got %v", got)
+		}
+	})
+
+	t.Run("should not entitle anonymous clients", func(t *testing.T) {
+		entries, _, err := u.ListEntries(ctx, nil, nil, 25, 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := entitled(entries); got["free"] || got["paid"] {
+			t.Errorf("expected no entitled ebooks, got %v", got)
+		}
+	})
+
+	t.Run("should reject an overly long search", func(t *testing.T) {
+		filter := &entity.EbookFilter{Query: strings.Repeat("a", entity.MaxEbookQueryLength+1)}
+		var validationErr *ValidationError
+		if _, _, err := u.ListEntries(ctx, nil, filter, 25, 0); !errors.As(err, &validationErr) {
+			t.Errorf("expected validation error, got %v", err)
+		}
+	})
+}
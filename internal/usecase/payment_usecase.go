@@ -7,7 +7,11 @@ import (
 
 // PaymentUsecase defines the interface for payment use cases
 type PaymentUsecase interface {
-	InitiatePayment(ctx context.Context, userID string, amount int64, currency, description string) (*entity.Payment, error)
+	// InitiatePayment starts the purchase of a published ebook at its current price
+	InitiatePayment(ctx context.Context, userID, ebookID string) (*entity.Payment, error)
 	GetPayment(ctx context.Context, paymentID string) (*entity.Payment, error)
 	HandleXenditCallback(ctx context.Context, callbackData map[string]interface{}) error
+	ListPayments(ctx context.Context, userID string, limit, offset int) ([]*entity.Payment, error)
+	ListPaymentsByCursor(ctx context.Context, userID string, cursor *entity.PageCursor, limit int) ([]*entity.Payment, *entity.CursorPageInfo, error)
+	CountPayments(ctx context.Context, userID string) (int64, error)
 } 
\ No newline at end of file
@@ -1,36 +1,81 @@
 package usecase
 
 import (
+	"buku-pintar/internal/constant"
 	"buku-pintar/internal/domain/entity"
 	"buku-pintar/internal/domain/service"
 	"context"
 	"errors"
 	"fmt"
+	"log"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type paymentUsecase struct {
-	paymentService service.PaymentService
+	paymentService       service.PaymentService
+	ebookService         service.EbookService
+	ebookDiscountService service.EbookDiscountService
+	trendingService      service.TrendingService
 }
 
-func NewPaymentUsecase(paymentService service.PaymentService) PaymentUsecase {
+func NewPaymentUsecase(
+	paymentService service.PaymentService,
+	ebookService service.EbookService,
+	ebookDiscountService service.EbookDiscountService,
+	trendingService service.TrendingService,
+) PaymentUsecase {
 	return &paymentUsecase{
-		paymentService: paymentService,
+		paymentService:       paymentService,
+		ebookService:         ebookService,
+		ebookDiscountService: ebookDiscountService,
+		trendingService:      trendingService,
 	}
 }
 
-func (u *paymentUsecase) InitiatePayment(ctx context.Context, userID string, amount int64, currency, description string) (*entity.Payment, error) {
+// InitiatePayment charges the user the ebook's current price, or its active discount.
+// Paid payments grant ownership, so the amount never comes from the client.
+func (u *paymentUsecase) InitiatePayment(ctx context.Context, userID, ebookID string) (*entity.Payment, error) {
+	if userID == "" {
+		return nil, &ValidationError{Message: constant.ERR_ID_REQUIRED}
+	}
+	if ebookID == "" {
+		return nil, &ValidationError{Message: constant.EBOOK_ID_REQUIRED_VALIDATION}
+	}
+
+	ebook, err := u.ebookService.GetEbookByID(ctx, ebookID)
+	if err != nil {
+		return nil, err
+	}
+	// Unpublished ebooks cannot be bought, and are not revealed to exist
+	if ebook == nil || !ebook.IsPublished(time.Now()) {
+		return nil, ErrEbookNotFound
+	}
+
+	price := ebook.Price
+	discount, err := u.ebookDiscountService.GetActiveDiscountByEbookID(ctx, ebook.ID)
+	if err != nil {
+		return nil, err
+	}
+	if discount != nil {
+		price = discount.DiscountPrice
+	}
+	if price <= 0 {
+		return nil, &ValidationError{Message: constant.ERR_EBOOK_FREE}
+	}
+
 	payment := &entity.Payment{
 		ID:          uuid.New().String(),
 		UserID:      userID,
-		Amount:      amount,
-		Currency:    currency,
-		Description: description,
+		EbookID:     &ebook.ID,
+		Amount:      int64(price),
+		Currency:    constant.DEFAULT_CURRENCY,
+		Description: ebook.Title,
 		Status:      entity.PaymentStatusPending,
 	}
 
-	err := u.paymentService.InitiatePayment(ctx, payment)
+	err = u.paymentService.InitiatePayment(ctx, payment)
 	if err != nil {
 		return nil, err
 	}
@@ -71,5 +116,57 @@ func (u *paymentUsecase) HandleXenditCallback(ctx context.Context, callbackData
 		paymentStatus = entity.PaymentStatusFailed
 	}
 
-	return u.paymentService.UpdatePaymentStatus(ctx, payment.ID, paymentStatus)
-} 
\ No newline at end of file
+	if err := u.paymentService.UpdatePaymentStatus(ctx, payment.ID, paymentStatus); err != nil {
+		return err
+	}
+
+	// Xendit may repeat a callback, so only the change to paid counts as a purchase
+	if paymentStatus == entity.PaymentStatusPaid && payment.Status != entity.PaymentStatusPaid && payment.EbookID != nil {
+		u.recordPurchase(ctx, payment.UserID, *payment.EbookID)
+	}
+
+	return nil
+}
+
+func (u *paymentUsecase) recordPurchase(ctx context.Context, userID, ebookID string) {
+	ebook, err := u.ebookService.GetEbookByID(ctx, ebookID)
+	if err != nil || ebook == nil {
+		log.Printf("Failed to load ebook %s to record its purchase: %v", ebookID, err)
+		return
+	}
+
+	if err := u.trendingService.RecordEngagement(ctx, ebook.ID, ebook.CategoryID, entity.EngagementPurchase, userID); err != nil {
+		log.Printf("Failed to record ebook purchase: %v", err)
+	}
+}
+
+func (u *paymentUsecase) ListPayments(ctx context.Context, userID string, limit, offset int) ([]*entity.Payment, error) {
+	if userID == "" {
+		return nil, &ValidationError{Message: constant.ERR_ID_REQUIRED}
+	}
+
+	return u.paymentService.ListPaymentsByUserID(ctx, userID, limit, offset)
+}
+
+func (u *paymentUsecase) ListPaymentsByCursor(ctx context.Context, userID string, cursor *entity.PageCursor, limit int) ([]*entity.Payment, *entity.CursorPageInfo, error) {
+	if userID == "" {
+		return nil, nil, &ValidationError{Message: constant.ERR_ID_REQUIRED}
+	}
+	// Bind cursors to the buyer so one user's cursor cannot be replayed on another's list
+	filterHash := entity.CursorFilterHash("user=" + userID)
+	if err := checkCursor(cursor, entity.CursorSortPayments, filterHash); err != nil {
+		return nil, nil, err
+	}
+
+	payments, page, err := u.paymentService.ListPaymentsByUserIDCursor(ctx, userID, cursor, limit)
+	if err != nil {
+		return nil, nil, cursorValueError(err)
+	}
+	page.BindFilter(filterHash)
+
+	return payments, page, nil
+}
+
+func (u *paymentUsecase) CountPayments(ctx context.Context, userID string) (int64, error) {
+	return u.paymentService.CountPaymentsByUserID(ctx, userID)
+}
@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// MockPaymentService keeps initiated payments in memory and finds them by their Xendit reference
+type MockPaymentService struct {
+	payments []*entity.Payment
+	err      error
+}
+
+func (m *MockPaymentService) InitiatePayment(ctx context.Context, payment *entity.Payment) error {
+	m.payments = append(m.payments, payment)
+	return m.err
+}
+
+func (m *MockPaymentService) GetPaymentByID(ctx context.Context, id string) (*entity.Payment, error) {
+	return nil, m.err
+}
+
+func (m *MockPaymentService) GetPaymentByXenditReference(ctx context.Context, ref string) (*entity.Payment, error) {
+	for _, payment := range m.payments {
+		if payment.XenditReference == ref {
+			copied := *payment
+			return &copied, m.err
+		}
+	}
+	return nil, m.err
+}
+
+func (m *MockPaymentService) UpdatePaymentStatus(ctx context.Context, id string, status entity.PaymentStatus) error {
+	for _, payment := range m.payments {
+		if payment.ID == id {
+			payment.Status = status
+		}
+	}
+	return m.err
+}
+
+func (m *MockPaymentService) ListPaymentsByUserID(ctx context.Context, userID string, limit, offset int) ([]*entity.Payment, error) {
+	return m.payments, m.err
+}
+
+func (m *MockPaymentService) ListPaymentsByUserIDCursor(ctx context.Context, userID string, cursor *entity.PageCursor, limit int) ([]*entity.Payment, *entity.CursorPageInfo, error) {
+	return m.payments, &entity.CursorPageInfo{}, m.err
+}
+
+func (m *MockPaymentService) CountPaymentsByUserID(ctx context.Context, userID string) (int64, error) {
+	return int64(len(m.payments)), m.err
+}
+
+func TestPaymentUsecase_InitiatePayment(t *testing.T) {
+	ctx := context.Background()
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	published := &entity.Ebook{ID: "ebook-1", Title: "Paid Ebook", Price: 50000, ContentStatus: entity.ContentStatusPublished, PublishedAt: &past}
+	scheduled := &entity.Ebook{ID: "ebook-1", Title: "Paid Ebook", Price: 50000, ContentStatus: entity.ContentStatusPublished, PublishedAt: &future}
+	draft := &entity.Ebook{ID: "ebook-1", Title: "Paid Ebook", Price: 50000, ContentStatus: entity.ContentStatusDraft, PublishedAt: &past}
+	free := &entity.Ebook{ID: "ebook-1", Title: "Free Ebook", Price: 0, ContentStatus: entity.ContentStatusPublished, PublishedAt: &past}
+
+	tests := []struct {
+		name       string
+		ebook      *entity.Ebook
+		discount   *entity.EbookDiscount
+		wantAmount int64
+		wantErr    error
+	}{
+		{name: "published ebook at its price", ebook: published, wantAmount: 50000},
+		{name: "published ebook at its active discount", ebook: published, discount: &entity.EbookDiscount{DiscountPrice: 30000}, wantAmount: 30000},
+		{name: "unknown ebook", wantErr: ErrEbookNotFound},
+		{name: "ebook scheduled for later", ebook: scheduled, wantErr: ErrEbookNotFound},
+		{name: "draft ebook", ebook: draft, wantErr: ErrEbookNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paymentService := &MockPaymentService{}
+			u := NewPaymentUsecase(paymentService, &MockEbookService{ebook: tt.ebook}, &MockEbookDiscountService{discount: tt.discount}, &MockTrendingService{})
+
+			payment, err := u.InitiatePayment(ctx, "buyer", "ebook-1")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) || len(paymentService.payments) != 0 {
+					t.Fatalf("expected %v and no payment, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if payment.UserID != "buyer" || *payment.EbookID != "ebook-1" || payment.Amount != tt.wantAmount || payment.Currency != "IDR" {
+				t.Errorf("expected buyer to pay %d IDR for ebook-1, got %+v", tt.wantAmount, payment)
+			}
+		})
+	}
+
+	t.Run("should reject free ebooks", func(t *testing.T) {
+		u := NewPaymentUsecase(&MockPaymentService{}, &MockEbookService{ebook: free}, &MockEbookDiscountService{}, &MockTrendingService{})
+
+		var validationErr *ValidationError
+		if _, err := u.InitiatePayment(ctx, "buyer", "ebook-1"); !errors.As(err, &validationErr) {
+			t.Errorf("expected a validation error, got %v", err)
+		}
+	})
+}
+
+func TestPaymentUsecase_ListPaymentsByCursor(t *testing.T) {
+	ctx := context.Background()
+	u := NewPaymentUsecase(&MockPaymentService{}, &MockEbookService{}, &MockEbookDiscountService{}, &MockTrendingService{})
+	cursor := entity.NewTimeCursor(entity.CursorSortPayments, time.Now(), "payment-1")
+	cursor.Filter = entity.CursorFilterHash("user=buyer")
+
+	if _, _, err := u.ListPaymentsByCursor(ctx, "buyer", &cursor, 10); err != nil {
+		t.Errorf("expected the buyer's cursor to be accepted, got %v", err)
+	}
+	if _, _, err := u.ListPaymentsByCursor(ctx, "someone-else", &cursor, 10); !errors.Is(err, ErrCursorMismatch) {
+		t.Errorf("expected another user's cursor to be rejected, got %v", err)
+	}
+}
+
+func TestPaymentUsecase_HandleXenditCallback(t *testing.T) {
+	ctx := context.Background()
+	ebookID := "ebook-1"
+	paymentService := &MockPaymentService{payments: []*entity.Payment{
+		{ID: "payment-1", UserID: "buyer", EbookID: &ebookID, Status: entity.PaymentStatusPending, XenditReference: "inv_1"},
+	}}
+	trendingService := &MockTrendingService{}
+	u := NewPaymentUsecase(paymentService, &MockEbookService{ebook: &entity.Ebook{ID: ebookID}}, &MockEbookDiscountService{}, trendingService)
+
+	for i := 0; i < 2; i++ {
+		if err := u.HandleXenditCallback(ctx, map[string]interface{}{"external_id": "inv_1", "status": "PAID"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// A repeated callback is not another purchase, and the purchase is credited to the buyer
+	if len(trendingService.events[entity.EngagementPurchase]) != 1 || len(trendingService.actors) != 1 || trendingService.actors[0] != "buyer" {
+		t.Errorf("expected one purchase by the buyer, got %v by %v", trendingService.events, trendingService.actors)
+	}
+}
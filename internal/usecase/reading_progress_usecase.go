@@ -0,0 +1,14 @@
+package usecase
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// ReadingProgressUsecase defines the interface for reading progress use cases
+type ReadingProgressUsecase interface {
+	UpdateProgress(ctx context.Context, user *entity.User, progress *entity.ReadingProgress) (*entity.ReadingProgress, bool, error)
+	GetProgress(ctx context.Context, userID, ebookID string) (*entity.ReadingProgress, error)
+	ListContinueReading(ctx context.Context, userID string, limit, offset int) ([]*entity.ReadingProgressItem, error)
+	CountContinueReading(ctx context.Context, userID string) (int64, error)
+}
@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/service"
+	"context"
+	"time"
+)
+
+const maxCFILength = 512
+
+type readingProgressUsecase struct {
+	ebookService       service.EbookService
+	progressService    service.ReadingProgressService
+	entitlementService service.EntitlementService
+}
+
+func NewReadingProgressUsecase(
+	ebookService service.EbookService,
+	progressService service.ReadingProgressService,
+	entitlementService service.EntitlementService,
+) ReadingProgressUsecase {
+	return &readingProgressUsecase{
+		ebookService:       ebookService,
+		progressService:    progressService,
+		entitlementService: entitlementService,
+	}
+}
+
+// UpdateProgress stores a reading position unless another device reported a later one.
+// It returns nil if the ebook does not exist or is not published. Users who cannot read
+// past the preview have their position capped at the end of the preview.
+func (u *readingProgressUsecase) UpdateProgress(ctx context.Context, user *entity.User, progress *entity.ReadingProgress) (*entity.ReadingProgress, bool, error) {
+	if progress.EbookID == "" {
+		return nil, false, &ValidationError{Message: constant.EBOOK_ID_REQUIRED}
+	}
+	if progress.Page < 0 {
+		return nil, false, &ValidationError{Message: constant.ERR_PROGRESS_PAGE_INVALID}
+	}
+	if progress.CFI != nil && *progress.CFI == "" {
+		progress.CFI = nil
+	}
+	if progress.Page == 0 && progress.CFI == nil {
+		return nil, false, &ValidationError{Message: constant.ERR_PROGRESS_POSITION}
+	}
+	if progress.CFI != nil && len(*progress.CFI) > maxCFILength {
+		return nil, false, &ValidationError{Message: constant.ERR_PROGRESS_CFI_TOO_LONG}
+	}
+	if progress.Percentage < 0 || progress.Percentage > 100 {
+		return nil, false, &ValidationError{Message: constant.ERR_PROGRESS_PERCENTAGE}
+	}
+
+	// A device clock running ahead would otherwise win every later sync
+	now := time.Now()
+	if progress.ClientUpdatedAt.IsZero() || progress.ClientUpdatedAt.After(now) {
+		progress.ClientUpdatedAt = now
+	}
+	// Stored with millisecond precision, so compare at that precision too
+	progress.ClientUpdatedAt = progress.ClientUpdatedAt.UTC().Truncate(time.Millisecond)
+
+	ebook, err := u.ebookService.GetEbookByID(ctx, progress.EbookID)
+	if err != nil || ebook == nil {
+		return nil, false, err
+	}
+
+	// Editors read unpublished ebooks in full, like in the reader
+	entitled, err := u.entitlementService.CanEditEbooks(ctx, user)
+	if err != nil {
+		return nil, false, err
+	}
+	if !entitled {
+		if !ebook.IsPublished(now) {
+			return nil, false, nil
+		}
+		entitled, err = u.entitlementService.CanAccessEbook(ctx, user, ebook)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+	if !entitled {
+		capToPreview(progress, ebook)
+	}
+
+	progress.UserID = user.ID
+	return u.progressService.SaveProgress(ctx, progress)
+}
+
+// capToPreview moves a position beyond the preview back to its end. A CFI cannot be
+// placed within the preview, so it is dropped along with a capped position. Without a
+// page count the percentage of the preview is unknown, and the percentage is kept.
+func capToPreview(progress *entity.ReadingProgress, ebook *entity.Ebook) {
+	if !progress.PastPreview(ebook) {
+		return
+	}
+
+	progress.Page = min(progress.Page, int(ebook.PreviewPage))
+	if ebook.PageCount > 0 {
+		progress.Percentage = min(progress.Percentage, ebook.PreviewPercentage())
+	}
+	progress.CFI = nil
+}
+
+func (u *readingProgressUsecase) GetProgress(ctx context.Context, userID, ebookID string) (*entity.ReadingProgress, error) {
+	if ebookID == "" {
+		return nil, &ValidationError{Message: constant.EBOOK_ID_REQUIRED}
+	}
+
+	return u.progressService.GetProgress(ctx, userID, ebookID)
+}
+
+func (u *readingProgressUsecase) ListContinueReading(ctx context.Context, userID string, limit, offset int) ([]*entity.ReadingProgressItem, error) {
+	// Validate pagination parameters
+	if limit <= 0 {
+		limit = 10 // default limit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return u.progressService.GetContinueReadingList(ctx, userID, limit, offset)
+}
+
+func (u *readingProgressUsecase) CountContinueReading(ctx context.Context, userID string) (int64, error) {
+	return u.progressService.GetContinueReadingCount(ctx, userID)
+}
@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestReadingProgressUsecase_UpdateProgress(t *testing.T) {
+	ctx := context.Background()
+	publishedAt := time.Now().Add(-time.Hour)
+	published := &entity.Ebook{ID: "ebook-1", Price: 50000, PreviewPage: 2, PageCount: 10, ContentStatus: entity.ContentStatusPublished, PublishedAt: &publishedAt}
+	draft := &entity.Ebook{ID: "ebook-1", Price: 50000, PreviewPage: 2, PageCount: 10, ContentStatus: entity.ContentStatusDraft}
+	cfi := "epubcfi(/6/14!/4/2/1:0)"
+	entitlementService := &MockEntitlementService{owners: map[string]bool{"owner": true}, editors: map[string]bool{"editor": true}}
+
+	tests := []struct {
+		name           string
+		ebook          *entity.Ebook
+		user           string
+		progress       *entity.ReadingProgress
+		wantNil        bool
+		wantPage       int
+		wantPercentage float64
+		wantCFI        bool
+	}{
+		{name: "owner beyond the preview", ebook: published, user: "owner", progress: &entity.ReadingProgress{Page: 8, Percentage: 80, CFI: &cfi}, wantPage: 8, wantPercentage: 80, wantCFI: true},
+		{name: "reader within the preview", ebook: published, user: "reader", progress: &entity.ReadingProgress{Page: 2, Percentage: 15, CFI: &cfi}, wantPage: 2, wantPercentage: 15, wantCFI: true},
+		{name: "reader beyond the preview page is capped", ebook: published, user: "reader", progress: &entity.ReadingProgress{Page: 8, Percentage: 80, CFI: &cfi}, wantPage: 2, wantPercentage: 20},
+		{name: "reader beyond the preview percentage is capped", ebook: published, user: "reader", progress: &entity.ReadingProgress{CFI: &cfi, Percentage: 55}, wantPage: 0, wantPercentage: 20},
+		{name: "unpublished ebook is not found", ebook: draft, user: "owner", progress: &entity.ReadingProgress{Page: 1}, wantNil: true},
+		{name: "editor of an unpublished ebook", ebook: draft, user: "editor", progress: &entity.ReadingProgress{Page: 8}, wantPage: 8},
+		{name: "unknown ebook is not found", user: "owner", progress: &entity.ReadingProgress{Page: 1}, wantNil: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewReadingProgressUsecase(&MockEbookService{ebook: tt.ebook}, &MockReadingProgressService{}, entitlementService)
+
+			tt.progress.EbookID = "ebook-1"
+			stored, _, err := u.UpdateProgress(ctx, &entity.User{ID: tt.user}, tt.progress)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantNil {
+				if stored != nil {
+					t.Errorf("expected nothing stored, got %+v", stored)
+				}
+				return
+			}
+			if stored.UserID != tt.user || stored.Page != tt.wantPage || stored.Percentage != tt.wantPercentage || (stored.CFI != nil) != tt.wantCFI {
+				t.Errorf("unexpected progress %+v", stored)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package usecase
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// RecommendationUsecase defines the interface for related and recommended ebook use cases
+type RecommendationUsecase interface {
+	// ListRelatedEbooks returns ebooks similar to the given one for a "you may also like" section
+	ListRelatedEbooks(ctx context.Context, ebookID string, limit int) ([]*entity.EbookList, error)
+	// ListUserRecommendations returns ebooks picked for the user from what they bought and read
+	ListUserRecommendations(ctx context.Context, userID string, limit int) ([]*entity.EbookList, error)
+}
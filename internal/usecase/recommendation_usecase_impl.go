@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/service"
+	"context"
+)
+
+type recommendationUsecase struct {
+	ebookService          service.EbookService
+	recommendationService service.RecommendationService
+}
+
+func NewRecommendationUsecase(ebookService service.EbookService, recommendationService service.RecommendationService) RecommendationUsecase {
+	return &recommendationUsecase{
+		ebookService:          ebookService,
+		recommendationService: recommendationService,
+	}
+}
+
+// ListRelatedEbooks falls back to the most popular ebooks of the same category tree
+// until the ebook has precomputed related ebooks, e.g. right after it is published
+func (u *recommendationUsecase) ListRelatedEbooks(ctx context.Context, ebookID string, limit int) ([]*entity.EbookList, error) {
+	if ebookID == "" {
+		return nil, &ValidationError{Message: constant.EBOOK_ID_REQUIRED}
+	}
+	if limit <= 0 {
+		limit = 10 // default limit
+	}
+
+	ebook, err := u.ebookService.GetEbookByID(ctx, ebookID)
+	if err != nil {
+		return nil, err
+	}
+	if ebook == nil {
+		return nil, ErrEbookNotFound
+	}
+
+	related, err := u.recommendationService.GetRelatedEbooks(ctx, ebookID, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(related) > 0 {
+		return related, nil
+	}
+
+	// Fetch one extra so the list is still full after leaving out the ebook itself
+	popular, err := u.popularEbooks(ctx, ebook.CategoryID, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	related = make([]*entity.EbookList, 0, limit)
+	for _, candidate := range popular {
+		if candidate.ID != ebookID && len(related) < limit {
+			related = append(related, candidate)
+		}
+	}
+
+	return related, nil
+}
+
+// ListUserRecommendations falls back to the most popular ebooks for users without
+// precomputed recommendations, such as new users
+func (u *recommendationUsecase) ListUserRecommendations(ctx context.Context, userID string, limit int) ([]*entity.EbookList, error) {
+	if limit <= 0 {
+		limit = 10 // default limit
+	}
+
+	recommendations, err := u.recommendationService.GetUserRecommendations(ctx, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(recommendations) > 0 {
+		return recommendations, nil
+	}
+
+	return u.popularEbooks(ctx, "", limit)
+}
+
+func (u *recommendationUsecase) popularEbooks(ctx context.Context, categoryID string, limit int) ([]*entity.EbookList, error) {
+	filter := &entity.EbookFilter{CategoryID: categoryID, Sort: entity.EbookSortPopularity}
+	filter.Normalize()
+
+	return u.ebookService.GetEbookList(ctx, filter, limit, 0)
+}
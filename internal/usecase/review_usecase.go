@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// ReviewUsecase defines the interface for ebook review use cases
+type ReviewUsecase interface {
+	// CreateReview adds the user's review, once. Only users with access to the whole ebook,
+	// or whose reading progress is beyond its preview, may review it.
+	CreateReview(ctx context.Context, user *entity.User, review *entity.Review) error
+	// UpdateReview changes the rating and body of the user's own review
+	UpdateReview(ctx context.Context, review *entity.Review) (*entity.Review, error)
+	ReportReview(ctx context.Context, report *entity.ReviewReport) error
+	// ModerateReview hides or shows a review on behalf of a moderator
+	ModerateReview(ctx context.Context, moderatorID, id string, hidden bool) (*entity.Review, error)
+	ListEbookReviews(ctx context.Context, ebookID string, limit, offset int) ([]*entity.Review, error)
+	CountEbookReviews(ctx context.Context, ebookID string) (int64, error)
+	ListReportedReviews(ctx context.Context, limit, offset int) ([]*entity.Review, error)
+	CountReportedReviews(ctx context.Context) (int64, error)
+}
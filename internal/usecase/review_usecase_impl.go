@@ -0,0 +1,245 @@
+package usecase
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"buku-pintar/internal/domain/service"
+	"context"
+	"errors"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrEbookNotFound is returned when the ebook a request refers to does not exist
+	ErrEbookNotFound = errors.New(constant.EBOOK_NOT_FOUND)
+	// ErrReviewNotFound is returned when a review does not exist, is hidden, or belongs to another user
+	ErrReviewNotFound = errors.New(constant.ERR_REVIEW_NOT_FOUND)
+	// ErrReviewNotEligible is returned when the user neither has access to nor has read beyond the preview of the ebook
+	ErrReviewNotEligible = errors.New(constant.ERR_REVIEW_NOT_ELIGIBLE)
+	// ErrReviewAlreadyExists is returned when the user has already reviewed the ebook
+	ErrReviewAlreadyExists = errors.New(constant.ERR_REVIEW_ALREADY_EXISTS)
+)
+
+const (
+	minReviewRating       = 1
+	maxReviewRating       = 5
+	maxReviewBodyLength   = 5000
+	maxReportReasonLength = 500
+)
+
+type reviewUsecase struct {
+	ebookService       service.EbookService
+	reviewService      service.ReviewService
+	entitlementService service.EntitlementService
+	progressService    service.ReadingProgressService
+}
+
+func NewReviewUsecase(
+	ebookService service.EbookService,
+	reviewService service.ReviewService,
+	entitlementService service.EntitlementService,
+	progressService service.ReadingProgressService,
+) ReviewUsecase {
+	return &reviewUsecase{
+		ebookService:       ebookService,
+		reviewService:      reviewService,
+		entitlementService: entitlementService,
+		progressService:    progressService,
+	}
+}
+
+func (u *reviewUsecase) CreateReview(ctx context.Context, user *entity.User, review *entity.Review) error {
+	review.UserID = user.ID
+	if review.EbookID == "" {
+		return &ValidationError{Message: constant.EBOOK_ID_REQUIRED_VALIDATION}
+	}
+	if err := validateReview(review); err != nil {
+		return err
+	}
+
+	ebook, err := u.ebookService.GetEbookByID(ctx, review.EbookID)
+	if err != nil {
+		return err
+	}
+	if ebook == nil {
+		return ErrEbookNotFound
+	}
+
+	eligible, err := u.canReview(ctx, user, ebook)
+	if err != nil {
+		return err
+	}
+	if !eligible {
+		return ErrReviewNotEligible
+	}
+
+	existing, err := u.reviewService.GetUserReview(ctx, review.UserID, review.EbookID)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return ErrReviewAlreadyExists
+	}
+
+	review.ID = uuid.New().String()
+	err = u.reviewService.CreateReview(ctx, review)
+	if errors.Is(err, repository.ErrDuplicateKey) {
+		// Another request created the review after the check above
+		return ErrReviewAlreadyExists
+	}
+	return err
+}
+
+// UpdateReview keeps the moderation state; an edited hidden review stays hidden
+func (u *reviewUsecase) UpdateReview(ctx context.Context, review *entity.Review) (*entity.Review, error) {
+	if review.ID == "" {
+		return nil, &ValidationError{Message: constant.ERR_ID_REQUIRED}
+	}
+	if err := validateReview(review); err != nil {
+		return nil, err
+	}
+
+	existing, err := u.reviewService.GetReviewByID(ctx, review.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil || existing.UserID != review.UserID {
+		return nil, ErrReviewNotFound
+	}
+
+	existing.Rating = review.Rating
+	existing.Body = review.Body
+	if err := u.reviewService.UpdateReview(ctx, existing); err != nil {
+		return nil, err
+	}
+
+	return existing, nil
+}
+
+// ReportReview is idempotent: reporting the same review twice counts once
+func (u *reviewUsecase) ReportReview(ctx context.Context, report *entity.ReviewReport) error {
+	if report.ReviewID == "" {
+		return &ValidationError{Message: constant.ERR_ID_REQUIRED}
+	}
+	report.Reason = trimOptional(report.Reason)
+	if report.Reason != nil && utf8.RuneCountInString(*report.Reason) > maxReportReasonLength {
+		return &ValidationError{Message: constant.ERR_REVIEW_REASON_TOO_LONG}
+	}
+
+	review, err := u.reviewService.GetReviewByID(ctx, report.ReviewID)
+	if err != nil {
+		return err
+	}
+	if review == nil || review.Status != entity.ReviewVisible {
+		return ErrReviewNotFound
+	}
+	if review.UserID == report.UserID {
+		return &ValidationError{Message: constant.ERR_REVIEW_REPORT_OWN}
+	}
+
+	report.ID = uuid.New().String()
+	_, err = u.reviewService.ReportReview(ctx, report)
+	return err
+}
+
+func (u *reviewUsecase) ModerateReview(ctx context.Context, moderatorID, id string, hidden bool) (*entity.Review, error) {
+	if id == "" {
+		return nil, &ValidationError{Message: constant.ERR_ID_REQUIRED}
+	}
+
+	review, err := u.reviewService.GetReviewByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if review == nil {
+		return nil, ErrReviewNotFound
+	}
+
+	status := entity.ReviewVisible
+	if hidden {
+		status = entity.ReviewHidden
+	}
+	if err := u.reviewService.SetReviewStatus(ctx, id, status, moderatorID); err != nil {
+		return nil, err
+	}
+
+	return u.reviewService.GetReviewByID(ctx, id)
+}
+
+func (u *reviewUsecase) ListEbookReviews(ctx context.Context, ebookID string, limit, offset int) ([]*entity.Review, error) {
+	if ebookID == "" {
+		return nil, &ValidationError{Message: constant.EBOOK_ID_REQUIRED}
+	}
+
+	ebook, err := u.ebookService.GetEbookByID(ctx, ebookID)
+	if err != nil {
+		return nil, err
+	}
+	if ebook == nil {
+		return nil, ErrEbookNotFound
+	}
+
+	// Validate pagination parameters
+	if limit <= 0 {
+		limit = 10 // default limit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return u.reviewService.GetEbookReviews(ctx, ebookID, limit, offset)
+}
+
+func (u *reviewUsecase) CountEbookReviews(ctx context.Context, ebookID string) (int64, error) {
+	return u.reviewService.GetEbookReviewCount(ctx, ebookID)
+}
+
+func (u *reviewUsecase) ListReportedReviews(ctx context.Context, limit, offset int) ([]*entity.Review, error) {
+	// Validate pagination parameters
+	if limit <= 0 {
+		limit = 10 // default limit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return u.reviewService.GetReportedReviews(ctx, limit, offset)
+}
+
+func (u *reviewUsecase) CountReportedReviews(ctx context.Context) (int64, error) {
+	return u.reviewService.GetReportedReviewCount(ctx)
+}
+
+// canReview reports whether the user may read the whole ebook (free, bought or premium)
+// or has read beyond its preview
+func (u *reviewUsecase) canReview(ctx context.Context, user *entity.User, ebook *entity.Ebook) (bool, error) {
+	entitled, err := u.entitlementService.CanAccessEbook(ctx, user, ebook)
+	if err != nil {
+		return false, err
+	}
+	if entitled {
+		return true, nil
+	}
+
+	progress, err := u.progressService.GetProgress(ctx, user.ID, ebook.ID)
+	if err != nil {
+		return false, err
+	}
+	return progress != nil && progress.PastPreview(ebook), nil
+}
+
+func validateReview(review *entity.Review) error {
+	if review.Rating < minReviewRating || review.Rating > maxReviewRating {
+		return &ValidationError{Message: constant.ERR_REVIEW_RATING}
+	}
+
+	review.Body = trimOptional(review.Body)
+	if review.Body != nil && utf8.RuneCountInString(*review.Body) > maxReviewBodyLength {
+		return &ValidationError{Message: constant.ERR_REVIEW_BODY_TOO_LONG}
+	}
+
+	return nil
+}
@@ -0,0 +1,296 @@
+package usecase
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/repository"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// MockReviewService stores copies of reviews in memory and keeps a rating aggregate like the repository does
+type MockReviewService struct {
+	reviews     map[string]*entity.Review
+	reports     map[string]bool
+	ratingCount int
+	ratingSum   int
+	createErr   error
+}
+
+func (m *MockReviewService) CreateReview(ctx context.Context, review *entity.Review) error {
+	if m.createErr != nil {
+		return m.createErr
+	}
+	review.Status = entity.ReviewVisible
+	copied := *review
+	m.reviews[review.ID] = &copied
+	m.ratingCount++
+	m.ratingSum += review.Rating
+	return nil
+}
+
+func (m *MockReviewService) GetReviewByID(ctx context.Context, id string) (*entity.Review, error) {
+	review, ok := m.reviews[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *review
+	return &copied, nil
+}
+
+func (m *MockReviewService) GetUserReview(ctx context.Context, userID, ebookID string) (*entity.Review, error) {
+	for _, review := range m.reviews {
+		if review.UserID == userID && review.EbookID == ebookID {
+			return review, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockReviewService) UpdateReview(ctx context.Context, review *entity.Review) error {
+	stored := m.reviews[review.ID]
+	if stored.Status == entity.ReviewVisible {
+		m.ratingSum += review.Rating - stored.Rating
+	}
+	copied := *review
+	m.reviews[review.ID] = &copied
+	return nil
+}
+
+func (m *MockReviewService) SetReviewStatus(ctx context.Context, id string, status entity.ReviewStatus, moderatorID string) error {
+	review := m.reviews[id]
+	if review.Status == status {
+		return nil
+	}
+	if status == entity.ReviewHidden {
+		m.ratingCount--
+		m.ratingSum -= review.Rating
+		review.HiddenBy = &moderatorID
+	} else {
+		m.ratingCount++
+		m.ratingSum += review.Rating
+		review.HiddenBy = nil
+	}
+	review.Status = status
+	return nil
+}
+
+func (m *MockReviewService) ReportReview(ctx context.Context, report *entity.ReviewReport) (bool, error) {
+	key := report.ReviewID + "|" + report.UserID
+	if m.reports[key] {
+		return false, nil
+	}
+	m.reports[key] = true
+	m.reviews[report.ReviewID].ReportCount++
+	return true, nil
+}
+
+func (m *MockReviewService) GetEbookReviews(ctx context.Context, ebookID string, limit, offset int) ([]*entity.Review, error) {
+	return nil, nil
+}
+
+func (m *MockReviewService) GetEbookReviewCount(ctx context.Context, ebookID string) (int64, error) {
+	return 0, nil
+}
+
+func (m *MockReviewService) GetReportedReviews(ctx context.Context, limit, offset int) ([]*entity.Review, error) {
+	return nil, nil
+}
+
+func (m *MockReviewService) GetReportedReviewCount(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
+// MockReadingProgressService reports the page reached by the users listed in readers
+type MockReadingProgressService struct {
+	readers map[string]int
+}
+
+func (m *MockReadingProgressService) SaveProgress(ctx context.Context, progress *entity.ReadingProgress) (*entity.ReadingProgress, bool, error) {
+	return progress, true, nil
+}
+
+func (m *MockReadingProgressService) GetProgress(ctx context.Context, userID, ebookID string) (*entity.ReadingProgress, error) {
+	page, ok := m.readers[userID]
+	if !ok {
+		return nil, nil
+	}
+	return &entity.ReadingProgress{UserID: userID, EbookID: ebookID, Page: page}, nil
+}
+
+func (m *MockReadingProgressService) GetContinueReadingList(ctx context.Context, userID string, limit, offset int) ([]*entity.ReadingProgressItem, error) {
+	return nil, nil
+}
+
+func (m *MockReadingProgressService) GetContinueReadingCount(ctx context.Context, userID string) (int64, error) {
+	return 0, nil
+}
+
+func (m *MockReadingProgressService) FlushProgress(ctx context.Context) (int, error) {
+	return 0, nil
+}
+
+func newTestReviewUsecase(reviewService *MockReviewService) ReviewUsecase {
+	return NewReviewUsecase(
+		&MockEbookService{ebook: &entity.Ebook{ID: "ebook-1", Price: 50000, PreviewPage: 2, PageCount: 10}},
+		reviewService,
+		&MockEntitlementService{owners: map[string]bool{"buyer": true}, premium: map[string]bool{"premium": true}},
+		&MockReadingProgressService{readers: map[string]int{"reader": 3, "previewer": 2}},
+	)
+}
+
+func TestReviewUsecase_CreateReview(t *testing.T) {
+	tests := []struct {
+		name    string
+		review  *entity.Review
+		wantErr error
+	}{
+		{
+			name:   "should let an owner review",
+			review: &entity.Review{UserID: "buyer", EbookID: "ebook-1", Rating: 5, Body: stringPtr("  Loved it  ")},
+		},
+		{
+			name:   "should let a reader review without text",
+			review: &entity.Review{UserID: "reader", EbookID: "ebook-1", Rating: 3},
+		},
+		{
+			name:   "should let a premium user review",
+			review: &entity.Review{UserID: "premium", EbookID: "ebook-1", Rating: 4},
+		},
+		{
+			name:    "should reject a user who neither owns nor read the ebook",
+			review:  &entity.Review{UserID: "stranger", EbookID: "ebook-1", Rating: 1},
+			wantErr: ErrReviewNotEligible,
+		},
+		{
+			name:    "should reject a user who only read the preview",
+			review:  &entity.Review{UserID: "previewer", EbookID: "ebook-1", Rating: 1},
+			wantErr: ErrReviewNotEligible,
+		},
+		{
+			name:    "should reject a rating above five",
+			review:  &entity.Review{UserID: "buyer", EbookID: "ebook-1", Rating: 6},
+			wantErr: &ValidationError{},
+		},
+		{
+			name:    "should reject a missing rating",
+			review:  &entity.Review{UserID: "buyer", EbookID: "ebook-1"},
+			wantErr: &ValidationError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reviewService := &MockReviewService{reviews: map[string]*entity.Review{}, reports: map[string]bool{}}
+			u := newTestReviewUsecase(reviewService)
+
+			err := u.CreateReview(context.Background(), &entity.User{ID: tt.review.UserID}, tt.review)
+
+			var validationErr *ValidationError
+			switch {
+			case tt.wantErr == nil:
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if tt.review.ID == "" {
+					t.Error("expected review ID to be generated")
+				}
+				if tt.review.Body != nil && *tt.review.Body != "Loved it" {
+					t.Errorf("expected trimmed body, got %q", *tt.review.Body)
+				}
+			case errors.As(tt.wantErr, &validationErr):
+				if !errors.As(err, &validationErr) {
+					t.Errorf("expected validation error, got %v", err)
+				}
+			case !errors.Is(err, tt.wantErr):
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestReviewUsecase_OneReviewPerUser(t *testing.T) {
+	reviewService := &MockReviewService{reviews: map[string]*entity.Review{}, reports: map[string]bool{}}
+	u := newTestReviewUsecase(reviewService)
+	ctx := context.Background()
+
+	if err := u.CreateReview(ctx, &entity.User{ID: "buyer"}, &entity.Review{UserID: "buyer", EbookID: "ebook-1", Rating: 4}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err := u.CreateReview(ctx, &entity.User{ID: "buyer"}, &entity.Review{UserID: "buyer", EbookID: "ebook-1", Rating: 2})
+	if !errors.Is(err, ErrReviewAlreadyExists) {
+		t.Errorf("expected second review to be rejected, got %v", err)
+	}
+	if reviewService.ratingCount != 1 || reviewService.ratingSum != 4 {
+		t.Errorf("expected aggregate 1/4, got %d/%d", reviewService.ratingCount, reviewService.ratingSum)
+	}
+}
+
+func TestReviewUsecase_ConcurrentDuplicateReview(t *testing.T) {
+	// The unique key catches a review created between the existence check and the insert
+	reviewService := &MockReviewService{reviews: map[string]*entity.Review{}, reports: map[string]bool{}, createErr: fmt.Errorf("%w: uk_reviews_user_ebook", repository.ErrDuplicateKey)}
+	u := newTestReviewUsecase(reviewService)
+
+	err := u.CreateReview(context.Background(), &entity.User{ID: "buyer"}, &entity.Review{EbookID: "ebook-1", Rating: 4})
+	if !errors.Is(err, ErrReviewAlreadyExists) {
+		t.Errorf("expected ErrReviewAlreadyExists, got %v", err)
+	}
+}
+
+func TestReviewUsecase_EditReportAndModerate(t *testing.T) {
+	reviewService := &MockReviewService{reviews: map[string]*entity.Review{}, reports: map[string]bool{}}
+	u := newTestReviewUsecase(reviewService)
+	ctx := context.Background()
+
+	review := &entity.Review{UserID: "buyer", EbookID: "ebook-1", Rating: 2}
+	if err := u.CreateReview(ctx, &entity.User{ID: review.UserID}, review); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Only the author can edit, and the edit moves the aggregate by the rating delta
+	if _, err := u.UpdateReview(ctx, &entity.Review{ID: review.ID, UserID: "reader", Rating: 1}); !errors.Is(err, ErrReviewNotFound) {
+		t.Errorf("expected another user's edit to be not found, got %v", err)
+	}
+	updated, err := u.UpdateReview(ctx, &entity.Review{ID: review.ID, UserID: "buyer", Rating: 5, Body: stringPtr("Better on a second read")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.Rating != 5 || updated.EbookID != "ebook-1" {
+		t.Errorf("unexpected update result %+v", updated)
+	}
+	if reviewService.ratingSum != 5 {
+		t.Errorf("expected rating sum 5 after edit, got %d", reviewService.ratingSum)
+	}
+
+	// Authors cannot report themselves; repeated reports count once
+	if err := u.ReportReview(ctx, &entity.ReviewReport{ReviewID: review.ID, UserID: "buyer"}); err == nil {
+		t.Error("expected reporting your own review to fail")
+	}
+	for i := 0; i < 2; i++ {
+		if err := u.ReportReview(ctx, &entity.ReviewReport{ReviewID: review.ID, UserID: "reader", Reason: stringPtr("Spoilers")}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if reviewService.reviews[review.ID].ReportCount != 1 {
+		t.Errorf("expected one report, got %d", reviewService.reviews[review.ID].ReportCount)
+	}
+
+	// Hiding removes the rating from the aggregate, showing it again restores it
+	hidden, err := u.ModerateReview(ctx, "moderator", review.ID, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if hidden.Status != entity.ReviewHidden || reviewService.ratingCount != 0 || reviewService.ratingSum != 0 {
+		t.Errorf("expected hidden review out of the aggregate, got %s %d/%d", hidden.Status, reviewService.ratingCount, reviewService.ratingSum)
+	}
+	if err := u.ReportReview(ctx, &entity.ReviewReport{ReviewID: review.ID, UserID: "someone"}); !errors.Is(err, ErrReviewNotFound) {
+		t.Errorf("expected hidden review to be unreportable, got %v", err)
+	}
+	if _, err := u.ModerateReview(ctx, "moderator", review.ID, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reviewService.ratingCount != 1 || reviewService.ratingSum != 5 {
+		t.Errorf("expected aggregate 1/5 after showing the review, got %d/%d", reviewService.ratingCount, reviewService.ratingSum)
+	}
+}
@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// SeriesNext is the volume to read after an ebook of a series
+type SeriesNext struct {
+	Series    *entity.SeriesMembership // The ebook's place in its series
+	Volume    *entity.SeriesVolume     // Nil when the ebook is the last published volume
+	InLibrary bool                     // The user can already read the next volume: free, bought, or premium access
+}
+
+// SeriesUsecase defines the interface for series and their reading order
+type SeriesUsecase interface {
+	// ListSeries returns a page of the series with published volumes and their total count
+	ListSeries(ctx context.Context, limit, offset int) ([]*entity.Series, int64, error)
+	// GetSeriesBySlug returns a series with its published volumes in reading order
+	GetSeriesBySlug(ctx context.Context, slug string) (*entity.Series, []*entity.SeriesVolume, error)
+	CreateSeries(ctx context.Context, series *entity.Series) error
+	UpdateSeries(ctx context.Context, series *entity.Series) error
+	DeleteSeries(ctx context.Context, id string) error
+	// ReplaceVolumes replaces the ebooks of a series and their volume numbers at once
+	ReplaceVolumes(ctx context.Context, seriesID string, volumes []*entity.SeriesVolume) ([]*entity.SeriesVolume, error)
+	// GetNextInSeries returns the volume after an ebook and whether it is already in the user's library.
+	// The user may be nil for anonymous clients, whose library is empty.
+	GetNextInSeries(ctx context.Context, user *entity.User, ebookID string) (*SeriesNext, error)
+}
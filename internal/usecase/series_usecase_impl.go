@@ -0,0 +1,227 @@
+package usecase
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/service"
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxSeriesName    = 255
+	maxSeriesVolume  = 65535
+	maxSeriesVolumes = 500
+)
+
+var (
+	ErrSeriesNotFound   = errors.New(constant.ERR_SERIES_NOT_FOUND)
+	ErrSeriesSlugTaken  = errors.New(constant.ERR_SERIES_SLUG_TAKEN)
+	ErrEbookNotInSeries = errors.New(constant.ERR_EBOOK_NOT_IN_SERIES)
+)
+
+type seriesUsecase struct {
+	seriesService      service.SeriesService
+	ebookService       service.EbookService
+	entitlementService service.EntitlementService
+}
+
+func NewSeriesUsecase(seriesService service.SeriesService, ebookService service.EbookService, entitlementService service.EntitlementService) SeriesUsecase {
+	return &seriesUsecase{
+		seriesService:      seriesService,
+		ebookService:       ebookService,
+		entitlementService: entitlementService,
+	}
+}
+
+func (u *seriesUsecase) ListSeries(ctx context.Context, limit, offset int) ([]*entity.Series, int64, error) {
+	list, err := u.seriesService.GetSeriesList(ctx, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := u.seriesService.GetSeriesCount(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return list, total, nil
+}
+
+// GetSeriesBySlug hides series without published volumes, as the list does
+func (u *seriesUsecase) GetSeriesBySlug(ctx context.Context, slug string) (*entity.Series, []*entity.SeriesVolume, error) {
+	series, err := u.seriesService.GetSeriesBySlug(ctx, slug)
+	if err != nil {
+		return nil, nil, err
+	}
+	if series == nil || series.VolumeCount == 0 {
+		return nil, nil, ErrSeriesNotFound
+	}
+
+	volumes, err := u.seriesService.GetVolumes(ctx, series.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return series, volumes, nil
+}
+
+func (u *seriesUsecase) CreateSeries(ctx context.Context, series *entity.Series) error {
+	if err := u.validateSeries(ctx, series); err != nil {
+		return err
+	}
+
+	series.ID = uuid.New().String()
+	return u.seriesService.CreateSeries(ctx, series)
+}
+
+func (u *seriesUsecase) UpdateSeries(ctx context.Context, series *entity.Series) error {
+	if _, err := u.requireSeries(ctx, series.ID); err != nil {
+		return err
+	}
+	if err := u.validateSeries(ctx, series); err != nil {
+		return err
+	}
+
+	return u.seriesService.UpdateSeries(ctx, series)
+}
+
+func (u *seriesUsecase) DeleteSeries(ctx context.Context, id string) error {
+	if _, err := u.requireSeries(ctx, id); err != nil {
+		return err
+	}
+
+	return u.seriesService.DeleteSeries(ctx, id)
+}
+
+// validateSeries trims the fields, derives a missing slug from the name and checks the slug is free
+func (u *seriesUsecase) validateSeries(ctx context.Context, series *entity.Series) error {
+	series.Name = strings.TrimSpace(series.Name)
+	series.Slug = strings.TrimSpace(series.Slug)
+	if series.Name == "" {
+		return &ValidationError{Message: constant.ERR_SERIES_NAME_REQUIRED}
+	}
+	if utf8.RuneCountInString(series.Name) > maxSeriesName {
+		return &ValidationError{Message: constant.ERR_SERIES_NAME_TOO_LONG}
+	}
+	if series.Slug == "" {
+		series.Slug = catalogSlug(series.Name)
+	}
+	if !catalogSlugPattern.MatchString(series.Slug) {
+		return &ValidationError{Message: constant.ERR_SERIES_SLUG_INVALID}
+	}
+
+	existing, err := u.seriesService.GetSeriesBySlug(ctx, series.Slug)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != series.ID {
+		return ErrSeriesSlugTaken
+	}
+
+	return nil
+}
+
+func (u *seriesUsecase) ReplaceVolumes(ctx context.Context, seriesID string, volumes []*entity.SeriesVolume) ([]*entity.SeriesVolume, error) {
+	if _, err := u.requireSeries(ctx, seriesID); err != nil {
+		return nil, err
+	}
+	if len(volumes) > maxSeriesVolumes {
+		return nil, &ValidationError{Message: constant.ERR_SERIES_TOO_MANY_VOLUMES}
+	}
+
+	numbers := make(map[int]bool, len(volumes))
+	ebooks := make(map[string]bool, len(volumes))
+	for _, volume := range volumes {
+		volume.SeriesID = seriesID
+		volume.EbookID = strings.TrimSpace(volume.EbookID)
+		if volume.EbookID == "" {
+			return nil, &ValidationError{Message: constant.EBOOK_ID_REQUIRED_VALIDATION}
+		}
+		if volume.Volume < 1 || volume.Volume > maxSeriesVolume {
+			return nil, &ValidationError{Message: constant.ERR_SERIES_VOLUME_INVALID}
+		}
+		if numbers[volume.Volume] {
+			return nil, &ValidationError{Message: constant.ERR_SERIES_VOLUME_DUPLICATE}
+		}
+		if ebooks[volume.EbookID] {
+			return nil, &ValidationError{Message: constant.ERR_SERIES_EBOOK_DUPLICATE}
+		}
+		numbers[volume.Volume] = true
+		ebooks[volume.EbookID] = true
+
+		ebook, err := u.ebookService.GetEbookByID(ctx, volume.EbookID)
+		if err != nil {
+			return nil, err
+		}
+		if ebook == nil {
+			return nil, ErrEbookNotFound
+		}
+		volume.Title = ebook.Title
+		volume.Slug = ebook.Slug
+		volume.CoverImage = ebook.CoverImage
+		volume.Price = ebook.Price
+
+		membership, err := u.seriesService.GetMembership(ctx, volume.EbookID)
+		if err != nil {
+			return nil, err
+		}
+		if membership != nil && membership.SeriesID != seriesID {
+			return nil, &ValidationError{Message: constant.ERR_SERIES_EBOOK_TAKEN}
+		}
+	}
+
+	sort.Slice(volumes, func(i, j int) bool { return volumes[i].Volume < volumes[j].Volume })
+	if err := u.seriesService.ReplaceVolumes(ctx, seriesID, volumes); err != nil {
+		return nil, err
+	}
+
+	if volumes == nil {
+		volumes = []*entity.SeriesVolume{}
+	}
+	return volumes, nil
+}
+
+func (u *seriesUsecase) GetNextInSeries(ctx context.Context, user *entity.User, ebookID string) (*SeriesNext, error) {
+	membership, err := u.seriesService.GetMembership(ctx, ebookID)
+	if err != nil {
+		return nil, err
+	}
+	if membership == nil {
+		return nil, ErrEbookNotInSeries
+	}
+
+	next := &SeriesNext{Series: membership}
+	next.Volume, err = u.seriesService.GetNextVolume(ctx, ebookID)
+	if err != nil || next.Volume == nil {
+		return next, err
+	}
+
+	next.InLibrary, err = u.entitlementService.CanAccessEbook(ctx, user, &entity.Ebook{ID: next.Volume.EbookID, Price: next.Volume.Price})
+	if err != nil {
+		return nil, err
+	}
+
+	return next, nil
+}
+
+func (u *seriesUsecase) requireSeries(ctx context.Context, id string) (*entity.Series, error) {
+	if id == "" {
+		return nil, ErrSeriesNotFound
+	}
+
+	series, err := u.seriesService.GetSeriesByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if series == nil {
+		return nil, ErrSeriesNotFound
+	}
+
+	return series, nil
+}
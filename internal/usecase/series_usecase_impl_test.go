@@ -0,0 +1,190 @@
+package usecase
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+	"errors"
+	"testing"
+)
+
+// MockSeriesService keeps series by slug and the membership of ebooks in memory
+type MockSeriesService struct {
+	series      map[string]*entity.Series
+	memberships map[string]*entity.SeriesMembership
+	next        *entity.SeriesVolume
+	replaced    []*entity.SeriesVolume
+	err         error
+}
+
+func (m *MockSeriesService) CreateSeries(ctx context.Context, series *entity.Series) error {
+	if m.series == nil {
+		m.series = map[string]*entity.Series{}
+	}
+	m.series[series.Slug] = series
+	return m.err
+}
+
+func (m *MockSeriesService) GetSeriesByID(ctx context.Context, id string) (*entity.Series, error) {
+	for _, series := range m.series {
+		if series.ID == id {
+			return series, m.err
+		}
+	}
+	return nil, m.err
+}
+
+func (m *MockSeriesService) GetSeriesBySlug(ctx context.Context, slug string) (*entity.Series, error) {
+	return m.series[slug], m.err
+}
+
+func (m *MockSeriesService) UpdateSeries(ctx context.Context, series *entity.Series) error {
+	return m.err
+}
+
+func (m *MockSeriesService) DeleteSeries(ctx context.Context, id string) error {
+	return m.err
+}
+
+func (m *MockSeriesService) GetSeriesList(ctx context.Context, limit, offset int) ([]*entity.Series, error) {
+	return nil, m.err
+}
+
+func (m *MockSeriesService) GetSeriesCount(ctx context.Context) (int64, error) {
+	return int64(len(m.series)), m.err
+}
+
+func (m *MockSeriesService) GetVolumes(ctx context.Context, seriesID string) ([]*entity.SeriesVolume, error) {
+	return m.replaced, m.err
+}
+
+func (m *MockSeriesService) ReplaceVolumes(ctx context.Context, seriesID string, volumes []*entity.SeriesVolume) error {
+	m.replaced = volumes
+	return m.err
+}
+
+func (m *MockSeriesService) GetMembership(ctx context.Context, ebookID string) (*entity.SeriesMembership, error) {
+	return m.memberships[ebookID], m.err
+}
+
+func (m *MockSeriesService) GetNextVolume(ctx context.Context, ebookID string) (*entity.SeriesVolume, error) {
+	return m.next, m.err
+}
+
+func TestSeriesUsecase_CreateSeries(t *testing.T) {
+	ctx := context.Background()
+	seriesService := &MockSeriesService{series: map[string]*entity.Series{"laskar-pelangi": {ID: "series-1", Slug: "laskar-pelangi"}}}
+	u := NewSeriesUsecase(seriesService, &MockEbookService{}, &MockEntitlementService{})
+
+	t.Run("should derive the slug from the name", func(t *testing.T) {
+		series := &entity.Series{Name: "  Bumi Manusia  "}
+		if err := u.CreateSeries(ctx, series); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if series.ID == "" || series.Name != "Bumi Manusia" || series.Slug != "bumi-manusia" {
+			t.Errorf("unexpected series %+v", series)
+		}
+	})
+
+	t.Run("should reject a taken slug and an invalid one", func(t *testing.T) {
+		if err := u.CreateSeries(ctx, &entity.Series{Name: "Laskar Pelangi"}); !errors.Is(err, ErrSeriesSlugTaken) {
+			t.Errorf("expected ErrSeriesSlugTaken, got %v", err)
+		}
+		var validationErr *ValidationError
+		if err := u.CreateSeries(ctx, &entity.Series{Name: "Tetralogi", Slug: "Tetra Logi"}); !errors.As(err, &validationErr) {
+			t.Errorf("expected a validation error, got %v", err)
+		}
+	})
+}
+
+func TestSeriesUsecase_ReplaceVolumes(t *testing.T) {
+	ctx := context.Background()
+	ebookService := &MockEbookService{
+		getByIDFunc: func(ctx context.Context, id string) (*entity.Ebook, error) {
+			if id == "missing" {
+				return nil, nil
+			}
+			return &entity.Ebook{ID: id, Title: "Title " + id, Slug: id, Price: 1000}, nil
+		},
+	}
+	seriesService := &MockSeriesService{
+		series:      map[string]*entity.Series{"tetralogi-buru": {ID: "series-1", Slug: "tetralogi-buru"}},
+		memberships: map[string]*entity.SeriesMembership{"ebook-9": {SeriesID: "series-2"}},
+	}
+	u := NewSeriesUsecase(seriesService, ebookService, &MockEntitlementService{})
+
+	t.Run("should store the volumes in reading order", func(t *testing.T) {
+		volumes, err := u.ReplaceVolumes(ctx, "series-1", []*entity.SeriesVolume{
+			{EbookID: "ebook-2", Volume: 2},
+			{EbookID: "ebook-1", Volume: 1},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(seriesService.replaced) != 2 || volumes[0].EbookID != "ebook-1" || volumes[1].Title != "Title ebook-2" {
+			t.Errorf("unexpected volumes %+v", volumes)
+		}
+	})
+
+	tests := []struct {
+		name    string
+		volumes []*entity.SeriesVolume
+	}{
+		{name: "volume out of range", volumes: []*entity.SeriesVolume{{EbookID: "ebook-1", Volume: 0}}},
+		{name: "duplicate volume", volumes: []*entity.SeriesVolume{{EbookID: "ebook-1", Volume: 1}, {EbookID: "ebook-2", Volume: 1}}},
+		{name: "duplicate ebook", volumes: []*entity.SeriesVolume{{EbookID: "ebook-1", Volume: 1}, {EbookID: "ebook-1", Volume: 2}}},
+		{name: "ebook in another series", volumes: []*entity.SeriesVolume{{EbookID: "ebook-9", Volume: 1}}},
+	}
+	for _, tt := range tests {
+		t.Run("should reject "+tt.name, func(t *testing.T) {
+			var validationErr *ValidationError
+			if _, err := u.ReplaceVolumes(ctx, "series-1", tt.volumes); !errors.As(err, &validationErr) {
+				t.Errorf("expected a validation error, got %v", err)
+			}
+		})
+	}
+
+	t.Run("should reject unknown series and ebooks", func(t *testing.T) {
+		if _, err := u.ReplaceVolumes(ctx, "series-404", nil); !errors.Is(err, ErrSeriesNotFound) {
+			t.Errorf("expected ErrSeriesNotFound, got %v", err)
+		}
+		if _, err := u.ReplaceVolumes(ctx, "series-1", []*entity.SeriesVolume{{EbookID: "missing", Volume: 1}}); !errors.Is(err, ErrEbookNotFound) {
+			t.Errorf("expected ErrEbookNotFound, got %v", err)
+		}
+	})
+}
+
+func TestSeriesUsecase_GetNextInSeries(t *testing.T) {
+	ctx := context.Background()
+	seriesService := &MockSeriesService{
+		memberships: map[string]*entity.SeriesMembership{"ebook-1": {SeriesID: "series-1", SeriesName: "Tetralogi Buru", Volume: 1, VolumeCount: 2}},
+		next:        &entity.SeriesVolume{SeriesID: "series-1", EbookID: "ebook-2", Volume: 2, Price: 50000},
+	}
+	u := NewSeriesUsecase(seriesService, &MockEbookService{}, &MockEntitlementService{owners: map[string]bool{"owner": true}})
+
+	tests := []struct {
+		name      string
+		user      *entity.User
+		inLibrary bool
+	}{
+		{name: "should report a bought next volume as in the library", user: &entity.User{ID: "owner"}, inLibrary: true},
+		{name: "should report a paid next volume as missing for other readers", user: &entity.User{ID: "reader"}, inLibrary: false},
+		{name: "should treat anonymous clients as having an empty library", user: nil, inLibrary: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next, err := u.GetNextInSeries(ctx, tt.user, "ebook-1")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if next.Volume.EbookID != "ebook-2" || next.Series.Volume != 1 || next.InLibrary != tt.inLibrary {
+				t.Errorf("unexpected next %+v", next)
+			}
+		})
+	}
+
+	t.Run("should reject ebooks outside a series", func(t *testing.T) {
+		if _, err := u.GetNextInSeries(ctx, nil, "ebook-3"); !errors.Is(err, ErrEbookNotInSeries) {
+			t.Errorf("expected ErrEbookNotInSeries, got %v", err)
+		}
+	})
+}
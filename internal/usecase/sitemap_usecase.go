@@ -0,0 +1,11 @@
+package usecase
+
+import "context"
+
+// SitemapUsecase defines the interface for the sitemaps of the public content
+type SitemapUsecase interface {
+	// GetSitemap returns the sitemap index for entity.SitemapIndexName, or a child sitemap by file name
+	GetSitemap(ctx context.Context, name string) ([]byte, error)
+	// RefreshSitemaps regenerates the sitemaps if the public content changed
+	RefreshSitemaps(ctx context.Context) (bool, error)
+}
@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/service"
+	"context"
+	"errors"
+	"regexp"
+)
+
+var ErrSitemapNotFound = errors.New(constant.ERR_SITEMAP_NOT_FOUND)
+
+// sitemapNamePattern matches the names of child sitemaps, {content}-{n}.xml
+var sitemapNamePattern = regexp.MustCompile(`^[a-z]+-[1-9][0-9]*\.xml$`)
+
+type sitemapUsecase struct {
+	sitemapService service.SitemapService
+}
+
+func NewSitemapUsecase(sitemapService service.SitemapService) SitemapUsecase {
+	return &sitemapUsecase{
+		sitemapService: sitemapService,
+	}
+}
+
+// GetSitemap serves the stored sitemaps. They are generated on the spot when none are stored yet,
+// e.g. after Redis was flushed, instead of waiting for the next refresh.
+func (u *sitemapUsecase) GetSitemap(ctx context.Context, name string) ([]byte, error) {
+	if name != entity.SitemapIndexName && !sitemapNamePattern.MatchString(name) {
+		return nil, ErrSitemapNotFound
+	}
+
+	document, err := u.sitemapService.GetSitemap(ctx, name)
+	if err != nil || document != nil {
+		return document, err
+	}
+
+	if _, err := u.sitemapService.RefreshSitemaps(ctx); err != nil {
+		return nil, err
+	}
+	document, err = u.sitemapService.GetSitemap(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if document == nil {
+		return nil, ErrSitemapNotFound
+	}
+
+	return document, nil
+}
+
+func (u *sitemapUsecase) RefreshSitemaps(ctx context.Context) (bool, error) {
+	return u.sitemapService.RefreshSitemaps(ctx)
+}
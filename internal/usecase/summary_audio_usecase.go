@@ -0,0 +1,17 @@
+package usecase
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// SummaryAudioUsecase defines the interface for streaming summary audio and syncing the listening position
+type SummaryAudioUsecase interface {
+	// AuthorizeAudio returns the audio to stream to the user, who is nil when anonymous,
+	// or nil if the summary does not exist
+	AuthorizeAudio(ctx context.Context, user *entity.User, summaryID string) (*entity.SummaryAudio, error)
+	// UpdateProgress stores a listening position unless another device reported a later one,
+	// returning the stored position and whether this one was applied
+	UpdateProgress(ctx context.Context, user *entity.User, progress *entity.ListeningProgress) (*entity.ListeningProgress, bool, error)
+	GetProgress(ctx context.Context, user *entity.User, summaryID string) (*entity.ListeningProgress, error)
+}
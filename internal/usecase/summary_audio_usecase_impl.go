@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/service"
+	"context"
+	"errors"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+// defaultAudioContentType is assumed for hand-entered audio URLs without a known extension
+const defaultAudioContentType = "audio/mpeg"
+
+var (
+	// ErrSummaryPremiumRequired is returned when a user without premium access plays a premium summary
+	ErrSummaryPremiumRequired = errors.New(constant.ERR_SUMMARY_PREMIUM_REQUIRED)
+	// ErrSummaryAudioMissing is returned for summaries with neither uploaded audio nor an audio URL
+	ErrSummaryAudioMissing = errors.New(constant.ERR_SUMMARY_AUDIO_MISSING)
+)
+
+type summaryAudioUsecase struct {
+	summaryAudioService service.SummaryAudioService
+	ebookService        service.EbookService
+	entitlementService  service.EntitlementService
+	mediaService        service.MediaService
+}
+
+func NewSummaryAudioUsecase(
+	summaryAudioService service.SummaryAudioService,
+	ebookService service.EbookService,
+	entitlementService service.EntitlementService,
+	mediaService service.MediaService,
+) SummaryAudioUsecase {
+	return &summaryAudioUsecase{
+		summaryAudioService: summaryAudioService,
+		ebookService:        ebookService,
+		entitlementService:  entitlementService,
+		mediaService:        mediaService,
+	}
+}
+
+func (u *summaryAudioUsecase) AuthorizeAudio(ctx context.Context, user *entity.User, summaryID string) (*entity.SummaryAudio, error) {
+	audio, err := u.getAccessibleAudio(ctx, user, summaryID)
+	if err != nil || audio == nil {
+		return nil, err
+	}
+
+	// Uploaded audio takes precedence over a hand-entered location
+	switch {
+	case audio.AudioMediaKey != nil && *audio.AudioMediaKey != "":
+		audio.Location, err = u.mediaService.FileLocation(*audio.AudioMediaKey)
+		if err != nil {
+			return nil, err
+		}
+	case audio.AudioURL != "":
+		audio.Location = audio.AudioURL
+	default:
+		return nil, ErrSummaryAudioMissing
+	}
+
+	if audio.AudioContentType == nil || *audio.AudioContentType == "" {
+		contentType := audioContentType(audio.AudioURL)
+		audio.AudioContentType = &contentType
+	}
+
+	return audio, nil
+}
+
+func (u *summaryAudioUsecase) UpdateProgress(ctx context.Context, user *entity.User, progress *entity.ListeningProgress) (*entity.ListeningProgress, bool, error) {
+	if progress.PositionSeconds < 0 {
+		return nil, false, &ValidationError{Message: constant.ERR_LISTENING_POSITION}
+	}
+	if progress.DurationSeconds != nil && (*progress.DurationSeconds <= 0 || *progress.DurationSeconds < progress.PositionSeconds) {
+		return nil, false, &ValidationError{Message: constant.ERR_LISTENING_DURATION}
+	}
+
+	// A device clock running ahead would otherwise win every later sync
+	now := time.Now()
+	if progress.ClientUpdatedAt.IsZero() || progress.ClientUpdatedAt.After(now) {
+		progress.ClientUpdatedAt = now
+	}
+	// Stored with millisecond precision, so compare at that precision too
+	progress.ClientUpdatedAt = progress.ClientUpdatedAt.UTC().Truncate(time.Millisecond)
+
+	audio, err := u.getAccessibleAudio(ctx, user, progress.SummaryID)
+	if err != nil || audio == nil {
+		return nil, false, err
+	}
+
+	progress.UserID = user.ID
+	return u.summaryAudioService.SaveProgress(ctx, progress)
+}
+
+func (u *summaryAudioUsecase) GetProgress(ctx context.Context, user *entity.User, summaryID string) (*entity.ListeningProgress, error) {
+	if summaryID == "" {
+		return nil, &ValidationError{Message: constant.SUMMARY_ID_REQUIRED}
+	}
+
+	return u.summaryAudioService.GetProgress(ctx, user.ID, summaryID)
+}
+
+// getAccessibleAudio returns the summary's audio if the user may play it, or nil if the summary does not exist
+// or its ebook is not published yet, which only editors get past.
+// Regular summaries are open to everyone, premium ones need premium access.
+func (u *summaryAudioUsecase) getAccessibleAudio(ctx context.Context, user *entity.User, summaryID string) (*entity.SummaryAudio, error) {
+	if summaryID == "" {
+		return nil, &ValidationError{Message: constant.SUMMARY_ID_REQUIRED}
+	}
+
+	audio, err := u.summaryAudioService.GetAudio(ctx, summaryID)
+	if err != nil || audio == nil {
+		return nil, err
+	}
+
+	ebook, err := u.ebookService.GetEbookByID(ctx, audio.EbookID)
+	if err != nil || ebook == nil {
+		return nil, err
+	}
+	if !ebook.IsPublished(time.Now()) {
+		editor, err := u.entitlementService.CanEditEbooks(ctx, user)
+		if err != nil || !editor {
+			return nil, err
+		}
+	}
+
+	if !audio.Premium {
+		return audio, nil
+	}
+
+	premium, err := u.entitlementService.HasPremiumAccess(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if !premium {
+		return nil, ErrSummaryPremiumRequired
+	}
+
+	return audio, nil
+}
+
+// audioContentType guesses the type of a hand-entered audio URL from its extension
+func audioContentType(audioURL string) string {
+	if parsed, err := url.Parse(audioURL); err == nil {
+		audioURL = parsed.Path
+	}
+	ext := strings.ToLower(path.Ext(audioURL))
+	for contentType, mediaExt := range mediaExtensions {
+		if strings.HasPrefix(contentType, "audio/") && mediaExt == ext {
+			return contentType
+		}
+	}
+	return defaultAudioContentType
+}
@@ -0,0 +1,202 @@
+package usecase
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// MockSummaryAudioService serves fixed audio and keeps listening progress in memory under last-writer-wins
+type MockSummaryAudioService struct {
+	audio    map[string]*entity.SummaryAudio
+	progress map[string]*entity.ListeningProgress
+	err      error
+}
+
+func (m *MockSummaryAudioService) GetAudio(ctx context.Context, summaryID string) (*entity.SummaryAudio, error) {
+	return m.audio[summaryID], m.err
+}
+
+func (m *MockSummaryAudioService) SaveProgress(ctx context.Context, progress *entity.ListeningProgress) (*entity.ListeningProgress, bool, error) {
+	if m.progress == nil {
+		m.progress = map[string]*entity.ListeningProgress{}
+	}
+	key := progress.UserID + "/" + progress.SummaryID
+	if stored := m.progress[key]; !progress.IsNewerThan(stored) {
+		return stored, false, m.err
+	}
+	m.progress[key] = progress
+	return progress, true, m.err
+}
+
+func (m *MockSummaryAudioService) GetProgress(ctx context.Context, userID, summaryID string) (*entity.ListeningProgress, error) {
+	return m.progress[userID+"/"+summaryID], m.err
+}
+
+func newMockSummaryAudioService() *MockSummaryAudioService {
+	mediaKey := "audio/summary-1.m4a"
+	mediaType := "audio/mp4"
+	return &MockSummaryAudioService{
+		audio: map[string]*entity.SummaryAudio{
+			"summary-1": {SummaryID: "summary-1", EbookID: "ebook-1", AudioMediaKey: &mediaKey, AudioContentType: &mediaType},
+			"summary-2": {SummaryID: "summary-2", EbookID: "ebook-1", AudioURL: "https://cdn.test/summary-2.ogg?v=2"},
+			"premium-1": {SummaryID: "premium-1", EbookID: "ebook-1", Premium: true, AudioURL: "audio/premium-1"},
+			"silent-1":  {SummaryID: "silent-1", EbookID: "ebook-1"},
+		},
+	}
+}
+
+// newPublishedEbookService serves ebook-1, the ebook of every mock summary, as published
+func newPublishedEbookService() *MockEbookService {
+	publishedAt := time.Now().Add(-time.Hour)
+	return &MockEbookService{ebook: &entity.Ebook{ID: "ebook-1", ContentStatus: entity.ContentStatusPublished, PublishedAt: &publishedAt}}
+}
+
+func TestSummaryAudioUsecase_AuthorizeAudio(t *testing.T) {
+	ctx := context.Background()
+	entitlementService := &MockEntitlementService{premium: map[string]bool{"premium": true}}
+	u := NewSummaryAudioUsecase(newMockSummaryAudioService(), newPublishedEbookService(), entitlementService, NewMockMediaService())
+
+	tests := []struct {
+		name         string
+		user         *entity.User
+		summaryID    string
+		wantLocation string
+		wantType     string
+		wantErr      error
+	}{
+		{name: "uploaded audio for anonymous listeners", summaryID: "summary-1", wantLocation: "audio/summary-1.m4a", wantType: "audio/mp4"},
+		{name: "audio URL typed by extension", summaryID: "summary-2", wantLocation: "https://cdn.test/summary-2.ogg?v=2", wantType: "audio/ogg"},
+		{name: "premium audio for premium users", user: &entity.User{ID: "premium"}, summaryID: "premium-1", wantLocation: "audio/premium-1", wantType: "audio/mpeg"},
+		{name: "premium audio for anonymous listeners", summaryID: "premium-1", wantErr: ErrSummaryPremiumRequired},
+		{name: "premium audio for other users", user: &entity.User{ID: "reader"}, summaryID: "premium-1", wantErr: ErrSummaryPremiumRequired},
+		{name: "summary without audio", summaryID: "silent-1", wantErr: ErrSummaryAudioMissing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			audio, err := u.AuthorizeAudio(ctx, tt.user, tt.summaryID)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if audio.Location != tt.wantLocation || *audio.AudioContentType != tt.wantType {
+				t.Errorf("expected %s (%s), got %s (%s)", tt.wantLocation, tt.wantType, audio.Location, *audio.AudioContentType)
+			}
+		})
+	}
+
+	t.Run("should return nil for an unknown summary", func(t *testing.T) {
+		audio, err := u.AuthorizeAudio(ctx, nil, "missing")
+		if err != nil || audio != nil {
+			t.Errorf("expected no audio, got %+v (%v)", audio, err)
+		}
+	})
+}
+
+func TestSummaryAudioUsecase_UpdateProgress(t *testing.T) {
+	ctx := context.Background()
+	reader := &entity.User{ID: "reader"}
+	duration := func(seconds int) *int { return &seconds }
+
+	t.Run("should keep the position reported last", func(t *testing.T) {
+		u := NewSummaryAudioUsecase(newMockSummaryAudioService(), newPublishedEbookService(), &MockEntitlementService{}, NewMockMediaService())
+		now := time.Now()
+
+		stored, applied, err := u.UpdateProgress(ctx, reader, &entity.ListeningProgress{SummaryID: "summary-1", PositionSeconds: 120, ClientUpdatedAt: now})
+		if err != nil || !applied || stored.UserID != "reader" {
+			t.Fatalf("expected the position to be applied, got %+v (%v)", stored, err)
+		}
+
+		stored, applied, err = u.UpdateProgress(ctx, reader, &entity.ListeningProgress{SummaryID: "summary-1", PositionSeconds: 30, ClientUpdatedAt: now.Add(-time.Minute)})
+		if err != nil || applied || stored.PositionSeconds != 120 {
+			t.Errorf("expected the later position to be kept, got %+v (applied %v, %v)", stored, applied, err)
+		}
+
+		progress, err := u.GetProgress(ctx, reader, "summary-1")
+		if err != nil || progress.PositionSeconds != 120 {
+			t.Errorf("expected position 120, got %+v (%v)", progress, err)
+		}
+	})
+
+	t.Run("should not trust device clocks running ahead", func(t *testing.T) {
+		u := NewSummaryAudioUsecase(newMockSummaryAudioService(), newPublishedEbookService(), &MockEntitlementService{}, NewMockMediaService())
+
+		stored, _, err := u.UpdateProgress(ctx, reader, &entity.ListeningProgress{SummaryID: "summary-1", PositionSeconds: 10, ClientUpdatedAt: time.Now().Add(time.Hour)})
+		if err != nil || stored.ClientUpdatedAt.After(time.Now()) {
+			t.Errorf("expected the time to be capped at now, got %+v (%v)", stored, err)
+		}
+	})
+
+	invalid := []struct {
+		name     string
+		progress *entity.ListeningProgress
+	}{
+		{"negative position", &entity.ListeningProgress{SummaryID: "summary-1", PositionSeconds: -1}},
+		{"zero duration", &entity.ListeningProgress{SummaryID: "summary-1", DurationSeconds: duration(0)}},
+		{"position past the duration", &entity.ListeningProgress{SummaryID: "summary-1", PositionSeconds: 90, DurationSeconds: duration(60)}},
+		{"missing summary ID", &entity.ListeningProgress{PositionSeconds: 10}},
+	}
+	for _, tt := range invalid {
+		t.Run("should reject "+tt.name, func(t *testing.T) {
+			u := NewSummaryAudioUsecase(newMockSummaryAudioService(), newPublishedEbookService(), &MockEntitlementService{}, NewMockMediaService())
+
+			var validationErr *ValidationError
+			if _, _, err := u.UpdateProgress(ctx, reader, tt.progress); !errors.As(err, &validationErr) {
+				t.Errorf("expected a validation error, got %v", err)
+			}
+		})
+	}
+
+	t.Run("should require premium access for premium summaries", func(t *testing.T) {
+		u := NewSummaryAudioUsecase(newMockSummaryAudioService(), newPublishedEbookService(), &MockEntitlementService{}, NewMockMediaService())
+
+		if _, _, err := u.UpdateProgress(ctx, reader, &entity.ListeningProgress{SummaryID: "premium-1", PositionSeconds: 10}); !errors.Is(err, ErrSummaryPremiumRequired) {
+			t.Errorf("expected ErrSummaryPremiumRequired, got %v", err)
+		}
+	})
+
+	t.Run("should return nil for an unknown summary", func(t *testing.T) {
+		u := NewSummaryAudioUsecase(newMockSummaryAudioService(), newPublishedEbookService(), &MockEntitlementService{}, NewMockMediaService())
+
+		stored, _, err := u.UpdateProgress(ctx, reader, &entity.ListeningProgress{SummaryID: "missing", PositionSeconds: 10})
+		if err != nil || stored != nil {
+			t.Errorf("expected nothing stored, got %+v (%v)", stored, err)
+		}
+	})
+}
+
+func TestSummaryAudioUsecase_UnpublishedEbook(t *testing.T) {
+	ctx := context.Background()
+	scheduledAt := time.Now().Add(time.Hour)
+	entitlementService := &MockEntitlementService{premium: map[string]bool{"premium": true}, editors: map[string]bool{"editor": true}}
+
+	for _, ebook := range []*entity.Ebook{
+		{ID: "ebook-1", ContentStatus: entity.ContentStatusDraft},
+		{ID: "ebook-1", ContentStatus: entity.ContentStatusPublished, PublishedAt: &scheduledAt},
+	} {
+		summaryAudioService := newMockSummaryAudioService()
+		u := NewSummaryAudioUsecase(summaryAudioService, &MockEbookService{ebook: ebook}, entitlementService, NewMockMediaService())
+
+		for _, user := range []*entity.User{nil, {ID: "premium"}} {
+			if audio, err := u.AuthorizeAudio(ctx, user, "summary-1"); err != nil || audio != nil {
+				t.Errorf("expected the audio of a %s ebook to be hidden from %+v, got %+v (%v)", ebook.ContentStatus, user, audio, err)
+			}
+		}
+		stored, _, err := u.UpdateProgress(ctx, &entity.User{ID: "premium"}, &entity.ListeningProgress{SummaryID: "summary-1", PositionSeconds: 10})
+		if err != nil || stored != nil || len(summaryAudioService.progress) != 0 {
+			t.Errorf("expected no progress stored for a %s ebook, got %+v (%v)", ebook.ContentStatus, stored, err)
+		}
+
+		if audio, err := u.AuthorizeAudio(ctx, &entity.User{ID: "editor"}, "summary-1"); err != nil || audio == nil {
+			t.Errorf("expected an editor to play the audio, got %+v (%v)", audio, err)
+		}
+	}
+}
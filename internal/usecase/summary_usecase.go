@@ -13,6 +13,7 @@ type SummaryUsecase interface {
 	UpdateSummary(ctx context.Context, summary *entity.EbookSummary) error
 	DeleteSummary(ctx context.Context, id string) error
 	ListSummaries(ctx context.Context, limit, offset int) ([]*response.EbookSummaryResponse, error)
+	ListSummariesByCursor(ctx context.Context, cursor *entity.PageCursor, limit int) ([]*response.EbookSummaryResponse, *entity.CursorPageInfo, error)
 	GetSummariesByEbookID(ctx context.Context, ebookID string, limit, offset int) ([]*entity.EbookSummary, error)
 	CountSummaries(ctx context.Context) (int64, error)
 	CountSummariesByEbookID(ctx context.Context, ebookID string) (int64, error)
@@ -68,6 +68,19 @@ func (u *SummaryUsecaseImpl) ListSummaries(ctx context.Context, limit, offset in
 	return u.summaryService.ListSummaries(ctx, limit, offset)
 }
 
+func (u *SummaryUsecaseImpl) ListSummariesByCursor(ctx context.Context, cursor *entity.PageCursor, limit int) ([]*response.EbookSummaryResponse, *entity.CursorPageInfo, error) {
+	if err := checkCursor(cursor, entity.CursorSortSummaries, ""); err != nil {
+		return nil, nil, err
+	}
+
+	summaries, page, err := u.summaryService.ListSummariesByCursor(ctx, cursor, limit)
+	if err != nil {
+		return nil, nil, cursorValueError(err)
+	}
+
+	return summaries, page, nil
+}
+
 func (u *SummaryUsecaseImpl) GetSummariesByEbookID(ctx context.Context, ebookID string, limit, offset int) ([]*entity.EbookSummary, error) {
 	if ebookID == "" {
 		return nil, &ValidationError{Message: constant.EBOOK_ID_REQUIRED_VALIDATION}
@@ -0,0 +1,15 @@
+package usecase
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// TableOfContentUsecase defines the interface for table of content use cases
+type TableOfContentUsecase interface {
+	// GetTableOfContents returns the top-level entries with their chapters nested in Children;
+	// an ebook that is not published yet is only shown to users who may edit ebooks
+	GetTableOfContents(ctx context.Context, user *entity.User, ebookID string) ([]*entity.TableOfContent, error)
+	// ReplaceTableOfContents swaps the whole table of contents for the given tree and returns it with IDs assigned
+	ReplaceTableOfContents(ctx context.Context, ebookID string, entries []*entity.TableOfContent) ([]*entity.TableOfContent, error)
+}
@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/service"
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxTableOfContentDepth   = 6
+	maxTableOfContentEntries = 2000
+	maxTableOfContentTitle   = 255
+)
+
+type tableOfContentUsecase struct {
+	ebookService          service.EbookService
+	tableOfContentService service.TableOfContentService
+	entitlementService    service.EntitlementService
+}
+
+func NewTableOfContentUsecase(ebookService service.EbookService, tableOfContentService service.TableOfContentService, entitlementService service.EntitlementService) TableOfContentUsecase {
+	return &tableOfContentUsecase{
+		ebookService:          ebookService,
+		tableOfContentService: tableOfContentService,
+		entitlementService:    entitlementService,
+	}
+}
+
+func (u *tableOfContentUsecase) GetTableOfContents(ctx context.Context, user *entity.User, ebookID string) ([]*entity.TableOfContent, error) {
+	ebook, err := u.requireEbook(ctx, ebookID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The chapters of a draft or scheduled ebook are only shown to editors
+	if !ebook.IsPublished(time.Now()) {
+		editor, err := u.entitlementService.CanEditEbooks(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+		if !editor {
+			return nil, ErrEbookNotFound
+		}
+	}
+
+	entries, err := u.tableOfContentService.GetTableOfContents(ctx, ebookID)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.BuildTableOfContentTree(entries), nil
+}
+
+func (u *tableOfContentUsecase) ReplaceTableOfContents(ctx context.Context, ebookID string, entries []*entity.TableOfContent) ([]*entity.TableOfContent, error) {
+	ebook, err := u.requireEbook(ctx, ebookID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Flatten depth-first so every parent is written before its children
+	var flat []*entity.TableOfContent
+	var walk func(siblings []*entity.TableOfContent, parentID *string, depth int) error
+	walk = func(siblings []*entity.TableOfContent, parentID *string, depth int) error {
+		if len(siblings) > 0 && depth >= maxTableOfContentDepth {
+			return &ValidationError{Message: constant.ERR_TOC_TOO_DEEP}
+		}
+		for i, entry := range siblings {
+			entry.Title = strings.TrimSpace(entry.Title)
+			if err := validateTableOfContentEntry(entry, ebook); err != nil {
+				return err
+			}
+
+			entry.ID = uuid.New().String()
+			entry.EbookID = ebookID
+			entry.ParentID = parentID
+			entry.OrderNumber = i
+			entry.Depth = depth
+			flat = append(flat, entry)
+			if len(flat) > maxTableOfContentEntries {
+				return &ValidationError{Message: constant.ERR_TOC_TOO_MANY_ENTRIES}
+			}
+
+			id := entry.ID
+			if err := walk(entry.Children, &id, depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(entries, nil, 0); err != nil {
+		return nil, err
+	}
+
+	if err := u.tableOfContentService.ReplaceTableOfContents(ctx, ebookID, flat); err != nil {
+		return nil, err
+	}
+
+	if entries == nil {
+		entries = []*entity.TableOfContent{}
+	}
+	return entries, nil
+}
+
+func validateTableOfContentEntry(entry *entity.TableOfContent, ebook *entity.Ebook) error {
+	if entry.Title == "" {
+		return &ValidationError{Message: constant.ERR_TOC_TITLE_REQUIRED}
+	}
+	if utf8.RuneCountInString(entry.Title) > maxTableOfContentTitle {
+		return &ValidationError{Message: constant.ERR_TOC_TITLE_TOO_LONG}
+	}
+	if entry.PageNumber < 1 {
+		return &ValidationError{Message: constant.ERR_TOC_PAGE_INVALID}
+	}
+	if ebook.PageCount > 0 && entry.PageNumber > ebook.PageCount {
+		return &ValidationError{Message: constant.ERR_TOC_PAGE_OUT_OF_RANGE}
+	}
+	return nil
+}
+
+func (u *tableOfContentUsecase) requireEbook(ctx context.Context, ebookID string) (*entity.Ebook, error) {
+	if ebookID == "" {
+		return nil, &ValidationError{Message: constant.EBOOK_ID_REQUIRED}
+	}
+
+	ebook, err := u.ebookService.GetEbookByID(ctx, ebookID)
+	if err != nil {
+		return nil, err
+	}
+	if ebook == nil {
+		return nil, ErrEbookNotFound
+	}
+
+	return ebook, nil
+}
@@ -0,0 +1,186 @@
+package usecase
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// MockTableOfContentService stores the last replaced entries flat, like the table
+type MockTableOfContentService struct {
+	entries []*entity.TableOfContent
+}
+
+func (m *MockTableOfContentService) GetTableOfContents(ctx context.Context, ebookID string) ([]*entity.TableOfContent, error) {
+	var entries []*entity.TableOfContent
+	for _, entry := range m.entries {
+		stored := *entry
+		stored.Children = nil
+		entries = append(entries, &stored)
+	}
+	return entries, nil
+}
+
+func (m *MockTableOfContentService) ReplaceTableOfContents(ctx context.Context, ebookID string, entries []*entity.TableOfContent) error {
+	m.entries = entries
+	return nil
+}
+
+func tocEntry(title string, page int16, children ...*entity.TableOfContent) *entity.TableOfContent {
+	return &entity.TableOfContent{Title: title, PageNumber: page, Children: children}
+}
+
+func TestTableOfContentUsecase_ReplaceTableOfContents(t *testing.T) {
+	publishedAt := time.Now().Add(-time.Hour)
+	tocService := &MockTableOfContentService{}
+	ebook := &entity.Ebook{ID: "ebook-1", PageCount: 100, ContentStatus: entity.ContentStatusPublished, PublishedAt: &publishedAt}
+	u := NewTableOfContentUsecase(&MockEbookService{ebook: ebook}, tocService, &MockEntitlementService{})
+	ctx := context.Background()
+
+	_, err := u.ReplaceTableOfContents(ctx, "ebook-1", []*entity.TableOfContent{
+		tocEntry(" Part One ", 1,
+			tocEntry("Chapter 1", 2, tocEntry("Section 1.1", 3)),
+			tocEntry("Chapter 2", 10),
+		),
+		tocEntry("Part Two", 50),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Entries are written parents first, with their position in the tree
+	want := []struct {
+		title  string
+		parent string
+		order  int
+		depth  int
+	}{
+		{"Part One", "", 0, 0},
+		{"Chapter 1", "Part One", 0, 1},
+		{"Section 1.1", "Chapter 1", 0, 2},
+		{"Chapter 2", "Part One", 1, 1},
+		{"Part Two", "", 1, 0},
+	}
+	if len(tocService.entries) != len(want) {
+		t.Fatalf("expected %d entries, got %d", len(want), len(tocService.entries))
+	}
+	titles := make(map[string]string)
+	for i, entry := range tocService.entries {
+		titles[entry.ID] = entry.Title
+		parent := ""
+		if entry.ParentID != nil {
+			parent = titles[*entry.ParentID]
+		}
+		if entry.Title != want[i].title || parent != want[i].parent || entry.OrderNumber != want[i].order || entry.Depth != want[i].depth {
+			t.Errorf("entry %d = %q under %q order %d depth %d, want %+v", i, entry.Title, parent, entry.OrderNumber, entry.Depth, want[i])
+		}
+		if entry.EbookID != "ebook-1" {
+			t.Errorf("expected ebook ID to be set, got %q", entry.EbookID)
+		}
+	}
+
+	// Reading it back nests the flat rows again
+	tree, err := u.GetTableOfContents(ctx, nil, "ebook-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tree) != 2 || len(tree[0].Children) != 2 || len(tree[0].Children[0].Children) != 1 {
+		t.Errorf("unexpected tree shape %+v", tree)
+	}
+}
+
+func TestTableOfContentUsecase_GetTableOfContents_Unpublished(t *testing.T) {
+	ctx := context.Background()
+	scheduledAt := time.Now().Add(time.Hour)
+	tocService := &MockTableOfContentService{entries: []*entity.TableOfContent{{ID: "chapter-1", Title: "Chapter 1"}}}
+	entitlementService := &MockEntitlementService{premium: map[string]bool{"premium": true}, editors: map[string]bool{"editor": true}}
+
+	for _, ebook := range []*entity.Ebook{
+		{ID: "ebook-1", ContentStatus: entity.ContentStatusDraft},
+		{ID: "ebook-1", ContentStatus: entity.ContentStatusPublished, PublishedAt: &scheduledAt},
+	} {
+		u := NewTableOfContentUsecase(&MockEbookService{ebook: ebook}, tocService, entitlementService)
+
+		for _, user := range []*entity.User{nil, {ID: "premium"}} {
+			if _, err := u.GetTableOfContents(ctx, user, "ebook-1"); !errors.Is(err, ErrEbookNotFound) {
+				t.Errorf("expected the chapters of a %s ebook to be hidden from %+v, got %v", ebook.ContentStatus, user, err)
+			}
+		}
+
+		tree, err := u.GetTableOfContents(ctx, &entity.User{ID: "editor"}, "ebook-1")
+		if err != nil || len(tree) != 1 {
+			t.Errorf("expected an editor to see the chapters, got %+v, %v", tree, err)
+		}
+	}
+}
+
+func TestTableOfContentUsecase_ReplaceTableOfContents_Validation(t *testing.T) {
+	deep := tocEntry("Level 6", 1)
+	for i := 5; i >= 0; i-- {
+		deep = tocEntry("Level", 1, deep)
+	}
+
+	tests := []struct {
+		name    string
+		ebookID string
+		entries []*entity.TableOfContent
+		wantErr error
+	}{
+		{
+			name:    "should reject an entry without a title",
+			ebookID: "ebook-1",
+			entries: []*entity.TableOfContent{tocEntry("Part", 1, tocEntry("  ", 2))},
+			wantErr: &ValidationError{},
+		},
+		{
+			name:    "should reject a page beyond the page count",
+			ebookID: "ebook-1",
+			entries: []*entity.TableOfContent{tocEntry("Epilogue", 101)},
+			wantErr: &ValidationError{},
+		},
+		{
+			name:    "should reject nesting deeper than six levels",
+			ebookID: "ebook-1",
+			entries: []*entity.TableOfContent{deep},
+			wantErr: &ValidationError{},
+		},
+		{
+			name:    "should reject an unknown ebook",
+			ebookID: "missing",
+			entries: []*entity.TableOfContent{tocEntry("Part", 1)},
+			wantErr: ErrEbookNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			existing := &entity.TableOfContent{ID: "kept", Title: "Kept"}
+			tocService := &MockTableOfContentService{entries: []*entity.TableOfContent{existing}}
+			ebookService := &MockEbookService{
+				getByIDFunc: func(ctx context.Context, id string) (*entity.Ebook, error) {
+					if id != "ebook-1" {
+						return nil, nil
+					}
+					return &entity.Ebook{ID: id, PageCount: 100}, nil
+				},
+			}
+			u := NewTableOfContentUsecase(ebookService, tocService, &MockEntitlementService{})
+
+			_, err := u.ReplaceTableOfContents(context.Background(), tt.ebookID, tt.entries)
+
+			var validationErr *ValidationError
+			if errors.As(tt.wantErr, &validationErr) {
+				if !errors.As(err, &validationErr) {
+					t.Errorf("expected validation error, got %v", err)
+				}
+			} else if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+			if len(tocService.entries) != 1 || tocService.entries[0] != existing {
+				t.Error("expected the existing table of contents to be left untouched")
+			}
+		})
+	}
+}
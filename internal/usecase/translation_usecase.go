@@ -0,0 +1,15 @@
+package usecase
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// TranslationUsecase defines the interface for content translation use cases.
+// Editors need the update permission of the content they translate.
+type TranslationUsecase interface {
+	// SaveTranslation creates or replaces the translation of the content in its locale
+	SaveTranslation(ctx context.Context, userID string, translation *entity.Translation) error
+	ListTranslations(ctx context.Context, userID string, contentType entity.TranslatableContent, contentID string) ([]*entity.Translation, error)
+	DeleteTranslation(ctx context.Context, userID string, contentType entity.TranslatableContent, contentID, locale string) error
+}
@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/service"
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	// ErrTranslationNotFound is returned when deleting a translation that does not exist
+	ErrTranslationNotFound = errors.New(constant.ERR_TRANSLATION_NOT_FOUND)
+	// ErrTranslatedContentNotFound is returned when the content to translate does not exist
+	ErrTranslatedContentNotFound = errors.New(constant.ERR_TRANSLATION_CONTENT_GONE)
+	// ErrTranslationForbidden is returned when the user may not update the translated content
+	ErrTranslationForbidden = errors.New(constant.ERR_TRANSLATION_FORBIDDEN)
+)
+
+type translationUsecase struct {
+	translationService service.TranslationService
+	permissionService  service.PermissionService
+	locales            entity.LocaleSet
+}
+
+// NewTranslationUsecase creates a new instance of TranslationUsecase.
+// Content can be translated into the supported locales other than the default one.
+func NewTranslationUsecase(
+	translationService service.TranslationService,
+	permissionService service.PermissionService,
+	locales entity.LocaleSet,
+) TranslationUsecase {
+	return &translationUsecase{
+		translationService: translationService,
+		permissionService:  permissionService,
+		locales:            locales,
+	}
+}
+
+func (u *translationUsecase) SaveTranslation(ctx context.Context, userID string, translation *entity.Translation) error {
+	translation.Locale = strings.ToLower(strings.TrimSpace(translation.Locale))
+	if err := u.validateTarget(translation.ContentType, translation.ContentID); err != nil {
+		return err
+	}
+	if !u.locales.IsTranslation(translation.Locale) {
+		return &ValidationError{Message: constant.ERR_TRANSLATION_LOCALE}
+	}
+	if err := validateTranslationFields(translation); err != nil {
+		return err
+	}
+
+	if err := u.checkPermission(ctx, userID, translation.ContentType); err != nil {
+		return err
+	}
+	if err := u.checkContentExists(ctx, translation.ContentType, translation.ContentID); err != nil {
+		return err
+	}
+
+	return u.translationService.SaveTranslation(ctx, translation)
+}
+
+func (u *translationUsecase) ListTranslations(ctx context.Context, userID string, contentType entity.TranslatableContent, contentID string) ([]*entity.Translation, error) {
+	if err := u.validateTarget(contentType, contentID); err != nil {
+		return nil, err
+	}
+	if err := u.checkPermission(ctx, userID, contentType); err != nil {
+		return nil, err
+	}
+	if err := u.checkContentExists(ctx, contentType, contentID); err != nil {
+		return nil, err
+	}
+
+	return u.translationService.ListTranslations(ctx, contentType, contentID)
+}
+
+func (u *translationUsecase) DeleteTranslation(ctx context.Context, userID string, contentType entity.TranslatableContent, contentID, locale string) error {
+	if err := u.validateTarget(contentType, contentID); err != nil {
+		return err
+	}
+	if err := u.checkPermission(ctx, userID, contentType); err != nil {
+		return err
+	}
+
+	deleted, err := u.translationService.DeleteTranslation(ctx, contentType, contentID, strings.ToLower(locale))
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrTranslationNotFound
+	}
+	return nil
+}
+
+func (u *translationUsecase) validateTarget(contentType entity.TranslatableContent, contentID string) error {
+	if !contentType.IsValid() {
+		return &ValidationError{Message: constant.ERR_TRANSLATION_CONTENT_TYPE}
+	}
+	if contentID == "" {
+		return &ValidationError{Message: constant.ERR_ID_REQUIRED}
+	}
+	return nil
+}
+
+// validateTranslationFields trims the translated text and drops empty fields, which fall back
+// to the text in the default language
+func validateTranslationFields(translation *entity.Translation) error {
+	limits := translation.ContentType.Fields()
+	fields := make(map[string]string, len(translation.Fields))
+	for field, text := range translation.Fields {
+		limit, ok := limits[field]
+		if !ok {
+			return &ValidationError{Message: constant.ERR_TRANSLATION_FIELD + ": " + field}
+		}
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
+		}
+		if limit > 0 && utf8.RuneCountInString(text) > limit {
+			return &ValidationError{Message: constant.ERR_TRANSLATION_TOO_LONG + ": " + field}
+		}
+		fields[field] = text
+	}
+	if len(fields) == 0 {
+		return &ValidationError{Message: constant.ERR_TRANSLATION_FIELDS}
+	}
+
+	translation.Fields = fields
+	return nil
+}
+
+func (u *translationUsecase) checkPermission(ctx context.Context, userID string, contentType entity.TranslatableContent) error {
+	allowed, err := u.permissionService.HasPermission(ctx, userID, contentType.UpdatePermission())
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrTranslationForbidden
+	}
+	return nil
+}
+
+func (u *translationUsecase) checkContentExists(ctx context.Context, contentType entity.TranslatableContent, contentID string) error {
+	exists, err := u.translationService.ContentExists(ctx, contentType, contentID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrTranslatedContentNotFound
+	}
+	return nil
+}
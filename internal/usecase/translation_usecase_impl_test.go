@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// MockTranslationService keeps translations in memory, keyed by content id and locale
+type MockTranslationService struct {
+	content      map[string]bool
+	translations map[string]*entity.Translation
+}
+
+func NewMockTranslationService(contentIDs ...string) *MockTranslationService {
+	m := &MockTranslationService{content: map[string]bool{}, translations: map[string]*entity.Translation{}}
+	for _, id := range contentIDs {
+		m.content[id] = true
+	}
+	return m
+}
+
+func (m *MockTranslationService) SaveTranslation(ctx context.Context, translation *entity.Translation) error {
+	m.translations[translation.ContentID+":"+translation.Locale] = translation
+	return nil
+}
+
+func (m *MockTranslationService) ListTranslations(ctx context.Context, contentType entity.TranslatableContent, contentID string) ([]*entity.Translation, error) {
+	var translations []*entity.Translation
+	for _, translation := range m.translations {
+		if translation.ContentType == contentType && translation.ContentID == contentID {
+			translations = append(translations, translation)
+		}
+	}
+	return translations, nil
+}
+
+func (m *MockTranslationService) DeleteTranslation(ctx context.Context, contentType entity.TranslatableContent, contentID, locale string) (bool, error) {
+	key := contentID + ":" + locale
+	if _, ok := m.translations[key]; !ok {
+		return false, nil
+	}
+	delete(m.translations, key)
+	return true, nil
+}
+
+func (m *MockTranslationService) ContentExists(ctx context.Context, contentType entity.TranslatableContent, contentID string) (bool, error) {
+	return m.content[contentID], nil
+}
+
+func newTestTranslationUsecase(granted ...string) (TranslationUsecase, *MockTranslationService) {
+	translationService := NewMockTranslationService("ebook-1")
+	permissions := &stubPermissionService{granted: map[string]bool{}}
+	for _, permission := range granted {
+		permissions.granted[permission] = true
+	}
+	locales := entity.LocaleSet{Default: "id", Supported: []string{"id", "en"}}
+	return NewTranslationUsecase(translationService, permissions, locales), translationService
+}
+
+func TestTranslationUsecase_SaveTranslation(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should save trimmed fields and drop empty ones", func(t *testing.T) {
+		u, translationService := newTestTranslationUsecase(entity.PermissionEbookUpdate)
+		translation := &entity.Translation{
+			ContentType: entity.TranslatableEbook,
+			ContentID:   "ebook-1",
+			Locale:      "EN",
+			Fields:      map[string]string{"title": "  The Little Prince ", "synopsis": "  "},
+		}
+
+		if err := u.SaveTranslation(ctx, "user-1", translation); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		saved := translationService.translations["ebook-1:en"]
+		if saved == nil || len(saved.Fields) != 1 || saved.Fields["title"] != "The Little Prince" {
+			t.Errorf("unexpected translation %+v", saved)
+		}
+	})
+
+	t.Run("should reject invalid translations", func(t *testing.T) {
+		u, _ := newTestTranslationUsecase(entity.PermissionEbookUpdate)
+		tests := []struct {
+			name        string
+			translation *entity.Translation
+		}{
+			{"unknown content type", &entity.Translation{ContentType: "author", ContentID: "ebook-1", Locale: "en", Fields: map[string]string{"title": "x"}}},
+			{"default locale", &entity.Translation{ContentType: entity.TranslatableEbook, ContentID: "ebook-1", Locale: "id", Fields: map[string]string{"title": "x"}}},
+			{"unsupported locale", &entity.Translation{ContentType: entity.TranslatableEbook, ContentID: "ebook-1", Locale: "fr", Fields: map[string]string{"title": "x"}}},
+			{"unknown field", &entity.Translation{ContentType: entity.TranslatableEbook, ContentID: "ebook-1", Locale: "en", Fields: map[string]string{"slug": "x"}}},
+			{"no fields", &entity.Translation{ContentType: entity.TranslatableEbook, ContentID: "ebook-1", Locale: "en", Fields: map[string]string{"title": " "}}},
+			{"too long", &entity.Translation{ContentType: entity.TranslatableEbook, ContentID: "ebook-1", Locale: "en", Fields: map[string]string{"title": strings.Repeat("a", 256)}}},
+		}
+
+		for _, tt := range tests {
+			var validationErr *ValidationError
+			if err := u.SaveTranslation(ctx, "user-1", tt.translation); !errors.As(err, &validationErr) {
+				t.Errorf("%s: expected validation error, got %v", tt.name, err)
+			}
+		}
+	})
+
+	t.Run("should check permission and content", func(t *testing.T) {
+		u, _ := newTestTranslationUsecase(entity.PermissionCategoryUpdate)
+		translation := &entity.Translation{ContentType: entity.TranslatableEbook, ContentID: "ebook-1", Locale: "en", Fields: map[string]string{"title": "x"}}
+		if err := u.SaveTranslation(ctx, "user-1", translation); !errors.Is(err, ErrTranslationForbidden) {
+			t.Errorf("expected ErrTranslationForbidden, got %v", err)
+		}
+
+		u, _ = newTestTranslationUsecase(entity.PermissionEbookUpdate)
+		translation = &entity.Translation{ContentType: entity.TranslatableEbook, ContentID: "missing", Locale: "en", Fields: map[string]string{"title": "x"}}
+		if err := u.SaveTranslation(ctx, "user-1", translation); !errors.Is(err, ErrTranslatedContentNotFound) {
+			t.Errorf("expected ErrTranslatedContentNotFound, got %v", err)
+		}
+	})
+}
+
+func TestTranslationUsecase_DeleteTranslation(t *testing.T) {
+	ctx := context.Background()
+	u, _ := newTestTranslationUsecase(entity.PermissionEbookUpdate)
+
+	translation := &entity.Translation{ContentType: entity.TranslatableEbook, ContentID: "ebook-1", Locale: "en", Fields: map[string]string{"title": "x"}}
+	if err := u.SaveTranslation(ctx, "user-1", translation); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := u.DeleteTranslation(ctx, "user-1", entity.TranslatableEbook, "ebook-1", "en"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := u.DeleteTranslation(ctx, "user-1", entity.TranslatableEbook, "ebook-1", "en"); !errors.Is(err, ErrTranslationNotFound) {
+		t.Errorf("expected ErrTranslationNotFound, got %v", err)
+	}
+}
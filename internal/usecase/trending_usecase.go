@@ -0,0 +1,13 @@
+package usecase
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// TrendingUsecase defines the interface for trending ebook use cases
+type TrendingUsecase interface {
+	// ListTrendingEbooks returns the ebooks with the most recent engagement in the window (24h by default),
+	// optionally within a category, and the number of ranked ebooks
+	ListTrendingEbooks(ctx context.Context, window, categoryID string, limit, offset int) ([]*entity.EbookList, int64, error)
+}
@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/service"
+	"context"
+	"strings"
+)
+
+type trendingUsecase struct {
+	ebookService    service.EbookService
+	trendingService service.TrendingService
+}
+
+func NewTrendingUsecase(ebookService service.EbookService, trendingService service.TrendingService) TrendingUsecase {
+	return &trendingUsecase{
+		ebookService:    ebookService,
+		trendingService: trendingService,
+	}
+}
+
+// ListTrendingEbooks falls back to the most popular ebooks when nothing was ranked in the window,
+// e.g. on a new install or while Redis is unavailable
+func (u *trendingUsecase) ListTrendingEbooks(ctx context.Context, window, categoryID string, limit, offset int) ([]*entity.EbookList, int64, error) {
+	trendingWindow := entity.TrendingWindow(strings.TrimSpace(window))
+	if trendingWindow == "" {
+		trendingWindow = entity.TrendingWindowDay
+	}
+	if !trendingWindow.IsValid() {
+		return nil, 0, &ValidationError{Message: constant.ERR_TRENDING_WINDOW_INVALID}
+	}
+	if limit <= 0 {
+		limit = 10 // default limit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	ebooks, total, err := u.trendingService.GetTrendingEbooks(ctx, trendingWindow, categoryID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	if total > 0 {
+		return ebooks, total, nil
+	}
+
+	filter := &entity.EbookFilter{CategoryID: categoryID, Sort: entity.EbookSortPopularity}
+	filter.Normalize()
+
+	ebooks, err = u.ebookService.GetEbookList(ctx, filter, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err = u.ebookService.GetEbookCount(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return ebooks, total, nil
+}
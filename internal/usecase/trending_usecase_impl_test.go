@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+	"errors"
+	"testing"
+)
+
+// MockTrendingService serves a fixed ranking and records engagement per event
+type MockTrendingService struct {
+	ranked []*entity.EbookList
+	window entity.TrendingWindow
+	events map[entity.EngagementEvent][]string
+	actors []string
+	err    error
+}
+
+func (m *MockTrendingService) RecordEngagement(ctx context.Context, ebookID, categoryID string, event entity.EngagementEvent, actorID string) error {
+	if m.events == nil {
+		m.events = map[entity.EngagementEvent][]string{}
+	}
+	m.events[event] = append(m.events[event], ebookID)
+	m.actors = append(m.actors, actorID)
+	return m.err
+}
+
+func (m *MockTrendingService) GetTrendingEbooks(ctx context.Context, window entity.TrendingWindow, categoryID string, limit, offset int) ([]*entity.EbookList, int64, error) {
+	m.window = window
+	return m.ranked, int64(len(m.ranked)), m.err
+}
+
+func (m *MockTrendingService) SnapshotScores(ctx context.Context) (int, error) {
+	return 0, m.err
+}
+
+func TestTrendingUsecase_ListTrendingEbooks(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should rank within the 24h window by default", func(t *testing.T) {
+		trendingService := &MockTrendingService{ranked: []*entity.EbookList{{ID: "ebook-2"}, {ID: "ebook-1"}}}
+		u := NewTrendingUsecase(&MockEbookService{}, trendingService)
+
+		ebooks, total, err := u.ListTrendingEbooks(ctx, "", "", 10, 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if trendingService.window != entity.TrendingWindowDay || total != 2 || ebooks[0].ID != "ebook-2" {
+			t.Errorf("unexpected ranking %+v (total %d, window %s)", ebooks, total, trendingService.window)
+		}
+	})
+
+	t.Run("should fall back to popular ebooks when nothing was ranked", func(t *testing.T) {
+		var sort entity.EbookSort
+		ebookService := &MockEbookService{
+			count: 1,
+			listFunc: func(ctx context.Context, filter *entity.EbookFilter, limit, offset int) ([]*entity.EbookList, error) {
+				sort = filter.Sort
+				return []*entity.EbookList{{ID: "ebook-9"}}, nil
+			},
+		}
+		u := NewTrendingUsecase(ebookService, &MockTrendingService{})
+
+		ebooks, total, err := u.ListTrendingEbooks(ctx, "7d", "category-1", 10, 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if sort != entity.EbookSortPopularity || total != 1 || len(ebooks) != 1 {
+			t.Errorf("unexpected fallback %+v (total %d, sort %s)", ebooks, total, sort)
+		}
+	})
+
+	t.Run("should reject an unknown window", func(t *testing.T) {
+		u := NewTrendingUsecase(&MockEbookService{}, &MockTrendingService{})
+
+		var validationErr *ValidationError
+		if _, _, err := u.ListTrendingEbooks(ctx, "1y", "", 10, 0); !errors.As(err, &validationErr) {
+			t.Errorf("expected a validation error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,28 @@
+package usecase
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+	"io"
+)
+
+// UploadUsecase defines the interface for resumable uploads following the tus 1.0 protocol
+type UploadUsecase interface {
+	// CreateUpload starts an upload of length bytes. checksum is the optional hex SHA-256 of the whole file,
+	// verified once the last chunk has arrived.
+	CreateUpload(ctx context.Context, userID string, kind entity.MediaKind, filename string, length int64, checksum string) (*entity.Upload, error)
+	// GetUpload returns an upload started by the user
+	GetUpload(ctx context.Context, userID, id string) (*entity.Upload, error)
+	// AppendChunk writes a chunk at offset, which must be the number of bytes received so far.
+	// The last chunk stores the upload as media and sets its MediaID.
+	AppendChunk(ctx context.Context, userID, id string, offset int64, body io.Reader, checksum *ChunkChecksum) (*entity.Upload, error)
+	DeleteUpload(ctx context.Context, userID, id string) error
+	// DeleteExpiredUploads removes abandoned uploads and returns how many were removed
+	DeleteExpiredUploads(ctx context.Context) (int, error)
+}
+
+// ChunkChecksum is the checksum a client sent with a chunk, as in the tus Upload-Checksum header
+type ChunkChecksum struct {
+	Algorithm string
+	Sum       []byte
+}
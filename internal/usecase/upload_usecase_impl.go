@@ -0,0 +1,293 @@
+package usecase
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/service"
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"io"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrUploadNotFound is returned for unknown uploads and uploads started by someone else
+	ErrUploadNotFound = errors.New(constant.ERR_UPLOAD_NOT_FOUND)
+	// ErrUploadExpired is returned for abandoned uploads that have not been cleaned up yet
+	ErrUploadExpired = errors.New(constant.ERR_UPLOAD_EXPIRED)
+	// ErrUploadOffsetMismatch is returned when a chunk does not continue where the upload stopped
+	ErrUploadOffsetMismatch = errors.New(constant.ERR_UPLOAD_OFFSET_MISMATCH)
+	// ErrUploadChecksumMismatch is returned when a chunk or the whole file does not match its checksum
+	ErrUploadChecksumMismatch = errors.New(constant.ERR_UPLOAD_CHECKSUM_MISMATCH)
+	// ErrUploadLocked is returned while another request is writing to the same upload
+	ErrUploadLocked = errors.New(constant.ERR_UPLOAD_LOCKED)
+	// ErrUploadForbidden is returned when the user may not create the kind of media being uploaded
+	ErrUploadForbidden = errors.New(constant.ERR_UPLOAD_FORBIDDEN)
+)
+
+// chunkHashes are the Upload-Checksum algorithms accepted, as named by the tus checksum extension
+var chunkHashes = map[string]func() hash.Hash{
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"md5":    md5.New,
+}
+
+type uploadUsecase struct {
+	uploadService     service.UploadService
+	permissionService service.PermissionService
+	mediaUsecase      MediaUsecase
+	expiry            time.Duration
+	now               func() time.Time
+
+	mu      sync.Mutex
+	writing map[string]bool
+}
+
+// NewUploadUsecase creates a new instance of UploadUsecase.
+// expiry is how long an upload may sit idle before it is abandoned; every chunk extends it.
+func NewUploadUsecase(
+	uploadService service.UploadService,
+	permissionService service.PermissionService,
+	mediaUsecase MediaUsecase,
+	expiry time.Duration,
+) UploadUsecase {
+	return &uploadUsecase{
+		uploadService:     uploadService,
+		permissionService: permissionService,
+		mediaUsecase:      mediaUsecase,
+		expiry:            expiry,
+		now:               time.Now,
+		writing:           map[string]bool{},
+	}
+}
+
+func (u *uploadUsecase) CreateUpload(ctx context.Context, userID string, kind entity.MediaKind, filename string, length int64, checksum string) (*entity.Upload, error) {
+	if !kind.IsValid() {
+		return nil, &ValidationError{Message: constant.ERR_MEDIA_KIND_INVALID}
+	}
+	if length <= 0 {
+		return nil, &ValidationError{Message: constant.ERR_UPLOAD_LENGTH_INVALID}
+	}
+	if length > kind.MaxSize() {
+		return nil, ErrMediaTooLarge
+	}
+
+	upload := &entity.Upload{
+		ID:         uuid.New().String(),
+		Kind:       kind,
+		Filename:   truncateRunes(filepath.Base(filepath.ToSlash(filename)), maxMediaFilenameLength),
+		Length:     length,
+		UploadedBy: userID,
+		ExpiresAt:  u.now().Add(u.expiry),
+	}
+	if checksum != "" {
+		checksum = strings.ToLower(checksum)
+		if decoded, err := hex.DecodeString(checksum); err != nil || len(decoded) != sha256.Size {
+			return nil, &ValidationError{Message: constant.ERR_UPLOAD_CHECKSUM_INVALID}
+		}
+		upload.Checksum = &checksum
+	}
+
+	allowed, err := u.permissionService.HasPermission(ctx, userID, kind.UploadPermission())
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, ErrUploadForbidden
+	}
+
+	if err := u.uploadService.CreateUpload(ctx, upload); err != nil {
+		return nil, err
+	}
+	return upload, nil
+}
+
+func (u *uploadUsecase) GetUpload(ctx context.Context, userID, id string) (*entity.Upload, error) {
+	if id == "" {
+		return nil, &ValidationError{Message: constant.ERR_ID_REQUIRED}
+	}
+
+	upload, err := u.uploadService.GetUpload(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if upload == nil || upload.UploadedBy != userID {
+		return nil, ErrUploadNotFound
+	}
+	if upload.IsExpired(u.now()) {
+		return nil, ErrUploadExpired
+	}
+	return upload, nil
+}
+
+func (u *uploadUsecase) AppendChunk(ctx context.Context, userID, id string, offset int64, body io.Reader, checksum *ChunkChecksum) (*entity.Upload, error) {
+	var chunkHash hash.Hash
+	if checksum != nil {
+		newHash, ok := chunkHashes[checksum.Algorithm]
+		if !ok {
+			return nil, &ValidationError{Message: constant.ERR_UPLOAD_ALGORITHM_INVALID}
+		}
+		chunkHash = newHash()
+	}
+
+	if !u.lock(id) {
+		return nil, ErrUploadLocked
+	}
+	defer u.unlock(id)
+
+	// Read after locking so the offset cannot move underneath this request
+	upload, err := u.GetUpload(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if offset != upload.Offset {
+		return nil, ErrUploadOffsetMismatch
+	}
+	if upload.IsComplete() {
+		return upload, nil
+	}
+
+	data, err := u.uploadService.OpenUploadData(upload.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer data.Close()
+
+	// Bytes past the recorded offset were left by a write that failed before it was recorded
+	if err := data.Truncate(upload.Offset); err != nil {
+		return nil, err
+	}
+	if _, err := data.Seek(upload.Offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	var dst io.Writer = data
+	if chunkHash != nil {
+		dst = io.MultiWriter(data, chunkHash)
+	}
+	written, copyErr := io.Copy(dst, io.LimitReader(body, upload.Length-upload.Offset))
+
+	// A chunk with a checksum is kept whole or not at all; without one, whatever arrived is kept to resume from
+	if chunkHash != nil && (copyErr != nil || !bytes.Equal(chunkHash.Sum(nil), checksum.Sum)) {
+		if err := data.Truncate(upload.Offset); err != nil {
+			return nil, err
+		}
+		if copyErr != nil {
+			return nil, copyErr
+		}
+		return nil, ErrUploadChecksumMismatch
+	}
+
+	if written > 0 {
+		expiresAt := u.now().Add(u.expiry)
+		recorded, err := u.uploadService.RecordUploadOffset(ctx, upload.ID, upload.Offset, upload.Offset+written, expiresAt)
+		if err != nil {
+			return nil, err
+		}
+		if !recorded {
+			return nil, ErrUploadOffsetMismatch
+		}
+		upload.Offset += written
+		upload.ExpiresAt = expiresAt
+	}
+	if copyErr != nil {
+		return nil, copyErr
+	}
+
+	if upload.Offset == upload.Length {
+		if err := u.finish(ctx, upload, data); err != nil {
+			return nil, err
+		}
+	}
+	return upload, nil
+}
+
+// finish verifies the whole file and stores it as media. Content that can never become media,
+// such as a file of the wrong type, is discarded since resuming would not help.
+func (u *uploadUsecase) finish(ctx context.Context, upload *entity.Upload, data service.UploadData) error {
+	if upload.Checksum != nil {
+		if _, err := data.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		sum := sha256.New()
+		if _, err := io.Copy(sum, data); err != nil {
+			return err
+		}
+		if hex.EncodeToString(sum.Sum(nil)) != *upload.Checksum {
+			return u.discard(ctx, upload, ErrUploadChecksumMismatch)
+		}
+	}
+
+	if _, err := data.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	media, _, err := u.mediaUsecase.UploadMedia(ctx, upload.UploadedBy, upload.Kind, upload.Filename, data, upload.Length)
+	if err != nil {
+		var validationErr *ValidationError
+		var typeErr *UnsupportedMediaTypeError
+		if errors.As(err, &validationErr) || errors.As(err, &typeErr) || errors.Is(err, ErrMediaTooLarge) {
+			return u.discard(ctx, upload, err)
+		}
+		return err
+	}
+
+	if err := u.uploadService.CompleteUpload(ctx, upload.ID, media.ID); err != nil {
+		return err
+	}
+	upload.MediaID = &media.ID
+	return nil
+}
+
+// discard deletes an upload that cannot be completed and returns the reason
+func (u *uploadUsecase) discard(ctx context.Context, upload *entity.Upload, reason error) error {
+	if err := u.uploadService.DeleteUpload(ctx, upload.ID); err != nil {
+		return err
+	}
+	return reason
+}
+
+func (u *uploadUsecase) DeleteUpload(ctx context.Context, userID, id string) error {
+	if !u.lock(id) {
+		return ErrUploadLocked
+	}
+	defer u.unlock(id)
+
+	upload, err := u.GetUpload(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	return u.uploadService.DeleteUpload(ctx, upload.ID)
+}
+
+func (u *uploadUsecase) DeleteExpiredUploads(ctx context.Context) (int, error) {
+	return u.uploadService.DeleteExpiredUploads(ctx, u.now())
+}
+
+// lock marks an upload as being written; it fails instead of waiting so a client's retry
+// cannot interleave with a request that is still streaming
+func (u *uploadUsecase) lock(id string) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.writing[id] {
+		return false
+	}
+	u.writing[id] = true
+	return true
+}
+
+func (u *uploadUsecase) unlock(id string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	delete(u.writing, id)
+}
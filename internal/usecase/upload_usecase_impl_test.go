@@ -0,0 +1,218 @@
+package usecase
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/service"
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// MockUploadService keeps upload records in memory and their data in a temporary directory
+type MockUploadService struct {
+	dir     string
+	uploads map[string]*entity.Upload
+}
+
+func NewMockUploadService(dir string) *MockUploadService {
+	return &MockUploadService{dir: dir, uploads: map[string]*entity.Upload{}}
+}
+
+func (m *MockUploadService) CreateUpload(ctx context.Context, upload *entity.Upload) error {
+	stored := *upload
+	m.uploads[upload.ID] = &stored
+	return os.WriteFile(filepath.Join(m.dir, upload.ID), nil, 0o644)
+}
+
+func (m *MockUploadService) GetUpload(ctx context.Context, id string) (*entity.Upload, error) {
+	upload, ok := m.uploads[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *upload
+	return &copied, nil
+}
+
+func (m *MockUploadService) OpenUploadData(id string) (service.UploadData, error) {
+	return os.OpenFile(filepath.Join(m.dir, id), os.O_RDWR, 0)
+}
+
+func (m *MockUploadService) RecordUploadOffset(ctx context.Context, id string, from, to int64, expiresAt time.Time) (bool, error) {
+	upload := m.uploads[id]
+	if upload == nil || upload.Offset != from || upload.MediaID != nil {
+		return false, nil
+	}
+	upload.Offset = to
+	upload.ExpiresAt = expiresAt
+	return true, nil
+}
+
+func (m *MockUploadService) CompleteUpload(ctx context.Context, id, mediaID string) error {
+	m.uploads[id].MediaID = &mediaID
+	return os.Remove(filepath.Join(m.dir, id))
+}
+
+func (m *MockUploadService) DeleteUpload(ctx context.Context, id string) error {
+	delete(m.uploads, id)
+	os.Remove(filepath.Join(m.dir, id))
+	return nil
+}
+
+func (m *MockUploadService) DeleteExpiredUploads(ctx context.Context, now time.Time) (int, error) {
+	deleted := 0
+	for id, upload := range m.uploads {
+		if upload.IsExpired(now) {
+			m.DeleteUpload(ctx, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// stubPermissionService grants the listed permissions; other PermissionService methods are not used
+type stubPermissionService struct {
+	service.PermissionService
+	granted map[string]bool
+}
+
+func (s *stubPermissionService) HasPermission(ctx context.Context, userID, permissionName string) (bool, error) {
+	return s.granted[permissionName], nil
+}
+
+func newTestUploadUsecase(t *testing.T) (*uploadUsecase, *MockUploadService, *MockMediaService) {
+	uploadService := NewMockUploadService(t.TempDir())
+	mediaService := NewMockMediaService()
+	permissions := &stubPermissionService{granted: map[string]bool{entity.PermissionEbookCreate: true}}
+	u := NewUploadUsecase(uploadService, permissions, NewMediaUsecase(mediaService), time.Hour).(*uploadUsecase)
+	return u, uploadService, mediaService
+}
+
+func TestUploadUsecase_AppendChunk(t *testing.T) {
+	ctx := context.Background()
+	content := encodeTestPNG(16, 16)
+	first, second := content[:20], content[20:]
+
+	t.Run("should assemble chunks and store the result as media", func(t *testing.T) {
+		u, _, mediaService := newTestUploadUsecase(t)
+		sum := sha256.Sum256(content)
+
+		upload, err := u.CreateUpload(ctx, "user-1", entity.MediaCover, "cover.png", int64(len(content)), hex.EncodeToString(sum[:]))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		upload, err = u.AppendChunk(ctx, "user-1", upload.ID, 0, bytes.NewReader(first), nil)
+		if err != nil || upload.Offset != int64(len(first)) || upload.IsComplete() {
+			t.Fatalf("expected the first chunk to be recorded, got %+v, %v", upload, err)
+		}
+
+		chunkSum := sha1.Sum(second)
+		upload, err = u.AppendChunk(ctx, "user-1", upload.ID, upload.Offset, bytes.NewReader(second), &ChunkChecksum{Algorithm: "sha1", Sum: chunkSum[:]})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !upload.IsComplete() {
+			t.Fatal("expected the upload to be complete")
+		}
+		media := mediaService.media[*upload.MediaID]
+		if media == nil || media.ContentType != "image/png" || !bytes.Equal(mediaService.content[media.StorageKey], content) {
+			t.Errorf("unexpected media %+v", media)
+		}
+
+		// Repeating the last request is harmless
+		again, err := u.AppendChunk(ctx, "user-1", upload.ID, upload.Length, bytes.NewReader(nil), nil)
+		if err != nil || *again.MediaID != *upload.MediaID {
+			t.Errorf("expected the completed upload back, got %+v, %v", again, err)
+		}
+	})
+
+	t.Run("should reject chunks at the wrong offset or with a wrong checksum", func(t *testing.T) {
+		u, uploadService, _ := newTestUploadUsecase(t)
+		upload, err := u.CreateUpload(ctx, "user-1", entity.MediaCover, "cover.png", int64(len(content)), "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if _, err := u.AppendChunk(ctx, "user-1", upload.ID, 5, bytes.NewReader(first), nil); !errors.Is(err, ErrUploadOffsetMismatch) {
+			t.Errorf("expected ErrUploadOffsetMismatch, got %v", err)
+		}
+
+		wrongSum := sha1.Sum([]byte("something else"))
+		_, err = u.AppendChunk(ctx, "user-1", upload.ID, 0, bytes.NewReader(first), &ChunkChecksum{Algorithm: "sha1", Sum: wrongSum[:]})
+		if !errors.Is(err, ErrUploadChecksumMismatch) {
+			t.Errorf("expected ErrUploadChecksumMismatch, got %v", err)
+		}
+		if uploadService.uploads[upload.ID].Offset != 0 {
+			t.Error("expected a chunk with a wrong checksum to be dropped")
+		}
+
+		_, err = u.AppendChunk(ctx, "user-1", upload.ID, 0, bytes.NewReader(first), &ChunkChecksum{Algorithm: "crc32"})
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("expected validation error for an unknown algorithm, got %v", err)
+		}
+	})
+
+	t.Run("should discard an upload that does not match the declared file checksum", func(t *testing.T) {
+		u, uploadService, mediaService := newTestUploadUsecase(t)
+		sum := sha256.Sum256([]byte("another file"))
+		upload, err := u.CreateUpload(ctx, "user-1", entity.MediaCover, "cover.png", int64(len(content)), hex.EncodeToString(sum[:]))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if _, err := u.AppendChunk(ctx, "user-1", upload.ID, 0, bytes.NewReader(content), nil); !errors.Is(err, ErrUploadChecksumMismatch) {
+			t.Errorf("expected ErrUploadChecksumMismatch, got %v", err)
+		}
+		if _, ok := uploadService.uploads[upload.ID]; ok || len(mediaService.media) != 0 {
+			t.Error("expected the upload to be discarded without storing media")
+		}
+	})
+
+	t.Run("should hide uploads of other users and expired uploads", func(t *testing.T) {
+		u, _, _ := newTestUploadUsecase(t)
+		upload, err := u.CreateUpload(ctx, "user-1", entity.MediaEbookFile, "book.pdf", 1024, "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if _, err := u.GetUpload(ctx, "user-2", upload.ID); !errors.Is(err, ErrUploadNotFound) {
+			t.Errorf("expected ErrUploadNotFound, got %v", err)
+		}
+
+		u.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+		if _, err := u.GetUpload(ctx, "user-1", upload.ID); !errors.Is(err, ErrUploadExpired) {
+			t.Errorf("expected ErrUploadExpired, got %v", err)
+		}
+		if deleted, _ := u.DeleteExpiredUploads(ctx); deleted != 1 {
+			t.Errorf("expected the expired upload to be deleted, got %d", deleted)
+		}
+	})
+}
+
+func TestUploadUsecase_CreateUpload(t *testing.T) {
+	ctx := context.Background()
+	u, _, _ := newTestUploadUsecase(t)
+
+	if _, err := u.CreateUpload(ctx, "user-1", entity.MediaAudio, "a.mp3", 1024, ""); !errors.Is(err, ErrUploadForbidden) {
+		t.Errorf("expected ErrUploadForbidden without summary:create, got %v", err)
+	}
+	if _, err := u.CreateUpload(ctx, "user-1", entity.MediaCover, "c.png", entity.MediaCover.MaxSize()+1, ""); !errors.Is(err, ErrMediaTooLarge) {
+		t.Errorf("expected ErrMediaTooLarge, got %v", err)
+	}
+
+	var validationErr *ValidationError
+	if _, err := u.CreateUpload(ctx, "user-1", entity.MediaCover, "c.png", 0, ""); !errors.As(err, &validationErr) {
+		t.Errorf("expected validation error for an empty upload, got %v", err)
+	}
+	if _, err := u.CreateUpload(ctx, "user-1", entity.MediaCover, "c.png", 10, "not-a-digest"); !errors.As(err, &validationErr) {
+		t.Errorf("expected validation error for a malformed checksum, got %v", err)
+	}
+}
@@ -0,0 +1,17 @@
+package usecase
+
+import (
+	"buku-pintar/internal/domain/entity"
+	"context"
+)
+
+// WishlistUsecase defines the interface for wishlist use cases
+type WishlistUsecase interface {
+	// AddToWishlist is idempotent and returns false if the ebook was already wishlisted
+	AddToWishlist(ctx context.Context, userID, ebookID string) (bool, error)
+	RemoveFromWishlist(ctx context.Context, userID, ebookID string) error
+	ListWishlist(ctx context.Context, userID string, limit, offset int) ([]*entity.WishlistItem, error)
+	CountWishlist(ctx context.Context, userID string) (int64, error)
+	// CountEbookWishlists returns how many users wishlisted the ebook, for editors
+	CountEbookWishlists(ctx context.Context, ebookID string) (int64, error)
+}
@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"buku-pintar/internal/constant"
+	"buku-pintar/internal/domain/entity"
+	"buku-pintar/internal/domain/service"
+	"context"
+	"log"
+)
+
+type wishlistUsecase struct {
+	ebookService    service.EbookService
+	wishlistService service.WishlistService
+	trendingService service.TrendingService
+}
+
+func NewWishlistUsecase(ebookService service.EbookService, wishlistService service.WishlistService, trendingService service.TrendingService) WishlistUsecase {
+	return &wishlistUsecase{
+		ebookService:    ebookService,
+		wishlistService: wishlistService,
+		trendingService: trendingService,
+	}
+}
+
+func (u *wishlistUsecase) AddToWishlist(ctx context.Context, userID, ebookID string) (bool, error) {
+	ebook, err := u.requireEbook(ctx, ebookID)
+	if err != nil {
+		return false, err
+	}
+
+	added, err := u.wishlistService.AddToWishlist(ctx, userID, ebookID)
+	if err != nil || !added {
+		return added, err
+	}
+
+	// Counted once a day per user, so removing and re-adding an ebook does not push it up
+	if err := u.trendingService.RecordEngagement(ctx, ebook.ID, ebook.CategoryID, entity.EngagementWishlist, userID); err != nil {
+		log.Printf("Failed to record wishlist add: %v", err)
+	}
+
+	return true, nil
+}
+
+func (u *wishlistUsecase) RemoveFromWishlist(ctx context.Context, userID, ebookID string) error {
+	if ebookID == "" {
+		return &ValidationError{Message: constant.EBOOK_ID_REQUIRED}
+	}
+
+	return u.wishlistService.RemoveFromWishlist(ctx, userID, ebookID)
+}
+
+func (u *wishlistUsecase) ListWishlist(ctx context.Context, userID string, limit, offset int) ([]*entity.WishlistItem, error) {
+	// Validate pagination parameters
+	if limit <= 0 {
+		limit = 10 // default limit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return u.wishlistService.GetWishlist(ctx, userID, limit, offset)
+}
+
+func (u *wishlistUsecase) CountWishlist(ctx context.Context, userID string) (int64, error) {
+	return u.wishlistService.GetWishlistCount(ctx, userID)
+}
+
+func (u *wishlistUsecase) CountEbookWishlists(ctx context.Context, ebookID string) (int64, error) {
+	if _, err := u.requireEbook(ctx, ebookID); err != nil {
+		return 0, err
+	}
+
+	return u.wishlistService.GetEbookWishlistCount(ctx, ebookID)
+}
+
+func (u *wishlistUsecase) requireEbook(ctx context.Context, ebookID string) (*entity.Ebook, error) {
+	if ebookID == "" {
+		return nil, &ValidationError{Message: constant.EBOOK_ID_REQUIRED}
+	}
+
+	ebook, err := u.ebookService.GetEbookByID(ctx, ebookID)
+	if err != nil {
+		return nil, err
+	}
+	if ebook == nil {
+		return nil, ErrEbookNotFound
+	}
+
+	return ebook, nil
+}
@@ -0,0 +1,8 @@
+DROP INDEX `idx_ebook_discounts_ebook_period` ON `ebook_discounts`;
+DROP INDEX `idx_ebooks_popularity_score` ON `ebooks`;
+DROP INDEX `idx_ebooks_price` ON `ebooks`;
+DROP INDEX `idx_ebooks_format` ON `ebooks`;
+DROP INDEX `idx_ebooks_language` ON `ebooks`;
+DROP INDEX `idx_ebooks_published_at` ON `ebooks`;
+
+ALTER TABLE `ebooks` DROP COLUMN `popularity_score`;
@@ -0,0 +1,11 @@
+-- popularity_score backs sort=popularity. It stays 0, and the sort falls back to the id tiebreak,
+-- until the trending snapshot job fills it with the 30-day trending score (see 000041).
+ALTER TABLE `ebooks`
+ADD COLUMN `popularity_score` INT NOT NULL DEFAULT 0 AFTER `url`;
+
+CREATE INDEX `idx_ebooks_published_at` ON `ebooks`(`published_at`);
+CREATE INDEX `idx_ebooks_language` ON `ebooks`(`language`);
+CREATE INDEX `idx_ebooks_format` ON `ebooks`(`format`);
+CREATE INDEX `idx_ebooks_price` ON `ebooks`(`price`);
+CREATE INDEX `idx_ebooks_popularity_score` ON `ebooks`(`popularity_score`);
+CREATE INDEX `idx_ebook_discounts_ebook_period` ON `ebook_discounts`(`ebook_id`, `started_at`, `ended_at`);
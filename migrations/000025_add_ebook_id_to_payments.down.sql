@@ -0,0 +1,4 @@
+ALTER TABLE `payments`
+DROP FOREIGN KEY `fk_payments_ebook_id`,
+DROP INDEX `idx_payments_user_ebook_status`,
+DROP COLUMN `ebook_id`;
@@ -0,0 +1,5 @@
+ALTER TABLE `payments`
+ADD COLUMN `ebook_id` VARCHAR(36) DEFAULT NULL AFTER `user_id`,
+ADD CONSTRAINT `fk_payments_ebook_id` FOREIGN KEY (`ebook_id`) REFERENCES `ebooks`(`id`) ON DELETE SET NULL;
+
+CREATE INDEX `idx_payments_user_ebook_status` ON `payments`(`user_id`, `ebook_id`, `status`);
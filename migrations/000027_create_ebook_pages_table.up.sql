@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS `ebook_pages` (
+  `id` VARCHAR(36) PRIMARY KEY,
+  `ebook_id` VARCHAR(36) NOT NULL,
+  `page_number` INT NOT NULL,
+  `title` VARCHAR(255) NULL,
+  `content_type` VARCHAR(100) NOT NULL,
+  `content` LONGTEXT NOT NULL,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  FOREIGN KEY (`ebook_id`) REFERENCES `ebooks`(`id`) ON DELETE CASCADE,
+  UNIQUE KEY `uk_ebook_pages_ebook_page` (`ebook_id`, `page_number`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS `reading_progress` (
+  `user_id` VARCHAR(36) NOT NULL,
+  `ebook_id` VARCHAR(36) NOT NULL,
+  `page` INT NOT NULL DEFAULT 0,
+  `cfi` VARCHAR(512) NULL,
+  `percentage` DECIMAL(5,2) NOT NULL DEFAULT 0,
+  `client_updated_at` DATETIME(3) NOT NULL,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`user_id`, `ebook_id`),
+  FOREIGN KEY (`user_id`) REFERENCES `users`(`id`) ON DELETE CASCADE,
+  FOREIGN KEY (`ebook_id`) REFERENCES `ebooks`(`id`) ON DELETE CASCADE,
+  INDEX `idx_reading_progress_user_read` (`user_id`, `client_updated_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
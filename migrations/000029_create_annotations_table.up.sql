@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS `annotations` (
+  `id` VARCHAR(36) PRIMARY KEY,
+  `user_id` VARCHAR(36) NOT NULL,
+  `ebook_id` VARCHAR(36) NOT NULL,
+  `type` ENUM('bookmark', 'highlight', 'note') NOT NULL,
+  `page` INT NULL,
+  `cfi_start` VARCHAR(512) NULL,
+  `cfi_end` VARCHAR(512) NULL,
+  `selected_text` TEXT NULL,
+  `color` VARCHAR(20) NULL,
+  `note` TEXT NULL,
+  `created_at` DATETIME(3) NOT NULL,
+  `updated_at` DATETIME(3) NOT NULL,
+  `deleted_at` DATETIME(3) NULL,
+  FOREIGN KEY (`user_id`) REFERENCES `users`(`id`) ON DELETE CASCADE,
+  FOREIGN KEY (`ebook_id`) REFERENCES `ebooks`(`id`) ON DELETE CASCADE,
+  INDEX `idx_annotations_user_ebook_updated` (`user_id`, `ebook_id`, `updated_at`),
+  INDEX `idx_annotations_user_updated` (`user_id`, `updated_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
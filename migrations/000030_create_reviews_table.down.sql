@@ -0,0 +1,7 @@
+ALTER TABLE `ebooks`
+DROP COLUMN `rating_average`,
+DROP COLUMN `rating_sum`,
+DROP COLUMN `rating_count`;
+
+DROP TABLE IF EXISTS `review_reports`;
+DROP TABLE IF EXISTS `reviews`;
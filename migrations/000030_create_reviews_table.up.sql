@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS `reviews` (
+  `id` VARCHAR(36) PRIMARY KEY,
+  `user_id` VARCHAR(36) NOT NULL,
+  `ebook_id` VARCHAR(36) NOT NULL,
+  `rating` TINYINT UNSIGNED NOT NULL,
+  `body` TEXT NULL,
+  `status` ENUM('visible', 'hidden') NOT NULL DEFAULT 'visible',
+  `report_count` INT NOT NULL DEFAULT 0,
+  `hidden_at` TIMESTAMP NULL,
+  `hidden_by` VARCHAR(36) NULL,
+  `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  FOREIGN KEY (`user_id`) REFERENCES `users`(`id`) ON DELETE CASCADE,
+  FOREIGN KEY (`ebook_id`) REFERENCES `ebooks`(`id`) ON DELETE CASCADE,
+  UNIQUE KEY `uk_reviews_user_ebook` (`user_id`, `ebook_id`),
+  INDEX `idx_reviews_ebook_status_created` (`ebook_id`, `status`, `created_at`),
+  INDEX `idx_reviews_report_count` (`report_count`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `review_reports` (
+  `id` VARCHAR(36) PRIMARY KEY,
+  `review_id` VARCHAR(36) NOT NULL,
+  `user_id` VARCHAR(36) NOT NULL,
+  `reason` VARCHAR(500) NULL,
+  `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (`review_id`) REFERENCES `reviews`(`id`) ON DELETE CASCADE,
+  FOREIGN KEY (`user_id`) REFERENCES `users`(`id`) ON DELETE CASCADE,
+  UNIQUE KEY `uk_review_reports_review_user` (`review_id`, `user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+ALTER TABLE `ebooks`
+ADD COLUMN `rating_count` INT NOT NULL DEFAULT 0 AFTER `popularity_score`,
+ADD COLUMN `rating_sum` INT NOT NULL DEFAULT 0 AFTER `rating_count`,
+ADD COLUMN `rating_average` DECIMAL(3,2) NOT NULL DEFAULT 0.00 AFTER `rating_sum`;
@@ -0,0 +1,7 @@
+DROP INDEX `idx_ebook_discounts_notified_started` ON `ebook_discounts`;
+
+ALTER TABLE `ebook_discounts`
+DROP COLUMN `notified_at`;
+
+DROP TABLE IF EXISTS `notification_events`;
+DROP TABLE IF EXISTS `wishlists`;
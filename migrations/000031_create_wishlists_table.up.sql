@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS `wishlists` (
+  `user_id` VARCHAR(36) NOT NULL,
+  `ebook_id` VARCHAR(36) NOT NULL,
+  `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`user_id`, `ebook_id`),
+  FOREIGN KEY (`user_id`) REFERENCES `users`(`id`) ON DELETE CASCADE,
+  FOREIGN KEY (`ebook_id`) REFERENCES `ebooks`(`id`) ON DELETE CASCADE,
+  INDEX `idx_wishlists_ebook_id` (`ebook_id`),
+  INDEX `idx_wishlists_user_created` (`user_id`, `created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `notification_events` (
+  `id` VARCHAR(36) PRIMARY KEY,
+  `user_id` VARCHAR(36) NOT NULL,
+  `type` VARCHAR(50) NOT NULL,
+  `ebook_id` VARCHAR(36) NULL,
+  `payload` JSON NOT NULL,
+  `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (`user_id`) REFERENCES `users`(`id`) ON DELETE CASCADE,
+  FOREIGN KEY (`ebook_id`) REFERENCES `ebooks`(`id`) ON DELETE SET NULL,
+  INDEX `idx_notification_events_user_created` (`user_id`, `created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+ALTER TABLE `ebook_discounts`
+ADD COLUMN `notified_at` TIMESTAMP NULL DEFAULT NULL AFTER `ended_at`;
+
+CREATE INDEX `idx_ebook_discounts_notified_started` ON `ebook_discounts`(`notified_at`, `started_at`);
@@ -0,0 +1,7 @@
+DROP INDEX `idx_table_of_contents_ebook_tree` ON `table_of_contents`;
+
+ALTER TABLE `table_of_contents`
+DROP FOREIGN KEY `fk_table_of_contents_parent_id`,
+DROP COLUMN `depth`,
+DROP COLUMN `order_number`,
+DROP COLUMN `parent_id`;
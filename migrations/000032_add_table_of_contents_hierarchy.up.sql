@@ -0,0 +1,7 @@
+ALTER TABLE `table_of_contents`
+ADD COLUMN `parent_id` VARCHAR(36) NULL AFTER `ebook_id`,
+ADD COLUMN `order_number` INT NOT NULL DEFAULT 0 AFTER `page_number`,
+ADD COLUMN `depth` TINYINT UNSIGNED NOT NULL DEFAULT 0 AFTER `order_number`,
+ADD CONSTRAINT `fk_table_of_contents_parent_id` FOREIGN KEY (`parent_id`) REFERENCES `table_of_contents`(`id`) ON DELETE CASCADE;
+
+CREATE INDEX `idx_table_of_contents_ebook_tree` ON `table_of_contents`(`ebook_id`, `depth`, `order_number`);
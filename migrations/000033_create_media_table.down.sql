@@ -0,0 +1,19 @@
+ALTER TABLE `categories`
+DROP FOREIGN KEY `fk_categories_icon_media_id`,
+DROP COLUMN `icon_media_id`;
+
+ALTER TABLE `banners`
+DROP FOREIGN KEY `fk_banners_image_media_id`,
+DROP COLUMN `image_media_id`;
+
+ALTER TABLE `ebook_summaries`
+DROP FOREIGN KEY `fk_ebook_summaries_audio_media_id`,
+DROP COLUMN `audio_media_id`;
+
+ALTER TABLE `ebooks`
+DROP FOREIGN KEY `fk_ebooks_cover_media_id`,
+DROP FOREIGN KEY `fk_ebooks_file_media_id`,
+DROP COLUMN `cover_media_id`,
+DROP COLUMN `file_media_id`;
+
+DROP TABLE IF EXISTS `media`;
@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS `media` (
+  `id` VARCHAR(36) PRIMARY KEY,
+  `kind` ENUM('cover', 'ebook_file', 'audio', 'banner', 'icon') NOT NULL,
+  `storage_key` VARCHAR(255) NOT NULL,
+  `filename` VARCHAR(255) NOT NULL,
+  `content_type` VARCHAR(100) NOT NULL,
+  `size` BIGINT NOT NULL,
+  `checksum` CHAR(64) NOT NULL,
+  `uploaded_by` VARCHAR(36) NULL,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (`uploaded_by`) REFERENCES `users`(`id`) ON DELETE SET NULL,
+  UNIQUE KEY `uq_media_kind_checksum` (`kind`, `checksum`),
+  UNIQUE KEY `uq_media_storage_key` (`storage_key`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+ALTER TABLE `ebooks`
+ADD COLUMN `cover_media_id` VARCHAR(36) NULL AFTER `cover_image`,
+ADD COLUMN `file_media_id` VARCHAR(36) NULL AFTER `url`,
+ADD CONSTRAINT `fk_ebooks_cover_media_id` FOREIGN KEY (`cover_media_id`) REFERENCES `media`(`id`) ON DELETE SET NULL,
+ADD CONSTRAINT `fk_ebooks_file_media_id` FOREIGN KEY (`file_media_id`) REFERENCES `media`(`id`) ON DELETE SET NULL;
+
+ALTER TABLE `ebook_summaries`
+ADD COLUMN `audio_media_id` VARCHAR(36) NULL AFTER `audio_url`,
+ADD CONSTRAINT `fk_ebook_summaries_audio_media_id` FOREIGN KEY (`audio_media_id`) REFERENCES `media`(`id`) ON DELETE SET NULL;
+
+ALTER TABLE `banners`
+ADD COLUMN `image_media_id` VARCHAR(36) NULL AFTER `image_url`,
+ADD CONSTRAINT `fk_banners_image_media_id` FOREIGN KEY (`image_media_id`) REFERENCES `media`(`id`) ON DELETE SET NULL;
+
+ALTER TABLE `categories`
+ADD COLUMN `icon_media_id` VARCHAR(36) NULL AFTER `icon_link`,
+ADD CONSTRAINT `fk_categories_icon_media_id` FOREIGN KEY (`icon_media_id`) REFERENCES `media`(`id`) ON DELETE SET NULL;
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS `media_variants` (
+  `id` VARCHAR(36) PRIMARY KEY,
+  `media_id` VARCHAR(36) NOT NULL,
+  `name` ENUM('thumbnail', 'medium', 'large') NOT NULL,
+  `format` ENUM('jpeg', 'png') NOT NULL,
+  `storage_key` VARCHAR(255) NOT NULL,
+  `width` INT NOT NULL,
+  `height` INT NOT NULL,
+  `size` BIGINT NOT NULL,
+  `checksum` CHAR(64) NOT NULL,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (`media_id`) REFERENCES `media`(`id`) ON DELETE CASCADE,
+  UNIQUE KEY `uq_media_variants_media_name_format` (`media_id`, `name`, `format`),
+  UNIQUE KEY `uq_media_variants_storage_key` (`storage_key`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS `uploads` (
+  `id` VARCHAR(36) PRIMARY KEY,
+  `kind` ENUM('cover', 'ebook_file', 'audio', 'banner', 'icon') NOT NULL,
+  `filename` VARCHAR(255) NOT NULL,
+  `upload_length` BIGINT NOT NULL,
+  `upload_offset` BIGINT NOT NULL DEFAULT 0,
+  `checksum` CHAR(64) NULL,
+  `uploaded_by` VARCHAR(36) NOT NULL,
+  `media_id` VARCHAR(36) NULL,
+  `expires_at` TIMESTAMP NOT NULL,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  FOREIGN KEY (`uploaded_by`) REFERENCES `users`(`id`) ON DELETE CASCADE,
+  FOREIGN KEY (`media_id`) REFERENCES `media`(`id`) ON DELETE SET NULL,
+  INDEX `idx_uploads_expires_at` (`expires_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS `content_schedules` (
+  `id` VARCHAR(36) PRIMARY KEY,
+  `content_type` ENUM('ebook', 'article', 'inspiration') NOT NULL,
+  `content_id` VARCHAR(36) NOT NULL,
+  `action` ENUM('publish', 'unpublish') NOT NULL,
+  `run_at` TIMESTAMP NOT NULL,
+  `created_by` VARCHAR(36),
+  `applied_at` TIMESTAMP NULL,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (`created_by`) REFERENCES `users`(`id`) ON DELETE SET NULL,
+  INDEX `idx_content_schedules_due` (`applied_at`, `run_at`),
+  INDEX `idx_content_schedules_content` (`content_type`, `content_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS `banner_translations`;
+DROP TABLE IF EXISTS `article_translations`;
+DROP TABLE IF EXISTS `category_translations`;
+DROP TABLE IF EXISTS `ebook_translations`;
@@ -0,0 +1,44 @@
+CREATE TABLE IF NOT EXISTS `ebook_translations` (
+  `ebook_id` VARCHAR(36) NOT NULL,
+  `locale` VARCHAR(10) NOT NULL,
+  `title` VARCHAR(255) NULL,
+  `synopsis` TEXT NULL,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`ebook_id`, `locale`),
+  FOREIGN KEY (`ebook_id`) REFERENCES `ebooks`(`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `category_translations` (
+  `category_id` VARCHAR(36) NOT NULL,
+  `locale` VARCHAR(10) NOT NULL,
+  `name` VARCHAR(255) NULL,
+  `description` TEXT NULL,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`category_id`, `locale`),
+  FOREIGN KEY (`category_id`) REFERENCES `categories`(`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `article_translations` (
+  `article_id` VARCHAR(36) NOT NULL,
+  `locale` VARCHAR(10) NOT NULL,
+  `title` VARCHAR(255) NULL,
+  `excerpt` VARCHAR(255) NULL,
+  `content` TEXT NULL,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`article_id`, `locale`),
+  FOREIGN KEY (`article_id`) REFERENCES `articles`(`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `banner_translations` (
+  `banner_id` VARCHAR(36) NOT NULL,
+  `locale` VARCHAR(10) NOT NULL,
+  `title` VARCHAR(255) NULL,
+  `cta_label` VARCHAR(255) NULL,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`banner_id`, `locale`),
+  FOREIGN KEY (`banner_id`) REFERENCES `banners`(`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1 @@
+ALTER TABLE `seo_metadatas` DROP COLUMN `noindex`;
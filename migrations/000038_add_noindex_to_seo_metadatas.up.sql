@@ -0,0 +1,2 @@
+ALTER TABLE `seo_metadatas`
+ADD COLUMN `noindex` BOOLEAN NOT NULL DEFAULT FALSE AFTER `keywords`;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS `series_ebooks`;
+DROP TABLE IF EXISTS `series`;
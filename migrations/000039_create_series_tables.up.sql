@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS `series` (
+  `id` VARCHAR(36) PRIMARY KEY,
+  `name` VARCHAR(255) NOT NULL,
+  `slug` VARCHAR(255) NOT NULL,
+  `description` TEXT NULL,
+  `cover_image` VARCHAR(255) NOT NULL DEFAULT '',
+  `cover_media_id` VARCHAR(36) NULL,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  FOREIGN KEY (`cover_media_id`) REFERENCES `media`(`id`) ON DELETE SET NULL,
+  UNIQUE KEY `uq_series_slug` (`slug`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- An ebook belongs to at most one series; volumes are read in ascending order
+CREATE TABLE IF NOT EXISTS `series_ebooks` (
+  `series_id` VARCHAR(36) NOT NULL,
+  `ebook_id` VARCHAR(36) NOT NULL,
+  `volume` SMALLINT UNSIGNED NOT NULL,
+  PRIMARY KEY (`series_id`, `volume`),
+  FOREIGN KEY (`series_id`) REFERENCES `series`(`id`) ON DELETE CASCADE,
+  FOREIGN KEY (`ebook_id`) REFERENCES `ebooks`(`id`) ON DELETE CASCADE,
+  UNIQUE KEY `uq_series_ebooks_ebook` (`ebook_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
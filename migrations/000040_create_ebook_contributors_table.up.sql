@@ -0,0 +1,16 @@
+-- Everyone who contributed to an ebook, shown in display_order. ebooks.author_id stays the
+-- primary author and is listed here as well, so existing ebooks start with their author.
+CREATE TABLE IF NOT EXISTS `ebook_contributors` (
+  `ebook_id` VARCHAR(36) NOT NULL,
+  `author_id` VARCHAR(36) NOT NULL,
+  `role` ENUM('author', 'translator', 'editor', 'narrator') NOT NULL,
+  `display_order` SMALLINT UNSIGNED NOT NULL DEFAULT 0,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`ebook_id`, `author_id`, `role`),
+  FOREIGN KEY (`ebook_id`) REFERENCES `ebooks`(`id`) ON DELETE CASCADE,
+  FOREIGN KEY (`author_id`) REFERENCES `authors`(`id`) ON DELETE CASCADE,
+  INDEX `idx_ebook_contributors_author` (`author_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+INSERT INTO `ebook_contributors` (`ebook_id`, `author_id`, `role`, `display_order`)
+SELECT `id`, `author_id`, 'author', 0 FROM `ebooks`;
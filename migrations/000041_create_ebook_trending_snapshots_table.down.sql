@@ -0,0 +1 @@
+DROP TABLE IF EXISTS `ebook_trending_snapshots`;
@@ -0,0 +1,9 @@
+-- Daily engagement scores copied from Redis, so trending can be rebuilt after a cache flush
+CREATE TABLE IF NOT EXISTS `ebook_trending_snapshots` (
+  `day` DATE NOT NULL,
+  `ebook_id` VARCHAR(36) NOT NULL,
+  `score` DOUBLE NOT NULL,
+  `updated_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`day`, `ebook_id`),
+  FOREIGN KEY (`ebook_id`) REFERENCES `ebooks`(`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,28 @@
+-- Client usage events, partitioned by month of occurred_at so expired months are dropped whole.
+-- There are no foreign keys (partitioned tables cannot have them); monthly partitions are split
+-- off p_future ahead of time by the analytics partition job, starting at the bound of p_past.
+-- The job empties p_past once its rows are older than the retention period.
+CREATE TABLE IF NOT EXISTS `analytics_events` (
+  `id` VARCHAR(36) NOT NULL,
+  `event_type` ENUM('view', 'read_page', 'audio_play', 'search', 'banner_click') NOT NULL,
+  `occurred_at` DATETIME(3) NOT NULL,
+  `received_at` DATETIME(3) NOT NULL,
+  `user_id` VARCHAR(36) NULL,
+  `session_id` VARCHAR(64) NULL,
+  `ebook_id` VARCHAR(36) NULL,
+  `banner_id` VARCHAR(36) NULL,
+  `page_number` INT UNSIGNED NULL,
+  `position_seconds` INT UNSIGNED NULL,
+  `query` VARCHAR(255) NULL,
+  `properties` JSON NULL,
+  `locale` VARCHAR(16) NOT NULL DEFAULT '',
+  `ip_address` VARCHAR(45) NOT NULL DEFAULT '',
+  `user_agent` VARCHAR(255) NOT NULL DEFAULT '',
+  PRIMARY KEY (`id`, `occurred_at`),
+  INDEX `idx_analytics_events_type_occurred` (`event_type`, `occurred_at`),
+  INDEX `idx_analytics_events_ebook_occurred` (`ebook_id`, `occurred_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci
+PARTITION BY RANGE COLUMNS(`occurred_at`) (
+  PARTITION `p_past` VALUES LESS THAN ('2026-01-01'),
+  PARTITION `p_future` VALUES LESS THAN (MAXVALUE)
+);
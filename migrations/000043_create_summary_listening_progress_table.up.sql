@@ -0,0 +1,13 @@
+-- Listening position per user in the audio of a regular or premium summary. summary_id refers to
+-- either table, so there is no foreign key on it.
+CREATE TABLE IF NOT EXISTS `summary_listening_progress` (
+  `user_id` VARCHAR(36) NOT NULL,
+  `summary_id` VARCHAR(36) NOT NULL,
+  `position_seconds` INT UNSIGNED NOT NULL DEFAULT 0,
+  `duration_seconds` INT UNSIGNED NULL,
+  `client_updated_at` DATETIME(3) NOT NULL,
+  `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`user_id`, `summary_id`),
+  FOREIGN KEY (`user_id`) REFERENCES `users`(`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,137 @@
+// Package catalog reads ebook catalogs exported by publishers as CSV or JSON.
+// Records are returned as raw text by column name; converting and validating them is left to the caller
+// so that every problem can be reported against its record.
+package catalog
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Format is the encoding of a catalog file
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatJSON Format = "json"
+)
+
+// FormatFromFilename returns the format for a file extension, or "" if it is not a catalog format
+func FormatFromFilename(name string) Format {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".csv":
+		return FormatCSV
+	case ".json":
+		return FormatJSON
+	}
+	return ""
+}
+
+// Record is one entry of a catalog. Line is the line of a CSV record, or the 1-based position
+// of a JSON object in the array. Values holds the non-empty values by lowercased column name.
+type Record struct {
+	Line   int
+	Values map[string]string
+}
+
+// Get returns the trimmed value of a column, or "" if it is missing
+func (r Record) Get(column string) string {
+	return r.Values[column]
+}
+
+// Read reads every record of a catalog. A CSV catalog starts with a header row naming the columns;
+// a JSON catalog is an array of objects whose values are strings, numbers or booleans.
+func Read(r io.Reader, format Format) ([]Record, error) {
+	switch format {
+	case FormatCSV:
+		return readCSV(r)
+	case FormatJSON:
+		return readJSON(r)
+	}
+	return nil, fmt.Errorf("unsupported catalog format %q", format)
+}
+
+func readCSV(r io.Reader) ([]Record, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("catalog is empty")
+		}
+		return nil, err
+	}
+	columns := make([]string, len(header))
+	for i, name := range header {
+		// Spreadsheets often save a byte order mark in front of the first column
+		columns[i] = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+	}
+
+	var records []Record
+	for {
+		fields, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		record := Record{Line: line, Values: map[string]string{}}
+		for i, value := range fields {
+			if i >= len(columns) {
+				return nil, fmt.Errorf("line %d: more values than columns in the header", line)
+			}
+			if value = strings.TrimSpace(value); value != "" && columns[i] != "" {
+				record.Values[columns[i]] = value
+			}
+		}
+		if len(record.Values) > 0 {
+			records = append(records, record)
+		}
+	}
+}
+
+func readJSON(r io.Reader) ([]Record, error) {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+
+	var objects []map[string]any
+	if err := decoder.Decode(&objects); err != nil {
+		return nil, fmt.Errorf("catalog must be a JSON array of objects: %w", err)
+	}
+
+	records := make([]Record, 0, len(objects))
+	for i, object := range objects {
+		record := Record{Line: i + 1, Values: map[string]string{}}
+		for name, raw := range object {
+			var value string
+			switch v := raw.(type) {
+			case nil:
+				continue
+			case string:
+				value = v
+			case json.Number:
+				value = v.String()
+			case bool:
+				value = strconv.FormatBool(v)
+			default:
+				return nil, fmt.Errorf("entry %d: %s must be a string, number or boolean", i+1, name)
+			}
+			if value = strings.TrimSpace(value); value != "" {
+				record.Values[strings.ToLower(strings.TrimSpace(name))] = value
+			}
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
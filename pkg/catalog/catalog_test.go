@@ -0,0 +1,71 @@
+package catalog
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRead(t *testing.T) {
+	t.Run("should read CSV records with their line numbers", func(t *testing.T) {
+		input := "\ufeffTitle, Slug ,price\n" +
+			"Laskar Pelangi,laskar-pelangi,50000\n" +
+			"\n" +
+			"\"Bumi, Manusia\",bumi-manusia,\n"
+
+		records, err := Read(strings.NewReader(input), FormatCSV)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(records) != 2 {
+			t.Fatalf("expected 2 records, got %d", len(records))
+		}
+		if records[0].Line != 2 || records[0].Get("title") != "Laskar Pelangi" || records[0].Get("price") != "50000" {
+			t.Errorf("unexpected first record %+v", records[0])
+		}
+		if records[1].Line != 4 || records[1].Get("title") != "Bumi, Manusia" {
+			t.Errorf("unexpected second record %+v", records[1])
+		}
+		if _, ok := records[1].Values["price"]; ok {
+			t.Error("expected empty values to be left out")
+		}
+	})
+
+	t.Run("should reject CSV records with more values than columns", func(t *testing.T) {
+		if _, err := Read(strings.NewReader("title\na,b\n"), FormatCSV); err == nil {
+			t.Error("expected an error")
+		}
+	})
+
+	t.Run("should read JSON objects as text", func(t *testing.T) {
+		input := `[{"Title": "Laskar Pelangi", "price": 50000, "published": true, "synopsis": null}]`
+
+		records, err := Read(strings.NewReader(input), FormatJSON)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(records) != 1 {
+			t.Fatalf("expected 1 record, got %d", len(records))
+		}
+		record := records[0]
+		if record.Line != 1 || record.Get("title") != "Laskar Pelangi" || record.Get("price") != "50000" || record.Get("published") != "true" {
+			t.Errorf("unexpected record %+v", record)
+		}
+		if _, ok := record.Values["synopsis"]; ok {
+			t.Error("expected null values to be left out")
+		}
+	})
+
+	t.Run("should reject JSON that is not an array of flat objects", func(t *testing.T) {
+		for _, input := range []string{`{"title": "x"}`, `[{"title": ["x"]}]`} {
+			if _, err := Read(strings.NewReader(input), FormatJSON); err == nil {
+				t.Errorf("expected an error for %s", input)
+			}
+		}
+	})
+}
+
+func TestFormatFromFilename(t *testing.T) {
+	if FormatFromFilename("catalog.CSV") != FormatCSV || FormatFromFilename("a/b.json") != FormatJSON || FormatFromFilename("c.xlsx") != "" {
+		t.Error("unexpected format")
+	}
+}
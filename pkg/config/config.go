@@ -1,16 +1,21 @@
 package config
 
 import (
+	"buku-pintar/pkg/storage"
 	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
 
 	"github.com/redis/go-redis/v9"
 )
 
 type AppConfig struct {
-	Port        string `json:"port"`
-	Environment string `json:"environment"`
+	Port           string   `json:"port"`
+	Environment    string   `json:"environment"`
+	CursorSecret   string   `json:"cursor_secret"`   // HMAC key used to sign pagination cursors
+	TrustedProxies []string `json:"trusted_proxies"` // Proxy addresses or CIDR ranges whose X-Forwarded-For is believed
 }
 
 // DatabaseConfig represents the database configuration
@@ -46,17 +51,125 @@ type PaymentConfig struct {
 }
 
 type XenditConfig struct {
-	Key string `json:"key"`
+	Key           string `json:"key"`
+	CallbackToken string `json:"callback_token"` // Verification token Xendit sends in X-CALLBACK-TOKEN
+}
+
+// DownloadConfig represents the signed file download configuration
+type DownloadConfig struct {
+	Secret     string `json:"secret"`      // HMAC key used to sign download URLs
+	TTLSeconds int    `json:"ttl_seconds"` // Lifetime of a signed download URL
+	StorageDir string `json:"storage_dir"` // Base directory for ebook files stored on local disk
+}
+
+// ProgressConfig represents the reading progress sync configuration
+type ProgressConfig struct {
+	FlushIntervalSeconds int `json:"flush_interval_seconds"` // How often hot progress is written from Redis to MySQL
+}
+
+// WishlistConfig represents the wishlist notification configuration
+type WishlistConfig struct {
+	PriceDropCheckIntervalSeconds int `json:"price_drop_check_interval_seconds"` // How often newly active discounts are announced
+}
+
+// RecommendationConfig represents the related and recommended ebooks configuration
+type RecommendationConfig struct {
+	RefreshIntervalSeconds int `json:"refresh_interval_seconds"` // How often recommendations are recomputed into Redis
+	MaxResults             int `json:"max_results"`              // Recommendations stored per ebook and per user
+	ActiveUserDays         int `json:"active_user_days"`         // Users who bought or read within this window get personal recommendations
+}
+
+// TrendingConfig represents the trending ebooks configuration
+type TrendingConfig struct {
+	SnapshotIntervalSeconds int `json:"snapshot_interval_seconds"` // How often daily scores are copied from Redis to MySQL
+	SnapshotRetentionDays   int `json:"snapshot_retention_days"`   // Days of snapshots kept, at least the 30-day window
+}
+
+// AnalyticsConfig represents the usage event ingestion configuration
+type AnalyticsConfig struct {
+	FlushIntervalSeconds          int `json:"flush_interval_seconds"`           // How often buffered events are written from Redis to MySQL
+	RetentionMonths               int `json:"retention_months"`                 // Full months of events kept besides the current one
+	PartitionCheckIntervalSeconds int `json:"partition_check_interval_seconds"` // How often monthly partitions are created and dropped
+	MaxBufferedEvents             int `json:"max_buffered_events"`              // Events waiting in Redis beyond which new batches are refused
+}
+
+// MediaConfig represents the uploaded media storage configuration
+type MediaConfig struct {
+	Driver        string        `json:"driver"`          // "local" stores under download.storage_dir, "s3" uses the bucket below
+	PublicBaseURL string        `json:"public_base_url"` // Where the local driver's public media are served from
+	S3            MediaS3Config `json:"s3"`
+}
+
+// MediaS3Config represents an S3-compatible bucket for uploaded media
+type MediaS3Config struct {
+	Endpoint        string `json:"endpoint"`
+	Region          string `json:"region"`
+	Bucket          string `json:"bucket"`
+	AccessKeyID     string `json:"access_key_id"`
+	SecretAccessKey string `json:"secret_access_key"`
+	PublicURL       string `json:"public_url"`     // CDN or bucket URL public media are served from
+	UsePathStyle    bool   `json:"use_path_style"` // Needed by MinIO and most self-hosted S3 servers
+}
+
+// UploadConfig represents the resumable (tus) upload configuration
+type UploadConfig struct {
+	Dir                    string `json:"dir"`                      // Local directory partial uploads are kept in until complete
+	ExpirySeconds          int    `json:"expiry_seconds"`           // How long an upload may sit idle before it is abandoned
+	CleanupIntervalSeconds int    `json:"cleanup_interval_seconds"` // How often abandoned uploads are deleted
+}
+
+// PublishingConfig represents the scheduled publishing configuration
+type PublishingConfig struct {
+	CheckIntervalSeconds int `json:"check_interval_seconds"` // How often due publications and unpublications are applied
+}
+
+// I18nConfig represents the languages catalog content is served in
+type I18nConfig struct {
+	DefaultLocale    string   `json:"default_locale"`    // Language content is stored in
+	SupportedLocales []string `json:"supported_locales"` // Languages content can be translated into, the default included
+}
+
+// OPDSConfig represents the OPDS catalog served to e-reader apps
+type OPDSConfig struct {
+	Title  string `json:"title"`   // Catalog name shown by reader apps
+	BuyURL string `json:"buy_url"` // Store page of an ebook, {slug} and {id} are replaced with the ebook's
+}
+
+// SitemapConfig represents the sitemap generation configuration
+type SitemapConfig struct {
+	SiteURL              string `json:"site_url"`               // Storefront origin the listed pages and sitemaps are served from
+	URLsPerSitemap       int    `json:"urls_per_sitemap"`       // Pages per child sitemap, at most 50000
+	CheckIntervalSeconds int    `json:"check_interval_seconds"` // How often content changes are looked for
+}
+
+// FeedConfig represents the RSS and Atom feeds of published content
+type FeedConfig struct {
+	Title   string `json:"title"`    // Prefix of every feed's title
+	SiteURL string `json:"site_url"` // Storefront origin items link to, the sitemap's by default
+	Items   int    `json:"items"`    // Newest items listed in a feed
 }
 
 // Config represents the application configuration
 type Config struct {
-	Supabase      SupabaseConfig `json:"supabase"`
-	Database      DatabaseConfig `json:"database"`
-	DatabaseLocal DatabaseConfig `json:"database_local"`
-	App           AppConfig      `json:"app"`
-	Payment       PaymentConfig  `json:"payment"`
-	Redis         RedisConfig    `json:"redis"`
+	Supabase       SupabaseConfig       `json:"supabase"`
+	Database       DatabaseConfig       `json:"database"`
+	DatabaseLocal  DatabaseConfig       `json:"database_local"`
+	App            AppConfig            `json:"app"`
+	Payment        PaymentConfig        `json:"payment"`
+	Redis          RedisConfig          `json:"redis"`
+	Download       DownloadConfig       `json:"download"`
+	Progress       ProgressConfig       `json:"progress"`
+	Wishlist       WishlistConfig       `json:"wishlist"`
+	Recommendation RecommendationConfig `json:"recommendation"`
+	Trending       TrendingConfig       `json:"trending"`
+	Analytics      AnalyticsConfig      `json:"analytics"`
+	Media          MediaConfig          `json:"media"`
+	Upload         UploadConfig         `json:"upload"`
+	Publishing     PublishingConfig     `json:"publishing"`
+	I18n           I18nConfig           `json:"i18n"`
+	OPDS           OPDSConfig           `json:"opds"`
+	Sitemap        SitemapConfig        `json:"sitemap"`
+	Feed           FeedConfig           `json:"feed"`
 }
 
 // Load loads the configuration from a JSON file
@@ -78,6 +191,123 @@ func Load(path string) (*Config, error) {
 		config.App.Environment = "production"
 	}
 
+	// Set default download settings if not specified
+	if config.Download.TTLSeconds <= 0 {
+		config.Download.TTLSeconds = 300
+	}
+	if config.Download.StorageDir == "" {
+		config.Download.StorageDir = "./storage"
+	}
+
+	// Set default reading progress flush interval if not specified
+	if config.Progress.FlushIntervalSeconds <= 0 {
+		config.Progress.FlushIntervalSeconds = 30
+	}
+
+	// Set default price drop check interval if not specified
+	if config.Wishlist.PriceDropCheckIntervalSeconds <= 0 {
+		config.Wishlist.PriceDropCheckIntervalSeconds = 60
+	}
+
+	// Set default recommendation settings if not specified
+	if config.Recommendation.RefreshIntervalSeconds <= 0 {
+		config.Recommendation.RefreshIntervalSeconds = 3600
+	}
+	if config.Recommendation.MaxResults <= 0 {
+		config.Recommendation.MaxResults = 20
+	}
+	if config.Recommendation.ActiveUserDays <= 0 {
+		config.Recommendation.ActiveUserDays = 90
+	}
+
+	// Set default trending settings if not specified
+	if config.Trending.SnapshotIntervalSeconds <= 0 {
+		config.Trending.SnapshotIntervalSeconds = 3600
+	}
+	if config.Trending.SnapshotRetentionDays <= 0 {
+		config.Trending.SnapshotRetentionDays = 90
+	}
+
+	// Set default analytics settings if not specified
+	if config.Analytics.FlushIntervalSeconds <= 0 {
+		config.Analytics.FlushIntervalSeconds = 10
+	}
+	if config.Analytics.RetentionMonths <= 0 {
+		config.Analytics.RetentionMonths = 12
+	}
+	if config.Analytics.PartitionCheckIntervalSeconds <= 0 {
+		config.Analytics.PartitionCheckIntervalSeconds = 86400
+	}
+	if config.Analytics.MaxBufferedEvents <= 0 {
+		config.Analytics.MaxBufferedEvents = 100000
+	}
+
+	// Set default media storage if not specified
+	if config.Media.Driver == "" {
+		config.Media.Driver = "local"
+	}
+	if config.Media.PublicBaseURL == "" {
+		config.Media.PublicBaseURL = "/media"
+	}
+
+	// Set default resumable upload settings if not specified
+	if config.Upload.Dir == "" {
+		config.Upload.Dir = "./uploads"
+	}
+	if config.Upload.ExpirySeconds <= 0 {
+		config.Upload.ExpirySeconds = 86400
+	}
+	if config.Upload.CleanupIntervalSeconds <= 0 {
+		config.Upload.CleanupIntervalSeconds = 3600
+	}
+	if config.Publishing.CheckIntervalSeconds <= 0 {
+		config.Publishing.CheckIntervalSeconds = 60
+	}
+
+	// Set default languages if not specified; the default language is always supported
+	config.I18n.DefaultLocale = strings.ToLower(strings.TrimSpace(config.I18n.DefaultLocale))
+	if config.I18n.DefaultLocale == "" {
+		config.I18n.DefaultLocale = "id"
+	}
+	supported := []string{config.I18n.DefaultLocale}
+	for _, locale := range config.I18n.SupportedLocales {
+		locale = strings.ToLower(strings.TrimSpace(locale))
+		if locale != "" && !slices.Contains(supported, locale) {
+			supported = append(supported, locale)
+		}
+	}
+	config.I18n.SupportedLocales = supported
+
+	// Set default OPDS catalog settings if not specified
+	if config.OPDS.Title == "" {
+		config.OPDS.Title = "Buku Pintar"
+	}
+	if config.OPDS.BuyURL == "" {
+		config.OPDS.BuyURL = "/api/v1/ebooks/slug/{slug}"
+	}
+
+	// Set default sitemap settings if not specified
+	if config.Sitemap.SiteURL == "" {
+		config.Sitemap.SiteURL = "http://localhost:8080"
+	}
+	if config.Sitemap.URLsPerSitemap <= 0 || config.Sitemap.URLsPerSitemap > 50000 {
+		config.Sitemap.URLsPerSitemap = 10000
+	}
+	if config.Sitemap.CheckIntervalSeconds <= 0 {
+		config.Sitemap.CheckIntervalSeconds = 300
+	}
+
+	// Set default feed settings if not specified
+	if config.Feed.Title == "" {
+		config.Feed.Title = "Buku Pintar"
+	}
+	if config.Feed.SiteURL == "" {
+		config.Feed.SiteURL = config.Sitemap.SiteURL
+	}
+	if config.Feed.Items <= 0 || config.Feed.Items > 100 {
+		config.Feed.Items = 20
+	}
+
 	return config, nil
 }
 
@@ -100,3 +330,27 @@ func (c *Config) LoadRedis() (*redis.Client, error) {
 
 	return redisClient, nil
 }
+
+// LoadMediaStorage opens the configured media storage driver.
+// The local driver keeps uploads next to the downloadable files so both stream from one directory.
+func (c *Config) LoadMediaStorage() (storage.Storage, error) {
+	switch c.Media.Driver {
+	case "local":
+		return storage.NewLocal(c.Download.StorageDir, c.Media.PublicBaseURL), nil
+	case "s3":
+		s3, err := storage.NewS3(storage.S3Config{
+			Endpoint:        c.Media.S3.Endpoint,
+			Region:          c.Media.S3.Region,
+			Bucket:          c.Media.S3.Bucket,
+			AccessKeyID:     c.Media.S3.AccessKeyID,
+			SecretAccessKey: c.Media.S3.SecretAccessKey,
+			PublicURL:       c.Media.S3.PublicURL,
+			UsePathStyle:    c.Media.S3.UsePathStyle,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return s3, nil
+	}
+	return nil, fmt.Errorf("unknown media driver %q", c.Media.Driver)
+}
@@ -0,0 +1,446 @@
+// Package epub reads the metadata and navigation of EPUB 2 and EPUB 3 files.
+// Only the container, the OPF package and the navigation documents are read up front;
+// content documents are read on request and never parsed.
+package epub
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+)
+
+const (
+	mimeType        = "application/epub+zip"
+	containerPath   = "META-INF/container.xml"
+	packageMimeType = "application/oebps-package+xml"
+	ncxMimeType     = "application/x-dtbncx+xml"
+
+	// maxDocumentSize caps how much of a single XML document is read, so a
+	// small ZIP entry cannot expand into an unbounded amount of memory
+	maxDocumentSize = 10 << 20
+)
+
+// Book is the metadata and navigation of an EPUB
+type Book struct {
+	Version     string
+	Title       string
+	Language    string
+	Creators    []string
+	Identifier  string
+	Description string
+
+	// Spine lists the content documents in reading order, as paths inside the container
+	Spine []string
+	// TOC is the table of contents from the EPUB 3 nav document, or the NCX for EPUB 2
+	TOC []*NavPoint
+	// Warnings are problems that do not stop the book from being read, such as a missing table of contents
+	Warnings []string
+
+	files      map[string]*zip.File
+	mediaTypes map[string]string
+}
+
+// NavPoint is one entry of the table of contents.
+// Href is the path of the content document inside the container, without the fragment.
+type NavPoint struct {
+	Title    string
+	Href     string
+	Children []*NavPoint
+}
+
+// SpinePosition returns the 1-based position of a content document in the reading order, or 0 if it is not in the spine
+func (b *Book) SpinePosition(href string) int {
+	for i, item := range b.Spine {
+		if item == href {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// ReadContent returns a content document of the spine with its media type from the manifest.
+// The reader the book was read from must still be open.
+func (b *Book) ReadContent(href string) ([]byte, string, error) {
+	if b.SpinePosition(href) == 0 {
+		return nil, "", fmt.Errorf("%s is not in the spine", href)
+	}
+	data, err := readFile(b.files, href)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, b.mediaTypes[href], nil
+}
+
+// ValidationError lists everything that makes a file unreadable as an EPUB
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid EPUB: " + strings.Join(e.Problems, "; ")
+}
+
+type container struct {
+	Rootfiles []struct {
+		FullPath  string `xml:"full-path,attr"`
+		MediaType string `xml:"media-type,attr"`
+	} `xml:"rootfiles>rootfile"`
+}
+
+type packageDocument struct {
+	Version  string `xml:"version,attr"`
+	Metadata struct {
+		Titles       []string `xml:"title"`
+		Languages    []string `xml:"language"`
+		Creators     []string `xml:"creator"`
+		Identifiers  []string `xml:"identifier"`
+		Descriptions []string `xml:"description"`
+	} `xml:"metadata"`
+	Manifest []struct {
+		ID         string `xml:"id,attr"`
+		Href       string `xml:"href,attr"`
+		MediaType  string `xml:"media-type,attr"`
+		Properties string `xml:"properties,attr"`
+	} `xml:"manifest>item"`
+	Spine struct {
+		TOC      string `xml:"toc,attr"`
+		Itemrefs []struct {
+			IDRef string `xml:"idref,attr"`
+		} `xml:"itemref"`
+	} `xml:"spine"`
+}
+
+type ncxPoint struct {
+	Label   string `xml:"navLabel>text"`
+	Content struct {
+		Src string `xml:"src,attr"`
+	} `xml:"content"`
+	Points []ncxPoint `xml:"navPoint"`
+}
+
+type ncxDocument struct {
+	Points []ncxPoint `xml:"navMap>navPoint"`
+}
+
+// xhtmlNode is a generic element tree, used to walk the EPUB 3 nav document
+type xhtmlNode struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr  `xml:",any,attr"`
+	Inner    string      `xml:",innerxml"`
+	Children []xhtmlNode `xml:",any"`
+}
+
+// Read parses the EPUB in r. Files that are not a usable EPUB return a *ValidationError listing every problem found.
+func Read(r io.ReaderAt, size int64) (*Book, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, &ValidationError{Problems: []string{"file is not a ZIP container"}}
+	}
+
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, file := range archive.File {
+		files[file.Name] = file
+	}
+
+	var problems []string
+	book := &Book{files: files}
+
+	if mimetype, err := readFile(files, "mimetype"); err != nil {
+		problems = append(problems, "mimetype file is missing")
+	} else if strings.TrimSpace(string(mimetype)) != mimeType {
+		problems = append(problems, fmt.Sprintf("mimetype must be %s", mimeType))
+	} else if archive.File[0].Name != "mimetype" {
+		book.Warnings = append(book.Warnings, "mimetype should be the first file in the container")
+	}
+
+	var c container
+	if err := decodeFile(files, containerPath, &c); err != nil {
+		problems = append(problems, err.Error())
+		return nil, &ValidationError{Problems: problems}
+	}
+	packagePath := ""
+	for _, rootfile := range c.Rootfiles {
+		if rootfile.MediaType == packageMimeType || packagePath == "" {
+			packagePath = rootfile.FullPath
+		}
+	}
+	if packagePath == "" {
+		problems = append(problems, containerPath+" does not reference a package document")
+		return nil, &ValidationError{Problems: problems}
+	}
+
+	var pkg packageDocument
+	if err := decodeFile(files, packagePath, &pkg); err != nil {
+		problems = append(problems, err.Error())
+		return nil, &ValidationError{Problems: problems}
+	}
+
+	book.Version = pkg.Version
+	book.Title = firstNonEmpty(pkg.Metadata.Titles)
+	book.Language = firstNonEmpty(pkg.Metadata.Languages)
+	book.Identifier = firstNonEmpty(pkg.Metadata.Identifiers)
+	book.Description = firstNonEmpty(pkg.Metadata.Descriptions)
+	for _, creator := range pkg.Metadata.Creators {
+		if creator = strings.TrimSpace(creator); creator != "" {
+			book.Creators = append(book.Creators, creator)
+		}
+	}
+	if book.Title == "" {
+		problems = append(problems, "package metadata has no dc:title")
+	}
+	if book.Language == "" {
+		problems = append(problems, "package metadata has no dc:language")
+	}
+
+	// Manifest hrefs are relative to the package document
+	baseDir := path.Dir(packagePath)
+	manifest := make(map[string]string, len(pkg.Manifest))
+	book.mediaTypes = make(map[string]string, len(pkg.Manifest))
+	navPath, ncxPath := "", ""
+	for _, item := range pkg.Manifest {
+		href := resolveHref(baseDir, item.Href)
+		manifest[item.ID] = href
+		book.mediaTypes[href] = item.MediaType
+		if navPath == "" && hasProperty(item.Properties, "nav") {
+			navPath = href
+		}
+		if item.MediaType == ncxMimeType && (ncxPath == "" || item.ID == pkg.Spine.TOC) {
+			ncxPath = href
+		}
+	}
+
+	for _, itemref := range pkg.Spine.Itemrefs {
+		href, ok := manifest[itemref.IDRef]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("spine references unknown manifest item %q", itemref.IDRef))
+			continue
+		}
+		if _, ok := files[href]; !ok {
+			problems = append(problems, fmt.Sprintf("content document %s is missing", href))
+			continue
+		}
+		book.Spine = append(book.Spine, href)
+	}
+	if len(pkg.Spine.Itemrefs) == 0 {
+		problems = append(problems, "spine is empty")
+	}
+
+	if len(problems) > 0 {
+		return nil, &ValidationError{Problems: problems}
+	}
+
+	switch {
+	case navPath != "":
+		book.TOC, err = readNav(files, navPath)
+	case ncxPath != "":
+		book.TOC, err = readNCX(files, ncxPath)
+	default:
+		book.Warnings = append(book.Warnings, "no navigation document, the table of contents is empty")
+	}
+	if err != nil {
+		book.Warnings = append(book.Warnings, err.Error())
+	}
+
+	return book, nil
+}
+
+// readNav reads the toc nav of an EPUB 3 navigation document, or its first nav if none is marked as toc
+func readNav(files map[string]*zip.File, navPath string) ([]*NavPoint, error) {
+	data, err := readFile(files, navPath)
+	if err != nil {
+		return nil, fmt.Errorf("navigation document %s is missing", navPath)
+	}
+
+	var root xhtmlNode
+	if err := newXHTMLDecoder(string(data)).Decode(&root); err != nil {
+		return nil, fmt.Errorf("navigation document %s is not valid XHTML: %v", navPath, err)
+	}
+
+	navs := findElements(&root, "nav")
+	if len(navs) == 0 {
+		return nil, fmt.Errorf("navigation document %s has no nav element", navPath)
+	}
+	toc := navs[0]
+	for _, nav := range navs {
+		if hasProperty(attr(nav, "type"), "toc") {
+			toc = nav
+			break
+		}
+	}
+
+	baseDir := path.Dir(navPath)
+	var walk func(list *xhtmlNode) []*NavPoint
+	walk = func(list *xhtmlNode) []*NavPoint {
+		var points []*NavPoint
+		for i := range list.Children {
+			item := &list.Children[i]
+			if item.XMLName.Local != "li" {
+				continue
+			}
+			point := &NavPoint{}
+			for j := range item.Children {
+				child := &item.Children[j]
+				switch child.XMLName.Local {
+				case "a", "span":
+					if point.Title == "" {
+						point.Title = collapseSpace(textContent(child))
+						if href := attr(child, "href"); href != "" {
+							point.Href = resolveHref(baseDir, href)
+						}
+					}
+				case "ol":
+					point.Children = walk(child)
+				}
+			}
+			points = append(points, point)
+		}
+		return points
+	}
+
+	for i := range toc.Children {
+		if toc.Children[i].XMLName.Local == "ol" {
+			return walk(&toc.Children[i]), nil
+		}
+	}
+	return nil, nil
+}
+
+// readNCX reads the navMap of an EPUB 2 NCX document
+func readNCX(files map[string]*zip.File, ncxPath string) ([]*NavPoint, error) {
+	var doc ncxDocument
+	if err := decodeFile(files, ncxPath, &doc); err != nil {
+		return nil, err
+	}
+
+	baseDir := path.Dir(ncxPath)
+	var walk func(points []ncxPoint) []*NavPoint
+	walk = func(points []ncxPoint) []*NavPoint {
+		var result []*NavPoint
+		for _, point := range points {
+			result = append(result, &NavPoint{
+				Title:    collapseSpace(point.Label),
+				Href:     resolveHref(baseDir, point.Content.Src),
+				Children: walk(point.Points),
+			})
+		}
+		return result
+	}
+
+	return walk(doc.Points), nil
+}
+
+func readFile(files map[string]*zip.File, name string) ([]byte, error) {
+	file, ok := files[name]
+	if !ok {
+		return nil, fmt.Errorf("%s is missing", name)
+	}
+
+	rc, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("%s cannot be read: %v", name, err)
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, maxDocumentSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("%s cannot be read: %v", name, err)
+	}
+	if len(data) > maxDocumentSize {
+		return nil, fmt.Errorf("%s is larger than %d bytes", name, maxDocumentSize)
+	}
+	return data, nil
+}
+
+func decodeFile(files map[string]*zip.File, name string, v any) error {
+	data, err := readFile(files, name)
+	if err != nil {
+		return err
+	}
+	if err := xml.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%s is not valid XML: %v", name, err)
+	}
+	return nil
+}
+
+// resolveHref turns an href relative to baseDir into a container path, dropping any fragment
+func resolveHref(baseDir, href string) string {
+	href, _, _ = strings.Cut(href, "#")
+	if href == "" {
+		return ""
+	}
+	if unescaped, err := url.PathUnescape(href); err == nil {
+		href = unescaped
+	}
+	return strings.TrimPrefix(path.Join(baseDir, href), "/")
+}
+
+func findElements(node *xhtmlNode, local string) []*xhtmlNode {
+	var found []*xhtmlNode
+	if node.XMLName.Local == local {
+		found = append(found, node)
+	}
+	for i := range node.Children {
+		found = append(found, findElements(&node.Children[i], local)...)
+	}
+	return found
+}
+
+func attr(node *xhtmlNode, local string) string {
+	for _, a := range node.Attrs {
+		if a.Name.Local == local {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// newXHTMLDecoder is lenient about HTML entities and unclosed void elements, which nav documents often contain
+func newXHTMLDecoder(data string) *xml.Decoder {
+	decoder := xml.NewDecoder(strings.NewReader(data))
+	decoder.Strict = false
+	decoder.AutoClose = xml.HTMLAutoClose
+	decoder.Entity = xml.HTMLEntity
+	return decoder
+}
+
+// textContent returns the text of node and its descendants in document order
+func textContent(node *xhtmlNode) string {
+	var b strings.Builder
+	decoder := newXHTMLDecoder(node.Inner)
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+		if text, ok := token.(xml.CharData); ok {
+			b.Write(text)
+		}
+	}
+	return b.String()
+}
+
+func hasProperty(properties, name string) bool {
+	for _, property := range strings.Fields(properties) {
+		if property == name {
+			return true
+		}
+	}
+	return false
+}
+
+func collapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func firstNonEmpty(values []string) string {
+	for _, value := range values {
+		if value = collapseSpace(value); value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,203 @@
+package epub
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"testing"
+)
+
+const testContainer = `<?xml version="1.0"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles>
+    <rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/>
+  </rootfiles>
+</container>`
+
+const testPackage3 = `<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="id">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:identifier id="id">urn:isbn:9786020000000</dc:identifier>
+    <dc:title>  Laskar   Pelangi </dc:title>
+    <dc:language>id</dc:language>
+    <dc:creator>Andrea Hirata</dc:creator>
+  </metadata>
+  <manifest>
+    <item id="nav" href="nav.xhtml" media-type="application/xhtml+xml" properties="nav"/>
+    <item id="c1" href="text/chapter%201.xhtml" media-type="application/xhtml+xml"/>
+    <item id="c2" href="text/chapter2.xhtml" media-type="application/xhtml+xml"/>
+  </manifest>
+  <spine>
+    <itemref idref="c1"/>
+    <itemref idref="c2"/>
+  </spine>
+</package>`
+
+const testNav = `<?xml version="1.0" encoding="UTF-8"?>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops">
+<body>
+  <nav epub:type="landmarks"><ol><li><a href="text/chapter2.xhtml">Start</a></li></ol></nav>
+  <nav epub:type="toc">
+    <ol>
+      <li><a href="text/chapter%201.xhtml"><span>1.</span> Sekolah&nbsp;Muhammadiyah</a>
+        <ol>
+          <li><a href="text/chapter%201.xhtml#s1">Hari Pertama</a></li>
+        </ol>
+      </li>
+      <li><span>Bagian Dua</span>
+        <ol><li><a href="text/chapter2.xhtml">Lintang</a></li></ol>
+      </li>
+    </ol>
+  </nav>
+</body>
+</html>`
+
+const testPackage2 = `<?xml version="1.0"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="2.0">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:title>Bumi Manusia</dc:title>
+    <dc:language>id</dc:language>
+  </metadata>
+  <manifest>
+    <item id="ncx" href="toc.ncx" media-type="application/x-dtbncx+xml"/>
+    <item id="c1" href="c1.html" media-type="application/xhtml+xml"/>
+  </manifest>
+  <spine toc="ncx"><itemref idref="c1"/></spine>
+</package>`
+
+const testNCX = `<?xml version="1.0"?>
+<ncx xmlns="http://www.daisy.org/z3986/2005/ncx/" version="2005-1">
+  <navMap>
+    <navPoint id="p1"><navLabel><text>Satu</text></navLabel><content src="c1.html"/>
+      <navPoint id="p2"><navLabel><text>Satu A</text></navLabel><content src="c1.html#a"/></navPoint>
+    </navPoint>
+  </navMap>
+</ncx>`
+
+func buildEPUB(t *testing.T, files map[string]string) *bytes.Reader {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	names := []string{"mimetype"}
+	for name := range files {
+		if name != "mimetype" {
+			names = append(names, name)
+		}
+	}
+	for _, name := range names {
+		content, ok := files[name]
+		if !ok {
+			continue
+		}
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestRead_EPUB3(t *testing.T) {
+	r := buildEPUB(t, map[string]string{
+		"mimetype":                   "application/epub+zip",
+		"META-INF/container.xml":     testContainer,
+		"OEBPS/content.opf":          testPackage3,
+		"OEBPS/nav.xhtml":            testNav,
+		"OEBPS/text/chapter 1.xhtml": "<html/>",
+		"OEBPS/text/chapter2.xhtml":  "<html><p>Lintang</p></html>",
+	})
+
+	book, err := Read(r, r.Size())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	content, mediaType, err := book.ReadContent("OEBPS/text/chapter2.xhtml")
+	if err != nil || string(content) != "<html><p>Lintang</p></html>" || mediaType != "application/xhtml+xml" {
+		t.Errorf("unexpected content %q (%s, %v)", content, mediaType, err)
+	}
+	if _, _, err := book.ReadContent("OEBPS/nav.xhtml"); err == nil {
+		t.Error("expected documents outside the spine to be refused")
+	}
+
+	if book.Title != "Laskar Pelangi" || book.Language != "id" || len(book.Creators) != 1 || book.Creators[0] != "Andrea Hirata" {
+		t.Errorf("unexpected metadata %+v", book)
+	}
+	if len(book.Spine) != 2 || book.SpinePosition("OEBPS/text/chapter2.xhtml") != 2 {
+		t.Errorf("unexpected spine %v", book.Spine)
+	}
+
+	// The toc nav is used rather than the landmarks, and labels keep their text order
+	if len(book.TOC) != 2 {
+		t.Fatalf("expected 2 top-level entries, got %d", len(book.TOC))
+	}
+	first := book.TOC[0]
+	if first.Title != "1. Sekolah Muhammadiyah" || first.Href != "OEBPS/text/chapter 1.xhtml" {
+		t.Errorf("unexpected first entry %+v", first)
+	}
+	if len(first.Children) != 1 || first.Children[0].Href != "OEBPS/text/chapter 1.xhtml" {
+		t.Errorf("expected the fragment to be dropped, got %+v", first.Children)
+	}
+	second := book.TOC[1]
+	if second.Title != "Bagian Dua" || second.Href != "" || len(second.Children) != 1 {
+		t.Errorf("unexpected heading entry %+v", second)
+	}
+}
+
+func TestRead_EPUB2NCX(t *testing.T) {
+	r := buildEPUB(t, map[string]string{
+		"mimetype":               "application/epub+zip",
+		"META-INF/container.xml": testContainer,
+		"OEBPS/content.opf":      testPackage2,
+		"OEBPS/toc.ncx":          testNCX,
+		"OEBPS/c1.html":          "<html/>",
+	})
+
+	book, err := Read(r, r.Size())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(book.TOC) != 1 || book.TOC[0].Title != "Satu" || len(book.TOC[0].Children) != 1 || book.TOC[0].Children[0].Href != "OEBPS/c1.html" {
+		t.Errorf("unexpected table of contents %+v", book.TOC)
+	}
+	if len(book.Creators) != 0 {
+		t.Errorf("expected no creators, got %v", book.Creators)
+	}
+}
+
+func TestRead_Invalid(t *testing.T) {
+	t.Run("should reject a file that is not a ZIP", func(t *testing.T) {
+		r := bytes.NewReader([]byte("%PDF-1.7"))
+		_, err := Read(r, r.Size())
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("expected validation error, got %v", err)
+		}
+	})
+
+	t.Run("should report every metadata problem at once", func(t *testing.T) {
+		r := buildEPUB(t, map[string]string{
+			"mimetype":               "application/zip",
+			"META-INF/container.xml": testContainer,
+			"OEBPS/content.opf": `<package version="3.0"><metadata/>
+				<manifest><item id="c1" href="c1.xhtml"/></manifest>
+				<spine><itemref idref="c1"/><itemref idref="c9"/></spine></package>`,
+		})
+		_, err := Read(r, r.Size())
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Fatalf("expected validation error, got %v", err)
+		}
+		// mimetype, title, language, missing c1.xhtml and unknown c9
+		if len(validationErr.Problems) != 5 {
+			t.Errorf("expected 5 problems, got %v", validationErr.Problems)
+		}
+	})
+}
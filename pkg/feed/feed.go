@@ -0,0 +1,252 @@
+// Package feed writes syndication feeds as RSS 2.0 or Atom documents from a single description.
+package feed
+
+import (
+	"bytes"
+	"encoding/xml"
+	"mime"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Media types of the feed documents
+const (
+	MediaTypeRSS  = "application/rss+xml"
+	MediaTypeAtom = "application/atom+xml"
+)
+
+const (
+	namespaceAtom = "http://www.w3.org/2005/Atom"
+	namespaceDC   = "http://purl.org/dc/elements/1.1/"
+)
+
+// Feed describes a feed independently of the format it is written in
+type Feed struct {
+	ID          string // Permanent identifier of the feed, used by Atom
+	Title       string
+	Description string
+	Link        string // Page the feed is about
+	SelfURL     string // Where the feed itself is served
+	Updated     time.Time
+	Items       []Item
+}
+
+// Item is an entry of a feed
+type Item struct {
+	ID        string // Permanent identifier of the item, never reused
+	Title     string
+	Link      string
+	Summary   string
+	Author    string
+	Category  string
+	Published time.Time
+	Updated   time.Time
+	Enclosure *Enclosure
+}
+
+// Enclosure is a file attached to an item, such as its cover image
+type Enclosure struct {
+	URL    string
+	Type   string
+	Length int64 // Size in bytes, 0 when unknown
+}
+
+// NewImageEnclosure attaches an image, guessing its type from the extension of the URL
+func NewImageEnclosure(url string) *Enclosure {
+	if url == "" {
+		return nil
+	}
+
+	name := url
+	if i := strings.IndexAny(name, "?#"); i >= 0 {
+		name = name[:i]
+	}
+	contentType := mime.TypeByExtension(strings.ToLower(path.Ext(name)))
+	if !strings.HasPrefix(contentType, "image/") {
+		contentType = "image/jpeg"
+	}
+	return &Enclosure{URL: url, Type: contentType}
+}
+
+type rss struct {
+	XMLName     xml.Name   `xml:"rss"`
+	Version     string     `xml:"version,attr"`
+	AtomNS      string     `xml:"xmlns:atom,attr"`
+	DCNamespace string     `xml:"xmlns:dc,attr"`
+	Channel     rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Self          *atomLink `xml:"atom:link,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string        `xml:"title"`
+	Link        string        `xml:"link"`
+	GUID        rssGUID       `xml:"guid"`
+	Description string        `xml:"description,omitempty"`
+	Creator     string        `xml:"dc:creator,omitempty"`
+	Category    string        `xml:"category,omitempty"`
+	PubDate     string        `xml:"pubDate,omitempty"`
+	Enclosure   *rssEnclosure `xml:"enclosure"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Length int64  `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+// RSS writes the feed as an RSS 2.0 document
+func RSS(f *Feed) ([]byte, error) {
+	doc := &rss{
+		Version:     "2.0",
+		AtomNS:      namespaceAtom,
+		DCNamespace: namespaceDC,
+		Channel: rssChannel{
+			Title:         f.Title,
+			Link:          f.Link,
+			Description:   f.Description,
+			LastBuildDate: rssDate(f.Updated),
+			Items:         make([]rssItem, 0, len(f.Items)),
+		},
+	}
+	if f.SelfURL != "" {
+		doc.Channel.Self = &atomLink{Rel: "self", Href: f.SelfURL, Type: MediaTypeRSS}
+	}
+
+	for _, item := range f.Items {
+		entry := rssItem{
+			Title:       item.Title,
+			Link:        item.Link,
+			GUID:        rssGUID{Value: item.ID},
+			Description: item.Summary,
+			Creator:     item.Author,
+			Category:    item.Category,
+			PubDate:     rssDate(item.Published),
+		}
+		if item.Enclosure != nil {
+			entry.Enclosure = &rssEnclosure{URL: item.Enclosure.URL, Length: item.Enclosure.Length, Type: item.Enclosure.Type}
+		}
+		doc.Channel.Items = append(doc.Channel.Items, entry)
+	}
+
+	return encode(doc)
+}
+
+// rssDate formats a time as RFC 822, which RSS requires
+func rssDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC1123Z)
+}
+
+type atom struct {
+	XMLName   xml.Name    `xml:"feed"`
+	Namespace string      `xml:"xmlns,attr"`
+	ID        string      `xml:"id"`
+	Title     string      `xml:"title"`
+	Subtitle  string      `xml:"subtitle,omitempty"`
+	Updated   time.Time   `xml:"updated"`
+	Links     []atomLink  `xml:"link"`
+	Entries   []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	ID        string        `xml:"id"`
+	Title     string        `xml:"title"`
+	Updated   time.Time     `xml:"updated"`
+	Published *time.Time    `xml:"published,omitempty"`
+	Author    *atomAuthor   `xml:"author,omitempty"`
+	Category  *atomCategory `xml:"category,omitempty"`
+	Summary   string        `xml:"summary,omitempty"`
+	Links     []atomLink    `xml:"link"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomLink struct {
+	Rel    string `xml:"rel,attr,omitempty"`
+	Href   string `xml:"href,attr"`
+	Type   string `xml:"type,attr,omitempty"`
+	Length string `xml:"length,attr,omitempty"`
+}
+
+// Atom writes the feed as an Atom document
+func Atom(f *Feed) ([]byte, error) {
+	doc := &atom{
+		Namespace: namespaceAtom,
+		ID:        f.ID,
+		Title:     f.Title,
+		Subtitle:  f.Description,
+		Updated:   f.Updated.UTC(),
+		Entries:   make([]atomEntry, 0, len(f.Items)),
+	}
+	if f.SelfURL != "" {
+		doc.Links = append(doc.Links, atomLink{Rel: "self", Href: f.SelfURL, Type: MediaTypeAtom})
+	}
+	if f.Link != "" {
+		doc.Links = append(doc.Links, atomLink{Rel: "alternate", Href: f.Link, Type: "text/html"})
+	}
+
+	for _, item := range f.Items {
+		entry := atomEntry{
+			ID:      item.ID,
+			Title:   item.Title,
+			Updated: item.Updated.UTC(),
+			Summary: item.Summary,
+			Links:   []atomLink{{Rel: "alternate", Href: item.Link, Type: "text/html"}},
+		}
+		if !item.Published.IsZero() {
+			published := item.Published.UTC()
+			entry.Published = &published
+		}
+		if item.Author != "" {
+			entry.Author = &atomAuthor{Name: item.Author}
+		}
+		if item.Category != "" {
+			entry.Category = &atomCategory{Term: item.Category}
+		}
+		if item.Enclosure != nil {
+			link := atomLink{Rel: "enclosure", Href: item.Enclosure.URL, Type: item.Enclosure.Type}
+			if item.Enclosure.Length > 0 {
+				link.Length = strconv.FormatInt(item.Enclosure.Length, 10)
+			}
+			entry.Links = append(entry.Links, link)
+		}
+		doc.Entries = append(doc.Entries, entry)
+	}
+
+	return encode(doc)
+}
+
+func encode(doc any) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	encoder := xml.NewEncoder(&buf)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return nil, err
+	}
+	buf.WriteString("\n")
+	return buf.Bytes(), nil
+}
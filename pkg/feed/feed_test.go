@@ -0,0 +1,82 @@
+package feed
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func testFeed() *Feed {
+	published := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	return &Feed{
+		ID:      "urn:test:articles",
+		Title:   "Articles",
+		Link:    "https://app.com/articles",
+		SelfURL: "https://api.app.com/feeds/articles/rss",
+		Updated: published.Add(time.Hour),
+		Items: []Item{{
+			ID:        "urn:test:articles:1",
+			Title:     "Membaca & Menulis",
+			Link:      "https://app.com/articles/membaca-menulis",
+			Summary:   "Kebiasaan membaca",
+			Author:    "Andrea Hirata",
+			Category:  "Literasi",
+			Published: published,
+			Updated:   published.Add(time.Hour),
+			Enclosure: NewImageEnclosure("https://cdn.app.com/covers/membaca.png?w=600"),
+		}},
+	}
+}
+
+func TestRSS(t *testing.T) {
+	doc, err := RSS(testFeed())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := string(doc)
+
+	for _, want := range []string{
+		`<rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom" xmlns:dc="http://purl.org/dc/elements/1.1/">`,
+		`<atom:link rel="self" href="https://api.app.com/feeds/articles/rss" type="application/rss+xml"></atom:link>`,
+		`<title>Membaca &amp; Menulis</title>`,
+		`<guid isPermaLink="false">urn:test:articles:1</guid>`,
+		`<dc:creator>Andrea Hirata</dc:creator>`,
+		`<pubDate>Thu, 01 Oct 2026 08:00:00 +0000</pubDate>`,
+		`<enclosure url="https://cdn.app.com/covers/membaca.png?w=600" length="0" type="image/png"></enclosure>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in\n%s", want, out)
+		}
+	}
+}
+
+func TestAtom(t *testing.T) {
+	doc, err := Atom(testFeed())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := string(doc)
+
+	for _, want := range []string{
+		`<feed xmlns="http://www.w3.org/2005/Atom">`,
+		`<updated>2026-10-01T09:00:00Z</updated>`,
+		`<link rel="self" href="https://api.app.com/feeds/articles/rss" type="application/atom+xml"></link>`,
+		`<published>2026-10-01T08:00:00Z</published>`,
+		`<author>`,
+		`<category term="Literasi"></category>`,
+		`<link rel="enclosure" href="https://cdn.app.com/covers/membaca.png?w=600" type="image/png"></link>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in\n%s", want, out)
+		}
+	}
+}
+
+func TestNewImageEnclosure(t *testing.T) {
+	if NewImageEnclosure("") != nil {
+		t.Error("expected no enclosure without a cover")
+	}
+	if enclosure := NewImageEnclosure("https://cdn.app.com/cover"); enclosure.Type != "image/jpeg" {
+		t.Errorf("expected JPEG for an unknown extension, got %q", enclosure.Type)
+	}
+}
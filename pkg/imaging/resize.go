@@ -0,0 +1,61 @@
+// Package imaging resizes images with the standard library only.
+package imaging
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// Fit returns the size of an image scaled to width, keeping the aspect ratio
+func Fit(bounds image.Rectangle, width int) (int, int) {
+	height := bounds.Dy() * width / bounds.Dx()
+	return width, max(height, 1)
+}
+
+// Resize scales src down to width×height by averaging every source pixel a destination pixel covers.
+// Area averaging keeps thin lines and text readable when shrinking, unlike nearest-neighbour sampling.
+// Scaling up is not supported; the result is the average of the nearest source pixels.
+func Resize(src image.Image, width, height int) *image.RGBA {
+	bounds := src.Bounds()
+	// Work on premultiplied RGBA so transparent pixels do not bleed their colour into the average
+	rgba, ok := src.(*image.RGBA)
+	if !ok || bounds.Min != (image.Point{}) {
+		rgba = image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+		draw.Draw(rgba, rgba.Bounds(), src, bounds.Min, draw.Src)
+	}
+	srcWidth, srcHeight := rgba.Bounds().Dx(), rgba.Bounds().Dy()
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0 := y * srcHeight / height
+		y1 := max((y+1)*srcHeight/height, y0+1)
+		for x := 0; x < width; x++ {
+			x0 := x * srcWidth / width
+			x1 := max((x+1)*srcWidth/width, x0+1)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				row := rgba.Pix[sy*rgba.Stride+x0*4 : sy*rgba.Stride+x1*4]
+				for i := 0; i < len(row); i += 4 {
+					r += uint64(row[i])
+					g += uint64(row[i+1])
+					b += uint64(row[i+2])
+					a += uint64(row[i+3])
+					n++
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{R: uint8(r / n), G: uint8(g / n), B: uint8(b / n), A: uint8(a / n)})
+		}
+	}
+	return dst
+}
+
+// Flatten draws img over an opaque background, for encodings such as JPEG that have no alpha channel
+func Flatten(img image.Image, background color.Color) *image.RGBA {
+	bounds := img.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(background), image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), img, bounds.Min, draw.Over)
+	return dst
+}
@@ -0,0 +1,48 @@
+package imaging
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestFit(t *testing.T) {
+	if w, h := Fit(image.Rect(0, 0, 1200, 1800), 200); w != 200 || h != 300 {
+		t.Errorf("got %dx%d, want 200x300", w, h)
+	}
+	if w, h := Fit(image.Rect(0, 0, 4000, 10), 200); w != 200 || h != 1 {
+		t.Errorf("got %dx%d, want a height of at least 1", w, h)
+	}
+}
+
+func TestResize_AveragesCoveredPixels(t *testing.T) {
+	// Alternating black and white columns average to grey
+	src := image.NewNRGBA(image.Rect(10, 10, 14, 12))
+	for y := 10; y < 12; y++ {
+		for x := 10; x < 14; x++ {
+			if x%2 == 0 {
+				src.Set(x, y, color.White)
+			} else {
+				src.Set(x, y, color.Black)
+			}
+		}
+	}
+
+	dst := Resize(src, 2, 1)
+	if dst.Bounds() != image.Rect(0, 0, 2, 1) {
+		t.Fatalf("unexpected bounds %v", dst.Bounds())
+	}
+	for x := 0; x < 2; x++ {
+		if got := dst.RGBAAt(x, 0); got != (color.RGBA{R: 127, G: 127, B: 127, A: 255}) {
+			t.Errorf("pixel %d = %v, want grey", x, got)
+		}
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 1, 1))
+	dst := Flatten(src, color.White)
+	if got := dst.RGBAAt(0, 0); got != (color.RGBA{R: 255, G: 255, B: 255, A: 255}) {
+		t.Errorf("transparent pixel = %v, want white", got)
+	}
+}
@@ -0,0 +1,161 @@
+// Package opds writes OPDS 1.2 catalog documents: Atom feeds carrying the OPDS link relations
+// understood by e-reader apps, and the OpenSearch description that lets them search a catalog.
+package opds
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+)
+
+// Media types of OPDS catalog documents
+const (
+	MediaTypeNavigation  = "application/atom+xml;profile=opds-catalog;kind=navigation"
+	MediaTypeAcquisition = "application/atom+xml;profile=opds-catalog;kind=acquisition"
+	MediaTypeOpenSearch  = "application/opensearchdescription+xml"
+)
+
+// Link relations used by OPDS catalogs
+const (
+	RelSelf        = "self"
+	RelStart       = "start"
+	RelUp          = "up"
+	RelFirst       = "first"
+	RelNext        = "next"
+	RelPrevious    = "previous"
+	RelSearch      = "search"
+	RelRelated     = "related"
+	RelSubsection  = "subsection"
+	RelNew         = "http://opds-spec.org/sort/new"
+	RelAcquisition = "http://opds-spec.org/acquisition"
+	RelBuy         = "http://opds-spec.org/acquisition/buy"
+	RelImage       = "http://opds-spec.org/image"
+	RelThumbnail   = "http://opds-spec.org/image/thumbnail"
+)
+
+const (
+	namespaceAtom       = "http://www.w3.org/2005/Atom"
+	namespaceDC         = "http://purl.org/dc/terms/"
+	namespaceOPDS       = "http://opds-spec.org/2010/catalog"
+	namespaceOpenSearch = "http://a9.com/-/spec/opensearch/1.1/"
+)
+
+// Feed is a navigation or acquisition feed. The OpenSearch fields describe the page of a
+// paginated acquisition feed and are left out when zero.
+type Feed struct {
+	XMLName      xml.Name `xml:"feed"`
+	Namespace    string   `xml:"xmlns,attr"`
+	DCNamespace  string   `xml:"xmlns:dc,attr"`
+	OPDSNS       string   `xml:"xmlns:opds,attr"`
+	OpenSearchNS string   `xml:"xmlns:opensearch,attr"`
+
+	ID           string    `xml:"id"`
+	Title        string    `xml:"title"`
+	Updated      time.Time `xml:"updated"`
+	Author       *Author   `xml:"author,omitempty"`
+	TotalResults int64     `xml:"opensearch:totalResults,omitempty"`
+	ItemsPerPage int       `xml:"opensearch:itemsPerPage,omitempty"`
+	StartIndex   int       `xml:"opensearch:startIndex,omitempty"`
+	Links        []Link    `xml:"link"`
+	Entries      []Entry   `xml:"entry"`
+}
+
+// Entry is a catalog entry: a publication in an acquisition feed, or a link to another feed
+// in a navigation feed
+type Entry struct {
+	ID         string     `xml:"id"`
+	Title      string     `xml:"title"`
+	Updated    time.Time  `xml:"updated"`
+	Published  *time.Time `xml:"published,omitempty"`
+	Authors    []Author   `xml:"author"`
+	Language   string     `xml:"dc:language,omitempty"`
+	Categories []Category `xml:"category"`
+	Summary    *Text      `xml:"summary,omitempty"`
+	Content    *Text      `xml:"content,omitempty"`
+	Links      []Link     `xml:"link"`
+}
+
+// Author is a person credited for a feed or an entry
+type Author struct {
+	Name string `xml:"name"`
+	URI  string `xml:"uri,omitempty"`
+}
+
+// Category labels an entry with a subject
+type Category struct {
+	Term  string `xml:"term,attr"`
+	Label string `xml:"label,attr,omitempty"`
+}
+
+// Text is an Atom text construct
+type Text struct {
+	Type  string `xml:"type,attr,omitempty"`
+	Value string `xml:",chardata"`
+}
+
+// Link points to another feed, an image or a way to acquire the publication
+type Link struct {
+	Rel   string `xml:"rel,attr,omitempty"`
+	Href  string `xml:"href,attr"`
+	Type  string `xml:"type,attr,omitempty"`
+	Title string `xml:"title,attr,omitempty"`
+	Price *Price `xml:"opds:price,omitempty"`
+}
+
+// Price is the price of an acquisition link
+type Price struct {
+	CurrencyCode string `xml:"currencycode,attr"`
+	Value        int    `xml:",chardata"`
+}
+
+// OpenSearchDescription tells clients how to build search URLs for a catalog
+type OpenSearchDescription struct {
+	XMLName        xml.Name        `xml:"OpenSearchDescription"`
+	Namespace      string          `xml:"xmlns,attr"`
+	ShortName      string          `xml:"ShortName"`
+	Description    string          `xml:"Description"`
+	InputEncoding  string          `xml:"InputEncoding"`
+	OutputEncoding string          `xml:"OutputEncoding"`
+	URLs           []OpenSearchURL `xml:"Url"`
+}
+
+// OpenSearchURL is a search URL template, with {searchTerms} standing for the query
+type OpenSearchURL struct {
+	Type     string `xml:"type,attr"`
+	Template string `xml:"template,attr"`
+}
+
+// NewOpenSearchDescription describes a search endpoint returning acquisition feeds
+func NewOpenSearchDescription(shortName, description, template string) *OpenSearchDescription {
+	return &OpenSearchDescription{
+		ShortName:      shortName,
+		Description:    description,
+		InputEncoding:  "UTF-8",
+		OutputEncoding: "UTF-8",
+		URLs:           []OpenSearchURL{{Type: MediaTypeAcquisition, Template: template}},
+	}
+}
+
+// Write encodes a feed or an OpenSearch description as an XML document, filling in the namespaces
+func Write(w io.Writer, doc any) error {
+	switch doc := doc.(type) {
+	case *Feed:
+		doc.Namespace = namespaceAtom
+		doc.DCNamespace = namespaceDC
+		doc.OPDSNS = namespaceOPDS
+		doc.OpenSearchNS = namespaceOpenSearch
+	case *OpenSearchDescription:
+		doc.Namespace = namespaceOpenSearch
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,72 @@
+package opds
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWrite(t *testing.T) {
+	t.Run("should write a feed with the OPDS namespaces", func(t *testing.T) {
+		feed := &Feed{
+			ID:      "urn:test:new",
+			Title:   "New releases",
+			Updated: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+			Links:   []Link{{Rel: RelSelf, Href: "/opds/new", Type: MediaTypeAcquisition}},
+			Entries: []Entry{{
+				ID:       "urn:uuid:1",
+				Title:    "Laskar & Pelangi",
+				Updated:  time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+				Authors:  []Author{{Name: "Andrea Hirata"}},
+				Language: "id",
+				Links: []Link{{
+					Rel:   RelBuy,
+					Href:  "/store/laskar-pelangi",
+					Type:  "text/html",
+					Price: &Price{CurrencyCode: "IDR", Value: 50000},
+				}},
+			}},
+		}
+
+		var buf bytes.Buffer
+		if err := Write(&buf, feed); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		out := buf.String()
+
+		for _, want := range []string{
+			`<?xml version="1.0" encoding="UTF-8"?>`,
+			`<feed xmlns="http://www.w3.org/2005/Atom" xmlns:dc="http://purl.org/dc/terms/" xmlns:opds="http://opds-spec.org/2010/catalog"`,
+			`<updated>2024-05-01T10:00:00Z</updated>`,
+			`<title>Laskar &amp; Pelangi</title>`,
+			`<dc:language>id</dc:language>`,
+			`<opds:price currencycode="IDR">50000</opds:price>`,
+		} {
+			if !strings.Contains(out, want) {
+				t.Errorf("expected %q in\n%s", want, out)
+			}
+		}
+		if strings.Contains(out, "opensearch:totalResults") || strings.Contains(out, "<published>") {
+			t.Errorf("expected empty optional elements to be left out:\n%s", out)
+		}
+	})
+
+	t.Run("should write an OpenSearch description", func(t *testing.T) {
+		var buf bytes.Buffer
+		doc := NewOpenSearchDescription("Store", "Search the store", "/opds/search?q={searchTerms}")
+		if err := Write(&buf, doc); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		out := buf.String()
+
+		for _, want := range []string{
+			`<OpenSearchDescription xmlns="http://a9.com/-/spec/opensearch/1.1/">`,
+			`<Url type="application/atom+xml;profile=opds-catalog;kind=acquisition" template="/opds/search?q={searchTerms}"></Url>`,
+		} {
+			if !strings.Contains(out, want) {
+				t.Errorf("expected %q in\n%s", want, out)
+			}
+		}
+	})
+}
@@ -0,0 +1,28 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Job is a unit of background work run on a fixed interval
+type Job func(ctx context.Context) error
+
+// Every runs job once per interval until ctx is cancelled. Failures are logged and the
+// job is retried on the next tick, so a job must be safe to run again after a partial run.
+func Every(ctx context.Context, name string, interval time.Duration, job Job) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := job(ctx); err != nil {
+				log.Printf("Scheduled job %s failed: %v", name, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,59 @@
+// Package sitemap writes sitemaps and sitemap indexes as defined by the sitemaps.org protocol.
+package sitemap
+
+import (
+	"bytes"
+	"encoding/xml"
+	"time"
+)
+
+// MaxURLs is the most URLs a single sitemap may list
+const MaxURLs = 50000
+
+const namespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// URLSet is a sitemap listing pages
+type URLSet struct {
+	XMLName   xml.Name `xml:"urlset"`
+	Namespace string   `xml:"xmlns,attr"`
+	URLs      []URL    `xml:"url"`
+}
+
+// URL is a page and when it last changed
+type URL struct {
+	Loc     string    `xml:"loc"`
+	LastMod time.Time `xml:"lastmod"`
+}
+
+// Index is a sitemap index listing sitemaps
+type Index struct {
+	XMLName   xml.Name  `xml:"sitemapindex"`
+	Namespace string    `xml:"xmlns,attr"`
+	Sitemaps  []Sitemap `xml:"sitemap"`
+}
+
+// Sitemap is a sitemap and when any of its pages last changed
+type Sitemap struct {
+	Loc     string    `xml:"loc"`
+	LastMod time.Time `xml:"lastmod"`
+}
+
+// Encode returns a URLSet or an Index as an XML document, filling in the namespace
+func Encode(doc any) ([]byte, error) {
+	switch doc := doc.(type) {
+	case *URLSet:
+		doc.Namespace = namespace
+	case *Index:
+		doc.Namespace = namespace
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	encoder := xml.NewEncoder(&buf)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return nil, err
+	}
+	buf.WriteString("\n")
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,43 @@
+// Package storage keeps uploaded media on the local disk or in an S3-compatible bucket.
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path"
+	"strings"
+	"time"
+)
+
+// ErrNotFound is returned when no object is stored under a key
+var ErrNotFound = errors.New("stored object not found")
+
+// ErrInvalidKey is returned for keys that are empty or would escape the storage root
+var ErrInvalidKey = errors.New("invalid storage key")
+
+// Storage stores objects under slash-separated keys such as "covers/ab/ab12.jpg"
+type Storage interface {
+	// Put writes size bytes from body under key, replacing any object already stored there
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	// Open reads the object stored under key
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	// URL is where clients fetch a public object
+	URL(key string) string
+	// Location is where the API reads a private object it streams to entitled users:
+	// a path relative to the local storage directory, or a signed URL valid for ttl
+	Location(key string, ttl time.Duration) (string, error)
+}
+
+// cleanKey rejects keys that are absolute or climb out of the storage root
+func cleanKey(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return "", ErrInvalidKey
+	}
+	cleaned := path.Clean(key)
+	if cleaned != key || cleaned == "." || strings.HasPrefix(cleaned, "../") || cleaned == ".." {
+		return "", ErrInvalidKey
+	}
+	return cleaned, nil
+}
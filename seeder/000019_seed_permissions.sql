@@ -99,3 +99,12 @@ INSERT IGNORE INTO `permissions` (`id`, `name`, `resource`, `action`, `descripti
 (UUID(), 'payment:delete', 'payment', 'delete', 'Delete payments'),
 (UUID(), 'payment:list', 'payment', 'list', 'List all payments'),
 (UUID(), 'payment:manage', 'payment', 'manage', 'Full payment management access');
+
+-- Review permissions
+INSERT IGNORE INTO `permissions` (`id`, `name`, `resource`, `action`, `description`) VALUES
+(UUID(), 'review:create', 'review', 'create', 'Write ebook reviews'),
+(UUID(), 'review:read', 'review', 'read', 'Read ebook reviews'),
+(UUID(), 'review:update', 'review', 'update', 'Edit ebook reviews'),
+(UUID(), 'review:delete', 'review', 'delete', 'Delete ebook reviews'),
+(UUID(), 'review:list', 'review', 'list', 'List ebook reviews'),
+(UUID(), 'review:manage', 'review', 'manage', 'Moderate ebook reviews (hide and show)');
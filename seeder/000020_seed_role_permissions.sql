@@ -59,7 +59,12 @@ AND p.name IN (
     'author:create',
     'author:read',
     'author:update',
-    'author:list'
+    'author:list',
+    
+    -- Review permissions (moderation)
+    'review:read',
+    'review:list',
+    'review:manage'
 );
 
 -- ============================================================================